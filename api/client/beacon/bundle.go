@@ -0,0 +1,278 @@
+package beacon
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// A checkpoint bundle is a tar archive with the following entries, all values being ssz-encoded:
+//
+//	version               the version of the bundle format
+//	state.ssz             the checkpoint BeaconState
+//	block.ssz             the SignedBeaconBlock most recently applied to the state
+//	history/<n>.ssz       the n-th ancestor of the block, from 1 for its parent
+//	blobs/<n>.ssz         a BlobSidecar of the block or of one of its bundled ancestors
+const (
+	bundleVersion      = "1"
+	bundleVersionEntry = "version"
+	bundleStateEntry   = "state.ssz"
+	bundleBlockEntry   = "block.ssz"
+	bundleHistoryDir   = "history"
+	bundleBlobsDir     = "blobs"
+)
+
+var errInvalidBundle = errors.New("invalid checkpoint bundle")
+
+// CheckpointBundle holds everything needed to initialize a beacon node with checkpoint sync from the local filesystem,
+// without access to a checkpoint sync provider: the checkpoint state and block, the blob sidecars of the block, and
+// optionally a run of its ancestors with their blob sidecars.
+type CheckpointBundle struct {
+	// State is the ssz-encoded checkpoint BeaconState.
+	State []byte
+	// Block is the ssz-encoded SignedBeaconBlock most recently applied to State.
+	Block []byte
+	// History holds the ssz-encoded ancestors of Block, starting with its parent.
+	History [][]byte
+	// Blobs holds the ssz-encoded BlobSidecars of Block and of the blocks in History.
+	Blobs [][]byte
+}
+
+// Write writes the bundle to w as a tar archive.
+func (b *CheckpointBundle) Write(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	if err := writeBundleEntry(tw, bundleVersionEntry, []byte(bundleVersion)); err != nil {
+		return err
+	}
+	if err := writeBundleEntry(tw, bundleStateEntry, b.State); err != nil {
+		return err
+	}
+	if err := writeBundleEntry(tw, bundleBlockEntry, b.Block); err != nil {
+		return err
+	}
+	for i, h := range b.History {
+		if err := writeBundleEntry(tw, bundleEntryName(bundleHistoryDir, i+1), h); err != nil {
+			return err
+		}
+	}
+	for i, sc := range b.Blobs {
+		if err := writeBundleEntry(tw, bundleEntryName(bundleBlobsDir, i), sc); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// ReadCheckpointBundle reads a bundle written by CheckpointBundle.Write.
+func ReadCheckpointBundle(r io.Reader) (*CheckpointBundle, error) {
+	b := &CheckpointBundle{}
+	var ver []byte
+	history := make(map[int][]byte)
+	blobs := make(map[int][]byte)
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read checkpoint bundle")
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read checkpoint bundle entry %s", h.Name)
+		}
+		dir, name := path.Split(h.Name)
+		switch strings.TrimSuffix(dir, "/") {
+		case "":
+			switch name {
+			case bundleVersionEntry:
+				ver = data
+			case bundleStateEntry:
+				b.State = data
+			case bundleBlockEntry:
+				b.Block = data
+			default:
+				return nil, errors.Wrapf(errInvalidBundle, "unexpected entry %s", h.Name)
+			}
+		case bundleHistoryDir:
+			if err := addIndexedBundleEntry(history, h.Name, data); err != nil {
+				return nil, err
+			}
+		case bundleBlobsDir:
+			if err := addIndexedBundleEntry(blobs, h.Name, data); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Wrapf(errInvalidBundle, "unexpected entry %s", h.Name)
+		}
+	}
+	if string(ver) != bundleVersion {
+		return nil, errors.Wrapf(errInvalidBundle, "unsupported version %q, expected %q", ver, bundleVersion)
+	}
+	if len(b.State) == 0 || len(b.Block) == 0 {
+		return nil, errors.Wrap(errInvalidBundle, "missing state or block")
+	}
+	var err error
+	if b.History, err = indexedBundleEntries(history, bundleHistoryDir, 1); err != nil {
+		return nil, err
+	}
+	if b.Blobs, err = indexedBundleEntries(blobs, bundleBlobsDir, 0); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func bundleEntryName(dir string, i int) string {
+	return fmt.Sprintf("%s/%d.ssz", dir, i)
+}
+
+func writeBundleEntry(tw *tar.Writer, name string, data []byte) error {
+	h := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Unix(0, 0),
+	}
+	if err := tw.WriteHeader(h); err != nil {
+		return errors.Wrapf(err, "could not write checkpoint bundle entry %s", name)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Wrapf(err, "could not write checkpoint bundle entry %s", name)
+	}
+	return nil
+}
+
+func addIndexedBundleEntry(entries map[int][]byte, name string, data []byte) error {
+	i, err := strconv.Atoi(strings.TrimSuffix(path.Base(name), ".ssz"))
+	if err != nil {
+		return errors.Wrapf(errInvalidBundle, "unexpected entry %s", name)
+	}
+	entries[i] = data
+	return nil
+}
+
+// indexedBundleEntries orders the entries by index, which must be contiguous from first.
+func indexedBundleEntries(entries map[int][]byte, dir string, first int) ([][]byte, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	ordered := make([][]byte, len(entries))
+	for i := range ordered {
+		data, ok := entries[i+first]
+		if !ok {
+			return nil, errors.Wrapf(errInvalidBundle, "missing entry %s", bundleEntryName(dir, i+first))
+		}
+		ordered[i] = data
+	}
+	return ordered, nil
+}
+
+// SaveBundle writes the checkpoint bundle to a unique file in the given path.
+// For readability and collision avoidance, the file name includes: config name, slot and block root.
+func (o *OriginData) SaveBundle(dir string, b *CheckpointBundle) (string, error) {
+	bundlePath := path.Join(dir, fmt.Sprintf("bundle_%s_%s_%d-%#x.tar",
+		o.vu.Config.ConfigName, version.String(o.vu.Fork), o.b.Block().Slot(), o.br))
+	buf := &bytes.Buffer{}
+	if err := b.Write(buf); err != nil {
+		return "", err
+	}
+	return bundlePath, file.WriteFile(bundlePath, buf.Bytes())
+}
+
+// DownloadCheckpointBundle bundles the checkpoint state and block of the origin data with the blob sidecars of the
+// block, and with up to historyLen of its ancestors and their blob sidecars, downloaded using the client.
+func DownloadCheckpointBundle(ctx context.Context, client *Client, od *OriginData, historyLen uint64) (*CheckpointBundle, error) {
+	b := &CheckpointBundle{State: od.sb, Block: od.bb}
+	blobs, err := downloadBlobSidecars(ctx, client, od.b, od.br)
+	if err != nil {
+		return nil, err
+	}
+	b.Blobs = append(b.Blobs, blobs...)
+
+	blk := od.b
+	for uint64(len(b.History)) < historyLen {
+		parent := blk.Block().ParentRoot()
+		if parent == [32]byte{} {
+			break
+		}
+		bb, err := client.GetBlock(ctx, IdFromRoot(parent))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error requesting ancestor block by root = %#x", parent)
+		}
+		vu, err := detect.FromBlock(bb)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error detecting chain config for ancestor block %#x", parent)
+		}
+		blk, err = vu.UnmarshalBeaconBlock(bb)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to unmarshal ancestor block %#x", parent)
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error computing hash_tree_root of ancestor block %#x", parent)
+		}
+		if root != parent {
+			return nil, fmt.Errorf("requested ancestor block %#x, received block %#x", parent, root)
+		}
+		blobs, err := downloadBlobSidecars(ctx, client, blk, root)
+		if err != nil {
+			return nil, err
+		}
+		b.History = append(b.History, bb)
+		b.Blobs = append(b.Blobs, blobs...)
+	}
+
+	log.WithFields(logrus.Fields{
+		"blockSlot": od.b.Block().Slot(),
+		"history":   len(b.History),
+		"blobs":     len(b.Blobs),
+	}).Info("Downloaded checkpoint bundle data")
+	return b, nil
+}
+
+// downloadBlobSidecars returns the ssz-encoded blob sidecars of every commitment in the block.
+func downloadBlobSidecars(ctx context.Context, client *Client, b interfaces.ReadOnlySignedBeaconBlock, root [32]byte) ([][]byte, error) {
+	if b.Version() < version.Deneb {
+		return nil, nil
+	}
+	commitments, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read kzg commitments of block %#x", root)
+	}
+	if len(commitments) == 0 {
+		return nil, nil
+	}
+	sb, err := client.GetBlobSidecars(ctx, IdFromRoot(root))
+	if err != nil {
+		return nil, err
+	}
+	scs := &ethpb.BlobSidecars{}
+	if err := scs.UnmarshalSSZ(sb); err != nil {
+		return nil, errors.Wrapf(err, "unable to unmarshal blob sidecars of block %#x", root)
+	}
+	if len(scs.Sidecars) != len(commitments) {
+		return nil, fmt.Errorf("received %d blob sidecars for block %#x with %d kzg commitments", len(scs.Sidecars), root, len(commitments))
+	}
+	encoded := make([][]byte, len(scs.Sidecars))
+	for i, sc := range scs.Sidecars {
+		if encoded[i], err = sc.MarshalSSZ(); err != nil {
+			return nil, errors.Wrapf(err, "could not marshal blob sidecar %d of block %#x", sc.Index, root)
+		}
+	}
+	return encoded, nil
+}
@@ -0,0 +1,173 @@
+package beacon
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestCheckpointBundle_WriteRead(t *testing.T) {
+	b := &CheckpointBundle{
+		State:   []byte("state"),
+		Block:   []byte("block"),
+		History: [][]byte{[]byte("parent"), []byte("grandparent")},
+		Blobs:   [][]byte{[]byte("blob0"), []byte("blob1"), []byte("blob2")},
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, b.Write(buf))
+	read, err := ReadCheckpointBundle(buf)
+	require.NoError(t, err)
+	require.DeepEqual(t, b, read)
+
+	buf.Reset()
+	require.NoError(t, (&CheckpointBundle{State: b.State, Block: b.Block}).Write(buf))
+	read, err = ReadCheckpointBundle(buf)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(read.History))
+	require.Equal(t, 0, len(read.Blobs))
+}
+
+func TestReadCheckpointBundle_Invalid(t *testing.T) {
+	write := func(entries map[string]string) io.Reader {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for name, data := range entries {
+			require.NoError(t, writeBundleEntry(tw, name, []byte(data)))
+		}
+		require.NoError(t, tw.Close())
+		return buf
+	}
+	cases := []struct {
+		name    string
+		entries map[string]string
+		err     string
+	}{
+		{
+			name:    "missing version",
+			entries: map[string]string{bundleStateEntry: "state", bundleBlockEntry: "block"},
+			err:     "unsupported version",
+		},
+		{
+			name:    "missing block",
+			entries: map[string]string{bundleVersionEntry: bundleVersion, bundleStateEntry: "state"},
+			err:     "missing state or block",
+		},
+		{
+			name: "unexpected entry",
+			entries: map[string]string{bundleVersionEntry: bundleVersion, bundleStateEntry: "state", bundleBlockEntry: "block",
+				"extra.ssz": "extra"},
+			err: "unexpected entry extra.ssz",
+		},
+		{
+			name: "gap in history",
+			entries: map[string]string{bundleVersionEntry: bundleVersion, bundleStateEntry: "state", bundleBlockEntry: "block",
+				"history/1.ssz": "parent", "history/3.ssz": "ancestor"},
+			err: "missing entry history/2.ssz",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ReadCheckpointBundle(write(c.entries))
+			require.ErrorIs(t, err, errInvalidBundle)
+			require.ErrorContains(t, c.err, err)
+		})
+	}
+}
+
+func TestDownloadCheckpointBundle(t *testing.T) {
+	ctx := context.Background()
+	slot, err := slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+	require.NoError(t, err)
+	grandparent, grandparentBlobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 1)
+	parent, parentBlobs := util.GenerateTestDenebBlockWithSidecar(t, grandparent.Root(), slot+1, 2)
+	blk, _ := util.GenerateTestDenebBlockWithSidecar(t, parent.Root(), slot+2, 0)
+
+	encoded := make(map[string][]byte)
+	for _, b := range []struct {
+		blk   blocks.ROBlock
+		blobs []blocks.ROBlob
+	}{{grandparent, grandparentBlobs}, {parent, parentBlobs}, {blk, nil}} {
+		mb, err := b.blk.MarshalSSZ()
+		require.NoError(t, err)
+		encoded[renderGetBlockPath(IdFromRoot(b.blk.Root()))] = mb
+		scs := &ethpb.BlobSidecars{}
+		for _, sc := range b.blobs {
+			scs.Sidecars = append(scs.Sidecars, sc.BlobSidecar)
+		}
+		ms, err := scs.MarshalSSZ()
+		require.NoError(t, err)
+		encoded[getBlobSidecarsTpl(IdFromRoot(b.blk.Root()))] = ms
+	}
+	od := &OriginData{sb: []byte("state"), bb: encoded[renderGetBlockPath(IdFromRoot(blk.Root()))], b: blk, br: blk.Root()}
+
+	testClient := func(t *testing.T, served map[string][]byte) *Client {
+		trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+			res := &http.Response{Request: req, StatusCode: http.StatusOK}
+			body, ok := served[req.URL.Path]
+			if !ok {
+				res.StatusCode = http.StatusNotFound
+			}
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+			return res, nil
+		}}
+		c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+		require.NoError(t, err)
+		return c
+	}
+	encodedBlobs := func(t *testing.T, scs ...blocks.ROBlob) [][]byte {
+		e := make([][]byte, len(scs))
+		for i, sc := range scs {
+			var err error
+			e[i], err = sc.MarshalSSZ()
+			require.NoError(t, err)
+		}
+		return e
+	}
+
+	t.Run("history up to the first block", func(t *testing.T) {
+		b, err := DownloadCheckpointBundle(ctx, testClient(t, encoded), od, 5)
+		require.NoError(t, err)
+		require.DeepEqual(t, od.sb, b.State)
+		require.DeepEqual(t, od.bb, b.Block)
+		require.DeepEqual(t, [][]byte{
+			encoded[renderGetBlockPath(IdFromRoot(parent.Root()))],
+			encoded[renderGetBlockPath(IdFromRoot(grandparent.Root()))],
+		}, b.History)
+		require.DeepEqual(t, encodedBlobs(t, append(parentBlobs, grandparentBlobs...)...), b.Blobs)
+	})
+	t.Run("limited history", func(t *testing.T) {
+		b, err := DownloadCheckpointBundle(ctx, testClient(t, encoded), od, 1)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(b.History))
+		require.DeepEqual(t, encodedBlobs(t, parentBlobs...), b.Blobs)
+	})
+	t.Run("no history", func(t *testing.T) {
+		b, err := DownloadCheckpointBundle(ctx, testClient(t, encoded), od, 0)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(b.History))
+		require.Equal(t, 0, len(b.Blobs))
+	})
+	t.Run("missing blob sidecars", func(t *testing.T) {
+		served := make(map[string][]byte)
+		for k, v := range encoded {
+			served[k] = v
+		}
+		scs := &ethpb.BlobSidecars{Sidecars: []*ethpb.BlobSidecar{parentBlobs[0].BlobSidecar}}
+		ms, err := scs.MarshalSSZ()
+		require.NoError(t, err)
+		served[getBlobSidecarsTpl(IdFromRoot(parent.Root()))] = ms
+		_, err = DownloadCheckpointBundle(ctx, testClient(t, served), od, 1)
+		require.ErrorContains(t, "received 1 blob sidecars", err)
+	})
+}
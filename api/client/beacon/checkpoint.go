@@ -98,14 +98,8 @@ func DownloadFinalizedData(ctx context.Context, client *Client) (*OriginData, er
 	if err != nil {
 		return nil, errors.Wrap(err, "error computing hash_tree_root of retrieved block")
 	}
-	bodyRoot, err := b.Block().Body().HashTreeRoot()
-	if err != nil {
-		return nil, errors.Wrap(err, "error computing hash_tree_root of retrieved block body")
-	}
-
-	sbr := bytesutil.ToBytes32(s.LatestBlockHeader().BodyRoot)
-	if sbr != bodyRoot {
-		return nil, errors.Wrapf(errCheckpointBlockMismatch, "state body root = %#x, block body root = %#x", sbr, bodyRoot)
+	if err := verifyLatestBlockBody(s, b); err != nil {
+		return nil, err
 	}
 	sr, err := s.HashTreeRoot(ctx)
 	if err != nil {
@@ -129,6 +123,19 @@ func DownloadFinalizedData(ctx context.Context, client *Client) (*OriginData, er
 	}, nil
 }
 
+// verifyLatestBlockBody checks that the body of the block is the body of the latest block header of the state.
+func verifyLatestBlockBody(s state.BeaconState, b interfaces.ReadOnlySignedBeaconBlock) error {
+	bodyRoot, err := b.Block().Body().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "error computing hash_tree_root of retrieved block body")
+	}
+	sbr := bytesutil.ToBytes32(s.LatestBlockHeader().BodyRoot)
+	if sbr != bodyRoot {
+		return errors.Wrapf(errCheckpointBlockMismatch, "state body root = %#x, block body root = %#x", sbr, bodyRoot)
+	}
+	return nil
+}
+
 // WeakSubjectivityData represents the state root, block root and epoch of the BeaconState + ReadOnlySignedBeaconBlock
 // that falls at the beginning of the current weak subjectivity period. These values can be used to construct
 // a weak subjectivity checkpoint beacon node flag to be used for validation.
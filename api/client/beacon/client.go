@@ -26,15 +26,17 @@ import (
 )
 
 const (
-	getSignedBlockPath       = "/eth/v2/beacon/blocks"
-	getBlockRootPath         = "/eth/v1/beacon/blocks/{{.Id}}/root"
-	getForkForStatePath      = "/eth/v1/beacon/states/{{.Id}}/fork"
-	getWeakSubjectivityPath  = "/prysm/v1/beacon/weak_subjectivity"
-	getForkSchedulePath      = "/eth/v1/config/fork_schedule"
-	getConfigSpecPath        = "/eth/v1/config/spec"
-	getStatePath             = "/eth/v2/debug/beacon/states"
-	getNodeVersionPath       = "/eth/v1/node/version"
-	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getSignedBlockPath         = "/eth/v2/beacon/blocks"
+	getBlockRootPath           = "/eth/v1/beacon/blocks/{{.Id}}/root"
+	getBlobSidecarsPath        = "/eth/v1/beacon/blob_sidecars/{{.Id}}"
+	getForkForStatePath        = "/eth/v1/beacon/states/{{.Id}}/fork"
+	getFinalityCheckpointsPath = "/eth/v1/beacon/states/{{.Id}}/finality_checkpoints"
+	getWeakSubjectivityPath    = "/prysm/v1/beacon/weak_subjectivity"
+	getForkSchedulePath        = "/eth/v1/config/fork_schedule"
+	getConfigSpecPath          = "/eth/v1/config/spec"
+	getStatePath               = "/eth/v2/debug/beacon/states"
+	getNodeVersionPath         = "/eth/v1/node/version"
+	changeBLStoExecutionPath   = "/eth/v1/beacon/pool/bls_to_execution_changes"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -136,6 +138,21 @@ func (c *Client) GetBlockRoot(ctx context.Context, blockId StateOrBlockId) ([32]
 	return bytesutil.ToBytes32(rs), nil
 }
 
+var getBlobSidecarsTpl = idTemplate(getBlobSidecarsPath)
+
+// GetBlobSidecars retrieves the BlobSidecars of the block for the given block id.
+// Block identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded blockRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+// The return value contains the ssz-encoded bytes of the list of sidecars.
+func (c *Client) GetBlobSidecars(ctx context.Context, blockId StateOrBlockId) ([]byte, error) {
+	b, err := c.Get(ctx, getBlobSidecarsTpl(blockId), client.WithSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting blob sidecars by block id = %s", blockId)
+	}
+	return b, nil
+}
+
 var getForkTpl = idTemplate(getForkForStatePath)
 
 // GetFork queries the Beacon Node API for the Fork from the state identified by stateId.
@@ -157,6 +174,28 @@ func (c *Client) GetFork(ctx context.Context, stateId StateOrBlockId) (*ethpb.Fo
 	return fr.ToConsensus()
 }
 
+var getFinalityCheckpointsTpl = idTemplate(getFinalityCheckpointsPath)
+
+// GetFinalizedCheckpoint queries the Beacon Node API for the finalized checkpoint of the state identified by stateId.
+// State identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded stateRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+func (c *Client) GetFinalizedCheckpoint(ctx context.Context, stateId StateOrBlockId) (*ethpb.Checkpoint, error) {
+	body, err := c.Get(ctx, getFinalityCheckpointsTpl(stateId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting finality checkpoints by state id = %s", stateId)
+	}
+	fcr := &structs.GetFinalityCheckpointsResponse{}
+	err = json.Unmarshal(body, fcr)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetFinalizedCheckpoint")
+	}
+	if fcr.Data == nil || fcr.Data.Finalized == nil {
+		return nil, errors.New("finalized checkpoint missing from finality checkpoints response")
+	}
+	return fcr.Data.Finalized.ToConsensus()
+}
+
 // GetForkSchedule retrieve all forks, past present and future, of which this node is aware.
 func (c *Client) GetForkSchedule(ctx context.Context) (forks.OrderedSchedule, error) {
 	body, err := c.Get(ctx, getForkSchedulePath)
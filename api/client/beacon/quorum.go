@@ -0,0 +1,244 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCheckpointQuorum is returned when not enough checkpoint sync providers agree on the finalized checkpoint.
+var ErrCheckpointQuorum = errors.New("checkpoint sync providers did not reach quorum on the finalized checkpoint")
+
+var errInvalidQuorum = errors.New("invalid checkpoint sync quorum")
+
+var errCheckpointRootMismatch = errors.New("checkpoint sync block does not match the quorum checkpoint root")
+
+// checkpointQuorumAttempts is the number of times the providers are asked for their finalized checkpoint before
+// giving up on a quorum. Honest providers may briefly disagree while finality advances at an epoch boundary.
+const checkpointQuorumAttempts = 3
+
+// checkpointQuorumRetryDelay is the time waited before asking the providers for their finalized checkpoint again.
+var checkpointQuorumRetryDelay = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+
+// providerCheckpoint is the finalized checkpoint reported by a checkpoint sync provider,
+// or the error encountered while requesting it.
+type providerCheckpoint struct {
+	provider   string
+	checkpoint *ethpb.Checkpoint
+	err        error
+}
+
+func (p *providerCheckpoint) String() string {
+	if p.err != nil {
+		return fmt.Sprintf("%s (error: %v)", p.provider, p.err)
+	}
+	return fmt.Sprintf("%s (epoch=%d, root=%#x)", p.provider, p.checkpoint.Epoch, p.checkpoint.Root)
+}
+
+type checkpointKey struct {
+	epoch primitives.Epoch
+	root  [32]byte
+}
+
+// FinalizedCheckpointQuorum requests the finalized checkpoint from each of the clients and returns the checkpoint
+// reported by at least threshold of them, along with the clients that agree on it. A threshold of 0 requires all
+// the clients to agree. Providers that reported a different checkpoint or failed to respond are logged, and are
+// listed in the error when the quorum is not reached. As the providers are not queried at the exact same time, they
+// are queried again a few times before concluding that they do not reach the quorum.
+func FinalizedCheckpointQuorum(ctx context.Context, clients []*Client, threshold int) (*ethpb.Checkpoint, []*Client, error) {
+	if threshold == 0 {
+		threshold = len(clients)
+	}
+	if len(clients) == 0 || threshold < 0 || threshold > len(clients) {
+		return nil, nil, errors.Wrapf(errInvalidQuorum, "threshold %d for %d providers", threshold, len(clients))
+	}
+	for attempt := 1; ; attempt++ {
+		cp, agreeing, err := finalizedCheckpointQuorum(ctx, clients, threshold)
+		if err == nil || attempt == checkpointQuorumAttempts {
+			return cp, agreeing, err
+		}
+		log.WithError(err).WithField("attempt", attempt).Warn("Checkpoint sync providers did not reach quorum, retrying")
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(checkpointQuorumRetryDelay):
+		}
+	}
+}
+
+func finalizedCheckpointQuorum(ctx context.Context, clients []*Client, threshold int) (*ethpb.Checkpoint, []*Client, error) {
+	reports := make([]*providerCheckpoint, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			cp, err := c.GetFinalizedCheckpoint(ctx, IdFinalized)
+			reports[i] = &providerCheckpoint{provider: c.NodeURL(), checkpoint: cp, err: err}
+		}(i, c)
+	}
+	wg.Wait()
+
+	votes := make(map[checkpointKey][]int)
+	for i, r := range reports {
+		if r.err != nil {
+			continue
+		}
+		k := checkpointKey{epoch: r.checkpoint.Epoch, root: bytesutil.ToBytes32(r.checkpoint.Root)}
+		votes[k] = append(votes[k], i)
+	}
+	var agreed []int
+	for _, v := range votes {
+		if len(v) < threshold {
+			continue
+		}
+		if agreed != nil {
+			// Only possible with a threshold of half of the providers or less.
+			return nil, nil, errors.Wrapf(ErrCheckpointQuorum, "several checkpoints reached the threshold of %d: %s",
+				threshold, joinReports(reports, nil))
+		}
+		agreed = v
+	}
+	if agreed == nil {
+		return nil, nil, errors.Wrapf(ErrCheckpointQuorum, "no checkpoint reached the threshold of %d, providers diverged: %s",
+			threshold, joinReports(reports, nil))
+	}
+
+	agreeing := make([]*Client, len(agreed))
+	for i, idx := range agreed {
+		agreeing[i] = clients[idx]
+	}
+	cp := reports[agreed[0]].checkpoint
+	if len(agreed) < len(clients) {
+		log.WithFields(logrus.Fields{
+			"epoch":    cp.Epoch,
+			"root":     fmt.Sprintf("%#x", cp.Root),
+			"agreed":   len(agreed),
+			"diverged": joinReports(reports, agreed),
+		}).Warn("Some checkpoint sync providers diverged from the quorum on the finalized checkpoint")
+	}
+	return cp, agreeing, nil
+}
+
+// DownloadFinalizedDataWithQuorum downloads the checkpoint block and state after the clients reached a quorum on
+// the finalized checkpoint. As with DownloadFinalizedData, the state is the state at the start of the checkpoint
+// epoch, whose latest block header is the checkpoint block. The block is requested by the checkpoint root and the
+// state by the start slot of the checkpoint epoch, from one of the clients which agree on the checkpoint. The block
+// must hash to the checkpoint root, and so must the latest block header of the state.
+func DownloadFinalizedDataWithQuorum(ctx context.Context, clients []*Client, threshold int) (*OriginData, error) {
+	cp, agreeing, err := FinalizedCheckpointQuorum(ctx, clients, threshold)
+	if err != nil {
+		return nil, err
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	for _, c := range agreeing {
+		od, err := downloadCheckpointData(ctx, c, cp)
+		if err != nil {
+			log.WithError(err).WithField("provider", c.NodeURL()).Warn("Could not download checkpoint sync data from provider")
+			continue
+		}
+		return od, nil
+	}
+	return nil, fmt.Errorf("could not download checkpoint sync data matching the quorum checkpoint %#x from any provider", root)
+}
+
+// downloadCheckpointData downloads the checkpoint block and the state at the start slot of the checkpoint epoch.
+func downloadCheckpointData(ctx context.Context, client *Client, cp *ethpb.Checkpoint) (*OriginData, error) {
+	root := bytesutil.ToBytes32(cp.Root)
+	bb, err := client.GetBlock(ctx, IdFromRoot(root))
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromBlock(bb)
+	if err != nil {
+		return nil, errors.Wrap(err, "error detecting chain config for checkpoint block")
+	}
+	b, err := vu.UnmarshalBeaconBlock(bb)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal block to a supported type using the detected fork schedule")
+	}
+	br, err := b.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing hash_tree_root of retrieved block")
+	}
+	if br != root {
+		return nil, errors.Wrapf(errCheckpointRootMismatch, "block root = %#x, checkpoint root = %#x", br, root)
+	}
+
+	slot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint epoch %d", cp.Epoch)
+	}
+	sb, err := client.GetState(ctx, IdFromSlot(slot))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting state by slot = %d", slot)
+	}
+	s, err := vu.UnmarshalBeaconState(sb)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling checkpoint state to correct version")
+	}
+	if err := verifyLatestBlockBody(s, b); err != nil {
+		return nil, err
+	}
+	sr, err := s.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute htr for checkpoint state at slot=%d", s.Slot())
+	}
+	// The state root of the latest block header is only filled in at the slot after the block.
+	header := s.LatestBlockHeader()
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		header.StateRoot = sr[:]
+	}
+	hr, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing hash_tree_root of the latest block header of the checkpoint state")
+	}
+	if hr != root {
+		return nil, errors.Wrapf(errCheckpointBlockMismatch, "latest block header root = %#x, checkpoint root = %#x", hr, root)
+	}
+
+	log.WithFields(logrus.Fields{
+		"provider":  client.NodeURL(),
+		"blockSlot": b.Block().Slot(),
+		"stateSlot": slot,
+		"blockRoot": fmt.Sprintf("%#x", br),
+		"stateRoot": fmt.Sprintf("%#x", sr),
+	}).Info("Downloaded checkpoint sync state and block matching the quorum checkpoint")
+	return &OriginData{
+		st: s,
+		b:  b,
+		sb: sb,
+		bb: bb,
+		vu: vu,
+		br: br,
+		sr: sr,
+	}, nil
+}
+
+// joinReports formats the reports of the providers which are not in the excluded indices.
+func joinReports(reports []*providerCheckpoint, excluded []int) string {
+	skip := make(map[int]bool, len(excluded))
+	for _, i := range excluded {
+		skip[i] = true
+	}
+	s := make([]string, 0, len(reports))
+	for i, r := range reports {
+		if !skip[i] {
+			s = append(s, r.String())
+		}
+	}
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,247 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	blocktest "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// testProvider serves a finalized checkpoint and, optionally, the checkpoint block by root and a state by slot.
+type testProvider struct {
+	epoch primitives.Epoch
+	root  [32]byte
+	fail  bool
+	state []byte
+	block []byte
+	slot  primitives.Slot
+	// next is the checkpoint reported once the finalized checkpoint has been requested.
+	next *testProvider
+}
+
+func (p *testProvider) client(t *testing.T, host string) *Client {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		switch {
+		case p.fail:
+			res.StatusCode = http.StatusInternalServerError
+			res.Body = io.NopCloser(bytes.NewBufferString(""))
+		case req.URL.Path == getFinalityCheckpointsTpl(IdFinalized):
+			b, err := json.Marshal(&structs.GetFinalityCheckpointsResponse{Data: &structs.FinalityCheckpoints{
+				Finalized: &structs.Checkpoint{Epoch: fmt.Sprintf("%d", p.epoch), Root: hexutil.Encode(p.root[:])},
+			}})
+			require.NoError(t, err)
+			res.Body = io.NopCloser(bytes.NewBuffer(b))
+			if p.next != nil {
+				p.epoch, p.root, p.next = p.next.epoch, p.next.root, nil
+			}
+		case req.URL.Path == renderGetStatePath(IdFromSlot(p.slot)) && p.state != nil:
+			res.Body = io.NopCloser(bytes.NewBuffer(p.state))
+		case req.URL.Path == renderGetBlockPath(IdFromRoot(p.root)) && p.block != nil:
+			res.Body = io.NopCloser(bytes.NewBuffer(p.block))
+		default:
+			res.StatusCode = http.StatusNotFound
+			res.Body = io.NopCloser(bytes.NewBufferString(""))
+		}
+		return res, nil
+	}}
+	c, err := NewClient(host, client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	return c
+}
+
+func testClients(t *testing.T, providers ...*testProvider) []*Client {
+	clients := make([]*Client, len(providers))
+	for i, p := range providers {
+		clients[i] = p.client(t, fmt.Sprintf("http://provider-%d:3500", i))
+	}
+	return clients
+}
+
+func shortQuorumRetryDelay(t *testing.T) {
+	retryDelay := checkpointQuorumRetryDelay
+	checkpointQuorumRetryDelay = time.Millisecond
+	t.Cleanup(func() {
+		checkpointQuorumRetryDelay = retryDelay
+	})
+}
+
+func TestFinalizedCheckpointQuorum(t *testing.T) {
+	ctx := context.Background()
+	shortQuorumRetryDelay(t)
+	a := &testProvider{epoch: 10, root: [32]byte{'a'}}
+	b := &testProvider{epoch: 10, root: [32]byte{'b'}}
+	failing := &testProvider{fail: true}
+
+	t.Run("all agree", func(t *testing.T) {
+		cp, agreeing, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, a, a), 0)
+		require.NoError(t, err)
+		require.Equal(t, primitives.Epoch(10), cp.Epoch)
+		require.DeepEqual(t, a.root[:], cp.Root)
+		require.Equal(t, 3, len(agreeing))
+	})
+	t.Run("quorum with a diverging provider", func(t *testing.T) {
+		clients := testClients(t, a, b, a)
+		cp, agreeing, err := FinalizedCheckpointQuorum(ctx, clients, 2)
+		require.NoError(t, err)
+		require.DeepEqual(t, a.root[:], cp.Root)
+		require.DeepEqual(t, []*Client{clients[0], clients[2]}, agreeing)
+	})
+	t.Run("quorum with a failing provider", func(t *testing.T) {
+		_, agreeing, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, failing, a), 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(agreeing))
+	})
+	t.Run("disagreement", func(t *testing.T) {
+		_, _, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, b, a), 0)
+		require.ErrorIs(t, err, ErrCheckpointQuorum)
+		require.ErrorContains(t, fmt.Sprintf("http://provider-1:3500 (epoch=10, root=%#x)", b.root), err)
+	})
+	t.Run("failing provider breaks unanimity", func(t *testing.T) {
+		_, _, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, failing), 0)
+		require.ErrorIs(t, err, ErrCheckpointQuorum)
+		require.ErrorContains(t, "http://provider-1:3500 (error:", err)
+	})
+	t.Run("agreement once finality advanced", func(t *testing.T) {
+		late := &testProvider{epoch: 9, root: [32]byte{'l'}, next: a}
+		cp, agreeing, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, late, a), 0)
+		require.NoError(t, err)
+		require.DeepEqual(t, a.root[:], cp.Root)
+		require.Equal(t, 3, len(agreeing))
+	})
+	t.Run("ambiguous threshold", func(t *testing.T) {
+		_, _, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, b), 1)
+		require.ErrorIs(t, err, ErrCheckpointQuorum)
+		require.ErrorContains(t, "several checkpoints reached the threshold of 1", err)
+	})
+	t.Run("threshold larger than providers", func(t *testing.T) {
+		_, _, err := FinalizedCheckpointQuorum(ctx, testClients(t, a, a), 3)
+		require.ErrorIs(t, err, errInvalidQuorum)
+	})
+}
+
+func TestDownloadFinalizedDataWithQuorum(t *testing.T) {
+	ctx := context.Background()
+	shortQuorumRetryDelay(t)
+	cfg := params.MainnetConfig().Copy()
+	slot, err := slots.EpochStart(cfg.AltairForkEpoch - 1)
+	require.NoError(t, err)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	fork, err := forkForEpoch(cfg, slots.ToEpoch(slot))
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(fork))
+	require.NoError(t, st.SetSlot(slot))
+	b, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockSlot(b, slot)
+	require.NoError(t, err)
+	header, err := b.Header()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(header.Header))
+	sr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockStateRoot(b, sr)
+	require.NoError(t, err)
+	mb, err := b.MarshalSSZ()
+	require.NoError(t, err)
+	br, err := b.Block().HashTreeRoot()
+	require.NoError(t, err)
+	ms, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	require.NoError(t, st.SetGenesisTime(1))
+	tampered, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	serving := &testProvider{epoch: slots.ToEpoch(slot), root: br, state: ms, block: mb, slot: slot}
+	// Agrees on the checkpoint but is unable to serve the finalized data.
+	agreeing := &testProvider{epoch: slots.ToEpoch(slot), root: br}
+	diverging := &testProvider{epoch: slots.ToEpoch(slot), root: [32]byte{'x'}, state: ms, block: mb, slot: slot}
+
+	t.Run("downloads from an agreeing provider", func(t *testing.T) {
+		od, err := DownloadFinalizedDataWithQuorum(ctx, testClients(t, agreeing, diverging, serving), 2)
+		require.NoError(t, err)
+		require.Equal(t, br, od.br)
+		require.Equal(t, sr, od.sr)
+		require.Equal(t, true, bytes.Equal(ms, od.StateBytes()))
+	})
+	t.Run("refuses on disagreement", func(t *testing.T) {
+		_, err := DownloadFinalizedDataWithQuorum(ctx, testClients(t, serving, diverging), 0)
+		require.ErrorIs(t, err, ErrCheckpointQuorum)
+	})
+	t.Run("finalized data does not match the quorum checkpoint", func(t *testing.T) {
+		_, err := DownloadFinalizedDataWithQuorum(ctx, testClients(t, diverging, diverging, serving), 2)
+		require.ErrorContains(t, "could not download checkpoint sync data matching the quorum checkpoint", err)
+	})
+	t.Run("block does not match the checkpoint root", func(t *testing.T) {
+		cp := &ethpb.Checkpoint{Epoch: diverging.epoch, Root: diverging.root[:]}
+		_, err := downloadCheckpointData(ctx, diverging.client(t, "http://provider-0:3500"), cp)
+		require.ErrorIs(t, err, errCheckpointRootMismatch)
+	})
+	t.Run("state does not match the checkpoint block", func(t *testing.T) {
+		p := &testProvider{epoch: slots.ToEpoch(slot), root: br, state: tampered, block: mb, slot: slot}
+		cp := &ethpb.Checkpoint{Epoch: p.epoch, Root: br[:]}
+		_, err := downloadCheckpointData(ctx, p.client(t, "http://provider-0:3500"), cp)
+		require.ErrorIs(t, err, errCheckpointBlockMismatch)
+
+		od, err := DownloadFinalizedDataWithQuorum(ctx, testClients(t, p, serving), 0)
+		require.NoError(t, err)
+		require.Equal(t, true, bytes.Equal(ms, od.StateBytes()))
+	})
+	t.Run("first slot of the checkpoint epoch skipped", func(t *testing.T) {
+		// The checkpoint block is in the previous epoch, the state at the start of the checkpoint epoch has it as its
+		// latest block header, with the post-state root of the block filled in.
+		blockSlot := slot - 2
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+		require.NoError(t, err)
+		blk, err = blocktest.SetBlockSlot(blk, blockSlot)
+		require.NoError(t, err)
+		postRoot := [32]byte{'p'}
+		blk, err = blocktest.SetBlockStateRoot(blk, postRoot)
+		require.NoError(t, err)
+		h, err := blk.Header()
+		require.NoError(t, err)
+		skipped, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, skipped.SetFork(fork))
+		require.NoError(t, skipped.SetSlot(slot))
+		require.NoError(t, skipped.SetLatestBlockHeader(h.Header))
+		skippedState, err := skipped.MarshalSSZ()
+		require.NoError(t, err)
+		skippedBlock, err := blk.MarshalSSZ()
+		require.NoError(t, err)
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+
+		p := &testProvider{epoch: slots.ToEpoch(slot), root: root, state: skippedState, block: skippedBlock, slot: slot}
+		od, err := DownloadFinalizedDataWithQuorum(ctx, testClients(t, p, p), 0)
+		require.NoError(t, err)
+		require.Equal(t, root, od.br)
+		require.Equal(t, slot, od.st.Slot())
+		require.Equal(t, blockSlot, od.b.Block().Slot())
+
+		// A state whose latest block header is another block with the same body is refused.
+		other := &testProvider{epoch: p.epoch, root: br, state: skippedState, block: mb, slot: slot}
+		_, err = downloadCheckpointData(ctx, other.client(t, "http://provider-0:3500"), &ethpb.Checkpoint{Epoch: p.epoch, Root: br[:]})
+		require.ErrorIs(t, err, errCheckpointBlockMismatch)
+		require.ErrorContains(t, "latest block header root", err)
+	})
+}
@@ -0,0 +1,404 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+const (
+	getGenesisPath          = "/eth/v1/beacon/genesis"
+	getBootstrapPath        = "/eth/v1/beacon/light_client/bootstrap/%#x"
+	getUpdatesPath          = "/eth/v1/beacon/light_client/updates"
+	getFinalityUpdatePath   = "/eth/v1/beacon/light_client/finality_update"
+	getOptimisticUpdatePath = "/eth/v1/beacon/light_client/optimistic_update"
+)
+
+// Client provides the Eth Beacon Node API endpoints consumed by the light client.
+type Client struct {
+	*client.Client
+}
+
+// NewClient returns a new Client that includes functions for the light client rest calls to the Beacon API.
+func NewClient(host string, opts ...client.ClientOpt) (*Client, error) {
+	c, err := client.NewClient(host, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{c}, nil
+}
+
+// Genesis holds the parts of the genesis information of the chain the light client needs.
+type Genesis struct {
+	Time                  uint64
+	GenesisValidatorsRoot [32]byte
+}
+
+// GetGenesis retrieves the genesis time and the genesis validators root of the chain.
+func (c *Client) GetGenesis(ctx context.Context) (*Genesis, error) {
+	body, err := c.Get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis")
+	}
+	v := &structs.GetGenesisResponse{}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetGenesis")
+	}
+	if v.Data == nil {
+		return nil, errors.New("empty genesis response")
+	}
+	t, err := strconv.ParseUint(v.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding genesis time %s", v.Data.GenesisTime)
+	}
+	gvr, err := bytesutil.DecodeHexWithLength(v.Data.GenesisValidatorsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding genesis validators root %s", v.Data.GenesisValidatorsRoot)
+	}
+	return &Genesis{Time: t, GenesisValidatorsRoot: bytesutil.ToBytes32(gvr)}, nil
+}
+
+// GetBootstrap retrieves the light client bootstrap for the given block root.
+// The bootstrap is not verified, see NewStore.
+func (c *Client) GetBootstrap(ctx context.Context, blockRoot [32]byte) (ethpb.LightClientBootstrap, error) {
+	body, err := c.Get(ctx, fmt.Sprintf(getBootstrapPath, blockRoot))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client bootstrap for block root %#x", blockRoot)
+	}
+	v := &struct {
+		Data *bootstrapJson `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetBootstrap")
+	}
+	if v.Data == nil {
+		return nil, errors.New("empty light client bootstrap response")
+	}
+	return v.Data.toConsensus()
+}
+
+// GetUpdatesByRange retrieves the best light client updates of the sync committee periods
+// [startPeriod, startPeriod + count).
+func (c *Client) GetUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]ethpb.LightClientUpdate, error) {
+	query := url.Values{}
+	query.Set("start_period", strconv.FormatUint(startPeriod, 10))
+	query.Set("count", strconv.FormatUint(count, 10))
+	body, err := c.Get(ctx, getUpdatesPath, withQuery(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client updates from period %d", startPeriod)
+	}
+	// The standard API responds with a list of versioned updates, while Prysm wraps the list in an object.
+	var items []*versionedUpdateJson
+	if err := json.Unmarshal(body, &items); err != nil {
+		wrapped := &struct {
+			Updates []*versionedUpdateJson `json:"updates"`
+		}{}
+		if err := json.Unmarshal(body, wrapped); err != nil {
+			return nil, errors.Wrap(err, "error decoding json response in GetUpdatesByRange")
+		}
+		items = wrapped.Updates
+	}
+	updates := make([]ethpb.LightClientUpdate, 0, len(items))
+	for i, item := range items {
+		if item == nil || item.Data == nil {
+			return nil, fmt.Errorf("empty light client update at index %d", i)
+		}
+		u, err := item.Data.toConsensus()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode light client update at index %d", i)
+		}
+		updates = append(updates, u)
+	}
+	return updates, nil
+}
+
+// GetFinalityUpdate retrieves the latest light client finality update known to the beacon node.
+func (c *Client) GetFinalityUpdate(ctx context.Context) (ethpb.LightClientFinalityUpdate, error) {
+	u, err := c.getUpdate(ctx, getFinalityUpdatePath)
+	if err != nil {
+		return nil, err
+	}
+	return lightClient.NewFinalityUpdateFromUpdate(u)
+}
+
+// GetOptimisticUpdate retrieves the latest light client optimistic update known to the beacon node.
+func (c *Client) GetOptimisticUpdate(ctx context.Context) (ethpb.LightClientOptimisticUpdate, error) {
+	u, err := c.getUpdate(ctx, getOptimisticUpdatePath)
+	if err != nil {
+		return nil, err
+	}
+	return lightClient.NewOptimisticUpdateFromUpdate(u)
+}
+
+func (c *Client) getUpdate(ctx context.Context, path string) (ethpb.LightClientUpdate, error) {
+	body, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %s", path)
+	}
+	v := &versionedUpdateJson{}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, errors.Wrapf(err, "error decoding json response from %s", path)
+	}
+	if v.Data == nil {
+		return nil, fmt.Errorf("empty response from %s", path)
+	}
+	return v.Data.toConsensus()
+}
+
+func withQuery(query url.Values) client.ReqOption {
+	return func(req *http.Request) {
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// headerJson accepts both the standard light client header, which wraps the beacon block header
+// in a "beacon" field, and the flat beacon block header served by Prysm. From Capella onwards both
+// carry the execution payload header and its branch next to the beacon block header.
+type headerJson struct {
+	*structs.BeaconBlockHeader
+	Execution       *structs.ExecutionPayloadHeaderDeneb
+	ExecutionBranch []string
+}
+
+func (h *headerJson) UnmarshalJSON(b []byte) error {
+	wrapped := &struct {
+		Beacon          *structs.BeaconBlockHeader           `json:"beacon"`
+		Execution       *structs.ExecutionPayloadHeaderDeneb `json:"execution"`
+		ExecutionBranch []string                             `json:"execution_branch"`
+	}{}
+	if err := json.Unmarshal(b, wrapped); err != nil {
+		return err
+	}
+	h.Execution = wrapped.Execution
+	h.ExecutionBranch = wrapped.ExecutionBranch
+	if wrapped.Beacon != nil {
+		h.BeaconBlockHeader = wrapped.Beacon
+		return nil
+	}
+	h.BeaconBlockHeader = &structs.BeaconBlockHeader{}
+	return json.Unmarshal(b, h.BeaconBlockHeader)
+}
+
+// slot returns the slot of the beacon block header, which decides the version of the light client data.
+func (h *headerJson) slot() (primitives.Slot, error) {
+	if h == nil || h.BeaconBlockHeader == nil {
+		return 0, errors.New("missing header")
+	}
+	slot, err := strconv.ParseUint(h.Slot, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not decode header slot %s", h.Slot)
+	}
+	return primitives.Slot(slot), nil
+}
+
+// toConsensus decodes the header into the light client header of version v. The execution payload header
+// is only decoded here, it is verified against the beacon block body root by the Store.
+func (h *headerJson) toConsensus(v int) (ethpb.LightClientHeader, error) {
+	if h == nil || h.BeaconBlockHeader == nil {
+		return lightClient.EmptyHeader(v, nil), nil
+	}
+	beacon, err := h.BeaconBlockHeader.ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	branch, err := decodeBranch(h.ExecutionBranch, executionBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode execution branch")
+	}
+	switch header := lightClient.EmptyHeader(v, beacon).(type) {
+	case *ethpb.LightClientHeaderAltair:
+		if h.Execution != nil || !lightClient.IsZeroBranch(branch) {
+			return nil, fmt.Errorf("header at slot %d has execution data before Capella", beacon.Slot)
+		}
+		return header, nil
+	case *ethpb.LightClientHeaderCapella:
+		header.ExecutionBranch = branch
+		if h.Execution == nil {
+			return header, nil
+		}
+		if !isZeroOrMissing(h.Execution.BlobGasUsed) || !isZeroOrMissing(h.Execution.ExcessBlobGas) {
+			return nil, fmt.Errorf("header at slot %d has blob gas before Deneb", beacon.Slot)
+		}
+		header.Execution, err = (&structs.ExecutionPayloadHeaderCapella{
+			ParentHash:       h.Execution.ParentHash,
+			FeeRecipient:     h.Execution.FeeRecipient,
+			StateRoot:        h.Execution.StateRoot,
+			ReceiptsRoot:     h.Execution.ReceiptsRoot,
+			LogsBloom:        h.Execution.LogsBloom,
+			PrevRandao:       h.Execution.PrevRandao,
+			BlockNumber:      h.Execution.BlockNumber,
+			GasLimit:         h.Execution.GasLimit,
+			GasUsed:          h.Execution.GasUsed,
+			Timestamp:        h.Execution.Timestamp,
+			ExtraData:        h.Execution.ExtraData,
+			BaseFeePerGas:    h.Execution.BaseFeePerGas,
+			BlockHash:        h.Execution.BlockHash,
+			TransactionsRoot: h.Execution.TransactionsRoot,
+			WithdrawalsRoot:  h.Execution.WithdrawalsRoot,
+		}).ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode execution payload header")
+		}
+		return header, nil
+	case *ethpb.LightClientHeaderDeneb:
+		header.ExecutionBranch = branch
+		if h.Execution == nil {
+			return header, nil
+		}
+		// Headers from before Deneb may leave out the blob gas fields.
+		execution := *h.Execution
+		if execution.BlobGasUsed == "" {
+			execution.BlobGasUsed = "0"
+		}
+		if execution.ExcessBlobGas == "" {
+			execution.ExcessBlobGas = "0"
+		}
+		header.Execution, err = execution.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode execution payload header")
+		}
+		return header, nil
+	default:
+		return nil, fmt.Errorf("unsupported light client header %T", header)
+	}
+}
+
+func isZeroOrMissing(v string) bool {
+	return v == "" || v == "0"
+}
+
+type bootstrapJson struct {
+	Header                     *headerJson            `json:"header"`
+	CurrentSyncCommittee       *structs.SyncCommittee `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string               `json:"current_sync_committee_branch"`
+}
+
+func (b *bootstrapJson) toConsensus() (ethpb.LightClientBootstrap, error) {
+	if b.Header == nil || b.CurrentSyncCommittee == nil {
+		return nil, errors.New("incomplete light client bootstrap")
+	}
+	slot, err := b.Header.slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode header")
+	}
+	header, err := b.Header.toConsensus(lightClient.VersionAtSlot(slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode header")
+	}
+	committee, err := b.CurrentSyncCommittee.ToConsensus()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode current sync committee")
+	}
+	branch, err := decodeBranch(b.CurrentSyncCommitteeBranch, fieldparams.NextSyncCommitteeBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode current sync committee branch")
+	}
+	return lightClient.NewBootstrap(header, committee, branch)
+}
+
+type versionedUpdateJson struct {
+	Version string      `json:"version"`
+	Data    *updateJson `json:"data"`
+}
+
+// updateJson is the superset of the light client update, finality update and optimistic update.
+// Fields missing from the response are decoded to their empty values.
+type updateJson struct {
+	AttestedHeader          *headerJson            `json:"attested_header"`
+	NextSyncCommittee       *structs.SyncCommittee `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []string               `json:"next_sync_committee_branch"`
+	FinalizedHeader         *headerJson            `json:"finalized_header"`
+	FinalityBranch          []string               `json:"finality_branch"`
+	SyncAggregate           *structs.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot           string                 `json:"signature_slot"`
+}
+
+// The version of the update is the one of the attested header, the finalized header is decoded into the same version.
+func (u *updateJson) toConsensus() (ethpb.LightClientUpdate, error) {
+	if u.AttestedHeader == nil || u.SyncAggregate == nil {
+		return nil, errors.New("incomplete light client update")
+	}
+	slot, err := u.AttestedHeader.slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode attested header")
+	}
+	v := lightClient.VersionAtSlot(slot)
+	attestedHeader, err := u.AttestedHeader.toConsensus(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode attested header")
+	}
+	finalizedHeader, err := u.FinalizedHeader.toConsensus(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode finalized header")
+	}
+	nextSyncCommittee := lightClient.EmptySyncCommittee()
+	if u.NextSyncCommittee != nil {
+		nextSyncCommittee, err = u.NextSyncCommittee.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode next sync committee")
+		}
+	}
+	nextSyncCommitteeBranch, err := decodeBranch(u.NextSyncCommitteeBranch, fieldparams.NextSyncCommitteeBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode next sync committee branch")
+	}
+	finalityBranch, err := decodeBranch(u.FinalityBranch, finalityBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode finality branch")
+	}
+	bits, err := bytesutil.DecodeHexWithLength(u.SyncAggregate.SyncCommitteeBits, fieldparams.SyncAggregateSyncCommitteeBytesLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode sync committee bits")
+	}
+	sig, err := bytesutil.DecodeHexWithLength(u.SyncAggregate.SyncCommitteeSignature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode sync committee signature")
+	}
+	signatureSlot, err := strconv.ParseUint(u.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode signature slot %s", u.SignatureSlot)
+	}
+	return lightClient.NewUpdate(
+		attestedHeader,
+		nextSyncCommittee,
+		nextSyncCommitteeBranch,
+		finalizedHeader,
+		finalityBranch,
+		&ethpb.SyncAggregate{
+			SyncCommitteeBits:      bits,
+			SyncCommitteeSignature: sig,
+		},
+		primitives.Slot(signatureSlot),
+	)
+}
+
+// decodeBranch decodes a hex encoded Merkle branch. A missing branch is decoded to a zero branch of the given depth.
+func decodeBranch(branch []string, depth int) ([][]byte, error) {
+	if len(branch) == 0 {
+		return lightClient.EmptyBranch(depth), nil
+	}
+	if len(branch) != depth {
+		return nil, fmt.Errorf("branch has length %d, expected %d", len(branch), depth)
+	}
+	decoded := make([][]byte, len(branch))
+	for i, node := range branch {
+		b, err := bytesutil.DecodeHexWithLength(node, fieldparams.RootLength)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode branch node %d", i)
+		}
+		decoded[i] = b
+	}
+	return decoded, nil
+}
@@ -0,0 +1,351 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type testRT struct {
+	rt func(*http.Request) (*http.Response, error)
+}
+
+func (rt *testRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.rt != nil {
+		return rt.rt(req)
+	}
+	return nil, errors.New("RoundTripper not implemented")
+}
+
+var _ http.RoundTripper = &testRT{}
+
+// testClient returns a client which serves the JSON encoded value registered for the request path.
+func testClient(t *testing.T, responses map[string]interface{}, onRequest ...func(*http.Request)) *Client {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		for _, f := range onRequest {
+			f(req)
+		}
+		res := &http.Response{Request: req}
+		v, ok := responses[req.URL.Path]
+		if !ok {
+			res.StatusCode = http.StatusNotFound
+			res.Body = io.NopCloser(bytes.NewBuffer(nil))
+			return res, nil
+		}
+		encoded, err := json.Marshal(v)
+		require.NoError(t, err)
+		res.StatusCode = http.StatusOK
+		res.Body = io.NopCloser(bytes.NewBuffer(encoded))
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	return c
+}
+
+func branchToJson(branch [][]byte) []string {
+	encoded := make([]string, len(branch))
+	for i, b := range branch {
+		encoded[i] = hexutil.Encode(b)
+	}
+	return encoded
+}
+
+func testUpdate() *ethpb.LightClientUpdateAltair {
+	u := util.HydrateLightClientUpdate(&ethpb.LightClientUpdateAltair{})
+	u.AttestedHeader.Beacon.Slot = 160
+	u.FinalizedHeader.Beacon.Slot = 96
+	u.FinalityBranch[0] = bytes.Repeat([]byte{'f'}, fieldparams.RootLength)
+	u.NextSyncCommitteeBranch[0] = bytes.Repeat([]byte{'n'}, fieldparams.RootLength)
+	u.NextSyncCommittee.AggregatePubkey = bytes.Repeat([]byte{'a'}, fieldparams.BLSPubkeyLength)
+	u.SyncAggregate.SyncCommitteeBits.SetBitAt(3, true)
+	u.SignatureSlot = 161
+	return u
+}
+
+func updateToJson(u *ethpb.LightClientUpdateAltair) map[string]interface{} {
+	return map[string]interface{}{
+		"attested_header":            map[string]interface{}{"beacon": structs.BeaconBlockHeaderFromConsensus(u.AttestedHeader.Beacon)},
+		"next_sync_committee":        structs.SyncCommitteeFromConsensus(u.NextSyncCommittee),
+		"next_sync_committee_branch": branchToJson(u.NextSyncCommitteeBranch),
+		"finalized_header":           map[string]interface{}{"beacon": structs.BeaconBlockHeaderFromConsensus(u.FinalizedHeader.Beacon)},
+		"finality_branch":            branchToJson(u.FinalityBranch),
+		"sync_aggregate": &structs.SyncAggregate{
+			SyncCommitteeBits:      hexutil.Encode(u.SyncAggregate.SyncCommitteeBits),
+			SyncCommitteeSignature: hexutil.Encode(u.SyncAggregate.SyncCommitteeSignature),
+		},
+		"signature_slot": fmt.Sprintf("%d", u.SignatureSlot),
+	}
+}
+
+func TestClient_GetGenesis(t *testing.T) {
+	c := testClient(t, map[string]interface{}{
+		getGenesisPath: &structs.GetGenesisResponse{Data: &structs.Genesis{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode(bytes.Repeat([]byte{'g'}, fieldparams.RootLength)),
+			GenesisForkVersion:    "0x00000000",
+		}},
+	})
+	g, err := c.GetGenesis(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1606824023), g.Time)
+	require.DeepEqual(t, bytes.Repeat([]byte{'g'}, fieldparams.RootLength), g.GenesisValidatorsRoot[:])
+}
+
+func TestClient_GetBootstrap(t *testing.T) {
+	b := util.HydrateLightClientBootstrap(&ethpb.LightClientBootstrapAltair{})
+	b.Header.Beacon.Slot = 64
+	b.CurrentSyncCommitteeBranch[2] = bytes.Repeat([]byte{'c'}, fieldparams.RootLength)
+	root := [32]byte{'r'}
+
+	for name, header := range map[string]interface{}{
+		"standard header": map[string]interface{}{"beacon": structs.BeaconBlockHeaderFromConsensus(b.Header.Beacon)},
+		"flat header":     structs.BeaconBlockHeaderFromConsensus(b.Header.Beacon),
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, map[string]interface{}{
+				fmt.Sprintf(getBootstrapPath, root): map[string]interface{}{
+					"version": "altair",
+					"data": map[string]interface{}{
+						"header":                        header,
+						"current_sync_committee":        structs.SyncCommitteeFromConsensus(b.CurrentSyncCommittee),
+						"current_sync_committee_branch": branchToJson(b.CurrentSyncCommitteeBranch),
+					},
+				},
+			})
+			got, err := c.GetBootstrap(context.Background(), root)
+			require.NoError(t, err)
+			require.DeepEqual(t, b, got)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		c := testClient(t, map[string]interface{}{})
+		_, err := c.GetBootstrap(context.Background(), root)
+		require.ErrorIs(t, err, client.ErrNotFound)
+	})
+}
+
+func TestClient_GetUpdatesByRange(t *testing.T) {
+	u := testUpdate()
+	versioned := []interface{}{map[string]interface{}{"version": "altair", "data": updateToJson(u)}}
+
+	for name, response := range map[string]interface{}{
+		"list":    versioned,
+		"wrapped": map[string]interface{}{"updates": versioned},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var query string
+			c := testClient(t, map[string]interface{}{getUpdatesPath: response}, func(req *http.Request) {
+				query = req.URL.RawQuery
+			})
+			updates, err := c.GetUpdatesByRange(context.Background(), 3, 2)
+			require.NoError(t, err)
+			require.Equal(t, "count=2&start_period=3", query)
+			require.Equal(t, 1, len(updates))
+			require.DeepEqual(t, u, updates[0])
+		})
+	}
+}
+
+func TestClient_GetFinalityAndOptimisticUpdates(t *testing.T) {
+	u := testUpdate()
+	encoded := updateToJson(u)
+	finality := map[string]interface{}{}
+	optimistic := map[string]interface{}{}
+	for _, k := range []string{"attested_header", "finalized_header", "finality_branch", "sync_aggregate", "signature_slot"} {
+		finality[k] = encoded[k]
+	}
+	for _, k := range []string{"attested_header", "sync_aggregate", "signature_slot"} {
+		optimistic[k] = encoded[k]
+	}
+	c := testClient(t, map[string]interface{}{
+		getFinalityUpdatePath:   map[string]interface{}{"version": "altair", "data": finality},
+		getOptimisticUpdatePath: map[string]interface{}{"version": "altair", "data": optimistic},
+	})
+
+	fu, err := c.GetFinalityUpdate(context.Background())
+	require.NoError(t, err)
+	require.DeepEqual(t, &ethpb.LightClientFinalityUpdateAltair{
+		AttestedHeader:  u.AttestedHeader,
+		FinalizedHeader: u.FinalizedHeader,
+		FinalityBranch:  u.FinalityBranch,
+		SyncAggregate:   u.SyncAggregate,
+		SignatureSlot:   u.SignatureSlot,
+	}, fu)
+
+	ou, err := c.GetOptimisticUpdate(context.Background())
+	require.NoError(t, err)
+	require.DeepEqual(t, &ethpb.LightClientOptimisticUpdateAltair{
+		AttestedHeader: u.AttestedHeader,
+		SyncAggregate:  u.SyncAggregate,
+		SignatureSlot:  u.SignatureSlot,
+	}, ou)
+}
+
+func TestDecodeBranch(t *testing.T) {
+	branch, err := decodeBranch(nil, finalityBranchDepth)
+	require.NoError(t, err)
+	require.DeepEqual(t, lightClient.EmptyBranch(finalityBranchDepth), branch)
+
+	_, err = decodeBranch([]string{hexutil.Encode(make([]byte, fieldparams.RootLength))}, finalityBranchDepth)
+	require.ErrorContains(t, "branch has length 1, expected 6", err)
+
+	_, err = decodeBranch(branchToJson([][]byte{{0x01}}), 1)
+	require.ErrorContains(t, "could not decode branch node 0", err)
+}
+
+func TestHeaderJson_ToConsensus(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 1
+	cfg.DenebForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+	capellaSlot := params.BeaconConfig().SlotsPerEpoch
+	denebSlot := 2 * params.BeaconConfig().SlotsPerEpoch
+
+	capellaExecution := util.HydrateBlindedBeaconBlockBodyCapella(&ethpb.BlindedBeaconBlockBodyCapella{}).ExecutionPayloadHeader
+	capellaExecution.BlockNumber = 1
+	denebExecution := util.HydrateBlindedBeaconBlockBodyDeneb(&ethpb.BlindedBeaconBlockBodyDeneb{}).ExecutionPayloadHeader
+	denebExecution.BlockNumber = 1
+	denebExecution.BlobGasUsed = 2
+	capellaJson, err := structs.ExecutionPayloadHeaderCapellaFromConsensus(capellaExecution)
+	require.NoError(t, err)
+	denebJson, err := structs.ExecutionPayloadHeaderDenebFromConsensus(denebExecution)
+	require.NoError(t, err)
+
+	branch := make([][]byte, executionBranchDepth)
+	for i := range branch {
+		branch[i] = bytes.Repeat([]byte{byte(i + 1)}, fieldparams.RootLength)
+	}
+	beacon := func(slot primitives.Slot) *ethpb.BeaconBlockHeader {
+		return util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: slot})
+	}
+	flat := func(slot primitives.Slot) map[string]interface{} {
+		return map[string]interface{}{
+			"slot":           fmt.Sprintf("%d", slot),
+			"proposer_index": "0",
+			"parent_root":    hexutil.Encode(make([]byte, fieldparams.RootLength)),
+			"state_root":     hexutil.Encode(make([]byte, fieldparams.RootLength)),
+			"body_root":      hexutil.Encode(make([]byte, fieldparams.RootLength)),
+		}
+	}
+	flatDeneb := flat(denebSlot)
+	flatDeneb["execution"] = denebJson
+	flatDeneb["execution_branch"] = branchToJson(branch)
+
+	tests := []struct {
+		name    string
+		header  map[string]interface{}
+		version int
+		want    ethpb.LightClientHeader
+		err     string
+	}{
+		{
+			name:    "altair",
+			header:  map[string]interface{}{"beacon": structs.BeaconBlockHeaderFromConsensus(beacon(capellaSlot - 1))},
+			version: version.Altair,
+			want:    &ethpb.LightClientHeaderAltair{Beacon: beacon(capellaSlot - 1)},
+		},
+		{
+			name:    "execution in altair header",
+			header:  map[string]interface{}{"beacon": structs.BeaconBlockHeaderFromConsensus(beacon(capellaSlot - 1)), "execution": capellaJson},
+			version: version.Altair,
+			err:     "has execution data before Capella",
+		},
+		{
+			name: "capella",
+			header: map[string]interface{}{
+				"beacon":           structs.BeaconBlockHeaderFromConsensus(beacon(capellaSlot)),
+				"execution":        capellaJson,
+				"execution_branch": branchToJson(branch),
+			},
+			version: version.Capella,
+			want:    &ethpb.LightClientHeaderCapella{Beacon: beacon(capellaSlot), Execution: capellaExecution, ExecutionBranch: branch},
+		},
+		{
+			name:    "capella without execution",
+			header:  map[string]interface{}{"beacon": structs.BeaconBlockHeaderFromConsensus(beacon(capellaSlot - 1))},
+			version: version.Capella,
+			want:    lightClient.EmptyHeader(version.Capella, beacon(capellaSlot-1)),
+		},
+		{
+			name: "capella with blob gas",
+			header: map[string]interface{}{
+				"beacon":           structs.BeaconBlockHeaderFromConsensus(beacon(capellaSlot)),
+				"execution":        denebJson,
+				"execution_branch": branchToJson(branch),
+			},
+			version: version.Capella,
+			err:     "has blob gas before Deneb",
+		},
+		{
+			name: "deneb",
+			header: map[string]interface{}{
+				"beacon":           structs.BeaconBlockHeaderFromConsensus(beacon(denebSlot)),
+				"execution":        denebJson,
+				"execution_branch": branchToJson(branch),
+			},
+			version: version.Deneb,
+			want:    &ethpb.LightClientHeaderDeneb{Beacon: beacon(denebSlot), Execution: denebExecution, ExecutionBranch: branch},
+		},
+		{
+			name:    "flat header",
+			header:  flatDeneb,
+			version: version.Deneb,
+			want:    &ethpb.LightClientHeaderDeneb{Beacon: beacon(denebSlot), Execution: denebExecution, ExecutionBranch: branch},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.header)
+			require.NoError(t, err)
+			h := &headerJson{}
+			require.NoError(t, json.Unmarshal(b, h))
+			got, err := h.toConsensus(tt.version)
+			if tt.err != "" {
+				require.ErrorContains(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.DeepEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateJson_Version(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 1
+	cfg.DenebForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+
+	u := testUpdate()
+	u.AttestedHeader.Beacon.Slot = 2 * params.BeaconConfig().SlotsPerEpoch
+	encoded, err := json.Marshal(updateToJson(u))
+	require.NoError(t, err)
+	v := &updateJson{}
+	require.NoError(t, json.Unmarshal(encoded, v))
+	got, err := v.toConsensus()
+	require.NoError(t, err)
+	// The finalized header from before Capella is decoded into the Deneb container of the attested header.
+	require.Equal(t, version.Deneb, got.Version())
+	require.DeepEqual(t, lightClient.EmptyHeader(version.Deneb, u.FinalizedHeader.Beacon), got.FinalizedHeaderVal())
+}
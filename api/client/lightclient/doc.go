@@ -0,0 +1,15 @@
+/*
+Package lightclient implements a light client for the Ethereum consensus layer.
+
+The Store follows the Altair light client sync protocol: it is initialized from a
+bootstrap that is checked against a trusted block root, and then only moves its
+finalized and optimistic headers forward for updates whose sync committee signatures
+and Merkle branches verify. From Capella onwards the headers also carry the execution
+payload header, which the Store verifies against the beacon block body root and exposes
+as the trust-minimized view of the execution chain. The Client fetches the light client
+data from any node implementing the standard Eth Beacon Node API, and the Follower
+drives a Store with it.
+
+Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md
+*/
+package lightclient
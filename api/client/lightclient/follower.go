@@ -0,0 +1,161 @@
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// Follower keeps a light client Store in sync with the chain served by a beacon node.
+type Follower struct {
+	client  *Client
+	store   *Store
+	genesis *Genesis
+}
+
+// NewFollower bootstraps a light client store from the trusted block root, using the data served by the client.
+func NewFollower(ctx context.Context, c *Client, trustedBlockRoot [32]byte) (*Follower, error) {
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := c.GetBootstrap(ctx, trustedBlockRoot)
+	if err != nil {
+		return nil, err
+	}
+	store, err := NewStore(trustedBlockRoot, bootstrap, genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize light client store")
+	}
+	logHeader(store.FinalizedHeader(), store.FinalizedExecutionHeader).Info("Initialized light client store from trusted block root")
+	return &Follower{client: c, store: store, genesis: genesis}, nil
+}
+
+// Store returns the light client store kept up to date by the follower.
+func (f *Follower) Store() *Store {
+	return f.store
+}
+
+// Run follows the chain until the context is canceled. Every slot, once the beacon node has had
+// the time to import the block of the slot, it syncs the store across the sync committee periods
+// it is behind and then applies the latest finality and optimistic updates.
+func (f *Follower) Run(ctx context.Context) error {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	offset := time.Duration(secondsPerSlot) * time.Second / 3
+	ticker := slots.NewSlotTickerWithOffset(time.Unix(int64(f.genesis.Time), 0), offset, secondsPerSlot)
+	defer ticker.Done()
+
+	currentSlot := slots.CurrentSlot(f.genesis.Time)
+	for {
+		if err := f.Step(ctx, currentSlot); err != nil {
+			log.WithError(err).WithField("slot", currentSlot).Error("Could not update light client store")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case currentSlot = <-ticker.C():
+		}
+	}
+}
+
+// Step brings the store up to date with the beacon node as of the current slot.
+// Updates which do not pass validation are ignored, only failures to fetch them are returned.
+func (f *Follower) Step(ctx context.Context, currentSlot primitives.Slot) error {
+	finalized, optimistic := f.store.FinalizedHeader(), f.store.OptimisticHeader()
+	defer f.logHeaderChanges(finalized, optimistic)
+
+	if err := f.syncPeriods(ctx, currentSlot); err != nil {
+		return err
+	}
+
+	finalityUpdate, err := f.client.GetFinalityUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	if finalityUpdate.FinalizedHeaderVal().GetBeacon().Slot > f.store.FinalizedHeader().Slot {
+		if err := f.store.ProcessFinalityUpdate(finalityUpdate, currentSlot); err != nil {
+			log.WithError(err).Debug("Ignoring light client finality update")
+		}
+	}
+
+	optimisticUpdate, err := f.client.GetOptimisticUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	if optimisticUpdate.AttestedHeaderVal().GetBeacon().Slot > f.store.OptimisticHeader().Slot {
+		if err := f.store.ProcessOptimisticUpdate(optimisticUpdate, currentSlot); err != nil {
+			log.WithError(err).Debug("Ignoring light client optimistic update")
+		}
+	}
+
+	if f.store.ForceUpdate(currentSlot) {
+		log.Warn("Light client store did not finalize for a sync committee period, applied best valid update")
+	}
+	return nil
+}
+
+// syncPeriods requests the best updates of the sync committee periods from the one of the finalized header
+// up to the current one, until the store knows the sync committee needed to verify the latest updates.
+func (f *Follower) syncPeriods(ctx context.Context, currentSlot primitives.Slot) error {
+	for {
+		storePeriod := lightClient.SyncCommitteePeriodAtSlot(f.store.FinalizedHeader().Slot)
+		currentPeriod := lightClient.SyncCommitteePeriodAtSlot(currentSlot)
+		if storePeriod > currentPeriod || (storePeriod == currentPeriod && f.store.IsNextSyncCommitteeKnown()) {
+			return nil
+		}
+		count := min(currentPeriod-storePeriod+1, params.BeaconConfig().MaxRequestLightClientUpdates)
+		updates, err := f.client.GetUpdatesByRange(ctx, storePeriod, count)
+		if err != nil {
+			return err
+		}
+		for _, u := range updates {
+			if err := f.store.ProcessUpdate(u, currentSlot); err != nil {
+				log.WithError(err).WithField("period", lightClient.SyncCommitteePeriodAtSlot(u.AttestedHeaderVal().GetBeacon().Slot)).Debug("Ignoring light client update")
+			}
+		}
+		// Stop when the updates did not move the store forward, the remaining periods are
+		// covered by the finality updates or the force update.
+		if lightClient.SyncCommitteePeriodAtSlot(f.store.FinalizedHeader().Slot) == storePeriod {
+			return nil
+		}
+	}
+}
+
+func (f *Follower) logHeaderChanges(finalized, optimistic *ethpb.BeaconBlockHeader) {
+	if h := f.store.FinalizedHeader(); h.Slot != finalized.Slot {
+		logHeader(h, f.store.FinalizedExecutionHeader).Info("New light client finalized header")
+	}
+	if h := f.store.OptimisticHeader(); h.Slot != optimistic.Slot {
+		logHeader(h, f.store.OptimisticExecutionHeader).Info("New light client optimistic header")
+	}
+}
+
+// logHeader returns a log entry with the beacon block header and, from Capella onwards, the execution block
+// of the header as verified by the store.
+func logHeader(h *ethpb.BeaconBlockHeader, executionHeader func() (interfaces.ExecutionData, error)) *logrus.Entry {
+	fields := logrus.Fields{
+		"slot":      h.Slot,
+		"stateRoot": fmt.Sprintf("%#x", bytesutil.Trunc(h.StateRoot)),
+	}
+	if root, err := h.HashTreeRoot(); err == nil {
+		fields["root"] = fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))
+	}
+	execution, err := executionHeader()
+	if err != nil {
+		log.WithError(err).Debug("Could not get execution payload header of light client header")
+	} else if execution != nil {
+		fields["executionBlockNumber"] = execution.BlockNumber()
+		fields["executionBlockHash"] = fmt.Sprintf("%#x", bytesutil.Trunc(execution.BlockHash()))
+	}
+	return log.WithFields(fields)
+}
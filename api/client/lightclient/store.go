@@ -0,0 +1,530 @@
+package lightclient
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// Generalized indices of the light client proofs in the Altair beacon state.
+	finalizedRootGindex        = 105
+	currentSyncCommitteeGindex = 54
+	nextSyncCommitteeGindex    = 55
+	// Generalized index of the execution payload header in the Capella beacon block body.
+	executionPayloadGindex = 25
+
+	finalityBranchDepth  = 6
+	executionBranchDepth = 4
+)
+
+// Store is a light client store as defined by the Altair light client sync protocol, with the
+// execution payload headers added to the light client headers from Capella onwards.
+// It is safe for concurrent use.
+type Store struct {
+	lock                  sync.RWMutex
+	genesisValidatorsRoot [32]byte
+	// Header that is finalized
+	finalizedHeader ethpb.LightClientHeader
+	// Sync committees corresponding to the finalized header
+	currentSyncCommittee *ethpb.SyncCommittee
+	nextSyncCommittee    *ethpb.SyncCommittee
+	// Best available header to switch finalized head to if we see nothing else
+	bestValidUpdate ethpb.LightClientUpdate
+	// Most recent available reasonably-safe header
+	optimisticHeader ethpb.LightClientHeader
+	// Max number of active participants in a sync committee (used to calculate safety threshold)
+	previousMaxActiveParticipants uint64
+	currentMaxActiveParticipants  uint64
+}
+
+// NewStore initializes a light client store from a bootstrap of the trusted block root.
+// Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#initialize_light_client_store
+func NewStore(trustedBlockRoot [32]byte, bootstrap ethpb.LightClientBootstrap, genesisValidatorsRoot [32]byte) (*Store, error) {
+	if bootstrap == nil || isNilHeader(bootstrap.HeaderVal()) || bootstrap.GetCurrentSyncCommittee() == nil {
+		return nil, errors.New("incomplete light client bootstrap")
+	}
+	header := bootstrap.HeaderVal()
+	if err := validateHeader(header); err != nil {
+		return nil, err
+	}
+	headerRoot, err := header.GetBeacon().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute bootstrap header root")
+	}
+	if headerRoot != trustedBlockRoot {
+		return nil, fmt.Errorf("bootstrap header root %#x does not match trusted block root %#x", headerRoot, trustedBlockRoot)
+	}
+	committeeRoot, err := bootstrap.GetCurrentSyncCommittee().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute current sync committee root")
+	}
+	if !isValidMerkleBranch(committeeRoot, bootstrap.GetCurrentSyncCommitteeBranch(), currentSyncCommitteeGindex, header.GetBeacon().StateRoot) {
+		return nil, errors.New("invalid current sync committee branch")
+	}
+	return &Store{
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		finalizedHeader:       header,
+		currentSyncCommittee:  bootstrap.GetCurrentSyncCommittee(),
+		nextSyncCommittee:     lightClient.EmptySyncCommittee(),
+		optimisticHeader:      header,
+	}, nil
+}
+
+// FinalizedHeader returns a copy of the latest finalized header known to the store.
+func (s *Store) FinalizedHeader() *ethpb.BeaconBlockHeader {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return ethpb.CopyBeaconBlockHeader(s.finalizedHeader.GetBeacon())
+}
+
+// FinalizedExecutionHeader returns a copy of the execution payload header of the latest finalized header,
+// which is verified against the body root of the beacon block header. It returns nil for headers from
+// before Capella, which do not carry an execution payload header.
+func (s *Store) FinalizedExecutionHeader() (interfaces.ExecutionData, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return executionHeader(s.finalizedHeader)
+}
+
+// OptimisticHeader returns a copy of the most recent header which is signed by enough of the sync committee
+// to be reasonably safe.
+func (s *Store) OptimisticHeader() *ethpb.BeaconBlockHeader {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return ethpb.CopyBeaconBlockHeader(s.optimisticHeader.GetBeacon())
+}
+
+// OptimisticExecutionHeader returns a copy of the execution payload header of the optimistic header,
+// which is verified against the body root of the beacon block header. It returns nil for headers from
+// before Capella, which do not carry an execution payload header.
+func (s *Store) OptimisticExecutionHeader() (interfaces.ExecutionData, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return executionHeader(s.optimisticHeader)
+}
+
+// IsNextSyncCommitteeKnown reports whether the store knows the sync committee of the period
+// following the one of its finalized header.
+func (s *Store) IsNextSyncCommitteeKnown() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.isNextSyncCommitteeKnown()
+}
+
+// ProcessUpdate validates the light client update and applies it to the store.
+// Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_update
+func (s *Store) ProcessUpdate(update ethpb.LightClientUpdate, currentSlot primitives.Slot) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.processUpdate(update, currentSlot)
+}
+
+// ProcessFinalityUpdate validates the light client finality update and applies it to the store.
+// Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_finality_update
+func (s *Store) ProcessFinalityUpdate(update ethpb.LightClientFinalityUpdate, currentSlot primitives.Slot) error {
+	if update == nil || isNilHeader(update.AttestedHeaderVal()) || isNilHeader(update.FinalizedHeaderVal()) {
+		return errors.New("incomplete light client finality update")
+	}
+	u, err := lightClient.NewUpdate(
+		update.AttestedHeaderVal(),
+		lightClient.EmptySyncCommittee(),
+		lightClient.EmptyBranch(fieldparams.NextSyncCommitteeBranchDepth),
+		update.FinalizedHeaderVal(),
+		update.GetFinalityBranch(),
+		update.GetSyncAggregate(),
+		update.GetSignatureSlot(),
+	)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.processUpdate(u, currentSlot)
+}
+
+// ProcessOptimisticUpdate validates the light client optimistic update and applies it to the store.
+// Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_optimistic_update
+func (s *Store) ProcessOptimisticUpdate(update ethpb.LightClientOptimisticUpdate, currentSlot primitives.Slot) error {
+	if update == nil || isNilHeader(update.AttestedHeaderVal()) {
+		return errors.New("incomplete light client optimistic update")
+	}
+	u, err := lightClient.NewUpdate(
+		update.AttestedHeaderVal(),
+		lightClient.EmptySyncCommittee(),
+		lightClient.EmptyBranch(fieldparams.NextSyncCommitteeBranchDepth),
+		lightClient.EmptyHeader(update.AttestedHeaderVal().Version(), nil),
+		lightClient.EmptyBranch(finalityBranchDepth),
+		update.GetSyncAggregate(),
+		update.GetSignatureSlot(),
+	)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.processUpdate(u, currentSlot)
+}
+
+// ForceUpdate applies the best valid update when the store has not been able to finalize
+// a header for a whole sync committee period. It reports whether an update was applied.
+// Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_store_force_update
+func (s *Store) ForceUpdate(currentSlot primitives.Slot) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	updateTimeout := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	if currentSlot <= s.finalizedHeader.GetBeacon().Slot+updateTimeout || s.bestValidUpdate == nil {
+		return false
+	}
+	// Forced best update when the update timeout has elapsed.
+	// Because the apply logic waits for finalized_header.slot to indicate sync committee finality,
+	// the attested_header may be treated as finalized_header in extended periods of non-finality
+	// to guarantee progression into later sync committee periods according to is_better_update.
+	finalizedHeader := s.bestValidUpdate.FinalizedHeaderVal()
+	if finalizedHeader.GetBeacon().Slot <= s.finalizedHeader.GetBeacon().Slot {
+		finalizedHeader = s.bestValidUpdate.AttestedHeaderVal()
+	}
+	s.applyUpdate(finalizedHeader, s.bestValidUpdate.GetNextSyncCommittee())
+	s.bestValidUpdate = nil
+	return true
+}
+
+func (s *Store) processUpdate(update ethpb.LightClientUpdate, currentSlot primitives.Slot) error {
+	if err := s.validateUpdate(update, currentSlot); err != nil {
+		return err
+	}
+	participants := update.GetSyncAggregate().SyncCommitteeBits.Count()
+	maxParticipants := update.GetSyncAggregate().SyncCommitteeBits.Len()
+
+	// Update the best update in case we have to force-update to it if the timeout elapses
+	if s.bestValidUpdate == nil || lightClient.IsBetterUpdate(update, s.bestValidUpdate) {
+		s.bestValidUpdate = update
+	}
+
+	// Track the maximum number of active participants in the committee signatures
+	s.currentMaxActiveParticipants = max(s.currentMaxActiveParticipants, participants)
+
+	// Update the optimistic header
+	attestedHeader, finalizedHeader := update.AttestedHeaderVal(), update.FinalizedHeaderVal()
+	if participants > s.safetyThreshold() && attestedHeader.GetBeacon().Slot > s.optimisticHeader.GetBeacon().Slot {
+		s.optimisticHeader = attestedHeader
+	}
+
+	// Update finalized header
+	updateHasFinalizedNextSyncCommittee := !s.isNextSyncCommitteeKnown() &&
+		lightClient.IsSyncCommitteeUpdate(update) &&
+		lightClient.IsFinalityUpdate(update) &&
+		lightClient.SyncCommitteePeriodAtSlot(finalizedHeader.GetBeacon().Slot) == lightClient.SyncCommitteePeriodAtSlot(attestedHeader.GetBeacon().Slot)
+	if participants*3 >= maxParticipants*2 &&
+		(finalizedHeader.GetBeacon().Slot > s.finalizedHeader.GetBeacon().Slot || updateHasFinalizedNextSyncCommittee) {
+		// Normal update through 2/3 threshold
+		s.applyUpdate(finalizedHeader, update.GetNextSyncCommittee())
+		s.bestValidUpdate = nil
+	}
+	return nil
+}
+
+// validateUpdate implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#validate_light_client_update
+func (s *Store) validateUpdate(update ethpb.LightClientUpdate, currentSlot primitives.Slot) error {
+	if update == nil || isNilHeader(update.AttestedHeaderVal()) || isNilHeader(update.FinalizedHeaderVal()) ||
+		update.GetNextSyncCommittee() == nil || update.GetSyncAggregate() == nil {
+		return errors.New("incomplete light client update")
+	}
+	attested := update.AttestedHeaderVal().GetBeacon()
+	finalized := update.FinalizedHeaderVal().GetBeacon()
+	signatureSlot := update.GetSignatureSlot()
+
+	// Verify sync committee has sufficient participants
+	participants := update.GetSyncAggregate().SyncCommitteeBits.Count()
+	if participants < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return fmt.Errorf("not enough sync committee participants: %d", participants)
+	}
+
+	if err := validateHeader(update.AttestedHeaderVal()); err != nil {
+		return errors.Wrap(err, "invalid attested header")
+	}
+
+	// Verify update does not skip a sync committee period
+	if currentSlot < signatureSlot || signatureSlot <= attested.Slot || attested.Slot < finalized.Slot {
+		return fmt.Errorf("invalid update slots: current %d, signature %d, attested %d, finalized %d",
+			currentSlot, signatureSlot, attested.Slot, finalized.Slot)
+	}
+	storePeriod := lightClient.SyncCommitteePeriodAtSlot(s.finalizedHeader.GetBeacon().Slot)
+	signaturePeriod := lightClient.SyncCommitteePeriodAtSlot(signatureSlot)
+	if s.isNextSyncCommitteeKnown() {
+		if signaturePeriod != storePeriod && signaturePeriod != storePeriod+1 {
+			return fmt.Errorf("signature period %d is not in store period %d or the next one", signaturePeriod, storePeriod)
+		}
+	} else if signaturePeriod != storePeriod {
+		return fmt.Errorf("signature period %d is not store period %d", signaturePeriod, storePeriod)
+	}
+
+	// Verify update is relevant
+	attestedPeriod := lightClient.SyncCommitteePeriodAtSlot(attested.Slot)
+	updateHasNextSyncCommittee := !s.isNextSyncCommitteeKnown() && lightClient.IsSyncCommitteeUpdate(update) && attestedPeriod == storePeriod
+	if attested.Slot <= s.finalizedHeader.GetBeacon().Slot && !updateHasNextSyncCommittee {
+		return fmt.Errorf("update with attested slot %d is not newer than finalized slot %d", attested.Slot, s.finalizedHeader.GetBeacon().Slot)
+	}
+
+	// Verify that the finality branch, if present, confirms finalized header
+	// to match the finalized checkpoint root saved in the state of attested header.
+	// Note that the genesis finalized checkpoint root is represented as a zero hash.
+	if !lightClient.IsFinalityUpdate(update) {
+		if !isEmptyHeader(update.FinalizedHeaderVal()) {
+			return errors.New("finalized header present without finality branch")
+		}
+	} else {
+		var finalizedRoot [32]byte
+		if finalized.Slot == params.BeaconConfig().GenesisSlot {
+			if !isEmptyHeader(update.FinalizedHeaderVal()) {
+				return errors.New("genesis finalized header must be empty")
+			}
+		} else {
+			if err := validateHeader(update.FinalizedHeaderVal()); err != nil {
+				return errors.Wrap(err, "invalid finalized header")
+			}
+			var err error
+			finalizedRoot, err = finalized.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not compute finalized header root")
+			}
+		}
+		if !isValidMerkleBranch(finalizedRoot, update.GetFinalityBranch(), finalizedRootGindex, attested.StateRoot) {
+			return errors.New("invalid finality branch")
+		}
+	}
+
+	// Verify that the next sync committee, if present, actually is the next sync committee saved in the
+	// state of the attested header
+	if !lightClient.IsSyncCommitteeUpdate(update) {
+		if !isEmptySyncCommittee(update.GetNextSyncCommittee()) {
+			return errors.New("next sync committee present without next sync committee branch")
+		}
+	} else {
+		nextCommitteeRoot, err := update.GetNextSyncCommittee().HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute next sync committee root")
+		}
+		if attestedPeriod == storePeriod && s.isNextSyncCommitteeKnown() {
+			knownRoot, err := s.nextSyncCommittee.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not compute known next sync committee root")
+			}
+			if nextCommitteeRoot != knownRoot {
+				return errors.New("next sync committee does not match the known next sync committee")
+			}
+		}
+		if !isValidMerkleBranch(nextCommitteeRoot, update.GetNextSyncCommitteeBranch(), nextSyncCommitteeGindex, attested.StateRoot) {
+			return errors.New("invalid next sync committee branch")
+		}
+	}
+
+	// Verify sync committee aggregate signature
+	committee := s.currentSyncCommittee
+	if signaturePeriod != storePeriod {
+		committee = s.nextSyncCommittee
+	}
+	return verifySyncAggregate(update, committee, s.genesisValidatorsRoot)
+}
+
+// applyUpdate implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#apply_light_client_update
+// for the finalized header and the next sync committee of an update.
+func (s *Store) applyUpdate(finalizedHeader ethpb.LightClientHeader, nextSyncCommittee *ethpb.SyncCommittee) {
+	storePeriod := lightClient.SyncCommitteePeriodAtSlot(s.finalizedHeader.GetBeacon().Slot)
+	finalizedPeriod := lightClient.SyncCommitteePeriodAtSlot(finalizedHeader.GetBeacon().Slot)
+	if !s.isNextSyncCommitteeKnown() {
+		if finalizedPeriod != storePeriod {
+			return
+		}
+		s.nextSyncCommittee = nextSyncCommittee
+	} else if finalizedPeriod == storePeriod+1 {
+		s.currentSyncCommittee = s.nextSyncCommittee
+		s.nextSyncCommittee = nextSyncCommittee
+		s.previousMaxActiveParticipants = s.currentMaxActiveParticipants
+		s.currentMaxActiveParticipants = 0
+	}
+	if finalizedHeader.GetBeacon().Slot > s.finalizedHeader.GetBeacon().Slot {
+		s.finalizedHeader = finalizedHeader
+		if s.finalizedHeader.GetBeacon().Slot > s.optimisticHeader.GetBeacon().Slot {
+			s.optimisticHeader = s.finalizedHeader
+		}
+	}
+}
+
+func (s *Store) isNextSyncCommitteeKnown() bool {
+	return !isEmptySyncCommittee(s.nextSyncCommittee)
+}
+
+func (s *Store) safetyThreshold() uint64 {
+	return (s.previousMaxActiveParticipants + s.currentMaxActiveParticipants) / 2
+}
+
+func verifySyncAggregate(update ethpb.LightClientUpdate, committee *ethpb.SyncCommittee, genesisValidatorsRoot [32]byte) error {
+	bits := update.GetSyncAggregate().SyncCommitteeBits
+	pubkeys := make([]bls.PublicKey, 0, bits.Count())
+	for i, pubkey := range committee.Pubkeys {
+		if !bits.BitAt(uint64(i)) {
+			continue
+		}
+		pk, err := bls.PublicKeyFromBytes(pubkey)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode sync committee public key %d", i)
+		}
+		pubkeys = append(pubkeys, pk)
+	}
+	sig, err := bls.SignatureFromBytes(update.GetSyncAggregate().SyncCommitteeSignature)
+	if err != nil {
+		return errors.Wrap(err, "could not decode sync committee signature")
+	}
+	forkVersionSlot := max(update.GetSignatureSlot(), 1) - 1
+	fork, err := forks.Fork(slots.ToEpoch(forkVersionSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not compute fork version")
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, genesisValidatorsRoot[:])
+	if err != nil {
+		return errors.Wrap(err, "could not compute sync committee domain")
+	}
+	signingRoot, err := signing.ComputeSigningRoot(update.AttestedHeaderVal().GetBeacon(), domain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	if !sig.FastAggregateVerify(pubkeys, signingRoot) {
+		return errors.New("invalid sync committee signature")
+	}
+	return nil
+}
+
+// isValidMerkleBranch verifies the branch of the leaf at the generalized index against the root.
+func isValidMerkleBranch(leaf [32]byte, branch [][]byte, gindex uint64, root []byte) bool {
+	depth := floorLog2(gindex)
+	if uint64(len(branch)) != depth {
+		return false
+	}
+	return trie.VerifyMerkleProof(root, leaf[:], gindex-(1<<depth), branch)
+}
+
+func floorLog2(x uint64) uint64 {
+	var depth uint64
+	for x > 1 {
+		x >>= 1
+		depth++
+	}
+	return depth
+}
+
+// validateHeader implements is_valid_light_client_header. Before Capella the header must not carry any
+// execution data. From Capella onwards the execution payload header must be included in the beacon block
+// body, and the Deneb blob gas fields must be zero before Deneb.
+// Spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/light-client/sync-protocol.md#modified-is_valid_light_client_header
+func validateHeader(header ethpb.LightClientHeader) error {
+	beacon := header.GetBeacon()
+	epoch := slots.ToEpoch(beacon.Slot)
+	var execution ssz.HashRoot
+	var executionBranch [][]byte
+	switch h := header.(type) {
+	case *ethpb.LightClientHeaderAltair:
+		if epoch >= params.BeaconConfig().CapellaForkEpoch {
+			return fmt.Errorf("header at slot %d is missing the execution payload header", beacon.Slot)
+		}
+		return nil
+	case *ethpb.LightClientHeaderCapella:
+		if h.Execution == nil {
+			return fmt.Errorf("header at slot %d is missing the execution payload header", beacon.Slot)
+		}
+		execution, executionBranch = h.Execution, h.ExecutionBranch
+	case *ethpb.LightClientHeaderDeneb:
+		if h.Execution == nil {
+			return fmt.Errorf("header at slot %d is missing the execution payload header", beacon.Slot)
+		}
+		if epoch < params.BeaconConfig().DenebForkEpoch && (h.Execution.BlobGasUsed != 0 || h.Execution.ExcessBlobGas != 0) {
+			return fmt.Errorf("header at slot %d has blob gas before Deneb", beacon.Slot)
+		}
+		execution, executionBranch = h.Execution, h.ExecutionBranch
+	default:
+		return fmt.Errorf("unsupported light client header %T", header)
+	}
+	if epoch < params.BeaconConfig().CapellaForkEpoch {
+		root, err := header.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute header root")
+		}
+		emptyRoot, err := lightClient.EmptyHeader(header.Version(), beacon).HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute empty header root")
+		}
+		if root != emptyRoot {
+			return fmt.Errorf("header at slot %d has execution data before Capella", beacon.Slot)
+		}
+		return nil
+	}
+	root, err := execution.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute execution payload header root")
+	}
+	if !isValidMerkleBranch(root, executionBranch, executionPayloadGindex, beacon.BodyRoot) {
+		return fmt.Errorf("invalid execution branch for header at slot %d", beacon.Slot)
+	}
+	return nil
+}
+
+// executionHeader returns a copy of the execution payload header of the light client header,
+// or nil for headers from before Capella.
+func executionHeader(header ethpb.LightClientHeader) (interfaces.ExecutionData, error) {
+	if slots.ToEpoch(header.GetBeacon().Slot) < params.BeaconConfig().CapellaForkEpoch {
+		return nil, nil
+	}
+	switch h := header.(type) {
+	case *ethpb.LightClientHeaderCapella:
+		return blocks.WrappedExecutionPayloadHeaderCapella(h.Execution.Copy())
+	case *ethpb.LightClientHeaderDeneb:
+		return blocks.WrappedExecutionPayloadHeaderDeneb(h.Execution.Copy())
+	default:
+		return nil, nil
+	}
+}
+
+func isNilHeader(h ethpb.LightClientHeader) bool {
+	return h == nil || h.GetBeacon() == nil
+}
+
+// isEmptyHeader reports whether the light client header is the zero valued header of its version.
+func isEmptyHeader(h ethpb.LightClientHeader) bool {
+	root, err := h.HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	emptyRoot, err := lightClient.EmptyHeader(h.Version(), nil).HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	return root == emptyRoot
+}
+
+func isEmptySyncCommittee(c *ethpb.SyncCommittee) bool {
+	if c == nil {
+		return true
+	}
+	empty := make([]byte, fieldparams.BLSPubkeyLength)
+	for _, pubkey := range c.Pubkeys {
+		if !bytes.Equal(pubkey, empty) {
+			return false
+		}
+	}
+	return bytes.Equal(c.AggregatePubkey, empty)
+}
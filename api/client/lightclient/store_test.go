@@ -0,0 +1,467 @@
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// testChain holds the sync committees and the keys needed to produce valid light client data.
+type testChain struct {
+	t                     *testing.T
+	keys                  []bls.SecretKey
+	current               *ethpb.SyncCommittee
+	next                  *ethpb.SyncCommittee
+	genesisValidatorsRoot [32]byte
+}
+
+func newTestChain(t *testing.T) *testChain {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	keys := make([]bls.SecretKey, 8)
+	for i := range keys {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = k
+	}
+	return &testChain{
+		t:                     t,
+		keys:                  keys,
+		current:               syncCommitteeFromKeys(keys[:4]),
+		next:                  syncCommitteeFromKeys(keys[4:]),
+		genesisValidatorsRoot: [32]byte{'g', 'v', 'r'},
+	}
+}
+
+func syncCommitteeFromKeys(keys []bls.SecretKey) *ethpb.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = keys[i%len(keys)].PublicKey().Marshal()
+	}
+	return &ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: keys[0].PublicKey().Marshal()}
+}
+
+// state returns an Altair state at the slot with the test sync committees and the finalized checkpoint.
+func (c *testChain) state(slot primitives.Slot, finalizedRoot [32]byte) state.BeaconState {
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(c.t, err)
+	require.NoError(c.t, st.SetSlot(slot))
+	require.NoError(c.t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}))
+	require.NoError(c.t, st.SetCurrentSyncCommittee(c.current))
+	require.NoError(c.t, st.SetNextSyncCommittee(c.next))
+	require.NoError(c.t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: slots.ToEpoch(slot), Root: finalizedRoot[:]}))
+	return st
+}
+
+func (c *testChain) bootstrap(slot primitives.Slot) (*ethpb.LightClientBootstrapAltair, [32]byte) {
+	ctx := context.Background()
+	st := c.state(slot, [32]byte{})
+	block := util.NewBeaconBlockAltair()
+	block.Block.Slot = slot
+	signed, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(c.t, err)
+	header, err := signed.Header()
+	require.NoError(c.t, err)
+	require.NoError(c.t, st.SetLatestBlockHeader(header.Header))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(c.t, err)
+	signed.SetStateRoot(stateRoot[:])
+	bootstrap, err := lightClient.NewBootstrapFromBeaconState(ctx, st, signed)
+	require.NoError(c.t, err)
+	root, err := signed.Block().HashTreeRoot()
+	require.NoError(c.t, err)
+	return bootstrap.(*ethpb.LightClientBootstrapAltair), root
+}
+
+// update returns a light client update attesting to a header at the attested slot, which finalizes
+// a header at the finalized slot and carries the next sync committee. The update is signed by the
+// given number of participants of the committee.
+func (c *testChain) update(attestedSlot, finalizedSlot primitives.Slot, committee *ethpb.SyncCommittee, participants int) *ethpb.LightClientUpdateAltair {
+	u, ok := c.updateAtVersion(attestedSlot, finalizedSlot, committee, participants, version.Altair).(*ethpb.LightClientUpdateAltair)
+	require.Equal(c.t, true, ok)
+	return u
+}
+
+// updateAtVersion returns the same update as update, built with the light client containers of version v.
+func (c *testChain) updateAtVersion(attestedSlot, finalizedSlot primitives.Slot, committee *ethpb.SyncCommittee, participants int, v int) ethpb.LightClientUpdate {
+	ctx := context.Background()
+	finalized := c.header(finalizedSlot, bytesutil.PadTo([]byte{'s'}, fieldparams.RootLength), v)
+	finalizedRoot, err := finalized.GetBeacon().HashTreeRoot()
+	require.NoError(c.t, err)
+	st := c.state(attestedSlot, finalizedRoot)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(c.t, err)
+	finalityBranch, err := st.FinalizedRootProof(ctx)
+	require.NoError(c.t, err)
+	nextSyncCommitteeBranch, err := st.NextSyncCommitteeProof(ctx)
+	require.NoError(c.t, err)
+	attested := c.header(attestedSlot, stateRoot[:], v)
+	signatureSlot := attestedSlot + 1
+	u, err := lightClient.NewUpdate(
+		attested,
+		c.next,
+		nextSyncCommitteeBranch,
+		finalized,
+		finalityBranch,
+		c.sign(attested.GetBeacon(), signatureSlot, committee, participants),
+		signatureSlot,
+	)
+	require.NoError(c.t, err)
+	return u
+}
+
+// header returns the light client header of version v of a block at the slot with the state root.
+// From Capella onwards the execution block number of the block is its slot.
+func (c *testChain) header(slot primitives.Slot, stateRoot []byte, v int) ethpb.LightClientHeader {
+	var block interface{}
+	if v >= version.Capella {
+		b := util.NewBeaconBlockCapella()
+		b.Block.Slot = slot
+		b.Block.StateRoot = stateRoot
+		b.Block.Body.ExecutionPayload.BlockNumber = uint64(slot)
+		b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo(bytesutil.Bytes8(uint64(slot)), fieldparams.RootLength)
+		block = b
+	} else {
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = slot
+		b.Block.StateRoot = stateRoot
+		block = b
+	}
+	signed, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(c.t, err)
+	header, err := lightClient.BlockToLightClientHeader(signed, v)
+	require.NoError(c.t, err)
+	return header
+}
+
+func (c *testChain) sign(header *ethpb.BeaconBlockHeader, signatureSlot primitives.Slot, committee *ethpb.SyncCommittee, participants int) *ethpb.SyncAggregate {
+	fork, err := forks.Fork(slots.ToEpoch(signatureSlot - 1))
+	require.NoError(c.t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, c.genesisValidatorsRoot[:])
+	require.NoError(c.t, err)
+	root, err := signing.ComputeSigningRoot(header, domain)
+	require.NoError(c.t, err)
+
+	keys := make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey, len(c.keys))
+	for _, k := range c.keys {
+		keys[bytesutil.ToBytes48(k.PublicKey().Marshal())] = k
+	}
+	bits := bitfield.NewBitvector512()
+	sigs := make([]bls.Signature, 0, participants)
+	for i := 0; i < participants; i++ {
+		bits.SetBitAt(uint64(i), true)
+		sigs = append(sigs, keys[bytesutil.ToBytes48(committee.Pubkeys[i])].Sign(root[:]))
+	}
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: bls.AggregateSignatures(sigs).Marshal(),
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	c := newTestChain(t)
+	bootstrap, root := c.bootstrap(64)
+
+	s, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.DeepEqual(t, bootstrap.Header.Beacon, s.FinalizedHeader())
+	require.DeepEqual(t, bootstrap.Header.Beacon, s.OptimisticHeader())
+	require.Equal(t, false, s.IsNextSyncCommitteeKnown())
+
+	t.Run("untrusted root", func(t *testing.T) {
+		_, err := NewStore([32]byte{'a'}, bootstrap, c.genesisValidatorsRoot)
+		require.ErrorContains(t, "does not match trusted block root", err)
+	})
+	t.Run("invalid branch", func(t *testing.T) {
+		bootstrap, root := c.bootstrap(64)
+		bootstrap.CurrentSyncCommitteeBranch[0] = bytesutil.PadTo([]byte{'x'}, fieldparams.RootLength)
+		_, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+		require.ErrorContains(t, "invalid current sync committee branch", err)
+	})
+	t.Run("wrong committee", func(t *testing.T) {
+		bootstrap, root := c.bootstrap(64)
+		bootstrap.CurrentSyncCommittee = c.next
+		_, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+		require.ErrorContains(t, "invalid current sync committee branch", err)
+	})
+}
+
+func TestStore_ProcessUpdate(t *testing.T) {
+	c := newTestChain(t)
+	bootstrap, root := c.bootstrap(64)
+	newStore := func() *Store {
+		s, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		require.NoError(t, s.ProcessUpdate(u, 161))
+		require.DeepEqual(t, u.FinalizedHeader.Beacon, s.FinalizedHeader())
+		require.DeepEqual(t, u.AttestedHeader.Beacon, s.OptimisticHeader())
+		require.Equal(t, true, s.IsNextSyncCommitteeKnown())
+	})
+	t.Run("no supermajority", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength/2)
+		require.NoError(t, s.ProcessUpdate(u, 161))
+		require.DeepEqual(t, bootstrap.Header.Beacon, s.FinalizedHeader())
+		require.DeepEqual(t, u.AttestedHeader.Beacon, s.OptimisticHeader())
+		require.Equal(t, false, s.IsNextSyncCommitteeKnown())
+	})
+	t.Run("signed by the wrong committee", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.next, fieldparams.SyncCommitteeLength)
+		require.ErrorContains(t, "invalid sync committee signature", s.ProcessUpdate(u, 161))
+	})
+	t.Run("wrong genesis validators root", func(t *testing.T) {
+		s, err := NewStore(root, bootstrap, [32]byte{'x'})
+		require.NoError(t, err)
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		require.ErrorContains(t, "invalid sync committee signature", s.ProcessUpdate(u, 161))
+	})
+	t.Run("invalid finality branch", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		u.FinalizedHeader.Beacon.Slot = 97
+		require.ErrorContains(t, "invalid finality branch", s.ProcessUpdate(u, 161))
+	})
+	t.Run("invalid next sync committee branch", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		u.NextSyncCommittee = c.current
+		require.ErrorContains(t, "invalid next sync committee branch", s.ProcessUpdate(u, 161))
+	})
+	t.Run("not enough participants", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		u.SyncAggregate.SyncCommitteeBits = bitfield.NewBitvector512()
+		require.ErrorContains(t, "not enough sync committee participants", s.ProcessUpdate(u, 161))
+	})
+	t.Run("signature slot in the future", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		require.ErrorContains(t, "invalid update slots", s.ProcessUpdate(u, 160))
+	})
+	t.Run("not newer than finalized", func(t *testing.T) {
+		s := newStore()
+		u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+		require.NoError(t, s.ProcessUpdate(u, 161))
+		u = c.update(96, 32, c.current, fieldparams.SyncCommitteeLength)
+		require.ErrorContains(t, "is not newer than finalized slot", s.ProcessUpdate(u, 161))
+	})
+}
+
+func TestStore_ProcessFinalityAndOptimisticUpdates(t *testing.T) {
+	c := newTestChain(t)
+	bootstrap, root := c.bootstrap(64)
+	s, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength)
+	require.NoError(t, s.ProcessOptimisticUpdate(&ethpb.LightClientOptimisticUpdateAltair{
+		AttestedHeader: u.AttestedHeader,
+		SyncAggregate:  u.SyncAggregate,
+		SignatureSlot:  u.SignatureSlot,
+	}, 161))
+	require.DeepEqual(t, bootstrap.Header.Beacon, s.FinalizedHeader())
+	require.DeepEqual(t, u.AttestedHeader.Beacon, s.OptimisticHeader())
+
+	u = c.update(192, 128, c.current, fieldparams.SyncCommitteeLength)
+	require.NoError(t, s.ProcessFinalityUpdate(&ethpb.LightClientFinalityUpdateAltair{
+		AttestedHeader:  u.AttestedHeader,
+		FinalizedHeader: u.FinalizedHeader,
+		FinalityBranch:  u.FinalityBranch,
+		SyncAggregate:   u.SyncAggregate,
+		SignatureSlot:   u.SignatureSlot,
+	}, 193))
+	require.DeepEqual(t, u.FinalizedHeader.Beacon, s.FinalizedHeader())
+	require.DeepEqual(t, u.AttestedHeader.Beacon, s.OptimisticHeader())
+	// Finality updates do not carry the next sync committee.
+	require.Equal(t, false, s.IsNextSyncCommitteeKnown())
+}
+
+func TestStore_ForceUpdate(t *testing.T) {
+	c := newTestChain(t)
+	bootstrap, root := c.bootstrap(64)
+	s, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	u := c.update(160, 96, c.current, fieldparams.SyncCommitteeLength/2)
+	require.NoError(t, s.ProcessUpdate(u, 161))
+	require.DeepEqual(t, bootstrap.Header.Beacon, s.FinalizedHeader())
+
+	updateTimeout := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	require.Equal(t, false, s.ForceUpdate(64+updateTimeout))
+	require.Equal(t, true, s.ForceUpdate(64+updateTimeout+1))
+	require.DeepEqual(t, u.FinalizedHeader.Beacon, s.FinalizedHeader())
+	require.Equal(t, true, s.IsNextSyncCommitteeKnown())
+	// The best valid update is consumed by the force update.
+	require.Equal(t, false, s.ForceUpdate(64+updateTimeout+1))
+}
+
+func TestStore_ExecutionHeaders(t *testing.T) {
+	c := newTestChain(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 3
+	cfg.CapellaForkEpoch = 3
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+	bootstrap, root := c.bootstrap(64)
+	newStore := func() *Store {
+		s, err := NewStore(root, bootstrap, c.genesisValidatorsRoot)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		s := newStore()
+		execution, err := s.FinalizedExecutionHeader()
+		require.NoError(t, err)
+		require.IsNil(t, execution)
+
+		u := c.updateAtVersion(160, 96, c.current, fieldparams.SyncCommitteeLength, version.Capella)
+		require.NoError(t, s.ProcessUpdate(u, 161))
+		execution, err = s.FinalizedExecutionHeader()
+		require.NoError(t, err)
+		require.Equal(t, uint64(96), execution.BlockNumber())
+		require.DeepEqual(t, bytesutil.PadTo(bytesutil.Bytes8(96), fieldparams.RootLength), execution.BlockHash())
+		execution, err = s.OptimisticExecutionHeader()
+		require.NoError(t, err)
+		require.Equal(t, uint64(160), execution.BlockNumber())
+	})
+	t.Run("tampered execution payload header", func(t *testing.T) {
+		s := newStore()
+		u := c.updateAtVersion(160, 96, c.current, fieldparams.SyncCommitteeLength, version.Capella)
+		u.AttestedHeaderVal().(*ethpb.LightClientHeaderCapella).Execution.BlockNumber = 1000
+		require.ErrorContains(t, "invalid execution branch", s.ProcessUpdate(u, 161))
+
+		u = c.updateAtVersion(160, 96, c.current, fieldparams.SyncCommitteeLength, version.Capella)
+		u.FinalizedHeaderVal().(*ethpb.LightClientHeaderCapella).Execution.BlockNumber = 1000
+		require.ErrorContains(t, "invalid finalized header", s.ProcessUpdate(u, 161))
+	})
+	t.Run("missing execution payload header", func(t *testing.T) {
+		s := newStore()
+		u := c.updateAtVersion(160, 96, c.current, fieldparams.SyncCommitteeLength, version.Altair)
+		require.ErrorContains(t, "missing the execution payload header", s.ProcessUpdate(u, 161))
+	})
+}
+
+func TestValidateHeader(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 1
+	cfg.DenebForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+	capellaSlot := params.BeaconConfig().SlotsPerEpoch
+	denebSlot := 2 * params.BeaconConfig().SlotsPerEpoch
+
+	header := func(block interface{}, v int) ethpb.LightClientHeader {
+		signed, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+		h, err := lightClient.BlockToLightClientHeader(signed, v)
+		require.NoError(t, err)
+		return h
+	}
+	capellaBlock := util.NewBeaconBlockCapella()
+	capellaBlock.Block.Slot = capellaSlot
+	capellaBlock.Block.Body.ExecutionPayload.BlockNumber = 1
+	denebBlock := util.NewBeaconBlockDeneb()
+	denebBlock.Block.Slot = denebSlot
+	denebBlock.Block.Body.ExecutionPayload.BlobGasUsed = 2
+	beacon := func(slot primitives.Slot) *ethpb.BeaconBlockHeader {
+		return util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: slot})
+	}
+
+	tests := []struct {
+		name   string
+		header func() ethpb.LightClientHeader
+		err    string
+	}{
+		{
+			name:   "altair",
+			header: func() ethpb.LightClientHeader { return &ethpb.LightClientHeaderAltair{Beacon: beacon(capellaSlot - 1)} },
+		},
+		{
+			name:   "altair after capella",
+			header: func() ethpb.LightClientHeader { return &ethpb.LightClientHeaderAltair{Beacon: beacon(capellaSlot)} },
+			err:    "missing the execution payload header",
+		},
+		{
+			name:   "empty execution before capella",
+			header: func() ethpb.LightClientHeader { return lightClient.EmptyHeader(version.Deneb, beacon(capellaSlot-1)) },
+		},
+		{
+			name: "execution before capella",
+			header: func() ethpb.LightClientHeader {
+				h := lightClient.EmptyHeader(version.Capella, beacon(capellaSlot-1))
+				h.(*ethpb.LightClientHeaderCapella).Execution.BlockNumber = 1
+				return h
+			},
+			err: "has execution data before Capella",
+		},
+		{
+			name:   "capella",
+			header: func() ethpb.LightClientHeader { return header(capellaBlock, version.Capella) },
+		},
+		{
+			name: "capella with tampered execution",
+			header: func() ethpb.LightClientHeader {
+				h := header(capellaBlock, version.Capella)
+				h.(*ethpb.LightClientHeaderCapella).Execution.BlockNumber = 2
+				return h
+			},
+			err: "invalid execution branch",
+		},
+		{
+			name: "blob gas before deneb",
+			header: func() ethpb.LightClientHeader {
+				h := header(capellaBlock, version.Deneb)
+				h.(*ethpb.LightClientHeaderDeneb).Execution.BlobGasUsed = 1
+				return h
+			},
+			err: "has blob gas before Deneb",
+		},
+		{
+			name:   "deneb",
+			header: func() ethpb.LightClientHeader { return header(denebBlock, version.Deneb) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHeader(tt.header())
+			if tt.err != "" {
+				require.ErrorContains(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
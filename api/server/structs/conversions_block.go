@@ -2320,6 +2320,138 @@ func ExecutionPayloadHeaderFromConsensus(payload *enginev1.ExecutionPayloadHeade
 	}, nil
 }
 
+func (h *ExecutionPayloadHeaderCapella) ToConsensus() (*enginev1.ExecutionPayloadHeaderCapella, error) {
+	parentHash, err := bytesutil.DecodeHexWithLength(h.ParentHash, common.HashLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ParentHash")
+	}
+	feeRecipient, err := bytesutil.DecodeHexWithLength(h.FeeRecipient, fieldparams.FeeRecipientLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FeeRecipient")
+	}
+	stateRoot, err := bytesutil.DecodeHexWithLength(h.StateRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "StateRoot")
+	}
+	receiptsRoot, err := bytesutil.DecodeHexWithLength(h.ReceiptsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ReceiptsRoot")
+	}
+	logsBloom, err := bytesutil.DecodeHexWithLength(h.LogsBloom, fieldparams.LogsBloomLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "LogsBloom")
+	}
+	prevRandao, err := bytesutil.DecodeHexWithLength(h.PrevRandao, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "PrevRandao")
+	}
+	blockNumber, err := strconv.ParseUint(h.BlockNumber, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BlockNumber")
+	}
+	gasLimit, err := strconv.ParseUint(h.GasLimit, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "GasLimit")
+	}
+	gasUsed, err := strconv.ParseUint(h.GasUsed, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "GasUsed")
+	}
+	timestamp, err := strconv.ParseUint(h.Timestamp, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Timestamp")
+	}
+	extraData, err := bytesutil.DecodeHexWithMaxLength(h.ExtraData, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExtraData")
+	}
+	baseFeePerGas, err := bytesutil.Uint256ToSSZBytes(h.BaseFeePerGas)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BaseFeePerGas")
+	}
+	blockHash, err := bytesutil.DecodeHexWithLength(h.BlockHash, common.HashLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BlockHash")
+	}
+	txsRoot, err := bytesutil.DecodeHexWithLength(h.TransactionsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "TransactionsRoot")
+	}
+	withdrawalsRoot, err := bytesutil.DecodeHexWithLength(h.WithdrawalsRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "WithdrawalsRoot")
+	}
+
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       parentHash,
+		FeeRecipient:     feeRecipient,
+		StateRoot:        stateRoot,
+		ReceiptsRoot:     receiptsRoot,
+		LogsBloom:        logsBloom,
+		PrevRandao:       prevRandao,
+		BlockNumber:      blockNumber,
+		GasLimit:         gasLimit,
+		GasUsed:          gasUsed,
+		Timestamp:        timestamp,
+		ExtraData:        extraData,
+		BaseFeePerGas:    baseFeePerGas,
+		BlockHash:        blockHash,
+		TransactionsRoot: txsRoot,
+		WithdrawalsRoot:  withdrawalsRoot,
+	}, nil
+}
+
+func (h *ExecutionPayloadHeaderDeneb) ToConsensus() (*enginev1.ExecutionPayloadHeaderDeneb, error) {
+	capella, err := (&ExecutionPayloadHeaderCapella{
+		ParentHash:       h.ParentHash,
+		FeeRecipient:     h.FeeRecipient,
+		StateRoot:        h.StateRoot,
+		ReceiptsRoot:     h.ReceiptsRoot,
+		LogsBloom:        h.LogsBloom,
+		PrevRandao:       h.PrevRandao,
+		BlockNumber:      h.BlockNumber,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Timestamp:        h.Timestamp,
+		ExtraData:        h.ExtraData,
+		BaseFeePerGas:    h.BaseFeePerGas,
+		BlockHash:        h.BlockHash,
+		TransactionsRoot: h.TransactionsRoot,
+		WithdrawalsRoot:  h.WithdrawalsRoot,
+	}).ToConsensus()
+	if err != nil {
+		return nil, err
+	}
+	blobGasUsed, err := strconv.ParseUint(h.BlobGasUsed, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "BlobGasUsed")
+	}
+	excessBlobGas, err := strconv.ParseUint(h.ExcessBlobGas, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExcessBlobGas")
+	}
+
+	return &enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       capella.ParentHash,
+		FeeRecipient:     capella.FeeRecipient,
+		StateRoot:        capella.StateRoot,
+		ReceiptsRoot:     capella.ReceiptsRoot,
+		LogsBloom:        capella.LogsBloom,
+		PrevRandao:       capella.PrevRandao,
+		BlockNumber:      capella.BlockNumber,
+		GasLimit:         capella.GasLimit,
+		GasUsed:          capella.GasUsed,
+		Timestamp:        capella.Timestamp,
+		ExtraData:        capella.ExtraData,
+		BaseFeePerGas:    capella.BaseFeePerGas,
+		BlockHash:        capella.BlockHash,
+		TransactionsRoot: capella.TransactionsRoot,
+		WithdrawalsRoot:  capella.WithdrawalsRoot,
+		BlobGasUsed:      blobGasUsed,
+		ExcessBlobGas:    excessBlobGas,
+	}, nil
+}
+
 func ExecutionPayloadHeaderCapellaFromConsensus(payload *enginev1.ExecutionPayloadHeaderCapella) (*ExecutionPayloadHeaderCapella, error) {
 	baseFeePerGas, err := sszBytesToUint256String(payload.BaseFeePerGas)
 	if err != nil {
@@ -12,3 +12,35 @@ type ExpectedWithdrawal struct {
 	Index          string `json:"index"`
 	ValidatorIndex string `json:"validator_index"`
 }
+
+type GetBuilderBidsResponse struct {
+	Data   []*BuilderBid      `json:"data"`
+	Relays []*RelayBidSummary `json:"relays"`
+}
+
+type BuilderBid struct {
+	Slot               string `json:"slot"`
+	ProposerIndex      string `json:"proposer_index"`
+	Timestamp          string `json:"timestamp"`
+	Outcome            string `json:"outcome"`
+	RelayPubkey        string `json:"relay_pubkey"`
+	BlockHash          string `json:"block_hash"`
+	BuilderValue       string `json:"builder_value"`
+	LocalValue         string `json:"local_value"`
+	LocalBoost         string `json:"local_boost"`
+	BuilderBoostFactor string `json:"builder_boost_factor"`
+	Latency            string `json:"latency"`
+	SinceSlotStart     string `json:"since_slot_start"`
+	Error              string `json:"error,omitempty"`
+}
+
+type RelayBidSummary struct {
+	RelayPubkey         string `json:"relay_pubkey"`
+	Bids                string `json:"bids"`
+	Accepted            string `json:"accepted"`
+	LowerValue          string `json:"lower_value"`
+	Rejected            string `json:"rejected"`
+	AverageLatency      string `json:"average_latency"`
+	AverageBuilderValue string `json:"average_builder_value"`
+	AverageLocalValue   string `json:"average_local_value"`
+}
@@ -55,3 +55,85 @@ type ForkChoiceNodeExtraData struct {
 	ExecutionOptimistic      bool   `json:"execution_optimistic"`
 	TimeStamp                string `json:"timestamp"`
 }
+
+type GetReorgsResponse struct {
+	Data []*HeadChange `json:"data"`
+}
+
+type HeadChange struct {
+	Slot               string `json:"slot"`
+	Timestamp          string `json:"timestamp"`
+	Reason             string `json:"reason"`
+	OldHeadRoot        string `json:"old_head_root"`
+	OldHeadSlot        string `json:"old_head_slot"`
+	OldHeadWeight      string `json:"old_head_weight"`
+	NewHeadRoot        string `json:"new_head_root"`
+	NewHeadSlot        string `json:"new_head_slot"`
+	NewHeadWeight      string `json:"new_head_weight"`
+	CommonAncestorRoot string `json:"common_ancestor_root"`
+	CommonAncestorSlot string `json:"common_ancestor_slot"`
+	Depth              string `json:"depth"`
+	Distance           string `json:"distance"`
+	ProposerBoostRoot  string `json:"proposer_boost_root"`
+	JustifiedEpoch     string `json:"justified_epoch"`
+	FinalizedEpoch     string `json:"finalized_epoch"`
+}
+
+type GetAttestationPoolResponse struct {
+	Data *AttestationPool `json:"data"`
+}
+
+type AttestationPool struct {
+	Aggregated   string                 `json:"aggregated"`
+	Unaggregated string                 `json:"unaggregated"`
+	Block        string                 `json:"block"`
+	Forkchoice   string                 `json:"forkchoice"`
+	Slots        []*AttestationPoolSlot `json:"slots"`
+}
+
+type AttestationPoolSlot struct {
+	Slot         string                      `json:"slot"`
+	Aggregated   string                      `json:"aggregated"`
+	Unaggregated string                      `json:"unaggregated"`
+	Block        string                      `json:"block"`
+	Forkchoice   string                      `json:"forkchoice"`
+	Committees   []*AttestationPoolCommittee `json:"committees"`
+}
+
+type AttestationPoolCommittee struct {
+	Index             string `json:"index"`
+	Aggregated        string `json:"aggregated"`
+	Unaggregated      string `json:"unaggregated"`
+	Block             string `json:"block"`
+	Forkchoice        string `json:"forkchoice"`
+	CommitteeSize     string `json:"committee_size"`
+	CoveredValidators string `json:"covered_validators"`
+}
+
+type GetAttestationPackingResponse struct {
+	Data *AttestationPacking `json:"data"`
+}
+
+type AttestationPacking struct {
+	Slot                string                `json:"slot"`
+	ProposerIndex       string                `json:"proposer_index"`
+	TotalReward         string                `json:"total_reward"`
+	Attestations        []*PackedAttestation  `json:"attestations"`
+	UncoveredValidators []*UncoveredCommittee `json:"uncovered_validators"`
+}
+
+type PackedAttestation struct {
+	Slot                string   `json:"slot"`
+	CommitteeIndices    []string `json:"committee_indices"`
+	BeaconBlockRoot     string   `json:"beacon_block_root"`
+	TargetEpoch         string   `json:"target_epoch"`
+	AggregationBits     string   `json:"aggregation_bits"`
+	AttestingValidators string   `json:"attesting_validators"`
+	Reward              string   `json:"reward"`
+}
+
+type UncoveredCommittee struct {
+	Slot       string   `json:"slot"`
+	Index      string   `json:"index"`
+	Validators []string `json:"validators"`
+}
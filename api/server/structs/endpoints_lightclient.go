@@ -1,20 +1,31 @@
 package structs
 
+import "encoding/json"
+
 type LightClientBootstrapResponse struct {
 	Version string                `json:"version"`
 	Data    *LightClientBootstrap `json:"data"`
 }
 
+// LightClientHeader is the header of light client data. From Capella onwards it also holds the execution
+// payload header of the block, which is an ExecutionPayloadHeaderCapella or ExecutionPayloadHeaderDeneb
+// depending on the version of the data, and its Merkle branch in the block body.
+type LightClientHeader struct {
+	*BeaconBlockHeader
+	Execution       json.RawMessage `json:"execution,omitempty"`
+	ExecutionBranch []string        `json:"execution_branch,omitempty"`
+}
+
 type LightClientBootstrap struct {
-	Header                     *BeaconBlockHeader `json:"header"`
+	Header                     *LightClientHeader `json:"header"`
 	CurrentSyncCommittee       *SyncCommittee     `json:"current_sync_committee"`
 	CurrentSyncCommitteeBranch []string           `json:"current_sync_committee_branch"`
 }
 
 type LightClientUpdate struct {
-	AttestedHeader          *BeaconBlockHeader `json:"attested_header"`
+	AttestedHeader          *LightClientHeader `json:"attested_header"`
 	NextSyncCommittee       *SyncCommittee     `json:"next_sync_committee,omitempty"`
-	FinalizedHeader         *BeaconBlockHeader `json:"finalized_header,omitempty"`
+	FinalizedHeader         *LightClientHeader `json:"finalized_header,omitempty"`
 	SyncAggregate           *SyncAggregate     `json:"sync_aggregate"`
 	NextSyncCommitteeBranch []string           `json:"next_sync_committee_branch,omitempty"`
 	FinalityBranch          []string           `json:"finality_branch,omitempty"`
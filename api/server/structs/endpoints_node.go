@@ -45,6 +45,48 @@ type Peer struct {
 	Direction          string `json:"direction"`
 }
 
+type GetPeerScoreResponse struct {
+	Data *PeerScore `json:"data"`
+}
+
+type PeerScore struct {
+	PeerId           string         `json:"peer_id"`
+	Agent            string         `json:"agent"`
+	Score            string         `json:"score"`
+	Scorers          []*ScorerScore `json:"scorers"`
+	ClientPreference string         `json:"client_preference"`
+	IsBad            bool           `json:"is_bad"`
+	Trusted          bool           `json:"trusted"`
+	Allowed          bool           `json:"allowed"`
+	Denied           bool           `json:"denied"`
+	Pinned           bool           `json:"pinned"`
+}
+
+type ScorerScore struct {
+	Name   string `json:"name"`
+	Score  string `json:"score"`
+	Weight string `json:"weight"`
+}
+
+type GetPeerScoringPolicyResponse struct {
+	Data *PeerScoringPolicy `json:"data"`
+}
+
+type PeerScoringPolicy struct {
+	Weights           *ScorerWeights    `json:"weights"`
+	Allow             []string          `json:"allow"`
+	Deny              []string          `json:"deny"`
+	Pin               []string          `json:"pin"`
+	ClientPreferences map[string]string `json:"client_preferences"`
+}
+
+type ScorerWeights struct {
+	BadResponses  string `json:"bad_responses"`
+	BlockProvider string `json:"block_provider"`
+	PeerStatus    string `json:"peer_status"`
+	Gossip        string `json:"gossip"`
+}
+
 type GetPeerCountResponse struct {
 	Data *PeerCount `json:"data"`
 }
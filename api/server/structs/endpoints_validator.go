@@ -118,3 +118,36 @@ type GetValidatorPerformanceResponse struct {
 	MissingValidators             [][]byte `json:"missing_validators,omitempty"`
 	InactivityScores              []uint64 `json:"inactivity_scores,omitempty"`
 }
+
+type GetValidatorPerformanceHistoryResponse struct {
+	Data []*ValidatorEpochPerformance `json:"data"`
+}
+
+type ValidatorEpochPerformance struct {
+	Epoch                 string `json:"epoch"`
+	ValidatorIndex        string `json:"validator_index"`
+	AttestationExpected   bool   `json:"attestation_expected"`
+	AttestationIncluded   bool   `json:"attestation_included"`
+	InclusionDistance     string `json:"inclusion_distance"`
+	CorrectSource         bool   `json:"correct_source"`
+	CorrectTarget         bool   `json:"correct_target"`
+	CorrectHead           bool   `json:"correct_head"`
+	SyncCommitteeExpected string `json:"sync_committee_expected"`
+	SyncCommitteeIncluded string `json:"sync_committee_included"`
+	ProposalsExpected     string `json:"proposals_expected"`
+	ProposalsIncluded     string `json:"proposals_included"`
+	StartBalance          string `json:"start_balance"`
+	EndBalance            string `json:"end_balance"`
+	BalanceChange         string `json:"balance_change"`
+	MissedAttestation     bool   `json:"missed_attestation"`
+	MissedSyncCommittee   string `json:"missed_sync_committee"`
+	MissedProposals       string `json:"missed_proposals"`
+}
+
+type GetTrackedValidatorsResponse struct {
+	Data []string `json:"data"`
+}
+
+type AddTrackedValidatorsRequest struct {
+	Indices []string `json:"indices"`
+}
@@ -116,6 +116,12 @@ type OptimisticModeFetcher interface {
 	IsOptimisticForRoot(ctx context.Context, root [32]byte) (bool, error)
 }
 
+// LightClientFetcher retrieves the latest light client updates derived from the canonical head.
+type LightClientFetcher interface {
+	LightClientFinalityUpdate() ethpb.LightClientFinalityUpdate
+	LightClientOptimisticUpdate() ethpb.LightClientOptimisticUpdate
+}
+
 // FinalizedCheckpt returns the latest finalized checkpoint from chain store.
 func (s *Service) FinalizedCheckpt() *ethpb.Checkpoint {
 	s.cfg.ForkChoiceStore.RLock()
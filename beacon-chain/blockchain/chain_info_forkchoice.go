@@ -15,11 +15,17 @@ func (s *Service) CachedHeadRoot() [32]byte {
 	return s.cfg.ForkChoiceStore.CachedHeadRoot()
 }
 
-// GetProposerHead returns the corresponding value from forkchoice
+// GetProposerHead returns the corresponding value from forkchoice. When the proposer builds on the parent of
+// the head, the late head block is recorded so that its reorg is reported as a late block reorg.
 func (s *Service) GetProposerHead() [32]byte {
 	s.cfg.ForkChoiceStore.RLock()
 	defer s.cfg.ForkChoiceStore.RUnlock()
-	return s.cfg.ForkChoiceStore.GetProposerHead()
+	headRoot := s.cfg.ForkChoiceStore.CachedHeadRoot()
+	proposerHead := s.cfg.ForkChoiceStore.GetProposerHead()
+	if proposerHead != headRoot {
+		s.lateBlockReorg.record(headRoot, proposerHead)
+	}
+	return proposerHead
 }
 
 // ShouldOverrideFCU returns the corresponding value from forkchoice
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// saveForkchoiceSnapshot writes the current fork choice store to the DB, so that it can be restored on the next start.
+func (s *Service) saveForkchoiceSnapshot(ctx context.Context) error {
+	s.cfg.ForkChoiceStore.RLock()
+	snapshot, err := s.cfg.ForkChoiceStore.Snapshot()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not serialize forkchoice store")
+	}
+	return s.cfg.BeaconDB.SaveForkchoiceSnapshot(ctx, snapshot)
+}
+
+// runForkchoiceSnapshots saves a fork choice snapshot at the start of every epoch.
+func (s *Service) runForkchoiceSnapshots() {
+	if _, err := s.clockWaiter.WaitForClock(s.ctx); err != nil {
+		log.WithError(err).Error("Forkchoice snapshot routine failed to receive genesis data")
+		return
+	}
+	ticker := slots.NewSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-ticker.C():
+			if !slots.IsEpochStart(slot) {
+				continue
+			}
+			if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save forkchoice snapshot")
+			}
+		}
+	}
+}
+
+// restoreForkchoiceSnapshot loads the fork choice store saved by a previous run. The snapshot is only used if it
+// starts from the finalized checkpoint found in the DB and every block it references is in the DB.
+// This function requires a lock in forkchoice.
+func (s *Service) restoreForkchoiceSnapshot(ctx context.Context, finalized *ethpb.Checkpoint) error {
+	snapshot, err := s.cfg.BeaconDB.ForkchoiceSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	// Validate the snapshot on a scratch store, so that a failure leaves the real one empty.
+	fc := doublylinkedtree.New()
+	if err := fc.RestoreSnapshot(ctx, snapshot); err != nil {
+		return err
+	}
+	fcFinalized := fc.FinalizedCheckpoint()
+	if fcFinalized.Epoch != finalized.Epoch || !bytes.Equal(fcFinalized.Root[:], finalized.Root) {
+		return fmt.Errorf("snapshot finalized checkpoint (epoch %d, root %#x) does not match the DB (epoch %d, root %#x)",
+			fcFinalized.Epoch, fcFinalized.Root, finalized.Epoch, finalized.Root)
+	}
+	tips, _ := fc.Tips()
+	for _, root := range tips {
+		if !s.cfg.BeaconDB.HasBlock(ctx, root) {
+			return fmt.Errorf("snapshot block %#x is not in the DB", root)
+		}
+	}
+	if err := s.cfg.ForkChoiceStore.RestoreSnapshot(ctx, snapshot); err != nil {
+		return err
+	}
+	s.cfg.ForkChoiceStore.SetGenesisTime(uint64(s.genesisTime.Unix()))
+	log.WithFields(logrus.Fields{
+		"nodes":          s.cfg.ForkChoiceStore.NodeCount(),
+		"finalizedEpoch": finalized.Epoch,
+		"justifiedEpoch": s.cfg.ForkChoiceStore.JustifiedCheckpoint().Epoch,
+	}).Info("Restored forkchoice store from snapshot")
+	return nil
+}
@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"testing"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_ForkchoiceSnapshot(t *testing.T) {
+	service, tr := minimalTestService(t)
+	ctx := tr.ctx
+
+	// Blocks 1 <- 2 are in the DB, 1 is finalized.
+	b1 := util.NewBeaconBlock()
+	b1.Block.Slot = 1
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, tr.db, b1)
+	b2 := util.NewBeaconBlock()
+	b2.Block.Slot = 2
+	b2.Block.ParentRoot = r1[:]
+	r2, err := b2.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, tr.db, b2)
+
+	cp := &ethpb.Checkpoint{Root: r1[:]}
+	st, root, err := prepareForkchoiceState(ctx, 1, r1, params.BeaconConfig().ZeroHash, [32]byte{'a'}, cp, cp)
+	require.NoError(t, err)
+	require.NoError(t, tr.fcs.InsertNode(ctx, st, root))
+	require.NoError(t, tr.fcs.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Root: r1}))
+	st, root, err = prepareForkchoiceState(ctx, 2, r2, r1, [32]byte{'b'}, cp, cp)
+	require.NoError(t, err)
+	require.NoError(t, tr.fcs.InsertNode(ctx, st, root))
+	require.NoError(t, service.saveForkchoiceSnapshot(ctx))
+
+	t.Run("restored", func(t *testing.T) {
+		service.cfg.ForkChoiceStore = doublylinkedtree.New()
+		require.NoError(t, service.restoreForkchoiceSnapshot(ctx, cp))
+		require.Equal(t, 2, service.cfg.ForkChoiceStore.NodeCount())
+		require.Equal(t, true, service.cfg.ForkChoiceStore.HasNode(r2))
+	})
+	t.Run("finalized checkpoint mismatch", func(t *testing.T) {
+		service.cfg.ForkChoiceStore = doublylinkedtree.New()
+		err := service.restoreForkchoiceSnapshot(ctx, &ethpb.Checkpoint{Epoch: 1, Root: r1[:]})
+		require.ErrorContains(t, "does not match the DB", err)
+		require.Equal(t, 0, service.cfg.ForkChoiceStore.NodeCount())
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		st, root, err := prepareForkchoiceState(ctx, 3, [32]byte{'c'}, r2, [32]byte{'c'}, cp, cp)
+		require.NoError(t, err)
+		require.NoError(t, tr.fcs.InsertNode(ctx, st, root))
+		service.cfg.ForkChoiceStore = tr.fcs
+		require.NoError(t, service.saveForkchoiceSnapshot(ctx))
+
+		service.cfg.ForkChoiceStore = doublylinkedtree.New()
+		err = service.restoreForkchoiceSnapshot(ctx, cp)
+		require.ErrorContains(t, "is not in the DB", err)
+		require.Equal(t, 0, service.cfg.ForkChoiceStore.NodeCount())
+	})
+}
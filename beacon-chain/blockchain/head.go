@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -54,6 +57,33 @@ type head struct {
 	optimistic bool                                 // optimistic status when saved head
 }
 
+// lateBlockReorg is the late head block that the local proposer decided to orphan, as decided by the late
+// block reorg rules of forkchoice, and the parent it builds on instead.
+type lateBlockReorg struct {
+	sync.Mutex
+	orphaned [32]byte
+	parent   [32]byte
+}
+
+// record remembers that the proposer builds on the parent of the late head block.
+func (r *lateBlockReorg) record(orphaned, parent [32]byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.orphaned, r.parent = orphaned, parent
+}
+
+// orphans returns true if a head change from the old head to a child of the given parent is the recorded
+// late block reorg. The record is used only once.
+func (r *lateBlockReorg) orphans(oldHead, newParent [32]byte) bool {
+	r.Lock()
+	defer r.Unlock()
+	if r.orphaned == [32]byte{} || r.orphaned != oldHead || r.parent != newParent {
+		return false
+	}
+	r.orphaned, r.parent = [32]byte{}, [32]byte{}
+	return true
+}
+
 // This saves head info to the local service cache, it also saves the
 // new head root to the DB.
 // Caller of the method MUST acquire a lock on forkchoice.
@@ -100,6 +130,30 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 	if err != nil {
 		log.WithError(err).Error("could not check if node is optimistically synced")
 	}
+	oldWeight, err := s.cfg.ForkChoiceStore.Weight(oldHeadRoot)
+	if err != nil {
+		log.WithField("root", fmt.Sprintf("%#x", oldHeadRoot)).Warn("could not determine node weight")
+	}
+	newWeight, err := s.cfg.ForkChoiceStore.Weight(newHeadRoot)
+	if err != nil {
+		log.WithField("root", fmt.Sprintf("%#x", newHeadRoot)).Warn("could not determine node weight")
+	}
+	change := &forkchoicetypes.HeadChange{
+		Slot:               s.CurrentSlot(),
+		Timestamp:          uint64(time.Now().UnixMilli()),
+		Reason:             forkchoicetypes.HeadChangeNewBlock,
+		OldHeadRoot:        oldHeadRoot,
+		OldHeadSlot:        headSlot,
+		OldHeadWeight:      oldWeight,
+		NewHeadRoot:        newHeadRoot,
+		NewHeadSlot:        newHeadSlot,
+		NewHeadWeight:      newWeight,
+		CommonAncestorRoot: oldHeadRoot,
+		CommonAncestorSlot: headSlot,
+		ProposerBoostRoot:  s.cfg.ForkChoiceStore.ProposerBoost(),
+		JustifiedEpoch:     s.cfg.ForkChoiceStore.JustifiedCheckpoint().Epoch,
+		FinalizedEpoch:     s.cfg.ForkChoiceStore.FinalizedCheckpoint().Epoch,
+	}
 	if headBlock.Block().ParentRoot() != oldHeadRoot {
 		// A chain re-org occurred, so we fire an event notifying the rest of the services.
 		commonRoot, forkSlot, err := s.cfg.ForkChoiceStore.CommonAncestor(ctx, oldHeadRoot, newHeadRoot)
@@ -109,14 +163,14 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		}
 		dis := headSlot + newHeadSlot - 2*forkSlot
 		dep := math.Max(uint64(headSlot-forkSlot), uint64(newHeadSlot-forkSlot))
-		oldWeight, err := s.cfg.ForkChoiceStore.Weight(oldHeadRoot)
-		if err != nil {
-			log.WithField("root", fmt.Sprintf("%#x", oldHeadRoot)).Warn("could not determine node weight")
-		}
-		newWeight, err := s.cfg.ForkChoiceStore.Weight(newHeadRoot)
-		if err != nil {
-			log.WithField("root", fmt.Sprintf("%#x", newHeadRoot)).Warn("could not determine node weight")
+		change.Reason = forkchoicetypes.HeadChangeReorg
+		if s.lateBlockReorg.orphans(oldHeadRoot, headBlock.Block().ParentRoot()) {
+			change.Reason = forkchoicetypes.HeadChangeLateBlockReorg
 		}
+		change.CommonAncestorRoot = commonRoot
+		change.CommonAncestorSlot = forkSlot
+		change.Depth = dep
+		change.Distance = uint64(dis)
 		log.WithFields(logrus.Fields{
 			"newSlot":            fmt.Sprintf("%d", newHeadSlot),
 			"newRoot":            fmt.Sprintf("%#x", newHeadRoot),
@@ -150,6 +204,9 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		}
 		reorgCount.Inc()
 	}
+	if err := s.cfg.BeaconDB.SaveHeadChange(ctx, change); err != nil {
+		log.WithError(err).Error("Could not save head change")
+	}
 
 	// Cache the new head info.
 	newHead := &head{
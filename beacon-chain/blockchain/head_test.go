@@ -88,6 +88,12 @@ func TestSaveHead_Different(t *testing.T) {
 	require.NoError(t, err)
 	assert.DeepEqual(t, newHeadSignedBlock, pb, "Head did not change")
 	assert.DeepSSZEqual(t, headState.ToProto(), service.headState(ctx).ToProto(), "Head did not change")
+
+	changes, err := beaconDB.HeadChanges(ctx, 0, params.BeaconConfig().FarFutureSlot)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	assert.Equal(t, oldRoot, changes[0].OldHeadRoot)
+	assert.Equal(t, newRoot, changes[0].NewHeadRoot)
 }
 
 func TestSaveHead_Different_Reorg(t *testing.T) {
@@ -148,6 +154,84 @@ func TestSaveHead_Different_Reorg(t *testing.T) {
 	require.LogsContain(t, hook, "Chain reorg occurred")
 	require.LogsContain(t, hook, "distance=1")
 	require.LogsContain(t, hook, "depth=1")
+
+	changes, err := beaconDB.HeadChanges(ctx, 0, params.BeaconConfig().FarFutureSlot)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	assert.Equal(t, forkchoicetypes.HeadChangeReorg, changes[0].Reason)
+	assert.Equal(t, oldRoot, changes[0].OldHeadRoot)
+	assert.Equal(t, newRoot, changes[0].NewHeadRoot)
+	assert.Equal(t, primitives.Slot(1), changes[0].NewHeadSlot)
+	assert.Equal(t, uint64(1), changes[0].Depth)
+	assert.Equal(t, uint64(1), changes[0].Distance)
+}
+
+func TestSaveHead_LateBlockReorg(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		decided  bool
+		expected forkchoicetypes.HeadChangeReason
+	}{
+		{name: "proposer orphaned the late block", decided: true, expected: forkchoicetypes.HeadChangeLateBlockReorg},
+		{name: "sibling reorg", decided: false, expected: forkchoicetypes.HeadChangeReorg},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			beaconDB := testDB.SetupDB(t)
+			service := setupBeaconChain(t, beaconDB)
+
+			parentRoot := [32]byte{'P'}
+			jc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+			state, blkRoot, err := prepareForkchoiceState(ctx, 1, parentRoot, service.originBlockRoot, [32]byte{}, jc, jc)
+			require.NoError(t, err)
+			require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, state, blkRoot))
+
+			// The late block at slot 2 is the head until the proposer of slot 3 builds on its parent.
+			lateBlock := util.NewBeaconBlock()
+			lateBlock.Block.Slot = 2
+			lateBlock.Block.ParentRoot = parentRoot[:]
+			wsbLate := util.SaveBlock(t, ctx, beaconDB, lateBlock)
+			lateRoot, err := lateBlock.Block.HashTreeRoot()
+			require.NoError(t, err)
+			state, blkRoot, err = prepareForkchoiceState(ctx, 2, lateRoot, parentRoot, [32]byte{'a'}, jc, jc)
+			require.NoError(t, err)
+			require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, state, blkRoot))
+			lateState, err := util.NewBeaconState()
+			require.NoError(t, err)
+			service.head = &head{root: lateRoot, block: wsbLate, state: lateState, slot: 2}
+			if tt.decided {
+				service.lateBlockReorg.record(lateRoot, parentRoot)
+			}
+
+			newBlock := util.NewBeaconBlock()
+			newBlock.Block.Slot = 3
+			newBlock.Block.ParentRoot = parentRoot[:]
+			wsb := util.SaveBlock(t, ctx, beaconDB, newBlock)
+			newRoot, err := newBlock.Block.HashTreeRoot()
+			require.NoError(t, err)
+			state, blkRoot, err = prepareForkchoiceState(ctx, 3, newRoot, parentRoot, [32]byte{'b'}, jc, jc)
+			require.NoError(t, err)
+			require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, state, blkRoot))
+			headState, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, headState.SetSlot(3))
+			require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 3, Root: newRoot[:]}))
+			require.NoError(t, beaconDB.SaveState(ctx, headState, newRoot))
+			require.NoError(t, service.saveHead(ctx, newRoot, wsb, headState))
+
+			changes, err := beaconDB.HeadChanges(ctx, 0, params.BeaconConfig().FarFutureSlot)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(changes))
+			assert.Equal(t, tt.expected, changes[0].Reason)
+			assert.Equal(t, lateRoot, changes[0].OldHeadRoot)
+			assert.Equal(t, primitives.Slot(2), changes[0].OldHeadSlot)
+			assert.Equal(t, newRoot, changes[0].NewHeadRoot)
+			assert.Equal(t, parentRoot, changes[0].CommonAncestorRoot)
+			assert.Equal(t, primitives.Slot(1), changes[0].CommonAncestorSlot)
+			// The decision is used only once.
+			assert.Equal(t, false, service.lateBlockReorg.orphans(lateRoot, parentRoot))
+		})
+	}
 }
 
 func Test_notifyNewHeadEvent(t *testing.T) {
@@ -0,0 +1,109 @@
+package kzg
+
+import (
+	"sync"
+
+	GoEthKZG "github.com/crate-crypto/go-eth-kzg"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+)
+
+var (
+	errCellLength = errors.New("cell has an invalid length")
+
+	// The EIP-7594 context precomputes the FK20 tables, which takes a few seconds,
+	// so it is only initialized the first time cells are needed.
+	ethKZGOnce    sync.Once
+	ethKZGContext *GoEthKZG.Context
+	ethKZGErr     error
+)
+
+func cellsContext() (*GoEthKZG.Context, error) {
+	ethKZGOnce.Do(func() {
+		ethKZGContext, ethKZGErr = GoEthKZG.NewContext4096Secure()
+		if ethKZGErr != nil {
+			ethKZGErr = errors.Wrap(ethKZGErr, "could not initialize go-eth-kzg context")
+		}
+	})
+	return ethKZGContext, ethKZGErr
+}
+
+// ComputeCellsAndKZGProofs extends the given blob and returns its cells along with the KZG proof of each cell.
+func ComputeCellsAndKZGProofs(blob []byte) ([][]byte, [][]byte, error) {
+	ctx, err := cellsContext()
+	if err != nil {
+		return nil, nil, err
+	}
+	b := GoEthKZG.Blob(bytesToBlob(blob))
+	cells, proofs, err := ctx.ComputeCellsAndKZGProofs(&b, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cellsToBytes(cells, proofs)
+}
+
+// RecoverCellsAndKZGProofs recovers all the cells of an extended blob, and their KZG proofs,
+// from at least half of its cells.
+func RecoverCellsAndKZGProofs(cellIndices []uint64, cells [][]byte) ([][]byte, [][]byte, error) {
+	ctx, err := cellsContext()
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := bytesToCells(cells)
+	if err != nil {
+		return nil, nil, err
+	}
+	recovered, proofs, err := ctx.RecoverCellsAndComputeKZGProofs(cellIndices, c, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cellsToBytes(recovered, proofs)
+}
+
+// VerifyCellKZGProofBatch verifies a batch of cells against the commitments of their blobs.
+// The i-th cell is at column cellIndices[i] of the blob committed to by commitments[i].
+func VerifyCellKZGProofBatch(commitments [][]byte, cellIndices []uint64, cells [][]byte, proofs [][]byte) error {
+	if len(commitments) != len(cellIndices) || len(cells) != len(cellIndices) || len(proofs) != len(cellIndices) {
+		return errors.New("mismatched number of commitments, cell indices, cells and proofs")
+	}
+	ctx, err := cellsContext()
+	if err != nil {
+		return err
+	}
+	c, err := bytesToCells(cells)
+	if err != nil {
+		return err
+	}
+	cmts := make([]GoEthKZG.KZGCommitment, len(commitments))
+	p := make([]GoEthKZG.KZGProof, len(proofs))
+	for i := range commitments {
+		copy(cmts[i][:], commitments[i])
+		copy(p[i][:], proofs[i])
+	}
+	return ctx.VerifyCellKZGProofBatch(cmts, cellIndices, c, p)
+}
+
+func bytesToCells(cells [][]byte) ([]*GoEthKZG.Cell, error) {
+	c := make([]*GoEthKZG.Cell, len(cells))
+	for i := range cells {
+		if len(cells[i]) != fieldparams.BytesPerCell {
+			return nil, errors.Wrapf(errCellLength, "cell %d has %d bytes", i, len(cells[i]))
+		}
+		c[i] = new(GoEthKZG.Cell)
+		copy(c[i][:], cells[i])
+	}
+	return c, nil
+}
+
+func cellsToBytes(cells [GoEthKZG.CellsPerExtBlob]*GoEthKZG.Cell, proofs [GoEthKZG.CellsPerExtBlob]GoEthKZG.KZGProof) ([][]byte, [][]byte, error) {
+	c := make([][]byte, len(cells))
+	p := make([][]byte, len(proofs))
+	for i := range cells {
+		if cells[i] == nil {
+			return nil, nil, errors.Errorf("missing cell %d", i)
+		}
+		c[i] = cells[i][:]
+		p[i] = proofs[i][:]
+	}
+	return c, p, nil
+}
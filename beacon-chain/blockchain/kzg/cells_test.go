@@ -0,0 +1,46 @@
+package kzg
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestCells_ComputeVerifyRecover(t *testing.T) {
+	require.NoError(t, Start())
+	blob := GetRandBlob(123)
+	commitment, _, err := GenerateCommitmentAndProof(blob)
+	require.NoError(t, err)
+
+	cells, proofs, err := ComputeCellsAndKZGProofs(blob[:])
+	require.NoError(t, err)
+	require.Equal(t, fieldparams.CellsPerBlob, len(cells))
+	require.Equal(t, fieldparams.CellsPerBlob, len(proofs))
+	require.Equal(t, fieldparams.BytesPerCell, len(cells[0]))
+
+	indices := []uint64{0, 5, 127}
+	cmts := [][]byte{commitment[:], commitment[:], commitment[:]}
+	sub := [][]byte{cells[0], cells[5], cells[127]}
+	subProofs := [][]byte{proofs[0], proofs[5], proofs[127]}
+	require.NoError(t, VerifyCellKZGProofBatch(cmts, indices, sub, subProofs))
+
+	// A cell checked against the proof of another cell must fail.
+	require.NotNil(t, VerifyCellKZGProofBatch(cmts, indices, sub, [][]byte{proofs[1], proofs[5], proofs[127]}))
+	require.NotNil(t, VerifyCellKZGProofBatch(cmts[:2], indices, sub, subProofs))
+
+	// Half of the cells are enough to recover the extended blob.
+	half := make([]uint64, 0, fieldparams.CellsPerBlob/2)
+	halfCells := make([][]byte, 0, fieldparams.CellsPerBlob/2)
+	for i := uint64(0); i < fieldparams.CellsPerBlob; i += 2 {
+		half = append(half, i)
+		halfCells = append(halfCells, cells[i])
+	}
+	recovered, recoveredProofs, err := RecoverCellsAndKZGProofs(half, halfCells)
+	require.NoError(t, err)
+	require.DeepEqual(t, cells, recovered)
+	require.DeepEqual(t, proofs, recoveredProofs)
+
+	_, _, err = RecoverCellsAndKZGProofs([]uint64{0}, [][]byte{cells[0][:10]})
+	require.ErrorIs(t, err, errCellLength)
+}
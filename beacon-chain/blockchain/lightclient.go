@@ -5,13 +5,16 @@ import (
 	"context"
 	"fmt"
 
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -243,3 +246,80 @@ func NewLightClientUpdateFromOptimisticUpdate(update *ethpbv2.LightClientOptimis
 		SignatureSlot:  update.SignatureSlot,
 	}
 }
+
+// NewLightClientUpdateFromBeaconState - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/light-client/full-node.md#create_light_client_update
+// It builds the full light client update of the block, including the next sync committee of the attested
+// state when the attested header and the signature slot are in the same sync committee period. The update
+// uses the light client containers of the fork of the attested block.
+func NewLightClientUpdateFromBeaconState(
+	ctx context.Context,
+	state state.BeaconState,
+	block interfaces.ReadOnlySignedBeaconBlock,
+	attestedState state.BeaconState,
+	attestedBlock interfaces.ReadOnlySignedBeaconBlock,
+	finalizedBlock interfaces.ReadOnlySignedBeaconBlock) (ethpb.LightClientUpdate, error) {
+	update, err := NewLightClientFinalityUpdateFromBeaconState(ctx, state, block, attestedState, finalizedBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// assert hash_tree_root(attested_header) == hash_tree_root(attested_block.message)
+	attestedBlockRoot, err := attestedBlock.Block().HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("could not get attested block root %w", err)
+	}
+	if attestedBlockRoot != block.Block().ParentRoot() {
+		return nil, fmt.Errorf("attested block root %#x not equal to block parent root %#x", attestedBlockRoot, block.Block().ParentRoot())
+	}
+
+	v := lightClient.VersionAtSlot(attestedBlock.Block().Slot())
+	attestedHeader, err := lightClient.BlockToLightClientHeader(attestedBlock, v)
+	if err != nil {
+		return nil, fmt.Errorf("could not get attested light client header %w", err)
+	}
+	var finalizedHeader ethpb.LightClientHeader
+	if finalizedBlock != nil && !finalizedBlock.IsNil() && finalizedBlock.Block().Slot() != 0 {
+		finalizedHeader, err = lightClient.BlockToLightClientHeader(finalizedBlock, v)
+		if err != nil {
+			return nil, fmt.Errorf("could not get finalized light client header %w", err)
+		}
+	} else {
+		finalizedHeader = lightClient.EmptyHeader(v, nil)
+	}
+
+	syncAggregate, err := block.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, fmt.Errorf("could not get sync aggregate %w", err)
+	}
+
+	// if update_attested_period == update_signature_period:
+	//     update.next_sync_committee = attested_state.next_sync_committee
+	//     update.next_sync_committee_branch = compute_merkle_proof(attested_state, NEXT_SYNC_COMMITTEE_GINDEX)
+	nextSyncCommittee := lightClient.EmptySyncCommittee()
+	nextSyncCommitteeBranch := lightClient.EmptyBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	attestedPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(attestedState.Slot()))
+	signaturePeriod := slots.SyncCommitteePeriod(slots.ToEpoch(block.Block().Slot()))
+	if attestedPeriod == signaturePeriod {
+		nextSyncCommittee, err = attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, fmt.Errorf("could not get next sync committee %w", err)
+		}
+		nextSyncCommitteeBranch, err = attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not get next sync committee proof %w", err)
+		}
+	}
+
+	return lightClient.NewUpdate(
+		attestedHeader,
+		nextSyncCommittee,
+		nextSyncCommitteeBranch,
+		finalizedHeader,
+		update.FinalityBranch,
+		&ethpb.SyncAggregate{
+			SyncCommitteeBits:      syncAggregate.SyncCommitteeBits,
+			SyncCommitteeSignature: syncAggregate.SyncCommitteeSignature,
+		},
+		update.SignatureSlot,
+	)
+}
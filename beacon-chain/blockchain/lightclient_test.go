@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -12,6 +13,7 @@ import (
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
@@ -22,6 +24,7 @@ type testlc struct {
 	state          state.BeaconState
 	block          interfaces.ReadOnlySignedBeaconBlock
 	attestedState  state.BeaconState
+	attestedBlock  interfaces.ReadOnlySignedBeaconBlock
 	attestedHeader *ethpb.BeaconBlockHeader
 }
 
@@ -30,9 +33,11 @@ func newTestLc(t *testing.T) *testlc {
 }
 
 func (l *testlc) setupTest() *testlc {
-	ctx := context.Background()
+	return l.setupTestAtSlot(primitives.Slot(params.BeaconConfig().AltairForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1))
+}
 
-	slot := primitives.Slot(params.BeaconConfig().AltairForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
+func (l *testlc) setupTestAtSlot(slot primitives.Slot) *testlc {
+	ctx := context.Background()
 
 	attestedState, err := util.NewBeaconStateCapella()
 	require.NoError(l.t, err)
@@ -41,6 +46,7 @@ func (l *testlc) setupTest() *testlc {
 
 	parent := util.NewBeaconBlockCapella()
 	parent.Block.Slot = slot
+	parent.Block.Body.ExecutionPayload.BlockNumber = 42
 
 	signedParent, err := blocks.NewSignedBeaconBlock(parent)
 	require.NoError(l.t, err)
@@ -93,6 +99,7 @@ func (l *testlc) setupTest() *testlc {
 
 	l.state = state
 	l.attestedState = attestedState
+	l.attestedBlock = signedParent
 	l.attestedHeader = attestedHeader
 	l.block = signedBlock
 	l.ctx = ctx
@@ -158,3 +165,44 @@ func TestLightClient_NewLightClientFinalityUpdateFromBeaconState(t *testing.T) {
 		require.DeepSSZEqual(t, zeroHash, leaf, "Leaf is not zero")
 	}
 }
+
+func TestLightClient_NewLightClientUpdateFromBeaconState(t *testing.T) {
+	l := newTestLc(t).setupTest()
+
+	update, err := NewLightClientUpdateFromBeaconState(l.ctx, l.state, l.block, l.attestedState, l.attestedBlock, nil)
+	require.NoError(t, err)
+	require.Equal(t, version.Altair, update.Version())
+	require.Equal(t, l.block.Block().Slot(), update.GetSignatureSlot(), "Signature slot is not equal")
+	require.Equal(t, l.attestedHeader.Slot, update.AttestedHeaderVal().GetBeacon().Slot, "Attested header slot is not equal")
+
+	// The attested header and the signature slot are in the same period.
+	nextSyncCommittee, err := l.attestedState.NextSyncCommittee()
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, nextSyncCommittee, update.GetNextSyncCommittee(), "Next sync committee is not equal")
+	branch, err := l.attestedState.NextSyncCommitteeProof(l.ctx)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, branch, update.GetNextSyncCommitteeBranch(), "Next sync committee branch is not equal")
+
+	_, err = update.MarshalSSZ()
+	require.NoError(t, err)
+
+	_, err = NewLightClientUpdateFromBeaconState(l.ctx, l.state, l.block, l.attestedState, l.block, nil)
+	require.ErrorContains(t, "not equal to block parent root", err)
+}
+
+func TestLightClient_NewLightClientUpdateFromBeaconState_Capella(t *testing.T) {
+	slot := primitives.Slot(params.BeaconConfig().CapellaForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
+	l := newTestLc(t).setupTestAtSlot(slot)
+
+	update, err := NewLightClientUpdateFromBeaconState(l.ctx, l.state, l.block, l.attestedState, l.attestedBlock, nil)
+	require.NoError(t, err)
+	capella, ok := update.(*ethpb.LightClientUpdateCapella)
+	require.Equal(t, true, ok, "Update is not a Capella update")
+	require.Equal(t, uint64(42), capella.AttestedHeader.Execution.BlockNumber)
+	require.Equal(t, false, lightClient.IsZeroBranch(capella.AttestedHeader.ExecutionBranch))
+	// Without a finalized block the finalized header is empty.
+	require.Equal(t, true, lightClient.IsZeroBranch(capella.FinalizedHeader.ExecutionBranch))
+
+	_, err = update.MarshalSSZ()
+	require.NoError(t, err)
+}
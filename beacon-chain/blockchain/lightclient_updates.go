@@ -0,0 +1,192 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdates holds the latest light client finality and optimistic updates
+// derived from the canonical head, as served and gossiped to light clients.
+type lightClientUpdates struct {
+	sync.RWMutex
+	finality   ethpb.LightClientFinalityUpdate
+	optimistic ethpb.LightClientOptimisticUpdate
+}
+
+// LightClientFinalityUpdate returns the latest light client finality update, or nil if there is none.
+func (s *Service) LightClientFinalityUpdate() ethpb.LightClientFinalityUpdate {
+	s.lightClientUpdates.RLock()
+	defer s.lightClientUpdates.RUnlock()
+	return s.lightClientUpdates.finality
+}
+
+// LightClientOptimisticUpdate returns the latest light client optimistic update, or nil if there is none.
+func (s *Service) LightClientOptimisticUpdate() ethpb.LightClientOptimisticUpdate {
+	s.lightClientUpdates.RLock()
+	defer s.lightClientUpdates.RUnlock()
+	return s.lightClientUpdates.optimistic
+}
+
+// processLightClientUpdate builds the light client update of a block that became head. It is kept in the
+// database when it is the best update of its sync committee period, and the finality and optimistic updates
+// derived from it are broadcast when they are newer than the latest ones.
+func (s *Service) processLightClientUpdate(cfg *postBlockProcessConfig) error {
+	if cfg.headRoot != cfg.blockRoot {
+		return nil
+	}
+	block := cfg.signed.Block()
+	if slots.ToEpoch(block.Slot()) < params.BeaconConfig().AltairForkEpoch {
+		return nil
+	}
+	syncAggregate, err := block.Body().SyncAggregate()
+	if err != nil {
+		return errors.Wrap(err, "could not get sync aggregate")
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(cfg.ctx, block.ParentRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+	if !lightClient.IsSupportedSlot(attestedState.Slot()) {
+		return nil
+	}
+	attestedBlock, err := s.getBlock(cfg.ctx, block.ParentRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get attested block")
+	}
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	if cp := attestedState.FinalizedCheckpoint(); cp != nil {
+		finalizedBlock, err = s.cfg.BeaconDB.Block(cfg.ctx, bytesutil.ToBytes32(cp.Root))
+		if err != nil {
+			return errors.Wrap(err, "could not get finalized block")
+		}
+	}
+	update, err := NewLightClientUpdateFromBeaconState(cfg.ctx, cfg.postState, cfg.signed, attestedState, attestedBlock, finalizedBlock)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+
+	period := lightClient.SyncCommitteePeriodAtSlot(update.AttestedHeaderVal().GetBeacon().Slot)
+	best, err := s.cfg.BeaconDB.LightClientUpdate(cfg.ctx, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get best light client update")
+	}
+	if best == nil || lightClient.IsBetterUpdate(update, best) {
+		if err := s.cfg.BeaconDB.SaveLightClientUpdate(cfg.ctx, period, update); err != nil {
+			return errors.Wrap(err, "could not save light client update")
+		}
+	}
+
+	finality, optimistic, err := s.setLatestLightClientUpdates(update)
+	if err != nil {
+		return err
+	}
+	if finality != nil {
+		s.broadcastLightClientUpdate(finality, finality.GetSignatureSlot())
+	}
+	if optimistic != nil {
+		s.broadcastLightClientUpdate(optimistic, optimistic.GetSignatureSlot())
+	}
+	return nil
+}
+
+// setLatestLightClientUpdates replaces the latest finality and optimistic updates with the ones derived
+// from the update when they are newer, and returns the replaced ones. A finality update is newer when its
+// finalized header is more recent, or is the same but now has a supermajority of the sync committee.
+func (s *Service) setLatestLightClientUpdates(update ethpb.LightClientUpdate) (ethpb.LightClientFinalityUpdate, ethpb.LightClientOptimisticUpdate, error) {
+	s.lightClientUpdates.Lock()
+	defer s.lightClientUpdates.Unlock()
+
+	var finality ethpb.LightClientFinalityUpdate
+	if lightClient.IsFinalityUpdate(update) {
+		latest := s.lightClientUpdates.finality
+		newSlot := update.FinalizedHeaderVal().GetBeacon().Slot
+		if latest == nil || newSlot > latest.FinalizedHeaderVal().GetBeacon().Slot ||
+			(newSlot == latest.FinalizedHeaderVal().GetBeacon().Slot && hasSupermajority(update.GetSyncAggregate()) && !hasSupermajority(latest.GetSyncAggregate())) {
+			var err error
+			finality, err = lightClient.NewFinalityUpdateFromUpdate(update)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "could not create light client finality update")
+			}
+			s.lightClientUpdates.finality = finality
+		}
+	}
+
+	var optimistic ethpb.LightClientOptimisticUpdate
+	latest := s.lightClientUpdates.optimistic
+	if latest == nil || update.AttestedHeaderVal().GetBeacon().Slot > latest.AttestedHeaderVal().GetBeacon().Slot {
+		var err error
+		optimistic, err = lightClient.NewOptimisticUpdateFromUpdate(update)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not create light client optimistic update")
+		}
+		s.lightClientUpdates.optimistic = optimistic
+	}
+	return finality, optimistic, nil
+}
+
+// lightClientGossipUpdate is a light client update that is broadcast on gossip.
+type lightClientGossipUpdate interface {
+	proto.Message
+	Version() int
+}
+
+// broadcastLightClientUpdate broadcasts the update once one third of its signature slot has passed,
+// as peers ignore light client updates received earlier than that. The gossip topics of a fork carry the
+// containers of that fork, so an update whose attested header is from the previous fork is not broadcast.
+func (s *Service) broadcastLightClientUpdate(msg lightClientGossipUpdate, signatureSlot primitives.Slot) {
+	if s.cfg.P2p == nil || msg.Version() != lightClient.VersionAtSlot(signatureSlot) {
+		return
+	}
+	wait := time.Until(slots.BeginsAt(signatureSlot, s.genesisTime).Add(slots.DivideSlotBy(int64(params.BeaconConfig().IntervalsPerSlot))))
+	go func() {
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		if err := s.cfg.P2p.Broadcast(s.ctx, msg); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client update")
+		}
+	}()
+}
+
+// saveLightClientBootstrap stores the light client bootstrap of the finalized block root.
+func (s *Service) saveLightClientBootstrap(ctx context.Context, root [32]byte) error {
+	st, err := s.cfg.StateGen.StateByRoot(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	if !lightClient.IsSupportedSlot(st.Slot()) {
+		return nil
+	}
+	block, err := s.getBlock(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+	bootstrap, err := lightClient.NewBootstrapFromBeaconState(ctx, st, block)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client bootstrap")
+	}
+	return s.cfg.BeaconDB.SaveLightClientBootstrap(ctx, root, bootstrap)
+}
+
+func hasSupermajority(syncAggregate *ethpb.SyncAggregate) bool {
+	bits := syncAggregate.SyncCommitteeBits
+	return bits.Count()*3 >= bits.Len()*2
+}
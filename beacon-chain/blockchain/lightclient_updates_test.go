@@ -0,0 +1,61 @@
+package blockchain
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestService_setLatestLightClientUpdates(t *testing.T) {
+	update := func(attestedSlot, finalizedSlot primitives.Slot, participants uint64, finality bool) *ethpb.LightClientUpdateDeneb {
+		u := util.HydrateLightClientUpdateDeneb(&ethpb.LightClientUpdateDeneb{
+			AttestedHeader:  &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: attestedSlot}},
+			FinalizedHeader: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: finalizedSlot}},
+			SignatureSlot:   attestedSlot + 1,
+		})
+		for i := uint64(0); i < participants; i++ {
+			u.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+		}
+		if finality {
+			u.FinalityBranch[0] = bytesutil.PadTo([]byte{1}, 32)
+		}
+		return u
+	}
+	s := &Service{}
+	require.Equal(t, nil, s.LightClientFinalityUpdate())
+	require.Equal(t, nil, s.LightClientOptimisticUpdate())
+
+	// An update without finality only replaces the optimistic update.
+	finality, optimistic, err := s.setLatestLightClientUpdates(update(10, 0, 1, false))
+	require.NoError(t, err)
+	require.Equal(t, nil, finality)
+	require.NotNil(t, optimistic)
+	require.Equal(t, primitives.Slot(10), s.LightClientOptimisticUpdate().AttestedHeaderVal().GetBeacon().Slot)
+	require.Equal(t, nil, s.LightClientFinalityUpdate())
+
+	finality, optimistic, err = s.setLatestLightClientUpdates(update(12, 8, 1, true))
+	require.NoError(t, err)
+	require.NotNil(t, finality)
+	require.NotNil(t, optimistic)
+	require.Equal(t, primitives.Slot(8), s.LightClientFinalityUpdate().FinalizedHeaderVal().GetBeacon().Slot)
+	_, ok := s.LightClientFinalityUpdate().(*ethpb.LightClientFinalityUpdateDeneb)
+	require.Equal(t, true, ok, "Finality update is not a Deneb update")
+
+	// The same finalized header with a supermajority replaces the finality update, an older attested header
+	// does not replace the optimistic update.
+	finality, optimistic, err = s.setLatestLightClientUpdates(update(11, 8, fieldparams.SyncCommitteeLength, true))
+	require.NoError(t, err)
+	require.NotNil(t, finality)
+	require.Equal(t, nil, optimistic)
+	require.Equal(t, primitives.Slot(12), s.LightClientOptimisticUpdate().AttestedHeaderVal().GetBeacon().Slot)
+
+	finality, _, err = s.setLatestLightClientUpdates(update(13, 8, fieldparams.SyncCommitteeLength, true))
+	require.NoError(t, err)
+	require.Equal(t, nil, finality)
+	require.Equal(t, primitives.Slot(11), s.LightClientFinalityUpdate().AttestedHeaderVal().GetBeacon().Slot)
+}
@@ -199,6 +199,23 @@ func WithBlobStorage(b *filesystem.BlobStorage) Option {
 	}
 }
 
+// WithDataColumnStorage sets the data column storage backend for the blockchain service.
+func WithDataColumnStorage(b *filesystem.DataColumnStorage) Option {
+	return func(s *Service) error {
+		s.dataColumnStorage = b
+		return nil
+	}
+}
+
+// WithCustodyColumns sets the data columns this node custodies, which must be available before a block from the
+// EIP-7594 fork onwards can be imported.
+func WithCustodyColumns(custody map[uint64]bool) Option {
+	return func(s *Service) error {
+		s.custodyColumns = custody
+		return nil
+	}
+}
+
 func WithSyncChecker(checker Checker) Option {
 	return func(s *Service) error {
 		s.cfg.SyncChecker = checker
@@ -527,6 +527,9 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	if block == nil {
 		return errors.New("invalid nil beacon block")
 	}
+	if params.PeerDASEnabled(slots.ToEpoch(block.Slot())) {
+		return s.areDataColumnsAvailable(ctx, root, block)
+	}
 	// We are only required to check within MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS
 	if !params.WithinDAPeriod(slots.ToEpoch(block.Slot()), slots.ToEpoch(s.CurrentSlot())) {
 		return nil
@@ -590,6 +593,54 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	}
 }
 
+// areDataColumnsAvailable is the data column counterpart of isDataAvailable, used for blocks from the EIP-7594 fork
+// onwards. It blocks until all the columns custodied by this node are available for the given block, or an error or
+// context cancellation occurs.
+func (s *Service) areDataColumnsAvailable(ctx context.Context, root [32]byte, block interfaces.ReadOnlyBeaconBlock) error {
+	// We are only required to check within MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS
+	if !params.WithinColumnDAPeriod(slots.ToEpoch(block.Slot()), slots.ToEpoch(s.CurrentSlot())) {
+		return nil
+	}
+	body := block.Body()
+	if body == nil {
+		return errors.New("invalid nil beacon block body")
+	}
+	kzgCommitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrap(err, "could not get KZG commitments")
+	}
+	if len(kzgCommitments) == 0 {
+		return nil
+	}
+	summary := s.dataColumnStorage.Summary(root)
+	missing := make(map[uint64]struct{}, len(s.custodyColumns))
+	for idx := range s.custodyColumns {
+		if !summary.HasIndex(idx) {
+			missing[idx] = struct{}{}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// The gossip handler for data columns writes the index of each verified column referencing the given
+	// root to the channel returned by dataColumnNotifiers.forRoot.
+	nc := s.dataColumnNotifiers.forRoot(root)
+	for {
+		select {
+		case idx := <-nc:
+			delete(missing, idx)
+			if len(missing) > 0 {
+				continue
+			}
+			s.dataColumnNotifiers.delete(root)
+			return nil
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "context deadline waiting for data column sidecars slot: %d, BlockRoot: %#x, missing: %d", block.Slot(), root, len(missing))
+		}
+	}
+}
+
 func daCheckLogFields(root [32]byte, slot primitives.Slot, expected, missing int) logrus.Fields {
 	return logrus.Fields{
 		"slot":          slot,
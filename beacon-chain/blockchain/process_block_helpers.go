@@ -123,6 +123,10 @@ func (s *Service) sendLightClientFeeds(cfg *postBlockProcessConfig) {
 
 		// LightClientFinalityUpdate needs super majority
 		s.tryPublishLightClientFinalityUpdate(cfg.ctx, cfg.signed, finalized, cfg.postState)
+
+		if err := s.processLightClientUpdate(cfg); err != nil {
+			log.WithError(err).Error("Failed to process light client update")
+		}
 	}
 }
 
@@ -396,6 +400,11 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 		if err := s.cfg.StateGen.MigrateToCold(s.ctx, fRoot); err != nil {
 			log.WithError(err).Error("could not migrate to cold")
 		}
+		if features.Get().EnableLightClient {
+			if err := s.saveLightClientBootstrap(s.ctx, fRoot); err != nil {
+				log.WithError(err).Error("Could not save light client bootstrap")
+			}
+		}
 	}()
 	return nil
 }
@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+)
+
+// dataColumnNotifierMap is the data column counterpart of blobNotifierMap. It is used by the DA check of blocks
+// from the EIP-7594 fork onwards to wait for the custodied columns of a block to be received.
+type dataColumnNotifierMap struct {
+	sync.RWMutex
+	notifiers map[[32]byte]chan uint64
+	seenIndex map[[32]byte][fieldparams.NumberOfColumns]bool
+}
+
+func newDataColumnNotifierMap() *dataColumnNotifierMap {
+	return &dataColumnNotifierMap{
+		notifiers: make(map[[32]byte]chan uint64),
+		seenIndex: make(map[[32]byte][fieldparams.NumberOfColumns]bool),
+	}
+}
+
+// notifyIndex notifies a data column by its index for a given root.
+func (cn *dataColumnNotifierMap) notifyIndex(root [32]byte, idx uint64) {
+	if idx >= fieldparams.NumberOfColumns {
+		return
+	}
+
+	cn.Lock()
+	seen := cn.seenIndex[root]
+	if seen[idx] {
+		cn.Unlock()
+		return
+	}
+	seen[idx] = true
+	cn.seenIndex[root] = seen
+
+	c, ok := cn.notifiers[root]
+	if !ok {
+		c = make(chan uint64, fieldparams.NumberOfColumns)
+		cn.notifiers[root] = c
+	}
+
+	cn.Unlock()
+
+	c <- idx
+}
+
+func (cn *dataColumnNotifierMap) forRoot(root [32]byte) chan uint64 {
+	cn.Lock()
+	defer cn.Unlock()
+	c, ok := cn.notifiers[root]
+	if !ok {
+		c = make(chan uint64, fieldparams.NumberOfColumns)
+		cn.notifiers[root] = c
+	}
+	return c
+}
+
+func (cn *dataColumnNotifierMap) delete(root [32]byte) {
+	cn.Lock()
+	defer cn.Unlock()
+	delete(cn.seenIndex, root)
+	delete(cn.notifiers, root)
+}
+
+// ReceiveDataColumn saves the data column sidecar to storage and notifies the DA check waiting on its block.
+func (s *Service) ReceiveDataColumn(_ context.Context, dc blocks.VerifiedRODataColumn) error {
+	if err := s.dataColumnStorage.Save(dc); err != nil {
+		return err
+	}
+
+	s.dataColumnNotifiers.notifyIndex(dc.BlockRoot(), dc.Index)
+	return nil
+}
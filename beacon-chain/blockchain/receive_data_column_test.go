@@ -0,0 +1,46 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestAreDataColumnsAvailable(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	slot := params.BeaconConfig().SlotsPerEpoch
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, slot, 1)
+	store := filesystem.NewEphemeralDataColumnStorage(t)
+	s, _ := minimalTestService(t, WithDataColumnStorage(store), WithCustodyColumns(map[uint64]bool{1: true, 2: true}))
+	s.SetGenesisTime(time.Now().Add(-time.Duration(uint64(slot)*params.BeaconConfig().SecondsPerSlot) * time.Second))
+
+	require.NoError(t, s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[1])))
+	// Columns outside of custody do not count towards availability.
+	require.NoError(t, s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[3])))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.isDataAvailable(ctx, blk.Root(), blk), context.DeadlineExceeded)
+
+	// The check is satisfied once the remaining custodied column is received.
+	go func() {
+		require.NoError(t, s.ReceiveDataColumn(context.Background(), blocks.NewVerifiedRODataColumn(columns[2])))
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.isDataAvailable(ctx, blk.Root(), blk))
+
+	// Blocks without commitments are always available.
+	empty, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, slot, 0)
+	require.NoError(t, s.isDataAvailable(context.Background(), empty.Root(), empty))
+}
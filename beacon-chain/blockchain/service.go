@@ -66,7 +66,12 @@ type Service struct {
 	blobNotifiers                 *blobNotifierMap
 	blockBeingSynced              *currentlySyncingBlock
 	blobStorage                   *filesystem.BlobStorage
+	dataColumnNotifiers           *dataColumnNotifierMap
+	dataColumnStorage             *filesystem.DataColumnStorage
+	custodyColumns                map[uint64]bool
 	lastPublishedLightClientEpoch primitives.Epoch
+	lightClientUpdates            lightClientUpdates
+	lateBlockReorg                lateBlockReorg
 }
 
 // config options for the service.
@@ -177,6 +182,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
 		blobNotifiers:        bn,
+		dataColumnNotifiers:  newDataColumnNotifierMap(),
 		cfg:                  &config{},
 		blockBeingSynced:     &currentlySyncingBlock{roots: make(map[[32]byte]struct{})},
 	}
@@ -211,6 +217,9 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	if features.Get().EnableForkchoiceSnapshot {
+		go s.runForkchoiceSnapshots()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
@@ -229,6 +238,11 @@ func (s *Service) Stop() error {
 	} else {
 		s.headLock.RUnlock()
 	}
+	if features.Get().EnableForkchoiceSnapshot && s.cfg.ForkChoiceStore.NodeCount() > 0 {
+		if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+			log.WithError(err).Error("Could not save forkchoice snapshot")
+		}
+	}
 	// Save initial sync cached blocks to the DB before stop.
 	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
 }
@@ -286,9 +300,42 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 		return errNilFinalizedCheckpoint
 	}
 
-	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
+	restored := false
+	if features.Get().EnableForkchoiceSnapshot {
+		if err := s.restoreForkchoiceSnapshot(s.ctx, finalized); err != nil {
+			log.WithError(err).Warn("Could not restore forkchoice snapshot, starting from the finalized checkpoint")
+		} else {
+			restored = true
+		}
+	}
+	if !restored {
+		if err := s.initializeForkchoiceFromCheckpoints(justified, finalized); err != nil {
+			return err
+		}
+	}
+	// not attempting to save initial sync blocks here, because there shouldn't be any until
+	// after the statefeed.Initialized event is fired (below)
+	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
+		// Exit run time if the node failed to verify weak subjectivity checkpoint.
+		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
+	}
+
+	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
+	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
+		return errors.Wrap(err, "failed to initialize blockchain service")
+	}
+
+	saved.SaveValidatorIndices() // used to handle Validator index invariant from EIP6110
+
+	return nil
+}
+
+// initializeForkchoiceFromCheckpoints sets up an empty forkchoice store with the finalized block as its root.
+// This function requires a lock in forkchoice.
+func (s *Service) initializeForkchoiceFromCheckpoints(justified, finalized *ethpb.Checkpoint) error {
+	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	if err := s.cfg.ForkChoiceStore.UpdateJustifiedCheckpoint(s.ctx, &forkchoicetypes.Checkpoint{Epoch: justified.Epoch,
 		Root: bytesutil.ToBytes32(justified.Root)}); err != nil {
 		return errors.Wrap(err, "could not update forkchoice's justified checkpoint")
@@ -317,20 +364,6 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 			}
 		}
 	}
-	// not attempting to save initial sync blocks here, because there shouldn't be any until
-	// after the statefeed.Initialized event is fired (below)
-	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
-		// Exit run time if the node failed to verify weak subjectivity checkpoint.
-		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
-	}
-
-	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
-	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
-		return errors.Wrap(err, "failed to initialize blockchain service")
-	}
-
-	saved.SaveValidatorIndices() // used to handle Validator index invariant from EIP6110
-
 	return nil
 }
 
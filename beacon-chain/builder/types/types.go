@@ -0,0 +1,58 @@
+package types
+
+import (
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// BidOutcome describes what happened to the builder bid of a proposal.
+type BidOutcome uint8
+
+const (
+	// BidNotRequested means no bid was requested, because the local payload had to be used.
+	BidNotRequested BidOutcome = iota
+	// BidFailed means the builder did not return a valid bid.
+	BidFailed
+	// BidLowerValue means the bid lost against the boosted local payload value.
+	BidLowerValue
+	// BidRejected means the bid had the highest value but could not be used, for instance
+	// because its withdrawals did not match the local ones.
+	BidRejected
+	// BidAccepted means the builder payload was used for the proposal.
+	BidAccepted
+)
+
+// String returns the name of the outcome, as used in the API and metrics.
+func (o BidOutcome) String() string {
+	switch o {
+	case BidNotRequested:
+		return "not_requested"
+	case BidFailed:
+		return "failed"
+	case BidLowerValue:
+		return "lower_value"
+	case BidRejected:
+		return "rejected"
+	case BidAccepted:
+		return "accepted"
+	default:
+		return "unknown"
+	}
+}
+
+// BidRecord is the audit record of the builder bid for a block proposal, compared to the local payload.
+type BidRecord struct {
+	Slot               primitives.Slot
+	ProposerIndex      primitives.ValidatorIndex
+	Timestamp          uint64 // unix time in milliseconds at which the payload was picked
+	Outcome            BidOutcome
+	RelayPubkey        [fieldparams.BLSPubkeyLength]byte
+	BlockHash          [fieldparams.RootLength]byte
+	BuilderValue       primitives.Gwei
+	LocalValue         primitives.Gwei
+	LocalBoost         uint64 // percentage added to the local payload value
+	BuilderBoostFactor uint64 // percentage applied to the builder bid value
+	Latency            uint64 // milliseconds spent requesting the bid
+	SinceSlotStart     uint64 // milliseconds between the start of the slot and the reception of the bid
+	Error              string // reason the bid could not be obtained, if any
+}
@@ -0,0 +1,477 @@
+package light_client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ErrUnsupportedFork is returned for light client data of a fork without light client containers.
+var ErrUnsupportedFork = errors.New("light client data is only supported from Altair up to Deneb")
+
+// executionBranchDepth is the depth of the Merkle branch of the execution payload in the beacon block body.
+const executionBranchDepth = 4
+
+// IsSupportedSlot reports whether light client data with a header at the slot can be represented. The Altair,
+// Capella and Deneb light client containers are implemented, which cover the forks from Altair up to Deneb.
+// From Electra the proofs into the beacon state are deeper than the branches of these containers.
+func IsSupportedSlot(slot primitives.Slot) bool {
+	epoch := slots.ToEpoch(slot)
+	return epoch >= params.BeaconConfig().AltairForkEpoch && epoch < params.BeaconConfig().ElectraForkEpoch
+}
+
+// VersionAtSlot returns the version of the light client containers of data with a header at the slot.
+// Bellatrix did not change the light client containers, so its data uses the Altair ones.
+func VersionAtSlot(slot primitives.Slot) int {
+	epoch := slots.ToEpoch(slot)
+	switch {
+	case epoch >= params.BeaconConfig().DenebForkEpoch:
+		return version.Deneb
+	case epoch >= params.BeaconConfig().CapellaForkEpoch:
+		return version.Capella
+	default:
+		return version.Altair
+	}
+}
+
+// NewBootstrapFromBeaconState - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/light-client/full-node.md#create_light_client_bootstrap
+// The state must be the post state of the block the bootstrap is created for.
+func NewBootstrapFromBeaconState(ctx context.Context, state state.BeaconState, block interfaces.ReadOnlySignedBeaconBlock) (ethpb.LightClientBootstrap, error) {
+	// assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
+	if !IsSupportedSlot(state.Slot()) {
+		return nil, errors.Wrapf(ErrUnsupportedFork, "invalid slot %d", state.Slot())
+	}
+
+	// assert state.slot == state.latest_block_header.slot
+	header := state.LatestBlockHeader()
+	if state.Slot() != header.Slot {
+		return nil, fmt.Errorf("state slot %d not equal to latest block header slot %d", state.Slot(), header.Slot)
+	}
+
+	// header.state_root = hash_tree_root(state)
+	stateRoot, err := state.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get state root %w", err)
+	}
+	header.StateRoot = stateRoot[:]
+
+	// assert hash_tree_root(header) == hash_tree_root(block.message)
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("could not get header root %w", err)
+	}
+	blockRoot, err := block.Block().HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("could not get block root %w", err)
+	}
+	if headerRoot != blockRoot {
+		return nil, fmt.Errorf("header root %#x not equal to block root %#x", headerRoot, blockRoot)
+	}
+
+	lightClientHeader, err := BlockToLightClientHeader(block, VersionAtSlot(state.Slot()))
+	if err != nil {
+		return nil, fmt.Errorf("could not get light client header %w", err)
+	}
+	committee, err := state.CurrentSyncCommittee()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current sync committee %w", err)
+	}
+	branch, err := state.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get current sync committee proof %w", err)
+	}
+	return NewBootstrap(lightClientHeader, committee, branch)
+}
+
+// BlockToLightClientHeader - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/light-client/full-node.md#modified-block_to_light_client_header
+// The header is built with the light client containers of version v, which may be of a later fork than the block
+// when the header is the finalized header of an update. Blocks from before Capella then have an empty execution
+// payload header, and blocks from before Deneb have no blob gas.
+func BlockToLightClientHeader(block interfaces.ReadOnlySignedBeaconBlock, v int) (ethpb.LightClientHeader, error) {
+	signedHeader, err := block.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block header")
+	}
+	beacon := signedHeader.Header
+	if v < version.Capella {
+		return &ethpb.LightClientHeaderAltair{Beacon: beacon}, nil
+	}
+
+	if block.Version() < version.Capella {
+		return EmptyHeader(v, beacon), nil
+	}
+	body := block.Block().Body()
+	execution, err := body.Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload")
+	}
+	branch, err := blocks.PayloadProof(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload proof")
+	}
+	executionCapella, err := executionPayloadHeaderCapella(execution)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload header")
+	}
+	if v == version.Capella {
+		return &ethpb.LightClientHeaderCapella{
+			Beacon:          beacon,
+			Execution:       executionCapella,
+			ExecutionBranch: branch,
+		}, nil
+	}
+
+	var blobGasUsed, excessBlobGas uint64
+	if block.Version() >= version.Deneb {
+		if blobGasUsed, err = execution.BlobGasUsed(); err != nil {
+			return nil, errors.Wrap(err, "could not get blob gas used")
+		}
+		if excessBlobGas, err = execution.ExcessBlobGas(); err != nil {
+			return nil, errors.Wrap(err, "could not get excess blob gas")
+		}
+	}
+	return &ethpb.LightClientHeaderDeneb{
+		Beacon: beacon,
+		Execution: &enginev1.ExecutionPayloadHeaderDeneb{
+			ParentHash:       executionCapella.ParentHash,
+			FeeRecipient:     executionCapella.FeeRecipient,
+			StateRoot:        executionCapella.StateRoot,
+			ReceiptsRoot:     executionCapella.ReceiptsRoot,
+			LogsBloom:        executionCapella.LogsBloom,
+			PrevRandao:       executionCapella.PrevRandao,
+			BlockNumber:      executionCapella.BlockNumber,
+			GasLimit:         executionCapella.GasLimit,
+			GasUsed:          executionCapella.GasUsed,
+			Timestamp:        executionCapella.Timestamp,
+			ExtraData:        executionCapella.ExtraData,
+			BaseFeePerGas:    executionCapella.BaseFeePerGas,
+			BlockHash:        executionCapella.BlockHash,
+			TransactionsRoot: executionCapella.TransactionsRoot,
+			WithdrawalsRoot:  executionCapella.WithdrawalsRoot,
+			BlobGasUsed:      blobGasUsed,
+			ExcessBlobGas:    excessBlobGas,
+		},
+		ExecutionBranch: branch,
+	}, nil
+}
+
+// executionPayloadHeaderCapella returns the Capella fields of the execution payload header of the execution data,
+// which is the header itself in blinded blocks.
+func executionPayloadHeaderCapella(execution interfaces.ExecutionData) (*enginev1.ExecutionPayloadHeaderCapella, error) {
+	if !execution.IsBlinded() {
+		return blocks.PayloadToHeaderCapella(execution)
+	}
+	transactionsRoot, err := execution.TransactionsRoot()
+	if err != nil {
+		return nil, err
+	}
+	withdrawalsRoot, err := execution.WithdrawalsRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       bytesutil.SafeCopyBytes(execution.ParentHash()),
+		FeeRecipient:     bytesutil.SafeCopyBytes(execution.FeeRecipient()),
+		StateRoot:        bytesutil.SafeCopyBytes(execution.StateRoot()),
+		ReceiptsRoot:     bytesutil.SafeCopyBytes(execution.ReceiptsRoot()),
+		LogsBloom:        bytesutil.SafeCopyBytes(execution.LogsBloom()),
+		PrevRandao:       bytesutil.SafeCopyBytes(execution.PrevRandao()),
+		BlockNumber:      execution.BlockNumber(),
+		GasLimit:         execution.GasLimit(),
+		GasUsed:          execution.GasUsed(),
+		Timestamp:        execution.Timestamp(),
+		ExtraData:        bytesutil.SafeCopyBytes(execution.ExtraData()),
+		BaseFeePerGas:    bytesutil.SafeCopyBytes(execution.BaseFeePerGas()),
+		BlockHash:        bytesutil.SafeCopyBytes(execution.BlockHash()),
+		TransactionsRoot: bytesutil.SafeCopyBytes(transactionsRoot),
+		WithdrawalsRoot:  bytesutil.SafeCopyBytes(withdrawalsRoot),
+	}, nil
+}
+
+// EmptyHeader returns the light client header of version v with the beacon block header and an empty
+// execution payload header, as used for the headers of blocks from before Capella and for the zero valued
+// finalized header of updates without finality. A nil beacon block header is replaced with a zero valued one.
+func EmptyHeader(v int, beacon *ethpb.BeaconBlockHeader) ethpb.LightClientHeader {
+	if beacon == nil {
+		beacon = &ethpb.BeaconBlockHeader{
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		}
+	}
+	switch {
+	case v >= version.Deneb:
+		return &ethpb.LightClientHeaderDeneb{
+			Beacon: beacon,
+			Execution: &enginev1.ExecutionPayloadHeaderDeneb{
+				ParentHash:       make([]byte, fieldparams.RootLength),
+				FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+				StateRoot:        make([]byte, fieldparams.RootLength),
+				ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+				LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+				PrevRandao:       make([]byte, fieldparams.RootLength),
+				ExtraData:        make([]byte, 0),
+				BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+				BlockHash:        make([]byte, fieldparams.RootLength),
+				TransactionsRoot: make([]byte, fieldparams.RootLength),
+				WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+			},
+			ExecutionBranch: EmptyBranch(executionBranchDepth),
+		}
+	case v >= version.Capella:
+		return &ethpb.LightClientHeaderCapella{
+			Beacon: beacon,
+			Execution: &enginev1.ExecutionPayloadHeaderCapella{
+				ParentHash:       make([]byte, fieldparams.RootLength),
+				FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+				StateRoot:        make([]byte, fieldparams.RootLength),
+				ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+				LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+				PrevRandao:       make([]byte, fieldparams.RootLength),
+				ExtraData:        make([]byte, 0),
+				BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+				BlockHash:        make([]byte, fieldparams.RootLength),
+				TransactionsRoot: make([]byte, fieldparams.RootLength),
+				WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+			},
+			ExecutionBranch: EmptyBranch(executionBranchDepth),
+		}
+	default:
+		return &ethpb.LightClientHeaderAltair{Beacon: beacon}
+	}
+}
+
+// NewBootstrap returns the light client bootstrap of the version of the header.
+func NewBootstrap(header ethpb.LightClientHeader, committee *ethpb.SyncCommittee, branch [][]byte) (ethpb.LightClientBootstrap, error) {
+	switch h := header.(type) {
+	case *ethpb.LightClientHeaderAltair:
+		return &ethpb.LightClientBootstrapAltair{Header: h, CurrentSyncCommittee: committee, CurrentSyncCommitteeBranch: branch}, nil
+	case *ethpb.LightClientHeaderCapella:
+		return &ethpb.LightClientBootstrapCapella{Header: h, CurrentSyncCommittee: committee, CurrentSyncCommitteeBranch: branch}, nil
+	case *ethpb.LightClientHeaderDeneb:
+		return &ethpb.LightClientBootstrapDeneb{Header: h, CurrentSyncCommittee: committee, CurrentSyncCommitteeBranch: branch}, nil
+	default:
+		return nil, fmt.Errorf("unsupported light client header %T", header)
+	}
+}
+
+// NewUpdate returns the light client update of the version of the attested header. The finalized header
+// must be of the same version.
+func NewUpdate(
+	attestedHeader ethpb.LightClientHeader,
+	nextSyncCommittee *ethpb.SyncCommittee,
+	nextSyncCommitteeBranch [][]byte,
+	finalizedHeader ethpb.LightClientHeader,
+	finalityBranch [][]byte,
+	syncAggregate *ethpb.SyncAggregate,
+	signatureSlot primitives.Slot,
+) (ethpb.LightClientUpdate, error) {
+	if attestedHeader.Version() != finalizedHeader.Version() {
+		return nil, fmt.Errorf("finalized header version %s is not attested header version %s",
+			version.String(finalizedHeader.Version()), version.String(attestedHeader.Version()))
+	}
+	switch h := attestedHeader.(type) {
+	case *ethpb.LightClientHeaderAltair:
+		return &ethpb.LightClientUpdateAltair{
+			AttestedHeader:          h,
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*ethpb.LightClientHeaderAltair),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           signatureSlot,
+		}, nil
+	case *ethpb.LightClientHeaderCapella:
+		return &ethpb.LightClientUpdateCapella{
+			AttestedHeader:          h,
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*ethpb.LightClientHeaderCapella),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           signatureSlot,
+		}, nil
+	case *ethpb.LightClientHeaderDeneb:
+		return &ethpb.LightClientUpdateDeneb{
+			AttestedHeader:          h,
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*ethpb.LightClientHeaderDeneb),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           signatureSlot,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported light client header %T", attestedHeader)
+	}
+}
+
+// NewFinalityUpdateFromUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/full-node.md#create_light_client_finality_update
+func NewFinalityUpdateFromUpdate(update ethpb.LightClientUpdate) (ethpb.LightClientFinalityUpdate, error) {
+	switch u := update.(type) {
+	case *ethpb.LightClientUpdateAltair:
+		return &ethpb.LightClientFinalityUpdateAltair{
+			AttestedHeader:  u.AttestedHeader,
+			FinalizedHeader: u.FinalizedHeader,
+			FinalityBranch:  u.FinalityBranch,
+			SyncAggregate:   u.SyncAggregate,
+			SignatureSlot:   u.SignatureSlot,
+		}, nil
+	case *ethpb.LightClientUpdateCapella:
+		return &ethpb.LightClientFinalityUpdateCapella{
+			AttestedHeader:  u.AttestedHeader,
+			FinalizedHeader: u.FinalizedHeader,
+			FinalityBranch:  u.FinalityBranch,
+			SyncAggregate:   u.SyncAggregate,
+			SignatureSlot:   u.SignatureSlot,
+		}, nil
+	case *ethpb.LightClientUpdateDeneb:
+		return &ethpb.LightClientFinalityUpdateDeneb{
+			AttestedHeader:  u.AttestedHeader,
+			FinalizedHeader: u.FinalizedHeader,
+			FinalityBranch:  u.FinalityBranch,
+			SyncAggregate:   u.SyncAggregate,
+			SignatureSlot:   u.SignatureSlot,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported light client update %T", update)
+	}
+}
+
+// NewOptimisticUpdateFromUpdate - implements https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/full-node.md#create_light_client_optimistic_update
+func NewOptimisticUpdateFromUpdate(update ethpb.LightClientUpdate) (ethpb.LightClientOptimisticUpdate, error) {
+	switch u := update.(type) {
+	case *ethpb.LightClientUpdateAltair:
+		return &ethpb.LightClientOptimisticUpdateAltair{
+			AttestedHeader: u.AttestedHeader,
+			SyncAggregate:  u.SyncAggregate,
+			SignatureSlot:  u.SignatureSlot,
+		}, nil
+	case *ethpb.LightClientUpdateCapella:
+		return &ethpb.LightClientOptimisticUpdateCapella{
+			AttestedHeader: u.AttestedHeader,
+			SyncAggregate:  u.SyncAggregate,
+			SignatureSlot:  u.SignatureSlot,
+		}, nil
+	case *ethpb.LightClientUpdateDeneb:
+		return &ethpb.LightClientOptimisticUpdateDeneb{
+			AttestedHeader: u.AttestedHeader,
+			SyncAggregate:  u.SyncAggregate,
+			SignatureSlot:  u.SignatureSlot,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported light client update %T", update)
+	}
+}
+
+// IsBetterUpdate - implements https://github.com/ethereum/consensus-specs/blob/d70dcd9926a4bbe987f1b4e65c3e05bd029fcfb8/specs/altair/light-client/sync-protocol.md#is_better_update
+// It reports whether the new update should replace the old one as the best update of a sync committee period.
+func IsBetterUpdate(newUpdate, oldUpdate ethpb.LightClientUpdate) bool {
+	// Compare supermajority (> 2/3) sync committee participation
+	maxActiveParticipants := newUpdate.GetSyncAggregate().SyncCommitteeBits.Len()
+	newNumActiveParticipants := newUpdate.GetSyncAggregate().SyncCommitteeBits.Count()
+	oldNumActiveParticipants := oldUpdate.GetSyncAggregate().SyncCommitteeBits.Count()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Compare presence of relevant sync committee
+	newHasRelevantSyncCommittee := IsSyncCommitteeUpdate(newUpdate) &&
+		SyncCommitteePeriodAtSlot(newUpdate.AttestedHeaderVal().GetBeacon().Slot) == SyncCommitteePeriodAtSlot(newUpdate.GetSignatureSlot())
+	oldHasRelevantSyncCommittee := IsSyncCommitteeUpdate(oldUpdate) &&
+		SyncCommitteePeriodAtSlot(oldUpdate.AttestedHeaderVal().GetBeacon().Slot) == SyncCommitteePeriodAtSlot(oldUpdate.GetSignatureSlot())
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	// Compare indication of any finality
+	newHasFinality := IsFinalityUpdate(newUpdate)
+	oldHasFinality := IsFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// Compare sync committee finality
+	if newHasFinality {
+		newHasSyncCommitteeFinality := SyncCommitteePeriodAtSlot(newUpdate.FinalizedHeaderVal().GetBeacon().Slot) == SyncCommitteePeriodAtSlot(newUpdate.AttestedHeaderVal().GetBeacon().Slot)
+		oldHasSyncCommitteeFinality := SyncCommitteePeriodAtSlot(oldUpdate.FinalizedHeaderVal().GetBeacon().Slot) == SyncCommitteePeriodAtSlot(oldUpdate.AttestedHeaderVal().GetBeacon().Slot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Tiebreaker 2: Prefer older data (fewer changes to best)
+	if newUpdate.AttestedHeaderVal().GetBeacon().Slot != oldUpdate.AttestedHeaderVal().GetBeacon().Slot {
+		return newUpdate.AttestedHeaderVal().GetBeacon().Slot < oldUpdate.AttestedHeaderVal().GetBeacon().Slot
+	}
+	return newUpdate.GetSignatureSlot() < oldUpdate.GetSignatureSlot()
+}
+
+// IsSyncCommitteeUpdate reports whether the update carries the next sync committee.
+func IsSyncCommitteeUpdate(update ethpb.LightClientUpdate) bool {
+	return !IsZeroBranch(update.GetNextSyncCommitteeBranch())
+}
+
+// IsFinalityUpdate reports whether the update carries a finalized header.
+func IsFinalityUpdate(update ethpb.LightClientUpdate) bool {
+	return !IsZeroBranch(update.GetFinalityBranch())
+}
+
+// IsZeroBranch reports whether all the nodes of the Merkle branch are zero.
+func IsZeroBranch(branch [][]byte) bool {
+	for _, b := range branch {
+		if !bytes.Equal(b, params.BeaconConfig().ZeroHash[:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncCommitteePeriodAtSlot returns the sync committee period of the slot.
+func SyncCommitteePeriodAtSlot(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+// EmptySyncCommittee returns the zero valued sync committee used in updates without a next sync committee.
+func EmptySyncCommittee() *ethpb.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpb.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+// EmptyBranch returns a zero Merkle branch of the given depth.
+func EmptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
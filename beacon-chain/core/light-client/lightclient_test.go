@@ -0,0 +1,306 @@
+package light_client_test
+
+import (
+	"context"
+	"testing"
+
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestLightClient_NewBootstrapFromBeaconState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("altair", func(t *testing.T) {
+		slot := primitives.Slot(params.BeaconConfig().AltairForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
+		st, err := util.NewBeaconStateCapella()
+		require.NoError(t, err)
+		block := util.NewBeaconBlockCapella()
+		block.Block.Slot = slot
+		signed := signedBlockWithState(t, ctx, st, block)
+
+		bootstrap, err := lightClient.NewBootstrapFromBeaconState(ctx, st, signed)
+		require.NoError(t, err)
+		require.Equal(t, version.Altair, bootstrap.Version())
+		headerRoot, err := bootstrap.HeaderVal().GetBeacon().HashTreeRoot()
+		require.NoError(t, err)
+		blockRoot, err := signed.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, blockRoot, headerRoot, "Bootstrap header root is not the block root")
+		committee, err := st.CurrentSyncCommittee()
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, committee, bootstrap.GetCurrentSyncCommittee(), "Current sync committee is not equal")
+
+		require.NoError(t, st.SetSlot(st.Slot()+1))
+		_, err = lightClient.NewBootstrapFromBeaconState(ctx, st, signed)
+		require.ErrorContains(t, "not equal to latest block header slot", err)
+	})
+	t.Run("deneb", func(t *testing.T) {
+		slot := primitives.Slot(params.BeaconConfig().DenebForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch)).Add(1)
+		st, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		block := util.NewBeaconBlockDeneb()
+		block.Block.Slot = slot
+		block.Block.Body.ExecutionPayload.BlockNumber = 7
+		block.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{7}, 32)
+		signed := signedBlockWithState(t, ctx, st, block)
+
+		bootstrap, err := lightClient.NewBootstrapFromBeaconState(ctx, st, signed)
+		require.NoError(t, err)
+		deneb, ok := bootstrap.(*ethpb.LightClientBootstrapDeneb)
+		require.Equal(t, true, ok, "Bootstrap is not a Deneb bootstrap")
+		require.Equal(t, uint64(7), deneb.Header.Execution.BlockNumber)
+		require.DeepEqual(t, block.Block.Body.ExecutionPayload.BlockHash, deneb.Header.Execution.BlockHash)
+
+		// A block that is not the latest block of the state is rejected.
+		other := util.NewBeaconBlockDeneb()
+		other.Block.Slot = slot
+		otherSigned, err := blocks.NewSignedBeaconBlock(other)
+		require.NoError(t, err)
+		_, err = lightClient.NewBootstrapFromBeaconState(ctx, st, otherSigned)
+		require.ErrorContains(t, "not equal to block root", err)
+	})
+	t.Run("electra", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconConfig().Copy()
+		cfg.ElectraForkEpoch = cfg.DenebForkEpoch + 1
+		params.OverrideBeaconConfig(cfg)
+		slot := primitives.Slot(params.BeaconConfig().ElectraForkEpoch * primitives.Epoch(params.BeaconConfig().SlotsPerEpoch))
+		st, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slot))
+		block := util.NewBeaconBlockDeneb()
+		signed, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+		_, err = lightClient.NewBootstrapFromBeaconState(ctx, st, signed)
+		require.ErrorIs(t, err, lightClient.ErrUnsupportedFork)
+	})
+}
+
+// signedBlockWithState makes the block the latest block of the state at the block slot, and returns the block
+// with the state root of the state.
+func signedBlockWithState(t *testing.T, ctx context.Context, st state.BeaconState, block interface{}) interfaces.ReadOnlySignedBeaconBlock {
+	signed, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(signed.Block().Slot()))
+	h, err := signed.Header()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(h.Header))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	signed.SetStateRoot(stateRoot[:])
+	return signed
+}
+
+func TestLightClient_BlockToLightClientHeader(t *testing.T) {
+	t.Run("deneb block", func(t *testing.T) {
+		block := util.NewBeaconBlockDeneb()
+		block.Block.Body.ExecutionPayload.BlockNumber = 9
+		block.Block.Body.BlobKzgCommitments = [][]byte{make([]byte, 48)}
+		signed, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+
+		header, err := lightClient.BlockToLightClientHeader(signed, version.Deneb)
+		require.NoError(t, err)
+		deneb, ok := header.(*ethpb.LightClientHeaderDeneb)
+		require.Equal(t, true, ok, "Header is not a Deneb header")
+		require.Equal(t, uint64(9), deneb.Execution.BlockNumber)
+
+		payloadRoot, err := deneb.Execution.HashTreeRoot()
+		require.NoError(t, err)
+		execution, err := signed.Block().Body().Execution()
+		require.NoError(t, err)
+		executionRoot, err := execution.HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, executionRoot, payloadRoot, "Execution payload header root is not the payload root")
+		bodyRoot, err := signed.Block().Body().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, trie.VerifyMerkleProof(bodyRoot[:], payloadRoot[:], 9, deneb.ExecutionBranch))
+	})
+	t.Run("blinded capella block", func(t *testing.T) {
+		block := util.HydrateSignedBlindedBeaconBlockCapella(&ethpb.SignedBlindedBeaconBlockCapella{})
+		block.Block.Body.ExecutionPayloadHeader.BlockNumber = 10
+		signed, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+
+		header, err := lightClient.BlockToLightClientHeader(signed, version.Capella)
+		require.NoError(t, err)
+		capella, ok := header.(*ethpb.LightClientHeaderCapella)
+		require.Equal(t, true, ok, "Header is not a Capella header")
+		require.DeepEqual(t, block.Block.Body.ExecutionPayloadHeader, capella.Execution)
+		payloadRoot, err := capella.Execution.HashTreeRoot()
+		require.NoError(t, err)
+		bodyRoot, err := signed.Block().Body().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, trie.VerifyMerkleProof(bodyRoot[:], payloadRoot[:], 9, capella.ExecutionBranch))
+	})
+	t.Run("capella block in deneb header", func(t *testing.T) {
+		block := util.NewBeaconBlockCapella()
+		block.Block.Body.ExecutionPayload.BlockNumber = 11
+		signed, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+
+		header, err := lightClient.BlockToLightClientHeader(signed, version.Deneb)
+		require.NoError(t, err)
+		deneb, ok := header.(*ethpb.LightClientHeaderDeneb)
+		require.Equal(t, true, ok, "Header is not a Deneb header")
+		require.Equal(t, uint64(11), deneb.Execution.BlockNumber)
+		require.Equal(t, uint64(0), deneb.Execution.BlobGasUsed)
+		require.Equal(t, false, lightClient.IsZeroBranch(deneb.ExecutionBranch))
+	})
+	t.Run("bellatrix block in capella header", func(t *testing.T) {
+		block := util.NewBeaconBlockBellatrix()
+		block.Block.Body.ExecutionPayload.BlockNumber = 12
+		signed, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+
+		header, err := lightClient.BlockToLightClientHeader(signed, version.Capella)
+		require.NoError(t, err)
+		capella, ok := header.(*ethpb.LightClientHeaderCapella)
+		require.Equal(t, true, ok, "Header is not a Capella header")
+		require.Equal(t, uint64(0), capella.Execution.BlockNumber)
+		require.Equal(t, true, lightClient.IsZeroBranch(capella.ExecutionBranch))
+		_, err = capella.MarshalSSZ()
+		require.NoError(t, err)
+	})
+	t.Run("altair header", func(t *testing.T) {
+		signed, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockDeneb())
+		require.NoError(t, err)
+		header, err := lightClient.BlockToLightClientHeader(signed, version.Altair)
+		require.NoError(t, err)
+		require.Equal(t, version.Altair, header.Version())
+	})
+}
+
+func TestLightClient_NewUpdate(t *testing.T) {
+	attested := util.HydrateLightClientHeaderCapella(&ethpb.LightClientHeaderCapella{})
+	finalized := util.HydrateLightClientHeaderCapella(&ethpb.LightClientHeaderCapella{})
+	committee := lightClient.EmptySyncCommittee()
+	aggregate := util.HydrateLightClientOptimisticUpdate(&ethpb.LightClientOptimisticUpdateAltair{}).SyncAggregate
+
+	update, err := lightClient.NewUpdate(attested, committee, lightClient.EmptyBranch(fieldparams.NextSyncCommitteeBranchDepth),
+		finalized, lightClient.EmptyBranch(6), aggregate, 12)
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, update.Version())
+	require.Equal(t, primitives.Slot(12), update.GetSignatureSlot())
+
+	finality, err := lightClient.NewFinalityUpdateFromUpdate(update)
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, finality.Version())
+	optimistic, err := lightClient.NewOptimisticUpdateFromUpdate(update)
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, optimistic.Version())
+	_, err = optimistic.MarshalSSZ()
+	require.NoError(t, err)
+
+	_, err = lightClient.NewUpdate(attested, committee, lightClient.EmptyBranch(fieldparams.NextSyncCommitteeBranchDepth),
+		lightClient.EmptyHeader(version.Deneb, nil), lightClient.EmptyBranch(6), aggregate, 12)
+	require.ErrorContains(t, "is not attested header version", err)
+}
+
+func TestLightClient_IsBetterUpdate(t *testing.T) {
+	nonZeroBranch := func(depth int) [][]byte {
+		branch := make([][]byte, depth)
+		for i := range branch {
+			branch[i] = bytesutil.PadTo([]byte{1}, 32)
+		}
+		return branch
+	}
+	newUpdate := func(participants uint64, attestedSlot, finalizedSlot, signatureSlot primitives.Slot, syncCommittee, finality bool) ethpb.LightClientUpdate {
+		u := util.HydrateLightClientUpdate(&ethpb.LightClientUpdateAltair{
+			AttestedHeader:  &ethpb.LightClientHeaderAltair{Beacon: &ethpb.BeaconBlockHeader{Slot: attestedSlot}},
+			FinalizedHeader: &ethpb.LightClientHeaderAltair{Beacon: &ethpb.BeaconBlockHeader{Slot: finalizedSlot}},
+			SignatureSlot:   signatureSlot,
+		})
+		for i := uint64(0); i < participants; i++ {
+			u.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+		}
+		if syncCommittee {
+			u.NextSyncCommitteeBranch = nonZeroBranch(fieldparams.NextSyncCommitteeBranchDepth)
+		}
+		if finality {
+			u.FinalityBranch = nonZeroBranch(len(u.FinalityBranch))
+		}
+		return u
+	}
+	size := uint64(fieldparams.SyncCommitteeLength)
+	periodSlots := primitives.Slot(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * uint64(params.BeaconConfig().SlotsPerEpoch))
+
+	tests := []struct {
+		name      string
+		newUpdate ethpb.LightClientUpdate
+		oldUpdate ethpb.LightClientUpdate
+		want      bool
+	}{
+		{
+			name:      "supermajority wins",
+			newUpdate: newUpdate(size*2/3+1, 10, 0, 11, false, false),
+			oldUpdate: newUpdate(size/2, 10, 0, 11, true, true),
+			want:      true,
+		},
+		{
+			name:      "more participants without supermajority",
+			newUpdate: newUpdate(size/3, 10, 0, 11, false, false),
+			oldUpdate: newUpdate(size/2, 10, 0, 11, false, false),
+			want:      false,
+		},
+		{
+			name:      "relevant sync committee",
+			newUpdate: newUpdate(size, 10, 0, 11, true, false),
+			oldUpdate: newUpdate(size, 10, 0, 11, false, true),
+			want:      true,
+		},
+		{
+			name:      "sync committee of another period is not relevant",
+			newUpdate: newUpdate(size, periodSlots-1, 0, periodSlots, true, false),
+			oldUpdate: newUpdate(size, 10, 0, 11, false, false),
+			want:      false,
+		},
+		{
+			name:      "finality",
+			newUpdate: newUpdate(size, 10, 1, 11, true, true),
+			oldUpdate: newUpdate(size, 10, 0, 11, true, false),
+			want:      true,
+		},
+		{
+			name:      "sync committee finality",
+			newUpdate: newUpdate(size, periodSlots+1, periodSlots-1, periodSlots+2, true, true),
+			oldUpdate: newUpdate(size, periodSlots+1, periodSlots, periodSlots+2, true, true),
+			want:      false,
+		},
+		{
+			name:      "more participants beyond supermajority",
+			newUpdate: newUpdate(size, 10, 1, 11, true, true),
+			oldUpdate: newUpdate(size-1, 10, 1, 11, true, true),
+			want:      true,
+		},
+		{
+			name:      "older attested header",
+			newUpdate: newUpdate(size, 9, 1, 11, true, true),
+			oldUpdate: newUpdate(size, 10, 1, 11, true, true),
+			want:      true,
+		},
+		{
+			name:      "same update",
+			newUpdate: newUpdate(size, 10, 1, 11, true, true),
+			oldUpdate: newUpdate(size, 10, 1, 11, true, true),
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, lightClient.IsBetterUpdate(tt.newUpdate, tt.oldUpdate))
+		})
+	}
+}
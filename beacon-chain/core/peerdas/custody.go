@@ -0,0 +1,142 @@
+// Package peerdas implements the EIP-7594 (PeerDAS) data availability sampling primitives: custody group
+// assignment from the node ID, data column sidecar construction and data column sidecar verification.
+package peerdas
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+)
+
+var (
+	// ErrCustodyGroupCountTooLarge is returned when a node is asked to custody more groups than exist.
+	ErrCustodyGroupCountTooLarge = errors.New("custody group count is larger than the number of custody groups")
+	// ErrCustodyGroupTooLarge is returned for a custody group index outside of the custody groups.
+	ErrCustodyGroupTooLarge = errors.New("custody group is larger than the number of custody groups")
+)
+
+var maxUint256 = new(uint256.Int).SetAllOne()
+
+// CustodyGroups returns the sorted custody groups of a node, as defined by get_custody_groups in the spec.
+//
+// Spec pseudocode definition:
+//
+//	def get_custody_groups(node_id: NodeID, custody_group_count: uint64) -> Sequence[CustodyIndex]:
+//	    assert custody_group_count <= NUMBER_OF_CUSTODY_GROUPS
+//
+//	    current_id = uint256(node_id)
+//	    custody_groups: List[CustodyIndex] = []
+//	    while len(custody_groups) < custody_group_count:
+//	        custody_group = CustodyIndex(
+//	            bytes_to_uint64(hash(uint_to_bytes(uint256(current_id)))[0:8])
+//	            % NUMBER_OF_CUSTODY_GROUPS
+//	        )
+//	        if custody_group not in custody_groups:
+//	            custody_groups.append(custody_group)
+//	        if current_id == UINT256_MAX:
+//	            # Overflow prevention
+//	            current_id = NodeID(0)
+//	        else:
+//	            current_id += 1
+//
+//	    assert len(custody_groups) == len(set(custody_groups))
+//	    return sorted(custody_groups)
+func CustodyGroups(nodeID enode.ID, custodyGroupCount uint64) ([]uint64, error) {
+	numberOfCustodyGroups := params.BeaconConfig().NumberOfCustodyGroups
+	if custodyGroupCount > numberOfCustodyGroups {
+		return nil, errors.Wrapf(ErrCustodyGroupCountTooLarge, "%d > %d", custodyGroupCount, numberOfCustodyGroups)
+	}
+
+	current := new(uint256.Int).SetBytes(nodeID.Bytes())
+	seen := make(map[uint64]bool, custodyGroupCount)
+	groups := make([]uint64, 0, custodyGroupCount)
+	one := uint256.NewInt(1)
+	for uint64(len(groups)) < custodyGroupCount {
+		// uint_to_bytes serializes the node ID as a little endian uint256.
+		be := current.Bytes32()
+		le := make([]byte, len(be))
+		for i := range be {
+			le[i] = be[len(be)-1-i]
+		}
+		h := hash.Hash(le)
+		group := binary.LittleEndian.Uint64(h[:8]) % numberOfCustodyGroups
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+		if current.Eq(maxUint256) {
+			current.Clear()
+		} else {
+			current.Add(current, one)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i] < groups[j] })
+	return groups, nil
+}
+
+// ColumnsForCustodyGroup returns the columns which belong to the given custody group.
+//
+// Spec pseudocode definition:
+//
+//	def compute_columns_for_custody_group(custody_group: CustodyIndex) -> Sequence[ColumnIndex]:
+//	    assert custody_group < NUMBER_OF_CUSTODY_GROUPS
+//	    columns_per_group = NUMBER_OF_COLUMNS // NUMBER_OF_CUSTODY_GROUPS
+//	    return sorted([
+//	        ColumnIndex(NUMBER_OF_CUSTODY_GROUPS * i + custody_group)
+//	        for i in range(columns_per_group)
+//	    ])
+func ColumnsForCustodyGroup(group uint64) ([]uint64, error) {
+	cfg := params.BeaconConfig()
+	if group >= cfg.NumberOfCustodyGroups {
+		return nil, errors.Wrapf(ErrCustodyGroupTooLarge, "%d >= %d", group, cfg.NumberOfCustodyGroups)
+	}
+	columnsPerGroup := cfg.NumberOfColumns / cfg.NumberOfCustodyGroups
+	columns := make([]uint64, 0, columnsPerGroup)
+	for i := uint64(0); i < columnsPerGroup; i++ {
+		columns = append(columns, cfg.NumberOfCustodyGroups*i+group)
+	}
+	return columns, nil
+}
+
+// CustodyColumns returns the set of columns a node with the given ID and custody group count must custody.
+func CustodyColumns(nodeID enode.ID, custodyGroupCount uint64) (map[uint64]bool, error) {
+	groups, err := CustodyGroups(nodeID, custodyGroupCount)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[uint64]bool)
+	for _, g := range groups {
+		cols, err := ColumnsForCustodyGroup(g)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cols {
+			columns[c] = true
+		}
+	}
+	return columns, nil
+}
+
+// ComputeSubnetForDataColumnSidecar returns the gossip subnet of a data column.
+//
+// Spec pseudocode definition:
+//
+//	def compute_subnet_for_data_column_sidecar(column_index: ColumnIndex) -> SubnetID:
+//	    return SubnetID(column_index % DATA_COLUMN_SIDECAR_SUBNET_COUNT)
+func ComputeSubnetForDataColumnSidecar(columnIndex uint64) uint64 {
+	return columnIndex % params.BeaconConfig().DataColumnSidecarSubnetCount
+}
+
+// CustodySubnets returns the set of data column subnets a node with the given custody columns subscribes to.
+func CustodySubnets(columns map[uint64]bool) map[uint64]bool {
+	subnets := make(map[uint64]bool)
+	for c := range columns {
+		subnets[ComputeSubnetForDataColumnSidecar(c)] = true
+	}
+	return subnets
+}
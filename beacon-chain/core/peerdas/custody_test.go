@@ -0,0 +1,73 @@
+package peerdas
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestCustodyGroups(t *testing.T) {
+	cfg := params.BeaconConfig()
+
+	groups, err := CustodyGroups(enode.ID{}, cfg.CustodyRequirement)
+	require.NoError(t, err)
+	require.Equal(t, int(cfg.CustodyRequirement), len(groups))
+	for i := 1; i < len(groups); i++ {
+		require.Equal(t, true, groups[i-1] < groups[i])
+	}
+
+	// The computation is deterministic.
+	again, err := CustodyGroups(enode.ID{}, cfg.CustodyRequirement)
+	require.NoError(t, err)
+	require.DeepEqual(t, groups, again)
+
+	// Custodying every group yields every group, even when starting from the largest node ID.
+	var maxID enode.ID
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+	all, err := CustodyGroups(maxID, cfg.NumberOfCustodyGroups)
+	require.NoError(t, err)
+	require.Equal(t, int(cfg.NumberOfCustodyGroups), len(all))
+	for i := range all {
+		require.Equal(t, uint64(i), all[i])
+	}
+
+	_, err = CustodyGroups(enode.ID{}, cfg.NumberOfCustodyGroups+1)
+	require.ErrorIs(t, err, ErrCustodyGroupCountTooLarge)
+}
+
+func TestColumnsForCustodyGroup(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.NumberOfCustodyGroups = 64
+	params.OverrideBeaconConfig(cfg)
+
+	columns, err := ColumnsForCustodyGroup(3)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{3, 67}, columns)
+
+	_, err = ColumnsForCustodyGroup(64)
+	require.ErrorIs(t, err, ErrCustodyGroupTooLarge)
+}
+
+func TestCustodyColumnsAndSubnets(t *testing.T) {
+	cfg := params.BeaconConfig()
+	nodeID := enode.ID{1, 2, 3}
+	groups, err := CustodyGroups(nodeID, cfg.CustodyRequirement)
+	require.NoError(t, err)
+	columns, err := CustodyColumns(nodeID, cfg.CustodyRequirement)
+	require.NoError(t, err)
+	require.Equal(t, len(groups)*int(cfg.NumberOfColumns/cfg.NumberOfCustodyGroups), len(columns))
+	for _, g := range groups {
+		require.Equal(t, true, columns[g])
+	}
+
+	subnets := CustodySubnets(columns)
+	for c := range columns {
+		require.Equal(t, true, subnets[c%cfg.DataColumnSidecarSubnetCount])
+	}
+	require.Equal(t, uint64(5), ComputeSubnetForDataColumnSidecar(cfg.DataColumnSidecarSubnetCount+5))
+}
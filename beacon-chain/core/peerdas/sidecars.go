@@ -0,0 +1,204 @@
+package peerdas
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+var (
+	// ErrBlobCountMismatch is returned when the number of blobs does not match the block's commitments.
+	ErrBlobCountMismatch = errors.New("number of blobs does not match the number of KZG commitments in the block")
+	// ErrInvalidColumnIndex is returned for a data column sidecar with an index outside of the extended matrix.
+	ErrInvalidColumnIndex = errors.New("data column index is larger than the number of columns")
+	// ErrNoKZGCommitments is returned for a data column sidecar without KZG commitments.
+	ErrNoKZGCommitments = errors.New("data column sidecar has no KZG commitments")
+	// ErrMismatchedColumnLengths is returned when a data column sidecar's cells, commitments and proofs differ in length.
+	ErrMismatchedColumnLengths = errors.New("data column sidecar cells, KZG commitments and KZG proofs differ in length")
+)
+
+// DataColumnSidecars builds the data column sidecars of a block from the blobs it commits to.
+//
+// Spec pseudocode definition:
+//
+//	def get_data_column_sidecars(signed_block: SignedBeaconBlock,
+//	                             cells_and_kzg_proofs: Sequence[Tuple[
+//	    Vector[Cell, CELLS_PER_EXT_BLOB],
+//	    Vector[KZGProof, CELLS_PER_EXT_BLOB]]]) -> Sequence[DataColumnSidecar]:
+//	    blob_kzg_commitments = signed_block.message.body.blob_kzg_commitments
+//	    assert len(cells_and_kzg_proofs) == len(blob_kzg_commitments)
+//	    signed_block_header = compute_signed_block_header(signed_block)
+//	    kzg_commitments_inclusion_proof = compute_merkle_proof(
+//	        signed_block.message.body,
+//	        get_generalized_index(BeaconBlockBody, 'blob_kzg_commitments'),
+//	    )
+//
+//	    sidecars = []
+//	    for column_index in range(NUMBER_OF_COLUMNS):
+//	        column_cells, column_proofs = [], []
+//	        for cells, proofs in cells_and_kzg_proofs:
+//	            column_cells.append(cells[column_index])
+//	            column_proofs.append(proofs[column_index])
+//	        sidecars.append(DataColumnSidecar(
+//	            index=column_index,
+//	            column=column_cells,
+//	            kzg_commitments=blob_kzg_commitments,
+//	            kzg_proofs=column_proofs,
+//	            signed_block_header=signed_block_header,
+//	            kzg_commitments_inclusion_proof=kzg_commitments_inclusion_proof,
+//	        ))
+//	    return sidecars
+func DataColumnSidecars(signed interfaces.ReadOnlySignedBeaconBlock, blobs [][]byte) ([]*ethpb.DataColumnSidecar, error) {
+	if err := blocks.BeaconBlockIsNil(signed); err != nil {
+		return nil, err
+	}
+	body := signed.Block().Body()
+	commitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return nil, err
+	}
+	if len(blobs) != len(commitments) {
+		return nil, errors.Wrapf(ErrBlobCountMismatch, "%d blobs, %d commitments", len(blobs), len(commitments))
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	header, err := signed.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signed block header")
+	}
+	proof, err := blocks.MerkleProofKZGCommitments(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute KZG commitments inclusion proof")
+	}
+
+	numberOfColumns := params.BeaconConfig().NumberOfColumns
+	cells := make([][][]byte, len(blobs))
+	proofs := make([][][]byte, len(blobs))
+	for i := range blobs {
+		cells[i], proofs[i], err = kzg.ComputeCellsAndKZGProofs(blobs[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute cells of blob %d", i)
+		}
+	}
+
+	sidecars := make([]*ethpb.DataColumnSidecar, numberOfColumns)
+	for c := uint64(0); c < numberOfColumns; c++ {
+		column := make([][]byte, len(blobs))
+		columnProofs := make([][]byte, len(blobs))
+		for i := range blobs {
+			column[i] = cells[i][c]
+			columnProofs[i] = proofs[i][c]
+		}
+		sidecars[c] = &ethpb.DataColumnSidecar{
+			Index:                        c,
+			Column:                       column,
+			KzgCommitments:               commitments,
+			KzgProofs:                    columnProofs,
+			SignedBlockHeader:            header,
+			KzgCommitmentsInclusionProof: proof,
+		}
+	}
+	return sidecars, nil
+}
+
+// VerifyDataColumnSidecar performs the structural checks of a data column sidecar.
+//
+// Spec pseudocode definition:
+//
+//	def verify_data_column_sidecar(sidecar: DataColumnSidecar) -> bool:
+//	    # The sidecar index must be within the valid range
+//	    if sidecar.index >= NUMBER_OF_COLUMNS:
+//	        return False
+//	    # A sidecar for zero blobs is invalid
+//	    if len(sidecar.kzg_commitments) == 0:
+//	        return False
+//	    # The column length must be equal to the number of commitments/proofs
+//	    if len(sidecar.column) != len(sidecar.kzg_commitments) or len(sidecar.column) != len(sidecar.kzg_proofs):
+//	        return False
+//	    return True
+func VerifyDataColumnSidecar(sidecar blocks.RODataColumn) error {
+	if sidecar.Index >= params.BeaconConfig().NumberOfColumns {
+		return errors.Wrapf(ErrInvalidColumnIndex, "index %d", sidecar.Index)
+	}
+	if len(sidecar.KzgCommitments) == 0 {
+		return ErrNoKZGCommitments
+	}
+	if len(sidecar.Column) != len(sidecar.KzgCommitments) || len(sidecar.Column) != len(sidecar.KzgProofs) {
+		return ErrMismatchedColumnLengths
+	}
+	return nil
+}
+
+// VerifyDataColumnsSidecarKZGProofs verifies the cell proofs of any number of data column sidecars in a single batch.
+//
+// Spec pseudocode definition:
+//
+//	def verify_data_column_sidecar_kzg_proofs(sidecar: DataColumnSidecar) -> bool:
+//	    # The column index also represents the cell index
+//	    cell_indices = [CellIndex(sidecar.index)] * len(sidecar.column)
+//
+//	    # Batch verify that the cells match the corresponding commitments and proofs
+//	    return verify_cell_kzg_proof_batch(
+//	        commitments_bytes=sidecar.kzg_commitments,
+//	        cell_indices=cell_indices,
+//	        cells=sidecar.column,
+//	        proofs_bytes=sidecar.kzg_proofs,
+//	    )
+func VerifyDataColumnsSidecarKZGProofs(sidecars []blocks.RODataColumn) error {
+	var commitments, cells, proofs [][]byte
+	var indices []uint64
+	for _, sc := range sidecars {
+		if err := VerifyDataColumnSidecar(sc); err != nil {
+			return err
+		}
+		for i := range sc.Column {
+			commitments = append(commitments, sc.KzgCommitments[i])
+			indices = append(indices, sc.Index)
+			cells = append(cells, sc.Column[i])
+			proofs = append(proofs, sc.KzgProofs[i])
+		}
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+	return kzg.VerifyCellKZGProofBatch(commitments, indices, cells, proofs)
+}
+
+// RecoverBlobs reconstructs every blob of a block from at least half of its data columns. The columns must all belong
+// to the same block and must have been verified.
+func RecoverBlobs(columns []blocks.RODataColumn) ([][]byte, error) {
+	numberOfColumns := params.BeaconConfig().NumberOfColumns
+	if uint64(len(columns))*2 < numberOfColumns {
+		return nil, errors.Errorf("%d columns are not enough to recover the blobs, need %d", len(columns), (numberOfColumns+1)/2)
+	}
+	blobCount := len(columns[0].Column)
+	indices := make([]uint64, len(columns))
+	for i := range columns {
+		if len(columns[i].Column) != blobCount {
+			return nil, ErrMismatchedColumnLengths
+		}
+		indices[i] = columns[i].Index
+	}
+	blobs := make([][]byte, blobCount)
+	for b := 0; b < blobCount; b++ {
+		cells := make([][]byte, len(columns))
+		for i := range columns {
+			cells[i] = columns[i].Column[b]
+		}
+		extended, _, err := kzg.RecoverCellsAndKZGProofs(indices, cells)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not recover blob %d", b)
+		}
+		// The first half of the extended blob's cells is the original blob.
+		blob := make([]byte, 0, len(extended)/2*len(extended[0]))
+		for _, cell := range extended[:len(extended)/2] {
+			blob = append(blob, cell...)
+		}
+		blobs[b] = blob
+	}
+	return blobs, nil
+}
@@ -0,0 +1,75 @@
+package peerdas
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func testBlob(seed byte) []byte {
+	blob := make([]byte, 131072)
+	// Only set the low order byte of each big endian field element, so that every element is canonical.
+	for i := 31; i < len(blob); i += 32 {
+		blob[i] = seed + byte(i/32)
+	}
+	return blob
+}
+
+func TestDataColumnSidecars(t *testing.T) {
+	require.NoError(t, kzg.Start())
+	blk, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+
+	_, err := DataColumnSidecars(blk, [][]byte{testBlob(1)})
+	require.ErrorIs(t, err, ErrBlobCountMismatch)
+
+	blobs := [][]byte{testBlob(1), testBlob(2)}
+	sidecars, err := DataColumnSidecars(blk, blobs)
+	require.NoError(t, err)
+	require.Equal(t, int(params.BeaconConfig().NumberOfColumns), len(sidecars))
+
+	columns := make([]blocks.RODataColumn, 0, len(sidecars)/2)
+	for i, sc := range sidecars {
+		require.Equal(t, uint64(i), sc.Index)
+		ro, err := blocks.NewRODataColumn(sc)
+		require.NoError(t, err)
+		require.Equal(t, blk.Root(), ro.BlockRoot())
+		require.NoError(t, VerifyDataColumnSidecar(ro))
+		require.NoError(t, blocks.VerifyKZGCommitmentsInclusionProof(ro))
+		// Keep every other column, which is exactly enough to recover the blobs.
+		if i%2 == 1 {
+			columns = append(columns, ro)
+		}
+	}
+
+	recovered, err := RecoverBlobs(columns)
+	require.NoError(t, err)
+	require.DeepEqual(t, blobs, recovered)
+
+	_, err = RecoverBlobs(columns[1:])
+	require.NotNil(t, err)
+}
+
+func TestVerifyDataColumnSidecar(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	require.NoError(t, VerifyDataColumnSidecar(columns[0]))
+
+	col := columns[1]
+	col.Index = params.BeaconConfig().NumberOfColumns
+	require.ErrorIs(t, VerifyDataColumnSidecar(col), ErrInvalidColumnIndex)
+
+	col = columns[2]
+	col.KzgProofs = col.KzgProofs[:1]
+	require.ErrorIs(t, VerifyDataColumnSidecar(col), ErrMismatchedColumnLengths)
+
+	col = columns[3]
+	col.KzgCommitments = nil
+	require.ErrorIs(t, VerifyDataColumnSidecar(col), ErrNoKZGCommitments)
+
+	// The placeholder cells of the test columns do not match their commitments.
+	require.NoError(t, kzg.Start())
+	require.NotNil(t, VerifyDataColumnsSidecarKZGProofs(columns[4:6]))
+}
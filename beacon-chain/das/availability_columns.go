@@ -0,0 +1,148 @@
+package das
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errMixedColumnRoots = errors.New("DataColumnSidecars must all be for the same block")
+	errNoBlobStore      = errors.New("no blob AvailabilityStore to check a block from before the EIP-7594 fork")
+)
+
+// LazilyPersistentStoreColumn is the data column counterpart of LazilyPersistentStore. Blocks from the EIP-7594 fork
+// onwards are considered available once every column the node custodies has been verified and saved; earlier
+// blocks are handed to the wrapped blob AvailabilityStore.
+type LazilyPersistentStoreColumn struct {
+	store    *filesystem.DataColumnStorage
+	cache    *columnCache
+	verifier ColumnBatchVerifier
+	custody  map[uint64]bool
+	blobs    AvailabilityStore
+}
+
+var _ AvailabilityStore = &LazilyPersistentStoreColumn{}
+
+// ColumnBatchVerifier enables LazilyPersistentStoreColumn to manage the verification process going from
+// RODataColumn->VerifiedRODataColumn. Like BlobBatchVerifier, it takes all the columns of a block at once so
+// that the implementation can batch the cell proof verification.
+type ColumnBatchVerifier interface {
+	VerifiedRODataColumns(ctx context.Context, blk blocks.ROBlock, sc []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error)
+}
+
+// NewLazilyPersistentStoreColumn creates a new LazilyPersistentStoreColumn. custody is the set of column indices
+// the node must have for a block to be available, and blobs is used for blocks from before the EIP-7594 fork.
+func NewLazilyPersistentStoreColumn(store *filesystem.DataColumnStorage, verifier ColumnBatchVerifier, custody map[uint64]bool, blobs AvailabilityStore) *LazilyPersistentStoreColumn {
+	return &LazilyPersistentStoreColumn{
+		store:    store,
+		cache:    newColumnCache(),
+		verifier: verifier,
+		custody:  custody,
+		blobs:    blobs,
+	}
+}
+
+// Persist hands blob sidecars to the wrapped blob AvailabilityStore.
+func (s *LazilyPersistentStoreColumn) Persist(current primitives.Slot, sc ...blocks.ROBlob) error {
+	if s.blobs == nil {
+		return errNoBlobStore
+	}
+	return s.blobs.Persist(current, sc...)
+}
+
+// PersistColumns adds data columns to the working column cache. Columns stored in this cache will be persisted
+// for at least as long as the node is running. Once IsDataAvailable succeeds, all the custodied columns of the
+// given block are guaranteed to be persisted for the remainder of the retention period.
+func (s *LazilyPersistentStoreColumn) PersistColumns(current primitives.Slot, sc ...blocks.RODataColumn) error {
+	if len(sc) == 0 {
+		return nil
+	}
+	first := sc[0].BlockRoot()
+	for i := 1; i < len(sc); i++ {
+		if first != sc[i].BlockRoot() {
+			return errMixedColumnRoots
+		}
+	}
+	if !params.WithinColumnDAPeriod(slots.ToEpoch(sc[0].Slot()), slots.ToEpoch(current)) {
+		return nil
+	}
+	entry := s.cache.ensure(cacheKey{slot: sc[0].Slot(), root: first})
+	for i := range sc {
+		if !s.custody[sc[i].Index] {
+			continue
+		}
+		if err := entry.stash(&sc[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsDataAvailable returns nil if all the custodied columns of the given block are persisted to the db and have
+// been verified. DataColumnSidecars already in the db are assumed to have been previously verified against the block.
+func (s *LazilyPersistentStoreColumn) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if b.Version() < version.Deneb {
+		return nil
+	}
+	blockEpoch := slots.ToEpoch(b.Block().Slot())
+	if !params.PeerDASEnabled(blockEpoch) {
+		if s.blobs == nil {
+			return errNoBlobStore
+		}
+		return s.blobs.IsDataAvailable(ctx, current, b)
+	}
+	// We are only required to check within MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS.
+	if !params.WithinColumnDAPeriod(blockEpoch, slots.ToEpoch(current)) {
+		return nil
+	}
+	commitments, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrapf(err, "could check data availability for block %#x", b.Root())
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	key := keyFromBlock(b)
+	entry := s.cache.ensure(key)
+	defer s.cache.delete(key)
+	root := b.Root()
+	sumz, err := s.store.WaitForSummarizer(ctx)
+	if err != nil {
+		log.WithField("root", fmt.Sprintf("%#x", root)).
+			WithError(err).
+			Debug("Failed to receive DataColumnStorageSummarizer within IsDataAvailable")
+	} else {
+		entry.setDiskSummary(sumz.Summary(root))
+	}
+
+	sidecars, err := entry.filter(root, commitments, s.custody)
+	if err != nil {
+		return errors.Wrap(err, "incomplete DataColumnSidecar batch")
+	}
+	vscs, err := s.verifier.VerifiedRODataColumns(ctx, b, sidecars)
+	if err != nil {
+		if len(sidecars) > 0 {
+			log.WithFields(logging.BlockFieldsFromColumn(sidecars[0])).WithError(err).
+				Debug("invalid DataColumnSidecars received")
+		}
+		return errors.Wrapf(err, "invalid DataColumnSidecars received for block %#x", root)
+	}
+	for i := range vscs {
+		if err := s.store.Save(vscs[i]); err != nil {
+			return errors.Wrapf(err, "failed to save DataColumnSidecar index %d for block %#x", vscs[i].Index, root)
+		}
+	}
+	// All custodied DataColumnSidecars are persisted - da check succeeds.
+	return nil
+}
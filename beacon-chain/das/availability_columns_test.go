@@ -0,0 +1,80 @@
+package das
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestLazilyPersistentStoreColumn(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	slot := params.BeaconConfig().SlotsPerEpoch + 1
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, slot, 2)
+	custody := map[uint64]bool{1: true, 64: true, 127: true}
+
+	t.Run("missing custodied column", func(t *testing.T) {
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &MockColumnBatchVerifier{}, custody, nil)
+		require.NoError(t, as.PersistColumns(slot, columns[1], columns[64]))
+		require.ErrorIs(t, as.IsDataAvailable(ctx, slot, blk), errMissingColumn)
+	})
+
+	t.Run("all custodied columns saved", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		as := NewLazilyPersistentStoreColumn(store, &MockColumnBatchVerifier{}, custody, nil)
+		// Columns outside of custody are ignored.
+		require.NoError(t, as.PersistColumns(slot, columns...))
+		require.NoError(t, as.IsDataAvailable(ctx, slot, blk))
+		sum := store.Summary(blk.Root())
+		require.Equal(t, len(custody), sum.Count())
+		for c := range custody {
+			require.Equal(t, true, sum.HasIndex(c))
+		}
+		// Saved columns satisfy the check without being persisted again.
+		require.NoError(t, as.IsDataAvailable(ctx, slot, blk))
+	})
+
+	t.Run("verification failure", func(t *testing.T) {
+		errVerify := errors.New("invalid")
+		v := &MockColumnBatchVerifier{VerifyCallback: func(context.Context, blocks.ROBlock, []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+			return nil, errVerify
+		}}
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), v, custody, nil)
+		require.NoError(t, as.PersistColumns(slot, columns...))
+		require.ErrorIs(t, as.IsDataAvailable(ctx, slot, blk), errVerify)
+	})
+
+	t.Run("duplicates and mixed roots", func(t *testing.T) {
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &MockColumnBatchVerifier{}, custody, nil)
+		require.ErrorIs(t, as.PersistColumns(slot, columns[1], columns[1]), ErrDuplicateSidecar)
+		_, others := util.GenerateTestDenebBlockWithColumns(t, [32]byte{1}, slot, 1)
+		require.ErrorIs(t, as.PersistColumns(slot, columns[1], others[64]), errMixedColumnRoots)
+	})
+
+	t.Run("pre-PeerDAS blocks use the blob store", func(t *testing.T) {
+		pre, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 1)
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &MockColumnBatchVerifier{}, custody, nil)
+		require.ErrorIs(t, as.IsDataAvailable(ctx, 1, pre), errNoBlobStore)
+
+		called := false
+		blobs := &MockAvailabilityStore{VerifyAvailabilityCallback: func(context.Context, primitives.Slot, blocks.ROBlock) error {
+			called = true
+			return nil
+		}}
+		as = NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &MockColumnBatchVerifier{}, custody, blobs)
+		require.NoError(t, as.IsDataAvailable(ctx, 1, pre))
+		require.Equal(t, true, called)
+	})
+}
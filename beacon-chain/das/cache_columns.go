@@ -0,0 +1,94 @@
+package das
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+)
+
+var (
+	errColumnIndexOutOfBounds  = errors.New("sidecar.index >= NUMBER_OF_COLUMNS")
+	errColumnCommitmentsDiffer = errors.New("KzgCommitments of column sidecar in cache did not match block commitments")
+	errMissingColumn           = errors.New("no column sidecar in cache for custodied column")
+)
+
+type columnCache struct {
+	entries map[cacheKey]*columnCacheEntry
+}
+
+func newColumnCache() *columnCache {
+	return &columnCache{entries: make(map[cacheKey]*columnCacheEntry)}
+}
+
+// ensure returns the entry for the given key, creating it if it isn't already present.
+func (c *columnCache) ensure(key cacheKey) *columnCacheEntry {
+	e, ok := c.entries[key]
+	if !ok {
+		e = &columnCacheEntry{}
+		c.entries[key] = e
+	}
+	return e
+}
+
+// delete removes the cache entry from the cache.
+func (c *columnCache) delete(key cacheKey) {
+	delete(c.entries, key)
+}
+
+// columnCacheEntry holds a fixed-length cache of DataColumnSidecars.
+type columnCacheEntry struct {
+	scs         [fieldparams.NumberOfColumns]*blocks.RODataColumn
+	diskSummary filesystem.DataColumnStorageSummary
+}
+
+func (e *columnCacheEntry) setDiskSummary(sum filesystem.DataColumnStorageSummary) {
+	e.diskSummary = sum
+}
+
+// stash adds an item to the in-memory cache of DataColumnSidecars.
+// Only the first DataColumnSidecar of a given Index will be kept in the cache.
+func (e *columnCacheEntry) stash(sc *blocks.RODataColumn) error {
+	if sc.Index >= fieldparams.NumberOfColumns {
+		return errors.Wrapf(errColumnIndexOutOfBounds, "index=%d", sc.Index)
+	}
+	if e.scs[sc.Index] != nil {
+		return errors.Wrapf(ErrDuplicateSidecar, "root=%#x, index=%d", sc.BlockRoot(), sc.Index)
+	}
+	e.scs[sc.Index] = sc
+	return nil
+}
+
+// filter returns the custodied columns which still need to be verified and saved, excluding those already
+// available on disk. It returns an error if any custodied column is missing from the cache, or if the commitments
+// of a cached column do not match those of the block.
+func (e *columnCacheEntry) filter(root [32]byte, kc [][]byte, custody map[uint64]bool) ([]blocks.RODataColumn, error) {
+	scs := make([]blocks.RODataColumn, 0, len(custody))
+	for i := uint64(0); i < fieldparams.NumberOfColumns; i++ {
+		if !custody[i] || e.diskSummary.HasIndex(i) {
+			continue
+		}
+		if e.scs[i] == nil {
+			return nil, errors.Wrapf(errMissingColumn, "root=%#x, index=%d", root, i)
+		}
+		if !commitmentsEqual(kc, e.scs[i].KzgCommitments) {
+			return nil, errors.Wrapf(errColumnCommitmentsDiffer, "root=%#x, index=%d", root, i)
+		}
+		scs = append(scs, *e.scs[i])
+	}
+	return scs, nil
+}
+
+func commitmentsEqual(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -30,3 +30,22 @@ func (m *MockAvailabilityStore) Persist(current primitives.Slot, sc ...blocks.RO
 	}
 	return nil
 }
+
+// MockColumnBatchVerifier is an implementation of ColumnBatchVerifier that can be used by other packages in tests.
+type MockColumnBatchVerifier struct {
+	VerifyCallback func(ctx context.Context, blk blocks.ROBlock, sc []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error)
+}
+
+var _ ColumnBatchVerifier = &MockColumnBatchVerifier{}
+
+// VerifiedRODataColumns satisfies the ColumnBatchVerifier interface, laundering the columns unless a callback is set.
+func (m *MockColumnBatchVerifier) VerifiedRODataColumns(ctx context.Context, blk blocks.ROBlock, sc []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	if m.VerifyCallback != nil {
+		return m.VerifyCallback(ctx, blk, sc)
+	}
+	vs := make([]blocks.VerifiedRODataColumn, len(sc))
+	for i := range sc {
+		vs[i] = blocks.NewVerifiedRODataColumn(sc[i])
+	}
+	return vs, nil
+}
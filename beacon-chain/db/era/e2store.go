@@ -0,0 +1,90 @@
+package era
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// e2store record types used by era files.
+var (
+	typeVersion                     = [2]byte{0x65, 0x32}
+	typeCompressedSignedBeaconBlock = [2]byte{0x01, 0x00}
+	typeCompressedBeaconState       = [2]byte{0x02, 0x00}
+	typeSlotIndex                   = [2]byte{0x69, 0x32}
+)
+
+// headerSize is the size of an e2store record header: a 2 byte type, a 4 byte little endian length
+// and 2 reserved bytes.
+const headerSize = 8
+
+var errInvalidRecord = errors.New("invalid e2store record")
+
+// writeRecord writes a single e2store record and returns the number of bytes written.
+func writeRecord(w io.Writer, typ [2]byte, data []byte) (int64, error) {
+	if uint64(len(data)) > uint64(^uint32(0)) {
+		return 0, errors.Wrapf(errInvalidRecord, "record length %d exceeds the maximum length", len(data))
+	}
+	header := make([]byte, headerSize)
+	copy(header, typ[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	return int64(headerSize + len(data)), nil
+}
+
+// readRecord reads the e2store record at the given offset of an input of the given size. The record length is checked
+// against the size of the input before its data is read.
+func readRecord(r io.ReaderAt, size, offset int64) ([2]byte, []byte, error) {
+	var typ [2]byte
+	if offset < 0 {
+		return typ, nil, errors.Wrapf(errInvalidRecord, "negative record offset %d", offset)
+	}
+	header := make([]byte, headerSize)
+	if _, err := r.ReadAt(header, offset); err != nil {
+		return typ, nil, errors.Wrapf(err, "could not read record header at offset %d", offset)
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return typ, nil, errors.Wrapf(errInvalidRecord, "non-zero reserved bytes at offset %d", offset)
+	}
+	copy(typ[:], header[:2])
+	length := int64(binary.LittleEndian.Uint32(header[2:6]))
+	if length > size-offset-headerSize {
+		return typ, nil, errors.Wrapf(errInvalidRecord, "record length %d at offset %d exceeds the input size %d", length, offset, size)
+	}
+	data := make([]byte, length)
+	if _, err := r.ReadAt(data, offset+headerSize); err != nil {
+		return typ, nil, errors.Wrapf(err, "could not read record at offset %d", offset)
+	}
+	return typ, data, nil
+}
+
+// encodeSlotIndex encodes a slot index: the starting slot, one offset per slot and the number of offsets.
+func encodeSlotIndex(startSlot uint64, offsets []int64) []byte {
+	buf := make([]byte, 0, 16+8*len(offsets))
+	buf = binary.LittleEndian.AppendUint64(buf, startSlot)
+	for _, o := range offsets {
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(o))
+	}
+	return binary.LittleEndian.AppendUint64(buf, uint64(len(offsets)))
+}
+
+func decodeSlotIndex(data []byte) (uint64, []int64, error) {
+	if len(data) < 16 || len(data)%8 != 0 {
+		return 0, nil, errors.Wrapf(errInvalidRecord, "invalid slot index length %d", len(data))
+	}
+	count := binary.LittleEndian.Uint64(data[len(data)-8:])
+	if count != uint64(len(data)-16)/8 {
+		return 0, nil, errors.Wrapf(errInvalidRecord, "slot index count %d does not match its length %d", count, len(data))
+	}
+	offsets := make([]int64, count)
+	for i := range offsets {
+		offsets[i] = int64(binary.LittleEndian.Uint64(data[8+8*i:]))
+	}
+	return binary.LittleEndian.Uint64(data[:8]), offsets, nil
+}
@@ -0,0 +1,422 @@
+// Package era reads and writes era files, the e2store based archive format for finalized beacon chain history.
+// An era file covers SLOTS_PER_HISTORICAL_ROOT slots: it holds the snappy compressed SSZ encoding of every block of
+// the era and of the state at the first slot of the following era, along with slot indices to find them.
+package era
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+var (
+	// ErrBlockNotFound is returned when there is no block at the requested slot of an era.
+	ErrBlockNotFound = errors.New("no block at slot in era file")
+	// ErrSlotOutOfRange is returned when a slot is requested from an era file that does not cover it.
+	ErrSlotOutOfRange = errors.New("slot is not covered by era file")
+	// ErrBlockRootMismatch is returned when a block of an era file does not match the block roots of the era state.
+	ErrBlockRootMismatch = errors.New("block does not match the block roots of the era state")
+)
+
+// FileExtension is the extension of era file names.
+const FileExtension = ".era"
+
+// StartSlot returns the slot of the first block of the given era. Era 0 only holds the genesis state.
+func StartSlot(era uint64) primitives.Slot {
+	if era == 0 {
+		return 0
+	}
+	return primitives.Slot(era-1) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// StateSlot returns the slot of the state stored in the given era.
+func StateSlot(era uint64) primitives.Slot {
+	return primitives.Slot(era) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// ForSlot returns the era holding the block of the given slot.
+func ForSlot(slot primitives.Slot) uint64 {
+	return uint64(slot/params.BeaconConfig().SlotsPerHistoricalRoot) + 1
+}
+
+// FileName returns the standard file name of an era file: the config name, the era number and the first 4 bytes
+// of the last historical root of the era state, or of the genesis validators root for era 0.
+func FileName(era uint64, st state.ReadOnlyBeaconState) (string, error) {
+	root, err := shortHistoricalRoot(era, st)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%05d-%x%s", params.BeaconConfig().ConfigName, era, root, FileExtension), nil
+}
+
+func shortHistoricalRoot(era uint64, st state.ReadOnlyBeaconState) ([]byte, error) {
+	if era == 0 {
+		return st.GenesisValidatorsRoot()[:4], nil
+	}
+	if st.Version() >= version.Capella {
+		summaries, err := st.HistoricalSummaries()
+		if err != nil {
+			return nil, err
+		}
+		if len(summaries) > 0 {
+			root, err := summaries[len(summaries)-1].HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			return root[:4], nil
+		}
+	}
+	roots, err := st.HistoricalRoots()
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("era state has no historical roots")
+	}
+	return roots[len(roots)-1][:4], nil
+}
+
+// ListFiles returns the paths of the era files of the current network config in the given directory, by era number.
+func ListFiles(dir string) (map[uint64]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read era directory %s", dir)
+	}
+	prefix := params.BeaconConfig().ConfigName + "-"
+	files := make(map[uint64]string)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, FileExtension) {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, prefix), FileExtension), "-")
+		if len(parts) != 2 {
+			continue
+		}
+		era, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		files[era] = filepath.Join(dir, name)
+	}
+	return files, nil
+}
+
+// Write writes an era file holding the given blocks, sorted by slot, and the era state. The era is derived from
+// the slot of the state, which must be the first slot of an era.
+func Write(w io.Writer, blks []interfaces.ReadOnlySignedBeaconBlock, st state.ReadOnlyBeaconState) error {
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+	if st.Slot()%sphr != 0 {
+		return errors.Errorf("state slot %d is not the first slot of an era", st.Slot())
+	}
+	era := uint64(st.Slot() / sphr)
+	start := StartSlot(era)
+	if era == 0 && len(blks) > 0 {
+		return errors.New("era 0 only holds the genesis state")
+	}
+
+	var pos int64
+	n, err := writeRecord(w, typeVersion, nil)
+	if err != nil {
+		return err
+	}
+	pos += n
+
+	blockOffsets := make([]int64, sphr)
+	for i, b := range blks {
+		slot := b.Block().Slot()
+		if slot < start || slot >= st.Slot() {
+			return errors.Wrapf(ErrSlotOutOfRange, "block slot %d, era %d", slot, era)
+		}
+		if i > 0 && slot <= blks[i-1].Block().Slot() {
+			return errors.Errorf("blocks are not sorted by slot: %d follows %d", slot, blks[i-1].Block().Slot())
+		}
+		if b.IsBlinded() {
+			return errors.Errorf("block at slot %d is blinded", slot)
+		}
+		enc, err := b.MarshalSSZ()
+		if err != nil {
+			return errors.Wrapf(err, "could not encode block at slot %d", slot)
+		}
+		data, err := compress(enc)
+		if err != nil {
+			return err
+		}
+		blockOffsets[slot-start] = pos
+		n, err := writeRecord(w, typeCompressedSignedBeaconBlock, data)
+		if err != nil {
+			return err
+		}
+		pos += n
+	}
+
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not encode era state")
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	statePos := pos
+	n, err = writeRecord(w, typeCompressedBeaconState, data)
+	if err != nil {
+		return err
+	}
+	pos += n
+
+	// Offsets are relative to the start of the index record, with 0 marking empty slots.
+	if era > 0 {
+		for i := range blockOffsets {
+			if blockOffsets[i] != 0 {
+				blockOffsets[i] -= pos
+			}
+		}
+		n, err = writeRecord(w, typeSlotIndex, encodeSlotIndex(uint64(start), blockOffsets))
+		if err != nil {
+			return err
+		}
+		pos += n
+	}
+	_, err = writeRecord(w, typeSlotIndex, encodeSlotIndex(uint64(st.Slot()), []int64{statePos - pos}))
+	return err
+}
+
+// File is an era file opened for reading.
+type File struct {
+	f            *os.File
+	size         int64
+	era          uint64
+	blockOffsets []int64
+	stateOffset  int64
+}
+
+// Open opens the era file at the given path and reads its slot indices.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	ef, err := readIndices(f)
+	if err != nil {
+		if cErr := f.Close(); cErr != nil {
+			log.WithError(cErr).Error("Could not close era file")
+		}
+		return nil, errors.Wrapf(err, "could not read era file %s", path)
+	}
+	return ef, nil
+}
+
+func readIndices(f *os.File) (*File, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	// The state index holds a single offset and is the last record of the file.
+	stateIndexPos := size - headerSize - 24
+	if stateIndexPos < headerSize {
+		return nil, errors.Wrap(errInvalidRecord, "file too short")
+	}
+	typ, data, err := readRecord(f, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	if typ != typeVersion || len(data) != 0 {
+		return nil, errors.Wrap(errInvalidRecord, "missing version record")
+	}
+	typ, data, err = readRecord(f, size, stateIndexPos)
+	if err != nil {
+		return nil, err
+	}
+	if typ != typeSlotIndex {
+		return nil, errors.Wrap(errInvalidRecord, "missing state index")
+	}
+	stateSlot, offsets, err := decodeSlotIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	sphr := uint64(params.BeaconConfig().SlotsPerHistoricalRoot)
+	if len(offsets) != 1 || stateSlot%sphr != 0 {
+		return nil, errors.Wrap(errInvalidRecord, "invalid state index")
+	}
+	ef := &File{f: f, size: size, era: stateSlot / sphr, stateOffset: stateIndexPos + offsets[0]}
+	if ef.era == 0 {
+		return ef, nil
+	}
+
+	blockIndexPos := stateIndexPos - headerSize - 16 - 8*int64(sphr)
+	if blockIndexPos < headerSize {
+		return nil, errors.Wrap(errInvalidRecord, "missing block index")
+	}
+	typ, data, err = readRecord(f, size, blockIndexPos)
+	if err != nil {
+		return nil, err
+	}
+	if typ != typeSlotIndex {
+		return nil, errors.Wrap(errInvalidRecord, "missing block index")
+	}
+	startSlot, offsets, err := decodeSlotIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	if primitives.Slot(startSlot) != StartSlot(ef.era) || uint64(len(offsets)) != sphr {
+		return nil, errors.Wrap(errInvalidRecord, "invalid block index")
+	}
+	ef.blockOffsets = make([]int64, len(offsets))
+	for i, o := range offsets {
+		if o != 0 {
+			ef.blockOffsets[i] = blockIndexPos + o
+		}
+	}
+	return ef, nil
+}
+
+// Close closes the underlying file.
+func (f *File) Close() error {
+	return f.f.Close()
+}
+
+// Era returns the era number of the file.
+func (f *File) Era() uint64 {
+	return f.era
+}
+
+// State returns the era state, the state at the first slot of the next era.
+func (f *File) State() (state.BeaconState, error) {
+	enc, err := f.read(f.stateOffset, typeCompressedBeaconState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read era state")
+	}
+	cf, err := detect.FromState(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect fork of era state")
+	}
+	return cf.UnmarshalBeaconState(enc)
+}
+
+// Block returns the block at the given slot, or ErrBlockNotFound if the slot is empty.
+func (f *File) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	start := StartSlot(f.era)
+	if f.era == 0 || slot < start || slot >= StateSlot(f.era) {
+		return nil, errors.Wrapf(ErrSlotOutOfRange, "slot %d, era %d", slot, f.era)
+	}
+	offset := f.blockOffsets[slot-start]
+	if offset == 0 {
+		return nil, ErrBlockNotFound
+	}
+	enc, err := f.read(offset, typeCompressedSignedBeaconBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read block at slot %d", slot)
+	}
+	cf, err := detect.FromBlock(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not detect fork of block at slot %d", slot)
+	}
+	return cf.UnmarshalBeaconBlock(enc)
+}
+
+// Blocks returns all blocks of the era with a slot in the [start, end) range, sorted by slot.
+func (f *File) Blocks(start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if f.era == 0 {
+		return nil, nil
+	}
+	start = max(start, StartSlot(f.era))
+	end = min(end, StateSlot(f.era))
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	for slot := start; slot < end; slot++ {
+		b, err := f.Block(slot)
+		if errors.Is(err, ErrBlockNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, b)
+	}
+	return blks, nil
+}
+
+// Verify checks that every block of the era matches the block roots of the era state, and that the slots without
+// a block in the file are empty in the era state.
+func (f *File) Verify() error {
+	if f.era == 0 {
+		return nil
+	}
+	st, err := f.State()
+	if err != nil {
+		return err
+	}
+	if st.Slot() != StateSlot(f.era) {
+		return errors.Errorf("era state slot %d does not match era %d", st.Slot(), f.era)
+	}
+	roots := st.BlockRoots()
+	start := StartSlot(f.era)
+	// The roots of empty slots before the first block of the era belong to the previous era and are not checked.
+	var prev []byte
+	for slot := start; slot < st.Slot(); slot++ {
+		want := roots[slot%params.BeaconConfig().SlotsPerHistoricalRoot]
+		b, err := f.Block(slot)
+		if errors.Is(err, ErrBlockNotFound) {
+			// An empty slot repeats the root of the previous block.
+			if prev != nil && !bytes.Equal(want, prev) {
+				return errors.Wrapf(ErrBlockRootMismatch, "missing block at slot %d", slot)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(root[:], want) {
+			return errors.Wrapf(ErrBlockRootMismatch, "slot %d, root %#x", slot, root)
+		}
+		prev = want
+	}
+	return nil
+}
+
+func (f *File) read(offset int64, want [2]byte) ([]byte, error) {
+	typ, data, err := readRecord(f.f, f.size, offset)
+	if err != nil {
+		return nil, err
+	}
+	if typ != want {
+		return nil, errors.Wrapf(errInvalidRecord, "unexpected record type %#x at offset %d", typ, offset)
+	}
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+func compress(enc []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(enc); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sortBlocks sorts blocks by slot.
+func sortBlocks(blks []interfaces.ReadOnlySignedBeaconBlock) {
+	sort.Slice(blks, func(i, j int) bool { return blks[i].Block().Slot() < blks[j].Block().Slot() })
+}
@@ -0,0 +1,143 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestSlotIndex_RoundTrip(t *testing.T) {
+	offsets := []int64{-100, 0, -42}
+	start, got, err := decodeSlotIndex(encodeSlotIndex(64, offsets))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(64), start)
+	assert.DeepEqual(t, offsets, got)
+
+	_, _, err = decodeSlotIndex(make([]byte, 12))
+	require.ErrorIs(t, err, errInvalidRecord)
+}
+
+func TestRecord_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := writeRecord(&buf, typeSlotIndex, []byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(headerSize+3), n)
+	typ, data, err := readRecord(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 0)
+	require.NoError(t, err)
+	assert.Equal(t, typeSlotIndex, typ)
+	assert.DeepEqual(t, []byte{1, 2, 3}, data)
+
+	// A record declaring more data than the input holds is rejected before its data is allocated.
+	enc := buf.Bytes()
+	binary.LittleEndian.PutUint32(enc[2:6], ^uint32(0))
+	_, _, err = readRecord(bytes.NewReader(enc), int64(len(enc)), 0)
+	require.ErrorIs(t, err, errInvalidRecord)
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	sphr := params.BeaconConfig().SlotsPerHistoricalRoot
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	stRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(stRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	// Build a chain with a block in every epoch up to the start of the second era.
+	chainSlots := []primitives.Slot{1, 2, 3, 5}
+	for slot := params.BeaconConfig().SlotsPerEpoch + 7; slot < sphr; slot += params.BeaconConfig().SlotsPerEpoch {
+		chainSlots = append(chainSlots, slot)
+	}
+	chainSlots = append(chainSlots, sphr+1)
+	roots := make(map[primitives.Slot][32]byte)
+	var last [32]byte
+	for _, slot := range chainSlots {
+		b, err := util.GenerateFullBlock(st, keys, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		last, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots[slot] = last
+	}
+	require.NoError(t, beaconDB.SaveState(ctx, st, last))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{
+		Epoch: primitives.Epoch((sphr + 1) / params.BeaconConfig().SlotsPerEpoch),
+		Root:  last[:],
+	}))
+
+	dir := t.TempDir()
+	e := NewExporter(beaconDB, dir)
+	lastEra, err := e.LastFinalizedEra(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), lastEra)
+	_, err = e.Export(ctx, 0, 2)
+	require.ErrorContains(t, "is not finalized", err)
+	paths, err := e.Export(ctx, 0, lastEra)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(paths))
+
+	files, err := ListFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	assert.Equal(t, paths[1], files[1])
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(entries), "temporary files were not removed")
+
+	genesisFile, err := Open(files[0])
+	require.NoError(t, err)
+	defer func() { require.NoError(t, genesisFile.Close()) }()
+	gst, err := genesisFile.State()
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), gst.Slot())
+	assert.Equal(t, fmt.Sprintf("mainnet-00000-%x.era", gst.GenesisValidatorsRoot()[:4]), filepath.Base(files[0]))
+
+	f, err := Open(files[1])
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+	assert.Equal(t, uint64(1), f.Era())
+	require.NoError(t, f.Verify())
+	est, err := f.State()
+	require.NoError(t, err)
+	assert.Equal(t, sphr, est.Slot())
+
+	// The genesis block is the first block of era 1.
+	blks, err := f.Blocks(0, sphr)
+	require.NoError(t, err)
+	require.Equal(t, len(chainSlots), len(blks))
+	roots[0] = gRoot
+	for _, b := range blks {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, roots[b.Block().Slot()], r)
+	}
+	_, err = f.Block(4)
+	require.ErrorIs(t, err, ErrBlockNotFound)
+	_, err = f.Block(sphr + 1)
+	require.ErrorIs(t, err, ErrSlotOutOfRange)
+}
@@ -0,0 +1,226 @@
+package era
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrBlindedBlock is returned when exporting a blinded block without a PayloadReconstructor.
+var ErrBlindedBlock = errors.New("cannot export blinded block without an execution payload reconstructor")
+
+// PayloadReconstructor rebuilds full blocks from the blinded blocks stored in the database.
+// It is satisfied by the execution service.
+type PayloadReconstructor interface {
+	ReconstructFullBellatrixBlockBatch(ctx context.Context, blindedBlocks []interfaces.ReadOnlySignedBeaconBlock) ([]interfaces.SignedBeaconBlock, error)
+}
+
+// Exporter writes the finalized history of a beacon database to era files.
+type Exporter struct {
+	db            iface.ReadOnlyDatabase
+	dir           string
+	reconstructor PayloadReconstructor
+}
+
+// ExporterOption is a functional option for the Exporter.
+type ExporterOption func(*Exporter)
+
+// WithPayloadReconstructor sets the reconstructor used to export blinded blocks.
+func WithPayloadReconstructor(r PayloadReconstructor) ExporterOption {
+	return func(e *Exporter) {
+		e.reconstructor = r
+	}
+}
+
+// NewExporter creates an Exporter writing era files to the given directory.
+func NewExporter(db iface.ReadOnlyDatabase, dir string, opts ...ExporterOption) *Exporter {
+	e := &Exporter{db: db, dir: dir}
+	for _, o := range opts {
+		o(e)
+	}
+	return e
+}
+
+// LastFinalizedEra returns the highest era whose state is finalized.
+func (e *Exporter) LastFinalizedEra(ctx context.Context) (uint64, error) {
+	slot, err := e.finalizedSlot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(slot / StateSlot(1)), nil
+}
+
+// Export writes one era file for every era in the [from, to] range and returns the paths of the written files.
+// Only finalized eras can be exported.
+func (e *Exporter) Export(ctx context.Context, from, to uint64) ([]string, error) {
+	last, err := e.LastFinalizedEra(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if to > last {
+		return nil, errors.Errorf("era %d is not finalized, the last finalized era is %d", to, last)
+	}
+	if err := file.MkdirAll(e.dir); err != nil {
+		return nil, err
+	}
+	var paths []string
+	for era := from; era <= to; era++ {
+		if ctx.Err() != nil {
+			return paths, ctx.Err()
+		}
+		path, err := e.exportEra(ctx, era)
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not export era %d", era)
+		}
+		log.WithFields(logrus.Fields{"era": era, "path": path}).Info("Exported era file")
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (e *Exporter) exportEra(ctx context.Context, era uint64) (string, error) {
+	st, err := e.eraState(ctx, era)
+	if err != nil {
+		return "", err
+	}
+	blks, err := e.eraBlocks(ctx, era)
+	if err != nil {
+		return "", err
+	}
+	name, err := FileName(era, st)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(e.dir, name+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := os.Remove(tmp.Name()); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Error("Could not remove temporary era file")
+		}
+	}()
+	w := bufio.NewWriter(tmp)
+	if err := Write(w, blks, st); err != nil {
+		return "", closeAfterError(tmp, err)
+	}
+	if err := w.Flush(); err != nil {
+		return "", closeAfterError(tmp, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	path := filepath.Join(e.dir, name)
+	return path, os.Rename(tmp.Name(), path)
+}
+
+// eraState returns the state at the first slot after the era.
+func (e *Exporter) eraState(ctx context.Context, era uint64) (state.BeaconState, error) {
+	if era == 0 {
+		st, err := e.db.GenesisState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if st == nil || st.IsNil() {
+			return nil, errors.New("genesis state not found")
+		}
+		return st, nil
+	}
+	fSlot, err := e.finalizedSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slot := StateSlot(era)
+	h := stategen.NewCanonicalHistory(e.db, finalizedChecker{db: e.db}, finalizedSlotter(fSlot))
+	st, err := h.ReplayerForSlot(slot-1).ReplayToSlot(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not replay era state")
+	}
+	return st, nil
+}
+
+// eraBlocks returns the finalized blocks of the era, with blinded blocks reconstructed to full blocks.
+func (e *Exporter) eraBlocks(ctx context.Context, era uint64) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if era == 0 {
+		return nil, nil
+	}
+	f := filters.NewFilter().SetStartSlot(StartSlot(era)).SetEndSlot(StateSlot(era) - 1)
+	all, roots, err := e.db.Blocks(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(all))
+	var blinded []int
+	for i, b := range all {
+		if !e.db.IsFinalizedBlock(ctx, roots[i]) {
+			continue
+		}
+		if b.IsBlinded() {
+			blinded = append(blinded, len(blks))
+		}
+		blks = append(blks, b)
+	}
+	if len(blinded) > 0 {
+		if e.reconstructor == nil {
+			return nil, errors.Wrapf(ErrBlindedBlock, "slot %d", blks[blinded[0]].Block().Slot())
+		}
+		batch := make([]interfaces.ReadOnlySignedBeaconBlock, len(blinded))
+		for i, idx := range blinded {
+			batch[i] = blks[idx]
+		}
+		full, err := e.reconstructor.ReconstructFullBellatrixBlockBatch(ctx, batch)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not reconstruct blinded blocks")
+		}
+		if len(full) != len(batch) {
+			return nil, errors.Errorf("reconstructed %d blocks out of %d", len(full), len(batch))
+		}
+		for i, idx := range blinded {
+			blks[idx] = full[i]
+		}
+	}
+	sortBlocks(blks)
+	return blks, nil
+}
+
+func (e *Exporter) finalizedSlot(ctx context.Context) (primitives.Slot, error) {
+	cp, err := e.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return slots.EpochStart(cp.Epoch)
+}
+
+// finalizedChecker considers finalized blocks canonical, which is all the exporter needs to replay history.
+type finalizedChecker struct {
+	db iface.ReadOnlyDatabase
+}
+
+func (c finalizedChecker) IsCanonical(ctx context.Context, root [32]byte) (bool, error) {
+	return c.db.IsFinalizedBlock(ctx, root), nil
+}
+
+type finalizedSlotter primitives.Slot
+
+func (s finalizedSlotter) CurrentSlot() primitives.Slot {
+	return primitives.Slot(s)
+}
+
+func closeAfterError(f *os.File, err error) error {
+	if cErr := f.Close(); cErr != nil {
+		log.WithError(cErr).Error("Could not close temporary era file")
+	}
+	return err
+}
@@ -252,6 +252,15 @@ func (bs *BlobStorage) Remove(root [32]byte) error {
 	return bs.fs.RemoveAll(rootDir)
 }
 
+// PruneBefore removes the blobs of all blocks with a slot lower than the given slot, regardless of the
+// retention period.
+func (bs *BlobStorage) PruneBefore(slot primitives.Slot) error {
+	if bs.pruner == nil || slot == 0 {
+		return nil
+	}
+	return bs.pruner.prune(slot)
+}
+
 // Indices generates a bitmap representing which BlobSidecar.Index values are present on disk for a given root.
 // This value can be compared to the commitments observed in a block to determine which indices need to be found
 // on the network to confirm data availability.
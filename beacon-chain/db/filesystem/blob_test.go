@@ -212,6 +212,13 @@ func TestBlobStoragePrune(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 4, len(remainingFolders))
 	})
+	t.Run("PruneBefore", func(t *testing.T) {
+		require.NoError(t, bs.PruneBefore(90001))
+
+		remainingFolders, err := afero.ReadDir(fs, ".")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(remainingFolders))
+	})
 }
 
 func BenchmarkPruning(b *testing.B) {
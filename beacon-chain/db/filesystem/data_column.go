@@ -0,0 +1,434 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var (
+	errColumnIndexOutOfBounds = errors.New("data column index in file name >= NUMBER_OF_COLUMNS")
+	errEmptyColumnWritten     = errors.New("zero bytes written to disk when saving data column sidecar")
+	errNoColumnBasePath       = errors.New("DataColumnStorage base path not specified in init")
+)
+
+// dataColumnSlotOffset is the offset of the slot in a marshaled DataColumnSidecar: the 8 byte index and the three
+// 4 byte offsets of the variable length fields precede the signed block header, which starts with the slot.
+const dataColumnSlotOffset = 20
+
+// DataColumnStorageOption is a functional option for configuring a DataColumnStorage.
+type DataColumnStorageOption func(*DataColumnStorage) error
+
+// WithDataColumnBasePath is a required option that sets the base path of data column storage.
+func WithDataColumnBasePath(base string) DataColumnStorageOption {
+	return func(s *DataColumnStorage) error {
+		s.base = base
+		return nil
+	}
+}
+
+// WithDataColumnRetentionEpochs is an option that changes the number of epochs data columns will be persisted.
+func WithDataColumnRetentionEpochs(e primitives.Epoch) DataColumnStorageOption {
+	return func(s *DataColumnStorage) error {
+		s.retentionEpochs = e
+		return nil
+	}
+}
+
+// WithDataColumnSaveFsync is an option that causes Save to call fsync before renaming part files for improved durability.
+func WithDataColumnSaveFsync(fsync bool) DataColumnStorageOption {
+	return func(s *DataColumnStorage) error {
+		s.fsync = fsync
+		return nil
+	}
+}
+
+// NewDataColumnStorage creates a new instance of the DataColumnStorage object. Like BlobStorage, it should only be
+// initialized once per beacon node, and its base path must not be shared with the blob storage.
+func NewDataColumnStorage(opts ...DataColumnStorageOption) (*DataColumnStorage, error) {
+	s := &DataColumnStorage{}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			return nil, errors.Wrap(err, "failed to create data column storage")
+		}
+	}
+	if s.base == "" {
+		return nil, errNoColumnBasePath
+	}
+	s.base = path.Clean(s.base)
+	if err := file.MkdirAll(s.base); err != nil {
+		return nil, errors.Wrapf(err, "failed to create data column storage at %s", s.base)
+	}
+	return newDataColumnStorage(afero.NewBasePathFs(afero.NewOsFs(), s.base), s)
+}
+
+func newDataColumnStorage(fs afero.Fs, s *DataColumnStorage) (*DataColumnStorage, error) {
+	window, err := slots.EpochStart(s.retentionEpochs + retentionBuffer)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set retentionSlots")
+	}
+	s.fs = fs
+	s.windowSize = window
+	s.cache = newDataColumnStorageCache()
+	s.cacheReady = make(chan struct{})
+	return s, nil
+}
+
+// DataColumnStorage is the concrete implementation of the filesystem backend for saving and retrieving
+// DataColumnSidecars. Sidecars are laid out like BlobSidecars, in one directory per block root with one file
+// per column index.
+type DataColumnStorage struct {
+	base            string
+	retentionEpochs primitives.Epoch
+	fsync           bool
+	fs              afero.Fs
+
+	pruneLock    sync.Mutex
+	prunedBefore atomic.Uint64
+	windowSize   primitives.Slot
+	cache        *dataColumnStorageCache
+	cacheReady   chan struct{}
+	warmed       bool
+}
+
+// WarmCache runs the prune routine with an expiration of slot of 0, so nothing will be pruned, but the cache
+// will be populated at node startup.
+func (s *DataColumnStorage) WarmCache() {
+	go func() {
+		start := time.Now()
+		if err := s.warmCache(); err != nil {
+			log.WithError(err).Error("Error encountered while warming up data column storage cache")
+		}
+		log.WithField("elapsed", time.Since(start)).Info("Data column filesystem cache warm-up complete.")
+	}()
+}
+
+func (s *DataColumnStorage) warmCache() error {
+	s.pruneLock.Lock()
+	defer func() {
+		if !s.warmed {
+			s.warmed = true
+			close(s.cacheReady)
+		}
+		s.pruneLock.Unlock()
+	}()
+	return s.prune(0)
+}
+
+// WaitForSummarizer blocks until the DataColumnStorageSummarizer is ready to use.
+func (s *DataColumnStorage) WaitForSummarizer(ctx context.Context) (DataColumnStorageSummarizer, error) {
+	if s == nil || s.cache == nil {
+		return nil, ErrBlobStorageSummarizerUnavailable
+	}
+	select {
+	case <-s.cacheReady:
+		return s.cache, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Save saves a data column sidecar which has undergone full verification.
+func (s *DataColumnStorage) Save(sidecar blocks.VerifiedRODataColumn) error {
+	startTime := time.Now()
+	fname := columnNamer{root: sidecar.BlockRoot(), index: sidecar.Index}
+	sszPath := fname.path()
+	exists, err := afero.Exists(s.fs, sszPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.WithFields(logging.DataColumnFields(sidecar.RODataColumn)).Debug("Ignoring a duplicate data column sidecar save attempt")
+		return nil
+	}
+	if err := s.notify(sidecar.BlockRoot(), sidecar.Slot(), sidecar.Index); err != nil {
+		return errors.Wrapf(err, "problem maintaining pruning cache/metrics for data column with root=%#x", sidecar.BlockRoot())
+	}
+
+	sidecarData, err := sidecar.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize data column sidecar")
+	} else if len(sidecarData) == 0 {
+		return errSidecarEmptySSZData
+	}
+
+	if err := s.fs.MkdirAll(fname.dir(), directoryPermissions); err != nil {
+		return err
+	}
+	partPath := fname.partPath(fmt.Sprintf("%p", sidecarData))
+
+	partialMoved := false
+	// Ensure the partial file is deleted.
+	defer func() {
+		if partialMoved {
+			return
+		}
+		// It's expected to error if the save is successful.
+		if err := s.fs.Remove(partPath); err == nil {
+			log.WithField("partPath", partPath).Debug("Removed partial file")
+		}
+	}()
+
+	partialFile, err := s.fs.Create(partPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to create partial file")
+	}
+	n, err := partialFile.Write(sidecarData)
+	if err != nil {
+		if closeErr := partialFile.Close(); closeErr != nil {
+			return closeErr
+		}
+		return errors.Wrap(err, "failed to write to partial file")
+	}
+	if s.fsync {
+		if err := partialFile.Sync(); err != nil {
+			return err
+		}
+	}
+	if err := partialFile.Close(); err != nil {
+		return err
+	}
+	if n != len(sidecarData) {
+		return fmt.Errorf("failed to write the full bytes of sidecarData, wrote only %d of %d bytes", n, len(sidecarData))
+	}
+	if n == 0 {
+		return errEmptyColumnWritten
+	}
+
+	// Atomically rename the partial file to its final name.
+	if err := s.fs.Rename(partPath, sszPath); err != nil {
+		return errors.Wrap(err, "failed to rename partial file to final name")
+	}
+	partialMoved = true
+	dataColumnsWrittenCounter.Inc()
+	dataColumnSaveLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	return nil
+}
+
+// Get retrieves a single DataColumnSidecar by its root and index.
+// Since DataColumnStorage only writes columns that have undergone full verification, the return
+// value is always a VerifiedRODataColumn.
+func (s *DataColumnStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedRODataColumn, error) {
+	startTime := time.Now()
+	encoded, err := afero.ReadFile(s.fs, columnNamer{root: root, index: idx}.path())
+	if err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	sc := &ethpb.DataColumnSidecar{}
+	if err := sc.UnmarshalSSZ(encoded); err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	ro, err := blocks.NewRODataColumnWithRoot(sc, root)
+	if err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	defer func() {
+		dataColumnFetchLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	}()
+	return verification.DataColumnSidecarNoop(ro)
+}
+
+// Remove removes all data columns for a given root.
+func (s *DataColumnStorage) Remove(root [32]byte) error {
+	if err := s.fs.RemoveAll(columnNamer{root: root}.dir()); err != nil {
+		return err
+	}
+	s.cache.evict(root)
+	return nil
+}
+
+// Summary returns the DataColumnStorageSummary of the columns stored for the given root. It is only accurate for
+// roots saved before startup once the cache has been warmed up, see WaitForSummarizer.
+func (s *DataColumnStorage) Summary(root [32]byte) DataColumnStorageSummary {
+	return s.cache.Summary(root)
+}
+
+// Clear deletes all files on the filesystem.
+func (s *DataColumnStorage) Clear() error {
+	dirs, err := listDir(s.fs, ".")
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := s.fs.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	s.cache.clear()
+	return nil
+}
+
+// WithinRetentionPeriod checks if the requested epoch is within the data column retention period.
+func (s *DataColumnStorage) WithinRetentionPeriod(requested, current primitives.Epoch) bool {
+	if requested > math.MaxUint64-s.retentionEpochs {
+		// If there is an overflow, then the retention period was set to an extremely large number.
+		return true
+	}
+	return requested+s.retentionEpochs >= current
+}
+
+// PruneBefore removes the data columns of all blocks with a slot lower than the given slot, regardless of the
+// retention period.
+func (s *DataColumnStorage) PruneBefore(slot primitives.Slot) error {
+	if slot == 0 {
+		return nil
+	}
+	s.pruneLock.Lock()
+	defer s.pruneLock.Unlock()
+	return s.prune(slot)
+}
+
+// notify records a saved column in the cache, and prunes the columns which fell out of the retention window
+// in the background whenever the window moves forward.
+func (s *DataColumnStorage) notify(root [32]byte, slot primitives.Slot, idx uint64) error {
+	if err := s.cache.ensure(root, slot, idx); err != nil {
+		return err
+	}
+	pruned := uint64(windowMin(slot, s.windowSize))
+	if s.prunedBefore.Swap(pruned) == pruned {
+		return nil
+	}
+	go func() {
+		s.pruneLock.Lock()
+		defer s.pruneLock.Unlock()
+		if err := s.prune(primitives.Slot(pruned)); err != nil {
+			log.WithError(err).Errorf("Failed to prune data columns from slot %d", slot)
+		}
+	}()
+	return nil
+}
+
+// prune removes the data columns of all blocks with a slot lower than pruneBefore. With pruneBefore=0, nothing is
+// removed but the cache is populated from the filesystem.
+func (s *DataColumnStorage) prune(pruneBefore primitives.Slot) error {
+	start := time.Now()
+	totalPruned, totalErr := 0, 0
+	if pruneBefore > 0 {
+		defer func() {
+			log.WithFields(logrus.Fields{
+				"upToEpoch":    slots.ToEpoch(pruneBefore),
+				"duration":     time.Since(start).String(),
+				"filesRemoved": totalPruned,
+			}).Debug("Pruned old data columns")
+			dataColumnsPrunedCounter.Add(float64(totalPruned))
+		}()
+	}
+
+	entries, err := listDir(s.fs, ".")
+	if err != nil {
+		return errors.Wrap(err, "unable to list root data columns directory")
+	}
+	for _, dir := range filter(entries, filterRoot) {
+		pruned, err := s.tryPruneDir(dir, pruneBefore)
+		if err != nil {
+			totalErr += 1
+			log.WithError(err).WithField("directory", dir).Error("Unable to prune directory")
+		}
+		totalPruned += pruned
+	}
+	if totalErr > 0 {
+		return errors.Wrapf(errPruningFailures, "pruning failed for %d root directories", totalErr)
+	}
+	return nil
+}
+
+func (s *DataColumnStorage) tryPruneDir(dir string, pruneBefore primitives.Slot) (int, error) {
+	root, err := rootFromDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	slot, slotCached := s.cache.slot(root)
+	if slotCached && shouldRetain(slot, pruneBefore) {
+		return 0, nil
+	}
+
+	entries, err := listDir(s.fs, dir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list data columns in directory %s", dir)
+	}
+	scFiles := filter(entries, filterSsz)
+	if len(scFiles) == 0 {
+		log.WithField("dir", dir).Warn("Pruner ignoring directory with no data column files")
+		return 0, nil
+	}
+	if !slotCached {
+		slot, err = slotFromColumnFile(path.Join(dir, scFiles[0]), s.fs)
+		if err != nil {
+			return 0, errors.Wrapf(err, "slot could not be read from data column file %s", scFiles[0])
+		}
+		for i := range scFiles {
+			idx, err := idxFromPath(scFiles[i])
+			if err != nil {
+				return 0, errors.Wrapf(err, "index could not be determined for data column file %s", scFiles[i])
+			}
+			if err := s.cache.ensure(root, slot, idx); err != nil {
+				return 0, errors.Wrapf(err, "could not update cache for data column file %s", scFiles[i])
+			}
+		}
+		if shouldRetain(slot, pruneBefore) {
+			return 0, nil
+		}
+	}
+
+	if err := s.fs.RemoveAll(dir); err != nil {
+		return 0, errors.Wrapf(err, "unable to remove data column directory %s", dir)
+	}
+	s.cache.evict(root)
+	return len(scFiles), nil
+}
+
+// slotFromColumnFile reads the slot from the marshaled DataColumnSidecar in the given file.
+func slotFromColumnFile(file string, fs afero.Fs) (primitives.Slot, error) {
+	f, err := fs.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Errorf("Could not close data column file")
+		}
+	}()
+	return slotFromColumn(f)
+}
+
+func slotFromColumn(at io.ReaderAt) (primitives.Slot, error) {
+	b := make([]byte, 8)
+	if _, err := at.ReadAt(b, dataColumnSlotOffset); err != nil {
+		return 0, err
+	}
+	return primitives.Slot(binary.LittleEndian.Uint64(b)), nil
+}
+
+type columnNamer struct {
+	root  [32]byte
+	index uint64
+}
+
+func (p columnNamer) dir() string {
+	return rootString(p.root)
+}
+
+func (p columnNamer) partPath(entropy string) string {
+	return path.Join(p.dir(), fmt.Sprintf("%s-%d.%s", entropy, p.index, partExt))
+}
+
+func (p columnNamer) path() string {
+	return path.Join(p.dir(), fmt.Sprintf("%d.%s", p.index, sszExt))
+}
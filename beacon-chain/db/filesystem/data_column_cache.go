@@ -0,0 +1,107 @@
+package filesystem
+
+import (
+	"sync"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// DataColumnStorageSummary represents cached information about the DataColumnSidecars on disk for a root.
+type DataColumnStorageSummary struct {
+	slot primitives.Slot
+	mask [fieldparams.NumberOfColumns]bool
+}
+
+// HasIndex returns true if the DataColumnSidecar at the given index is available in the filesystem.
+func (s DataColumnStorageSummary) HasIndex(idx uint64) bool {
+	if idx >= fieldparams.NumberOfColumns {
+		return false
+	}
+	return s.mask[idx]
+}
+
+// Count returns the number of DataColumnSidecars available in the filesystem.
+func (s DataColumnStorageSummary) Count() int {
+	count := 0
+	for i := range s.mask {
+		if s.mask[i] {
+			count++
+		}
+	}
+	return count
+}
+
+// DataColumnStorageSummarizer can be used to receive a summary of metadata about data columns on disk for a given
+// root.
+type DataColumnStorageSummarizer interface {
+	Summary(root [32]byte) DataColumnStorageSummary
+}
+
+type dataColumnStorageCache struct {
+	mu       sync.RWMutex
+	nColumns float64
+	cache    map[[32]byte]DataColumnStorageSummary
+}
+
+var _ DataColumnStorageSummarizer = &dataColumnStorageCache{}
+
+func newDataColumnStorageCache() *dataColumnStorageCache {
+	return &dataColumnStorageCache{cache: make(map[[32]byte]DataColumnStorageSummary)}
+}
+
+// Summary returns the DataColumnStorageSummary for `root`.
+func (s *dataColumnStorageCache) Summary(root [32]byte) DataColumnStorageSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache[root]
+}
+
+func (s *dataColumnStorageCache) ensure(key [32]byte, slot primitives.Slot, idx uint64) error {
+	if idx >= fieldparams.NumberOfColumns {
+		return errColumnIndexOutOfBounds
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.cache[key]
+	v.slot = slot
+	if !v.mask[idx] {
+		s.updateMetrics(1)
+	}
+	v.mask[idx] = true
+	s.cache[key] = v
+	return nil
+}
+
+func (s *dataColumnStorageCache) slot(key [32]byte) (primitives.Slot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.cache[key]
+	if !ok {
+		return 0, false
+	}
+	return v.slot, ok
+}
+
+func (s *dataColumnStorageCache) evict(key [32]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.cache[key]
+	if !ok {
+		return
+	}
+	delete(s.cache, key)
+	s.updateMetrics(-float64(v.Count()))
+}
+
+func (s *dataColumnStorageCache) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = make(map[[32]byte]DataColumnStorageSummary)
+	s.updateMetrics(-s.nColumns)
+}
+
+func (s *dataColumnStorageCache) updateMetrics(delta float64) {
+	s.nColumns += delta
+	dataColumnDiskCount.Set(s.nColumns)
+}
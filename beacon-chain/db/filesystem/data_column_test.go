@@ -0,0 +1,91 @@
+package filesystem
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/spf13/afero"
+)
+
+func TestDataColumnStorage_SaveGet(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	verified := verification.FakeVerifyDataColumnSliceForTest(t, columns)
+
+	t.Run("round trip write then read", func(t *testing.T) {
+		fs, s := NewEphemeralDataColumnStorageWithFs(t)
+		expected := verified[3]
+		require.NoError(t, s.Save(expected))
+		// No error when attempting to write twice.
+		require.NoError(t, s.Save(expected))
+
+		content, err := afero.ReadFile(fs, columnNamer{root: expected.BlockRoot(), index: expected.Index}.path())
+		require.NoError(t, err)
+		encoded, err := expected.MarshalSSZ()
+		require.NoError(t, err)
+		require.Equal(t, true, bytes.Equal(encoded, content))
+
+		actual, err := s.Get(expected.BlockRoot(), expected.Index)
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, expected.DataColumnSidecar, actual.DataColumnSidecar)
+		require.Equal(t, expected.BlockRoot(), actual.BlockRoot())
+	})
+
+	t.Run("summary and remove", func(t *testing.T) {
+		s := NewEphemeralDataColumnStorage(t)
+		for _, i := range []int{0, 5, 127} {
+			require.NoError(t, s.Save(verified[i]))
+		}
+		root := verified[0].BlockRoot()
+		sum := s.Summary(root)
+		require.Equal(t, 3, sum.Count())
+		require.Equal(t, true, sum.HasIndex(5))
+		require.Equal(t, false, sum.HasIndex(6))
+		require.Equal(t, false, sum.HasIndex(1000))
+
+		require.NoError(t, s.Remove(root))
+		require.Equal(t, 0, s.Summary(root).Count())
+		_, err := s.Get(root, 5)
+		require.NotNil(t, err)
+	})
+
+	t.Run("cache warm-up reads the filesystem", func(t *testing.T) {
+		fs, s := NewEphemeralDataColumnStorageWithFs(t)
+		require.NoError(t, s.Save(verified[7]))
+		restarted, err := newDataColumnStorage(fs, &DataColumnStorage{})
+		require.NoError(t, err)
+		require.NoError(t, restarted.warmCache())
+		sum := restarted.Summary(verified[7].BlockRoot())
+		require.Equal(t, true, sum.HasIndex(7))
+		require.Equal(t, verified[7].Slot(), sum.slot)
+	})
+}
+
+func TestDataColumnStorage_PruneBefore(t *testing.T) {
+	s := NewEphemeralDataColumnStorage(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	_, old := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, slotsPerEpoch, 1)
+	_, recent := util.GenerateTestDenebBlockWithColumns(t, [32]byte{1}, 3*slotsPerEpoch, 1)
+	require.NoError(t, s.Save(verification.FakeVerifyDataColumnSliceForTest(t, old[:1])[0]))
+	require.NoError(t, s.Save(verification.FakeVerifyDataColumnSliceForTest(t, recent[:1])[0]))
+
+	require.NoError(t, s.PruneBefore(2*slotsPerEpoch))
+	require.Equal(t, 0, s.Summary(old[0].BlockRoot()).Count())
+	require.Equal(t, 1, s.Summary(recent[0].BlockRoot()).Count())
+	_, err := s.Get(old[0].BlockRoot(), 0)
+	require.NotNil(t, err)
+	_, err = s.Get(recent[0].BlockRoot(), 0)
+	require.NoError(t, err)
+}
+
+func TestDataColumnStorage_WithinRetentionPeriod(t *testing.T) {
+	s := &DataColumnStorage{retentionEpochs: 4096}
+	require.Equal(t, true, s.WithinRetentionPeriod(1, 4097))
+	require.Equal(t, false, s.WithinRetentionPeriod(1, 4098))
+	s.retentionEpochs = primitives.Epoch(^uint64(0))
+	require.Equal(t, true, s.WithinRetentionPeriod(2, 1<<40))
+}
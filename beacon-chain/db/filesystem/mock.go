@@ -73,3 +73,25 @@ func NewMockBlobStorageSummarizer(t *testing.T, set map[[32]byte][]int) BlobStor
 	}
 	return c
 }
+
+// NewEphemeralDataColumnStorage should only be used for tests.
+// The instance of DataColumnStorage returned is backed by an in-memory virtual filesystem,
+// improving test performance and simplifying cleanup.
+func NewEphemeralDataColumnStorage(t testing.TB) *DataColumnStorage {
+	_, s := NewEphemeralDataColumnStorageWithFs(t)
+	return s
+}
+
+// NewEphemeralDataColumnStorageWithFs can be used by tests that want access to the virtual filesystem
+// in order to interact with it outside the parameters of the DataColumnStorage api.
+func NewEphemeralDataColumnStorageWithFs(t testing.TB) (afero.Fs, *DataColumnStorage) {
+	fs := afero.NewMemMapFs()
+	s, err := newDataColumnStorage(fs, &DataColumnStorage{retentionEpochs: params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest})
+	if err != nil {
+		t.Fatal("test setup issue", err)
+	}
+	if err := s.warmCache(); err != nil {
+		t.Fatal("test setup issue", err)
+	}
+	return fs, s
+}
@@ -8,7 +8,10 @@ import (
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -41,6 +44,7 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	StateArchiveProgress(ctx context.Context) ([32]byte, [32]byte, error)
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -56,6 +60,16 @@ type ReadOnlyDatabase interface {
 	// Fee recipients operations.
 	FeeRecipientByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (common.Address, error)
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	// Head change history.
+	HeadChanges(ctx context.Context, startSlot, endSlot primitives.Slot) ([]*forkchoicetypes.HeadChange, error)
+	// Builder bid audit history.
+	BuilderBids(ctx context.Context, startSlot, endSlot primitives.Slot) ([]*buildertypes.BidRecord, error)
+	// Validator monitor performance history.
+	ValidatorPerformance(ctx context.Context, startEpoch, endEpoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*monitortypes.EpochPerformance, error)
+	// Light client data.
+	LightClientUpdate(ctx context.Context, period uint64) (ethpb.LightClientUpdate, error)
+	LightClientUpdates(ctx context.Context, startPeriod, count uint64) ([]ethpb.LightClientUpdate, error)
+	LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (ethpb.LightClientBootstrap, error)
 
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
@@ -77,6 +91,8 @@ type NoHeadAccessDatabase interface {
 	SaveStates(ctx context.Context, states []state.ReadOnlyBeaconState, blockRoots [][32]byte) error
 	DeleteState(ctx context.Context, blockRoot [32]byte) error
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
+	SaveStateDiff(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error
+	SaveStateArchiveProgress(ctx context.Context, root, baseRoot [32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
 	// Checkpoint operations.
@@ -92,6 +108,13 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Builder bid audit operations.
+	SaveBuilderBid(ctx context.Context, b *buildertypes.BidRecord) error
+	// Validator monitor performance operations.
+	SaveValidatorPerformance(ctx context.Context, records []*monitortypes.EpochPerformance) error
+	// Light client data operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update ethpb.LightClientUpdate) error
+	SaveLightClientBootstrap(ctx context.Context, blockRoot [32]byte, bootstrap ethpb.LightClientBootstrap) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 }
@@ -104,6 +127,11 @@ type HeadAccessDatabase interface {
 	HeadBlock(ctx context.Context) (interfaces.ReadOnlySignedBeaconBlock, error)
 	SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error
 
+	// Fork choice operations.
+	ForkchoiceSnapshot(ctx context.Context) ([]byte, error)
+	SaveForkchoiceSnapshot(ctx context.Context, snapshot []byte) error
+	SaveHeadChange(ctx context.Context, c *forkchoicetypes.HeadChange) error
+
 	// Genesis operations.
 	LoadGenesis(ctx context.Context, stateBytes []byte) error
 	SaveGenesisData(ctx context.Context, state state.BeaconState) error
@@ -155,6 +183,11 @@ type SlasherDatabase interface {
 	PruneProposalsAtEpoch(
 		ctx context.Context, maxEpoch primitives.Epoch,
 	) (numPruned uint, err error)
+	DeleteSlasherChunks(
+		ctx context.Context, kind slashertypes.ChunkKind, chunkKeys [][]byte,
+	) (numDeleted uint, err error)
+	DetectionProgress(ctx context.Context) (primitives.Epoch, bool, error)
+	SaveDetectionProgress(ctx context.Context, epoch primitives.Epoch) error
 	HighestAttestations(
 		ctx context.Context,
 		indices []primitives.ValidatorIndex,
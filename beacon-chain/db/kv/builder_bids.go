@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// maxBuilderBids is the number of builder bid records kept in the database, the oldest ones are deleted first.
+var maxBuilderBids = 4096
+
+const builderBidFixedLength = 8*9 + 1 + fieldparams.BLSPubkeyLength + fieldparams.RootLength
+
+// SaveBuilderBid stores the builder bid record of a proposal, replacing any previous record for the same slot.
+func (s *Store) SaveBuilderBid(ctx context.Context, b *buildertypes.BidRecord) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBuilderBid")
+	defer span.End()
+	if b == nil {
+		return errors.New("nil builder bid record")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(builderBidsBucket)
+		// The bucket sequence holds the number of records, so that the oldest ones are trimmed without walking the bucket.
+		count := bkt.Sequence()
+		key := bytesutil.SlotToBytesBigEndian(b.Slot)
+		if bkt.Get(key) == nil {
+			count++
+		}
+		if err := bkt.Put(key, encodeBuilderBid(b)); err != nil {
+			return err
+		}
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil && count > uint64(maxBuilderBids); k, _ = c.First() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+			count--
+		}
+		return bkt.SetSequence(count)
+	})
+}
+
+// BuilderBids returns the builder bid records of the proposals between the given slots, inclusive, in slot order.
+func (s *Store) BuilderBids(ctx context.Context, startSlot, endSlot primitives.Slot) ([]*buildertypes.BidRecord, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BuilderBids")
+	defer span.End()
+	if startSlot > endSlot {
+		return nil, errInvalidSlotRange
+	}
+	bids := make([]*buildertypes.BidRecord, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(builderBidsBucket).Cursor()
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(startSlot)); k != nil; k, v = c.Next() {
+			if bytesutil.BytesToSlotBigEndian(k) > endSlot {
+				break
+			}
+			b, err := decodeBuilderBid(v)
+			if err != nil {
+				return err
+			}
+			bids = append(bids, b)
+		}
+		return nil
+	})
+	return bids, err
+}
+
+func encodeBuilderBid(b *buildertypes.BidRecord) []byte {
+	enc := make([]byte, 0, builderBidFixedLength+len(b.Error))
+	enc = binary.BigEndian.AppendUint64(enc, uint64(b.Slot))
+	enc = binary.BigEndian.AppendUint64(enc, uint64(b.ProposerIndex))
+	enc = binary.BigEndian.AppendUint64(enc, b.Timestamp)
+	enc = append(enc, byte(b.Outcome))
+	enc = append(enc, b.RelayPubkey[:]...)
+	enc = append(enc, b.BlockHash[:]...)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(b.BuilderValue))
+	enc = binary.BigEndian.AppendUint64(enc, uint64(b.LocalValue))
+	enc = binary.BigEndian.AppendUint64(enc, b.LocalBoost)
+	enc = binary.BigEndian.AppendUint64(enc, b.BuilderBoostFactor)
+	enc = binary.BigEndian.AppendUint64(enc, b.Latency)
+	enc = binary.BigEndian.AppendUint64(enc, b.SinceSlotStart)
+	return append(enc, b.Error...)
+}
+
+func decodeBuilderBid(enc []byte) (*buildertypes.BidRecord, error) {
+	if len(enc) < builderBidFixedLength {
+		return nil, errors.Errorf("invalid builder bid record length: %d", len(enc))
+	}
+	b := &buildertypes.BidRecord{}
+	nextUint64 := func() uint64 {
+		v := binary.BigEndian.Uint64(enc)
+		enc = enc[8:]
+		return v
+	}
+	b.Slot = primitives.Slot(nextUint64())
+	b.ProposerIndex = primitives.ValidatorIndex(nextUint64())
+	b.Timestamp = nextUint64()
+	b.Outcome = buildertypes.BidOutcome(enc[0])
+	enc = enc[1:]
+	copy(b.RelayPubkey[:], enc)
+	enc = enc[fieldparams.BLSPubkeyLength:]
+	copy(b.BlockHash[:], enc)
+	enc = enc[fieldparams.RootLength:]
+	b.BuilderValue = primitives.Gwei(nextUint64())
+	b.LocalValue = primitives.Gwei(nextUint64())
+	b.LocalBoost = nextUint64()
+	b.BuilderBoostFactor = nextUint64()
+	b.Latency = nextUint64()
+	b.SinceSlotStart = nextUint64()
+	b.Error = string(enc)
+	return b, nil
+}
@@ -0,0 +1,71 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_BuilderBids(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	bid := &buildertypes.BidRecord{
+		Slot:               5,
+		ProposerIndex:      7,
+		Timestamp:          1234,
+		Outcome:            buildertypes.BidAccepted,
+		RelayPubkey:        [48]byte{'r'},
+		BlockHash:          [32]byte{'h'},
+		BuilderValue:       200,
+		LocalValue:         100,
+		LocalBoost:         10,
+		BuilderBoostFactor: 100,
+		Latency:            150,
+		SinceSlotStart:     400,
+	}
+	failed := &buildertypes.BidRecord{Slot: 9, Outcome: buildertypes.BidFailed, Error: "builder returned header with 0 bid amount"}
+	require.NoError(t, db.SaveBuilderBid(ctx, bid))
+	require.NoError(t, db.SaveBuilderBid(ctx, failed))
+
+	bids, err := db.BuilderBids(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(bids))
+	require.DeepEqual(t, bid, bids[0])
+	require.DeepEqual(t, failed, bids[1])
+
+	bids, err = db.BuilderBids(ctx, 6, 9)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(bids))
+	require.Equal(t, primitives.Slot(9), bids[0].Slot)
+
+	_, err = db.BuilderBids(ctx, 2, 1)
+	require.ErrorIs(t, err, errInvalidSlotRange)
+}
+
+func TestStore_BuilderBids_Prune(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	defer func(m int) { maxBuilderBids = m }(maxBuilderBids)
+	maxBuilderBids = 3
+
+	for slot := primitives.Slot(1); slot <= 5; slot++ {
+		require.NoError(t, db.SaveBuilderBid(ctx, &buildertypes.BidRecord{Slot: slot}))
+	}
+	bids, err := db.BuilderBids(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(bids))
+	require.Equal(t, primitives.Slot(3), bids[0].Slot)
+	require.Equal(t, primitives.Slot(5), bids[2].Slot)
+
+	// Replacing the record of a slot does not trim the oldest one.
+	require.NoError(t, db.SaveBuilderBid(ctx, &buildertypes.BidRecord{Slot: 5, ProposerIndex: 1}))
+	bids, err = db.BuilderBids(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(bids))
+	require.Equal(t, primitives.Slot(3), bids[0].Slot)
+	require.Equal(t, primitives.ValidatorIndex(1), bids[2].ProposerIndex)
+}
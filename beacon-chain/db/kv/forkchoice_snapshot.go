@@ -0,0 +1,37 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveForkchoiceSnapshot stores the serialized fork choice store, replacing the previous snapshot.
+func (s *Store) SaveForkchoiceSnapshot(ctx context.Context, snapshot []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkchoiceSnapshot")
+	defer span.End()
+	enc := snappy.Encode(nil, snapshot)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(forkchoiceSnapshotKey, enc)
+	})
+}
+
+// ForkchoiceSnapshot returns the last serialized fork choice store saved with SaveForkchoiceSnapshot.
+func (s *Store) ForkchoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkchoiceSnapshot")
+	defer span.End()
+	var snapshot []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(forkchoiceSnapshotKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "forkchoice snapshot not found")
+		}
+		var err error
+		snapshot, err = snappy.Decode(nil, enc)
+		return err
+	})
+	return snapshot, err
+}
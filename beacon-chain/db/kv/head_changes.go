@@ -0,0 +1,133 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// maxHeadChanges is the number of head changes kept in the database. Older entries are overwritten,
+// which leaves a bit more than a day of history when the head changes every slot.
+var maxHeadChanges = uint64(8192)
+
+const headChangeLength = 8*11 + 1 + 4*fieldparams.RootLength
+
+// SaveHeadChange appends a head change to the history, dropping the oldest entry once the history is full.
+func (s *Store) SaveHeadChange(ctx context.Context, c *forkchoicetypes.HeadChange) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveHeadChange")
+	defer span.End()
+	if c == nil {
+		return errors.New("nil head change")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(headChangesBucket)
+		seq, err := bkt.NextSequence()
+		if err != nil {
+			return err
+		}
+		if seq > maxHeadChanges {
+			if err := bkt.Delete(bytesutil.Uint64ToBytesBigEndian(seq - maxHeadChanges)); err != nil {
+				return err
+			}
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(seq), encodeHeadChange(c))
+	})
+}
+
+// HeadChanges returns the recorded head changes which happened between the given wall clock slots, inclusive,
+// from the oldest to the most recent.
+func (s *Store) HeadChanges(ctx context.Context, startSlot, endSlot primitives.Slot) ([]*forkchoicetypes.HeadChange, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.HeadChanges")
+	defer span.End()
+	if startSlot > endSlot {
+		return nil, errInvalidSlotRange
+	}
+	changes := make([]*forkchoicetypes.HeadChange, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(headChangesBucket).Cursor()
+		// Entries are appended as the clock moves forward, so they are sorted by slot.
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			change, err := decodeHeadChange(v)
+			if err != nil {
+				return err
+			}
+			if change.Slot < startSlot {
+				break
+			}
+			if change.Slot <= endSlot {
+				changes = append(changes, change)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes, nil
+}
+
+func encodeHeadChange(c *forkchoicetypes.HeadChange) []byte {
+	enc := make([]byte, 0, headChangeLength)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(c.Slot))
+	enc = binary.BigEndian.AppendUint64(enc, c.Timestamp)
+	enc = append(enc, byte(c.Reason))
+	enc = append(enc, c.OldHeadRoot[:]...)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(c.OldHeadSlot))
+	enc = binary.BigEndian.AppendUint64(enc, c.OldHeadWeight)
+	enc = append(enc, c.NewHeadRoot[:]...)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(c.NewHeadSlot))
+	enc = binary.BigEndian.AppendUint64(enc, c.NewHeadWeight)
+	enc = append(enc, c.CommonAncestorRoot[:]...)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(c.CommonAncestorSlot))
+	enc = binary.BigEndian.AppendUint64(enc, c.Depth)
+	enc = binary.BigEndian.AppendUint64(enc, c.Distance)
+	enc = append(enc, c.ProposerBoostRoot[:]...)
+	enc = binary.BigEndian.AppendUint64(enc, uint64(c.JustifiedEpoch))
+	enc = binary.BigEndian.AppendUint64(enc, uint64(c.FinalizedEpoch))
+	return enc
+}
+
+func decodeHeadChange(enc []byte) (*forkchoicetypes.HeadChange, error) {
+	if len(enc) != headChangeLength {
+		return nil, errors.Errorf("invalid head change length: %d", len(enc))
+	}
+	c := &forkchoicetypes.HeadChange{}
+	nextUint64 := func() uint64 {
+		v := binary.BigEndian.Uint64(enc)
+		enc = enc[8:]
+		return v
+	}
+	nextRoot := func() (root [fieldparams.RootLength]byte) {
+		copy(root[:], enc)
+		enc = enc[fieldparams.RootLength:]
+		return
+	}
+	c.Slot = primitives.Slot(nextUint64())
+	c.Timestamp = nextUint64()
+	c.Reason = forkchoicetypes.HeadChangeReason(enc[0])
+	enc = enc[1:]
+	c.OldHeadRoot = nextRoot()
+	c.OldHeadSlot = primitives.Slot(nextUint64())
+	c.OldHeadWeight = nextUint64()
+	c.NewHeadRoot = nextRoot()
+	c.NewHeadSlot = primitives.Slot(nextUint64())
+	c.NewHeadWeight = nextUint64()
+	c.CommonAncestorRoot = nextRoot()
+	c.CommonAncestorSlot = primitives.Slot(nextUint64())
+	c.Depth = nextUint64()
+	c.Distance = nextUint64()
+	c.ProposerBoostRoot = nextRoot()
+	c.JustifiedEpoch = primitives.Epoch(nextUint64())
+	c.FinalizedEpoch = primitives.Epoch(nextUint64())
+	return c, nil
+}
@@ -0,0 +1,65 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_HeadChanges(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	change := &forkchoicetypes.HeadChange{
+		Slot:               10,
+		Timestamp:          1234,
+		Reason:             forkchoicetypes.HeadChangeLateBlockReorg,
+		OldHeadRoot:        [32]byte{'a'},
+		OldHeadSlot:        9,
+		OldHeadWeight:      100,
+		NewHeadRoot:        [32]byte{'b'},
+		NewHeadSlot:        10,
+		NewHeadWeight:      200,
+		CommonAncestorRoot: [32]byte{'c'},
+		CommonAncestorSlot: 8,
+		Depth:              2,
+		Distance:           3,
+		ProposerBoostRoot:  [32]byte{'b'},
+		JustifiedEpoch:     4,
+		FinalizedEpoch:     3,
+	}
+	require.NoError(t, db.SaveHeadChange(ctx, change))
+	changes, err := db.HeadChanges(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	require.DeepEqual(t, change, changes[0])
+
+	_, err = db.HeadChanges(ctx, 2, 1)
+	require.ErrorIs(t, err, errInvalidSlotRange)
+}
+
+func TestStore_HeadChanges_RingBuffer(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	defer func(m uint64) { maxHeadChanges = m }(maxHeadChanges)
+	maxHeadChanges = 4
+
+	for slot := primitives.Slot(1); slot <= 6; slot++ {
+		require.NoError(t, db.SaveHeadChange(ctx, &forkchoicetypes.HeadChange{Slot: slot}))
+	}
+	changes, err := db.HeadChanges(ctx, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(changes))
+	for i, c := range changes {
+		require.Equal(t, primitives.Slot(i+3), c.Slot)
+	}
+
+	changes, err = db.HeadChanges(ctx, 4, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(changes))
+	require.Equal(t, primitives.Slot(4), changes[0].Slot)
+	require.Equal(t, primitives.Slot(5), changes[1].Slot)
+}
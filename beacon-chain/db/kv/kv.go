@@ -64,6 +64,10 @@ var (
 		Name: "db_beacon_state_saving_milliseconds",
 		Help: "Milliseconds it takes to save a beacon state to the DB",
 	})
+	stateDiffReadingTime = promauto.NewSummary(prometheus.SummaryOpts{
+		Name: "db_beacon_state_diff_reading_milliseconds",
+		Help: "Milliseconds it takes to rebuild a beacon state from a state diff in the DB",
+	})
 )
 
 // BlockCacheSize specifies 1000 slots worth of blocks cached, which
@@ -118,6 +122,13 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	stateDiffBucket,
+	stateDiffBasesBucket,
+	headChangesBucket,
+	builderBidsBucket,
+	validatorPerfBucket,
+	lightClientUpdatesBucket,
+	lightClientBootstrapsBucket,
 }
 
 // KVStoreOption is a functional option that modifies a kv.Store.
@@ -0,0 +1,227 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// maxLightClientBootstraps is the number of light client bootstraps kept in the database, the ones of the
+// oldest blocks are deleted first.
+var maxLightClientBootstraps = 1024
+
+// SaveLightClientUpdate stores the best light client update of the sync committee period,
+// replacing any previous update for the period. Updates with an attested header from Electra onwards
+// cannot be stored.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update ethpb.LightClientUpdate) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+	if update == nil || update.AttestedHeaderVal().GetBeacon() == nil {
+		return errors.New("nil light client update attested header")
+	}
+	slot := update.AttestedHeaderVal().GetBeacon().Slot
+	if !lightClient.IsSupportedSlot(slot) {
+		return errors.Wrapf(lightClient.ErrUnsupportedFork, "attested header slot %d", slot)
+	}
+	enc, err := encodeLightClientData(update)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lightClientUpdatesBucket).Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
+
+// LightClientUpdate returns the best light client update of the sync committee period, or nil if there is none.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (ethpb.LightClientUpdate, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytes.Clone(tx.Bucket(lightClientUpdatesBucket).Get(bytesutil.Uint64ToBytesBigEndian(period)))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	return decodeLightClientUpdate(enc)
+}
+
+// LightClientUpdates returns the best light client updates of the sync committee periods
+// [startPeriod, startPeriod + count), stopping at the first period without an update.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, count uint64) ([]ethpb.LightClientUpdate, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+	var encs [][]byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		period := startPeriod
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil && uint64(len(encs)) < count; k, v = c.Next() {
+			if bytesutil.BytesToUint64BigEndian(k) != period {
+				break
+			}
+			encs = append(encs, bytes.Clone(v))
+			period++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	updates := make([]ethpb.LightClientUpdate, len(encs))
+	for i, enc := range encs {
+		update, err := decodeLightClientUpdate(enc)
+		if err != nil {
+			return nil, err
+		}
+		updates[i] = update
+	}
+	return updates, nil
+}
+
+// SaveLightClientBootstrap stores the light client bootstrap of the block root. Only the bootstraps
+// of the latest maxLightClientBootstraps blocks are kept, and headers from Electra onwards cannot be stored.
+func (s *Store) SaveLightClientBootstrap(ctx context.Context, blockRoot [32]byte, bootstrap ethpb.LightClientBootstrap) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientBootstrap")
+	defer span.End()
+	if bootstrap == nil || bootstrap.HeaderVal().GetBeacon() == nil {
+		return errors.New("nil light client bootstrap header")
+	}
+	slot := bootstrap.HeaderVal().GetBeacon().Slot
+	if !lightClient.IsSupportedSlot(slot) {
+		return errors.Wrapf(lightClient.ErrUnsupportedFork, "header slot %d", slot)
+	}
+	enc, err := encodeLightClientData(bootstrap)
+	if err != nil {
+		return err
+	}
+	key := append(bytesutil.SlotToBytesBigEndian(slot), blockRoot[:]...)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(lightClientBootstrapsBucket)
+		if err := bkt.Put(key, enc); err != nil {
+			return err
+		}
+		var keys [][]byte
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, k)
+		}
+		for i := 0; i < len(keys)-maxLightClientBootstraps; i++ {
+			if err := bkt.Delete(keys[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LightClientBootstrap returns the light client bootstrap of the block root, or nil if there is none.
+func (s *Store) LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (ethpb.LightClientBootstrap, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LightClientBootstrap")
+	defer span.End()
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		// Bootstraps are keyed by slot then block root, so that the ones of the oldest blocks are pruned first.
+		c := tx.Bucket(lightClientBootstrapsBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if bytes.Equal(k[8:], blockRoot[:]) {
+				enc = bytes.Clone(v)
+				return nil
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	return decodeLightClientBootstrap(enc)
+}
+
+// lightClientData is the light client data stored in the database.
+type lightClientData interface {
+	ssz.Marshaler
+	Version() int
+}
+
+// encodeLightClientData encodes the light client data, prefixed with the key of its version as blocks are.
+func encodeLightClientData(data lightClientData) ([]byte, error) {
+	var key []byte
+	switch data.Version() {
+	case version.Altair:
+		key = altairKey
+	case version.Capella:
+		key = capellaKey
+	case version.Deneb:
+		key = denebKey
+	default:
+		return nil, fmt.Errorf("unsupported light client data version %s", version.String(data.Version()))
+	}
+	enc, err := data.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal light client data")
+	}
+	return snappy.Encode(nil, append(bytes.Clone(key), enc...)), nil
+}
+
+func decodeLightClientUpdate(enc []byte) (ethpb.LightClientUpdate, error) {
+	enc, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not snappy decode light client update")
+	}
+	var update ethpb.LightClientUpdate
+	switch {
+	case hasAltairKey(enc):
+		update = &ethpb.LightClientUpdateAltair{}
+		enc = enc[len(altairKey):]
+	case hasCapellaKey(enc):
+		update = &ethpb.LightClientUpdateCapella{}
+		enc = enc[len(capellaKey):]
+	case hasDenebKey(enc):
+		update = &ethpb.LightClientUpdateDeneb{}
+		enc = enc[len(denebKey):]
+	default:
+		return nil, errors.New("unknown light client update version")
+	}
+	if err := update.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s light client update", version.String(update.Version()))
+	}
+	return update, nil
+}
+
+func decodeLightClientBootstrap(enc []byte) (ethpb.LightClientBootstrap, error) {
+	enc, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not snappy decode light client bootstrap")
+	}
+	var bootstrap ethpb.LightClientBootstrap
+	switch {
+	case hasAltairKey(enc):
+		bootstrap = &ethpb.LightClientBootstrapAltair{}
+		enc = enc[len(altairKey):]
+	case hasCapellaKey(enc):
+		bootstrap = &ethpb.LightClientBootstrapCapella{}
+		enc = enc[len(capellaKey):]
+	case hasDenebKey(enc):
+		bootstrap = &ethpb.LightClientBootstrapDeneb{}
+		enc = enc[len(denebKey):]
+	default:
+		return nil, errors.New("unknown light client bootstrap version")
+	}
+	if err := bootstrap.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s light client bootstrap", version.String(bootstrap.Version()))
+	}
+	return bootstrap, nil
+}
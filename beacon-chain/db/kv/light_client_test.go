@@ -0,0 +1,129 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_LightClientUpdates(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.CapellaForkEpoch = 1
+	cfg.DenebForkEpoch = 2
+	cfg.ElectraForkEpoch = 3
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	db := setupDB(t)
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, nil, update)
+
+	updates := make(map[uint64]ethpb.LightClientUpdate)
+	for _, period := range []uint64{1, 2, 3, 5} {
+		updates[period] = util.HydrateLightClientUpdate(&ethpb.LightClientUpdateAltair{SignatureSlot: primitives.Slot(period)})
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, updates[period]))
+	}
+	// A better update replaces the update of the period.
+	updates[2] = util.HydrateLightClientUpdate(&ethpb.LightClientUpdateAltair{SignatureSlot: 20})
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 2, updates[2]))
+
+	update, err = db.LightClientUpdate(ctx, 2)
+	require.NoError(t, err)
+	require.DeepEqual(t, updates[2], update)
+
+	got, err := db.LightClientUpdates(ctx, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got), "updates must stop at the first missing period")
+	for i, u := range got {
+		require.DeepEqual(t, updates[uint64(i)+1], u)
+	}
+
+	got, err = db.LightClientUpdates(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.DeepEqual(t, updates[2], got[0])
+
+	got, err = db.LightClientUpdates(ctx, 4, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+
+	// Updates are decoded into the containers of their fork.
+	capella := util.HydrateLightClientUpdateCapella(&ethpb.LightClientUpdateCapella{
+		AttestedHeader: &ethpb.LightClientHeaderCapella{Beacon: &ethpb.BeaconBlockHeader{Slot: params.BeaconConfig().SlotsPerEpoch}},
+	})
+	capella.AttestedHeader.Execution.BlockNumber = 6
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 6, capella))
+	deneb := util.HydrateLightClientUpdateDeneb(&ethpb.LightClientUpdateDeneb{
+		AttestedHeader: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: 2 * params.BeaconConfig().SlotsPerEpoch}},
+	})
+	deneb.AttestedHeader.Execution.BlobGasUsed = 7
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 7, deneb))
+	got, err = db.LightClientUpdates(ctx, 6, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	require.DeepEqual(t, capella, got[0])
+	require.DeepEqual(t, deneb, got[1])
+
+	electra := util.HydrateLightClientUpdateDeneb(&ethpb.LightClientUpdateDeneb{
+		AttestedHeader: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: 3 * params.BeaconConfig().SlotsPerEpoch}},
+	})
+	require.ErrorIs(t, db.SaveLightClientUpdate(ctx, 8, electra), lightClient.ErrUnsupportedFork)
+}
+
+func TestStore_LightClientBootstraps(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.CapellaForkEpoch = 2
+	cfg.DenebForkEpoch = 3
+	cfg.ElectraForkEpoch = 4
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	db := setupDB(t)
+	defer func(m int) { maxLightClientBootstraps = m }(maxLightClientBootstraps)
+	maxLightClientBootstraps = 2
+
+	got, err := db.LightClientBootstrap(ctx, [32]byte{'a'})
+	require.NoError(t, err)
+	require.Equal(t, nil, got)
+
+	bootstraps := map[[32]byte]ethpb.LightClientBootstrap{
+		{'a'}: util.HydrateLightClientBootstrap(&ethpb.LightClientBootstrapAltair{
+			Header: &ethpb.LightClientHeaderAltair{Beacon: &ethpb.BeaconBlockHeader{Slot: 32}},
+		}),
+		{'b'}: util.HydrateLightClientBootstrapCapella(&ethpb.LightClientBootstrapCapella{
+			Header: &ethpb.LightClientHeaderCapella{Beacon: &ethpb.BeaconBlockHeader{Slot: 64}},
+		}),
+		{'c'}: util.HydrateLightClientBootstrapDeneb(&ethpb.LightClientBootstrapDeneb{
+			Header: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: 96}},
+		}),
+	}
+	for _, root := range [][32]byte{{'a'}, {'b'}, {'c'}} {
+		require.NoError(t, db.SaveLightClientBootstrap(ctx, root, bootstraps[root]))
+	}
+
+	// The bootstrap of the oldest block was pruned.
+	got, err = db.LightClientBootstrap(ctx, [32]byte{'a'})
+	require.NoError(t, err)
+	require.Equal(t, nil, got)
+	for _, root := range [][32]byte{{'b'}, {'c'}} {
+		got, err = db.LightClientBootstrap(ctx, root)
+		require.NoError(t, err)
+		require.DeepEqual(t, bootstraps[root], got)
+	}
+
+	require.ErrorContains(t, "nil light client bootstrap header", db.SaveLightClientBootstrap(ctx, [32]byte{'d'}, &ethpb.LightClientBootstrapAltair{}))
+	electra := util.HydrateLightClientBootstrapDeneb(&ethpb.LightClientBootstrapDeneb{
+		Header: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: 4 * params.BeaconConfig().SlotsPerEpoch}},
+	})
+	require.ErrorIs(t, db.SaveLightClientBootstrap(ctx, [32]byte{'d'}, electra), lightClient.ErrUnsupportedFork)
+}
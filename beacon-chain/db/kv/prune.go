@@ -0,0 +1,355 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// pruneBatchSize is the number of block roots deleted in a single transaction while pruning history.
+const pruneBatchSize = 1024
+
+// PruneStats describes the data removed by PruneHistory.
+type PruneStats struct {
+	// AnchorSlot and AnchorRoot identify the earliest finalized state kept in the database.
+	AnchorSlot       primitives.Slot
+	AnchorRoot       [32]byte
+	Blocks           int
+	States           int
+	ValidatorEntries int
+}
+
+// PruneHistory removes blocks, states, state diffs and state summaries before the given slot. Pruning stops at
+// the highest finalized block at or before the slot for which a full state is stored, so that the remaining chain
+// can still be replayed from a state in the database. The genesis block and state are always kept, and so are
+// the full states which retained state diffs are computed against. The backfill status is updated to the new
+// lowest available block.
+func (s *Store) PruneHistory(ctx context.Context, beforeSlot primitives.Slot) (*PruneStats, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneHistory")
+	defer span.End()
+
+	finalized, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	finalizedSlot, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if beforeSlot > finalizedSlot {
+		return nil, errors.Errorf("cannot prune history after the finalized slot %d", finalizedSlot)
+	}
+	genesisRoot, err := s.GenesisBlockRoot(ctx)
+	if err != nil && !errors.Is(err, ErrNotFoundGenesisBlockRoot) {
+		return nil, err
+	}
+	stats, err := s.pruneAnchor(ctx, beforeSlot, genesisRoot)
+	if err != nil {
+		return nil, err
+	}
+	if stats.AnchorSlot == 0 {
+		return stats, nil
+	}
+
+	keep, err := s.retainedDiffBases(ctx, stats.AnchorSlot)
+	if err != nil {
+		return nil, err
+	}
+	keep[genesisRoot] = true
+	keep[stats.AnchorRoot] = true
+
+	if err := s.pruneBlocks(ctx, stats, keep); err != nil {
+		return nil, errors.Wrap(err, "could not prune blocks")
+	}
+	if err := s.pruneStates(ctx, stats, keep); err != nil {
+		return nil, errors.Wrap(err, "could not prune states")
+	}
+	if err := s.pruneValidatorEntries(ctx, stats); err != nil {
+		return nil, errors.Wrap(err, "could not prune validator entries")
+	}
+	if err := s.updateStatusAfterPrune(ctx, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// pruneAnchor finds the highest finalized block at or before the given slot with a full state in the database.
+func (s *Store) pruneAnchor(ctx context.Context, beforeSlot primitives.Slot, genesisRoot [32]byte) (*PruneStats, error) {
+	stats := &PruneStats{AnchorRoot: genesisRoot}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		states := tx.Bucket(stateBucket)
+		finalized := tx.Bucket(finalizedBlockRootsIndexBucket)
+		c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+		k, v := c.Seek(bytesutil.SlotToBytesBigEndian(beforeSlot + 1))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil; k, v = c.Prev() {
+			for i := 0; i+hashLength <= len(v); i += hashLength {
+				root := bytesutil.ToBytes32(v[i : i+hashLength])
+				if finalized.Get(root[:]) == nil || states.Get(root[:]) == nil {
+					continue
+				}
+				slot, err := s.slotByBlockRoot(ctx, tx, root[:])
+				if err != nil {
+					return err
+				}
+				stats.AnchorSlot, stats.AnchorRoot = slot, root
+				return nil
+			}
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// retainedDiffBases returns the roots of the full states used as the base of state diffs at or after the given slot.
+func (s *Store) retainedDiffBases(ctx context.Context, slot primitives.Slot) (map[[32]byte]bool, error) {
+	bases := make(map[[32]byte]bool)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateDiffBucket).ForEach(func(k, v []byte) error {
+			diffSlot, err := s.slotByBlockRoot(ctx, tx, k)
+			if err != nil {
+				return err
+			}
+			if diffSlot < slot {
+				return nil
+			}
+			diff, err := snappy.Decode(nil, v)
+			if err != nil {
+				return errors.Wrap(err, "could not decompress state diff")
+			}
+			if len(diff) < hashLength {
+				return errors.Errorf("invalid state diff length: %d", len(diff))
+			}
+			bases[bytesutil.ToBytes32(diff[:hashLength])] = true
+			return nil
+		})
+	})
+	return bases, err
+}
+
+// pruneBlocks deletes every block before the anchor slot along with its state, state diff, state summary and indices.
+func (s *Store) pruneBlocks(ctx context.Context, stats *PruneStats, keep map[[32]byte]bool) error {
+	next := bytesutil.SlotToBytesBigEndian(1)
+	end := bytesutil.SlotToBytesBigEndian(stats.AnchorSlot)
+	for next != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket(blockSlotIndicesBucket)
+			c := bkt.Cursor()
+			k, v := c.Seek(next)
+			pruned := make(map[string][]byte)
+			count := 0
+			for ; k != nil && bytes.Compare(k, end) < 0 && count < pruneBatchSize; k, v = c.Next() {
+				var kept []byte
+				for i := 0; i+hashLength <= len(v); i += hashLength {
+					root := bytesutil.ToBytes32(v[i : i+hashLength])
+					// Blocks of retained diff bases are kept along with their state.
+					if keep[root] {
+						kept = append(kept, root[:]...)
+						continue
+					}
+					if err := s.pruneBlockRoot(ctx, tx, root, stats); err != nil {
+						return err
+					}
+					count++
+				}
+				pruned[string(k)] = kept
+			}
+			next = nil
+			if k != nil && bytes.Compare(k, end) < 0 {
+				next = bytesutil.SafeCopyBytes(k)
+			}
+			for key, kept := range pruned {
+				if len(kept) > 0 {
+					if err := bkt.Put([]byte(key), kept); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := bkt.Delete([]byte(key)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneBlockRoot deletes the block of the given root with its state, state diff, state summary and block indices.
+func (s *Store) pruneBlockRoot(ctx context.Context, tx *bolt.Tx, root [32]byte, stats *PruneStats) error {
+	if tx.Bucket(stateBucket).Get(root[:]) != nil || hasStateDiff(tx, root) {
+		if err := s.deleteFullState(ctx, tx, root); err != nil {
+			return err
+		}
+		stats.States++
+	}
+	for _, b := range [][]byte{
+		stateDiffBucket,
+		stateDiffBasesBucket,
+		stateSummaryBucket,
+		blocksBucket,
+		blockParentRootIndicesBucket,
+		finalizedBlockRootsIndexBucket,
+	} {
+		if err := tx.Bucket(b).Delete(root[:]); err != nil {
+			return err
+		}
+	}
+	s.blockCache.Del(string(root[:]))
+	s.stateSummaryCache.delete(root)
+	stats.Blocks++
+	return nil
+}
+
+// pruneStates deletes the remaining states saved before the anchor slot, such as states without a block.
+func (s *Store) pruneStates(ctx context.Context, stats *PruneStats, keep map[[32]byte]bool) error {
+	var roots [][32]byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		end := bytesutil.SlotToBytesBigEndian(stats.AnchorSlot)
+		c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+		for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(1)); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+			for i := 0; i+hashLength <= len(v); i += hashLength {
+				root := bytesutil.ToBytes32(v[i : i+hashLength])
+				if !keep[root] {
+					roots = append(roots, root)
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for len(roots) > 0 {
+		batch := roots
+		if len(batch) > pruneBatchSize {
+			batch = batch[:pruneBatchSize]
+		}
+		roots = roots[len(batch):]
+		if err := s.db.Update(func(tx *bolt.Tx) error {
+			for _, root := range batch {
+				if err := s.deleteFullState(ctx, tx, root); err != nil {
+					return err
+				}
+				if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+					return err
+				}
+				s.stateSummaryCache.delete(root)
+				stats.States++
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneValidatorEntries deletes the validator entries which are no longer referenced by any state.
+func (s *Store) pruneValidatorEntries(ctx context.Context, stats *PruneStats) error {
+	ok, err := s.isStateValidatorMigrationOver()
+	if err != nil || !ok {
+		return err
+	}
+	referenced := make(map[[32]byte]bool)
+	var unreferenced [][]byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(blockRootValidatorHashesBucket).ForEach(func(_, v []byte) error {
+			hashes, err := snappy.Decode(nil, v)
+			if err != nil {
+				return errors.Wrap(err, "failed to uncompress validator keys")
+			}
+			for i := 0; i+hashLength <= len(hashes); i += hashLength {
+				referenced[bytesutil.ToBytes32(hashes[i:i+hashLength])] = true
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(stateValidatorsBucket).ForEach(func(k, _ []byte) error {
+			if !referenced[bytesutil.ToBytes32(k)] {
+				unreferenced = append(unreferenced, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	for len(unreferenced) > 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		batch := unreferenced
+		if len(batch) > pruneBatchSize {
+			batch = batch[:pruneBatchSize]
+		}
+		unreferenced = unreferenced[len(batch):]
+		if err := s.db.Update(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket(stateValidatorsBucket)
+			for _, k := range batch {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+				s.validatorEntryCache.Del(k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		stats.ValidatorEntries += len(batch)
+	}
+	return nil
+}
+
+// updateStatusAfterPrune records the anchor as the lowest available block in the backfill status, and moves the
+// state archive back to the anchor if the last archived state was pruned.
+func (s *Store) updateStatusAfterPrune(ctx context.Context, stats *PruneStats) error {
+	blk, err := s.Block(ctx, stats.AnchorRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get anchor block")
+	}
+	if blk == nil || blk.IsNil() {
+		return errors.Errorf("no block found for anchor root %#x", stats.AnchorRoot)
+	}
+	parentRoot := blk.Block().ParentRoot()
+	bs, err := s.BackfillStatus(ctx)
+	if errors.Is(err, ErrNotFound) {
+		bs = &dbval.BackfillStatus{OriginSlot: uint64(stats.AnchorSlot), OriginRoot: stats.AnchorRoot[:]}
+	} else if err != nil {
+		return errors.Wrap(err, "could not get backfill status")
+	}
+	if bs.LowSlot < uint64(stats.AnchorSlot) {
+		bs.LowSlot, bs.LowRoot, bs.LowParentRoot = uint64(stats.AnchorSlot), stats.AnchorRoot[:], parentRoot[:]
+		if err := s.SaveBackfillStatus(ctx, bs); err != nil {
+			return errors.Wrap(err, "could not save backfill status")
+		}
+	}
+
+	root, _, err := s.StateArchiveProgress(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !s.HasState(ctx, root) {
+		return s.SaveStateArchiveProgress(ctx, stats.AnchorRoot, stats.AnchorRoot)
+	}
+	return nil
+}
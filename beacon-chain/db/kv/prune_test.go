@@ -0,0 +1,103 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_PruneHistory(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	genesis := util.NewBeaconBlock()
+	util.SaveBlock(t, ctx, db, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, gRoot))
+	gState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, gState, gRoot))
+
+	blks := makeBlocks(t, 0, slotsPerEpoch*4, gRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: roots[i][:]}))
+	}
+	saveState := func(i uint64) {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blks[i].Block().Slot()))
+		require.NoError(t, db.SaveState(ctx, st, roots[i]))
+	}
+	// Full states at slots 16, 32 and 64, and a state diff at slot 80 computed against the state at slot 16.
+	saveState(15)
+	saveState(slotsPerEpoch - 1)
+	saveState(2*slotsPerEpoch - 1)
+	saveState(3*slotsPerEpoch - 1)
+	diffState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, diffState.SetSlot(blks[79].Block().Slot()))
+	require.NoError(t, db.SaveStateDiff(ctx, diffState, roots[79], roots[15]))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[3*slotsPerEpoch-1][:]}))
+
+	_, err = db.PruneHistory(ctx, primitives.Slot(4*slotsPerEpoch))
+	require.ErrorContains(t, "cannot prune history after the finalized slot", err)
+
+	anchor := 2*slotsPerEpoch - 1
+	stats, err := db.PruneHistory(ctx, primitives.Slot(anchor+2))
+	require.NoError(t, err)
+	assert.Equal(t, roots[anchor], stats.AnchorRoot)
+	assert.Equal(t, blks[anchor].Block().Slot(), stats.AnchorSlot)
+	assert.Equal(t, int(anchor)-1, stats.Blocks)
+	assert.Equal(t, 1, stats.States)
+
+	for i := uint64(0); i < anchor; i++ {
+		if i == 15 {
+			continue
+		}
+		assert.Equal(t, false, db.HasBlock(ctx, roots[i]), "block at index %d was not pruned", i)
+		assert.Equal(t, false, db.HasStateSummary(ctx, roots[i]), "state summary at index %d was not pruned", i)
+		assert.Equal(t, false, db.IsFinalizedBlock(ctx, roots[i]), "block at index %d is still in the finalized index", i)
+	}
+	assert.Equal(t, false, db.HasState(ctx, roots[slotsPerEpoch-1]))
+	for i := anchor; i < uint64(len(blks)); i++ {
+		assert.Equal(t, true, db.HasBlock(ctx, roots[i]), "block at index %d was pruned", i)
+	}
+	assert.Equal(t, true, db.HasBlock(ctx, gRoot))
+	assert.Equal(t, true, db.HasState(ctx, gRoot))
+	assert.Equal(t, true, db.HasState(ctx, roots[anchor]))
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[anchor]))
+
+	// The base of a retained state diff is kept.
+	assert.Equal(t, true, db.HasState(ctx, roots[15]))
+	st, err := db.StateOrError(ctx, roots[79])
+	require.NoError(t, err)
+	assert.Equal(t, blks[79].Block().Slot(), st.Slot())
+
+	fs, _, err := db.HighestRootsBelowSlot(ctx, primitives.Slot(anchor))
+	require.NoError(t, err)
+	assert.Equal(t, blks[15].Block().Slot(), fs)
+
+	bs, err := db.BackfillStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(stats.AnchorSlot), bs.LowSlot)
+	assert.DeepEqual(t, roots[anchor][:], bs.LowRoot)
+	assert.DeepEqual(t, roots[anchor-1][:], bs.LowParentRoot)
+
+	// Pruning again up to the same slot is a no-op.
+	stats, err = db.PruneHistory(ctx, primitives.Slot(anchor+2))
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Blocks)
+	assert.Equal(t, 0, stats.States)
+}
@@ -16,6 +16,15 @@ var (
 	stateValidatorsBucket = []byte("state-validators")
 	feeRecipientBucket    = []byte("fee-recipient")
 	registrationBucket    = []byte("registration")
+	stateDiffBucket       = []byte("state-diff")
+	stateDiffBasesBucket  = []byte("state-diff-bases")
+	headChangesBucket     = []byte("head-changes")
+	builderBidsBucket     = []byte("builder-bids")
+	validatorPerfBucket   = []byte("validator-performance")
+
+	// Light client data buckets.
+	lightClientUpdatesBucket    = []byte("light-client-updates")
+	lightClientBootstrapsBucket = []byte("light-client-bootstraps")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -37,6 +46,8 @@ var (
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
+	stateArchiveProgressKey    = []byte("state-archive-progress")
+	forkchoiceSnapshotKey      = []byte("forkchoice-snapshot")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
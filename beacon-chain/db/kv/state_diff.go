@@ -0,0 +1,272 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrStateDiffVersionMismatch is returned when a state diff is requested against a base state of another fork.
+var ErrStateDiffVersionMismatch = errors.New("state and diff base are from different forks")
+
+// SaveStateDiff stores the state of the given block root as a diff against the full state saved for the base root.
+// Every top level field of the state is encoded separately and XOR-ed with the same field of the base state, so
+// that unchanged fields and unchanged parts of large lists compress to almost nothing. Loading a state from a diff
+// only requires reading the base state and the diff, regardless of how far apart both states are.
+// The base state is kept in the database for as long as the diffs which depend on it.
+func (s *Store) SaveStateDiff(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	base, err := s.fullState(ctx, baseRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not load diff base state")
+	}
+	if base.Version() != st.Version() {
+		return ErrStateDiffVersionMismatch
+	}
+	baseFields, err := encodeStateFields(base.ToProtoUnsafe())
+	if err != nil {
+		return errors.Wrap(err, "could not encode diff base state")
+	}
+	fields, err := encodeStateFields(st.ToProtoUnsafe())
+	if err != nil {
+		return errors.Wrap(err, "could not encode state")
+	}
+	enc := snappy.Encode(nil, encodeStateDiff(baseRoot, baseFields, fields))
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(stateDiffBasesBucket).Put(baseRoot[:], []byte{}); err != nil {
+			return err
+		}
+		return tx.Bucket(stateDiffBucket).Put(blockRoot[:], enc)
+	})
+}
+
+// StateArchiveProgress returns the block root of the last state saved by the historical state archive,
+// along with the root of the full state the next diffs are computed against.
+func (s *Store) StateArchiveProgress(ctx context.Context) ([32]byte, [32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.StateArchiveProgress")
+	defer span.End()
+	var root, baseRoot [32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(stateArchiveProgressKey)
+		if len(enc) == 0 {
+			return errors.Wrap(ErrNotFound, "state archive progress not found")
+		}
+		if len(enc) != 2*hashLength {
+			return errors.Errorf("invalid state archive progress length: %d", len(enc))
+		}
+		copy(root[:], enc[:hashLength])
+		copy(baseRoot[:], enc[hashLength:])
+		return nil
+	})
+	return root, baseRoot, err
+}
+
+// SaveStateArchiveProgress records the last state saved by the historical state archive, so that
+// archiving resumes from there after a restart.
+func (s *Store) SaveStateArchiveProgress(ctx context.Context, root, baseRoot [32]byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveStateArchiveProgress")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(stateArchiveProgressKey, append(root[:], baseRoot[:]...))
+	})
+}
+
+// stateFromDiff reconstructs the state of the given block root from its diff. It returns nil if no diff is stored.
+func (s *Store) stateFromDiff(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.stateFromDiff")
+	defer span.End()
+	startTime := time.Now()
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(stateDiffBucket).Get(blockRoot[:]))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	diff, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state diff")
+	}
+	if len(diff) < hashLength {
+		return nil, errors.Errorf("invalid state diff length: %d", len(diff))
+	}
+	baseRoot := bytesutil.ToBytes32(diff[:hashLength])
+	base, err := s.fullState(ctx, baseRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load base state %#x of state diff", baseRoot)
+	}
+	basePb, ok := base.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return nil, errors.New("base state is not a proto message")
+	}
+	baseFields, err := encodeStateFields(basePb)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode diff base state")
+	}
+	fields, err := applyStateDiff(baseFields, diff[hashLength:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not apply state diff of block root %#x", blockRoot)
+	}
+	target := basePb.ProtoReflect().New().Interface()
+	for _, f := range fields {
+		if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(f, target); err != nil {
+			return nil, errors.Wrap(err, "could not decode state field")
+		}
+	}
+	st, err := initializeStateFromProto(target)
+	if err != nil {
+		return nil, err
+	}
+	stateDiffReadingTime.Observe(float64(time.Since(startTime).Milliseconds()))
+	return st, nil
+}
+
+// fullState returns the state stored in full for the given block root, ignoring state diffs.
+func (s *Store) fullState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	enc, err := s.stateBytes(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, errors.Wrap(ErrNotFoundState, fmt.Sprintf("no full state with blockroot=%#x", blockRoot))
+	}
+	valEntries, err := s.validatorEntries(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	return s.unmarshalState(ctx, enc, valEntries)
+}
+
+func hasStateDiff(tx *bolt.Tx, blockRoot [32]byte) bool {
+	return len(tx.Bucket(stateDiffBucket).Get(blockRoot[:])) > 0
+}
+
+func isStateDiffBase(tx *bolt.Tx, blockRoot [32]byte) bool {
+	return tx.Bucket(stateDiffBasesBucket).Get(blockRoot[:]) != nil
+}
+
+// encodeStateFields encodes every top level field of a state proto on its own, keyed by field number.
+func encodeStateFields(pb interface{}) (map[protowire.Number][]byte, error) {
+	msg, ok := pb.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("%T is not a proto message", pb)
+	}
+	src := msg.ProtoReflect()
+	fds := src.Descriptor().Fields()
+	fields := make(map[protowire.Number][]byte, fds.Len())
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		single := src.New()
+		if src.Has(fd) {
+			single.Set(fd, src.Get(fd))
+		}
+		enc, err := proto.MarshalOptions{Deterministic: true}.Marshal(single.Interface())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encode field %s", fd.Name())
+		}
+		fields[fd.Number()] = enc
+	}
+	return fields, nil
+}
+
+// encodeStateDiff encodes the base root followed by one entry per field: the field number and the length of the
+// XOR-ed field plus one, or zero if the field did not change.
+func encodeStateDiff(baseRoot [32]byte, baseFields, fields map[protowire.Number][]byte) []byte {
+	nums := make([]protowire.Number, 0, len(fields))
+	for num := range fields {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	buf := make([]byte, 0, hashLength)
+	buf = append(buf, baseRoot[:]...)
+	for _, num := range nums {
+		f := fields[num]
+		buf = protowire.AppendVarint(buf, uint64(num))
+		baseField := baseFields[num]
+		if bytes.Equal(f, baseField) {
+			buf = protowire.AppendVarint(buf, 0)
+			continue
+		}
+		buf = protowire.AppendVarint(buf, uint64(len(f))+1)
+		buf = append(buf, xorBytes(f, baseField)...)
+	}
+	return buf
+}
+
+// applyStateDiff returns the encoded fields of the target state from the encoded fields of the base state.
+func applyStateDiff(baseFields map[protowire.Number][]byte, diff []byte) ([][]byte, error) {
+	fields := make([][]byte, 0, len(baseFields))
+	for len(diff) > 0 {
+		num, n := protowire.ConsumeVarint(diff)
+		if n < 0 {
+			return nil, errors.New("invalid field number")
+		}
+		diff = diff[n:]
+		size, n := protowire.ConsumeVarint(diff)
+		if n < 0 {
+			return nil, errors.New("invalid field length")
+		}
+		diff = diff[n:]
+		baseField := baseFields[protowire.Number(num)]
+		if size == 0 {
+			fields = append(fields, baseField)
+			continue
+		}
+		size--
+		if uint64(len(diff)) < size {
+			return nil, errors.Errorf("field %d is truncated", num)
+		}
+		fields = append(fields, xorBytes(diff[:size], baseField))
+		diff = diff[size:]
+	}
+	return fields, nil
+}
+
+// xorBytes returns a copy of a XOR-ed with the overlapping prefix of b.
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	copy(out, a)
+	for i := 0; i < len(out) && i < len(b); i++ {
+		out[i] ^= b[i]
+	}
+	return out
+}
+
+func initializeStateFromProto(pb proto.Message) (state.BeaconState, error) {
+	switch p := pb.(type) {
+	case *ethpb.BeaconState:
+		return statenative.InitializeFromProtoUnsafePhase0(p)
+	case *ethpb.BeaconStateAltair:
+		return statenative.InitializeFromProtoUnsafeAltair(p)
+	case *ethpb.BeaconStateBellatrix:
+		return statenative.InitializeFromProtoUnsafeBellatrix(p)
+	case *ethpb.BeaconStateCapella:
+		return statenative.InitializeFromProtoUnsafeCapella(p)
+	case *ethpb.BeaconStateDeneb:
+		return statenative.InitializeFromProtoUnsafeDeneb(p)
+	case *ethpb.BeaconStateElectra:
+		return statenative.InitializeFromProtoUnsafeElectra(p)
+	default:
+		return nil, errors.Errorf("unsupported state type %T", pb)
+	}
+}
@@ -0,0 +1,110 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_SaveStateDiff(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	base, _ := util.DeterministicGenesisStateDeneb(t, 64)
+	require.NoError(t, base.SetSlot(64))
+	baseRoot := [32]byte{'b'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(96))
+	require.NoError(t, st.UpdateBalancesAtIndex(3, params.BeaconConfig().MaxEffectiveBalance-1))
+	require.NoError(t, st.AppendValidator(&ethpb.Validator{
+		PublicKey:             bytesutil.PadTo([]byte{'v'}, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+	}))
+	require.NoError(t, st.AppendBalance(params.BeaconConfig().MaxEffectiveBalance))
+	require.NoError(t, st.AppendInactivityScore(0))
+	require.NoError(t, st.AppendCurrentParticipationBits(0))
+	require.NoError(t, st.AppendPreviousParticipationBits(0))
+	wantRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	root := [32]byte{'d'}
+	require.Equal(t, false, db.HasState(ctx, root))
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+	require.Equal(t, true, db.HasState(ctx, root))
+
+	got, err := db.StateOrError(ctx, root)
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+	assert.Equal(t, st.Version(), got.Version())
+	assert.Equal(t, 65, got.NumValidators())
+
+	// The base state is still served in full.
+	gotBase, err := db.StateOrError(ctx, baseRoot)
+	require.NoError(t, err)
+	assert.Equal(t, base.Slot(), gotBase.Slot())
+}
+
+func TestStore_SaveStateDiff_MissingBase(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateDeneb(t, 8)
+	err := db.SaveStateDiff(ctx, st, [32]byte{'d'}, [32]byte{'b'})
+	require.ErrorIs(t, err, ErrNotFoundState)
+}
+
+func TestStore_SaveStateDiff_VersionMismatch(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	base, _ := util.DeterministicGenesisStateCapella(t, 8)
+	baseRoot := [32]byte{'b'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+	st, _ := util.DeterministicGenesisStateDeneb(t, 8)
+	require.ErrorIs(t, db.SaveStateDiff(ctx, st, [32]byte{'d'}, baseRoot), ErrStateDiffVersionMismatch)
+}
+
+func TestStore_DeleteStateDiff(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	base, _ := util.DeterministicGenesisStateDeneb(t, 8)
+	baseRoot := [32]byte{'b'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(32))
+	root := [32]byte{'d'}
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+
+	// The base of a diff is not deleted.
+	require.NoError(t, db.DeleteState(ctx, baseRoot))
+	require.Equal(t, true, db.HasState(ctx, baseRoot))
+
+	require.NoError(t, db.DeleteState(ctx, root))
+	require.Equal(t, false, db.HasState(ctx, root))
+	got, err := db.State(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, true, got == nil)
+}
+
+func TestStore_StateArchiveProgress(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, _, err := db.StateArchiveProgress(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	root, baseRoot := [32]byte{'r'}, [32]byte{'b'}
+	require.NoError(t, db.SaveStateArchiveProgress(ctx, root, baseRoot))
+	gotRoot, gotBaseRoot, err := db.StateArchiveProgress(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, gotRoot)
+	assert.Equal(t, baseRoot, gotBaseRoot)
+}
@@ -0,0 +1,180 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// maxValidatorPerformanceEpochs is the number of epochs of validator performance kept in the database,
+// a bit more than a month. Records of older epochs are removed when new ones are saved.
+var maxValidatorPerformanceEpochs = primitives.Epoch(8192)
+
+var errInvalidEpochRange = errors.New("invalid end epoch and start epoch provided")
+
+const validatorPerformanceLength = 8*7 + 1
+
+const (
+	perfAttestationExpected = 1 << iota
+	perfAttestationIncluded
+	perfCorrectSource
+	perfCorrectTarget
+	perfCorrectHead
+)
+
+// SaveValidatorPerformance stores per epoch performance records of tracked validators, replacing any previous
+// record for the same epoch and validator.
+func (s *Store) SaveValidatorPerformance(ctx context.Context, records []*monitortypes.EpochPerformance) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorPerformance")
+	defer span.End()
+	if len(records) == 0 {
+		return nil
+	}
+	latest := primitives.Epoch(0)
+	for _, r := range records {
+		if r == nil {
+			return errors.New("nil validator performance record")
+		}
+		if r.Epoch > latest {
+			latest = r.Epoch
+		}
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorPerfBucket)
+		for _, r := range records {
+			if err := bkt.Put(validatorPerformanceKey(r.Epoch, r.ValidatorIndex), encodeValidatorPerformance(r)); err != nil {
+				return err
+			}
+		}
+		if latest < maxValidatorPerformanceEpochs {
+			return nil
+		}
+		cutoff := latest - maxValidatorPerformanceEpochs
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil && bytesutil.BytesToEpochBigEndian(k[:8]) < cutoff; k, _ = c.First() {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ValidatorPerformance returns the performance records between the given epochs, inclusive, sorted by epoch
+// and validator index. Records are returned for all validators when no index is given.
+func (s *Store) ValidatorPerformance(
+	ctx context.Context,
+	startEpoch, endEpoch primitives.Epoch,
+	indices []primitives.ValidatorIndex,
+) ([]*monitortypes.EpochPerformance, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorPerformance")
+	defer span.End()
+	if startEpoch > endEpoch {
+		return nil, errInvalidEpochRange
+	}
+	wanted := make(map[primitives.ValidatorIndex]bool, len(indices))
+	for _, idx := range indices {
+		wanted[idx] = true
+	}
+	records := make([]*monitortypes.EpochPerformance, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(validatorPerfBucket).Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(startEpoch)); k != nil; k, v = c.Next() {
+			epoch, idx, err := decodeValidatorPerformanceKey(k)
+			if err != nil {
+				return err
+			}
+			if epoch > endEpoch {
+				break
+			}
+			if len(wanted) > 0 && !wanted[idx] {
+				continue
+			}
+			r, err := decodeValidatorPerformance(v)
+			if err != nil {
+				return err
+			}
+			r.Epoch = epoch
+			r.ValidatorIndex = idx
+			records = append(records, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func validatorPerformanceKey(epoch primitives.Epoch, idx primitives.ValidatorIndex) []byte {
+	return append(bytesutil.EpochToBytesBigEndian(epoch), bytesutil.Uint64ToBytesBigEndian(uint64(idx))...)
+}
+
+func decodeValidatorPerformanceKey(k []byte) (primitives.Epoch, primitives.ValidatorIndex, error) {
+	if len(k) != 16 {
+		return 0, 0, errors.Errorf("invalid validator performance key length: %d", len(k))
+	}
+	return primitives.Epoch(binary.BigEndian.Uint64(k[:8])), primitives.ValidatorIndex(binary.BigEndian.Uint64(k[8:])), nil
+}
+
+func encodeValidatorPerformance(r *monitortypes.EpochPerformance) []byte {
+	var flags byte
+	if r.AttestationExpected {
+		flags |= perfAttestationExpected
+	}
+	if r.AttestationIncluded {
+		flags |= perfAttestationIncluded
+	}
+	if r.CorrectSource {
+		flags |= perfCorrectSource
+	}
+	if r.CorrectTarget {
+		flags |= perfCorrectTarget
+	}
+	if r.CorrectHead {
+		flags |= perfCorrectHead
+	}
+	enc := make([]byte, 0, validatorPerformanceLength)
+	enc = append(enc, flags)
+	enc = binary.BigEndian.AppendUint64(enc, r.InclusionDistance)
+	enc = binary.BigEndian.AppendUint64(enc, r.SyncCommitteeExpected)
+	enc = binary.BigEndian.AppendUint64(enc, r.SyncCommitteeIncluded)
+	enc = binary.BigEndian.AppendUint64(enc, r.ProposalsExpected)
+	enc = binary.BigEndian.AppendUint64(enc, r.ProposalsIncluded)
+	enc = binary.BigEndian.AppendUint64(enc, r.StartBalance)
+	enc = binary.BigEndian.AppendUint64(enc, r.EndBalance)
+	return enc
+}
+
+func decodeValidatorPerformance(enc []byte) (*monitortypes.EpochPerformance, error) {
+	if len(enc) != validatorPerformanceLength {
+		return nil, errors.Errorf("invalid validator performance length: %d", len(enc))
+	}
+	flags := enc[0]
+	enc = enc[1:]
+	nextUint64 := func() uint64 {
+		v := binary.BigEndian.Uint64(enc)
+		enc = enc[8:]
+		return v
+	}
+	return &monitortypes.EpochPerformance{
+		AttestationExpected:   flags&perfAttestationExpected != 0,
+		AttestationIncluded:   flags&perfAttestationIncluded != 0,
+		CorrectSource:         flags&perfCorrectSource != 0,
+		CorrectTarget:         flags&perfCorrectTarget != 0,
+		CorrectHead:           flags&perfCorrectHead != 0,
+		InclusionDistance:     nextUint64(),
+		SyncCommitteeExpected: nextUint64(),
+		SyncCommitteeIncluded: nextUint64(),
+		ProposalsExpected:     nextUint64(),
+		ProposalsIncluded:     nextUint64(),
+		StartBalance:          nextUint64(),
+		EndBalance:            nextUint64(),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ValidatorPerformance(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	record := &monitortypes.EpochPerformance{
+		Epoch:                 5,
+		ValidatorIndex:        7,
+		AttestationExpected:   true,
+		AttestationIncluded:   true,
+		InclusionDistance:     2,
+		CorrectSource:         true,
+		CorrectHead:           true,
+		SyncCommitteeExpected: 32,
+		SyncCommitteeIncluded: 30,
+		ProposalsExpected:     1,
+		StartBalance:          32000000000,
+		EndBalance:            32000012345,
+	}
+	require.NoError(t, db.SaveValidatorPerformance(ctx, []*monitortypes.EpochPerformance{
+		record,
+		{Epoch: 5, ValidatorIndex: 3},
+		{Epoch: 6, ValidatorIndex: 7, AttestationExpected: true},
+		{Epoch: 8, ValidatorIndex: 3},
+	}))
+
+	records, err := db.ValidatorPerformance(ctx, 5, 6, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	require.Equal(t, primitives.ValidatorIndex(3), records[0].ValidatorIndex)
+	require.DeepEqual(t, record, records[1])
+	require.Equal(t, primitives.Epoch(6), records[2].Epoch)
+
+	records, err = db.ValidatorPerformance(ctx, 0, 100, []primitives.ValidatorIndex{3})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, primitives.Epoch(8), records[1].Epoch)
+
+	_, err = db.ValidatorPerformance(ctx, 2, 1, nil)
+	require.ErrorIs(t, err, errInvalidEpochRange)
+}
+
+func TestStore_ValidatorPerformance_Prune(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	defer func(m primitives.Epoch) { maxValidatorPerformanceEpochs = m }(maxValidatorPerformanceEpochs)
+	maxValidatorPerformanceEpochs = 4
+
+	for epoch := primitives.Epoch(1); epoch <= 10; epoch++ {
+		require.NoError(t, db.SaveValidatorPerformance(ctx, []*monitortypes.EpochPerformance{
+			{Epoch: epoch, ValidatorIndex: 1},
+			{Epoch: epoch, ValidatorIndex: 2},
+		}))
+	}
+	records, err := db.ValidatorPerformance(ctx, 0, 100, nil)
+	require.NoError(t, err)
+	require.Equal(t, 10, len(records))
+	require.Equal(t, primitives.Epoch(6), records[0].Epoch)
+}
@@ -0,0 +1,71 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// DetectionProgress returns the last epoch up to which slashing detection processed every block,
+// and whether such an epoch was ever recorded.
+func (s *Store) DetectionProgress(ctx context.Context) (primitives.Epoch, bool, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DetectionProgress")
+	defer span.End()
+
+	var (
+		epoch primitives.Epoch
+		found bool
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(slasherMetadataBucket).Get(detectionProgressKey)
+		if enc == nil {
+			return nil
+		}
+		if len(enc) != 8 {
+			return fmt.Errorf("wrong length for encoded detection progress, want 8, got %d", len(enc))
+		}
+		epoch = primitives.Epoch(binary.BigEndian.Uint64(enc))
+		found = true
+		return nil
+	})
+	return epoch, found, err
+}
+
+// SaveDetectionProgress records the last epoch up to which slashing detection processed every block.
+func (s *Store) SaveDetectionProgress(ctx context.Context, epoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveDetectionProgress")
+	defer span.End()
+
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, uint64(epoch))
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(slasherMetadataBucket).Put(detectionProgressKey, enc)
+	})
+}
+
+// LastEpochWrittenForAllValidators returns the latest epoch recorded for every validator
+// the slasher has written data for.
+func (s *Store) LastEpochWrittenForAllValidators(ctx context.Context) (map[primitives.ValidatorIndex]primitives.Epoch, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastEpochWrittenForAllValidators")
+	defer span.End()
+
+	epochByValidator := make(map[primitives.ValidatorIndex]primitives.Epoch)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(attestedEpochsByValidator).ForEach(func(k, v []byte) error {
+			if len(k) != validatorIndexSize {
+				return fmt.Errorf("wrong length for encoded validator index, want %d, got %d", validatorIndexSize, len(k))
+			}
+			var epoch primitives.Epoch
+			if err := epoch.UnmarshalSSZ(v); err != nil {
+				return err
+			}
+			epochByValidator[decodeValidatorIndex(k)] = epoch
+			return nil
+		})
+	})
+	return epochByValidator, err
+}
@@ -0,0 +1,41 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_DetectionProgress(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	_, ok, err := beaconDB.DetectionProgress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	require.NoError(t, beaconDB.SaveDetectionProgress(ctx, 10))
+	require.NoError(t, beaconDB.SaveDetectionProgress(ctx, 12))
+	epoch, ok, err := beaconDB.DetectionProgress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.Equal(t, primitives.Epoch(12), epoch)
+}
+
+func TestStore_LastEpochWrittenForAllValidators(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	epochByValidator := map[primitives.ValidatorIndex]primitives.Epoch{
+		0:             3,
+		7:             5,
+		1<<32 + 1<<16: 9,
+	}
+	require.NoError(t, beaconDB.SaveLastEpochWrittenForValidators(ctx, epochByValidator))
+
+	retrieved, err := beaconDB.LastEpochWrittenForAllValidators(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, epochByValidator, retrieved)
+}
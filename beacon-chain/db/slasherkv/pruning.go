@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/binary"
 
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	bolt "go.etcd.io/bbolt"
@@ -165,3 +167,37 @@ func uint64PrefixGreaterThan(key, lessThan []byte) bool {
 	enc := key[:8]
 	return bytes.Compare(enc, lessThan) > 0
 }
+
+// DeleteSlasherChunks deletes the min or max span chunks with the specified disk keys,
+// returning the number of chunks which were actually stored.
+func (s *Store) DeleteSlasherChunks(
+	ctx context.Context, kind slashertypes.ChunkKind, chunkKeys [][]byte,
+) (numDeleted uint, err error) {
+	encodedKind := ssz.MarshalUint8(make([]byte, 0), uint8(kind))
+
+	// Delete chunks from the database by batch.
+	for start := 0; start < len(chunkKeys); start += batchSize {
+		if ctx.Err() != nil {
+			return numDeleted, ctx.Err()
+		}
+		stop := min(start+batchSize, len(chunkKeys))
+		if err = s.db.Update(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket(slasherChunksBucket)
+			for _, chunkKey := range chunkKeys[start:stop] {
+				encodedKey := append(encodedKind, chunkKey...)
+				if bkt.Get(encodedKey) == nil {
+					continue
+				}
+				if err := bkt.Delete(encodedKey); err != nil {
+					return err
+				}
+				slasherChunksPrunedTotal.Inc()
+				numDeleted++
+			}
+			return nil
+		}); err != nil {
+			return
+		}
+	}
+	return
+}
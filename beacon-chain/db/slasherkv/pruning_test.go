@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	ssz "github.com/prysmaticlabs/fastssz"
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -216,3 +217,26 @@ func TestStore_PruneAttestations_OK(t *testing.T) {
 		}
 	})
 }
+
+func TestStore_DeleteSlasherChunks(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	keys := [][]byte{ssz.MarshalUint64(nil, 1), ssz.MarshalUint64(nil, 2), ssz.MarshalUint64(nil, 3)}
+	chunks := [][]uint16{{1, 2}, {3, 4}, {5, 6}}
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, keys, chunks))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, keys, chunks))
+
+	// Missing keys are not counted.
+	numDeleted, err := beaconDB.DeleteSlasherChunks(ctx, slashertypes.MinSpan, [][]byte{keys[0], keys[2], ssz.MarshalUint64(nil, 4)})
+	require.NoError(t, err)
+	require.Equal(t, uint(2), numDeleted)
+
+	_, exist, err := beaconDB.LoadSlasherChunks(ctx, slashertypes.MinSpan, keys)
+	require.NoError(t, err)
+	require.DeepEqual(t, []bool{false, true, false}, exist)
+	// Chunks of the other kind are left untouched.
+	_, exist, err = beaconDB.LoadSlasherChunks(ctx, slashertypes.MaxSpan, keys)
+	require.NoError(t, err)
+	require.DeepEqual(t, []bool{true, true, true}, exist)
+}
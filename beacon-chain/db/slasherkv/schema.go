@@ -24,4 +24,10 @@ var (
 	// value: (encoded) SignedBlockHeaderWrapper
 	proposalRecordsBucket = []byte("proposal-records")
 	slasherChunksBucket   = []byte("slasher-chunks")
+
+	// key: detectionProgressKey
+	// value: (encoded) Epoch
+	slasherMetadataBucket = []byte("slasher-metadata")
 )
+
+var detectionProgressKey = []byte("detection-progress")
@@ -23,6 +23,7 @@ import (
 const (
 	attestationRecordKeySize = 32 // Bytes.
 	rootSize                 = 32 // Bytes.
+	validatorIndexSize       = 5  // Bytes.
 
 	// For database performance reasons, database read/write operations
 	// are chunked into batches of maximum `batchSize` elements.
@@ -789,3 +790,12 @@ func encodeValidatorIndex(index primitives.ValidatorIndex) []byte {
 	buf[4] = byte(v >> 32)
 	return buf
 }
+
+// Decodes a validator index encoded with encodeValidatorIndex.
+func decodeValidatorIndex(enc []byte) primitives.ValidatorIndex {
+	var v uint64
+	for i := validatorIndexSize - 1; i >= 0; i-- {
+		v = v<<8 | uint64(enc[i])
+	}
+	return primitives.ValidatorIndex(v)
+}
@@ -0,0 +1,158 @@
+package slasherkv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+// VerificationReport counts the entries checked by Verify in each bucket, and the invalid ones.
+type VerificationReport struct {
+	SpanChunks                   uint64
+	InvalidSpanChunks            uint64
+	AttestationRecords           uint64
+	InvalidAttestationRecords    uint64
+	AttestationDataRoots         uint64
+	DanglingAttestationDataRoots uint64
+	Proposals                    uint64
+	InvalidProposals             uint64
+	ValidatorEpochs              uint64
+	InvalidValidatorEpochs       uint64
+	// Deleted is the number of invalid entries deleted when repairing the database.
+	Deleted uint64
+}
+
+// Invalid returns the total number of invalid entries found.
+func (r *VerificationReport) Invalid() uint64 {
+	return r.InvalidSpanChunks + r.InvalidAttestationRecords + r.DanglingAttestationDataRoots +
+		r.InvalidProposals + r.InvalidValidatorEpochs
+}
+
+// Verify checks that every entry of the slasher database can be decoded: span chunks must hold
+// chunkLength values, attestation records and proposals must be valid SSZ, and attestation data roots
+// must point to an existing attestation record. When repair is set, the invalid entries are deleted.
+// It is meant to be used on a database which is not in use by a beacon node.
+func (s *Store) Verify(ctx context.Context, chunkLength int, repair bool) (*VerificationReport, error) {
+	report := &VerificationReport{}
+	invalidKeys := make(map[string][][]byte)
+	invalid := func(bucket []byte, key []byte) {
+		invalidKeys[string(bucket)] = append(invalidKeys[string(bucket)], bytesutil.SafeCopyBytes(key))
+	}
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(slasherChunksBucket).ForEach(func(k, v []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.SpanChunks++
+			if len(k) != 9 || (k[0] != byte(slashertypes.MinSpan) && k[0] != byte(slashertypes.MaxSpan)) {
+				report.InvalidSpanChunks++
+				invalid(slasherChunksBucket, k)
+				return nil
+			}
+			chunk, err := decodeSlasherChunk(v)
+			if err != nil || len(chunk) != chunkLength {
+				report.InvalidSpanChunks++
+				invalid(slasherChunksBucket, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Data roots pointing to an invalid attestation record are dangling once the record is deleted.
+		invalidRecords := make(map[string]bool)
+		attRecordsBkt := tx.Bucket(attestationRecordsBucket)
+		if err := attRecordsBkt.ForEach(func(k, v []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.AttestationRecords++
+			if len(k) != attestationRecordKeySize {
+				report.InvalidAttestationRecords++
+				invalid(attestationRecordsBucket, k)
+				return nil
+			}
+			if _, err := decodeAttestationRecord(v); err != nil {
+				report.InvalidAttestationRecords++
+				invalid(attestationRecordsBucket, k)
+				invalidRecords[string(k)] = true
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(attestationDataRootsBucket).ForEach(func(k, v []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.AttestationDataRoots++
+			if len(k) != 8+validatorIndexSize || len(v) != attestationRecordKeySize ||
+				attRecordsBkt.Get(v) == nil || invalidRecords[string(v)] {
+				report.DanglingAttestationDataRoots++
+				invalid(attestationDataRootsBucket, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(proposalRecordsBucket).ForEach(func(k, v []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.Proposals++
+			if len(k) != 8+validatorIndexSize {
+				report.InvalidProposals++
+				invalid(proposalRecordsBucket, k)
+				return nil
+			}
+			if _, err := decodeProposalRecord(v); err != nil {
+				report.InvalidProposals++
+				invalid(proposalRecordsBucket, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return tx.Bucket(attestedEpochsByValidator).ForEach(func(k, v []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.ValidatorEpochs++
+			var epoch primitives.Epoch
+			if len(k) != validatorIndexSize || epoch.UnmarshalSSZ(v) != nil {
+				report.InvalidValidatorEpochs++
+				invalid(attestedEpochsByValidator, k)
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not verify slasher database")
+	}
+
+	if !repair || len(invalidKeys) == 0 {
+		return report, nil
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		for bucket, keys := range invalidKeys {
+			bkt := tx.Bucket([]byte(bucket))
+			for _, k := range keys {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+				report.Deleted++
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not delete invalid entries")
+	}
+	return report, nil
+}
@@ -0,0 +1,57 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_Verify(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	keys := [][]byte{ssz.MarshalUint64(nil, 1), ssz.MarshalUint64(nil, 2)}
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, keys, [][]uint16{{1, 2}, {3, 4}}))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, keys[:1], [][]uint16{{1, 2, 3}}))
+	require.NoError(t, beaconDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapper(1, 2, []uint64{0, 1}, []byte{1}),
+		createAttestationWrapper(2, 3, []uint64{0}, []byte{2}),
+	}))
+	require.NoError(t, beaconDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 4, 1, []byte{1}),
+	}))
+
+	report, err := beaconDB.Verify(ctx, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), report.SpanChunks)
+	// The max span chunk does not have the expected length.
+	require.Equal(t, uint64(1), report.InvalidSpanChunks)
+	require.Equal(t, uint64(2), report.AttestationRecords)
+	require.Equal(t, uint64(3), report.AttestationDataRoots)
+	require.Equal(t, uint64(1), report.Proposals)
+	require.Equal(t, uint64(1), report.Invalid())
+
+	// Corrupt an attestation record, the data roots pointing to it become dangling.
+	require.NoError(t, beaconDB.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(attestationRecordsBucket)
+		k, _ := bkt.Cursor().First()
+		return bkt.Put(k, []byte("corrupted"))
+	}))
+	report, err = beaconDB.Verify(ctx, 2, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), report.InvalidAttestationRecords)
+	require.Equal(t, uint64(2), report.DanglingAttestationDataRoots)
+	require.Equal(t, uint64(4), report.Invalid())
+	require.Equal(t, uint64(4), report.Deleted)
+
+	report, err = beaconDB.Verify(ctx, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), report.Invalid())
+	require.Equal(t, uint64(2), report.SpanChunks)
+	require.Equal(t, uint64(1), report.AttestationRecords)
+	require.Equal(t, uint64(1), report.AttestationDataRoots)
+}
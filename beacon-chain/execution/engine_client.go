@@ -215,6 +215,7 @@ func (s *Service) ForkchoiceUpdated(
 	if attrs == nil {
 		return nil, nil, errors.New("nil payload attributer")
 	}
+	defer s.mirrorForkchoiceUpdated(state, attrs.Version())
 	switch attrs.Version() {
 	case version.Bellatrix:
 		a, err := attrs.PbV1()
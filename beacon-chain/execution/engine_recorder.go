@@ -0,0 +1,135 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+)
+
+// EngineCallRecorder appends the JSON-RPC calls made to the execution client to a file, with their results
+// and timing. The recording can be read with types.ReadEngineCalls and replayed by the mock execution client
+// of the execution testing package.
+//
+// Only the calls made through the RPC client of the service are recorded: the engine API, and the block and
+// header requests. The ethclient used for the deposit contract and the deposit logs needs the geth RPC client
+// itself, which cannot be wrapped, so its calls (eth_chainId, eth_getLogs, eth_call, eth_getCode) are left out.
+type EngineCallRecorder struct {
+	lock sync.Mutex
+	w    io.WriteCloser
+	enc  *json.Encoder
+}
+
+// NewEngineCallRecorder opens the recording file, calls are appended to the existing content.
+func NewEngineCallRecorder(path string) (*EngineCallRecorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open engine API recording file")
+	}
+	return &EngineCallRecorder{w: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Close closes the recording file.
+func (r *EngineCallRecorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.w.Close()
+}
+
+func (r *EngineCallRecorder) record(method string, args []interface{}, result json.RawMessage, err error, start time.Time) {
+	call := &types.EngineCall{
+		Method:   method,
+		Params:   make([]json.RawMessage, len(args)),
+		Start:    start,
+		Duration: time.Since(start),
+	}
+	for i, arg := range args {
+		enc, mErr := json.Marshal(arg)
+		if mErr != nil {
+			log.WithError(mErr).WithField("method", method).Debug("Could not encode engine call parameter")
+			enc = json.RawMessage("null")
+		}
+		call.Params[i] = enc
+	}
+	if err != nil {
+		call.Error = &types.EngineCallError{Message: err.Error()}
+		var rpcErr gethRPC.Error
+		if errors.As(err, &rpcErr) {
+			call.Error.Code = rpcErr.ErrorCode()
+		}
+		var dataErr gethRPC.DataError
+		if errors.As(err, &dataErr) {
+			if data, mErr := json.Marshal(dataErr.ErrorData()); mErr == nil {
+				call.Error.Data = data
+			}
+		}
+	} else {
+		call.Result = result
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := r.enc.Encode(call); err != nil {
+		log.WithError(err).WithField("method", method).Error("Could not record engine call")
+	}
+}
+
+// recordingRPCClient records the calls made through an RPC client. Results are decoded from the raw
+// JSON received from the execution client, so that the recording holds the exact responses.
+type recordingRPCClient struct {
+	client   RPCClient
+	recorder *EngineCallRecorder
+}
+
+func (c *recordingRPCClient) Close() {
+	c.client.Close()
+}
+
+func (c *recordingRPCClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	start := time.Now()
+	var raw json.RawMessage
+	err := c.client.CallContext(ctx, &raw, method, args...)
+	c.recorder.record(method, args, raw, err, start)
+	if err != nil || result == nil {
+		return err
+	}
+	return json.Unmarshal(raw, result)
+}
+
+func (c *recordingRPCClient) BatchCall(b []gethRPC.BatchElem) error {
+	start := time.Now()
+	raws := make([]json.RawMessage, len(b))
+	elems := make([]gethRPC.BatchElem, len(b))
+	for i := range b {
+		elems[i] = gethRPC.BatchElem{Method: b[i].Method, Args: b[i].Args, Result: &raws[i]}
+	}
+	err := c.client.BatchCall(elems)
+	for i := range elems {
+		if err != nil {
+			c.recorder.record(elems[i].Method, elems[i].Args, nil, err, start)
+			continue
+		}
+		c.recorder.record(elems[i].Method, elems[i].Args, raws[i], elems[i].Error, start)
+		b[i].Error = elems[i].Error
+		if b[i].Error == nil && b[i].Result != nil {
+			b[i].Error = json.Unmarshal(raws[i], b[i].Result)
+		}
+	}
+	return err
+}
+
+// recordCalls wraps the client so that its calls are recorded, if a recorder is configured. The ethclient
+// built on the same connection bypasses the wrapper, see EngineCallRecorder.
+func (s *Service) recordCalls(client RPCClient) RPCClient {
+	if s.cfg.engineCallRecorder == nil {
+		return client
+	}
+	return &recordingRPCClient{client: client, recorder: s.cfg.engineCallRecorder}
+}
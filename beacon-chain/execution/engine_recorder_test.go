@@ -0,0 +1,144 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	mockExecution "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func readRecording(t *testing.T, path string) []*types.EngineCall {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	calls, err := types.ReadEngineCalls(f)
+	require.NoError(t, err)
+	return calls
+}
+
+func TestEngineCallRecorder_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	lvh := bytesutil.PadTo([]byte{'v'}, 32)
+	cli, srv := newMockEngine(t)
+	srv.register(ForkchoiceUpdatedMethodV2, func(msg *jsonrpcMessage, w http.ResponseWriter, r *http.Request) {
+		mockWriteResult(t, w, msg, &ForkchoiceUpdatedResponse{
+			Status: &pb.PayloadStatus{Status: pb.PayloadStatus_INVALID, LatestValidHash: lvh},
+		})
+	})
+	srv.register(GetPayloadMethodV2, func(msg *jsonrpcMessage, w http.ResponseWriter, r *http.Request) {
+		msg.Error = &jsonError{Code: -38001, Message: "unknown payload"}
+		require.NoError(t, json.NewEncoder(w).Encode(msg))
+	})
+
+	path := filepath.Join(t.TempDir(), "engine.jsonl")
+	recorder, err := NewEngineCallRecorder(path)
+	require.NoError(t, err)
+	s := &Service{cfg: &config{engineCallRecorder: recorder}}
+	s.rpcClient = s.recordCalls(cli)
+
+	state := &pb.ForkchoiceState{
+		HeadBlockHash:      make([]byte, 32),
+		SafeBlockHash:      make([]byte, 32),
+		FinalizedBlockHash: make([]byte, 32),
+	}
+	attrs := payloadattribute.EmptyWithVersion(version.Capella)
+	capellaSlot, err := slots.EpochStart(params.BeaconConfig().CapellaForkEpoch)
+	require.NoError(t, err)
+	run := func(t *testing.T, s *Service) {
+		_, gotLvh, err := s.ForkchoiceUpdated(ctx, state, attrs)
+		require.ErrorIs(t, err, ErrInvalidPayloadStatus)
+		assert.DeepEqual(t, lvh, gotLvh)
+		_, err = s.GetPayload(ctx, [8]byte{1}, capellaSlot)
+		require.ErrorIs(t, err, ErrUnknownPayload)
+	}
+	run(t, s)
+	require.NoError(t, recorder.Close())
+
+	calls := readRecording(t, path)
+	require.Equal(t, 2, len(calls))
+	assert.Equal(t, ForkchoiceUpdatedMethodV2, calls[0].Method)
+	assert.Equal(t, 2, len(calls[0].Params))
+	require.IsNil(t, calls[0].Error)
+	assert.NotEqual(t, time.Duration(0), calls[0].Duration)
+	assert.Equal(t, GetPayloadMethodV2, calls[1].Method)
+	require.NotNil(t, calls[1].Error)
+	assert.Equal(t, -38001, calls[1].Error.Code)
+
+	// A fresh service gets the same answers from the replay server.
+	replay := mockExecution.NewEngineReplayServer(calls)
+	url, stop := replay.Start()
+	defer stop()
+	replayClient, err := rpc.DialHTTP(url)
+	require.NoError(t, err)
+	defer replayClient.Close()
+	run(t, &Service{cfg: &config{}, rpcClient: replayClient})
+	assert.Equal(t, 0, replay.Pending())
+	assert.Equal(t, 0, len(replay.Mismatches()))
+
+	// Calls that are not in the recording fail.
+	_, err = (&Service{cfg: &config{}, rpcClient: replayClient}).GetPayload(ctx, [8]byte{2}, capellaSlot)
+	require.NotNil(t, err)
+	assert.Equal(t, 1, len(replay.Mismatches()))
+}
+
+func TestEngineCallRecorder_BatchCall(t *testing.T) {
+	header := func(n int64) json.RawMessage {
+		enc, err := (&types.HeaderInfo{Number: big.NewInt(n), Hash: common.Hash{byte(n)}, Time: uint64(n)}).MarshalJSON()
+		require.NoError(t, err)
+		return enc
+	}
+	param := func(v interface{}) json.RawMessage {
+		enc, err := json.Marshal(v)
+		require.NoError(t, err)
+		return enc
+	}
+	replay := mockExecution.NewEngineReplayServer([]*types.EngineCall{
+		{Method: BlockByNumberMethod, Params: []json.RawMessage{param("0x1"), param(false)}, Result: header(1)},
+		{Method: BlockByNumberMethod, Params: []json.RawMessage{param("0x2"), param(false)}, Result: header(2)},
+	})
+	url, stop := replay.Start()
+	defer stop()
+	client, err := rpc.DialHTTP(url)
+	require.NoError(t, err)
+	defer client.Close()
+
+	path := filepath.Join(t.TempDir(), "engine.jsonl")
+	recorder, err := NewEngineCallRecorder(path)
+	require.NoError(t, err)
+	s := &Service{cfg: &config{engineCallRecorder: recorder}, headerCache: newHeaderCache()}
+	s.rpcClient = s.recordCalls(client)
+
+	headers, err := s.batchRequestHeaders(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(headers))
+	assert.Equal(t, uint64(2), headers[1].Number.Uint64())
+	assert.Equal(t, 0, replay.Pending())
+	require.NoError(t, recorder.Close())
+
+	calls := readRecording(t, path)
+	require.Equal(t, 2, len(calls))
+	assert.Equal(t, BlockByNumberMethod, calls[1].Method)
+	assert.DeepEqual(t, []json.RawMessage{param("0x2"), param(false)}, calls[1].Params)
+	var got types.HeaderInfo
+	require.NoError(t, json.Unmarshal(calls[1].Result, &got))
+	assert.Equal(t, uint64(2), got.Time)
+}
@@ -0,0 +1,343 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	contracts "github.com/prysmaticlabs/prysm/v5/contracts/deposit"
+	"github.com/prysmaticlabs/prysm/v5/io/logs"
+	"github.com/prysmaticlabs/prysm/v5/network"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+// SyncingMethod request string for JSON-RPC.
+const SyncingMethod = "eth_syncing"
+
+// time between two health checks of the execution endpoints, when fallback endpoints are configured.
+var engineHealthCheckPeriod = 4 * time.Second
+
+var errNoExecutionConnection = errors.New("rpc client is not initialized")
+
+// engineEndpoint is one of the execution endpoints the service can use. Each endpoint has its own client,
+// used for health checks and mirrored forkchoice updates, next to the main client of the endpoint in use.
+type engineEndpoint struct {
+	endpoint network.Endpoint
+	client   RPCClient
+	healthy  bool
+	syncing  bool
+}
+
+// ready returns true if the endpoint can be used to drive the execution chain.
+func (e *engineEndpoint) ready() bool {
+	return e.healthy && !e.syncing
+}
+
+// failoverClient is the execution client of the service when fallback endpoints are configured. It forwards
+// the calls to the connection of the endpoint in use, which is swapped when switching endpoints. The previous
+// connection is closed once the calls in flight on it are done.
+type failoverClient struct {
+	lock sync.RWMutex
+	conn *engineConn
+}
+
+// engineConn is the connection to one execution endpoint, with the calls in flight on it.
+type engineConn struct {
+	rpcClient RPCClient
+	fetcher   *ethclient.Client
+	calls     sync.WaitGroup
+}
+
+// acquire returns the connection in use, the caller must call done on it once its call returns.
+func (f *failoverClient) acquire() (*engineConn, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	if f.conn == nil {
+		return nil, errNoExecutionConnection
+	}
+	f.conn.calls.Add(1)
+	return f.conn, nil
+}
+
+func (c *engineConn) done() {
+	c.calls.Done()
+}
+
+// swap makes the client use the given connection, and closes the previous one once its calls are done.
+func (f *failoverClient) swap(rpcClient RPCClient, fetcher *ethclient.Client) {
+	f.lock.Lock()
+	prev := f.conn
+	f.conn = &engineConn{rpcClient: rpcClient, fetcher: fetcher}
+	f.lock.Unlock()
+	if prev != nil {
+		go func() {
+			prev.calls.Wait()
+			prev.rpcClient.Close()
+		}()
+	}
+}
+
+// Close closes the connection in use without waiting for the calls in flight, the service is stopping.
+func (f *failoverClient) Close() {
+	f.lock.Lock()
+	prev := f.conn
+	f.conn = nil
+	f.lock.Unlock()
+	if prev != nil {
+		prev.rpcClient.Close()
+	}
+}
+
+func (f *failoverClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c, err := f.acquire()
+	if err != nil {
+		return err
+	}
+	defer c.done()
+	return c.rpcClient.CallContext(ctx, result, method, args...)
+}
+
+func (f *failoverClient) BatchCall(b []gethRPC.BatchElem) error {
+	c, err := f.acquire()
+	if err != nil {
+		return err
+	}
+	defer c.done()
+	return c.rpcClient.BatchCall(b)
+}
+
+// FilterLogs lets the client be used to fetch the deposit logs.
+func (f *failoverClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethTypes.Log, error) {
+	c, err := f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.done()
+	return c.fetcher.FilterLogs(ctx, query)
+}
+
+// SubscribeFilterLogs lets the client be used to fetch the deposit logs. The subscription is bound to the
+// connection in use, it ends when switching endpoints.
+func (f *failoverClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- gethTypes.Log) (ethereum.Subscription, error) {
+	c, err := f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.done()
+	return c.fetcher.SubscribeFilterLogs(ctx, query, ch)
+}
+
+// CodeAt lets the client be used to call the deposit contract.
+func (f *failoverClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	c, err := f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.done()
+	return c.fetcher.CodeAt(ctx, contract, blockNumber)
+}
+
+// CallContract lets the client be used to call the deposit contract.
+func (f *failoverClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c, err := f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.done()
+	return c.fetcher.CallContract(ctx, call, blockNumber)
+}
+
+// initEngineEndpoints lists the configured execution endpoints, the primary one first. The service then uses
+// a failover client, so that its clients are never replaced while they are in use.
+func (s *Service) initEngineEndpoints() error {
+	if len(s.cfg.fallbackHttpEndpoints) == 0 {
+		return nil
+	}
+	s.engines = make([]*engineEndpoint, 0, len(s.cfg.fallbackHttpEndpoints)+1)
+	s.engines = append(s.engines, &engineEndpoint{endpoint: s.cfg.currHttpEndpoint})
+	for _, e := range s.cfg.fallbackHttpEndpoints {
+		s.engines = append(s.engines, &engineEndpoint{endpoint: e})
+	}
+	s.failover = &failoverClient{}
+	depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, s.failover)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize deposit contract caller")
+	}
+	s.rpcClient = s.failover
+	s.httpLogger = s.failover
+	s.depositContractCaller = depositContractCaller
+	return nil
+}
+
+// runEngineHealthChecks periodically checks every execution endpoint, and switches to another one
+// when the endpoint in use is offline or syncing.
+func (s *Service) runEngineHealthChecks() {
+	ticker := time.NewTicker(engineHealthCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkEngineEndpoints(s.ctx)
+		}
+	}
+}
+
+// checkEngineEndpoints updates the health of every execution endpoint, and switches to the first ready
+// endpoint in order of priority. The endpoint in use is kept when no endpoint is ready.
+func (s *Service) checkEngineEndpoints(ctx context.Context) {
+	for i, e := range s.engines {
+		healthy, syncing := s.engineEndpointStatus(ctx, e)
+		s.enginesLock.Lock()
+		e.healthy, e.syncing = healthy, syncing
+		s.enginesLock.Unlock()
+		executionEndpointHealthy.WithLabelValues(endpointLabel(i)).Set(boolToFloat(e.ready()))
+	}
+
+	s.enginesLock.RLock()
+	active := s.activeEngine
+	target := -1
+	for i, e := range s.engines {
+		if e.ready() {
+			target = i
+			break
+		}
+	}
+	s.enginesLock.RUnlock()
+	if target == -1 || target == active {
+		return
+	}
+	if err := s.switchEngineEndpoint(ctx, target); err != nil {
+		log.WithError(err).WithField("endpoint", logs.MaskCredentialsLogging(s.engines[target].endpoint.Url)).
+			Error("Could not switch to execution endpoint")
+	}
+}
+
+// engineEndpointStatus dials the endpoint if needed, and asks whether it is syncing.
+func (s *Service) engineEndpointStatus(ctx context.Context, e *engineEndpoint) (healthy bool, syncing bool) {
+	if e.client == nil {
+		client, err := s.newRPCClientWithAuth(ctx, e.endpoint)
+		if err != nil {
+			log.WithError(err).WithField("endpoint", logs.MaskCredentialsLogging(e.endpoint.Url)).
+				Debug("Could not dial execution endpoint")
+			return false, false
+		}
+		s.enginesLock.Lock()
+		e.client = client
+		s.enginesLock.Unlock()
+	}
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineTimeout)
+	defer cancel()
+	// The result is false when the node is synced, and an object describing the sync progress otherwise.
+	var result interface{}
+	if err := e.client.CallContext(ctx, &result, SyncingMethod); err != nil {
+		log.WithError(handleRPCError(err)).WithField("endpoint", logs.MaskCredentialsLogging(e.endpoint.Url)).
+			Debug("Execution endpoint health check failed")
+		return false, false
+	}
+	isSyncing, ok := result.(bool)
+	return true, !ok || isSyncing
+}
+
+// currentHttpEndpoint returns the execution endpoint in use, which the health checks may switch at any time.
+func (s *Service) currentHttpEndpoint() network.Endpoint {
+	s.enginesLock.RLock()
+	defer s.enginesLock.RUnlock()
+	return s.cfg.currHttpEndpoint
+}
+
+// switchEngineEndpoint connects the service to the execution endpoint at the given index. The calls in flight
+// on the previous endpoint are not interrupted.
+func (s *Service) switchEngineEndpoint(ctx context.Context, index int) error {
+	e := s.engines[index]
+	if err := s.setupExecutionClientConnections(ctx, e.endpoint); err != nil {
+		return err
+	}
+	s.enginesLock.Lock()
+	previous := s.activeEngine
+	s.activeEngine = index
+	s.cfg.currHttpEndpoint = e.endpoint
+	s.enginesLock.Unlock()
+
+	executionEndpointFailoverCount.Inc()
+	activeExecutionEndpoint.Set(float64(index))
+	log.WithFields(logrus.Fields{
+		"previous": logs.MaskCredentialsLogging(s.engines[previous].endpoint.Url),
+		"endpoint": logs.MaskCredentialsLogging(e.endpoint.Url),
+	}).Warn("Switched execution endpoint")
+	return nil
+}
+
+// mirrorForkchoiceUpdated sends the forkchoice state, without payload attributes, to the execution endpoints
+// that are not in use. They follow the same head, so that they are ready to take over.
+func (s *Service) mirrorForkchoiceUpdated(state *pb.ForkchoiceState, v int) {
+	s.enginesLock.RLock()
+	defer s.enginesLock.RUnlock()
+	if len(s.engines) == 0 {
+		return
+	}
+	var method string
+	switch v {
+	case version.Bellatrix:
+		method = ForkchoiceUpdatedMethod
+	case version.Capella:
+		method = ForkchoiceUpdatedMethodV2
+	case version.Deneb, version.Electra:
+		method = ForkchoiceUpdatedMethodV3
+	default:
+		return
+	}
+	for i, e := range s.engines {
+		if i == s.activeEngine || e.client == nil {
+			continue
+		}
+		go func(client RPCClient, url string) {
+			d := time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second
+			ctx, cancel := context.WithTimeout(context.Background(), d)
+			defer cancel()
+			result := &ForkchoiceUpdatedResponse{}
+			if err := client.CallContext(ctx, result, method, state, nil); err != nil {
+				log.WithError(handleRPCError(err)).WithField("endpoint", logs.MaskCredentialsLogging(url)).
+					Debug("Could not mirror forkchoice update")
+			}
+		}(e.client, e.endpoint.Url)
+	}
+}
+
+// closeEngineEndpoints closes the clients of the execution endpoints.
+func (s *Service) closeEngineEndpoints() {
+	s.enginesLock.Lock()
+	defer s.enginesLock.Unlock()
+	for _, e := range s.engines {
+		if e.client != nil {
+			e.client.Close()
+			e.client = nil
+		}
+	}
+}
+
+func endpointLabel(i int) string {
+	if i == 0 {
+		return "primary"
+	}
+	return fmt.Sprintf("fallback_%d", i)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,287 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	dbutil "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	payloadattribute "github.com/prysmaticlabs/prysm/v5/consensus-types/payload-attribute"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// failoverEngine is an execution client answering the chain ID, sync status and forkchoice update requests.
+type failoverEngine struct {
+	sync.Mutex
+	srv     *httptest.Server
+	down    bool
+	syncing bool
+	fcus    []json.RawMessage
+}
+
+func newFailoverEngine(t *testing.T) *failoverEngine {
+	e := &failoverEngine{}
+	e.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.Lock()
+		defer e.Unlock()
+		if e.down {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		msg := &jsonrpcMessage{}
+		if err := json.NewDecoder(r.Body).Decode(msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var result interface{}
+		switch msg.Method {
+		case "eth_chainId":
+			result = hexutil.EncodeBig(new(big.Int).SetUint64(params.BeaconConfig().DepositChainID))
+		case SyncingMethod:
+			if e.syncing {
+				result = map[string]string{"currentBlock": "0x1", "highestBlock": "0x2"}
+			} else {
+				result = false
+			}
+		case ForkchoiceUpdatedMethodV2:
+			e.fcus = append(e.fcus, msg.Params)
+			result = &ForkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: pb.PayloadStatus_VALID}}
+		default:
+			t.Errorf("unexpected method %s", msg.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      msg.ID,
+			"result":  result,
+		}))
+	}))
+	t.Cleanup(e.srv.Close)
+	return e
+}
+
+func (e *failoverEngine) set(down, syncing bool) {
+	e.Lock()
+	defer e.Unlock()
+	e.down, e.syncing = down, syncing
+}
+
+func (e *failoverEngine) forkchoiceUpdates() []json.RawMessage {
+	e.Lock()
+	defer e.Unlock()
+	return e.fcus
+}
+
+func TestService_ExecutionEndpointFailover(t *testing.T) {
+	ctx := context.Background()
+	primary := newFailoverEngine(t)
+	fallback := newFailoverEngine(t)
+	s, err := NewService(ctx,
+		WithHttpEndpoint(primary.srv.URL),
+		WithFallbackHttpEndpoint(fallback.srv.URL, nil),
+		WithDatabase(dbutil.SetupDB(t)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.initEngineEndpoints())
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+
+	s.checkEngineEndpoints(ctx)
+	assert.Equal(t, 0, s.activeEngine)
+	assert.Equal(t, primary.srv.URL, s.ExecutionClientEndpoint())
+
+	// The primary endpoint is syncing, the fallback one takes over.
+	primary.set(false, true)
+	s.checkEngineEndpoints(ctx)
+	assert.Equal(t, 1, s.activeEngine)
+	assert.Equal(t, fallback.srv.URL, s.ExecutionClientEndpoint())
+
+	// Nothing is ready, the endpoint in use is kept.
+	primary.set(true, false)
+	fallback.set(true, false)
+	s.checkEngineEndpoints(ctx)
+	assert.Equal(t, 1, s.activeEngine)
+
+	// The primary endpoint is back, it is used again.
+	primary.set(false, false)
+	fallback.set(false, false)
+	s.checkEngineEndpoints(ctx)
+	assert.Equal(t, 0, s.activeEngine)
+	assert.Equal(t, primary.srv.URL, s.ExecutionClientEndpoint())
+}
+
+func TestService_ExecutionEndpointFailover_ConcurrentReads(t *testing.T) {
+	ctx := context.Background()
+	primary := newFailoverEngine(t)
+	fallback := newFailoverEngine(t)
+	s, err := NewService(ctx,
+		WithHttpEndpoint(primary.srv.URL),
+		WithFallbackHttpEndpoint(fallback.srv.URL, nil),
+		WithDatabase(dbutil.SetupDB(t)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.initEngineEndpoints())
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+
+	// The endpoint in use is read while the health checks switch it, the race detector catches unlocked reads.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = s.ExecutionClientEndpoint()
+				s.mirrorForkchoiceUpdated(&pb.ForkchoiceState{}, version.Phase0)
+			}
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		primary.set(false, i%2 == 0)
+		s.checkEngineEndpoints(ctx)
+	}
+	close(done)
+	wg.Wait()
+	assert.Equal(t, primary.srv.URL, s.ExecutionClientEndpoint())
+}
+
+// blockingRPCClient answers the calls once it is released, and reports when it is closed.
+type blockingRPCClient struct {
+	started chan struct{}
+	release chan struct{}
+	closed  chan struct{}
+}
+
+func newBlockingRPCClient() *blockingRPCClient {
+	return &blockingRPCClient{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (c *blockingRPCClient) Close() {
+	close(c.closed)
+}
+
+func (c *blockingRPCClient) BatchCall([]gethRPC.BatchElem) error {
+	return c.CallContext(context.Background(), nil, "")
+}
+
+func (c *blockingRPCClient) CallContext(context.Context, interface{}, string, ...interface{}) error {
+	select {
+	case c.started <- struct{}{}:
+	default:
+	}
+	<-c.release
+	return nil
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestFailoverClient_Swap(t *testing.T) {
+	prev := newBlockingRPCClient()
+	f := &failoverClient{}
+	f.swap(prev, nil)
+	called := make(chan error)
+	go func() {
+		called <- f.CallContext(context.Background(), nil, SyncingMethod)
+	}()
+	<-prev.started
+
+	// New calls use the new connection, the previous one stays open for the call in flight.
+	next := newBlockingRPCClient()
+	close(next.release)
+	f.swap(next, nil)
+	require.NoError(t, f.CallContext(context.Background(), nil, SyncingMethod))
+	assert.Equal(t, false, isClosed(prev.closed))
+
+	// The previous connection is closed once the call is done.
+	close(prev.release)
+	require.NoError(t, <-called)
+	select {
+	case <-prev.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("previous connection was not closed")
+	}
+	assert.Equal(t, false, isClosed(next.closed))
+
+	f.Close()
+	assert.Equal(t, true, isClosed(next.closed))
+	require.ErrorIs(t, f.CallContext(context.Background(), nil, SyncingMethod), errNoExecutionConnection)
+}
+
+func TestService_MirrorForkchoiceUpdated(t *testing.T) {
+	ctx := context.Background()
+	primary := newFailoverEngine(t)
+	fallback := newFailoverEngine(t)
+	s, err := NewService(ctx,
+		WithHttpEndpoint(primary.srv.URL),
+		WithFallbackHttpEndpoint(fallback.srv.URL, nil),
+		WithDatabase(dbutil.SetupDB(t)),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.initEngineEndpoints())
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	s.checkEngineEndpoints(ctx)
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+
+	state := &pb.ForkchoiceState{
+		HeadBlockHash:      make([]byte, 32),
+		SafeBlockHash:      make([]byte, 32),
+		FinalizedBlockHash: make([]byte, 32),
+	}
+	_, _, err = s.ForkchoiceUpdated(ctx, state, payloadattribute.EmptyWithVersion(version.Capella))
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(primary.forkchoiceUpdates()))
+
+	// The fallback endpoint receives the same forkchoice state, without payload attributes.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(fallback.forkchoiceUpdates()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	fcus := fallback.forkchoiceUpdates()
+	require.Equal(t, 1, len(fcus))
+	var params []json.RawMessage
+	require.NoError(t, json.Unmarshal(fcus[0], &params))
+	require.Equal(t, 2, len(params))
+	assert.Equal(t, "null", string(params[1]))
+	assert.Equal(t, fmt.Sprintf("%#x", state.HeadBlockHash), headBlockHash(t, params[0]))
+}
+
+func headBlockHash(t *testing.T, enc json.RawMessage) string {
+	st := &struct {
+		HeadBlockHash string `json:"headBlockHash"`
+	}{}
+	require.NoError(t, json.Unmarshal(enc, st))
+	return st.HeadBlockHash
+}
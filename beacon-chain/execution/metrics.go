@@ -71,4 +71,16 @@ var (
 		Name: "execution_payload_bodies_count",
 		Help: "The number of requested payload bodies is too large",
 	})
+	executionEndpointFailoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "execution_endpoint_failover_count",
+		Help: "The number of times the beacon node switched to another execution endpoint",
+	})
+	activeExecutionEndpoint = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "execution_endpoint_active_index",
+		Help: "The index of the execution endpoint in use, 0 being the primary endpoint",
+	})
+	executionEndpointHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_endpoint_healthy",
+		Help: "Whether an execution endpoint is online and synced, 1 if it is and 0 otherwise",
+	}, []string{"endpoint"})
 )
@@ -37,6 +37,20 @@ func WithHttpEndpointAndJWTSecret(endpointString string, secret []byte) Option {
 	}
 }
 
+// WithFallbackHttpEndpoint adds an execution endpoint to switch to when the endpoint in use is offline or syncing.
+// Fallback endpoints are tried in the order they are added, and are kept at the same head with forkchoice updates.
+func WithFallbackHttpEndpoint(endpointString string, secret []byte) Option {
+	return func(s *Service) error {
+		hEndpoint := network.HttpEndpoint(endpointString)
+		if len(secret) > 0 {
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+		}
+		s.cfg.fallbackHttpEndpoints = append(s.cfg.fallbackHttpEndpoints, hEndpoint)
+		return nil
+	}
+}
+
 // WithHeaders adds headers to the execution node JSON-RPC requests.
 func WithHeaders(headers []string) Option {
 	return func(s *Service) error {
@@ -115,3 +129,16 @@ func WithJwtId(jwtId string) Option {
 		return nil
 	}
 }
+
+// WithEngineCallRecorder records the JSON-RPC calls made to the execution client through its RPC client in the
+// given file. The deposit contract and log calls go through an ethclient, and are not recorded.
+func WithEngineCallRecorder(path string) Option {
+	return func(s *Service) error {
+		recorder, err := NewEngineCallRecorder(path)
+		if err != nil {
+			return err
+		}
+		s.cfg.engineCallRecorder = recorder
+		return nil
+	}
+}
@@ -22,18 +22,7 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 	if err != nil {
 		return errors.Wrap(err, "could not dial execution node")
 	}
-	// Attach the clients to the service struct.
 	fetcher := ethclient.NewClient(client)
-	s.rpcClient = client
-	s.httpLogger = fetcher
-
-	depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, fetcher)
-	if err != nil {
-		client.Close()
-		return errors.Wrap(err, "could not initialize deposit contract caller")
-	}
-	s.depositContractCaller = depositContractCaller
-
 	// Ensure we have the correct chain and deposit IDs.
 	if err := ensureCorrectExecutionChain(ctx, fetcher); err != nil {
 		client.Close()
@@ -45,6 +34,25 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 		}
 		return errors.Wrap(err, errStr)
 	}
+
+	// Attach the clients to the service struct. With fallback endpoints, the failover client keeps serving the
+	// calls in flight on the previous connection before closing it.
+	if s.failover != nil {
+		s.failover.swap(s.recordCalls(client), fetcher)
+	} else {
+		depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.depositContractAddr, fetcher)
+		if err != nil {
+			client.Close()
+			return errors.Wrap(err, "could not initialize deposit contract caller")
+		}
+		// Close previous client, if connection was successful.
+		if s.rpcClient != nil {
+			s.rpcClient.Close()
+		}
+		s.rpcClient = s.recordCalls(client)
+		s.httpLogger = fetcher
+		s.depositContractCaller = depositContractCaller
+	}
 	s.updateConnectedETH1(true)
 	s.runError = nil
 	return nil
@@ -67,17 +75,13 @@ func (s *Service) pollConnectionStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
-			currClient := s.rpcClient
-			if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+			endpoint := s.currentHttpEndpoint()
+			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(endpoint.Url))
+			if err := s.setupExecutionClientConnections(ctx, endpoint); err != nil {
 				errorLogger(err, "Could not connect to execution client endpoint")
 				continue
 			}
-			// Close previous client, if connection was successful.
-			if currClient != nil {
-				currClient.Close()
-			}
-			log.WithField("endpoint", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url)).Info("Connected to new endpoint")
+			log.WithField("endpoint", logs.MaskCredentialsLogging(endpoint.Url)).Info("Connected to new endpoint")
 			return
 		case <-s.ctx.Done():
 			log.Debug("Received cancelled context,closing existing powchain service")
@@ -92,15 +96,10 @@ func (s *Service) retryExecutionClientConnection(ctx context.Context, err error)
 	s.updateConnectedETH1(false)
 	// Back off for a while before redialing.
 	time.Sleep(backOffPeriod)
-	currClient := s.rpcClient
-	if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+	if err := s.setupExecutionClientConnections(ctx, s.currentHttpEndpoint()); err != nil {
 		s.runError = errors.Wrap(err, "setupExecutionClientConnections")
 		return
 	}
-	// Close previous client, if connection was successful.
-	if currClient != nil {
-		currClient.Close()
-	}
 	// Reset run error in the event of a successful connection.
 	s.runError = nil
 }
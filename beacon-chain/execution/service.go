@@ -125,9 +125,11 @@ type config struct {
 	eth1HeaderReqLimit      uint64
 	beaconNodeStatsUpdater  BeaconNodeStatsUpdater
 	currHttpEndpoint        network.Endpoint
+	fallbackHttpEndpoints   []network.Endpoint
 	headers                 []string
 	finalizedStateAtStartup state.BeaconState
 	jwtId                   string
+	engineCallRecorder      *EngineCallRecorder
 }
 
 // Service fetches important information about the canonical
@@ -155,6 +157,10 @@ type Service struct {
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         state.BeaconState
+	engines                 []*engineEndpoint // configured execution endpoints, only set when there are fallbacks.
+	failover                *failoverClient   // client of the endpoint in use, only set when there are fallbacks.
+	activeEngine            int
+	enginesLock             sync.RWMutex
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -231,6 +237,14 @@ func (s *Service) Start() {
 
 	s.isRunning = true
 
+	// Check the health of every execution endpoint, to switch to a fallback one when needed.
+	if err := s.initEngineEndpoints(); err != nil {
+		log.WithError(err).Fatal("Could not initialize execution endpoints")
+	}
+	if len(s.engines) > 0 {
+		go s.runEngineHealthChecks()
+	}
+
 	// Poll the execution client connection and fallback if errors occur.
 	s.pollConnectionStatus(s.ctx)
 
@@ -245,6 +259,10 @@ func (s *Service) Stop() error {
 	if s.rpcClient != nil {
 		s.rpcClient.Close()
 	}
+	s.closeEngineEndpoints()
+	if s.cfg.engineCallRecorder != nil {
+		return s.cfg.engineCallRecorder.Close()
+	}
 	return nil
 }
 
@@ -282,7 +300,7 @@ func (s *Service) ExecutionClientConnected() bool {
 
 // ExecutionClientEndpoint returns the URL of the current, connected execution client.
 func (s *Service) ExecutionClientEndpoint() string {
-	return s.cfg.currHttpEndpoint.Url
+	return s.currentHttpEndpoint().Url
 }
 
 // ExecutionClientConnectionErr returns the error (if any) of the current connection.
@@ -0,0 +1,192 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+)
+
+// errNoRecordedCall is the JSON-RPC error code returned for requests that are not part of the recording.
+const errNoRecordedCall = -32603
+
+type replayRequest struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type replayResponse struct {
+	Version string                 `json:"jsonrpc"`
+	ID      json.RawMessage        `json:"id"`
+	Result  json.RawMessage        `json:"result,omitempty"`
+	Error   *types.EngineCallError `json:"error,omitempty"`
+}
+
+// EngineReplayServer is a mock execution client answering JSON-RPC requests with the responses of a
+// recording made by the beacon node, so that the interaction with an execution client can be reproduced
+// without running one. Each request is answered with the first unused recorded call with the same method
+// and parameters. The chain ID, network ID and sync status are answered from the beacon config when the
+// recording does not contain them.
+type EngineReplayServer struct {
+	// ReplayLatency delays each response by the duration of the recorded call.
+	ReplayLatency bool
+
+	lock       sync.Mutex
+	pending    map[string][]*types.EngineCall
+	mismatches []string
+}
+
+// NewEngineReplayServer prepares the replay of the given calls.
+func NewEngineReplayServer(calls []*types.EngineCall) *EngineReplayServer {
+	s := &EngineReplayServer{pending: make(map[string][]*types.EngineCall)}
+	for _, c := range calls {
+		s.pending[c.Method] = append(s.pending[c.Method], c)
+	}
+	return s
+}
+
+// Start serves the replay over HTTP. It returns the endpoint of the server and a function to stop it.
+func (s *EngineReplayServer) Start() (string, func()) {
+	srv := httptest.NewServer(s)
+	return srv.URL, srv.Close
+}
+
+// Pending returns the number of recorded calls that have not been replayed.
+func (s *EngineReplayServer) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	n := 0
+	for _, calls := range s.pending {
+		n += len(calls)
+	}
+	return n
+}
+
+// Mismatches describes the requests that could not be answered from the recording.
+func (s *EngineReplayServer) Mismatches() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string{}, s.mismatches...)
+}
+
+// ServeHTTP answers single and batched JSON-RPC requests.
+func (s *EngineReplayServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(r.Body); err != nil {
+		http.Error(w, "could not read request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	batch := len(bytes.TrimSpace(body.Bytes())) > 0 && bytes.TrimSpace(body.Bytes())[0] == '['
+	var reqs []*replayRequest
+	if batch {
+		if err := json.Unmarshal(body.Bytes(), &reqs); err != nil {
+			http.Error(w, "could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		req := &replayRequest{}
+		if err := json.Unmarshal(body.Bytes(), req); err != nil {
+			http.Error(w, "could not decode request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		reqs = []*replayRequest{req}
+	}
+
+	resps := make([]*replayResponse, len(reqs))
+	for i, req := range reqs {
+		call := s.next(req)
+		if call == nil {
+			resps[i] = s.defaultResponse(req)
+			continue
+		}
+		if s.ReplayLatency {
+			time.Sleep(call.Duration)
+		}
+		// A call that did not get a JSON-RPC response is replayed as a failed HTTP request.
+		if call.Error != nil && call.Error.Code == 0 {
+			http.Error(w, call.Error.Message, http.StatusServiceUnavailable)
+			return
+		}
+		resps[i] = &replayResponse{Version: "2.0", ID: req.ID, Result: call.Result, Error: call.Error}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	var err error
+	if batch {
+		err = json.NewEncoder(w).Encode(resps)
+	} else {
+		err = json.NewEncoder(w).Encode(resps[0])
+	}
+	if err != nil {
+		http.Error(w, "could not encode response: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// next removes and returns the first recorded call matching the request, if any.
+func (s *EngineReplayServer) next(req *replayRequest) *types.EngineCall {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	calls := s.pending[req.Method]
+	for i, c := range calls {
+		if paramsEqual(c.Params, req.Params) {
+			s.pending[req.Method] = append(calls[:i:i], calls[i+1:]...)
+			return c
+		}
+	}
+	return nil
+}
+
+// defaultResponse answers the requests that are not in the recording.
+func (s *EngineReplayServer) defaultResponse(req *replayRequest) *replayResponse {
+	resp := &replayResponse{Version: "2.0", ID: req.ID}
+	var result interface{}
+	switch req.Method {
+	case "eth_chainId":
+		result = hexutil.EncodeBig(new(big.Int).SetUint64(params.BeaconConfig().DepositChainID))
+	case "net_version":
+		result = fmt.Sprintf("%d", params.BeaconConfig().DepositNetworkID)
+	case "eth_syncing":
+		result = false
+	default:
+		s.lock.Lock()
+		s.mismatches = append(s.mismatches, fmt.Sprintf("%s %s", req.Method, req.Params))
+		s.lock.Unlock()
+		resp.Error = &types.EngineCallError{Code: errNoRecordedCall, Message: "no recorded call for " + req.Method}
+		return resp
+	}
+	enc, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &types.EngineCallError{Code: errNoRecordedCall, Message: err.Error()}
+		return resp
+	}
+	resp.Result = enc
+	return resp
+}
+
+// paramsEqual compares JSON-RPC parameters regardless of their formatting.
+func paramsEqual(a, b []json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		var x, y interface{}
+		if json.Unmarshal(a[i], &x) != nil || json.Unmarshal(b[i], &y) != nil {
+			return false
+		}
+		if !reflect.DeepEqual(x, y) {
+			return false
+		}
+	}
+	return true
+}
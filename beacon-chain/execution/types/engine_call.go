@@ -0,0 +1,44 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EngineCall is a JSON-RPC call made to the execution client along with its outcome, as recorded by the
+// beacon node. A recording is a sequence of calls encoded as JSON, one per line, in the order they completed.
+type EngineCall struct {
+	Method   string            `json:"method"`
+	Params   []json.RawMessage `json:"params"`
+	Result   json.RawMessage   `json:"result,omitempty"`
+	Error    *EngineCallError  `json:"error,omitempty"`
+	Start    time.Time         `json:"start"`
+	Duration time.Duration     `json:"duration"`
+}
+
+// EngineCallError is the error returned by a recorded call. A zero code means that no JSON-RPC response
+// was received, for instance because of a timeout or an HTTP error.
+type EngineCallError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// ReadEngineCalls decodes a recording of engine API calls.
+func ReadEngineCalls(r io.Reader) ([]*EngineCall, error) {
+	dec := json.NewDecoder(r)
+	var calls []*EngineCall
+	for {
+		call := &EngineCall{}
+		if err := dec.Decode(call); err != nil {
+			if errors.Is(err, io.EOF) {
+				return calls, nil
+			}
+			return nil, errors.Wrapf(err, "could not decode engine call %d", len(calls))
+		}
+		calls = append(calls, call)
+	}
+}
@@ -0,0 +1,305 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// snapshotVersion is bumped whenever the encoding of a snapshot changes. Snapshots of another version are refused.
+const snapshotVersion = 1
+
+var errInvalidSnapshot = errors.New("invalid forkchoice snapshot")
+
+// Snapshot serializes the full fork choice store: every node, the checkpoints, proposer boost, votes and balances.
+// The result can be loaded back with RestoreSnapshot, to resume from the same view of the chain after a restart
+// without replaying the non-finalized blocks. The caller must hold at least the read lock.
+func (f *ForkChoice) Snapshot() ([]byte, error) {
+	s := f.store
+	if s.treeRootNode == nil {
+		return nil, errors.Wrap(ErrNilNode, "empty forkchoice store")
+	}
+	w := &snapshotWriter{}
+	w.uint64(snapshotVersion)
+	for _, cp := range []*forkchoicetypes.Checkpoint{
+		s.justifiedCheckpoint,
+		s.unrealizedJustifiedCheckpoint,
+		s.unrealizedFinalizedCheckpoint,
+		s.prevJustifiedCheckpoint,
+		s.finalizedCheckpoint,
+	} {
+		w.checkpoint(cp)
+	}
+	w.root(s.proposerBoostRoot)
+	w.root(s.previousProposerBoostRoot)
+	w.uint64(s.previousProposerBoostScore)
+	w.uint64(s.committeeWeight)
+	w.root(s.originRoot)
+	w.uint64(s.genesisTime)
+	w.root(nodeRoot(s.headNode))
+	w.root(nodeRoot(s.highestReceivedNode))
+	for _, slot := range s.receivedBlocksLastEpoch {
+		w.uint64(uint64(slot))
+	}
+	w.bool(s.allTipsAreInvalid)
+	w.uint64(uint64(len(s.slashedIndices)))
+	for idx := range s.slashedIndices {
+		w.uint64(uint64(idx))
+	}
+
+	// Nodes are written parents first, so that every parent is known when a node is restored.
+	w.uint64(uint64(len(s.nodeByRoot)))
+	queue := []*Node{s.treeRootNode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = append(queue[1:], n.children...)
+		w.uint64(uint64(n.slot))
+		w.root(n.root)
+		w.root(n.payloadHash)
+		w.root(nodeRoot(n.parent))
+		w.root(nodeRoot(n.target))
+		w.root(nodeRoot(n.bestDescendant))
+		w.uint64(uint64(n.justifiedEpoch))
+		w.uint64(uint64(n.unrealizedJustifiedEpoch))
+		w.uint64(uint64(n.finalizedEpoch))
+		w.uint64(uint64(n.unrealizedFinalizedEpoch))
+		w.uint64(n.balance)
+		w.uint64(n.weight)
+		w.bool(n.optimistic)
+		w.uint64(n.timestamp)
+	}
+
+	w.uint64(uint64(len(f.votes)))
+	for _, v := range f.votes {
+		w.root(v.currentRoot)
+		w.root(v.nextRoot)
+		w.uint64(uint64(v.nextEpoch))
+	}
+	w.uint64s(f.balances)
+	w.uint64s(f.justifiedBalances)
+	w.uint64(f.numActiveValidators)
+	return w.buf, nil
+}
+
+// RestoreSnapshot replaces the content of the fork choice store with a snapshot obtained from Snapshot.
+// The balances by root handler is kept. The store is left untouched if the snapshot can't be decoded.
+// The caller must hold the lock.
+func (f *ForkChoice) RestoreSnapshot(ctx context.Context, enc []byte) error {
+	r := &snapshotReader{buf: enc}
+	if v := r.uint64(); v != snapshotVersion {
+		return errors.Wrapf(errInvalidSnapshot, "unsupported version %d", v)
+	}
+	s := &Store{
+		nodeByRoot:     make(map[[fieldparams.RootLength]byte]*Node),
+		nodeByPayload:  make(map[[fieldparams.RootLength]byte]*Node),
+		slashedIndices: make(map[primitives.ValidatorIndex]bool),
+	}
+	s.justifiedCheckpoint = r.checkpoint()
+	s.unrealizedJustifiedCheckpoint = r.checkpoint()
+	s.unrealizedFinalizedCheckpoint = r.checkpoint()
+	s.prevJustifiedCheckpoint = r.checkpoint()
+	s.finalizedCheckpoint = r.checkpoint()
+	s.proposerBoostRoot = r.root()
+	s.previousProposerBoostRoot = r.root()
+	s.previousProposerBoostScore = r.uint64()
+	s.committeeWeight = r.uint64()
+	s.originRoot = r.root()
+	s.genesisTime = r.uint64()
+	headRoot := r.root()
+	highestReceivedRoot := r.root()
+	for i := range s.receivedBlocksLastEpoch {
+		s.receivedBlocksLastEpoch[i] = primitives.Slot(r.uint64())
+	}
+	s.allTipsAreInvalid = r.bool()
+	numSlashed := r.length()
+	for i := 0; i < numSlashed; i++ {
+		s.slashedIndices[primitives.ValidatorIndex(r.uint64())] = true
+	}
+
+	numNodes := r.length()
+	if numNodes == 0 {
+		return errors.Wrap(errInvalidSnapshot, "no nodes")
+	}
+	targets := make(map[*Node][fieldparams.RootLength]byte, numNodes)
+	bestDescendants := make(map[*Node][fieldparams.RootLength]byte, numNodes)
+	for i := 0; i < numNodes; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := &Node{slot: primitives.Slot(r.uint64()), root: r.root(), payloadHash: r.root()}
+		parentRoot := r.root()
+		targets[n] = r.root()
+		bestDescendants[n] = r.root()
+		n.justifiedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedJustifiedEpoch = primitives.Epoch(r.uint64())
+		n.finalizedEpoch = primitives.Epoch(r.uint64())
+		n.unrealizedFinalizedEpoch = primitives.Epoch(r.uint64())
+		n.balance = r.uint64()
+		n.weight = r.uint64()
+		n.optimistic = r.bool()
+		n.timestamp = r.uint64()
+		if r.err != nil {
+			return r.err
+		}
+		if i == 0 {
+			s.treeRootNode = n
+		} else {
+			parent, ok := s.nodeByRoot[parentRoot]
+			if !ok {
+				return errors.Wrapf(errInvalidSnapshot, "unknown parent %#x of node %#x", parentRoot, n.root)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		}
+		s.nodeByRoot[n.root] = n
+		s.nodeByPayload[n.payloadHash] = n
+	}
+	for n, root := range targets {
+		n.target = s.nodeByRoot[root]
+	}
+	for n, root := range bestDescendants {
+		n.bestDescendant = s.nodeByRoot[root]
+	}
+	var ok bool
+	if s.headNode, ok = s.nodeByRoot[headRoot]; !ok {
+		return errors.Wrapf(errInvalidSnapshot, "unknown head %#x", headRoot)
+	}
+	// The highest received node may have been pruned when its branch lost against the finalized chain.
+	if s.highestReceivedNode, ok = s.nodeByRoot[highestReceivedRoot]; !ok {
+		s.highestReceivedNode = s.headNode
+	}
+
+	numVotes := r.length()
+	votes := make([]Vote, numVotes)
+	for i := range votes {
+		votes[i] = Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: primitives.Epoch(r.uint64())}
+	}
+	balances := r.uint64s()
+	justifiedBalances := r.uint64s()
+	numActiveValidators := r.uint64()
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return errors.Wrapf(errInvalidSnapshot, "%d trailing bytes", len(r.buf))
+	}
+
+	f.store = s
+	f.votes = votes
+	f.balances = balances
+	f.justifiedBalances = justifiedBalances
+	f.numActiveValidators = numActiveValidators
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	return nil
+}
+
+func nodeRoot(n *Node) [fieldparams.RootLength]byte {
+	if n == nil {
+		return [fieldparams.RootLength]byte{}
+	}
+	return n.root
+}
+
+type snapshotWriter struct {
+	buf []byte
+}
+
+func (w *snapshotWriter) uint64(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *snapshotWriter) uint64s(v []uint64) {
+	w.uint64(uint64(len(v)))
+	for _, x := range v {
+		w.uint64(x)
+	}
+}
+
+func (w *snapshotWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+func (w *snapshotWriter) root(v [fieldparams.RootLength]byte) {
+	w.buf = append(w.buf, v[:]...)
+}
+
+func (w *snapshotWriter) checkpoint(cp *forkchoicetypes.Checkpoint) {
+	if cp == nil {
+		cp = &forkchoicetypes.Checkpoint{}
+	}
+	w.uint64(uint64(cp.Epoch))
+	w.root(cp.Root)
+}
+
+// snapshotReader decodes the fields written by snapshotWriter. After the first error, every read
+// returns a zero value and the error is kept in err.
+type snapshotReader struct {
+	buf []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = errors.Wrap(errInvalidSnapshot, "unexpected end of data")
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// length reads a list length, which can't exceed the number of bytes left.
+func (r *snapshotReader) length() int {
+	l := r.uint64()
+	if l > uint64(len(r.buf)) {
+		if r.err == nil {
+			r.err = errors.Wrap(errInvalidSnapshot, fmt.Sprintf("list length %d exceeds data size", l))
+		}
+		return 0
+	}
+	return int(l)
+}
+
+func (r *snapshotReader) uint64s() []uint64 {
+	l := r.length()
+	v := make([]uint64, l)
+	for i := range v {
+		v[i] = r.uint64()
+	}
+	return v
+}
+
+func (r *snapshotReader) bool() bool {
+	b := r.next(1)
+	return b != nil && b[0] == 1
+}
+
+func (r *snapshotReader) root() [fieldparams.RootLength]byte {
+	var root [fieldparams.RootLength]byte
+	copy(root[:], r.next(fieldparams.RootLength))
+	return root
+}
+
+func (r *snapshotReader) checkpoint() *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+}
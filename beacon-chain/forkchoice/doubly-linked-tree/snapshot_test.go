@@ -0,0 +1,81 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+
+	// Two branches: 1 <- 2 <- 3 and 1 <- 4.
+	for _, n := range []struct {
+		slot         primitives.Slot
+		root, parent uint64
+	}{{1, 1, 0}, {2, 2, 1}, {3, 3, 2}, {2, 4, 1}} {
+		parent := indexToHash(n.parent)
+		if n.parent == 0 {
+			parent = params.BeaconConfig().ZeroHash
+		}
+		st, root, err := prepareForkchoiceState(ctx, n.slot, indexToHash(n.root), parent, indexToHash(100+n.root), 1, 1)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, root))
+	}
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.numActiveValidators = 3
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 2)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(4), 2)
+	f.InsertSlashedIndex(ctx, 7)
+	f.store.proposerBoostRoot = indexToHash(3)
+	f.store.unrealizedJustifiedCheckpoint = &forkchoicetypes.Checkpoint{Epoch: 2, Root: indexToHash(2)}
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+
+	snapshot, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restored := New()
+	require.NoError(t, restored.RestoreSnapshot(ctx, snapshot))
+	restored.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) { return restored.justifiedBalances, nil })
+	require.Equal(t, f.NodeCount(), restored.NodeCount())
+	require.DeepEqual(t, f.votes, restored.votes)
+	require.DeepEqual(t, f.balances, restored.balances)
+	require.Equal(t, f.store.proposerBoostRoot, restored.store.proposerBoostRoot)
+	require.DeepEqual(t, f.store.unrealizedJustifiedCheckpoint, restored.store.unrealizedJustifiedCheckpoint)
+	require.Equal(t, true, restored.store.slashedIndices[7])
+	wantDump, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	gotDump, err := restored.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, wantDump, gotDump)
+
+	// The restored store serializes to the same bytes and computes the same head.
+	again, err := restored.Snapshot()
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshot, again)
+	restoredHead, err := restored.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head, restoredHead)
+}
+
+func TestForkChoice_RestoreSnapshot_Invalid(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	snapshot, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restored := New()
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, snapshot[:len(snapshot)-1]), errInvalidSnapshot)
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, append(snapshot, 0)), errInvalidSnapshot)
+	bad := append([]byte{}, snapshot...)
+	bad[0] = snapshotVersion + 1
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, bad), errInvalidSnapshot)
+	// A failed restore leaves the store untouched.
+	require.Equal(t, 0, restored.NodeCount())
+}
@@ -57,6 +57,7 @@ type Getter interface {
 	CommonAncestor(ctx context.Context, root1 [32]byte, root2 [32]byte) ([32]byte, primitives.Slot, error)
 	ForkChoiceDump(context.Context) (*forkchoice2.Dump, error)
 	Tips() ([][32]byte, []primitives.Slot)
+	Snapshot() ([]byte, error)
 }
 
 type FastGetter interface {
@@ -93,4 +94,5 @@ type Setter interface {
 	NewSlot(context.Context, primitives.Slot) error
 	SetBalancesByRooter(BalancesByRooter)
 	InsertSlashedIndex(context.Context, primitives.ValidatorIndex)
+	RestoreSnapshot(context.Context, []byte) error
 }
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -21,3 +23,55 @@ type BlockAndCheckpoints struct {
 	JustifiedCheckpoint *ethpb.Checkpoint
 	FinalizedCheckpoint *ethpb.Checkpoint
 }
+
+// HeadChangeReason describes why the head of the chain changed.
+type HeadChangeReason uint8
+
+const (
+	// HeadChangeNewBlock is a head change to a descendant of the previous head.
+	HeadChangeNewBlock HeadChangeReason = iota
+	// HeadChangeReorg is a head change to a block which does not descend from the previous head.
+	HeadChangeReorg
+	// HeadChangeLateBlockReorg is a reorg of a single late block, which the local proposer decided to orphan
+	// by building on top of its parent.
+	HeadChangeLateBlockReorg
+)
+
+// String returns the name of the reason, as used in the API.
+func (r HeadChangeReason) String() string {
+	switch r {
+	case HeadChangeNewBlock:
+		return "new_block"
+	case HeadChangeReorg:
+		return "reorg"
+	case HeadChangeLateBlockReorg:
+		return "late_block_reorg"
+	default:
+		return fmt.Sprintf("unknown(%d)", r)
+	}
+}
+
+// IsReorg returns true if the previous head is no longer canonical after the change.
+func (r HeadChangeReason) IsReorg() bool {
+	return r != HeadChangeNewBlock
+}
+
+// HeadChange records a change of the head of the chain, kept in the database to investigate reorgs after the fact.
+type HeadChange struct {
+	Slot               primitives.Slot // the wall clock slot at which the head changed
+	Timestamp          uint64          // unix time in milliseconds at which the head changed
+	Reason             HeadChangeReason
+	OldHeadRoot        [fieldparams.RootLength]byte
+	OldHeadSlot        primitives.Slot
+	OldHeadWeight      uint64
+	NewHeadRoot        [fieldparams.RootLength]byte
+	NewHeadSlot        primitives.Slot
+	NewHeadWeight      uint64
+	CommonAncestorRoot [fieldparams.RootLength]byte
+	CommonAncestorSlot primitives.Slot
+	Depth              uint64
+	Distance           uint64
+	ProposerBoostRoot  [fieldparams.RootLength]byte // the block holding proposer boost when the head changed
+	JustifiedEpoch     primitives.Epoch
+	FinalizedEpoch     primitives.Epoch
+}
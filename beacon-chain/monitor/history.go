@@ -0,0 +1,233 @@
+package monitor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// TrackedValidatorsEditor gives access to the set of validators tracked by the monitor.
+type TrackedValidatorsEditor interface {
+	TrackedValidatorIndices() []primitives.ValidatorIndex
+	TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	UntrackValidators(indices []primitives.ValidatorIndex)
+}
+
+var _ TrackedValidatorsEditor = (*Service)(nil)
+
+// TrackedValidatorIndices returns the indices of the tracked validators, in ascending order.
+func (s *Service) TrackedValidatorIndices() []primitives.ValidatorIndex {
+	s.RLock()
+	defer s.RUnlock()
+	tracked := make([]primitives.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		tracked = append(tracked, idx)
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	return tracked
+}
+
+// TrackValidators adds validators to the tracked set. When the monitor is already running, their
+// performance is reported from the current head on, and recorded from the next epoch on.
+func (s *Service) TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	s.RLock()
+	isLogging := s.isLogging
+	s.RUnlock()
+	var st state.BeaconState
+	if isLogging {
+		var err error
+		st, err = s.config.HeadFetcher.HeadState(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not get head state")
+		}
+		if st == nil || st.IsNil() {
+			return errors.New("head state is nil")
+		}
+		for _, idx := range indices {
+			if uint64(idx) >= uint64(st.NumValidators()) {
+				return errors.Errorf("validator index %d is out of range", idx)
+			}
+		}
+	}
+
+	s.Lock()
+	added := make(map[primitives.ValidatorIndex]bool)
+	for _, idx := range indices {
+		if !s.TrackedValidators[idx] {
+			s.TrackedValidators[idx] = true
+			added[idx] = true
+		}
+	}
+	if st != nil && len(added) > 0 {
+		epoch := slots.ToEpoch(st.Slot())
+		for idx := range added {
+			balance, err := st.BalanceAtIndex(idx)
+			if err != nil {
+				log.WithError(err).WithField("validatorIndex", idx).Error(
+					"Could not fetch starting balance, skipping aggregated logs.")
+				balance = 0
+			}
+			s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+				startEpoch:   epoch,
+				startBalance: balance,
+			}
+			s.latestPerformance[idx] = ValidatorLatestPerformance{
+				balance: balance,
+			}
+		}
+	}
+	s.Unlock()
+
+	if len(added) == 0 {
+		return nil
+	}
+	if st != nil {
+		s.updateSyncCommitteeTrackedVals(st)
+	}
+	log.WithField("validatorIndices", sortedIndices(added)).Info("Started tracking validators")
+	return nil
+}
+
+// UntrackValidators removes validators from the tracked set. The performance recorded for them is kept.
+func (s *Service) UntrackValidators(indices []primitives.ValidatorIndex) {
+	s.Lock()
+	defer s.Unlock()
+	removed := make(map[primitives.ValidatorIndex]bool)
+	for _, idx := range indices {
+		if !s.TrackedValidators[idx] {
+			continue
+		}
+		removed[idx] = true
+		delete(s.TrackedValidators, idx)
+		delete(s.latestPerformance, idx)
+		delete(s.aggregatedPerformance, idx)
+		delete(s.trackedSyncCommitteeIndices, idx)
+		for _, records := range s.history {
+			delete(records, idx)
+		}
+	}
+	if len(removed) > 0 {
+		log.WithField("validatorIndices", sortedIndices(removed)).Info("Stopped tracking validators")
+	}
+}
+
+// updateHistory opens the performance records of the tracked validators when the first block of a new epoch
+// is processed. The previous epoch gets its end balance, and the records of the epochs whose attestations
+// can no longer be included are saved to the database.
+func (s *Service) updateHistory(ctx context.Context, st state.ReadOnlyBeaconState, epoch primitives.Epoch) {
+	if s.config.BeaconDB == nil {
+		return
+	}
+	s.Lock()
+	if epoch <= s.historyEpoch {
+		s.Unlock()
+		return
+	}
+	for idx, r := range s.history[s.historyEpoch] {
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			log.WithError(err).WithField("validatorIndex", idx).Error("Could not get balance")
+			continue
+		}
+		r.EndBalance = balance
+	}
+	s.historyEpoch = epoch
+	proposers, err := proposerAssignments(ctx, st, epoch)
+	if err != nil {
+		log.WithError(err).WithField("epoch", epoch).Error("Could not compute proposer assignments")
+	}
+	records := make(map[primitives.ValidatorIndex]*monitortypes.EpochPerformance, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		r := &monitortypes.EpochPerformance{
+			Epoch:             epoch,
+			ValidatorIndex:    idx,
+			ProposalsExpected: proposers[idx],
+		}
+		if v, err := st.ValidatorAtIndexReadOnly(idx); err == nil {
+			r.AttestationExpected = helpers.IsActiveValidatorUsingTrie(v, epoch)
+		}
+		if balance, err := st.BalanceAtIndex(idx); err == nil {
+			r.StartBalance = balance
+		}
+		records[idx] = r
+	}
+	s.history[epoch] = records
+
+	var completed []*monitortypes.EpochPerformance
+	for e, rs := range s.history {
+		if e+1 >= epoch {
+			continue
+		}
+		for _, r := range rs {
+			completed = append(completed, r)
+		}
+		delete(s.history, e)
+	}
+	s.Unlock()
+
+	if len(completed) == 0 {
+		return
+	}
+	if err := s.config.BeaconDB.SaveValidatorPerformance(ctx, completed); err != nil {
+		log.WithError(err).Error("Could not save validator performance")
+		return
+	}
+	for _, r := range completed {
+		if r.MissedAttestation() || r.MissedProposals() > 0 || r.MissedSyncCommittee() > 0 {
+			log.WithFields(logrus.Fields{
+				"validatorIndex":       r.ValidatorIndex,
+				"epoch":                r.Epoch,
+				"missedAttestation":    r.MissedAttestation(),
+				"missedProposals":      r.MissedProposals(),
+				"missedSyncSignatures": r.MissedSyncCommittee(),
+			}).Warn("Validator missed duties")
+		}
+	}
+}
+
+// historyRecord returns the open performance record of a tracked validator for the given epoch, if any.
+// It assumes the caller holds the service lock.
+func (s *Service) historyRecord(idx primitives.ValidatorIndex, epoch primitives.Epoch) *monitortypes.EpochPerformance {
+	records, ok := s.history[epoch]
+	if !ok {
+		return nil
+	}
+	return records[idx]
+}
+
+// proposerAssignments returns the number of slots of the epoch each validator has to propose a block for.
+func proposerAssignments(ctx context.Context, st state.ReadOnlyBeaconState, epoch primitives.Epoch) (map[primitives.ValidatorIndex]uint64, error) {
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	proposers := make(map[primitives.ValidatorIndex]uint64)
+	for slot := start; slot < start+params.BeaconConfig().SlotsPerEpoch; slot++ {
+		if slot == 0 {
+			continue
+		}
+		idx, err := helpers.BeaconProposerIndexAtSlot(ctx, st, slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get proposer at slot %d", slot)
+		}
+		proposers[idx]++
+	}
+	return proposers, nil
+}
+
+func sortedIndices(set map[primitives.ValidatorIndex]bool) []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(set))
+	for idx := range set {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
@@ -0,0 +1,88 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestTrackValidators(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	s := setupService(t)
+	s.isLogging = true
+
+	require.NoError(t, s.TrackValidators(ctx, []primitives.ValidatorIndex{3, 1}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 3, 12, 15}, s.TrackedValidatorIndices())
+	require.LogsContain(t, hook, "Started tracking validators")
+	// Already tracked validators keep their performance.
+	require.Equal(t, uint64(12), s.aggregatedPerformance[1].totalAttestedCount)
+	require.Equal(t, uint64(32000000000), s.aggregatedPerformance[3].startBalance)
+	require.DeepEqual(t, []primitives.CommitteeIndex{2}, s.trackedSyncCommitteeIndices[2])
+
+	require.ErrorContains(t, "out of range", s.TrackValidators(ctx, []primitives.ValidatorIndex{256}))
+
+	s.UntrackValidators([]primitives.ValidatorIndex{3, 12, 100})
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 15}, s.TrackedValidatorIndices())
+	_, ok := s.aggregatedPerformance[12]
+	require.Equal(t, false, ok)
+	require.LogsContain(t, hook, "Stopped tracking validators")
+}
+
+func TestUpdateHistory(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	s := &Service{
+		config: &ValidatorMonitorConfig{BeaconDB: beaconDB},
+		TrackedValidators: map[primitives.ValidatorIndex]bool{
+			1: true,
+			2: true,
+		},
+		history: make(map[primitives.Epoch]map[primitives.ValidatorIndex]*monitortypes.EpochPerformance),
+	}
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+
+	s.updateHistory(ctx, st, 1)
+	require.Equal(t, 2, len(s.history[1]))
+	r := s.historyRecord(1, 1)
+	require.NotNil(t, r)
+	require.Equal(t, true, r.AttestationExpected)
+	require.Equal(t, uint64(32000000000), r.StartBalance)
+	r.AttestationIncluded = true
+	r.InclusionDistance = 1
+
+	// The attestations of epoch 1 can still be included during epoch 2.
+	require.NoError(t, st.SetSlot(2*params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(t, st.UpdateBalancesAtIndex(1, 32000001000))
+	s.updateHistory(ctx, st, 2)
+	require.Equal(t, uint64(32000001000), s.historyRecord(1, 1).EndBalance)
+	records, err := beaconDB.ValidatorPerformance(ctx, 0, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+
+	// Processing the same epoch again is a no-op.
+	s.updateHistory(ctx, st, 2)
+	require.Equal(t, 2, len(s.history))
+
+	require.NoError(t, st.SetSlot(3*params.BeaconConfig().SlotsPerEpoch))
+	s.updateHistory(ctx, st, 3)
+	require.Equal(t, 2, len(s.history))
+	records, err = beaconDB.ValidatorPerformance(ctx, 0, 3, []primitives.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, primitives.Epoch(1), records[0].Epoch)
+	require.Equal(t, primitives.ValidatorIndex(1), records[0].ValidatorIndex)
+	require.Equal(t, int64(1000), records[0].BalanceChange())
+	require.Equal(t, false, records[0].MissedAttestation())
+	require.Equal(t, true, records[1].MissedAttestation())
+	require.LogsContain(t, hook, "Validator missed duties")
+}
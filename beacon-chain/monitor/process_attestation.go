@@ -93,7 +93,7 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 			inclusionSlotGauge.WithLabelValues(fmt.Sprintf("%d", idx)).Set(float64(latestPerf.inclusionSlot))
 			aggregatedPerf.totalDistance += uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
 
-			if state.Version() == version.Altair {
+			if state.Version() >= version.Altair {
 				targetIdx := params.BeaconConfig().TimelyTargetFlagIndex
 				sourceIdx := params.BeaconConfig().TimelySourceFlagIndex
 				headIdx := params.BeaconConfig().TimelyHeadFlagIndex
@@ -153,6 +153,13 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 			logFields["newBalance"] = balance
 			logFields["balanceChange"] = balanceChg
 
+			if r := s.historyRecord(primitives.ValidatorIndex(idx), slots.ToEpoch(latestPerf.attestedSlot)); r != nil && !r.AttestationIncluded {
+				r.AttestationIncluded = true
+				r.InclusionDistance = uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
+				r.CorrectSource = latestPerf.timelySource
+				r.CorrectTarget = latestPerf.timelyTarget
+				r.CorrectHead = latestPerf.timelyHead
+			}
 			s.latestPerformance[primitives.ValidatorIndex(idx)] = latestPerf
 			s.aggregatedPerformance[primitives.ValidatorIndex(idx)] = aggregatedPerf
 			log.WithFields(logFields).Info("Attestation included")
@@ -164,6 +171,9 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 func (s *Service) processUnaggregatedAttestation(ctx context.Context, att ethpb.Att) {
 	s.RLock()
 	defer s.RUnlock()
+	if len(s.TrackedValidators) == 0 {
+		return
+	}
 	root := bytesutil.ToBytes32(att.GetData().BeaconBlockRoot)
 	st := s.config.StateGen.StateByRootIfCachedNoCopy(root)
 	if st == nil {
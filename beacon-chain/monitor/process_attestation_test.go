@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/go-bitfield"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -61,6 +63,47 @@ func TestProcessIncludedAttestationTwoTracked(t *testing.T) {
 	require.LogsContain(t, hook, wanted2)
 }
 
+func TestProcessIncludedAttestation_Deneb(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := setupService(t)
+	state, _ := util.DeterministicGenesisStateDeneb(t, 256)
+	require.NoError(t, state.SetSlot(2))
+	require.NoError(t, state.SetCurrentParticipationBits(bytes.Repeat([]byte{0xff}, 13)))
+	s.history = map[primitives.Epoch]map[primitives.ValidatorIndex]*monitortypes.EpochPerformance{
+		0: {
+			2:  {Epoch: 0, ValidatorIndex: 2, AttestationExpected: true},
+			12: {Epoch: 0, ValidatorIndex: 12, AttestationExpected: true},
+		},
+	}
+
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  0,
+			BeaconBlockRoot: bytesutil.PadTo([]byte("hello-world"), 32),
+			Source: &ethpb.Checkpoint{
+				Epoch: 0,
+				Root:  bytesutil.PadTo([]byte("hello-world"), 32),
+			},
+			Target: &ethpb.Checkpoint{
+				Epoch: 1,
+				Root:  bytesutil.PadTo([]byte("hello-world"), 32),
+			},
+		},
+		AggregationBits: bitfield.Bitlist{0b11, 0b1},
+	}
+	s.processIncludedAttestation(context.Background(), state, att)
+	require.LogsContain(t, hook, "correctHead=true correctSource=true correctTarget=true")
+	for _, idx := range []primitives.ValidatorIndex{2, 12} {
+		r := s.historyRecord(idx, 0)
+		require.NotNil(t, r)
+		require.Equal(t, true, r.AttestationIncluded)
+		require.Equal(t, true, r.CorrectSource)
+		require.Equal(t, true, r.CorrectTarget)
+		require.Equal(t, true, r.CorrectHead)
+	}
+}
+
 func TestProcessUnaggregatedAttestationStateNotCached(t *testing.T) {
 	logrus.SetLevel(logrus.DebugLevel)
 	hook := logTest.NewGlobal()
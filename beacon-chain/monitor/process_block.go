@@ -54,6 +54,11 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.ReadOnlySignedB
 		s.updateSyncCommitteeTrackedVals(st)
 	}
 
+	s.updateHistory(ctx, st, currEpoch)
+	// The monitor runs even without tracked validators, so that validators can be tracked at runtime.
+	if !s.isTracking() {
+		return
+	}
 	s.processSyncAggregate(st, blk)
 	s.processProposedBlock(st, root, blk)
 	s.processAttestations(ctx, st, blk)
@@ -87,6 +92,9 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 		aggPerf := s.aggregatedPerformance[blk.ProposerIndex()]
 		aggPerf.totalProposedCount++
 		s.aggregatedPerformance[blk.ProposerIndex()] = aggPerf
+		if r := s.historyRecord(blk.ProposerIndex(), slots.ToEpoch(blk.Slot())); r != nil {
+			r.ProposalsIncluded++
+		}
 
 		parentRoot := blk.ParentRoot()
 		log.WithFields(logrus.Fields{
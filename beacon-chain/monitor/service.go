@@ -12,6 +12,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -59,6 +61,8 @@ type ValidatorMonitorConfig struct {
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
 	InitialSyncComplete chan struct{}
+	// BeaconDB stores the per epoch performance of the tracked validators, the history is not recorded when nil.
+	BeaconDB db.NoHeadAccessDatabase
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -70,7 +74,7 @@ type Service struct {
 	isLogging bool
 
 	// Locks access to TrackedValidators, latestPerformance, aggregatedPerformance,
-	// trackedSyncedCommitteeIndices and lastSyncedEpoch
+	// trackedSyncedCommitteeIndices, lastSyncedEpoch, history and historyEpoch
 	sync.RWMutex
 
 	TrackedValidators           map[primitives.ValidatorIndex]bool
@@ -78,6 +82,9 @@ type Service struct {
 	aggregatedPerformance       map[primitives.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[primitives.ValidatorIndex][]primitives.CommitteeIndex
 	lastSyncedEpoch             primitives.Epoch
+	// history holds the performance records of the epochs whose attestations can still be included.
+	history      map[primitives.Epoch]map[primitives.ValidatorIndex]*monitortypes.EpochPerformance
+	historyEpoch primitives.Epoch
 }
 
 // NewService sets up a new validator monitor service instance when given a list of validator indices to track.
@@ -91,6 +98,7 @@ func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []p
 		latestPerformance:           make(map[primitives.ValidatorIndex]ValidatorLatestPerformance),
 		aggregatedPerformance:       make(map[primitives.ValidatorIndex]ValidatorAggregatedPerformance),
 		trackedSyncCommitteeIndices: make(map[primitives.ValidatorIndex][]primitives.CommitteeIndex),
+		history:                     make(map[primitives.Epoch]map[primitives.ValidatorIndex]*monitortypes.EpochPerformance),
 		isLogging:                   false,
 	}
 	for _, idx := range tracked {
@@ -152,8 +160,10 @@ func (s *Service) run() {
 }
 
 // initializePerformanceStructures initializes the validatorLatestPerformance
-// and validatorAggregatedPerformance for each tracked validator.
+// and validatorAggregatedPerformance for each tracked validator. The history
+// is recorded from the next epoch on, the current one being incomplete.
 func (s *Service) initializePerformanceStructures(state state.BeaconState, epoch primitives.Epoch) {
+	s.historyEpoch = epoch
 	for idx := range s.TrackedValidators {
 		balance, err := state.BalanceAtIndex(idx)
 		if err != nil {
@@ -268,6 +278,13 @@ func (s *Service) trackedIndex(idx primitives.ValidatorIndex) bool {
 	return ok
 }
 
+// isTracking returns true when at least one validator is tracked.
+func (s *Service) isTracking() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.TrackedValidators) > 0
+}
+
 // updateSyncCommitteeTrackedVals updates the sync committee assignments of our
 // tracked validators. It gets called when we sync a block after the Sync Period changes.
 func (s *Service) updateSyncCommitteeTrackedVals(state state.BeaconState) {
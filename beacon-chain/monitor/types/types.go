@@ -0,0 +1,61 @@
+package types
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// EpochPerformance is the performance of a tracked validator over an epoch, as recorded by the validator monitor.
+// A record is written once the attestations of the epoch can no longer be included in a block.
+type EpochPerformance struct {
+	Epoch          primitives.Epoch
+	ValidatorIndex primitives.ValidatorIndex
+	// AttestationExpected is set when the validator was active during the epoch.
+	AttestationExpected bool
+	// AttestationIncluded is set when an attestation of the validator for the epoch was included in a block.
+	AttestationIncluded bool
+	// InclusionDistance is the number of slots between the attestation and its first inclusion.
+	InclusionDistance uint64
+	// CorrectSource, CorrectTarget and CorrectHead are read from the participation flags of the state including
+	// the attestation, like the attestation logs of the monitor. They are always false before Altair.
+	CorrectSource bool
+	CorrectTarget bool
+	CorrectHead   bool
+	// SyncCommitteeExpected is the number of sync committee signatures expected from the validator,
+	// one per block and per position in the sync committee.
+	SyncCommitteeExpected uint64
+	// SyncCommitteeIncluded is the number of expected sync committee signatures included in blocks.
+	SyncCommitteeIncluded uint64
+	// ProposalsExpected is the number of slots of the epoch the validator had to propose a block for.
+	ProposalsExpected uint64
+	// ProposalsIncluded is the number of blocks proposed by the validator during the epoch seen by the monitor.
+	ProposalsIncluded uint64
+	// StartBalance and EndBalance are the balances of the validator in the first block of the epoch and of the next one.
+	StartBalance uint64
+	EndBalance   uint64
+}
+
+// BalanceChange returns the balance difference over the epoch, in Gwei.
+func (p *EpochPerformance) BalanceChange() int64 {
+	return int64(p.EndBalance) - int64(p.StartBalance)
+}
+
+// MissedAttestation returns whether the validator was expected to attest but no attestation was included.
+func (p *EpochPerformance) MissedAttestation() bool {
+	return p.AttestationExpected && !p.AttestationIncluded
+}
+
+// MissedSyncCommittee returns the number of expected sync committee signatures that were not included.
+func (p *EpochPerformance) MissedSyncCommittee() uint64 {
+	if p.SyncCommitteeIncluded >= p.SyncCommitteeExpected {
+		return 0
+	}
+	return p.SyncCommitteeExpected - p.SyncCommitteeIncluded
+}
+
+// MissedProposals returns the number of proposals assigned to the validator for which no block was seen.
+func (p *EpochPerformance) MissedProposals() uint64 {
+	if p.ProposalsIncluded >= p.ProposalsExpected {
+		return 0
+	}
+	return p.ProposalsExpected - p.ProposalsIncluded
+}
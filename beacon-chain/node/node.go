@@ -44,6 +44,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
@@ -120,6 +121,8 @@ type BeaconNode struct {
 	initialSyncComplete     chan struct{}
 	BlobStorage             *filesystem.BlobStorage
 	BlobStorageOptions      []filesystem.BlobStorageOption
+	DataColumnStorage       *filesystem.DataColumnStorage
+	DataColumnStorageOpts   []filesystem.DataColumnStorageOption
 	verifyInitWaiter        *verification.InitializerWaiter
 	syncChecker             *initialsync.SyncChecker
 }
@@ -184,6 +187,14 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		}
 		beacon.BlobStorage = blobs
 	}
+	if beacon.DataColumnStorage == nil {
+		beacon.DataColumnStorageOpts = append(beacon.DataColumnStorageOpts, filesystem.WithDataColumnSaveFsync(features.Get().BlobSaveFsync))
+		columns, err := filesystem.NewDataColumnStorage(beacon.DataColumnStorageOpts...)
+		if err != nil {
+			return nil, err
+		}
+		beacon.DataColumnStorage = columns
+	}
 
 	bfs, err := startBaseServices(cliCtx, beacon, depositAddress)
 	if err != nil {
@@ -199,6 +210,7 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 		beacon.BackfillOpts,
 		backfill.WithVerifierWaiter(beacon.verifyInitWaiter),
 		backfill.WithInitSyncWaiter(initSyncWaiter(ctx, beacon.initialSyncComplete)),
+		backfill.WithDataColumnStorage(beacon.DataColumnStorage),
 	)
 
 	bf, err := backfill.NewService(ctx, bfs, beacon.BlobStorage, beacon.clockWaiter, beacon.fetchP2P(), pa, beacon.BackfillOpts...)
@@ -287,6 +299,7 @@ func startBaseServices(cliCtx *cli.Context, beacon *BeaconNode, depositAddress s
 		return nil, errors.Wrap(err, "could not start DB")
 	}
 	beacon.BlobStorage.WarmCache()
+	beacon.DataColumnStorage.WarmCache()
 
 	log.Debugln("Starting Slashing DB")
 	if err := beacon.startSlasherDB(cliCtx); err != nil {
@@ -361,6 +374,11 @@ func registerServices(cliCtx *cli.Context, beacon *BeaconNode, synchronizer *sta
 		return errors.Wrap(err, "could not register builder service")
 	}
 
+	log.Debugln("Registering Validator Monitoring Service")
+	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
+		return errors.Wrap(err, "could not register validator monitoring service")
+	}
+
 	log.Debugln("Registering RPC Service")
 	router := newRouter(cliCtx)
 	if err := beacon.registerRPCService(router); err != nil {
@@ -372,11 +390,6 @@ func registerServices(cliCtx *cli.Context, beacon *BeaconNode, synchronizer *sta
 		return errors.Wrap(err, "could not register GRPC gateway service")
 	}
 
-	log.Debugln("Registering Validator Monitoring Service")
-	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
-		return errors.Wrap(err, "could not register validator monitoring service")
-	}
-
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -503,6 +516,10 @@ func (b *BeaconNode) clearDB(clearDB, forceClearDB bool, d *kv.Store, dbPath str
 			return nil, errors.Wrap(err, "could not clear blob storage")
 		}
 
+		if err := b.DataColumnStorage.Clear(); err != nil {
+			return nil, errors.Wrap(err, "could not clear data column storage")
+		}
+
 		d, err = kv.NewKVStore(b.ctx, dbPath)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not create new database")
@@ -587,6 +604,9 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	}
 
 	if b.CheckpointInitializer != nil {
+		if bi, ok := b.CheckpointInitializer.(checkpoint.BlobStorageInitializer); ok {
+			bi.SetBlobStorage(b.BlobStorage)
+		}
 		if err := b.CheckpointInitializer.Initialize(b.ctx, d); err != nil {
 			return err
 		}
@@ -648,6 +668,9 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs coverage.AvailableBlocker, fc forkchoice.ForkChoicer) error {
 	opts := []stategen.Option{stategen.WithAvailableBlocker(bfs)}
+	if features.Get().EnableHistoricalStateArchive {
+		opts = append(opts, stategen.WithStateArchive())
+	}
 	sg := stategen.New(b.db, fc, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -685,6 +708,14 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		return errors.Wrapf(err, "could not register p2p service")
 	}
 
+	var scoringPolicy *scorers.Policy
+	if path := cliCtx.String(cmd.P2PScoringPolicy.Name); path != "" {
+		scoringPolicy, err = scorers.LoadPolicy(path)
+		if err != nil {
+			return errors.Wrap(err, "could not load peer scoring policy")
+		}
+	}
+
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
 		NoDiscovery:          cliCtx.Bool(cmd.NoDiscovery.Name),
 		StaticPeers:          slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
@@ -704,6 +735,9 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		QueueSize:            cliCtx.Uint(cmd.PubsubQueueSize.Name),
 		AllowListCIDR:        cliCtx.String(cmd.P2PAllowList.Name),
 		DenyListCIDR:         slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		ScoringPolicy:        scoringPolicy,
+		GossipTracePath:      cliCtx.String(cmd.GossipTraceFile.Name),
+		GossipTraceMaxSize:   cliCtx.Uint64(cmd.GossipTraceMaxSize.Name) * 1024 * 1024,
 		EnableUPnP:           cliCtx.Bool(cmd.EnableUPnPFlag.Name),
 		StateNotifier:        b,
 		DB:                   b.db,
@@ -753,6 +787,11 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		return err
 	}
 
+	custody, err := p2p.CustodyColumns(b.fetchP2P().PeerID())
+	if err != nil {
+		return errors.Wrap(err, "could not compute custody columns")
+	}
+
 	// skipcq: CRT-D0001
 	opts := append(
 		b.serviceFlagOpts.blockchainFlagOpts,
@@ -774,6 +813,8 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithClockSynchronizer(gs),
 		blockchain.WithSyncComplete(syncComplete),
 		blockchain.WithBlobStorage(b.BlobStorage),
+		blockchain.WithDataColumnStorage(b.DataColumnStorage),
+		blockchain.WithCustodyColumns(custody),
 		blockchain.WithTrackedValidatorsCache(b.trackedValidatorsCache),
 		blockchain.WithPayloadIDCache(b.payloadIDCache),
 		blockchain.WithSyncChecker(b.syncChecker),
@@ -857,6 +898,7 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		regularsync.WithInitialSyncComplete(initialSyncComplete),
 		regularsync.WithStateNotifier(b),
 		regularsync.WithBlobStorage(b.BlobStorage),
+		regularsync.WithDataColumnStorage(b.DataColumnStorage),
 		regularsync.WithVerifierWaiter(b.verifyInitWaiter),
 		regularsync.WithAvailableBlocker(bFillStore),
 	)
@@ -882,6 +924,7 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 		ClockWaiter:         b.clockWaiter,
 		InitialSyncComplete: complete,
 		BlobStorage:         b.BlobStorage,
+		DataColumnStorage:   b.DataColumnStorage,
 	}, opts...)
 	return b.services.RegisterService(is)
 }
@@ -910,6 +953,8 @@ func (b *BeaconNode) registerSlasherService() error {
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
+		BeaconDB:                b.db,
+		HistoricalDetection:     b.cliCtx.Bool(flags.HistoricalSlasherNode.Name),
 	})
 	if err != nil {
 		return err
@@ -940,6 +985,11 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		}
 	}
 
+	var monitorService *monitor.Service
+	if err := b.services.FetchService(&monitorService); err != nil {
+		return err
+	}
+
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	var depositFetcher cache.DepositFetcher
 	var chainStartFetcher execution.ChainStartFetcher
@@ -992,6 +1042,7 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		GenesisTimeFetcher:            chainService,
 		GenesisFetcher:                chainService,
 		OptimisticModeFetcher:         chainService,
+		LightClientFetcher:            chainService,
 		AttestationsPool:              b.attestationPool,
 		ExitPool:                      b.exitPool,
 		SlashingsPool:                 b.slashingsPool,
@@ -1016,6 +1067,7 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		BlobStorage:                   b.BlobStorage,
 		TrackedValidatorsCache:        b.trackedValidatorsCache,
 		PayloadIDCache:                b.payloadIDCache,
+		ValidatorMonitor:              monitorService,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -1114,10 +1166,8 @@ func (b *BeaconNode) registerDeterministicGenesisService() error {
 }
 
 func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan struct{}) error {
+	// The service is registered even without tracked validators, they can be added at runtime.
 	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
-	if cliSlice == nil {
-		return nil
-	}
 	tracked := make([]primitives.ValidatorIndex, len(cliSlice))
 	for i := range tracked {
 		tracked[i] = primitives.ValidatorIndex(cliSlice[i])
@@ -1133,6 +1183,7 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 		StateGen:            b.stateGen,
 		HeadFetcher:         chainService,
 		InitialSyncComplete: initialSyncComplete,
+		BeaconDB:            b.db,
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
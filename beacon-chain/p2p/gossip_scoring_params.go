@@ -44,6 +44,9 @@ const (
 	// blsToExecutionChangeWeight specifies the scoring weight that we apply to
 	// our bls to execution topic.
 	blsToExecutionChangeWeight = 0.05
+	// dataColumnSidecarTotalWeight specifies the scoring weight that we apply to
+	// our data column sidecar subnet topics.
+	dataColumnSidecarTotalWeight = 0.8
 
 	// maxInMeshScore describes the max score a peer can attain from being in the mesh.
 	maxInMeshScore = 10
@@ -124,6 +127,8 @@ func (s *Service) topicScoreParams(topic string) (*pubsub.TopicScoreParams, erro
 	case strings.Contains(topic, GossipBlobSidecarMessage):
 		// TODO(Deneb): Using the default block scoring. But this should be updated.
 		return defaultBlockTopicParams(), nil
+	case strings.Contains(topic, GossipDataColumnSidecarMessage):
+		return defaultDataColumnSubnetTopicParams(), nil
 	default:
 		return nil, errors.Errorf("unrecognized topic provided for parameter registration: %s", topic)
 	}
@@ -199,6 +204,51 @@ func defaultBlockTopicParams() *pubsub.TopicScoreParams {
 	}
 }
 
+// defaultDataColumnSubnetTopicParams scores a data column sidecar subnet like the block topic, with the weight
+// spread across subnets and the delivery caps scaled to the number of sidecars a subnet carries per block.
+func defaultDataColumnSubnetTopicParams() *pubsub.TopicScoreParams {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	if subnetCount == 0 {
+		log.Warn("Data column subnet count is 0, skipping initializing topic scoring")
+		return nil
+	}
+	// Get weight for each specific subnet.
+	topicWeight := dataColumnSidecarTotalWeight / float64(subnetCount)
+	// Determine the amount of sidecars expected in a subnet for a single block.
+	numPerSlot := params.BeaconConfig().NumberOfColumns / subnetCount
+	if numPerSlot == 0 {
+		log.Warn("numPerSlot is 0, skipping initializing topic scoring")
+		return nil
+	}
+	decayEpoch := time.Duration(5)
+	sidecarsPerEpoch := numPerSlot * uint64(params.BeaconConfig().SlotsPerEpoch)
+	meshWeight := -0.717
+	if !meshDeliveryIsScored {
+		// Set the mesh weight as zero as a temporary measure, so as to prevent
+		// the average nodes from being penalised.
+		meshWeight = 0
+	}
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     topicWeight,
+		TimeInMeshWeight:                maxInMeshScore / inMeshCap(),
+		TimeInMeshQuantum:               inMeshTime(),
+		TimeInMeshCap:                   inMeshCap(),
+		FirstMessageDeliveriesWeight:    1,
+		FirstMessageDeliveriesDecay:     scoreDecay(twentyEpochs),
+		FirstMessageDeliveriesCap:       23,
+		MeshMessageDeliveriesWeight:     meshWeight,
+		MeshMessageDeliveriesDecay:      scoreDecay(decayEpoch * oneEpochDuration()),
+		MeshMessageDeliveriesCap:        float64(sidecarsPerEpoch * uint64(decayEpoch)),
+		MeshMessageDeliveriesThreshold:  float64(sidecarsPerEpoch*uint64(decayEpoch)) / 10,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 4 * oneEpochDuration(),
+		MeshFailurePenaltyWeight:        meshWeight,
+		MeshFailurePenaltyDecay:         scoreDecay(decayEpoch * oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  -maxScore() / topicWeight,
+		InvalidMessageDeliveriesDecay:   scoreDecay(invalidDecayPeriod),
+	}
+}
+
 func defaultAggregateTopicParams(activeValidators uint64) *pubsub.TopicScoreParams {
 	// Determine the expected message rate for the particular gossip topic.
 	aggPerSlot := aggregatorsPerSlot(activeValidators)
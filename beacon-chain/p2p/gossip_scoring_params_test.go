@@ -75,4 +75,15 @@ func TestLoggingParameters(_ *testing.T) {
 	logGossipParameters("testing", defaultAttesterSlashingTopicParams())
 	logGossipParameters("testing", defaultProposerSlashingTopicParams())
 	logGossipParameters("testing", defaultVoluntaryExitTopicParams())
+	logGossipParameters("testing", defaultDataColumnSubnetTopicParams())
+}
+
+func TestDefaultDataColumnSubnetTopicParams(t *testing.T) {
+	p := defaultDataColumnSubnetTopicParams()
+	require.NotNil(t, p)
+	subnets := params.BeaconConfig().DataColumnSidecarSubnetCount
+	assert.Equal(t, dataColumnSidecarTotalWeight/float64(subnets), p.TopicWeight)
+	perEpoch := params.BeaconConfig().NumberOfColumns / subnets * uint64(params.BeaconConfig().SlotsPerEpoch)
+	assert.Equal(t, float64(perEpoch*5), p.MeshMessageDeliveriesCap)
+	assert.Equal(t, true, p.InvalidMessageDeliveriesWeight < 0)
 }
@@ -0,0 +1,445 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// GossipTraceStdout is the gossip trace path which streams the trace to the standard output.
+	GossipTraceStdout = "-"
+
+	traceReceive   = "receive"
+	traceDeliver   = "deliver"
+	traceReject    = "reject"
+	traceDuplicate = "duplicate"
+	traceSummary   = "summary"
+
+	// gossipTraceBackups is the number of rotated gossip trace files kept.
+	gossipTraceBackups = 3
+	// maxPendingValidationFailures bounds the validation failures waiting for pubsub to reject their message.
+	maxPendingValidationFailures = 1024
+	// maxTraceRejectionReasons bounds the distinct rejection reasons counted per topic in a summary.
+	maxTraceRejectionReasons = 16
+	// maxTraceFirstSeenSamples bounds the first seen offsets kept per topic in a summary.
+	maxTraceFirstSeenSamples = 1 << 16
+	gossipTraceFlushInterval = time.Second
+	// blobSidecarSlotOffset is the offset of the slot of the block header in a blob sidecar, after its index,
+	// blob, KZG commitment and KZG proof.
+	blobSidecarSlotOffset = 8 + fieldparams.BlobLength + 48 + 48
+)
+
+// gossipTraceEvent is a line of the gossip trace.
+type gossipTraceEvent struct {
+	Event        string    `json:"event"`
+	MessageID    string    `json:"message_id,omitempty"`
+	Topic        string    `json:"topic"`
+	Peer         string    `json:"peer,omitempty"`
+	Time         time.Time `json:"time"`
+	SlotOffsetMs *int64    `json:"slot_offset_ms,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+
+	Summary *gossipTopicSummary `json:"summary,omitempty"`
+}
+
+// gossipTopicSummary is the propagation analytics of a topic over a summary interval.
+type gossipTopicSummary struct {
+	Received         int            `json:"received"`
+	Delivered        int            `json:"delivered"`
+	Rejected         int            `json:"rejected"`
+	Duplicates       int            `json:"duplicates"`
+	DuplicateRate    float64        `json:"duplicate_rate"`
+	FirstSeenP50Ms   int64          `json:"first_seen_p50_ms"`
+	FirstSeenP90Ms   int64          `json:"first_seen_p90_ms"`
+	FirstSeenMaxMs   int64          `json:"first_seen_max_ms"`
+	RejectionReasons map[string]int `json:"rejection_reasons,omitempty"`
+}
+
+type gossipTopicStats struct {
+	summary    gossipTopicSummary
+	firstSeens []int64
+}
+
+// gossipTraceSink writes the receive, deliver, reject and duplicate events of every gossip message to a
+// JSON-lines trace, along with a per-topic summary of the propagation of the messages at every epoch, to
+// diagnose late propagation.
+type gossipTraceSink struct {
+	lock        sync.Mutex
+	out         *bufio.Writer
+	closer      io.Closer
+	genesisTime time.Time
+	failures    map[string]string
+	topics      map[string]*gossipTopicStats
+	cancel      context.CancelFunc
+	done        chan struct{}
+	closed      bool
+}
+
+// newGossipTraceSink creates a gossip trace sink writing to the file at path, rotated once it reaches maxSize
+// bytes, or to the standard output if path is GossipTraceStdout.
+func newGossipTraceSink(path string, maxSize uint64) (*gossipTraceSink, error) {
+	var w io.WriteCloser = nopCloser{os.Stdout}
+	if path != GossipTraceStdout {
+		f, err := newRotatingFile(path, maxSize, gossipTraceBackups)
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	}
+	return newGossipTraceSinkWithWriter(w), nil
+}
+
+func newGossipTraceSinkWithWriter(w io.WriteCloser) *gossipTraceSink {
+	return &gossipTraceSink{
+		out:      bufio.NewWriter(w),
+		closer:   w,
+		failures: make(map[string]string),
+		topics:   make(map[string]*gossipTopicStats),
+	}
+}
+
+func (t *gossipTraceSink) setGenesisTime(genesis time.Time) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.genesisTime = genesis
+}
+
+// recordValidationFailure keeps the reason the validation of the message failed, until pubsub rejects the message.
+func (t *gossipTraceSink) recordValidationFailure(msg *pubsub.Message, err error) {
+	if t == nil || msg == nil || err == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if len(t.failures) >= maxPendingValidationFailures {
+		t.failures = make(map[string]string)
+	}
+	t.failures[msg.ID] = err.Error()
+}
+
+func (t *gossipTraceSink) receive(msg *pubsub.Message) {
+	t.trace(traceReceive, msg, "")
+}
+
+func (t *gossipTraceSink) deliver(msg *pubsub.Message) {
+	t.trace(traceDeliver, msg, "")
+}
+
+func (t *gossipTraceSink) reject(msg *pubsub.Message, reason string) {
+	t.trace(traceReject, msg, reason)
+}
+
+func (t *gossipTraceSink) duplicate(msg *pubsub.Message) {
+	t.trace(traceDuplicate, msg, "")
+}
+
+func (t *gossipTraceSink) trace(event string, msg *pubsub.Message, reason string) {
+	if t == nil || msg == nil || msg.Topic == nil {
+		return
+	}
+	now := time.Now()
+	topic := *msg.Topic
+	slot, hasSlot := gossipMessageSlot(topic, msg.Data)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return
+	}
+
+	e := &gossipTraceEvent{
+		Event:     event,
+		MessageID: hex.EncodeToString([]byte(msg.ID)),
+		Topic:     topic,
+		Peer:      msg.ReceivedFrom.String(),
+		Time:      now,
+		Reason:    reason,
+	}
+	switch {
+	case t.genesisTime.IsZero():
+	case hasSlot:
+		// Blocks and blob sidecars are measured from the start of their own slot, so that one received in a
+		// later slot shows its full delay.
+		offset := now.Sub(slots.BeginsAt(slot, t.genesisTime)).Milliseconds()
+		e.SlotOffsetMs = &offset
+	case now.After(t.genesisTime):
+		slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+		offset := (now.Sub(t.genesisTime) % slotDuration).Milliseconds()
+		e.SlotOffsetMs = &offset
+	}
+	stats, ok := t.topics[topic]
+	if !ok {
+		stats = &gossipTopicStats{}
+		t.topics[topic] = stats
+	}
+	switch event {
+	case traceReceive:
+		stats.summary.Received++
+		if e.SlotOffsetMs != nil {
+			gossipFirstSeenSlotOffset.WithLabelValues(topic).Observe(float64(*e.SlotOffsetMs))
+			if len(stats.firstSeens) < maxTraceFirstSeenSamples {
+				stats.firstSeens = append(stats.firstSeens, *e.SlotOffsetMs)
+			}
+		}
+	case traceDeliver:
+		stats.summary.Delivered++
+	case traceReject:
+		if failure, ok := t.failures[msg.ID]; ok {
+			delete(t.failures, msg.ID)
+			e.Reason = fmt.Sprintf("%s: %s", reason, failure)
+		}
+		stats.summary.Rejected++
+		if stats.summary.RejectionReasons == nil {
+			stats.summary.RejectionReasons = make(map[string]int)
+		}
+		if _, ok := stats.summary.RejectionReasons[e.Reason]; ok || len(stats.summary.RejectionReasons) < maxTraceRejectionReasons {
+			stats.summary.RejectionReasons[e.Reason]++
+		} else {
+			stats.summary.RejectionReasons["other"]++
+		}
+	case traceDuplicate:
+		stats.summary.Duplicates++
+	}
+	t.write(e)
+}
+
+// gossipMessageSlot returns the slot of a block or blob sidecar gossip message. Other messages are measured
+// against the slot in which they are received.
+func gossipMessageSlot(topic string, data []byte) (primitives.Slot, bool) {
+	isBlock := strings.Contains(topic, GossipBlockMessage)
+	if !isBlock && !strings.Contains(topic, GossipBlobSidecarMessage) {
+		return 0, false
+	}
+	b, err := encoder.DecodeSnappy(data, encoder.MaxGossipSize)
+	if err != nil {
+		return 0, false
+	}
+	offset := uint64(blobSidecarSlotOffset)
+	if isBlock {
+		// The block is the variable size field of the signed block, found at the offset written first.
+		if len(b) < 4 {
+			return 0, false
+		}
+		offset = uint64(binary.LittleEndian.Uint32(b[:4]))
+	}
+	if uint64(len(b)) < offset+8 {
+		return 0, false
+	}
+	return primitives.Slot(binary.LittleEndian.Uint64(b[offset : offset+8])), true
+}
+
+// summarize writes the summary of every topic since the last summary, and resets the summaries.
+func (t *gossipTraceSink) summarize() {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return
+	}
+	topics := make([]string, 0, len(t.topics))
+	for topic := range t.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	for _, topic := range topics {
+		stats := t.topics[topic]
+		summary := stats.summary
+		if seen := summary.Received + summary.Duplicates; seen > 0 {
+			summary.DuplicateRate = float64(summary.Duplicates) / float64(seen)
+		}
+		if n := len(stats.firstSeens); n > 0 {
+			sort.Slice(stats.firstSeens, func(i, j int) bool { return stats.firstSeens[i] < stats.firstSeens[j] })
+			summary.FirstSeenP50Ms = stats.firstSeens[n/2]
+			summary.FirstSeenP90Ms = stats.firstSeens[n*9/10]
+			summary.FirstSeenMaxMs = stats.firstSeens[n-1]
+		}
+		t.write(&gossipTraceEvent{
+			Event:   traceSummary,
+			Topic:   topic,
+			Time:    now,
+			Summary: &summary,
+		})
+	}
+	t.topics = make(map[string]*gossipTopicStats)
+	t.flush()
+}
+
+// start runs the sink in the background until it is closed or the context is canceled.
+func (t *gossipTraceSink) start(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	t.lock.Lock()
+	t.cancel, t.done = cancel, done
+	t.lock.Unlock()
+	go func() {
+		defer close(done)
+		t.run(ctx)
+	}()
+}
+
+// run flushes the trace every second and writes the topic summaries at every epoch, until the context is canceled.
+func (t *gossipTraceSink) run(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	flush := time.NewTicker(gossipTraceFlushInterval)
+	defer flush.Stop()
+	summary := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second *
+		time.Duration(params.BeaconConfig().SlotsPerEpoch))
+	defer summary.Stop()
+	for {
+		select {
+		case <-flush.C:
+			t.lock.Lock()
+			t.flush()
+			t.lock.Unlock()
+		case <-summary.C:
+			t.summarize()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// close stops the background run of the sink and waits for it, then flushes and closes the trace. Events
+// traced afterwards are dropped.
+func (t *gossipTraceSink) close() error {
+	if t == nil {
+		return nil
+	}
+	t.lock.Lock()
+	cancel, done := t.cancel, t.done
+	t.lock.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	t.flush()
+	return t.closer.Close()
+}
+
+// write writes the event to the trace. The lock must be held.
+func (t *gossipTraceSink) write(e *gossipTraceEvent) {
+	line, err := json.Marshal(e)
+	if err != nil {
+		log.WithError(err).Debug("Could not encode gossip trace event")
+		return
+	}
+	if _, err := t.out.Write(append(line, '\n')); err != nil {
+		log.WithError(err).Debug("Could not write gossip trace event")
+	}
+}
+
+// flush flushes the buffered events. The lock must be held.
+func (t *gossipTraceSink) flush() {
+	if err := t.out.Flush(); err != nil {
+		log.WithError(err).Debug("Could not flush gossip trace")
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// rotatingFile is a file which is renamed with a .1 suffix once it reaches its maximum size, the previous
+// rotated files being shifted up to the number of backups kept.
+type rotatingFile struct {
+	path    string
+	maxSize uint64
+	backups int
+	size    uint64
+	file    *os.File
+}
+
+func newRotatingFile(path string, maxSize uint64, backups int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create gossip trace directory")
+	}
+	r := &rotatingFile{path: path, maxSize: maxSize, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(filepath.Clean(r.path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "could not open gossip trace file")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "could not stat gossip trace file")
+	}
+	r.file = f
+	r.size = uint64(info.Size())
+	return nil
+}
+
+// Write writes p to the file, rotating the file first if p would exceed its maximum size.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.maxSize > 0 && r.size > 0 && r.size+uint64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += uint64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return errors.Wrap(err, "could not close gossip trace file")
+	}
+	for i := r.backups - 1; i > 0; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "could not rotate gossip trace file")
+		}
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return errors.Wrap(err, "could not rotate gossip trace file")
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	return r.file.Close()
+}
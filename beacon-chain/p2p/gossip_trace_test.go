@@ -0,0 +1,237 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	fastssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (*bufferCloser) Close() error {
+	return nil
+}
+
+func traceEvents(t *testing.T, buf *bufferCloser) []*gossipTraceEvent {
+	var events []*gossipTraceEvent
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	for scanner.Scan() {
+		e := &gossipTraceEvent{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), e))
+		events = append(events, e)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func testGossipMessage(id, topic string) *pubsub.Message {
+	return &pubsub.Message{
+		Message:      &pubsubpb.Message{Topic: &topic},
+		ID:           id,
+		ReceivedFrom: peer.ID("peer"),
+	}
+}
+
+func TestGossipTraceSink_Events(t *testing.T) {
+	buf := &bufferCloser{}
+	sink := newGossipTraceSinkWithWriter(buf)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	sink.setGenesisTime(time.Now().Add(-10*slotDuration - time.Second))
+	tracer := gossipTracer{trace: sink}
+
+	accepted := testGossipMessage("accepted", "/eth2/00000000/beacon_block/ssz_snappy")
+	tracer.ValidateMessage(accepted)
+	tracer.DeliverMessage(accepted)
+	tracer.DuplicateMessage(accepted)
+
+	rejected := testGossipMessage("rejected", "/eth2/00000000/beacon_block/ssz_snappy")
+	tracer.ValidateMessage(rejected)
+	sink.recordValidationFailure(rejected, errors.New("block is from a future slot"))
+	tracer.RejectMessage(rejected, pubsub.RejectValidationFailed)
+
+	sink.summarize()
+	events := traceEvents(t, buf)
+	require.Equal(t, 6, len(events))
+
+	assert.Equal(t, traceReceive, events[0].Event)
+	assert.Equal(t, "6163636570746564", events[0].MessageID)
+	assert.Equal(t, "/eth2/00000000/beacon_block/ssz_snappy", events[0].Topic)
+	assert.Equal(t, peer.ID("peer").String(), events[0].Peer)
+	require.NotNil(t, events[0].SlotOffsetMs)
+	assert.Equal(t, true, *events[0].SlotOffsetMs >= 1000 && *events[0].SlotOffsetMs < slotDuration.Milliseconds())
+	assert.Equal(t, traceDeliver, events[1].Event)
+	assert.Equal(t, traceDuplicate, events[2].Event)
+	assert.Equal(t, traceReceive, events[3].Event)
+	assert.Equal(t, traceReject, events[4].Event)
+	assert.Equal(t, pubsub.RejectValidationFailed+": block is from a future slot", events[4].Reason)
+
+	summary := events[5]
+	assert.Equal(t, traceSummary, summary.Event)
+	require.NotNil(t, summary.Summary)
+	assert.Equal(t, 2, summary.Summary.Received)
+	assert.Equal(t, 1, summary.Summary.Delivered)
+	assert.Equal(t, 1, summary.Summary.Rejected)
+	assert.Equal(t, 1, summary.Summary.Duplicates)
+	assert.Equal(t, 1.0/3, summary.Summary.DuplicateRate)
+	assert.Equal(t, true, summary.Summary.FirstSeenMaxMs >= 1000)
+	assert.DeepEqual(t, map[string]int{pubsub.RejectValidationFailed + ": block is from a future slot": 1}, summary.Summary.RejectionReasons)
+
+	// The summaries are reset once written.
+	buf.Reset()
+	sink.summarize()
+	require.Equal(t, 0, len(traceEvents(t, buf)))
+}
+
+func encodedGossipMessage(t *testing.T, id, topic string, msg fastssz.Marshaler) *pubsub.Message {
+	buf := new(bytes.Buffer)
+	_, err := encoder.SszNetworkEncoder{}.EncodeGossip(buf, msg)
+	require.NoError(t, err)
+	m := testGossipMessage(id, topic)
+	m.Data = buf.Bytes()
+	return m
+}
+
+func TestGossipTraceSink_MessageSlotOffset(t *testing.T) {
+	buf := &bufferCloser{}
+	sink := newGossipTraceSinkWithWriter(buf)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	// The messages of slot 9 are received one second into slot 10.
+	sink.setGenesisTime(time.Now().Add(-10*slotDuration - time.Second))
+
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 9
+	sink.receive(encodedGossipMessage(t, "block", "/eth2/00000000/beacon_block/ssz_snappy", blk))
+	sidecar := &ethpb.BlobSidecar{
+		Blob:          make([]byte, fieldparams.BlobLength),
+		KzgCommitment: make([]byte, 48),
+		KzgProof:      make([]byte, 48),
+		SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:       9,
+				ParentRoot: make([]byte, 32),
+				StateRoot:  make([]byte, 32),
+				BodyRoot:   make([]byte, 32),
+			},
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		CommitmentInclusionProof: make([][]byte, fieldparams.KzgCommitmentInclusionProofDepth),
+	}
+	for i := range sidecar.CommitmentInclusionProof {
+		sidecar.CommitmentInclusionProof[i] = make([]byte, 32)
+	}
+	sink.receive(encodedGossipMessage(t, "sidecar", "/eth2/00000000/blob_sidecar_0/ssz_snappy", sidecar))
+	att := util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 9}})
+	sink.receive(encodedGossipMessage(t, "attestation", "/eth2/00000000/beacon_attestation_0/ssz_snappy", att))
+
+	sink.lock.Lock()
+	sink.flush()
+	sink.lock.Unlock()
+	events := traceEvents(t, buf)
+	require.Equal(t, 3, len(events))
+	for _, e := range events[:2] {
+		require.NotNil(t, e.SlotOffsetMs)
+		assert.Equal(t, true, *e.SlotOffsetMs >= slotDuration.Milliseconds()+1000, e.Topic)
+	}
+	require.NotNil(t, events[2].SlotOffsetMs)
+	assert.Equal(t, true, *events[2].SlotOffsetMs >= 1000 && *events[2].SlotOffsetMs < slotDuration.Milliseconds())
+}
+
+func TestGossipTraceSink_Close(t *testing.T) {
+	buf := &bufferCloser{}
+	sink := newGossipTraceSinkWithWriter(buf)
+	sink.start(context.Background())
+	sink.receive(testGossipMessage("before", "topic"))
+	require.NoError(t, sink.close())
+	// The run goroutine has returned and later events are dropped.
+	select {
+	case <-sink.done:
+	default:
+		t.Fatal("gossip trace still running after close")
+	}
+	sink.receive(testGossipMessage("after", "topic"))
+	sink.summarize()
+	require.NoError(t, sink.close())
+	events := traceEvents(t, buf)
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, "6265666f7265", events[0].MessageID)
+}
+
+func TestGossipTraceSink_RejectionReasonsBounded(t *testing.T) {
+	buf := &bufferCloser{}
+	sink := newGossipTraceSinkWithWriter(buf)
+	for i := 0; i < maxTraceRejectionReasons+2; i++ {
+		msg := testGossipMessage(string(rune('a'+i)), "topic")
+		sink.recordValidationFailure(msg, errors.New(msg.ID))
+		sink.reject(msg, pubsub.RejectValidationIgnored)
+	}
+	sink.summarize()
+	events := traceEvents(t, buf)
+	summary := events[len(events)-1].Summary
+	require.NotNil(t, summary)
+	assert.Equal(t, maxTraceRejectionReasons+1, len(summary.RejectionReasons))
+	assert.Equal(t, 2, summary.RejectionReasons["other"])
+}
+
+func TestGossipTraceSink_Disabled(t *testing.T) {
+	var sink *gossipTraceSink
+	tracer := gossipTracer{trace: sink}
+	msg := testGossipMessage("id", "topic")
+	tracer.ValidateMessage(msg)
+	tracer.RejectMessage(msg, pubsub.RejectValidationFailed)
+	sink.recordValidationFailure(msg, errors.New("failed"))
+	sink.summarize()
+	require.NoError(t, sink.close())
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace", "gossip.jsonl")
+	f, err := newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err = f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	for file, want := range map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	} {
+		got, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+	_, err = os.Stat(path + ".3")
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	// Reopening the file appends to it.
+	f, err = newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("fifth\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	got, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "fourth\n", string(got))
+}
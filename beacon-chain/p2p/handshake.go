@@ -92,6 +92,7 @@ func (s *Service) AddConnectionHandler(reqFunc, goodByeFunc func(ctx context.Con
 				}
 				validPeerConnection := func() {
 					s.peers.SetConnectionState(conn.RemotePeer(), peers.PeerConnected)
+					s.peers.SetAgent(conn.RemotePeer(), rawAgentFromPid(conn.RemotePeer(), s.host.Peerstore()))
 					// Go through the handshake process.
 					log.WithFields(logrus.Fields{
 						"direction":   conn.Stat().Direction,
@@ -23,6 +23,7 @@ type P2P interface {
 	SetStreamHandler
 	PubSubProvider
 	PubSubTopicUser
+	GossipTraceRecorder
 	SenderEncoder
 	PeerManager
 	ConnectionHandler
@@ -75,6 +76,11 @@ type PubSubProvider interface {
 	PubSub() *pubsub.PubSub
 }
 
+// GossipTraceRecorder records why gossip messages failed validation in the gossip trace.
+type GossipTraceRecorder interface {
+	TraceValidationFailure(msg *pubsub.Message, err error)
+}
+
 // PeerManager abstracts some peer management methods from libp2p.
 type PeerManager interface {
 	Disconnect(peer.ID) error
@@ -161,6 +161,12 @@ var (
 		Help: "The number of publish messages sent via rpc for a particular topic",
 	},
 		[]string{"topic"})
+	gossipFirstSeenSlotOffset = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "p2p_gossip_first_seen_slot_offset_milliseconds",
+		Help:    "The time since the start of the slot at which a gossip message was first seen, when the gossip trace is enabled",
+		Buckets: []float64{250, 500, 1000, 2000, 3000, 4000, 6000, 8000, 12000},
+	},
+		[]string{"topic"})
 )
 
 func (s *Service) updateMetrics() {
@@ -210,10 +216,8 @@ func average(xs []float64) float64 {
 }
 
 func agentFromPid(pid peer.ID, store peerstore.Peerstore) string {
-	// Get the agent data.
-	rawAgent, err := store.Get(pid, "AgentVersion")
-	agent, ok := rawAgent.(string)
-	if err != nil || !ok {
+	agent := rawAgentFromPid(pid, store)
+	if agent == "" {
 		return "unknown"
 	}
 	foundName := "unknown"
@@ -226,3 +230,13 @@ func agentFromPid(pid peer.ID, store peerstore.Peerstore) string {
 	}
 	return foundName
 }
+
+// rawAgentFromPid returns the agent string advertised by the peer, or an empty string if it is not known.
+func rawAgentFromPid(pid peer.ID, store peerstore.Peerstore) string {
+	rawAgent, err := store.Get(pid, "AgentVersion")
+	agent, ok := rawAgent.(string)
+	if err != nil || !ok {
+		return ""
+	}
+	return agent
+}
@@ -52,6 +52,7 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	Agent         string
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
@@ -0,0 +1,205 @@
+package scorers
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Default weights of the registered scorers, used when a policy does not override them.
+const (
+	DefaultBadResponsesWeight    = 0.3
+	DefaultBlockProviderWeight   = 0.0
+	DefaultPeerStatusWeight      = 0.3
+	DefaultGossipWeight          = 0.4
+	maxClientPreferenceMagnitude = 1.0
+)
+
+// ScorerWeights holds the weight of every registered scorer in the overall peer score.
+type ScorerWeights struct {
+	BadResponses  float64
+	BlockProvider float64
+	PeerStatus    float64
+	Gossip        float64
+}
+
+// Policy defines how peers are treated on top of the registered scorers:
+//   - Weights replace the default scorer weights.
+//   - Allowed peers are never considered bad by the scorers.
+//   - Denied peers are always considered bad, and get the bad peer score.
+//   - Pinned peers are never considered bad and are never pruned.
+//   - ClientPreferences adjust the score of peers whose agent string contains the given
+//     (lowercase) client name. When several names match, the longest one is used.
+type Policy struct {
+	Weights           ScorerWeights
+	Allowed           map[peer.ID]bool
+	Denied            map[peer.ID]bool
+	Pinned            map[peer.ID]bool
+	ClientPreferences map[string]float64
+}
+
+// PolicyConfig is the YAML representation of a scoring policy. Omitted weights keep their default value.
+type PolicyConfig struct {
+	Weights struct {
+		BadResponses  *float64 `yaml:"bad_responses"`
+		BlockProvider *float64 `yaml:"block_provider"`
+		PeerStatus    *float64 `yaml:"peer_status"`
+		Gossip        *float64 `yaml:"gossip"`
+	} `yaml:"weights"`
+	Allow             []string           `yaml:"allow"`
+	Deny              []string           `yaml:"deny"`
+	Pin               []string           `yaml:"pin"`
+	ClientPreferences map[string]float64 `yaml:"client_preferences"`
+}
+
+// DefaultPolicy returns the policy in use when none is configured.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Weights: ScorerWeights{
+			BadResponses:  DefaultBadResponsesWeight,
+			BlockProvider: DefaultBlockProviderWeight,
+			PeerStatus:    DefaultPeerStatusWeight,
+			Gossip:        DefaultGossipWeight,
+		},
+		Allowed:           make(map[peer.ID]bool),
+		Denied:            make(map[peer.ID]bool),
+		Pinned:            make(map[peer.ID]bool),
+		ClientPreferences: make(map[string]float64),
+	}
+}
+
+// LoadPolicy reads a scoring policy from a YAML file.
+func LoadPolicy(path string) (*Policy, error) {
+	enc, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read peer scoring policy")
+	}
+	return ParsePolicy(enc)
+}
+
+// ParsePolicy decodes a YAML scoring policy.
+func ParsePolicy(enc []byte) (*Policy, error) {
+	cfg := &PolicyConfig{}
+	if err := yaml.UnmarshalStrict(enc, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not decode peer scoring policy")
+	}
+	return cfg.Policy()
+}
+
+// Policy converts the configuration into a validated scoring policy.
+func (c *PolicyConfig) Policy() (*Policy, error) {
+	p := DefaultPolicy()
+	if c.Weights.BadResponses != nil {
+		p.Weights.BadResponses = *c.Weights.BadResponses
+	}
+	if c.Weights.BlockProvider != nil {
+		p.Weights.BlockProvider = *c.Weights.BlockProvider
+	}
+	if c.Weights.PeerStatus != nil {
+		p.Weights.PeerStatus = *c.Weights.PeerStatus
+	}
+	if c.Weights.Gossip != nil {
+		p.Weights.Gossip = *c.Weights.Gossip
+	}
+	lists := []struct {
+		name string
+		ids  []string
+		set  map[peer.ID]bool
+	}{
+		{"allow", c.Allow, p.Allowed},
+		{"deny", c.Deny, p.Denied},
+		{"pin", c.Pin, p.Pinned},
+	}
+	for _, l := range lists {
+		for _, id := range l.ids {
+			pid, err := peer.Decode(id)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid peer ID %q in %s list", id, l.name)
+			}
+			l.set[pid] = true
+		}
+	}
+	for client, adj := range c.ClientPreferences {
+		p.ClientPreferences[strings.ToLower(client)] = adj
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Copy returns a deep copy of the policy.
+func (p *Policy) Copy() *Policy {
+	cp := &Policy{
+		Weights:           p.Weights,
+		Allowed:           make(map[peer.ID]bool, len(p.Allowed)),
+		Denied:            make(map[peer.ID]bool, len(p.Denied)),
+		Pinned:            make(map[peer.ID]bool, len(p.Pinned)),
+		ClientPreferences: make(map[string]float64, len(p.ClientPreferences)),
+	}
+	for pid := range p.Allowed {
+		cp.Allowed[pid] = true
+	}
+	for pid := range p.Denied {
+		cp.Denied[pid] = true
+	}
+	for pid := range p.Pinned {
+		cp.Pinned[pid] = true
+	}
+	for client, adj := range p.ClientPreferences {
+		cp.ClientPreferences[client] = adj
+	}
+	return cp
+}
+
+// ClientPreference returns the score adjustment applied to a peer with the given agent string.
+func (p *Policy) ClientPreference(agent string) float64 {
+	agent = strings.ToLower(agent)
+	clients := make([]string, 0, len(p.ClientPreferences))
+	for client := range p.ClientPreferences {
+		if strings.Contains(agent, client) {
+			clients = append(clients, client)
+		}
+	}
+	if len(clients) == 0 {
+		return 0
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		if len(clients[i]) != len(clients[j]) {
+			return len(clients[i]) > len(clients[j])
+		}
+		return clients[i] < clients[j]
+	})
+	return p.ClientPreferences[clients[0]]
+}
+
+func (p *Policy) validate() error {
+	w := p.Weights
+	for _, weight := range []float64{w.BadResponses, w.BlockProvider, w.PeerStatus, w.Gossip} {
+		if weight < 0 {
+			return errors.Errorf("scorer weights must not be negative, got %f", weight)
+		}
+	}
+	if w.BadResponses+w.BlockProvider+w.PeerStatus+w.Gossip == 0 {
+		return errors.New("at least one scorer weight must be positive")
+	}
+	for client, adj := range p.ClientPreferences {
+		if client == "" {
+			return errors.New("client preference with an empty client name")
+		}
+		if adj < -maxClientPreferenceMagnitude || adj > maxClientPreferenceMagnitude {
+			return errors.Errorf("client preference for %s must be between %.0f and %.0f, got %f",
+				client, -maxClientPreferenceMagnitude, maxClientPreferenceMagnitude, adj)
+		}
+	}
+	for pid := range p.Denied {
+		if p.Allowed[pid] || p.Pinned[pid] {
+			return errors.Errorf("peer %s cannot be both denied and allowed or pinned", pid)
+		}
+	}
+	return nil
+}
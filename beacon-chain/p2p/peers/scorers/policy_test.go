@@ -0,0 +1,157 @@
+package scorers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const (
+	policyPeer1 = "16Uiu2HAkvyYtoQXZNTsthjgLHjEnv7kvwzEmjvsJjWXpbhtqpSUN"
+	policyPeer2 = "16Uiu2HAmQqFdEcHbSmQTQuLoAhnMUrgoWoraKK4cUJT6FuuqHqTU"
+	policyPeer3 = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"
+)
+
+func decodePeer(t *testing.T, id string) peer.ID {
+	pid, err := peer.Decode(id)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+weights:
+  bad_responses: 1
+  gossip: 0
+allow:
+  - `+policyPeer1+`
+deny:
+  - `+policyPeer2+`
+pin:
+  - `+policyPeer3+`
+client_preferences:
+  Lighthouse: 0.1
+  prysm: -0.2
+`), 0600))
+
+	policy, err := scorers.LoadPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), policy.Weights.BadResponses)
+	assert.Equal(t, scorers.DefaultPeerStatusWeight, policy.Weights.PeerStatus)
+	assert.Equal(t, float64(0), policy.Weights.Gossip)
+	assert.Equal(t, true, policy.Allowed[decodePeer(t, policyPeer1)])
+	assert.Equal(t, true, policy.Denied[decodePeer(t, policyPeer2)])
+	assert.Equal(t, true, policy.Pinned[decodePeer(t, policyPeer3)])
+	assert.Equal(t, 0.1, policy.ClientPreference("Lighthouse/v5.1.0-abc/x86_64-linux"))
+	assert.Equal(t, -0.2, policy.ClientPreference("Prysm/v5.0.3/abc"))
+	assert.Equal(t, float64(0), policy.ClientPreference("teku/v24.1.0"))
+
+	_, err = scorers.LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, "could not read peer scoring policy", err)
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{name: "unknown field", policy: "weights:\n  latency: 1\n", err: "could not decode peer scoring policy"},
+		{name: "invalid peer ID", policy: "deny: [foo]\n", err: "invalid peer ID \"foo\" in deny list"},
+		{name: "negative weight", policy: "weights:\n  gossip: -1\n", err: "scorer weights must not be negative"},
+		{
+			name:   "no positive weight",
+			policy: "weights:\n  bad_responses: 0\n  block_provider: 0\n  peer_status: 0\n  gossip: 0\n",
+			err:    "at least one scorer weight must be positive",
+		},
+		{name: "client preference out of range", policy: "client_preferences:\n  nimbus: 2\n", err: "client preference for nimbus"},
+		{name: "denied and pinned", policy: "deny: [" + policyPeer1 + "]\npin: [" + policyPeer1 + "]\n", err: "cannot be both denied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scorers.ParsePolicy([]byte(tt.policy))
+			assert.ErrorContains(t, tt.err, err)
+		})
+	}
+}
+
+func TestPolicy_ClientPreference(t *testing.T) {
+	policy := scorers.DefaultPolicy()
+	policy.ClientPreferences["libp2p"] = -0.5
+	policy.ClientPreferences["rust-libp2p"] = 0.5
+	assert.Equal(t, 0.5, policy.ClientPreference("rust-libp2p/0.53.0"))
+	assert.Equal(t, -0.5, policy.ClientPreference("js-libp2p/1.0.0"))
+	assert.Equal(t, float64(0), policy.ClientPreference(""))
+}
+
+func TestScorers_Service_Policy(t *testing.T) {
+	policy := scorers.DefaultPolicy()
+	policy.Weights = scorers.ScorerWeights{BadResponses: 1}
+	policy.ClientPreferences["lighthouse"] = 0.1
+	peerStatuses := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold:     2,
+				DecayInterval: 50 * time.Second,
+			},
+			Policy: policy,
+		},
+	})
+	s := peerStatuses.Scorers()
+	pid := decodePeer(t, policyPeer1)
+	peerStatuses.Add(nil, pid, nil, network.DirOutbound)
+
+	t.Run("weights and client preferences", func(t *testing.T) {
+		s.BadResponsesScorer().Increment(pid)
+		assert.Equal(t, s.BadResponsesScorer().Score(pid), s.Score(pid))
+		peerStatuses.SetAgent(pid, "Lighthouse/v5.1.0")
+		assert.Equal(t, roundScore(s.BadResponsesScorer().Score(pid)+0.1), s.Score(pid))
+
+		breakdown := s.ScoreBreakdown(pid)
+		require.NotNil(t, breakdown)
+		assert.Equal(t, "Lighthouse/v5.1.0", breakdown.Agent)
+		assert.Equal(t, 0.1, breakdown.ClientPreference)
+		assert.Equal(t, float64(1), breakdown.Weights.BadResponses)
+		assert.Equal(t, float64(0), breakdown.Weights.Gossip)
+		require.IsNil(t, s.ScoreBreakdown(decodePeer(t, policyPeer2)))
+	})
+
+	t.Run("allowed peers are not bad", func(t *testing.T) {
+		s.BadResponsesScorer().Increment(pid)
+		assert.Equal(t, true, s.IsBadPeer(pid))
+		assert.Equal(t, true, peerStatuses.IsBad(pid))
+		allowed := s.Policy()
+		allowed.Allowed[pid] = true
+		require.NoError(t, s.SetPolicy(allowed))
+		assert.Equal(t, false, s.IsBadPeer(pid))
+		assert.Equal(t, false, peerStatuses.IsBad(pid))
+		assert.Equal(t, 0, len(s.BadPeers()))
+	})
+
+	t.Run("denied peers are bad", func(t *testing.T) {
+		s.DenyPeer(pid, true)
+		assert.Equal(t, false, s.Policy().Allowed[pid])
+		assert.Equal(t, true, s.IsBadPeer(pid))
+		assert.Equal(t, scorers.BadPeerScore, s.Score(pid))
+		s.DenyPeer(pid, false)
+		assert.Equal(t, false, s.Policy().Denied[pid])
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		invalid := scorers.DefaultPolicy()
+		invalid.Weights = scorers.ScorerWeights{}
+		assert.ErrorContains(t, "invalid peer scoring policy", s.SetPolicy(invalid))
+		assert.Equal(t, float64(1), s.Policy().Weights.BadResponses)
+	})
+}
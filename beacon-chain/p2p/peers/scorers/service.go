@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 )
@@ -39,6 +40,7 @@ type Service struct {
 	}
 	weights     map[Scorer]float64
 	totalWeight float64
+	policy      *Policy
 }
 
 // Config holds configuration parameters for scoring service.
@@ -47,6 +49,8 @@ type Config struct {
 	BlockProviderScorerConfig *BlockProviderScorerConfig
 	PeerStatusScorerConfig    *PeerStatusScorerConfig
 	GossipScorerConfig        *GossipScorerConfig
+	// Policy is the initial scoring policy, the default policy is used when nil.
+	Policy *Policy
 }
 
 // NewService provides fully initialized peer scoring service.
@@ -58,13 +62,14 @@ func NewService(ctx context.Context, store *peerdata.Store, config *Config) *Ser
 
 	// Register scorers.
 	s.scorers.badResponsesScorer = newBadResponsesScorer(store, config.BadResponsesScorerConfig)
-	s.setScorerWeight(s.scorers.badResponsesScorer, 0.3)
 	s.scorers.blockProviderScorer = newBlockProviderScorer(store, config.BlockProviderScorerConfig)
-	s.setScorerWeight(s.scorers.blockProviderScorer, 0.0)
 	s.scorers.peerStatusScorer = newPeerStatusScorer(store, config.PeerStatusScorerConfig)
-	s.setScorerWeight(s.scorers.peerStatusScorer, 0.3)
 	s.scorers.gossipScorer = newGossipScorer(store, config.GossipScorerConfig)
-	s.setScorerWeight(s.scorers.gossipScorer, 0.4)
+	policy := config.Policy
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	s.applyPolicy(policy.Copy())
 
 	// Start background tasks.
 	go s.loop(ctx)
@@ -112,17 +117,68 @@ func (s *Service) Score(pid peer.ID) float64 {
 
 // ScoreNoLock is a lock-free version of Score.
 func (s *Service) ScoreNoLock(pid peer.ID) float64 {
-	score := float64(0)
-	if _, ok := s.store.PeerData(pid); !ok {
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
 		return 0
 	}
+	if s.policy.Denied[pid] {
+		return BadPeerScore
+	}
+	score := float64(0)
 	score += s.scorers.badResponsesScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.badResponsesScorer)
 	score += s.scorers.blockProviderScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.blockProviderScorer)
 	score += s.scorers.peerStatusScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.peerStatusScorer)
 	score += s.scorers.gossipScorer.scoreNoLock(pid) * s.scorerWeight(s.scorers.gossipScorer)
+	score += s.policy.ClientPreference(peerData.Agent)
 	return math.Round(score*ScoreRoundingFactor) / ScoreRoundingFactor
 }
 
+// ScoreBreakdown details how the score of a peer is calculated.
+type ScoreBreakdown struct {
+	Score            float64
+	BadResponses     float64
+	BlockProvider    float64
+	PeerStatus       float64
+	Gossip           float64
+	Weights          ScorerWeights
+	Agent            string
+	ClientPreference float64
+	IsBad            bool
+	Allowed          bool
+	Denied           bool
+	Pinned           bool
+}
+
+// ScoreBreakdown returns the individual scores and policy decisions for a peer, or nil if the peer is unknown.
+// Scorer weights are normalized so that they add up to one.
+func (s *Service) ScoreBreakdown(pid peer.ID) *ScoreBreakdown {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	peerData, ok := s.store.PeerData(pid)
+	if !ok {
+		return nil
+	}
+	return &ScoreBreakdown{
+		Score:         s.ScoreNoLock(pid),
+		BadResponses:  s.scorers.badResponsesScorer.scoreNoLock(pid),
+		BlockProvider: s.scorers.blockProviderScorer.scoreNoLock(pid),
+		PeerStatus:    s.scorers.peerStatusScorer.scoreNoLock(pid),
+		Gossip:        s.scorers.gossipScorer.scoreNoLock(pid),
+		Weights: ScorerWeights{
+			BadResponses:  s.scorerWeight(s.scorers.badResponsesScorer),
+			BlockProvider: s.scorerWeight(s.scorers.blockProviderScorer),
+			PeerStatus:    s.scorerWeight(s.scorers.peerStatusScorer),
+			Gossip:        s.scorerWeight(s.scorers.gossipScorer),
+		},
+		Agent:            peerData.Agent,
+		ClientPreference: s.policy.ClientPreference(peerData.Agent),
+		IsBad:            s.IsBadPeerNoLock(pid),
+		Allowed:          s.policy.Allowed[pid],
+		Denied:           s.policy.Denied[pid],
+		Pinned:           s.policy.Pinned[pid],
+	}
+}
+
 // IsBadPeer traverses all the scorers to see if any of them classifies peer as bad.
 func (s *Service) IsBadPeer(pid peer.ID) bool {
 	s.store.RLock()
@@ -132,6 +188,12 @@ func (s *Service) IsBadPeer(pid peer.ID) bool {
 
 // IsBadPeerNoLock is a lock-free version of IsBadPeer.
 func (s *Service) IsBadPeerNoLock(pid peer.ID) bool {
+	if s.policy.Denied[pid] {
+		return true
+	}
+	if s.IsProtectedPeerNoLock(pid) {
+		return false
+	}
 	if s.scorers.badResponsesScorer.isBadPeerNoLock(pid) {
 		return true
 	}
@@ -160,6 +222,53 @@ func (s *Service) BadPeers() []peer.ID {
 	return badPeers
 }
 
+// Policy returns a copy of the scoring policy in use.
+func (s *Service) Policy() *Policy {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	return s.policy.Copy()
+}
+
+// SetPolicy replaces the scoring policy in use.
+func (s *Service) SetPolicy(policy *Policy) error {
+	if err := policy.validate(); err != nil {
+		return errors.Wrap(err, "invalid peer scoring policy")
+	}
+	s.store.Lock()
+	defer s.store.Unlock()
+	s.applyPolicy(policy.Copy())
+	return nil
+}
+
+// DenyPeer adds the peer to the deny list of the scoring policy, or removes it when deny is false.
+// A denied peer is removed from the allow and pin lists.
+func (s *Service) DenyPeer(pid peer.ID, deny bool) {
+	s.store.Lock()
+	defer s.store.Unlock()
+	if !deny {
+		delete(s.policy.Denied, pid)
+		return
+	}
+	s.policy.Denied[pid] = true
+	delete(s.policy.Allowed, pid)
+	delete(s.policy.Pinned, pid)
+}
+
+// IsDeniedPeerNoLock checks whether the peer is in the deny list of the scoring policy.
+func (s *Service) IsDeniedPeerNoLock(pid peer.ID) bool {
+	return s.policy.Denied[pid]
+}
+
+// IsPinnedPeerNoLock checks whether the peer is pinned by the scoring policy.
+func (s *Service) IsPinnedPeerNoLock(pid peer.ID) bool {
+	return s.policy.Pinned[pid]
+}
+
+// IsProtectedPeerNoLock checks whether the scoring policy prevents the peer from being considered bad.
+func (s *Service) IsProtectedPeerNoLock(pid peer.ID) bool {
+	return s.policy.Allowed[pid] || s.policy.Pinned[pid]
+}
+
 // ValidationError returns peer data validation error, which potentially provides more information
 // why peer is considered bad.
 func (s *Service) ValidationError(pid peer.ID) error {
@@ -200,6 +309,17 @@ func (s *Service) loop(ctx context.Context) {
 	}
 }
 
+// applyPolicy sets the policy and the scorer weights it defines.
+func (s *Service) applyPolicy(policy *Policy) {
+	s.policy = policy
+	s.weights = make(map[Scorer]float64)
+	s.totalWeight = 0
+	s.setScorerWeight(s.scorers.badResponsesScorer, policy.Weights.BadResponses)
+	s.setScorerWeight(s.scorers.blockProviderScorer, policy.Weights.BlockProvider)
+	s.setScorerWeight(s.scorers.peerStatusScorer, policy.Weights.PeerStatus)
+	s.setScorerWeight(s.scorers.gossipScorer, policy.Weights.Gossip)
+}
+
 // setScorerWeight adds scorer to map of known scorers.
 func (s *Service) setScorerWeight(scorer Scorer, weight float64) {
 	s.weights[scorer] = weight
@@ -194,6 +194,23 @@ func (p *Status) ENR(pid peer.ID) (*enr.Record, error) {
 	return nil, peerdata.ErrPeerUnknown
 }
 
+// SetAgent sets the agent string advertised by the given remote peer.
+func (p *Status) SetAgent(pid peer.ID, agent string) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.PeerDataGetOrCreate(pid).Agent = agent
+}
+
+// Agent returns the agent string advertised by the given remote peer.
+func (p *Status) Agent(pid peer.ID) (string, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.Agent, nil
+	}
+	return "", peerdata.ErrPeerUnknown
+}
+
 // SetChainState sets the chain state of the given remote peer.
 func (p *Status) SetChainState(pid peer.ID, chainState *pb.Status) {
 	p.scorers.PeerStatusScorer().SetPeerStatus(pid, chainState, nil)
@@ -343,8 +360,12 @@ func (p *Status) IsBad(pid peer.ID) bool {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) bool {
-	// Do not disconnect from trusted peers.
-	if p.store.IsTrustedPeer(pid) {
+	// Peers denied by the scoring policy are bad, even if they are trusted.
+	if p.scorers.IsDeniedPeerNoLock(pid) {
+		return true
+	}
+	// Do not disconnect from trusted peers, or from peers allowed or pinned by the scoring policy.
+	if p.store.IsTrustedPeer(pid) || p.scorers.IsProtectedPeerNoLock(pid) {
 		return false
 	}
 	return p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
@@ -814,8 +835,8 @@ func (p *Status) PeersToPrune() []peer.ID {
 	peersToPrune := make([]*peerResp, 0)
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound && !p.store.IsTrustedPeer(pid) {
+		if peerData.ConnState == PeerConnected && peerData.Direction == network.DirInbound &&
+			!p.store.IsTrustedPeer(pid) && !p.scorers.IsPinnedPeerNoLock(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.scorers.ScoreNoLock(pid),
@@ -880,8 +901,8 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	peersToPrune := make([]*peerResp, 0)
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound && !p.store.IsTrustedPeer(pid) {
+		if peerData.ConnState == PeerConnected && peerData.Direction == network.DirInbound &&
+			!p.store.IsTrustedPeer(pid) && !p.scorers.IsPinnedPeerNoLock(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -860,6 +860,49 @@ func TestPrunePeers_TrustedPeers(t *testing.T) {
 	}
 }
 
+func TestPrunePeers_PinnedPeers(t *testing.T) {
+	policy := scorers.DefaultPolicy()
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+			Policy: policy,
+		},
+	})
+
+	for i := 0; i < 15; i++ {
+		createPeer(t, p, nil, network.DirOutbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	for i := 0; i < 18; i++ {
+		createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+
+	// Pin all inbound peers but two, pinned peers are neither pruned nor bad.
+	inboundPeers := p.InboundConnected()
+	for _, pid := range inboundPeers[2:] {
+		policy.Pinned[pid] = true
+		p.Scorers().BadResponsesScorer().Increment(pid)
+	}
+	require.NoError(t, p.Scorers().SetPolicy(policy))
+	peersToPrune := p.PeersToPrune()
+	assert.Equal(t, 2, len(peersToPrune))
+	for _, pid := range peersToPrune {
+		assert.Equal(t, false, p.Scorers().Policy().Pinned[pid])
+	}
+	for _, pid := range inboundPeers[2:] {
+		assert.Equal(t, false, p.IsBad(pid))
+	}
+
+	// A denied peer is bad, even if it is trusted.
+	p.SetTrustedPeers([]peer.ID{inboundPeers[0]})
+	p.Scorers().DenyPeer(inboundPeers[0], true)
+	assert.Equal(t, true, p.IsBad(inboundPeers[0]))
+	p.Scorers().DenyPeer(inboundPeers[0], false)
+	assert.Equal(t, false, p.IsBad(inboundPeers[0]))
+}
+
 func TestStatus_BestPeer(t *testing.T) {
 	type peerConfig struct {
 		headSlot       primitives.Slot
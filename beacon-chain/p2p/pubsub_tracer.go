@@ -20,9 +20,10 @@ const (
 )
 
 // This tracer is used to implement metrics collection for messages received
-// and broadcasted through gossipsub.
+// and broadcasted through gossipsub, and to feed the gossip trace when it is enabled.
 type gossipTracer struct {
-	host host.Host
+	host  host.Host
+	trace *gossipTraceSink
 }
 
 // AddPeer .
@@ -58,21 +59,25 @@ func (g gossipTracer) Prune(p peer.ID, topic string) {
 // ValidateMessage .
 func (g gossipTracer) ValidateMessage(msg *pubsub.Message) {
 	pubsubMessageValidate.WithLabelValues(*msg.Topic).Inc()
+	g.trace.receive(msg)
 }
 
 // DeliverMessage .
 func (g gossipTracer) DeliverMessage(msg *pubsub.Message) {
 	pubsubMessageDeliver.WithLabelValues(*msg.Topic).Inc()
+	g.trace.deliver(msg)
 }
 
 // RejectMessage .
 func (g gossipTracer) RejectMessage(msg *pubsub.Message, reason string) {
 	pubsubMessageReject.WithLabelValues(*msg.Topic, reason).Inc()
+	g.trace.reject(msg, reason)
 }
 
 // DuplicateMessage .
 func (g gossipTracer) DuplicateMessage(msg *pubsub.Message) {
 	pubsubMessageDuplicate.WithLabelValues(*msg.Topic).Inc()
+	g.trace.duplicate(msg)
 }
 
 // UndeliverableMessage .
@@ -78,6 +78,7 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	gossipTrace           *gossipTraceSink
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -119,6 +120,13 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		subnetsLock:  make(map[uint64]*sync.RWMutex),
 	}
 
+	if cfg.GossipTracePath != "" {
+		s.gossipTrace, err = newGossipTraceSink(cfg.GossipTracePath, cfg.GossipTraceMaxSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create gossip trace")
+		}
+	}
+
 	ipAddr := prysmnetwork.IPAddr()
 
 	opts, err := s.buildOptions(ipAddr, s.privKey)
@@ -162,6 +170,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 				Threshold:     maxBadResponses,
 				DecayInterval: time.Hour,
 			},
+			Policy: s.cfg.ScoringPolicy,
 		},
 	})
 
@@ -182,6 +191,10 @@ func (s *Service) Start() {
 	// Used for fork-related data when connecting peers.
 	s.awaitStateInitialized()
 	s.isPreGenesis = false
+	if s.gossipTrace != nil {
+		s.gossipTrace.setGenesisTime(s.genesisTime)
+		s.gossipTrace.start(s.ctx)
+	}
 
 	var relayNodes []string
 	if s.cfg.RelayNodeAddr != "" {
@@ -282,6 +295,9 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
+	if err := s.gossipTrace.close(); err != nil {
+		log.WithError(err).Error("Could not close gossip trace")
+	}
 	return nil
 }
 
@@ -318,6 +334,11 @@ func (s *Service) PubSub() *pubsub.PubSub {
 	return s.pubsub
 }
 
+// TraceValidationFailure records why the message failed validation in the gossip trace, if enabled.
+func (s *Service) TraceValidationFailure(msg *pubsub.Message, err error) {
+	s.gossipTrace.recordValidationFailure(msg, err)
+}
+
 // Host returns the currently running libp2p
 // host of the service.
 func (s *Service) Host() host.Host {
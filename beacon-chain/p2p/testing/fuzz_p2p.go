@@ -98,6 +98,9 @@ func (_ *FakeP2P) PubSub() *pubsub.PubSub {
 	return nil
 }
 
+// TraceValidationFailure -- fake.
+func (_ *FakeP2P) TraceValidationFailure(_ *pubsub.Message, _ error) {}
+
 // MetadataSeq -- fake.
 func (_ *FakeP2P) MetadataSeq() uint64 {
 	return 0
@@ -242,6 +242,9 @@ func (p *TestP2P) PubSub() *pubsub.PubSub {
 	return p.pubsub
 }
 
+// TraceValidationFailure -- fake.
+func (_ *TestP2P) TraceValidationFailure(_ *pubsub.Message, _ error) {}
+
 // Disconnect from a peer.
 func (p *TestP2P) Disconnect(pid peer.ID) error {
 	return p.BHost.Network().ClosePeer(pid)
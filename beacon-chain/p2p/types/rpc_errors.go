@@ -9,10 +9,12 @@ var (
 	ErrInvalidSequenceNum     = errors.New("invalid sequence number provided")
 	ErrGeneric                = errors.New("internal service error")
 
-	ErrRateLimited         = errors.New("rate limited")
-	ErrIODeadline          = errors.New("i/o deadline exceeded")
-	ErrInvalidRequest      = errors.New("invalid range, step or count")
-	ErrBlobLTMinRequest    = errors.New("blob slot < minimum_request_epoch")
-	ErrMaxBlobReqExceeded  = errors.New("requested more than MAX_REQUEST_BLOB_SIDECARS")
-	ErrResourceUnavailable = errors.New("resource requested unavailable")
+	ErrRateLimited          = errors.New("rate limited")
+	ErrIODeadline           = errors.New("i/o deadline exceeded")
+	ErrInvalidRequest       = errors.New("invalid range, step or count")
+	ErrBlobLTMinRequest     = errors.New("blob slot < minimum_request_epoch")
+	ErrMaxBlobReqExceeded   = errors.New("requested more than MAX_REQUEST_BLOB_SIDECARS")
+	ErrColumnLTMinRequest   = errors.New("data column slot < minimum_request_epoch")
+	ErrMaxColumnReqExceeded = errors.New("requested more than MAX_REQUEST_DATA_COLUMN_SIDECARS")
+	ErrResourceUnavailable  = errors.New("resource requested unavailable")
 )
@@ -207,7 +207,115 @@ func (s BlobSidecarsByRootReq) Len() int {
 	return len(s)
 }
 
+// DataColumnSidecarsByRootReq is used to specify a list of data column targets (root+index) in a
+// DataColumnSidecarsByRoot RPC request.
+type DataColumnSidecarsByRootReq []*eth.DataColumnIdentifier
+
+// DataColumnIdentifier is a fixed size value, so we can compute its fixed size at start time (see init below)
+var dataColumnIdSize int
+
+// SizeSSZ returns the size of the serialized representation.
+func (d *DataColumnSidecarsByRootReq) SizeSSZ() int {
+	return len(*d) * dataColumnIdSize
+}
+
+// MarshalSSZTo appends the serialized DataColumnSidecarsByRootReq value to the provided byte slice.
+func (d *DataColumnSidecarsByRootReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	marshalledObj, err := d.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, marshalledObj...), nil
+}
+
+// MarshalSSZ serializes the DataColumnSidecarsByRootReq value to a byte slice.
+func (d *DataColumnSidecarsByRootReq) MarshalSSZ() ([]byte, error) {
+	buf := make([]byte, len(*d)*dataColumnIdSize)
+	for i, id := range *d {
+		by, err := id.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		copy(buf[i*dataColumnIdSize:(i+1)*dataColumnIdSize], by)
+	}
+	return buf, nil
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the
+// DataColumnSidecarsByRootReq value.
+func (d *DataColumnSidecarsByRootReq) UnmarshalSSZ(buf []byte) error {
+	bufLen := len(buf)
+	maxLength := int(params.BeaconConfig().MaxRequestDataColumnSidecars) * dataColumnIdSize
+	if bufLen > maxLength {
+		return errors.Errorf("expected buffer with length of up to %d but received length %d", maxLength, bufLen)
+	}
+	if bufLen%dataColumnIdSize != 0 {
+		return errors.Wrapf(ssz.ErrIncorrectByteSize, "size=%d", bufLen)
+	}
+	count := bufLen / dataColumnIdSize
+	*d = make([]*eth.DataColumnIdentifier, count)
+	for i := 0; i < count; i++ {
+		id := &eth.DataColumnIdentifier{}
+		if err := id.UnmarshalSSZ(buf[i*dataColumnIdSize : (i+1)*dataColumnIdSize]); err != nil {
+			return err
+		}
+		(*d)[i] = id
+	}
+	return nil
+}
+
+var _ sort.Interface = DataColumnSidecarsByRootReq{}
+
+// Less reports whether the element with index i must sort before the element with index j.
+// DataColumnIdentifier will be sorted in lexicographic order by root, with column index as tiebreaker for a given root.
+func (d DataColumnSidecarsByRootReq) Less(i, j int) bool {
+	rootCmp := bytes.Compare(d[i].BlockRoot, d[j].BlockRoot)
+	if rootCmp != 0 {
+		return rootCmp < 0
+	}
+	return d[i].Index < d[j].Index
+}
+
+// Swap swaps the elements with indexes i and j.
+func (d DataColumnSidecarsByRootReq) Swap(i, j int) {
+	d[i], d[j] = d[j], d[i]
+}
+
+// Len is the number of elements in the collection.
+func (d DataColumnSidecarsByRootReq) Len() int {
+	return len(d)
+}
+
+// LightClientBootstrapReq is the block root of a LightClientBootstrap RPC request.
+type LightClientBootstrapReq [rootLength]byte
+
+// MarshalSSZTo marshals the light client bootstrap request with the provided byte slice.
+func (r *LightClientBootstrapReq) MarshalSSZTo(dst []byte) ([]byte, error) {
+	return append(dst, r[:]...), nil
+}
+
+// MarshalSSZ marshals the light client bootstrap request into the serialized object.
+func (r *LightClientBootstrapReq) MarshalSSZ() ([]byte, error) {
+	return r.MarshalSSZTo(make([]byte, 0, rootLength))
+}
+
+// SizeSSZ returns the size of the serialized representation.
+func (*LightClientBootstrapReq) SizeSSZ() int {
+	return rootLength
+}
+
+// UnmarshalSSZ unmarshals the provided bytes buffer into the
+// light client bootstrap request object.
+func (r *LightClientBootstrapReq) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != rootLength {
+		return errors.Wrapf(ssz.ErrIncorrectByteSize, "size=%d", len(buf))
+	}
+	copy(r[:], buf)
+	return nil
+}
+
 func init() {
 	sizer := &eth.BlobIdentifier{}
 	blobIdSize = sizer.SizeSSZ()
+	dataColumnIdSize = (&eth.DataColumnIdentifier{}).SizeSSZ()
 }
@@ -80,6 +80,31 @@ func TestBlobSidecarsByRootReq_MarshalSSZ(t *testing.T) {
 	}
 }
 
+func TestDataColumnSidecarsByRootReq_MarshalSSZ(t *testing.T) {
+	ids := make([]*eth.DataColumnIdentifier, 10)
+	for i := range ids {
+		ids[i] = &eth.DataColumnIdentifier{
+			BlockRoot: bytesutil.PadTo([]byte{byte(i)}, 32),
+			Index:     uint64(i * 3),
+		}
+	}
+	r := DataColumnSidecarsByRootReq(ids)
+	by, err := r.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, r.SizeSSZ(), len(by))
+
+	got := &DataColumnSidecarsByRootReq{}
+	require.NoError(t, got.UnmarshalSSZ(by))
+	require.Equal(t, len(ids), len(*got))
+	for i, gid := range *got {
+		require.DeepEqual(t, ids[i], gid)
+	}
+
+	require.ErrorIs(t, got.UnmarshalSSZ(append(by, 0)), ssz.ErrIncorrectByteSize)
+	tooMany := make([]byte, (params.BeaconConfig().MaxRequestDataColumnSidecars+1)*uint64(dataColumnIdSize))
+	require.ErrorContains(t, "expected buffer with length of up to", got.UnmarshalSSZ(tooMany))
+}
+
 func TestBeaconBlockByRootsReq_Limit(t *testing.T) {
 	fixedRoots := make([][32]byte, 0)
 	for i := uint64(0); i < params.BeaconConfig().MaxRequestBlocks+100; i++ {
@@ -112,6 +137,7 @@ func TestErrorResponse_Limit(t *testing.T) {
 func TestRoundTripSerialization(t *testing.T) {
 	roundTripTestBlocksByRootReq(t)
 	roundTripTestErrorMessage(t)
+	roundTripTestLightClientBootstrapReq(t)
 }
 
 func roundTripTestBlocksByRootReq(t *testing.T) {
@@ -142,6 +168,18 @@ func roundTripTestErrorMessage(t *testing.T) {
 	assert.DeepEqual(t, []byte(newVal), errMsg)
 }
 
+func roundTripTestLightClientBootstrapReq(t *testing.T) {
+	req := LightClientBootstrapReq{'r', 'o', 'o', 't'}
+	marshalledObj, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, rootLength, len(marshalledObj))
+
+	newVal := LightClientBootstrapReq{}
+	require.NoError(t, newVal.UnmarshalSSZ(marshalledObj))
+	assert.Equal(t, req, newVal)
+	require.ErrorIs(t, newVal.UnmarshalSSZ(marshalledObj[1:]), ssz.ErrIncorrectByteSize)
+}
+
 func TestSSZBytes_HashTreeRoot(t *testing.T) {
 	tests := []struct {
 		name        string
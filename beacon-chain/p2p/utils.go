@@ -12,10 +12,15 @@ import (
 	"path"
 	"time"
 
+	gcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/wrapper"
 	ecdsaprysm "github.com/prysmaticlabs/prysm/v5/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
@@ -43,6 +48,38 @@ func SerializeENR(record *enr.Record) (string, error) {
 	return enrString, nil
 }
 
+// ConvertPeerIDToNodeID returns the discovery node ID of the peer with the given libp2p peer ID. Both are derived
+// from the same secp256k1 public key.
+func ConvertPeerIDToNodeID(pid peer.ID) (enode.ID, error) {
+	pubKey, err := pid.ExtractPublicKey()
+	if err != nil {
+		return enode.ID{}, errors.Wrap(err, "could not extract public key from peer ID")
+	}
+	secpKey, ok := pubKey.(*crypto.Secp256k1PublicKey)
+	if !ok {
+		return enode.ID{}, errors.New("peer ID public key is not a secp256k1 key")
+	}
+	raw, err := secpKey.Raw()
+	if err != nil {
+		return enode.ID{}, err
+	}
+	ecdsaKey, err := gcrypto.DecompressPubkey(raw)
+	if err != nil {
+		return enode.ID{}, errors.Wrap(err, "could not decompress public key")
+	}
+	return enode.PubkeyToIDV4(ecdsaKey), nil
+}
+
+// CustodyColumns returns the data columns custodied by the node with the given peer ID, assuming that the node
+// custodies the minimum CUSTODY_REQUIREMENT custody groups, which is what this node advertises.
+func CustodyColumns(pid peer.ID) (map[uint64]bool, error) {
+	nodeID, err := ConvertPeerIDToNodeID(pid)
+	if err != nil {
+		return nil, err
+	}
+	return peerdas.CustodyColumns(nodeID, params.BeaconConfig().CustodyRequirement)
+}
+
 // Determines a private key for p2p networking from the p2p service's
 // configuration struct. If no key is found, it generates a new one.
 func privKey(cfg *Config) (*ecdsa.PrivateKey, error) {
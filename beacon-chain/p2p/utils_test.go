@@ -6,7 +6,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/v5/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -64,3 +66,16 @@ func TestSerializeENR(t *testing.T) {
 		assert.ErrorContains(t, "could not serialize nil record", err)
 	})
 }
+
+func TestConvertPeerIDToNodeID(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ifaceKey, err := ecdsaprysm.ConvertToInterfacePubkey(&key.PublicKey)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPublicKey(ifaceKey)
+	require.NoError(t, err)
+
+	nodeID, err := ConvertPeerIDToNodeID(pid)
+	require.NoError(t, err)
+	assert.Equal(t, enode.PubkeyToIDV4(&key.PublicKey), nodeID)
+}
@@ -72,7 +72,7 @@ func (s *Service) endpoints(
 	endpoints = append(endpoints, s.prysmNodeEndpoints()...)
 	endpoints = append(endpoints, s.prysmValidatorEndpoints(coreService)...)
 	if enableDebug {
-		endpoints = append(endpoints, s.debugEndpoints(stater)...)
+		endpoints = append(endpoints, s.debugEndpoints(stater, validatorServer)...)
 	}
 	return endpoints
 }
@@ -127,6 +127,7 @@ func (s *Service) builderEndpoints(stater lookup.Stater) []endpoint {
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		Stater:                stater,
+		BeaconDB:              s.cfg.BeaconDB,
 	}
 
 	const namespace = "builder"
@@ -140,6 +141,15 @@ func (s *Service) builderEndpoints(stater lookup.Stater) []endpoint {
 			handler: server.ExpectedWithdrawals,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/builder/bids",
+			name:     namespace + ".GetBuilderBids",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetBuilderBids,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -417,6 +427,52 @@ func (s *Service) nodeEndpoints() []endpoint {
 			handler: server.GetPeers,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/node/peers/scoring_policy",
+			name:     namespace + ".GetPeerScoringPolicy",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPeerScoringPolicy,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/peers/scoring_policy",
+			name:     namespace + ".SetPeerScoringPolicy",
+			middleware: []mux.MiddlewareFunc{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.SetPeerScoringPolicy,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/peers/{peer_id}/score",
+			name:     namespace + ".GetPeerScore",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetPeerScore,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/node/peers/{peer_id}/ban",
+			name:     namespace + ".BanPeer",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.BanPeer,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/node/peers/{peer_id}/ban",
+			name:     namespace + ".UnbanPeer",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UnbanPeer,
+			methods: []string{http.MethodDelete},
+		},
 		{
 			template: "/eth/v1/node/peer_count",
 			name:     namespace + ".GetPeerCount",
@@ -812,9 +868,11 @@ func (*Service) configEndpoints() []endpoint {
 
 func (s *Service) lightClientEndpoints(blocker lookup.Blocker, stater lookup.Stater) []endpoint {
 	server := &lightclient.Server{
-		Blocker:     blocker,
-		Stater:      stater,
-		HeadFetcher: s.cfg.HeadFetcher,
+		Blocker:            blocker,
+		Stater:             stater,
+		HeadFetcher:        s.cfg.HeadFetcher,
+		BeaconDB:           s.cfg.BeaconDB,
+		LightClientFetcher: s.cfg.LightClientFetcher,
 	}
 
 	const namespace = "lightclient"
@@ -858,7 +916,7 @@ func (s *Service) lightClientEndpoints(blocker lookup.Blocker, stater lookup.Sta
 	}
 }
 
-func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
+func (s *Service) debugEndpoints(stater lookup.Stater, validatorServer *validatorv1alpha1.Server) []endpoint {
 	server := &debug.Server{
 		BeaconDB:              s.cfg.BeaconDB,
 		HeadFetcher:           s.cfg.HeadFetcher,
@@ -868,6 +926,9 @@ func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
 		ForkchoiceFetcher:     s.cfg.ForkchoiceFetcher,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
 		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
+		AttestationsPool:      s.cfg.AttestationsPool,
+		AttestationPacker:     validatorServer,
 	}
 
 	const namespace = "debug"
@@ -899,6 +960,33 @@ func (s *Service) debugEndpoints(stater lookup.Stater) []endpoint {
 			handler: server.GetForkChoice,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/debug/reorgs",
+			name:     namespace + ".GetReorgs",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetReorgs,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/debug/attestation_pool",
+			name:     namespace + ".GetAttestationPool",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetAttestationPool,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/debug/attestation_pool/packing",
+			name:     namespace + ".GetAttestationPacking",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetAttestationPacking,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
 
@@ -1060,9 +1148,12 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 	}
 }
 
-func (*Service) prysmValidatorEndpoints(coreService *core.Service) []endpoint {
+func (s *Service) prysmValidatorEndpoints(coreService *core.Service) []endpoint {
 	server := &validatorprysm.Server{
-		CoreService: coreService,
+		CoreService:        coreService,
+		BeaconDB:           s.cfg.BeaconDB,
+		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
+		ValidatorMonitor:   s.cfg.ValidatorMonitor,
 	}
 
 	const namespace = "prysm.validator"
@@ -1087,5 +1178,42 @@ func (*Service) prysmValidatorEndpoints(coreService *core.Service) []endpoint {
 			handler: server.GetValidatorPerformance,
 			methods: []string{http.MethodPost},
 		},
+		{
+			template: "/prysm/v1/validators/performance/history",
+			name:     namespace + ".GetValidatorPerformanceHistory",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetValidatorPerformanceHistory,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/validators/performance/tracked",
+			name:     namespace + ".GetTrackedValidators",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetTrackedValidators,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/validators/performance/tracked",
+			name:     namespace + ".AddTrackedValidators",
+			middleware: []mux.MiddlewareFunc{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.AddTrackedValidators,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/validators/performance/tracked/{validator_index}",
+			name:     namespace + ".RemoveTrackedValidator",
+			middleware: []mux.MiddlewareFunc{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.RemoveTrackedValidator,
+			methods: []string{http.MethodDelete},
+		},
 	}
 }
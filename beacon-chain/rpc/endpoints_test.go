@@ -56,6 +56,7 @@ func Test_endpoints(t *testing.T) {
 
 	builderRoutes := map[string][]string{
 		"/eth/v1/builder/states/{state_id}/expected_withdrawals": {http.MethodGet},
+		"/prysm/v1/builder/bids":                                 {http.MethodGet},
 	}
 
 	blobRoutes := map[string][]string{
@@ -69,10 +70,13 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	debugRoutes := map[string][]string{
-		"/eth/v1/debug/beacon/states/{state_id}": {http.MethodGet},
-		"/eth/v2/debug/beacon/states/{state_id}": {http.MethodGet},
-		"/eth/v2/debug/beacon/heads":             {http.MethodGet},
-		"/eth/v1/debug/fork_choice":              {http.MethodGet},
+		"/eth/v1/debug/beacon/states/{state_id}":   {http.MethodGet},
+		"/eth/v2/debug/beacon/states/{state_id}":   {http.MethodGet},
+		"/eth/v2/debug/beacon/heads":               {http.MethodGet},
+		"/eth/v1/debug/fork_choice":                {http.MethodGet},
+		"/prysm/v1/debug/reorgs":                   {http.MethodGet},
+		"/prysm/v1/debug/attestation_pool":         {http.MethodGet},
+		"/prysm/v1/debug/attestation_pool/packing": {http.MethodGet},
 	}
 
 	eventsRoutes := map[string][]string{
@@ -80,13 +84,16 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	nodeRoutes := map[string][]string{
-		"/eth/v1/node/identity":        {http.MethodGet},
-		"/eth/v1/node/peers":           {http.MethodGet},
-		"/eth/v1/node/peers/{peer_id}": {http.MethodGet},
-		"/eth/v1/node/peer_count":      {http.MethodGet},
-		"/eth/v1/node/version":         {http.MethodGet},
-		"/eth/v1/node/syncing":         {http.MethodGet},
-		"/eth/v1/node/health":          {http.MethodGet},
+		"/eth/v1/node/identity":                {http.MethodGet},
+		"/eth/v1/node/peers":                   {http.MethodGet},
+		"/eth/v1/node/peers/{peer_id}":         {http.MethodGet},
+		"/prysm/v1/node/peers/scoring_policy":  {http.MethodGet, http.MethodPost},
+		"/prysm/v1/node/peers/{peer_id}/score": {http.MethodGet},
+		"/prysm/v1/node/peers/{peer_id}/ban":   {http.MethodPost, http.MethodDelete},
+		"/eth/v1/node/peer_count":              {http.MethodGet},
+		"/eth/v1/node/version":                 {http.MethodGet},
+		"/eth/v1/node/syncing":                 {http.MethodGet},
+		"/eth/v1/node/health":                  {http.MethodGet},
 	}
 
 	validatorRoutes := map[string][]string{
@@ -124,8 +131,11 @@ func Test_endpoints(t *testing.T) {
 	}
 
 	prysmValidatorRoutes := map[string][]string{
-		"/prysm/validators/performance":    {http.MethodPost},
-		"/prysm/v1/validators/performance": {http.MethodPost},
+		"/prysm/validators/performance":                              {http.MethodPost},
+		"/prysm/v1/validators/performance":                           {http.MethodPost},
+		"/prysm/v1/validators/performance/history":                   {http.MethodGet},
+		"/prysm/v1/validators/performance/tracked":                   {http.MethodGet, http.MethodPost},
+		"/prysm/v1/validators/performance/tracked/{validator_index}": {http.MethodDelete},
 	}
 
 	s := &Service{cfg: &Config{}}
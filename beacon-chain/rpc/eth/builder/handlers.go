@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -9,13 +10,17 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"go.opencensus.io/trace"
 )
 
 // ExpectedWithdrawals get the withdrawals computed from the specified state, that will be included in the block that gets built on the specified state.
@@ -111,6 +116,152 @@ func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetBuilderBids returns the audit records of the builder bids requested for the proposals of this node, from the
+// oldest to the most recent, along with a per relay summary of the bids and how they compared to the local payloads.
+// The records can be narrowed down to a range of slots, a relay, an outcome and a maximum number of entries.
+// The summary covers every record of the requested slots and relay, regardless of the outcome and limit.
+func (s *Server) GetBuilderBids(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "builder.GetBuilderBids")
+	defer span.End()
+
+	_, startSlot, ok := shared.UintFromQuery(w, r, "start_slot", false)
+	if !ok {
+		return
+	}
+	rawEndSlot, endSlot, ok := shared.UintFromQuery(w, r, "end_slot", false)
+	if !ok {
+		return
+	}
+	if rawEndSlot == "" {
+		endSlot = uint64(params.BeaconConfig().FarFutureSlot)
+	}
+	if startSlot > endSlot {
+		httputil.HandleError(w, "start_slot must not be greater than end_slot", http.StatusBadRequest)
+		return
+	}
+	_, limit, ok := shared.UintFromQuery(w, r, "limit", false)
+	if !ok {
+		return
+	}
+	var relay []byte
+	rawRelay := r.URL.Query().Get("relay")
+	if rawRelay != "" {
+		var valid bool
+		relay, valid = shared.ValidateHex(w, "relay", rawRelay, fieldparams.BLSPubkeyLength)
+		if !valid {
+			return
+		}
+	}
+	outcome := r.URL.Query().Get("outcome")
+	switch outcome {
+	case "",
+		buildertypes.BidNotRequested.String(),
+		buildertypes.BidFailed.String(),
+		buildertypes.BidLowerValue.String(),
+		buildertypes.BidRejected.String(),
+		buildertypes.BidAccepted.String():
+	default:
+		httputil.HandleError(w, "Invalid outcome: "+outcome, http.StatusBadRequest)
+		return
+	}
+
+	bids, err := s.BeaconDB.BuilderBids(ctx, primitives.Slot(startSlot), primitives.Slot(endSlot))
+	if err != nil {
+		httputil.HandleError(w, "Could not get builder bids: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.BuilderBid, 0, len(bids))
+	summaries := newRelaySummaries()
+	for _, b := range bids {
+		if relay != nil && !bytes.Equal(relay, b.RelayPubkey[:]) {
+			continue
+		}
+		summaries.add(b)
+		if outcome != "" && b.Outcome.String() != outcome {
+			continue
+		}
+		data = append(data, &structs.BuilderBid{
+			Slot:               fmt.Sprintf("%d", b.Slot),
+			ProposerIndex:      fmt.Sprintf("%d", b.ProposerIndex),
+			Timestamp:          fmt.Sprintf("%d", b.Timestamp),
+			Outcome:            b.Outcome.String(),
+			RelayPubkey:        hexutil.Encode(b.RelayPubkey[:]),
+			BlockHash:          hexutil.Encode(b.BlockHash[:]),
+			BuilderValue:       fmt.Sprintf("%d", b.BuilderValue),
+			LocalValue:         fmt.Sprintf("%d", b.LocalValue),
+			LocalBoost:         fmt.Sprintf("%d", b.LocalBoost),
+			BuilderBoostFactor: fmt.Sprintf("%d", b.BuilderBoostFactor),
+			Latency:            fmt.Sprintf("%d", b.Latency),
+			SinceSlotStart:     fmt.Sprintf("%d", b.SinceSlotStart),
+			Error:              b.Error,
+		})
+	}
+	// Keep the most recent entries when the response is limited.
+	if limit > 0 && uint64(len(data)) > limit {
+		data = data[uint64(len(data))-limit:]
+	}
+	httputil.WriteJson(w, &structs.GetBuilderBidsResponse{Data: data, Relays: summaries.summaries()})
+}
+
+type relayTotals struct {
+	bids, accepted, lowerValue, rejected uint64
+	latency, builderValue, localValue    uint64
+}
+
+// relaySummaries aggregates the bids received from each relay, in the order the relays are first seen.
+// Records without a relay pubkey, where no bid was received, are left out.
+type relaySummaries struct {
+	order  [][fieldparams.BLSPubkeyLength]byte
+	totals map[[fieldparams.BLSPubkeyLength]byte]*relayTotals
+}
+
+func newRelaySummaries() *relaySummaries {
+	return &relaySummaries{totals: make(map[[fieldparams.BLSPubkeyLength]byte]*relayTotals)}
+}
+
+func (s *relaySummaries) add(b *buildertypes.BidRecord) {
+	if b.RelayPubkey == [fieldparams.BLSPubkeyLength]byte{} {
+		return
+	}
+	t, ok := s.totals[b.RelayPubkey]
+	if !ok {
+		t = &relayTotals{}
+		s.totals[b.RelayPubkey] = t
+		s.order = append(s.order, b.RelayPubkey)
+	}
+	t.bids++
+	switch b.Outcome {
+	case buildertypes.BidAccepted:
+		t.accepted++
+	case buildertypes.BidLowerValue:
+		t.lowerValue++
+	case buildertypes.BidRejected:
+		t.rejected++
+	default:
+	}
+	t.latency += b.Latency
+	t.builderValue += uint64(b.BuilderValue)
+	t.localValue += uint64(b.LocalValue)
+}
+
+func (s *relaySummaries) summaries() []*structs.RelayBidSummary {
+	res := make([]*structs.RelayBidSummary, 0, len(s.order))
+	for _, pubkey := range s.order {
+		t := s.totals[pubkey]
+		res = append(res, &structs.RelayBidSummary{
+			RelayPubkey:         hexutil.Encode(pubkey[:]),
+			Bids:                fmt.Sprintf("%d", t.bids),
+			Accepted:            fmt.Sprintf("%d", t.accepted),
+			LowerValue:          fmt.Sprintf("%d", t.lowerValue),
+			Rejected:            fmt.Sprintf("%d", t.rejected),
+			AverageLatency:      fmt.Sprintf("%d", t.latency/t.bids),
+			AverageBuilderValue: fmt.Sprintf("%d", t.builderValue/t.bids),
+			AverageLocalValue:   fmt.Sprintf("%d", t.localValue/t.bids),
+		})
+	}
+	return res
+}
+
 func buildExpectedWithdrawalsData(withdrawals []*enginev1.Withdrawal) []*structs.ExpectedWithdrawal {
 	data := make([]*structs.ExpectedWithdrawal, len(withdrawals))
 	for i, withdrawal := range withdrawals {
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -12,8 +13,11 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
@@ -209,3 +213,78 @@ func TestExpectedWithdrawals(t *testing.T) {
 		require.DeepEqual(t, expectedWithdrawal3, resp.Data[2])
 	})
 }
+
+func TestGetBuilderBids(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	relayA := [fieldparams.BLSPubkeyLength]byte{'a'}
+	relayB := [fieldparams.BLSPubkeyLength]byte{'b'}
+	for _, b := range []*buildertypes.BidRecord{
+		{Slot: 10, Outcome: buildertypes.BidAccepted, RelayPubkey: relayA, BuilderValue: 30, LocalValue: 10, Latency: 100},
+		{Slot: 11, Outcome: buildertypes.BidFailed, Error: "timeout"},
+		{Slot: 12, Outcome: buildertypes.BidLowerValue, RelayPubkey: relayA, BuilderValue: 10, LocalValue: 20, Latency: 300},
+		{Slot: 20, Outcome: buildertypes.BidRejected, RelayPubkey: relayB, BuilderValue: 50, LocalValue: 10, Latency: 50},
+	} {
+		require.NoError(t, db.SaveBuilderBid(ctx, b))
+	}
+	s := &Server{BeaconDB: db}
+
+	get := func(t *testing.T, query string) *structs.GetBuilderBidsResponse {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/builder/bids"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBuilderBids(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBuilderBidsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	t.Run("all", func(t *testing.T) {
+		resp := get(t, "")
+		require.Equal(t, 4, len(resp.Data))
+		assert.Equal(t, "10", resp.Data[0].Slot)
+		assert.Equal(t, "accepted", resp.Data[0].Outcome)
+		assert.Equal(t, hexutil.Encode(relayA[:]), resp.Data[0].RelayPubkey)
+		assert.Equal(t, "failed", resp.Data[1].Outcome)
+		assert.Equal(t, "timeout", resp.Data[1].Error)
+
+		require.Equal(t, 2, len(resp.Relays))
+		a := resp.Relays[0]
+		assert.Equal(t, hexutil.Encode(relayA[:]), a.RelayPubkey)
+		assert.Equal(t, "2", a.Bids)
+		assert.Equal(t, "1", a.Accepted)
+		assert.Equal(t, "1", a.LowerValue)
+		assert.Equal(t, "0", a.Rejected)
+		assert.Equal(t, "200", a.AverageLatency)
+		assert.Equal(t, "20", a.AverageBuilderValue)
+		assert.Equal(t, "15", a.AverageLocalValue)
+		assert.Equal(t, "1", resp.Relays[1].Rejected)
+	})
+	t.Run("filters", func(t *testing.T) {
+		resp := get(t, "?relay="+hexutil.Encode(relayA[:])+"&outcome=lower_value")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "12", resp.Data[0].Slot)
+		// The summary is not narrowed down by the outcome.
+		require.Equal(t, 1, len(resp.Relays))
+		assert.Equal(t, "2", resp.Relays[0].Bids)
+
+		resp = get(t, "?start_slot=11&end_slot=19")
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "11", resp.Data[0].Slot)
+	})
+	t.Run("limit keeps the most recent", func(t *testing.T) {
+		resp := get(t, "?limit=1")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "20", resp.Data[0].Slot)
+	})
+	t.Run("bad request", func(t *testing.T) {
+		for _, query := range []string{"?outcome=foo", "?relay=0x01", "?start_slot=5&end_slot=4"} {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/builder/bids"+query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.GetBuilderBids(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, query)
+		}
+	})
+}
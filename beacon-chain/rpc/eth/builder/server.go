@@ -2,6 +2,7 @@ package builder
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 )
 
@@ -9,4 +10,5 @@ type Server struct {
 	FinalizationFetcher   blockchain.FinalizationFetcher
 	OptimisticModeFetcher blockchain.OptimisticModeFetcher
 	Stater                lookup.Stater
+	BeaconDB              db.ReadOnlyDatabase
 }
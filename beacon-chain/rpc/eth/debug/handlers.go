@@ -10,8 +10,11 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"go.opencensus.io/trace"
@@ -196,3 +199,91 @@ func (s *Server) GetForkChoice(w http.ResponseWriter, r *http.Request) {
 	}
 	httputil.WriteJson(w, resp)
 }
+
+// GetReorgs returns the head changes recorded by the node, from the oldest to the most recent. Only reorgs are
+// returned unless the reason query parameter is set to `new_block` or `all`. The history can be further narrowed down
+// to a range of wall clock slots, a minimum reorg depth and a maximum number of entries.
+func (s *Server) GetReorgs(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "debug.GetReorgs")
+	defer span.End()
+
+	_, startSlot, ok := shared.UintFromQuery(w, r, "start_slot", false)
+	if !ok {
+		return
+	}
+	rawEndSlot, endSlot, ok := shared.UintFromQuery(w, r, "end_slot", false)
+	if !ok {
+		return
+	}
+	if rawEndSlot == "" {
+		endSlot = uint64(params.BeaconConfig().FarFutureSlot)
+	}
+	if startSlot > endSlot {
+		httputil.HandleError(w, "start_slot must not be greater than end_slot", http.StatusBadRequest)
+		return
+	}
+	_, minDepth, ok := shared.UintFromQuery(w, r, "min_depth", false)
+	if !ok {
+		return
+	}
+	_, limit, ok := shared.UintFromQuery(w, r, "limit", false)
+	if !ok {
+		return
+	}
+	reason := r.URL.Query().Get("reason")
+	switch reason {
+	case "", "all",
+		forkchoicetypes.HeadChangeNewBlock.String(),
+		forkchoicetypes.HeadChangeReorg.String(),
+		forkchoicetypes.HeadChangeLateBlockReorg.String():
+	default:
+		httputil.HandleError(w, "Invalid reason: "+reason, http.StatusBadRequest)
+		return
+	}
+
+	changes, err := s.BeaconDB.HeadChanges(ctx, primitives.Slot(startSlot), primitives.Slot(endSlot))
+	if err != nil {
+		httputil.HandleError(w, "Could not get head changes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.HeadChange, 0, len(changes))
+	for _, c := range changes {
+		switch reason {
+		case "":
+			if !c.Reason.IsReorg() {
+				continue
+			}
+		case "all":
+		default:
+			if c.Reason.String() != reason {
+				continue
+			}
+		}
+		if c.Depth < minDepth {
+			continue
+		}
+		data = append(data, &structs.HeadChange{
+			Slot:               fmt.Sprintf("%d", c.Slot),
+			Timestamp:          fmt.Sprintf("%d", c.Timestamp),
+			Reason:             c.Reason.String(),
+			OldHeadRoot:        hexutil.Encode(c.OldHeadRoot[:]),
+			OldHeadSlot:        fmt.Sprintf("%d", c.OldHeadSlot),
+			OldHeadWeight:      fmt.Sprintf("%d", c.OldHeadWeight),
+			NewHeadRoot:        hexutil.Encode(c.NewHeadRoot[:]),
+			NewHeadSlot:        fmt.Sprintf("%d", c.NewHeadSlot),
+			NewHeadWeight:      fmt.Sprintf("%d", c.NewHeadWeight),
+			CommonAncestorRoot: hexutil.Encode(c.CommonAncestorRoot[:]),
+			CommonAncestorSlot: fmt.Sprintf("%d", c.CommonAncestorSlot),
+			Depth:              fmt.Sprintf("%d", c.Depth),
+			Distance:           fmt.Sprintf("%d", c.Distance),
+			ProposerBoostRoot:  hexutil.Encode(c.ProposerBoostRoot[:]),
+			JustifiedEpoch:     fmt.Sprintf("%d", c.JustifiedEpoch),
+			FinalizedEpoch:     fmt.Sprintf("%d", c.FinalizedEpoch),
+		})
+	}
+	// Keep the most recent entries when the response is limited.
+	if limit > 0 && uint64(len(data)) > limit {
+		data = data[uint64(len(data))-limit:]
+	}
+	httputil.WriteJson(w, &structs.GetReorgsResponse{Data: data})
+}
@@ -0,0 +1,329 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	corehelpers "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	coretime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// The caches of the attestation pool, in the order they are reported in.
+const (
+	aggregatedCache = iota
+	unaggregatedCache
+	blockCache
+	forkchoiceCache
+	poolCacheCount
+)
+
+type poolCounts [poolCacheCount]uint64
+
+type poolCommitteeStats struct {
+	counts  poolCounts
+	size    uint64
+	covered map[int]bool
+}
+
+type poolSlotStats struct {
+	counts     poolCounts
+	committees map[primitives.CommitteeIndex]*poolCommitteeStats
+}
+
+// GetAttestationPool returns the number of attestations held in each cache of the attestation pool, broken down by
+// slot and committee. For every committee, it also reports how many distinct validators are covered by the aggregated
+// and unaggregated attestations, which are the ones available to proposers. The slots can be narrowed down to a single
+// one, in which case the totals still cover the whole pool.
+func (s *Server) GetAttestationPool(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "debug.GetAttestationPool")
+	defer span.End()
+
+	rawSlot, slot, ok := shared.UintFromQuery(w, r, "slot", false)
+	if !ok {
+		return
+	}
+	unaggregated, err := s.AttestationsPool.UnaggregatedAttestations()
+	if err != nil {
+		httputil.HandleError(w, "Could not get unaggregated attestations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	caches := [poolCacheCount][]ethpb.Att{
+		aggregatedCache:   s.AttestationsPool.AggregatedAttestations(),
+		unaggregatedCache: unaggregated,
+		blockCache:        s.AttestationsPool.BlockAttestations(),
+		forkchoiceCache:   s.AttestationsPool.ForkchoiceAttestations(),
+	}
+
+	var total poolCounts
+	bySlot := make(map[primitives.Slot]*poolSlotStats)
+	for cache, atts := range caches {
+		total[cache] = uint64(len(atts))
+		for _, att := range atts {
+			if rawSlot != "" && att.GetData().Slot != primitives.Slot(slot) {
+				continue
+			}
+			ss, ok := bySlot[att.GetData().Slot]
+			if !ok {
+				ss = &poolSlotStats{committees: make(map[primitives.CommitteeIndex]*poolCommitteeStats)}
+				bySlot[att.GetData().Slot] = ss
+			}
+			ss.counts[cache]++
+			indices := committeeIndices(att)
+			for _, idx := range indices {
+				cs, ok := ss.committees[idx]
+				if !ok {
+					cs = &poolCommitteeStats{covered: make(map[int]bool)}
+					ss.committees[idx] = cs
+				}
+				cs.counts[cache]++
+				// The aggregation bits of an attestation spanning several committees can't be attributed to a
+				// single one of them.
+				if len(indices) != 1 {
+					continue
+				}
+				cs.size = att.GetAggregationBits().Len()
+				if cache != aggregatedCache && cache != unaggregatedCache {
+					continue
+				}
+				for _, i := range att.GetAggregationBits().BitIndices() {
+					cs.covered[i] = true
+				}
+			}
+		}
+	}
+
+	data := &structs.AttestationPool{
+		Aggregated:   fmt.Sprintf("%d", total[aggregatedCache]),
+		Unaggregated: fmt.Sprintf("%d", total[unaggregatedCache]),
+		Block:        fmt.Sprintf("%d", total[blockCache]),
+		Forkchoice:   fmt.Sprintf("%d", total[forkchoiceCache]),
+		Slots:        make([]*structs.AttestationPoolSlot, 0, len(bySlot)),
+	}
+	for _, sl := range sortedKeys(bySlot) {
+		ss := bySlot[sl]
+		slotData := &structs.AttestationPoolSlot{
+			Slot:         fmt.Sprintf("%d", sl),
+			Aggregated:   fmt.Sprintf("%d", ss.counts[aggregatedCache]),
+			Unaggregated: fmt.Sprintf("%d", ss.counts[unaggregatedCache]),
+			Block:        fmt.Sprintf("%d", ss.counts[blockCache]),
+			Forkchoice:   fmt.Sprintf("%d", ss.counts[forkchoiceCache]),
+			Committees:   make([]*structs.AttestationPoolCommittee, 0, len(ss.committees)),
+		}
+		for _, idx := range sortedKeys(ss.committees) {
+			cs := ss.committees[idx]
+			slotData.Committees = append(slotData.Committees, &structs.AttestationPoolCommittee{
+				Index:             fmt.Sprintf("%d", idx),
+				Aggregated:        fmt.Sprintf("%d", cs.counts[aggregatedCache]),
+				Unaggregated:      fmt.Sprintf("%d", cs.counts[unaggregatedCache]),
+				Block:             fmt.Sprintf("%d", cs.counts[blockCache]),
+				Forkchoice:        fmt.Sprintf("%d", cs.counts[forkchoiceCache]),
+				CommitteeSize:     fmt.Sprintf("%d", cs.size),
+				CoveredValidators: fmt.Sprintf("%d", len(cs.covered)),
+			})
+		}
+		data.Slots = append(data.Slots, slotData)
+	}
+	httputil.WriteJson(w, &structs.GetAttestationPoolResponse{Data: data})
+}
+
+// GetAttestationPacking runs the proposer's attestation selection for a block at the requested slot built on top of
+// the head, without altering the attestation pool. Every selected attestation is reported with the proposer reward it
+// earns on top of the attestations selected before it. The validators of the committees which can still be included
+// in the block, and whose votes are neither on chain nor in the selected attestations, are reported as uncovered.
+// The slot defaults to the current slot, or to the slot after the head when the head is at the current slot.
+func (s *Server) GetAttestationPacking(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "debug.GetAttestationPacking")
+	defer span.End()
+
+	rawSlot, slot, ok := shared.UintFromQuery(w, r, "slot", false)
+	if !ok {
+		return
+	}
+	st, err := s.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get head state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blkSlot := primitives.Slot(slot)
+	if rawSlot == "" {
+		blkSlot = s.TimeFetcher.CurrentSlot()
+		if blkSlot <= st.Slot() {
+			blkSlot = st.Slot() + 1
+		}
+	}
+	if blkSlot <= st.Slot() || blkSlot > st.Slot()+params.BeaconConfig().SlotsPerEpoch {
+		httputil.HandleError(w, fmt.Sprintf("Slot must be after the head slot %d and at most one epoch ahead of it", st.Slot()), http.StatusBadRequest)
+		return
+	}
+	st, err = transition.ProcessSlots(ctx, st, blkSlot)
+	if err != nil {
+		httputil.HandleError(w, fmt.Sprintf("Could not process slots up to %d: %v", blkSlot, err), http.StatusInternalServerError)
+		return
+	}
+	if st.Version() < version.Altair {
+		httputil.HandleError(w, "Attestation packing is not supported before Altair", http.StatusBadRequest)
+		return
+	}
+
+	atts, err := s.AttestationPacker.PackAttestationsDryRun(ctx, st, blkSlot)
+	if err != nil {
+		httputil.HandleError(w, "Could not pack attestations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	proposerIndex, err := corehelpers.BeaconProposerIndex(ctx, st)
+	if err != nil {
+		httputil.HandleError(w, "Could not get proposer index: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	totalBalance, err := corehelpers.TotalActiveBalance(st)
+	if err != nil {
+		httputil.HandleError(w, "Could not get total active balance: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	initBalance, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		httputil.HandleError(w, "Could not get proposer's balance: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	packed := make([]*structs.PackedAttestation, len(atts))
+	balance := initBalance
+	for i, att := range atts {
+		committees, err := corehelpers.AttestationCommittees(ctx, st, att)
+		if err != nil {
+			httputil.HandleError(w, "Could not get attestation committees: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		indices, err := attestation.AttestingIndices(att, committees...)
+		if err != nil {
+			httputil.HandleError(w, "Could not get attesting indices: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		st, err = altair.ProcessAttestationNoVerifySignature(ctx, st, att, totalBalance)
+		if err != nil {
+			httputil.HandleError(w, "Could not process attestation: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		attBalance, err := st.BalanceAtIndex(proposerIndex)
+		if err != nil {
+			httputil.HandleError(w, "Could not get proposer's balance: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		ci := committeeIndices(att)
+		packed[i] = &structs.PackedAttestation{
+			Slot:                fmt.Sprintf("%d", att.GetData().Slot),
+			CommitteeIndices:    make([]string, len(ci)),
+			BeaconBlockRoot:     hexutil.Encode(att.GetData().BeaconBlockRoot),
+			TargetEpoch:         fmt.Sprintf("%d", att.GetData().Target.Epoch),
+			AggregationBits:     hexutil.Encode(att.GetAggregationBits()),
+			AttestingValidators: fmt.Sprintf("%d", len(indices)),
+			Reward:              fmt.Sprintf("%d", attBalance-balance),
+		}
+		for j, idx := range ci {
+			packed[i].CommitteeIndices[j] = fmt.Sprintf("%d", idx)
+		}
+		balance = attBalance
+	}
+
+	uncovered, err := uncoveredValidators(ctx, st)
+	if err != nil {
+		httputil.HandleError(w, "Could not get uncovered validators: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetAttestationPackingResponse{Data: &structs.AttestationPacking{
+		Slot:                fmt.Sprintf("%d", blkSlot),
+		ProposerIndex:       fmt.Sprintf("%d", proposerIndex),
+		TotalReward:         fmt.Sprintf("%d", balance-initBalance),
+		Attestations:        packed,
+		UncoveredValidators: uncovered,
+	}})
+}
+
+// uncoveredValidators returns the validators without any participation flag set in the state, for every committee
+// whose attestations can be included in a block at the state's slot.
+func uncoveredValidators(ctx context.Context, st state.BeaconState) ([]*structs.UncoveredCommittee, error) {
+	currentEpoch := coretime.CurrentEpoch(st)
+	start, err := slots.EpochStart(coretime.PrevEpoch(st))
+	if err != nil {
+		return nil, err
+	}
+	prevParticipation, err := st.PreviousEpochParticipation()
+	if err != nil {
+		return nil, err
+	}
+	currParticipation, err := st.CurrentEpochParticipation()
+	if err != nil {
+		return nil, err
+	}
+
+	uncovered := make([]*structs.UncoveredCommittee, 0)
+	for sl := start; sl < st.Slot(); sl++ {
+		epoch := slots.ToEpoch(sl)
+		participation := prevParticipation
+		if epoch == currentEpoch {
+			participation = currParticipation
+		}
+		activeCount, err := corehelpers.ActiveValidatorCount(ctx, st, epoch)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < corehelpers.SlotCommitteeCount(activeCount); i++ {
+			committee, err := corehelpers.BeaconCommitteeFromState(ctx, st, sl, primitives.CommitteeIndex(i))
+			if err != nil {
+				return nil, err
+			}
+			var vals []string
+			for _, v := range committee {
+				if uint64(v) >= uint64(len(participation)) {
+					return nil, fmt.Errorf("no participation for validator %d in epoch %d", v, epoch)
+				}
+				if participation[v] == 0 {
+					vals = append(vals, fmt.Sprintf("%d", v))
+				}
+			}
+			if len(vals) > 0 {
+				uncovered = append(uncovered, &structs.UncoveredCommittee{
+					Slot:       fmt.Sprintf("%d", sl),
+					Index:      fmt.Sprintf("%d", i),
+					Validators: vals,
+				})
+			}
+		}
+	}
+	return uncovered, nil
+}
+
+// committeeIndices returns the indices of the committees the attestation is from.
+func committeeIndices(att ethpb.Att) []primitives.CommitteeIndex {
+	if att.Version() >= version.Electra {
+		return corehelpers.CommitteeIndices(att.CommitteeBitsVal())
+	}
+	return []primitives.CommitteeIndex{att.GetData().CommitteeIndex}
+}
+
+func sortedKeys[K ~uint64, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
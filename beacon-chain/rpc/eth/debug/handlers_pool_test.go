@@ -0,0 +1,181 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	blockchainmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockAttestationPacker struct {
+	atts []ethpb.Att
+	slot primitives.Slot
+}
+
+func (m *mockAttestationPacker) PackAttestationsDryRun(_ context.Context, _ state.BeaconState, blkSlot primitives.Slot) ([]ethpb.Att, error) {
+	m.slot = blkSlot
+	return m.atts, nil
+}
+
+func testAttestation(slot primitives.Slot, committeeIndex primitives.CommitteeIndex, bits bitfield.Bitlist) *ethpb.Attestation {
+	return util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bits,
+		Data:            &ethpb.AttestationData{Slot: slot, CommitteeIndex: committeeIndex},
+	})
+}
+
+func TestGetAttestationPool(t *testing.T) {
+	pool := attestations.NewPool()
+	require.NoError(t, pool.SaveAggregatedAttestation(testAttestation(1, 0, bitfield.Bitlist{0b10011})))
+	require.NoError(t, pool.SaveUnaggregatedAttestation(testAttestation(1, 0, bitfield.Bitlist{0b10100})))
+	require.NoError(t, pool.SaveForkchoiceAttestation(testAttestation(1, 1, bitfield.Bitlist{0b10111})))
+	require.NoError(t, pool.SaveBlockAttestation(testAttestation(2, 0, bitfield.Bitlist{0b111})))
+	s := &Server{AttestationsPool: pool}
+
+	get := func(t *testing.T, query string) *structs.AttestationPool {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/attestation_pool"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetAttestationPool(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttestationPoolResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp.Data
+	}
+
+	t.Run("all slots", func(t *testing.T) {
+		data := get(t, "")
+		assert.Equal(t, "1", data.Aggregated)
+		assert.Equal(t, "1", data.Unaggregated)
+		assert.Equal(t, "1", data.Block)
+		assert.Equal(t, "1", data.Forkchoice)
+		require.Equal(t, 2, len(data.Slots))
+
+		slot := data.Slots[0]
+		assert.Equal(t, "1", slot.Slot)
+		assert.Equal(t, "1", slot.Aggregated)
+		assert.Equal(t, "1", slot.Unaggregated)
+		assert.Equal(t, "0", slot.Block)
+		assert.Equal(t, "1", slot.Forkchoice)
+		require.Equal(t, 2, len(slot.Committees))
+		assert.DeepEqual(t, &structs.AttestationPoolCommittee{
+			Index:             "0",
+			Aggregated:        "1",
+			Unaggregated:      "1",
+			Block:             "0",
+			Forkchoice:        "0",
+			CommitteeSize:     "4",
+			CoveredValidators: "3",
+		}, slot.Committees[0])
+		// Forkchoice attestations aren't available to proposers.
+		assert.Equal(t, "1", slot.Committees[1].Index)
+		assert.Equal(t, "4", slot.Committees[1].CommitteeSize)
+		assert.Equal(t, "0", slot.Committees[1].CoveredValidators)
+
+		assert.Equal(t, "2", data.Slots[1].Slot)
+		assert.Equal(t, "1", data.Slots[1].Block)
+	})
+	t.Run("single slot", func(t *testing.T) {
+		data := get(t, "?slot=2")
+		assert.Equal(t, "1", data.Aggregated)
+		require.Equal(t, 1, len(data.Slots))
+		assert.Equal(t, "2", data.Slots[0].Slot)
+	})
+	t.Run("invalid slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/attestation_pool?slot=x", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetAttestationPool(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestGetAttestationPacking(t *testing.T) {
+	// With 64 validators there is a single committee of 2 validators per slot.
+	full := testAttestation(1, 0, bitfield.Bitlist{0b111})
+	single := testAttestation(1, 0, bitfield.Bitlist{0b101})
+
+	request := func(t *testing.T, s *Server, query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/attestation_pool/packing"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetAttestationPacking(writer, request)
+		return writer
+	}
+	newServer := func(t *testing.T, currentSlot primitives.Slot) (*Server, *mockAttestationPacker) {
+		st, _ := util.DeterministicGenesisStateAltair(t, 64)
+		require.NoError(t, st.SetSlot(1))
+		packer := &mockAttestationPacker{atts: []ethpb.Att{full, single}}
+		chain := &blockchainmock.ChainService{State: st, Slot: &currentSlot}
+		return &Server{HeadFetcher: chain, TimeFetcher: chain, AttestationPacker: packer}, packer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		s, packer := newServer(t, 2)
+		writer := request(t, s, "")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetAttestationPackingResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		data := resp.Data
+		assert.Equal(t, primitives.Slot(2), packer.slot)
+		assert.Equal(t, "2", data.Slot)
+
+		require.Equal(t, 2, len(data.Attestations))
+		assert.Equal(t, "1", data.Attestations[0].Slot)
+		assert.DeepEqual(t, []string{"0"}, data.Attestations[0].CommitteeIndices)
+		assert.Equal(t, "2", data.Attestations[0].AttestingValidators)
+		reward, err := strconv.ParseUint(data.Attestations[0].Reward, 10, 64)
+		require.NoError(t, err)
+		assert.NotEqual(t, uint64(0), reward)
+		// The second attestation doesn't add any new vote.
+		assert.Equal(t, "1", data.Attestations[1].AttestingValidators)
+		assert.Equal(t, "0", data.Attestations[1].Reward)
+		assert.Equal(t, data.Attestations[0].Reward, data.TotalReward)
+
+		// Only the committee of slot 0 has no vote on chain or in the block.
+		require.Equal(t, 1, len(data.UncoveredValidators))
+		assert.Equal(t, "0", data.UncoveredValidators[0].Slot)
+		assert.Equal(t, "0", data.UncoveredValidators[0].Index)
+		assert.Equal(t, 2, len(data.UncoveredValidators[0].Validators))
+	})
+	t.Run("head at the current slot", func(t *testing.T) {
+		s, packer := newServer(t, 1)
+		writer := request(t, s, "")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Slot(2), packer.slot)
+	})
+	t.Run("requested slot", func(t *testing.T) {
+		s, packer := newServer(t, 2)
+		writer := request(t, s, "?slot=3")
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Slot(3), packer.slot)
+	})
+	t.Run("invalid slot", func(t *testing.T) {
+		s, _ := newServer(t, 2)
+		for _, query := range []string{"?slot=1", "?slot=100", "?slot=x"} {
+			writer := request(t, s, query)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, query)
+		}
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		st, _ := util.DeterministicGenesisState(t, 64)
+		chain := &blockchainmock.ChainService{State: st}
+		s := &Server{HeadFetcher: chain, TimeFetcher: chain, AttestationPacker: &mockAttestationPacker{}}
+		writer := request(t, s, "?slot=1")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -459,3 +459,68 @@ func TestGetForkChoice(t *testing.T) {
 	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
 	require.Equal(t, "2", resp.FinalizedCheckpoint.Epoch)
 }
+
+func TestGetReorgs(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	for _, c := range []*forkchoicetypes.HeadChange{
+		{Slot: 10, Reason: forkchoicetypes.HeadChangeNewBlock},
+		{Slot: 11, Reason: forkchoicetypes.HeadChangeLateBlockReorg, Depth: 1, OldHeadRoot: [32]byte{'a'}},
+		{Slot: 12, Reason: forkchoicetypes.HeadChangeNewBlock},
+		{Slot: 20, Reason: forkchoicetypes.HeadChangeReorg, Depth: 3},
+	} {
+		require.NoError(t, db.SaveHeadChange(ctx, c))
+	}
+	s := &Server{BeaconDB: db}
+
+	get := func(t *testing.T, query string) *structs.GetReorgsResponse {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/reorgs"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetReorgs(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetReorgsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	t.Run("reorgs by default", func(t *testing.T) {
+		resp := get(t, "")
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "11", resp.Data[0].Slot)
+		assert.Equal(t, "late_block_reorg", resp.Data[0].Reason)
+		assert.Equal(t, hexutil.Encode(bytesutil.PadTo([]byte{'a'}, 32)), resp.Data[0].OldHeadRoot)
+		assert.Equal(t, "20", resp.Data[1].Slot)
+		assert.Equal(t, "reorg", resp.Data[1].Reason)
+	})
+	t.Run("all", func(t *testing.T) {
+		resp := get(t, "?reason=all")
+		require.Equal(t, 4, len(resp.Data))
+	})
+	t.Run("filters", func(t *testing.T) {
+		resp := get(t, "?reason=new_block&start_slot=11")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "12", resp.Data[0].Slot)
+
+		resp = get(t, "?min_depth=2")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "20", resp.Data[0].Slot)
+
+		resp = get(t, "?end_slot=15")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "11", resp.Data[0].Slot)
+
+		resp = get(t, "?reason=all&limit=1")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "20", resp.Data[0].Slot)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, query := range []string{"?reason=foo", "?start_slot=5&end_slot=4", "?min_depth=x"} {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/reorgs"+query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.GetReorgs(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, query)
+		}
+	})
+}
@@ -34,6 +34,28 @@ func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, req *http.Reques
 	}
 
 	blockRoot := bytesutil.ToBytes32(blockRootParam)
+
+	// Serve the bootstrap persisted when the block was finalized, if there is one.
+	if s.BeaconDB != nil {
+		bootstrap, err := s.BeaconDB.LightClientBootstrap(ctx, blockRoot)
+		if err != nil {
+			httputil.HandleError(w, "could not get light client bootstrap: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if bootstrap != nil {
+			data, err := lightClientBootstrapToJSON(bootstrap)
+			if err != nil {
+				httputil.HandleError(w, "could not convert light client bootstrap: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			httputil.WriteJson(w, &structs.LightClientBootstrapResponse{
+				Version: lightClientVersion(bootstrap.HeaderVal().GetBeacon().Slot),
+				Data:    data,
+			})
+			return
+		}
+	}
+
 	blk, err := s.Blocker.Block(ctx, blockRoot[:])
 	if !shared.WriteBlockFetchError(w, blk, err) {
 		return
@@ -119,6 +141,27 @@ func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, req *http.R
 	// Populate updates
 	var updates []*structs.LightClientUpdateWithVersion
 	for period := startPeriod; period <= endPeriod; period++ {
+		// Serve the best update persisted for the period, if there is one.
+		if s.BeaconDB != nil {
+			update, err := s.BeaconDB.LightClientUpdate(ctx, period)
+			if err != nil {
+				httputil.HandleError(w, "could not get light client update: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if update != nil {
+				data, err := lightClientUpdateToJSON(update)
+				if err != nil {
+					httputil.HandleError(w, "could not convert light client update: "+err.Error(), http.StatusInternalServerError)
+					return
+				}
+				updates = append(updates, &structs.LightClientUpdateWithVersion{
+					Version: lightClientVersion(update.AttestedHeaderVal().GetBeacon().Slot),
+					Data:    data,
+				})
+				continue
+			}
+		}
+
 		// Get the last known state of the period,
 		//    1. We wish the block has a parent in the same period if possible
 		//	  2. We wish the block has a state in the same period
@@ -228,6 +271,22 @@ func (s *Server) GetLightClientFinalityUpdate(w http.ResponseWriter, req *http.R
 	ctx, span := trace.StartSpan(req.Context(), "beacon.GetLightClientFinalityUpdate")
 	defer span.End()
 
+	// Serve the latest finality update derived from the canonical head, if there is one.
+	if s.LightClientFetcher != nil {
+		if update := s.LightClientFetcher.LightClientFinalityUpdate(); update != nil {
+			data, err := lightClientFinalityUpdateToJSON(update)
+			if err != nil {
+				httputil.HandleError(w, "could not convert light client finality update: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			httputil.WriteJson(w, &structs.LightClientUpdateWithVersion{
+				Version: lightClientVersion(update.AttestedHeaderVal().GetBeacon().Slot),
+				Data:    data,
+			})
+			return
+		}
+	}
+
 	// Finality update needs super majority of sync committee signatures
 	minSyncCommitteeParticipants := float64(params.BeaconConfig().MinSyncCommitteeParticipants)
 	minSignatures := uint64(math.Ceil(minSyncCommitteeParticipants * 2 / 3))
@@ -295,6 +354,22 @@ func (s *Server) GetLightClientOptimisticUpdate(w http.ResponseWriter, req *http
 	ctx, span := trace.StartSpan(req.Context(), "beacon.GetLightClientOptimisticUpdate")
 	defer span.End()
 
+	// Serve the latest optimistic update derived from the canonical head, if there is one.
+	if s.LightClientFetcher != nil {
+		if update := s.LightClientFetcher.LightClientOptimisticUpdate(); update != nil {
+			data, err := lightClientOptimisticUpdateToJSON(update)
+			if err != nil {
+				httputil.HandleError(w, "could not convert light client optimistic update: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			httputil.WriteJson(w, &structs.LightClientUpdateWithVersion{
+				Version: lightClientVersion(update.AttestedHeaderVal().GetBeacon().Slot),
+				Data:    data,
+			})
+			return
+		}
+	}
+
 	minSignatures := params.BeaconConfig().MinSyncCommitteeParticipants
 
 	block, err := s.getLightClientEventBlock(ctx, minSignatures)
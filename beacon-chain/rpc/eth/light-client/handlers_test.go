@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -21,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
@@ -1007,3 +1009,147 @@ func TestLightClientHandler_GetLightClientEventBlock_NeedFetchParent(t *testing.
 	require.Equal(t, true, syncAggregate.SyncCommitteeBits.Count() >= minSignaturesRequired)
 	require.Equal(t, slot-1, eventBlock.Block().Slot())
 }
+
+func TestLightClientHandler_PersistedData(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = cfg.EpochsPerSyncCommitteePeriod
+	cfg.CapellaForkEpoch = 2 * cfg.EpochsPerSyncCommitteePeriod
+	cfg.DenebForkEpoch = 3 * cfg.EpochsPerSyncCommitteePeriod
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	slotsPerPeriod := primitives.Slot(uint64(cfg.EpochsPerSyncCommitteePeriod) * uint64(cfg.SlotsPerEpoch))
+	d := dbtest.SetupDB(t)
+
+	t.Run("bootstrap", func(t *testing.T) {
+		bootstrap := util.HydrateLightClientBootstrap(&ethpb.LightClientBootstrapAltair{
+			Header: &ethpb.LightClientHeaderAltair{Beacon: &ethpb.BeaconBlockHeader{Slot: 64}},
+		})
+		root := [32]byte{'r'}
+		require.NoError(t, d.SaveLightClientBootstrap(ctx, root, bootstrap))
+		s := &Server{BeaconDB: d}
+		request := httptest.NewRequest("GET", "http://foo.com/", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientBootstrap(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.LightClientBootstrapResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, "altair", resp.Version)
+		expected, err := lightClientBootstrapToJSON(bootstrap)
+		require.NoError(t, err)
+		require.DeepEqual(t, expected, resp.Data)
+		require.Equal(t, 0, len(resp.Data.Header.Execution))
+	})
+
+	t.Run("deneb bootstrap", func(t *testing.T) {
+		bootstrap := util.HydrateLightClientBootstrapDeneb(&ethpb.LightClientBootstrapDeneb{
+			Header: &ethpb.LightClientHeaderDeneb{
+				Beacon:    &ethpb.BeaconBlockHeader{Slot: 3*slotsPerPeriod + 1},
+				Execution: &enginev1.ExecutionPayloadHeaderDeneb{BlockNumber: 42},
+			},
+		})
+		root := [32]byte{'d'}
+		require.NoError(t, d.SaveLightClientBootstrap(ctx, root, bootstrap))
+		s := &Server{BeaconDB: d}
+		request := httptest.NewRequest("GET", "http://foo.com/", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientBootstrap(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.LightClientBootstrapResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, "deneb", resp.Version)
+		expected, err := lightClientBootstrapToJSON(bootstrap)
+		require.NoError(t, err)
+		require.DeepEqual(t, expected, resp.Data)
+		execution := &structs.ExecutionPayloadHeaderDeneb{}
+		require.NoError(t, json.Unmarshal(resp.Data.Header.Execution, execution))
+		require.Equal(t, "42", execution.BlockNumber)
+		require.Equal(t, 4, len(resp.Data.Header.ExecutionBranch))
+	})
+
+	t.Run("updates by range", func(t *testing.T) {
+		altair := util.HydrateLightClientUpdate(&ethpb.LightClientUpdateAltair{
+			AttestedHeader: &ethpb.LightClientHeaderAltair{Beacon: &ethpb.BeaconBlockHeader{Slot: 10}},
+			SignatureSlot:  11,
+		})
+		bellatrix := util.HydrateLightClientUpdate(&ethpb.LightClientUpdateAltair{
+			AttestedHeader: &ethpb.LightClientHeaderAltair{Beacon: &ethpb.BeaconBlockHeader{Slot: slotsPerPeriod + 10}},
+			SignatureSlot:  slotsPerPeriod + 11,
+		})
+		capella := util.HydrateLightClientUpdateCapella(&ethpb.LightClientUpdateCapella{
+			AttestedHeader: &ethpb.LightClientHeaderCapella{
+				Beacon:    &ethpb.BeaconBlockHeader{Slot: 2*slotsPerPeriod + 10},
+				Execution: &enginev1.ExecutionPayloadHeaderCapella{BlockNumber: 7},
+			},
+			SignatureSlot: 2*slotsPerPeriod + 11,
+		})
+		require.NoError(t, d.SaveLightClientUpdate(ctx, 0, altair))
+		require.NoError(t, d.SaveLightClientUpdate(ctx, 1, bellatrix))
+		require.NoError(t, d.SaveLightClientUpdate(ctx, 2, capella))
+		headState, err := util.NewBeaconStateCapella()
+		require.NoError(t, err)
+		require.NoError(t, headState.SetSlot(3*slotsPerPeriod-1))
+		s := &Server{BeaconDB: d, HeadFetcher: &mock.ChainService{State: headState}}
+		request := httptest.NewRequest("GET", "http://foo.com/?start_period=0&count=3", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetLightClientUpdatesByRange(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		var resp []*structs.LightClientUpdateWithVersion
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+		require.Equal(t, 3, len(resp))
+		for i, update := range []ethpb.LightClientUpdate{altair, bellatrix, capella} {
+			expected, err := lightClientUpdateToJSON(update)
+			require.NoError(t, err)
+			require.DeepEqual(t, expected, resp[i].Data)
+		}
+		require.Equal(t, "altair", resp[0].Version)
+		require.Equal(t, "bellatrix", resp[1].Version)
+		require.Equal(t, "capella", resp[2].Version)
+		execution := &structs.ExecutionPayloadHeaderCapella{}
+		require.NoError(t, json.Unmarshal(resp[2].Data.AttestedHeader.Execution, execution))
+		require.Equal(t, "7", execution.BlockNumber)
+	})
+
+	t.Run("finality and optimistic updates", func(t *testing.T) {
+		finality := util.HydrateLightClientFinalityUpdateDeneb(&ethpb.LightClientFinalityUpdateDeneb{
+			AttestedHeader: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: 3*slotsPerPeriod + 10}},
+			SignatureSlot:  3*slotsPerPeriod + 11,
+		})
+		optimistic := util.HydrateLightClientOptimisticUpdateDeneb(&ethpb.LightClientOptimisticUpdateDeneb{
+			AttestedHeader: &ethpb.LightClientHeaderDeneb{Beacon: &ethpb.BeaconBlockHeader{Slot: 3*slotsPerPeriod + 11}},
+			SignatureSlot:  3*slotsPerPeriod + 12,
+		})
+		s := &Server{LightClientFetcher: &mock.ChainService{LCFinalityUpdate: finality, LCOptimisticUpdate: optimistic}}
+
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientFinalityUpdate(writer, httptest.NewRequest("GET", "http://foo.com/", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.LightClientUpdateWithVersion{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, "deneb", resp.Version)
+		expectedFinality, err := lightClientFinalityUpdateToJSON(finality)
+		require.NoError(t, err)
+		require.DeepEqual(t, expectedFinality, resp.Data)
+
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientOptimisticUpdate(writer, httptest.NewRequest("GET", "http://foo.com/", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &structs.LightClientUpdateWithVersion{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, "deneb", resp.Version)
+		expectedOptimistic, err := lightClientOptimisticUpdateToJSON(optimistic)
+		require.NoError(t, err)
+		require.DeepEqual(t, expectedOptimistic, resp.Data)
+	})
+}
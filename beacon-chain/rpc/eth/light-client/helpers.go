@@ -2,19 +2,24 @@ package lightclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	v2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -79,7 +84,7 @@ func createLightClientBootstrap(ctx context.Context, state state.BeaconState) (*
 
 	// Return result
 	result := &structs.LightClientBootstrap{
-		Header:                     header,
+		Header:                     &structs.LightClientHeader{BeaconBlockHeader: header},
 		CurrentSyncCommittee:       committee,
 		CurrentSyncCommitteeBranch: branch,
 	}
@@ -296,13 +301,13 @@ func newLightClientUpdateToJSON(input *v2.LightClientUpdate) *structs.LightClien
 		nextSyncCommittee = structs.SyncCommitteeFromConsensus(migration.V2SyncCommitteeToV1Alpha1(input.NextSyncCommittee))
 	}
 
-	var finalizedHeader *structs.BeaconBlockHeader
+	var finalizedHeader *structs.LightClientHeader
 	if input.FinalizedHeader != nil {
-		finalizedHeader = structs.BeaconBlockHeaderFromConsensus(migration.V1HeaderToV1Alpha1(input.FinalizedHeader))
+		finalizedHeader = &structs.LightClientHeader{BeaconBlockHeader: structs.BeaconBlockHeaderFromConsensus(migration.V1HeaderToV1Alpha1(input.FinalizedHeader))}
 	}
 
 	return &structs.LightClientUpdate{
-		AttestedHeader:          structs.BeaconBlockHeaderFromConsensus(migration.V1HeaderToV1Alpha1(input.AttestedHeader)),
+		AttestedHeader:          &structs.LightClientHeader{BeaconBlockHeader: structs.BeaconBlockHeaderFromConsensus(migration.V1HeaderToV1Alpha1(input.AttestedHeader))},
 		NextSyncCommittee:       nextSyncCommittee,
 		NextSyncCommitteeBranch: branchToJSON(input.NextSyncCommitteeBranch),
 		FinalizedHeader:         finalizedHeader,
@@ -311,3 +316,113 @@ func newLightClientUpdateToJSON(input *v2.LightClientUpdate) *structs.LightClien
 		SignatureSlot:           strconv.FormatUint(uint64(input.SignatureSlot), 10),
 	}
 }
+
+// lightClientVersion returns the version of persisted light client data with a header at the slot, which is
+// the fork of the slot. Bellatrix data uses the Altair light client containers.
+func lightClientVersion(slot primitives.Slot) string {
+	epoch := slots.ToEpoch(slot)
+	switch {
+	case epoch >= params.BeaconConfig().DenebForkEpoch:
+		return version.String(version.Deneb)
+	case epoch >= params.BeaconConfig().CapellaForkEpoch:
+		return version.String(version.Capella)
+	case epoch >= params.BeaconConfig().BellatrixForkEpoch:
+		return version.String(version.Bellatrix)
+	default:
+		return version.String(version.Altair)
+	}
+}
+
+func lightClientHeaderToJSON(input ethpb.LightClientHeader) (*structs.LightClientHeader, error) {
+	header := &structs.LightClientHeader{BeaconBlockHeader: structs.BeaconBlockHeaderFromConsensus(input.GetBeacon())}
+	var execution interface{}
+	var err error
+	switch h := input.(type) {
+	case *ethpb.LightClientHeaderAltair:
+		return header, nil
+	case *ethpb.LightClientHeaderCapella:
+		execution, err = structs.ExecutionPayloadHeaderCapellaFromConsensus(h.Execution)
+		header.ExecutionBranch = branchToJSON(h.ExecutionBranch)
+	case *ethpb.LightClientHeaderDeneb:
+		execution, err = structs.ExecutionPayloadHeaderDenebFromConsensus(h.Execution)
+		header.ExecutionBranch = branchToJSON(h.ExecutionBranch)
+	default:
+		return nil, fmt.Errorf("unsupported light client header %T", input)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert execution payload header")
+	}
+	if header.Execution, err = json.Marshal(execution); err != nil {
+		return nil, errors.Wrap(err, "could not marshal execution payload header")
+	}
+	return header, nil
+}
+
+func lightClientBootstrapToJSON(input ethpb.LightClientBootstrap) (*structs.LightClientBootstrap, error) {
+	header, err := lightClientHeaderToJSON(input.HeaderVal())
+	if err != nil {
+		return nil, err
+	}
+	return &structs.LightClientBootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       structs.SyncCommitteeFromConsensus(input.GetCurrentSyncCommittee()),
+		CurrentSyncCommitteeBranch: branchToJSON(input.GetCurrentSyncCommitteeBranch()),
+	}, nil
+}
+
+func lightClientUpdateToJSON(input ethpb.LightClientUpdate) (*structs.LightClientUpdate, error) {
+	attestedHeader, err := lightClientHeaderToJSON(input.AttestedHeaderVal())
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := lightClientHeaderToJSON(input.FinalizedHeaderVal())
+	if err != nil {
+		return nil, err
+	}
+	return &structs.LightClientUpdate{
+		AttestedHeader:          attestedHeader,
+		NextSyncCommittee:       structs.SyncCommitteeFromConsensus(input.GetNextSyncCommittee()),
+		NextSyncCommitteeBranch: branchToJSON(input.GetNextSyncCommitteeBranch()),
+		FinalizedHeader:         finalizedHeader,
+		FinalityBranch:          branchToJSON(input.GetFinalityBranch()),
+		SyncAggregate:           altairSyncAggregateToJSON(input.GetSyncAggregate()),
+		SignatureSlot:           strconv.FormatUint(uint64(input.GetSignatureSlot()), 10),
+	}, nil
+}
+
+func lightClientFinalityUpdateToJSON(input ethpb.LightClientFinalityUpdate) (*structs.LightClientUpdate, error) {
+	attestedHeader, err := lightClientHeaderToJSON(input.AttestedHeaderVal())
+	if err != nil {
+		return nil, err
+	}
+	finalizedHeader, err := lightClientHeaderToJSON(input.FinalizedHeaderVal())
+	if err != nil {
+		return nil, err
+	}
+	return &structs.LightClientUpdate{
+		AttestedHeader:  attestedHeader,
+		FinalizedHeader: finalizedHeader,
+		FinalityBranch:  branchToJSON(input.GetFinalityBranch()),
+		SyncAggregate:   altairSyncAggregateToJSON(input.GetSyncAggregate()),
+		SignatureSlot:   strconv.FormatUint(uint64(input.GetSignatureSlot()), 10),
+	}, nil
+}
+
+func lightClientOptimisticUpdateToJSON(input ethpb.LightClientOptimisticUpdate) (*structs.LightClientUpdate, error) {
+	attestedHeader, err := lightClientHeaderToJSON(input.AttestedHeaderVal())
+	if err != nil {
+		return nil, err
+	}
+	return &structs.LightClientUpdate{
+		AttestedHeader: attestedHeader,
+		SyncAggregate:  altairSyncAggregateToJSON(input.GetSyncAggregate()),
+		SignatureSlot:  strconv.FormatUint(uint64(input.GetSignatureSlot()), 10),
+	}, nil
+}
+
+func altairSyncAggregateToJSON(input *ethpb.SyncAggregate) *structs.SyncAggregate {
+	return &structs.SyncAggregate{
+		SyncCommitteeBits:      hexutil.Encode(input.SyncCommitteeBits),
+		SyncCommitteeSignature: hexutil.Encode(input.SyncCommitteeSignature),
+	}
+}
@@ -1,7 +1,10 @@
 package node
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -200,6 +204,210 @@ func (s *Server) GetPeerCount(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
+// GetPeerScore retrieves the score of the given peer, along with the scores of the individual scorers
+// and the decisions of the peer scoring policy.
+func (s *Server) GetPeerScore(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetPeerScore")
+	defer span.End()
+
+	id, ok := peerIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	peerStatus := s.PeersFetcher.Peers()
+	breakdown := peerStatus.Scorers().ScoreBreakdown(id)
+	if breakdown == nil {
+		httputil.HandleError(w, "Peer not found", http.StatusNotFound)
+		return
+	}
+
+	resp := &structs.GetPeerScoreResponse{
+		Data: &structs.PeerScore{
+			PeerId: id.String(),
+			Agent:  breakdown.Agent,
+			Score:  formatScore(breakdown.Score),
+			Scorers: []*structs.ScorerScore{
+				{Name: "bad_responses", Score: formatScore(breakdown.BadResponses), Weight: formatScore(breakdown.Weights.BadResponses)},
+				{Name: "block_provider", Score: formatScore(breakdown.BlockProvider), Weight: formatScore(breakdown.Weights.BlockProvider)},
+				{Name: "peer_status", Score: formatScore(breakdown.PeerStatus), Weight: formatScore(breakdown.Weights.PeerStatus)},
+				{Name: "gossip", Score: formatScore(breakdown.Gossip), Weight: formatScore(breakdown.Weights.Gossip)},
+			},
+			ClientPreference: formatScore(breakdown.ClientPreference),
+			IsBad:            peerStatus.IsBad(id),
+			Trusted:          peerStatus.IsTrustedPeers(id),
+			Allowed:          breakdown.Allowed,
+			Denied:           breakdown.Denied,
+			Pinned:           breakdown.Pinned,
+		},
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// BanPeer adds the given peer to the deny list of the peer scoring policy and disconnects from it.
+// The peer is removed from the allow and pin lists of the policy.
+func (s *Server) BanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.BanPeer")
+	defer span.End()
+
+	id, ok := peerIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	peerStatus := s.PeersFetcher.Peers()
+	peerStatus.Scorers().DenyPeer(id, true)
+	if peerStatus.IsActive(id) {
+		if err := s.PeerManager.Disconnect(id); err != nil {
+			httputil.HandleError(w, "Could not disconnect from peer: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanPeer removes the given peer from the deny list of the peer scoring policy.
+func (s *Server) UnbanPeer(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.UnbanPeer")
+	defer span.End()
+
+	id, ok := peerIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	s.PeersFetcher.Peers().Scorers().DenyPeer(id, false)
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetPeerScoringPolicy retrieves the peer scoring policy in use.
+func (s *Server) GetPeerScoringPolicy(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetPeerScoringPolicy")
+	defer span.End()
+
+	policy := s.PeersFetcher.Peers().Scorers().Policy()
+	resp := &structs.GetPeerScoringPolicyResponse{
+		Data: &structs.PeerScoringPolicy{
+			Weights: &structs.ScorerWeights{
+				BadResponses:  formatScore(policy.Weights.BadResponses),
+				BlockProvider: formatScore(policy.Weights.BlockProvider),
+				PeerStatus:    formatScore(policy.Weights.PeerStatus),
+				Gossip:        formatScore(policy.Weights.Gossip),
+			},
+			Allow:             peerIdList(policy.Allowed),
+			Deny:              peerIdList(policy.Denied),
+			Pin:               peerIdList(policy.Pinned),
+			ClientPreferences: make(map[string]string, len(policy.ClientPreferences)),
+		},
+	}
+	for client, adj := range policy.ClientPreferences {
+		resp.Data.ClientPreferences[client] = formatScore(adj)
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// SetPeerScoringPolicy replaces the peer scoring policy in use, without restarting the node.
+// Omitted weights keep their default value, like in the policy file given with --p2p-scoring-policy.
+// Newly denied peers are disconnected.
+func (s *Server) SetPeerScoringPolicy(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.SetPeerScoringPolicy")
+	defer span.End()
+
+	var req structs.PeerScoringPolicy
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	policy, err := scoringPolicyFromJson(&req)
+	if err != nil {
+		httputil.HandleError(w, "Invalid peer scoring policy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	peerStatus := s.PeersFetcher.Peers()
+	if err := peerStatus.Scorers().SetPolicy(policy); err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for id := range policy.Denied {
+		if !peerStatus.IsActive(id) {
+			continue
+		}
+		if err := s.PeerManager.Disconnect(id); err != nil {
+			httputil.HandleError(w, "Could not disconnect from peer: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func scoringPolicyFromJson(p *structs.PeerScoringPolicy) (*scorers.Policy, error) {
+	cfg := &scorers.PolicyConfig{
+		Allow: p.Allow,
+		Deny:  p.Deny,
+		Pin:   p.Pin,
+	}
+	if p.Weights != nil {
+		weights := []struct {
+			name  string
+			value string
+			dst   **float64
+		}{
+			{"bad_responses", p.Weights.BadResponses, &cfg.Weights.BadResponses},
+			{"block_provider", p.Weights.BlockProvider, &cfg.Weights.BlockProvider},
+			{"peer_status", p.Weights.PeerStatus, &cfg.Weights.PeerStatus},
+			{"gossip", p.Weights.Gossip, &cfg.Weights.Gossip},
+		}
+		for _, weight := range weights {
+			if weight.value == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(weight.value, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s weight", weight.name)
+			}
+			*weight.dst = &v
+		}
+	}
+	cfg.ClientPreferences = make(map[string]float64, len(p.ClientPreferences))
+	for client, rawAdj := range p.ClientPreferences {
+		adj, err := strconv.ParseFloat(rawAdj, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid client preference for %s", client)
+		}
+		cfg.ClientPreferences[client] = adj
+	}
+	return cfg.Policy()
+}
+
+func peerIdList(set map[peer.ID]bool) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id.String())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func peerIdFromPath(w http.ResponseWriter, r *http.Request) (peer.ID, bool) {
+	rawId := mux.Vars(r)["peer_id"]
+	if rawId == "" {
+		httputil.HandleError(w, "peer_id is required in URL params", http.StatusBadRequest)
+		return "", false
+	}
+	id, err := peer.Decode(rawId)
+	if err != nil {
+		httputil.HandleError(w, "Invalid peer ID: "+err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
 func handleEmptyFilters(states []string, directions []string) (emptyState, emptyDirection bool) {
 	emptyState = true
 	for _, stateFilter := range states {
@@ -345,3 +345,173 @@ func BenchmarkGetPeers(b *testing.B) {
 		s.GetPeers(writer, request)
 	}
 }
+
+func TestGetPeerScore(t *testing.T) {
+	const rawId = "16Uiu2HAkvyYtoQXZNTsthjgLHjEnv7kvwzEmjvsJjWXpbhtqpSUN"
+	id, err := peer.Decode(rawId)
+	require.NoError(t, err)
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	peerStatus := peerFetcher.Peers()
+	peerStatus.Add(nil, id, nil, network.DirInbound)
+	peerStatus.SetAgent(id, "Lighthouse/v5.1.0")
+	policy := peerStatus.Scorers().Policy()
+	policy.ClientPreferences["lighthouse"] = 0.25
+	policy.Pinned[id] = true
+	require.NoError(t, peerStatus.Scorers().SetPolicy(policy))
+	s := Server{PeersFetcher: peerFetcher}
+
+	t.Run("OK", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peers/{peer_id}/score", nil)
+		request = mux.SetURLVars(request, map[string]string{"peer_id": rawId})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPeerScore(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPeerScoreResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, rawId, resp.Data.PeerId)
+		assert.Equal(t, "Lighthouse/v5.1.0", resp.Data.Agent)
+		assert.Equal(t, "0.25", resp.Data.ClientPreference)
+		require.Equal(t, 4, len(resp.Data.Scorers))
+		assert.Equal(t, "bad_responses", resp.Data.Scorers[0].Name)
+		assert.Equal(t, "0.3", resp.Data.Scorers[0].Weight)
+		assert.Equal(t, true, resp.Data.Pinned)
+		assert.Equal(t, false, resp.Data.Denied)
+		assert.Equal(t, false, resp.Data.IsBad)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peers/{peer_id}/score", nil)
+		request = mux.SetURLVars(request, map[string]string{"peer_id": "foo"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPeerScore(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid peer ID", e.Message)
+	})
+
+	t.Run("Peer not found", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peers/{peer_id}/score", nil)
+		request = mux.SetURLVars(request, map[string]string{"peer_id": "16Uiu2HAmQqFdEcHbSmQTQuLoAhnMUrgoWoraKK4cUJT6FuuqHqTU"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetPeerScore(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestBanPeer(t *testing.T) {
+	const rawId = "16Uiu2HAkvyYtoQXZNTsthjgLHjEnv7kvwzEmjvsJjWXpbhtqpSUN"
+	id, err := peer.Decode(rawId)
+	require.NoError(t, err)
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	peerStatus := peerFetcher.Peers()
+	peerStatus.Add(nil, id, nil, network.DirInbound)
+	peerStatus.SetConnectionState(id, peers.PeerConnected)
+	peerStatus.SetTrustedPeers([]peer.ID{id})
+	s := Server{PeersFetcher: peerFetcher, PeerManager: &mockp2p.MockPeerManager{}}
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peers/{peer_id}/ban", nil)
+	request = mux.SetURLVars(request, map[string]string{"peer_id": rawId})
+	writer := httptest.NewRecorder()
+	s.BanPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, peerStatus.Scorers().Policy().Denied[id])
+	assert.Equal(t, true, peerStatus.IsBad(id))
+
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/node/peers/{peer_id}/ban", nil)
+	request = mux.SetURLVars(request, map[string]string{"peer_id": rawId})
+	writer = httptest.NewRecorder()
+	s.UnbanPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, false, peerStatus.Scorers().Policy().Denied[id])
+	assert.Equal(t, false, peerStatus.IsBad(id))
+
+	request = httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peers/{peer_id}/ban", nil)
+	request = mux.SetURLVars(request, map[string]string{"peer_id": "foo"})
+	writer = httptest.NewRecorder()
+	s.BanPeer(writer, request)
+	require.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestPeerScoringPolicy(t *testing.T) {
+	const rawId = "16Uiu2HAkvyYtoQXZNTsthjgLHjEnv7kvwzEmjvsJjWXpbhtqpSUN"
+	id, err := peer.Decode(rawId)
+	require.NoError(t, err)
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	peerStatus := peerFetcher.Peers()
+	peerStatus.Add(nil, id, nil, network.DirInbound)
+	peerStatus.SetConnectionState(id, peers.PeerConnected)
+	s := Server{PeersFetcher: peerFetcher, PeerManager: &mockp2p.MockPeerManager{}}
+
+	getPolicy := func(t *testing.T) *structs.PeerScoringPolicy {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/peers/scoring_policy", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPeerScoringPolicy(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPeerScoringPolicyResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp.Data
+	}
+	setPolicy := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/node/peers/scoring_policy", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.SetPeerScoringPolicy(writer, request)
+		return writer
+	}
+
+	t.Run("default policy", func(t *testing.T) {
+		policy := getPolicy(t)
+		assert.Equal(t, "0.3", policy.Weights.BadResponses)
+		assert.Equal(t, "0", policy.Weights.BlockProvider)
+		assert.Equal(t, "0.4", policy.Weights.Gossip)
+		assert.Equal(t, 0, len(policy.Deny))
+	})
+	t.Run("replace policy", func(t *testing.T) {
+		writer := setPolicy(t, `{"weights":{"gossip":"0.6"},"deny":["`+rawId+`"],"client_preferences":{"Teku":"-0.5"}}`)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, true, peerStatus.Scorers().Policy().Denied[id])
+		assert.Equal(t, true, peerStatus.IsBad(id))
+
+		policy := getPolicy(t)
+		assert.Equal(t, "0.3", policy.Weights.BadResponses)
+		assert.Equal(t, "0.6", policy.Weights.Gossip)
+		assert.DeepEqual(t, []string{rawId}, policy.Deny)
+		assert.DeepEqual(t, map[string]string{"teku": "-0.5"}, policy.ClientPreferences)
+
+		// The returned policy can be submitted back unchanged.
+		b, err := json.Marshal(policy)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, setPolicy(t, string(b)).Code)
+		assert.DeepEqual(t, policy, getPolicy(t))
+	})
+	t.Run("invalid policy", func(t *testing.T) {
+		cases := map[string]string{
+			"":                                    "No data submitted",
+			`{"weights":{"gossip":"foo"}}`:        "invalid gossip weight",
+			`{"weights":{"gossip":"-1"}}`:         "scorer weights must not be negative",
+			`{"deny":["foo"]}`:                    "invalid peer ID",
+			`{"client_preferences":{"teku":"2"}}`: "client preference for teku must be between",
+			`{"deny":["` + rawId + `"],"pin":["` + rawId + `"]}`: "cannot be both denied and allowed or pinned",
+		}
+		for body, msg := range cases {
+			writer := setPolicy(t, body)
+			require.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &httputil.DefaultJsonError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, msg, e.Message)
+		}
+		// The policy in use is left unchanged.
+		assert.Equal(t, "0.6", getPolicy(t).Weights.Gossip)
+	})
+}
@@ -235,11 +235,15 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 
 		// There's no reason to try to get a builder bid if local override is true.
 		var builderBid builderapi.Bid
+		var builderErr error
+		var bidRequested, bidReceived time.Time
 		if !(local.OverrideBuilder || skipMevBoost) {
-			builderBid, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
-			if err != nil {
+			bidRequested = time.Now()
+			builderBid, builderErr = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+			bidReceived = time.Now()
+			if builderErr != nil {
 				builderGetPayloadMissCount.Inc()
-				log.WithError(err).Error("Could not get builder payload")
+				log.WithError(builderErr).Error("Could not get builder payload")
 			}
 		}
 
@@ -247,6 +251,7 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
 		}
+		vs.recordBuilderBid(vs.builderBidRecord(sBlk, local, builderBid, builderErr, bidRequested, bidReceived, builderBoostFactor))
 	}
 
 	wg.Wait()
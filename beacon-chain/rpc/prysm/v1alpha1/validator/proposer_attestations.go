@@ -41,7 +41,26 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 		return nil, errors.Wrap(err, "could not filter attestations")
 	}
 	atts = append(atts, uAtts...)
+	return selectAttestations(atts, blkSlot)
+}
+
+// PackAttestationsDryRun returns the attestations which would be packed into a block at blkSlot built on top of
+// latestState. Unlike block production, attestations which are not valid for inclusion are left in the pool.
+func (vs *Server) PackAttestationsDryRun(ctx context.Context, latestState state.BeaconState, blkSlot primitives.Slot) ([]ethpb.Att, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.PackAttestationsDryRun")
+	defer span.End()
+
+	uAtts, err := vs.AttPool.UnaggregatedAttestations()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get unaggregated attestations")
+	}
+	atts, _ := proposerAtts(append(vs.AttPool.AggregatedAttestations(), uAtts...)).filter(ctx, latestState)
+	return selectAttestations(atts, blkSlot)
+}
 
+// selectAttestations aggregates attestations which are valid for inclusion and selects the most profitable ones for
+// a block at blkSlot.
+func selectAttestations(atts []ethpb.Att, blkSlot primitives.Slot) ([]ethpb.Att, error) {
 	// Checking the state's version here will give the wrong result if the last slot of Deneb is missed.
 	// The head state will still be in Deneb while we are trying to build an Electra block.
 	postElectra := slots.ToEpoch(blkSlot) >= params.BeaconConfig().ElectraForkEpoch
@@ -63,7 +82,7 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 
 	// Remove duplicates from both aggregated/unaggregated attestations. This
 	// prevents inefficient aggregates being created.
-	versionAtts, err = proposerAtts(versionAtts).dedup()
+	versionAtts, err := proposerAtts(versionAtts).dedup()
 	if err != nil {
 		return nil, err
 	}
@@ -113,8 +132,7 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 	if err != nil {
 		return nil, err
 	}
-	atts = sorted.limitToMaxAttestations()
-	return atts, nil
+	return sorted.limitToMaxAttestations(), nil
 }
 
 // filter separates attestation list into two groups: valid and invalid attestations.
@@ -547,3 +547,37 @@ func Test_limitToMaxAttestations(t *testing.T) {
 		assert.Equal(t, len(pAtts)-1, len(pAtts.limitToMaxAttestations()))
 	})
 }
+
+func TestServer_PackAttestationsDryRun(t *testing.T) {
+	ctx := context.Background()
+	valid := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b11111},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	invalid := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b11111},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 5, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	pool := attestations.NewPool()
+	require.NoError(t, pool.SaveAggregatedAttestations([]ethpb.Att{valid, invalid}))
+	s := &Server{AttPool: pool}
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetSlot(1))
+
+	atts, err := s.PackAttestationsDryRun(ctx, st, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	assert.DeepEqual(t, valid, atts[0])
+	// Attestations which are not valid for inclusion are kept in the pool.
+	assert.Equal(t, 2, pool.AggregatedAttestationCount())
+}
@@ -99,7 +99,7 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		// Use builder payload if the following in true:
 		// builder_bid_value * builderBoostFactor(default 100) > local_block_value * (local-block-value-boost + 100)
 		boost := primitives.Gwei(params.BeaconConfig().LocalBlockValueBoost)
-		higherValueBuilder := builderValueGwei*builderBoostFactor > localValueGwei*(100+boost)
+		higherValueBuilder := builderBidWins(builderValueGwei, localValueGwei, builderBoostFactor)
 		if boost > 0 && builderBoostFactor != defaultBuilderBoostFactor {
 			log.WithFields(logrus.Fields{
 				"localGweiValue":       localValueGwei,
@@ -146,6 +146,13 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	}
 }
 
+// builderBidWins returns true if the builder bid value scaled by the builder boost factor is higher than the local
+// payload value increased by the local block value boost, in which case the builder payload should be used.
+func builderBidWins(builderValue, localValue, builderBoostFactor primitives.Gwei) bool {
+	boost := primitives.Gwei(params.BeaconConfig().LocalBlockValueBoost)
+	return builderValue*builderBoostFactor > localValue*(100+boost)
+}
+
 // This function retrieves the payload header and kzg commitments given the slot number and the validator index.
 // It's a no-op if the latest head block is not versioned bellatrix.
 func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (builder.Bid, error) {
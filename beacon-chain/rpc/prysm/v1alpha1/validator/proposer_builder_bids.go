@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	builderapi "github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var (
+	builderBidCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "builder_bid_total",
+		Help: "The number of builder bids per relay and outcome, when proposing a block",
+	}, []string{"relay", "outcome"})
+	builderBidLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "builder_bid_latency_milliseconds",
+		Help:    "Time spent requesting a builder bid per relay, in milliseconds",
+		Buckets: []float64{50, 100, 200, 300, 400, 500, 750, 1000, 1500},
+	}, []string{"relay"})
+	builderBidValueDeltaGwei = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "builder_bid_value_delta_gwei",
+		Help: "Difference between the last builder bid value of a relay and the local payload value, in gwei",
+	}, []string{"relay"})
+)
+
+// builderBidRecord returns the audit record of the builder bid obtained for a proposal, compared to the local payload.
+// It returns nil when no builder is configured.
+func (vs *Server) builderBidRecord(
+	blk interfaces.SignedBeaconBlock,
+	local *blocks.GetPayloadResponse,
+	bid builderapi.Bid,
+	bidErr error,
+	requested, received time.Time,
+	builderBoostFactor primitives.Gwei,
+) *buildertypes.BidRecord {
+	if vs.BlockBuilder == nil || !vs.BlockBuilder.Configured() || blk.Version() < version.Bellatrix {
+		return nil
+	}
+	slot := blk.Block().Slot()
+	r := &buildertypes.BidRecord{
+		Slot:               slot,
+		ProposerIndex:      blk.Block().ProposerIndex(),
+		Timestamp:          uint64(time.Now().UnixMilli()),
+		Outcome:            buildertypes.BidNotRequested,
+		LocalValue:         primitives.WeiToGwei(local.Bid),
+		LocalBoost:         params.BeaconConfig().LocalBlockValueBoost,
+		BuilderBoostFactor: uint64(builderBoostFactor),
+	}
+	if !requested.IsZero() {
+		r.Latency = uint64(received.Sub(requested).Milliseconds())
+		slotStart, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
+		if err == nil && received.After(slotStart) {
+			r.SinceSlotStart = uint64(received.Sub(slotStart).Milliseconds())
+		}
+	}
+	switch {
+	case bidErr != nil:
+		r.Outcome = buildertypes.BidFailed
+		r.Error = bidErr.Error()
+	case bid == nil || bid.IsNil():
+		// The builder could not be used for this proposal, for instance because the validator is not registered.
+	default:
+		copy(r.RelayPubkey[:], bid.Pubkey())
+		if header, err := bid.Header(); err == nil {
+			copy(r.BlockHash[:], header.BlockHash())
+		}
+		r.BuilderValue = primitives.WeiToGwei(bid.Value())
+		switch {
+		case blk.IsBlinded():
+			r.Outcome = buildertypes.BidAccepted
+		case !builderBidWins(r.BuilderValue, r.LocalValue, builderBoostFactor):
+			r.Outcome = buildertypes.BidLowerValue
+		default:
+			r.Outcome = buildertypes.BidRejected
+		}
+	}
+	return r
+}
+
+// recordBuilderBid updates the builder bid metrics and saves the record in the background, so that it does not delay
+// the proposal.
+func (vs *Server) recordBuilderBid(r *buildertypes.BidRecord) {
+	if r == nil {
+		return
+	}
+	relay := "none"
+	if r.RelayPubkey != [fieldparams.BLSPubkeyLength]byte{} {
+		relay = fmt.Sprintf("%#x", r.RelayPubkey)
+		builderBidLatency.WithLabelValues(relay).Observe(float64(r.Latency))
+		builderBidValueDeltaGwei.WithLabelValues(relay).Set(float64(r.BuilderValue) - float64(r.LocalValue))
+	}
+	builderBidCount.WithLabelValues(relay, r.Outcome.String()).Inc()
+
+	go func() {
+		if err := vs.BeaconDB.SaveBuilderBid(context.Background(), r); err != nil {
+			log.WithError(err).Error("Could not save builder bid record")
+		}
+	}()
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	builderapi "github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	builderTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/testing"
+	buildertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/builder/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestServer_builderBidRecord(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.LocalBlockValueBoost = 0
+	params.OverrideBeaconConfig(cfg)
+
+	genesis := time.Unix(time.Now().Add(-time.Hour).Unix(), 0)
+	vs := &Server{
+		BlockBuilder: &builderTest.MockBuilderService{HasConfigured: true},
+		TimeFetcher:  &testutil.MockGenesisTimeFetcher{Genesis: genesis},
+	}
+	local := &blocks.GetPayloadResponse{Bid: primitives.Uint64ToWei(2e9)}
+	relay := bytesutil.PadTo([]byte{'r'}, 48)
+	newBid := func(t *testing.T, gwei uint64) builderapi.Bid {
+		bid, err := builderapi.WrappedBuilderBidCapella(&ethpb.BuilderBidCapella{
+			Header: &v1.ExecutionPayloadHeaderCapella{
+				ParentHash:       make([]byte, 32),
+				FeeRecipient:     make([]byte, 20),
+				StateRoot:        make([]byte, 32),
+				ReceiptsRoot:     make([]byte, 32),
+				LogsBloom:        make([]byte, 256),
+				PrevRandao:       make([]byte, 32),
+				BaseFeePerGas:    make([]byte, 32),
+				BlockHash:        bytesutil.PadTo([]byte{'h'}, 32),
+				TransactionsRoot: make([]byte, 32),
+				WithdrawalsRoot:  make([]byte, 32),
+			},
+			Value:  bytesutil.PadTo(bytesutil.ReverseByteOrder(new(big.Int).SetUint64(gwei*1e9).Bytes()), 32),
+			Pubkey: relay,
+		})
+		require.NoError(t, err)
+		return bid
+	}
+	slot := primitives.Slot(10)
+	slotStart := genesis.Add(time.Duration(uint64(slot)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	requested := slotStart.Add(100 * time.Millisecond)
+	received := requested.Add(250 * time.Millisecond)
+
+	t.Run("accepted", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBlindedBeaconBlockCapella())
+		require.NoError(t, err)
+		blk.SetSlot(slot)
+		r := vs.builderBidRecord(blk, local, newBid(t, 3), nil, requested, received, defaultBuilderBoostFactor)
+		require.NotNil(t, r)
+		assert.Equal(t, buildertypes.BidAccepted, r.Outcome)
+		assert.Equal(t, slot, r.Slot)
+		assert.Equal(t, primitives.Gwei(3), r.BuilderValue)
+		assert.Equal(t, primitives.Gwei(2), r.LocalValue)
+		assert.Equal(t, uint64(100), r.BuilderBoostFactor)
+		assert.Equal(t, uint64(250), r.Latency)
+		assert.Equal(t, uint64(350), r.SinceSlotStart)
+		assert.DeepEqual(t, relay, r.RelayPubkey[:])
+		assert.DeepEqual(t, bytesutil.PadTo([]byte{'h'}, 32), r.BlockHash[:])
+	})
+	t.Run("lower value", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		r := vs.builderBidRecord(blk, &blocks.GetPayloadResponse{Bid: primitives.Uint64ToWei(5e18)}, newBid(t, 3), nil, requested, received, defaultBuilderBoostFactor)
+		require.NotNil(t, r)
+		assert.Equal(t, buildertypes.BidLowerValue, r.Outcome)
+	})
+	t.Run("lower value bellatrix", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+		require.NoError(t, err)
+		r := vs.builderBidRecord(blk, &blocks.GetPayloadResponse{Bid: primitives.Uint64ToWei(5e18)}, newBid(t, 3), nil, requested, received, defaultBuilderBoostFactor)
+		require.NotNil(t, r)
+		assert.Equal(t, buildertypes.BidLowerValue, r.Outcome)
+	})
+	t.Run("rejected", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		r := vs.builderBidRecord(blk, local, newBid(t, 3), nil, requested, received, defaultBuilderBoostFactor)
+		require.NotNil(t, r)
+		assert.Equal(t, buildertypes.BidRejected, r.Outcome)
+	})
+	t.Run("failed", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		r := vs.builderBidRecord(blk, local, nil, errors.New("timeout"), requested, received, defaultBuilderBoostFactor)
+		require.NotNil(t, r)
+		assert.Equal(t, buildertypes.BidFailed, r.Outcome)
+		assert.Equal(t, "timeout", r.Error)
+	})
+	t.Run("not requested", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		r := vs.builderBidRecord(blk, local, nil, nil, time.Time{}, time.Time{}, defaultBuilderBoostFactor)
+		require.NotNil(t, r)
+		assert.Equal(t, buildertypes.BidNotRequested, r.Outcome)
+		assert.Equal(t, uint64(0), r.Latency)
+	})
+	t.Run("no builder", func(t *testing.T) {
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		s := &Server{BlockBuilder: &builderTest.MockBuilderService{}}
+		require.IsNil(t, s.builderBidRecord(blk, local, nil, nil, time.Time{}, time.Time{}, defaultBuilderBoostFactor))
+	})
+}
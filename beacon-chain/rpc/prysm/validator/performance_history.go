@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// defaultPerformanceHistoryEpochs is the number of epochs returned when no start epoch is requested.
+	defaultPerformanceHistoryEpochs = primitives.Epoch(32)
+	// maxPerformanceHistoryEpochs is the widest range of epochs that can be requested at once.
+	maxPerformanceHistoryEpochs = primitives.Epoch(1024)
+)
+
+// GetValidatorPerformanceHistory returns the per epoch performance recorded by the validator monitor, ordered by
+// epoch and validator index. The range defaults to the last 32 epochs, and can be narrowed down to some validators
+// with the index query parameter. Epochs are recorded once their attestations can no longer be included.
+func (s *Server) GetValidatorPerformanceHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorPerformanceHistory")
+	defer span.End()
+
+	rawEndEpoch, endEpoch, ok := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !ok {
+		return
+	}
+	if rawEndEpoch == "" {
+		endEpoch = uint64(slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot()))
+	}
+	rawStartEpoch, startEpoch, ok := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !ok {
+		return
+	}
+	if rawStartEpoch == "" && endEpoch >= uint64(defaultPerformanceHistoryEpochs) {
+		startEpoch = endEpoch - uint64(defaultPerformanceHistoryEpochs) + 1
+	}
+	if startEpoch > endEpoch {
+		httputil.HandleError(w, "start_epoch must not be greater than end_epoch", http.StatusBadRequest)
+		return
+	}
+	if endEpoch-startEpoch >= uint64(maxPerformanceHistoryEpochs) {
+		httputil.HandleError(w, "Epoch range must not exceed "+strconv.FormatUint(uint64(maxPerformanceHistoryEpochs), 10)+" epochs", http.StatusBadRequest)
+		return
+	}
+	rawIndices := r.URL.Query()["index"]
+	indices := make([]primitives.ValidatorIndex, 0, len(rawIndices))
+	for _, raw := range rawIndices {
+		idx, valid := shared.ValidateUint(w, "index", raw)
+		if !valid {
+			return
+		}
+		indices = append(indices, primitives.ValidatorIndex(idx))
+	}
+
+	records, err := s.BeaconDB.ValidatorPerformance(ctx, primitives.Epoch(startEpoch), primitives.Epoch(endEpoch), indices)
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator performance history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.ValidatorEpochPerformance, len(records))
+	for i, rec := range records {
+		data[i] = epochPerformanceToStruct(rec)
+	}
+	httputil.WriteJson(w, &structs.GetValidatorPerformanceHistoryResponse{Data: data})
+}
+
+// GetTrackedValidators returns the indices of the validators tracked by the validator monitor. The set is empty
+// when the validator monitor is not available.
+func (s *Server) GetTrackedValidators(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.GetTrackedValidators")
+	defer span.End()
+
+	var tracked []primitives.ValidatorIndex
+	if s.ValidatorMonitor != nil {
+		tracked = s.ValidatorMonitor.TrackedValidatorIndices()
+	}
+	data := make([]string, len(tracked))
+	for i, idx := range tracked {
+		data[i] = strconv.FormatUint(uint64(idx), 10)
+	}
+	httputil.WriteJson(w, &structs.GetTrackedValidatorsResponse{Data: data})
+}
+
+// AddTrackedValidators adds validators to the set tracked by the validator monitor.
+func (s *Server) AddTrackedValidators(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.AddTrackedValidators")
+	defer span.End()
+
+	if !s.validatorMonitorEnabled(w) {
+		return
+	}
+	var req structs.AddTrackedValidatorsRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Indices) == 0 {
+		httputil.HandleError(w, "No validator indices submitted", http.StatusBadRequest)
+		return
+	}
+	indices := make([]primitives.ValidatorIndex, len(req.Indices))
+	for i, raw := range req.Indices {
+		idx, valid := shared.ValidateUint(w, "indices", raw)
+		if !valid {
+			return
+		}
+		indices[i] = primitives.ValidatorIndex(idx)
+	}
+	if err := s.ValidatorMonitor.TrackValidators(ctx, indices); err != nil {
+		httputil.HandleError(w, "Could not track validators: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+// RemoveTrackedValidator removes a validator from the set tracked by the validator monitor. Its recorded
+// performance history is kept.
+func (s *Server) RemoveTrackedValidator(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.RemoveTrackedValidator")
+	defer span.End()
+
+	if !s.validatorMonitorEnabled(w) {
+		return
+	}
+	_, idx, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return
+	}
+	s.ValidatorMonitor.UntrackValidators([]primitives.ValidatorIndex{primitives.ValidatorIndex(idx)})
+}
+
+func (s *Server) validatorMonitorEnabled(w http.ResponseWriter) bool {
+	if s.ValidatorMonitor == nil {
+		httputil.HandleError(w, "Validator monitor is not available", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+func epochPerformanceToStruct(p *monitortypes.EpochPerformance) *structs.ValidatorEpochPerformance {
+	return &structs.ValidatorEpochPerformance{
+		Epoch:                 strconv.FormatUint(uint64(p.Epoch), 10),
+		ValidatorIndex:        strconv.FormatUint(uint64(p.ValidatorIndex), 10),
+		AttestationExpected:   p.AttestationExpected,
+		AttestationIncluded:   p.AttestationIncluded,
+		InclusionDistance:     strconv.FormatUint(p.InclusionDistance, 10),
+		CorrectSource:         p.CorrectSource,
+		CorrectTarget:         p.CorrectTarget,
+		CorrectHead:           p.CorrectHead,
+		SyncCommitteeExpected: strconv.FormatUint(p.SyncCommitteeExpected, 10),
+		SyncCommitteeIncluded: strconv.FormatUint(p.SyncCommitteeIncluded, 10),
+		ProposalsExpected:     strconv.FormatUint(p.ProposalsExpected, 10),
+		ProposalsIncluded:     strconv.FormatUint(p.ProposalsIncluded, 10),
+		StartBalance:          strconv.FormatUint(p.StartBalance, 10),
+		EndBalance:            strconv.FormatUint(p.EndBalance, 10),
+		BalanceChange:         strconv.FormatInt(p.BalanceChange(), 10),
+		MissedAttestation:     p.MissedAttestation(),
+		MissedSyncCommittee:   strconv.FormatUint(p.MissedSyncCommittee(), 10),
+		MissedProposals:       strconv.FormatUint(p.MissedProposals(), 10),
+	}
+}
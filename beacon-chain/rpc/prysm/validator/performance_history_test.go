@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type trackedValidatorsEditor struct {
+	tracked map[primitives.ValidatorIndex]bool
+}
+
+func (e *trackedValidatorsEditor) TrackedValidatorIndices() []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(e.tracked))
+	for idx := range e.tracked {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func (e *trackedValidatorsEditor) TrackValidators(_ context.Context, indices []primitives.ValidatorIndex) error {
+	for _, idx := range indices {
+		e.tracked[idx] = true
+	}
+	return nil
+}
+
+func (e *trackedValidatorsEditor) UntrackValidators(indices []primitives.ValidatorIndex) {
+	for _, idx := range indices {
+		delete(e.tracked, idx)
+	}
+}
+
+func TestGetValidatorPerformanceHistory(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	require.NoError(t, db.SaveValidatorPerformance(ctx, []*monitortypes.EpochPerformance{
+		{Epoch: 5, ValidatorIndex: 1, AttestationExpected: true, AttestationIncluded: true, InclusionDistance: 1, CorrectSource: true, StartBalance: 100, EndBalance: 110},
+		{Epoch: 5, ValidatorIndex: 2, AttestationExpected: true, ProposalsExpected: 1, StartBalance: 100, EndBalance: 90},
+		{Epoch: 40, ValidatorIndex: 1, AttestationExpected: true, SyncCommitteeExpected: 32, SyncCommitteeIncluded: 30},
+	}))
+	slot := 50 * params.BeaconConfig().SlotsPerEpoch
+	s := &Server{BeaconDB: db, GenesisTimeFetcher: &mock.ChainService{Slot: &slot}}
+
+	get := func(t *testing.T, query string) *structs.GetValidatorPerformanceHistoryResponse {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/performance/history"+query, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorPerformanceHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorPerformanceHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp
+	}
+
+	t.Run("defaults to the last epochs", func(t *testing.T) {
+		resp := get(t, "")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "40", resp.Data[0].Epoch)
+		assert.Equal(t, "2", resp.Data[0].MissedSyncCommittee)
+		assert.Equal(t, true, resp.Data[0].MissedAttestation)
+	})
+	t.Run("range and indices", func(t *testing.T) {
+		resp := get(t, "?start_epoch=0&end_epoch=10")
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].ValidatorIndex)
+		assert.Equal(t, "10", resp.Data[0].BalanceChange)
+		assert.Equal(t, false, resp.Data[0].MissedAttestation)
+		assert.Equal(t, "-10", resp.Data[1].BalanceChange)
+		assert.Equal(t, "1", resp.Data[1].MissedProposals)
+
+		resp = get(t, "?start_epoch=0&index=2")
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "2", resp.Data[0].ValidatorIndex)
+	})
+	t.Run("bad requests", func(t *testing.T) {
+		for _, query := range []string{"?start_epoch=10&end_epoch=5", "?start_epoch=0&end_epoch=2000", "?index=foo"} {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/performance/history"+query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.GetValidatorPerformanceHistory(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, query)
+		}
+	})
+}
+
+func TestTrackedValidators(t *testing.T) {
+	editor := &trackedValidatorsEditor{tracked: map[primitives.ValidatorIndex]bool{3: true}}
+	s := &Server{ValidatorMonitor: editor}
+
+	getTracked := func(t *testing.T) []string {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/performance/tracked", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetTrackedValidators(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetTrackedValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp.Data
+	}
+
+	body := bytes.NewBufferString(`{"indices":["1","5"]}`)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/performance/tracked", body)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.AddTrackedValidators(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.DeepEqual(t, []string{"1", "3", "5"}, getTracked(t))
+
+	request = httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/performance/tracked", bytes.NewBufferString(`{"indices":["x"]}`))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.AddTrackedValidators(writer, request)
+	require.Equal(t, http.StatusBadRequest, writer.Code)
+
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/validators/performance/tracked/3", nil)
+	request = mux.SetURLVars(request, map[string]string{"validator_index": "3"})
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.RemoveTrackedValidator(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.DeepEqual(t, []string{"1", "5"}, getTracked(t))
+
+	t.Run("monitor not available", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/performance/tracked", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetTrackedValidators(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetTrackedValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.DeepEqual(t, []string{}, resp.Data)
+
+		request = httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/performance/tracked", bytes.NewBufferString(`{"indices":["1"]}`))
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.AddTrackedValidators(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
@@ -1,9 +1,15 @@
 package validator
 
 import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 )
 
 type Server struct {
-	CoreService *core.Service
+	CoreService        *core.Service
+	BeaconDB           db.ReadOnlyDatabase
+	GenesisTimeFetcher blockchain.TimeFetcher
+	ValidatorMonitor   monitor.TrackedValidatorsEditor
 }
@@ -0,0 +1,213 @@
+package slasher
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// historicalDetectionLogPeriod is the minimum time between two logs reporting the progress of historical detection.
+const historicalDetectionLogPeriod = time.Minute
+
+// Loads the last checkpoint of slashing detection from the database.
+func (s *Service) loadCheckpoint(ctx context.Context) error {
+	epoch, ok, err := s.serviceCfg.Database.DetectionProgress(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get slashing detection progress")
+	}
+	s.checkpointed = ok
+	s.checkpointedEpoch = epoch
+	return nil
+}
+
+// Records that slashing detection processed every block up to the given epoch, along with the
+// last epoch written for the validators updated since the previous checkpoint, so that detection
+// can resume from there after a restart.
+// This must be called from the routine updating the spans, as it reads the latest epoch written for each validator.
+func (s *Service) checkpoint(ctx context.Context, epoch primitives.Epoch) error {
+	updated := make(map[primitives.ValidatorIndex]primitives.Epoch)
+	for validatorIndex, latest := range s.latestEpochUpdatedForValidator {
+		if !s.checkpointed || latest > s.checkpointedEpoch {
+			updated[validatorIndex] = latest
+		}
+	}
+	if err := s.serviceCfg.Database.SaveLastEpochWrittenForValidators(ctx, updated); err != nil {
+		return errors.Wrap(err, "could not save last epoch written for validators")
+	}
+	if err := s.serviceCfg.Database.SaveDetectionProgress(ctx, epoch); err != nil {
+		return errors.Wrap(err, "could not save slashing detection progress")
+	}
+	s.checkpointed = true
+	s.checkpointedEpoch = epoch
+	return nil
+}
+
+// Checkpoints the progress of slashing detection and prunes stale spans once per epoch. Detection is
+// considered done for an epoch once the next one starts.
+func (s *Service) onEpochProcessed(ctx context.Context, currentEpoch primitives.Epoch) {
+	if currentEpoch == 0 || (s.checkpointed && currentEpoch-1 <= s.checkpointedEpoch) {
+		return
+	}
+	if err := s.checkpoint(ctx, currentEpoch-1); err != nil {
+		log.WithError(err).Error("Could not checkpoint slashing detection")
+	}
+	if err := s.pruneStaleSpans(ctx, currentEpoch); err != nil {
+		log.WithError(err).Error("Could not prune stale spans")
+	}
+}
+
+// Runs slashing detection on the blocks stored in the beacon database, and on the attestations they include,
+// epoch by epoch up to the head epoch. Detection starts after the last checkpoint, or at the start of the
+// history window if there is none, so an interrupted historical detection resumes where it stopped.
+func (s *Service) detectHistoricalSlashings(ctx context.Context) error {
+	headEpoch := slots.ToEpoch(s.serviceCfg.HeadStateFetcher.HeadSlot())
+	epoch := s.historicalDetectionStart(headEpoch)
+	if epoch > headEpoch {
+		return nil
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch": epoch,
+		"headEpoch":  headEpoch,
+	}).Info("Starting historical slashing detection")
+
+	start := time.Now()
+	lastLog := start
+	for ; epoch <= headEpoch; epoch++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.detectSlashingsInEpoch(ctx, epoch); err != nil {
+			return errors.Wrapf(err, "could not detect slashings in epoch %d", epoch)
+		}
+		if err := s.checkpoint(ctx, epoch); err != nil {
+			return err
+		}
+		if time.Since(lastLog) >= historicalDetectionLogPeriod {
+			lastLog = time.Now()
+			log.WithFields(logrus.Fields{
+				"epoch":     epoch,
+				"headEpoch": headEpoch,
+			}).Info("Historical slashing detection in progress")
+		}
+		// The head keeps moving while catching up.
+		if epoch == headEpoch {
+			headEpoch = slots.ToEpoch(s.serviceCfg.HeadStateFetcher.HeadSlot())
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"headEpoch": headEpoch,
+		"elapsed":   time.Since(start),
+	}).Info("Completed historical slashing detection")
+	return nil
+}
+
+// Returns the first epoch historical detection has to process.
+func (s *Service) historicalDetectionStart(headEpoch primitives.Epoch) primitives.Epoch {
+	start := primitives.Epoch(0)
+	if headEpoch >= s.params.historyLength {
+		start = headEpoch - s.params.historyLength + 1
+	}
+	if s.checkpointed && s.checkpointedEpoch+1 > start {
+		start = s.checkpointedEpoch + 1
+	}
+	// Spans cannot be updated for epochs before the latest one written.
+	var latest primitives.Epoch
+	for _, epoch := range s.latestEpochUpdatedForValidator {
+		latest = max(latest, epoch)
+	}
+	if latest > start {
+		log.WithFields(logrus.Fields{
+			"startEpoch":         start,
+			"latestEpochWritten": latest,
+		}).Info("Skipping epochs before the latest epoch written for historical slashing detection")
+		start = latest
+	}
+	return start
+}
+
+// Runs slashing detection on the blocks of the given epoch, and on the attestations they include.
+func (s *Service) detectSlashingsInEpoch(ctx context.Context, epoch primitives.Epoch) error {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return err
+	}
+	endSlot, err := slots.EpochEnd(epoch)
+	if err != nil {
+		return err
+	}
+	blocks, _, err := s.serviceCfg.BeaconDB.Blocks(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not get blocks")
+	}
+
+	headers := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blocks))
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	for _, blk := range blocks {
+		header, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+		if err != nil {
+			return errors.Wrapf(err, "could not get header of block at slot %d", blk.Block().Slot())
+		}
+		headerRoot, err := header.Header.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not get hash tree root of block header")
+		}
+		headers = append(headers, &slashertypes.SignedBlockHeaderWrapper{
+			SignedBeaconBlockHeader: header,
+			HeaderRoot:              headerRoot,
+		})
+
+		for _, att := range blk.Block().Body().Attestations() {
+			attWrapper, err := s.indexedAttestationWrapper(ctx, att)
+			if err != nil {
+				log.WithError(err).WithField("blockSlot", blk.Block().Slot()).Warn("Could not convert block attestation for slashing detection")
+				continue
+			}
+			atts = append(atts, attWrapper)
+		}
+	}
+
+	if len(atts) > 0 {
+		s.processAttestations(ctx, atts, endSlot)
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	proposerSlashings, err := s.detectProposerSlashings(ctx, headers)
+	if err != nil {
+		return errors.Wrap(err, "could not detect proposer slashings")
+	}
+	return s.processProposerSlashings(ctx, proposerSlashings)
+}
+
+func (s *Service) indexedAttestationWrapper(ctx context.Context, att ethpb.Att) (*slashertypes.IndexedAttestationWrapper, error) {
+	targetState, err := s.serviceCfg.AttestationStateFetcher.AttestationTargetState(ctx, att.GetData().Target)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation target state")
+	}
+	committees, err := helpers.AttestationCommittees(ctx, targetState, att)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation committees")
+	}
+	indexedAtt, err := attestation.ConvertToIndexed(ctx, att, committees...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert to indexed attestation")
+	}
+	dataRoot, err := indexedAtt.GetData().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get hash tree root of attestation data")
+	}
+	return &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: indexedAtt,
+		DataRoot:           dataRoot,
+	}, nil
+}
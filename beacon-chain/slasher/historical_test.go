@@ -0,0 +1,104 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_onEpochProcessed_Checkpoints(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	s := &Service{
+		serviceCfg: &ServiceConfig{Database: slasherDB},
+		params:     DefaultParams(),
+		latestEpochUpdatedForValidator: map[primitives.ValidatorIndex]primitives.Epoch{
+			0: 3,
+			1: 5,
+		},
+	}
+	require.NoError(t, s.loadCheckpoint(ctx))
+	require.Equal(t, false, s.checkpointed)
+
+	// Nothing is checkpointed during the first epoch.
+	s.onEpochProcessed(ctx, 0)
+	_, ok, err := slasherDB.DetectionProgress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, false, ok)
+
+	s.onEpochProcessed(ctx, 6)
+	epoch, ok, err := slasherDB.DetectionProgress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	require.Equal(t, primitives.Epoch(5), epoch)
+	written, err := slasherDB.LastEpochWrittenForValidators(ctx, []primitives.ValidatorIndex{0, 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(written))
+
+	// A restarted service resumes from the checkpoint.
+	restarted := &Service{
+		serviceCfg: &ServiceConfig{Database: slasherDB},
+		params:     DefaultParams(),
+	}
+	require.NoError(t, restarted.loadCheckpoint(ctx))
+	require.Equal(t, true, restarted.checkpointed)
+	require.Equal(t, primitives.Epoch(5), restarted.checkpointedEpoch)
+}
+
+func TestService_historicalDetectionStart(t *testing.T) {
+	params := DefaultParams()
+	params.historyLength = 10
+	tests := []struct {
+		name         string
+		checkpointed bool
+		checkpoint   primitives.Epoch
+		latest       map[primitives.ValidatorIndex]primitives.Epoch
+		headEpoch    primitives.Epoch
+		want         primitives.Epoch
+	}{
+		{
+			name:      "head within first window",
+			headEpoch: 5,
+			want:      0,
+		},
+		{
+			name:      "start of history window",
+			headEpoch: 25,
+			want:      16,
+		},
+		{
+			name:         "resumes after checkpoint",
+			checkpointed: true,
+			checkpoint:   20,
+			headEpoch:    25,
+			want:         21,
+		},
+		{
+			name:         "checkpoint before history window",
+			checkpointed: true,
+			checkpoint:   3,
+			headEpoch:    25,
+			want:         16,
+		},
+		{
+			name:      "latest epoch written",
+			latest:    map[primitives.ValidatorIndex]primitives.Epoch{0: 18, 1: 22},
+			headEpoch: 25,
+			want:      22,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				params:                         params,
+				checkpointed:                   tt.checkpointed,
+				checkpointedEpoch:              tt.checkpoint,
+				latestEpochUpdatedForValidator: tt.latest,
+			}
+			require.Equal(t, tt.want, s.historicalDetectionStart(tt.headEpoch))
+		})
+	}
+}
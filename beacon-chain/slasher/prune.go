@@ -0,0 +1,139 @@
+package slasher
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+// PruneResult counts the slasher data deleted by PruneDatabase.
+type PruneResult struct {
+	Attestations uint
+	Proposals    uint
+	SpanChunks   uint
+}
+
+// PruneDatabase deletes the slasher data outside of the history window ending at currentEpoch:
+// attestations, proposals, and the min and max span chunks of the validators which did not attest
+// within the window. latestEpochs holds the last epoch written for each validator.
+// It is meant to be used on a database which is not in use by a beacon node.
+func PruneDatabase(
+	ctx context.Context,
+	database db.SlasherDatabase,
+	params *Parameters,
+	latestEpochs map[primitives.ValidatorIndex]primitives.Epoch,
+	currentEpoch primitives.Epoch,
+) (*PruneResult, error) {
+	result := &PruneResult{}
+	if currentEpoch < params.historyLength {
+		return result, nil
+	}
+	maxPruningEpoch := currentEpoch - params.historyLength
+
+	var err error
+	if result.Attestations, err = database.PruneAttestationsAtEpoch(ctx, maxPruningEpoch); err != nil {
+		return nil, errors.Wrap(err, "could not prune attestations")
+	}
+	if result.Proposals, err = database.PruneProposalsAtEpoch(ctx, maxPruningEpoch); err != nil {
+		return nil, errors.Wrap(err, "could not prune proposals")
+	}
+	validatorChunkIndexes := params.staleValidatorChunkIndexes(latestEpochs, 0, maxPruningEpoch)
+	if result.SpanChunks, err = pruneSpanChunks(ctx, database, params, validatorChunkIndexes); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Prunes the min and max span chunks of the validator chunks which were last written before the history
+// window. Only the validator chunks whose last written epoch left the window since the previous pruning are
+// considered, except for the first pruning after start up which considers all of them.
+// This must be called from the routine updating the spans, as it reads the latest epoch written for each validator.
+func (s *Service) pruneStaleSpans(ctx context.Context, currentEpoch primitives.Epoch) error {
+	if currentEpoch < s.params.historyLength {
+		return nil
+	}
+	maxPruningEpoch := currentEpoch - s.params.historyLength
+	minPruningEpoch := primitives.Epoch(0)
+	if s.spansPruned {
+		if maxPruningEpoch <= s.spansPrunedUntil {
+			return nil
+		}
+		minPruningEpoch = s.spansPrunedUntil + 1
+	}
+
+	validatorChunkIndexes := s.params.staleValidatorChunkIndexes(s.latestEpochUpdatedForValidator, minPruningEpoch, maxPruningEpoch)
+	numPruned, err := pruneSpanChunks(ctx, s.serviceCfg.Database, s.params, validatorChunkIndexes)
+	if err != nil {
+		return err
+	}
+	s.spansPruned = true
+	s.spansPrunedUntil = maxPruningEpoch
+
+	if numPruned > 0 {
+		log.WithFields(logrus.Fields{
+			"currentEpoch":        currentEpoch,
+			"numValidatorChunks":  len(validatorChunkIndexes),
+			"numPrunedSpanChunks": numPruned,
+		}).Info("Pruned span chunks outside of the history window")
+	}
+	return nil
+}
+
+// Deletes the min and max span chunks of the specified validator chunk indexes.
+func pruneSpanChunks(
+	ctx context.Context, database db.SlasherDatabase, params *Parameters, validatorChunkIndexes []uint64,
+) (uint, error) {
+	if len(validatorChunkIndexes) == 0 {
+		return 0, nil
+	}
+	width := uint64(params.historyLength.Div(params.chunkSize))
+	chunkKeys := make([][]byte, 0, uint64(len(validatorChunkIndexes))*width)
+	for _, validatorChunkIndex := range validatorChunkIndexes {
+		for chunkIndex := uint64(0); chunkIndex < width; chunkIndex++ {
+			chunkKeys = append(chunkKeys, params.flatSliceID(validatorChunkIndex, chunkIndex))
+		}
+	}
+
+	var numPruned uint
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		n, err := database.DeleteSlasherChunks(ctx, kind, chunkKeys)
+		if err != nil {
+			return numPruned, errors.Wrapf(err, "could not delete %s chunks", kind)
+		}
+		numPruned += n
+	}
+	return numPruned, nil
+}
+
+// Given the latest epoch written for each validator, returns in ascending order the validator chunk indexes
+// whose spans were last written within [minEpoch, maxEpoch]. As the spans of all the validators of a chunk are
+// written together, a chunk last written at or before current_epoch - HISTORY_LENGTH only holds values for
+// epochs outside of the history window. Such values are always overwritten before being read again, so the
+// chunks can be deleted.
+func (p *Parameters) staleValidatorChunkIndexes(
+	latestEpochs map[primitives.ValidatorIndex]primitives.Epoch, minEpoch, maxEpoch primitives.Epoch,
+) []uint64 {
+	latestByValidatorChunk := make(map[uint64]primitives.Epoch)
+	for validatorIndex, epoch := range latestEpochs {
+		validatorChunkIndex := p.validatorChunkIndex(validatorIndex)
+		if latest, ok := latestByValidatorChunk[validatorChunkIndex]; !ok || epoch > latest {
+			latestByValidatorChunk[validatorChunkIndex] = epoch
+		}
+	}
+
+	validatorChunkIndexes := make([]uint64, 0)
+	for validatorChunkIndex, latest := range latestByValidatorChunk {
+		if latest >= minEpoch && latest <= maxEpoch {
+			validatorChunkIndexes = append(validatorChunkIndexes, validatorChunkIndex)
+		}
+	}
+	sort.Slice(validatorChunkIndexes, func(i, j int) bool {
+		return validatorChunkIndexes[i] < validatorChunkIndexes[j]
+	})
+	return validatorChunkIndexes
+}
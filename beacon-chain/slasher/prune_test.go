@@ -0,0 +1,112 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestParameters_staleValidatorChunkIndexes(t *testing.T) {
+	// 2 validators per validator chunk.
+	params := NewParams(2, 2, 4)
+	latestEpochs := map[primitives.ValidatorIndex]primitives.Epoch{
+		0: 1, 1: 5, // Validator chunk 0.
+		2: 2, 3: 2, // Validator chunk 1.
+		4: 3, // Validator chunk 2.
+		6: 6, // Validator chunk 3.
+	}
+	require.DeepEqual(t, []uint64{1, 2}, params.staleValidatorChunkIndexes(latestEpochs, 0, 3))
+	require.DeepEqual(t, []uint64{2}, params.staleValidatorChunkIndexes(latestEpochs, 3, 4))
+	require.DeepEqual(t, []uint64{}, params.staleValidatorChunkIndexes(latestEpochs, 7, 10))
+}
+
+func TestService_pruneStaleSpans(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	params := NewParams(2, 2, 4)
+	s := &Service{
+		serviceCfg: &ServiceConfig{Database: slasherDB},
+		params:     params,
+		latestEpochUpdatedForValidator: map[primitives.ValidatorIndex]primitives.Epoch{
+			0: 1, // Validator chunk 0.
+			2: 6, // Validator chunk 1.
+		},
+	}
+	chunkKeys := saveSpanChunks(t, slasherDB, params, 0, 1)
+
+	// Nothing is outside of the history window yet.
+	require.NoError(t, s.pruneStaleSpans(ctx, 4))
+	requireSpanChunks(t, slasherDB, chunkKeys, []bool{true, true, true, true})
+
+	require.NoError(t, s.pruneStaleSpans(ctx, 6))
+	requireSpanChunks(t, slasherDB, chunkKeys, []bool{false, false, true, true})
+	require.Equal(t, true, s.spansPruned)
+	require.Equal(t, primitives.Epoch(2), s.spansPrunedUntil)
+
+	// Validator chunks which left the window before the previous pruning are not considered again.
+	chunkKeys = saveSpanChunks(t, slasherDB, params, 0)
+	require.NoError(t, s.pruneStaleSpans(ctx, 7))
+	requireSpanChunks(t, slasherDB, chunkKeys, []bool{true, true})
+}
+
+func TestPruneDatabase(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	params := NewParams(2, 2, 4)
+	chunkKeys := saveSpanChunks(t, slasherDB, params, 0, 1)
+	require.NoError(t, slasherDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapperEmptySig(t, 0, 1, []uint64{0}, bytesutil.PadTo([]byte("1a"), 32)),
+		createAttestationWrapperEmptySig(t, 5, 6, []uint64{2}, bytesutil.PadTo([]byte("6a"), 32)),
+	}))
+	latestEpochs := map[primitives.ValidatorIndex]primitives.Epoch{0: 1, 2: 6}
+
+	// Nothing is pruned before the history window starts.
+	result, err := PruneDatabase(ctx, slasherDB, params, latestEpochs, 3)
+	require.NoError(t, err)
+	require.DeepEqual(t, &PruneResult{}, result)
+
+	result, err = PruneDatabase(ctx, slasherDB, params, latestEpochs, 6)
+	require.NoError(t, err)
+	require.Equal(t, uint(1), result.Attestations)
+	require.Equal(t, uint(4), result.SpanChunks)
+	requireSpanChunks(t, slasherDB, chunkKeys, []bool{false, false, true, true})
+
+	att, err := slasherDB.AttestationRecordForValidator(ctx, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, true, att == nil)
+	att, err = slasherDB.AttestationRecordForValidator(ctx, 2, 6)
+	require.NoError(t, err)
+	require.NotNil(t, att)
+}
+
+// Saves min and max span chunks for every chunk index of the given validator chunks, and returns their keys.
+func saveSpanChunks(t *testing.T, database db.SlasherDatabase, params *Parameters, validatorChunkIndexes ...uint64) [][]byte {
+	width := uint64(params.historyLength.Div(params.chunkSize))
+	chunkKeys := make([][]byte, 0)
+	chunks := make([][]uint16, 0)
+	for _, validatorChunkIndex := range validatorChunkIndexes {
+		for chunkIndex := uint64(0); chunkIndex < width; chunkIndex++ {
+			chunkKeys = append(chunkKeys, params.flatSliceID(validatorChunkIndex, chunkIndex))
+			chunks = append(chunks, make([]uint16, params.chunkSize*params.validatorChunkSize))
+		}
+	}
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		require.NoError(t, database.SaveSlasherChunks(context.Background(), kind, chunkKeys, chunks))
+	}
+	return chunkKeys
+}
+
+// Checks which of the min and max span chunks with the given keys exist in the database.
+func requireSpanChunks(t *testing.T, database db.SlasherDatabase, chunkKeys [][]byte, want []bool) {
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		_, exists, err := database.LoadSlasherChunks(context.Background(), kind, chunkKeys)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, exists)
+	}
+}
@@ -98,6 +98,9 @@ func (s *Service) processQueuedAttestations(ctx context.Context, slotTicker <-ch
 
 			// Process the retrieved attestations.
 			s.processAttestations(ctx, attestations, currentSlot)
+
+			// Checkpoint the detection progress and prune stale spans when a new epoch starts.
+			s.onEpochProcessed(ctx, slots.ToEpoch(currentSlot))
 		case <-ctx.Done():
 			return
 		}
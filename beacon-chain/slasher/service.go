@@ -42,6 +42,10 @@ type ServiceConfig struct {
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             beaconChainSync.Checker
 	ClockWaiter             startup.ClockWaiter
+	BeaconDB                db.ReadOnlyDatabase
+	// HistoricalDetection enables slashing detection on the blocks of the history window
+	// stored in BeaconDB, before detecting slashings on the incoming data.
+	HistoricalDetection bool
 }
 
 // Service defining a slasher implementation as part of
@@ -60,6 +64,10 @@ type Service struct {
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochUpdatedForValidator map[primitives.ValidatorIndex]primitives.Epoch
+	checkpointed                   bool
+	checkpointedEpoch              primitives.Epoch
+	spansPruned                    bool
+	spansPrunedUntil               primitives.Epoch
 	wg                             sync.WaitGroup
 }
 
@@ -141,6 +149,22 @@ func (s *Service) run() {
 	}
 	// End of section that can be removed once Electra is on mainnet.
 
+	if err := s.loadCheckpoint(s.ctx); err != nil {
+		log.WithError(err).Error("Failed to load slashing detection checkpoint")
+		return
+	}
+	if s.serviceCfg.HistoricalDetection {
+		s.wg.Add(1)
+		err := s.detectHistoricalSlashings(s.ctx)
+		s.wg.Done()
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.WithError(err).Error("Could not complete historical slashing detection")
+		}
+	}
+
 	s.wg.Add(1)
 	go s.receiveAttestations(s.ctx, indexedAttsChan)
 
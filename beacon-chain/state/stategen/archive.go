@@ -0,0 +1,182 @@
+package stategen
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// WithStateArchive enables the historical state archive. In archive mode the state of the last block of every
+// finalized epoch is saved to the DB as a diff against a recent full state, so that any historical state can be
+// loaded by replaying at most one epoch of blocks.
+func WithStateArchive() Option {
+	return func(sg *State) {
+		sg.archive = &stateArchive{wake: make(chan struct{}, 1)}
+	}
+}
+
+// stateArchive signals the archiving routine that the finalized checkpoint has advanced.
+type stateArchive struct {
+	wake chan struct{}
+}
+
+func (a *stateArchive) notify() {
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runArchive archives the finalized states every time finality advances. The first run also archives
+// the history of an existing database, which is resumed from the last archived state after a restart.
+func (s *State) runArchive(ctx context.Context) {
+	for {
+		s.finalizedInfo.lock.RLock()
+		fSlot := s.finalizedInfo.slot
+		s.finalizedInfo.lock.RUnlock()
+		if err := s.archiveStates(ctx, fSlot); err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("Could not archive historical states")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.archive.wake:
+		}
+	}
+}
+
+// archiveStates saves the state of the last block of every epoch up to the finalized slot. States are
+// saved as diffs against the last full state, and a new full state is saved once the diff base is
+// slotsPerArchivedPoint slots behind or the state crosses a fork boundary.
+func (s *State) archiveStates(ctx context.Context, fSlot primitives.Slot) error {
+	root, baseRoot, err := s.beaconDB.StateArchiveProgress(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		root, err = s.archiveStartRoot(ctx)
+		if err != nil {
+			return err
+		}
+		baseRoot = root
+		if err := s.beaconDB.SaveStateArchiveProgress(ctx, root, baseRoot); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "could not get state archive progress")
+	}
+
+	st, err := s.beaconDB.StateOrError(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get last archived state")
+	}
+	baseBlock, err := s.beaconDB.Block(ctx, baseRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get block of archive base state")
+	}
+	baseSlot, baseVersion := baseBlock.Block().Slot(), baseBlock.Version()
+
+	start := time.Now()
+	startSlot := st.Slot()
+	archived := 0
+	epochStart, err := slots.EpochStart(slots.ToEpoch(st.Slot()) + 1)
+	if err != nil {
+		return err
+	}
+	for ; epochStart <= fSlot; epochStart += params.BeaconConfig().SlotsPerEpoch {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		next, ok, err := s.finalizedRootInRange(ctx, st.Slot(), epochStart)
+		if err != nil {
+			return err
+		}
+		// There is no new block in this epoch, the last archived state still applies.
+		if !ok {
+			continue
+		}
+		blk, err := s.beaconDB.Block(ctx, next)
+		if err != nil {
+			return err
+		}
+		blkSlot := blk.Block().Slot()
+		blks, err := s.loadBlocks(ctx, st.Slot()+1, blkSlot, next)
+		if err != nil {
+			return errors.Wrap(err, "could not load blocks for archived state")
+		}
+		st, err = s.replayBlocks(ctx, st, blks, blkSlot)
+		if err != nil {
+			return errors.Wrap(err, "could not replay blocks for archived state")
+		}
+
+		if !s.beaconDB.HasState(ctx, next) {
+			saveFull := st.Version() != baseVersion || blkSlot-baseSlot >= s.slotsPerArchivedPoint
+			if !saveFull {
+				err = s.beaconDB.SaveStateDiff(ctx, st, next, baseRoot)
+				// The base state may have been removed before any diff was computed against it.
+				saveFull = errors.Is(err, db.ErrNotFoundState)
+				if err != nil && !saveFull {
+					return errors.Wrap(err, "could not save state diff")
+				}
+			}
+			if saveFull {
+				if err := s.beaconDB.SaveState(ctx, st.Copy(), next); err != nil {
+					return errors.Wrap(err, "could not save archive base state")
+				}
+				baseRoot, baseSlot, baseVersion = next, blkSlot, st.Version()
+			}
+			archived++
+		}
+		root = next
+		if err := s.beaconDB.SaveStateArchiveProgress(ctx, root, baseRoot); err != nil {
+			return err
+		}
+	}
+	if archived > 0 {
+		log.WithFields(logrus.Fields{
+			"startSlot": startSlot,
+			"endSlot":   st.Slot(),
+			"count":     archived,
+			"duration":  time.Since(start),
+		}).Info("Archived historical states")
+	}
+	return nil
+}
+
+// archiveStartRoot returns the root of the earliest state available in the DB, which is the
+// origin checkpoint state for checkpoint synced nodes and the genesis state otherwise.
+func (s *State) archiveStartRoot(ctx context.Context) ([32]byte, error) {
+	root, err := s.beaconDB.OriginCheckpointBlockRoot(ctx)
+	if err == nil {
+		return root, nil
+	}
+	if !errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return [32]byte{}, err
+	}
+	return s.beaconDB.GenesisBlockRoot(ctx)
+}
+
+// finalizedRootInRange returns the root of the highest finalized block with a slot in the (floor, slot] range,
+// skipping blocks of abandoned forks.
+func (s *State) finalizedRootInRange(ctx context.Context, floor, slot primitives.Slot) ([32]byte, bool, error) {
+	slotAbove := slot + 1
+	for slotAbove > floor+1 {
+		found, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slotAbove)
+		if err != nil {
+			return [32]byte{}, false, err
+		}
+		if found <= floor || len(roots) == 0 {
+			break
+		}
+		for _, r := range roots {
+			if s.beaconDB.IsFinalizedBlock(ctx, r) {
+				return r, true, nil
+			}
+		}
+		slotAbove = found
+	}
+	return [32]byte{}, false, nil
+}
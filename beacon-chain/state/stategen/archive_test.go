@@ -0,0 +1,130 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestArchiveStates(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB, doublylinkedtree.New(), WithStateArchive())
+	service.slotsPerArchivedPoint = 2 * params.BeaconConfig().SlotsPerEpoch
+
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, beaconState, gRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	// Build a chain with a block every fourth slot.
+	stateRoots := make(map[[32]byte][32]byte)
+	var roots [][32]byte
+	lastSlot := 4 * params.BeaconConfig().SlotsPerEpoch
+	for slot := primitives.Slot(4); slot <= lastSlot; slot += 4 {
+		b, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		beaconState, err = executeStateTransitionStateGen(ctx, beaconState, wsb)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		stateRoots[r], err = beaconState.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		roots = append(roots, r)
+	}
+	fRoot := roots[len(roots)-1]
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(lastSlot), Root: fRoot[:]}))
+
+	require.NoError(t, service.archiveStates(ctx, lastSlot))
+	require.LogsContain(t, hook, "Archived historical states")
+
+	// The last block of every epoch has a state in the DB.
+	for i, r := range roots {
+		slot := primitives.Slot(4 * (i + 1))
+		if slot%params.BeaconConfig().SlotsPerEpoch != 0 {
+			continue
+		}
+		require.Equal(t, true, beaconDB.HasState(ctx, r), "no archived state for slot %d", slot)
+		st, err := beaconDB.StateOrError(ctx, r)
+		require.NoError(t, err)
+		got, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, stateRoots[r], got)
+	}
+
+	// States between epochs are replayed from the archived state of the previous epoch.
+	r := roots[len(roots)/2+2]
+	st, err := service.StateByRoot(ctx, r)
+	require.NoError(t, err)
+	got, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stateRoots[r], got)
+
+	archived, _, err := beaconDB.StateArchiveProgress(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, fRoot, archived)
+
+	// Archiving resumes from the saved progress.
+	hook.Reset()
+	require.NoError(t, service.archiveStates(ctx, lastSlot))
+	require.LogsDoNotContain(t, hook, "Archived historical states")
+}
+
+func TestFinalizedRootInRange(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB, doublylinkedtree.New())
+
+	genesis := util.NewBeaconBlock()
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	canonical := util.NewBeaconBlock()
+	canonical.Block.Slot = 5
+	canonical.Block.ParentRoot = gRoot[:]
+	cRoot, err := canonical.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, canonical)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 5, Root: cRoot[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: cRoot[:]}))
+
+	// A block of an abandoned fork at a higher slot is skipped.
+	orphan := util.NewBeaconBlock()
+	orphan.Block.Slot = 7
+	orphan.Block.ParentRoot = bytesutil.PadTo([]byte{'o'}, 32)
+	util.SaveBlock(t, ctx, beaconDB, orphan)
+
+	r, ok, err := service.finalizedRootInRange(ctx, 0, 8)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+	assert.Equal(t, cRoot, r)
+
+	_, ok, err = service.finalizedRootInRange(ctx, 5, 8)
+	require.NoError(t, err)
+	assert.Equal(t, false, ok)
+}
@@ -117,6 +117,9 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 	if ok {
 		s.SaveFinalizedState(fSlot, fRoot, fInfo.state)
 	}
+	if s.archive != nil {
+		s.archive.notify()
+	}
 
 	return nil
 }
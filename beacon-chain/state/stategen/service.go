@@ -56,6 +56,7 @@ type State struct {
 	avb                     coverage.AvailableBlocker
 	migrationLock           *sync.Mutex
 	fc                      forkchoice.ForkChoicer
+	archive                 *stateArchive
 }
 
 // This tracks the config in the event of long non-finality,
@@ -121,6 +122,9 @@ func (s *State) Resume(ctx context.Context, fState state.BeaconState) (state.Bea
 		return nil, err
 	}
 	fRoot := bytesutil.ToBytes32(c.Root)
+	if s.archive != nil {
+		go s.runArchive(ctx)
+	}
 	// Resume as genesis state if last finalized root is zero hashes.
 	if fRoot == params.BeaconConfig().ZeroHash {
 		st, err := s.beaconDB.GenesisState(ctx)
@@ -139,15 +139,16 @@ func (b batch) blobRequest() *eth.BlobSidecarsByRangeRequest {
 func (b batch) withResults(results verifiedROBlocks, bs *blobSync) batch {
 	b.results = results
 	b.bs = bs
-	if bs.blobsNeeded() > 0 {
+	if bs.blobsNeeded() > 0 || bs.columnsNeeded() > 0 {
 		return b.withState(batchBlobSync)
 	}
 	return b.withState(batchImportable)
 }
 
 func (b batch) postBlobSync() batch {
-	if b.blobsNeeded() > 0 {
-		log.WithFields(b.logFields()).WithField("blobsMissing", b.blobsNeeded()).Error("Batch still missing blobs after downloading from peer")
+	if b.blobsNeeded() > 0 || b.bs.columnsNeeded() > 0 {
+		log.WithFields(b.logFields()).WithField("blobsMissing", b.blobsNeeded()).WithField("columnsMissing", b.bs.columnsNeeded()).
+			Error("Batch still missing blobs after downloading from peer")
 		b.bs = nil
 		b.results = []blocks.ROBlock{}
 		return b.withState(batchErrRetryable)
@@ -32,6 +32,7 @@ type blobSyncConfig struct {
 	retentionStart primitives.Slot
 	nbv            verification.NewBlobVerifier
 	store          *filesystem.BlobStorage
+	columns        *columnSyncConfig
 }
 
 func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncConfig) (*blobSync, error) {
@@ -41,7 +42,14 @@ func newBlobSync(current primitives.Slot, vbs verifiedROBlocks, cfg *blobSyncCon
 	}
 	bbv := newBlobBatchVerifier(cfg.nbv)
 	as := das.NewLazilyPersistentStore(cfg.store, bbv)
-	return &blobSync{current: current, expected: expected, bbv: bbv, store: as}, nil
+	if cfg.columns == nil {
+		return &blobSync{current: current, expected: expected, bbv: bbv, store: as}, nil
+	}
+	cs, err := newColumnSync(current, vbs, cfg.columns, as)
+	if err != nil {
+		return nil, err
+	}
+	return &blobSync{current: current, expected: expected, bbv: bbv, store: cs.store, columns: cs}, nil
 }
 
 type blobVerifierMap map[[32]byte][fieldparams.MaxBlobsPerBlock]verification.BlobVerifier
@@ -52,12 +60,20 @@ type blobSync struct {
 	next     int
 	bbv      *blobBatchVerifier
 	current  primitives.Slot
+	columns  *columnSync
 }
 
 func (bs *blobSync) blobsNeeded() int {
 	return len(bs.expected) - bs.next
 }
 
+func (bs *blobSync) columnsNeeded() int {
+	if bs.columns == nil {
+		return 0
+	}
+	return bs.columns.columnsNeeded()
+}
+
 func (bs *blobSync) validateNext(rb blocks.ROBlob) error {
 	if bs.next >= len(bs.expected) {
 		return errUnexpectedResponseSize
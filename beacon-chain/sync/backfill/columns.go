@@ -0,0 +1,149 @@
+package backfill
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var errUnexpectedColumn = errors.New("DataColumnSidecar response does not match the expected blocks")
+
+// columnSyncConfig holds what backfill needs to make blocks from the EIP-7594 fork onwards available.
+type columnSyncConfig struct {
+	retentionStart primitives.Slot
+	store          *filesystem.DataColumnStorage
+	custody        map[uint64]bool
+}
+
+// columnSync is the data column counterpart of blobSync. It tracks the custodied columns still missing for the
+// blocks of a batch, and stashes the ones received in the batch AvailabilityStore.
+type columnSync struct {
+	store   *das.LazilyPersistentStoreColumn
+	current primitives.Slot
+	custody map[uint64]bool
+	blocks  map[[32]byte]blocks.ROBlock
+	missing map[[32]byte]map[uint64]bool
+	low     primitives.Slot
+	high    primitives.Slot
+}
+
+func newColumnSync(current primitives.Slot, vbs verifiedROBlocks, cfg *columnSyncConfig, blobs das.AvailabilityStore) (*columnSync, error) {
+	cs := &columnSync{
+		store:   das.NewLazilyPersistentStoreColumn(cfg.store, verification.NewDataColumnBatchVerifier(), cfg.custody, blobs),
+		current: current,
+		custody: cfg.custody,
+		blocks:  make(map[[32]byte]blocks.ROBlock),
+		missing: make(map[[32]byte]map[uint64]bool),
+	}
+	for i := range vbs {
+		slot := vbs[i].Block().Slot()
+		if slot < cfg.retentionStart || vbs[i].Version() < version.Deneb || !params.PeerDASEnabled(slots.ToEpoch(slot)) {
+			continue
+		}
+		c, err := vbs[i].Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected error checking commitments for block root %#x", vbs[i].Root())
+		}
+		if len(c) == 0 {
+			continue
+		}
+		var sum filesystem.DataColumnStorageSummary
+		if cfg.store != nil {
+			sum = cfg.store.Summary(vbs[i].Root())
+		}
+		missing := make(map[uint64]bool, len(cfg.custody))
+		for idx := range cfg.custody {
+			if !sum.HasIndex(idx) {
+				missing[idx] = true
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if len(cs.blocks) == 0 || slot < cs.low {
+			cs.low = slot
+		}
+		if slot > cs.high {
+			cs.high = slot
+		}
+		cs.blocks[vbs[i].Root()] = vbs[i]
+		cs.missing[vbs[i].Root()] = missing
+	}
+	return cs, nil
+}
+
+func (cs *columnSync) columnsNeeded() int {
+	n := 0
+	for _, m := range cs.missing {
+		n += len(m)
+	}
+	return n
+}
+
+// missingColumns returns the sorted indices of the columns still missing for at least one block which are also
+// custodied by a peer with the given custody set.
+func (cs *columnSync) missingColumns(peerCustody map[uint64]bool) []uint64 {
+	columns := make([]uint64, 0)
+	for idx := uint64(0); idx < fieldparams.NumberOfColumns; idx++ {
+		if !peerCustody[idx] {
+			continue
+		}
+		for _, m := range cs.missing {
+			if m[idx] {
+				columns = append(columns, idx)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+func (cs *columnSync) request(columns []uint64) *eth.DataColumnSidecarsByRangeRequest {
+	return &eth.DataColumnSidecarsByRangeRequest{
+		StartSlot: cs.low,
+		Count:     uint64(cs.high.FlooredSubSlot(cs.low)) + 1,
+		Columns:   columns,
+	}
+}
+
+// add checks that the columns belong to the blocks of the batch, then stashes them in the AvailabilityStore.
+// Columns that were not needed are ignored.
+func (cs *columnSync) add(dcs []blocks.RODataColumn) error {
+	for _, dc := range dcs {
+		root := dc.BlockRoot()
+		if !cs.missing[root][dc.Index] {
+			continue
+		}
+		blk := cs.blocks[root]
+		c, err := blk.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return errors.Wrapf(errUnexpectedCommitment, "error reading commitments from block root %#x", root)
+		}
+		if dc.Slot() != blk.Block().Slot() || len(c) != len(dc.KzgCommitments) {
+			return errors.Wrapf(errUnexpectedColumn, "column %d does not match block root %#x", dc.Index, root)
+		}
+		for i := range c {
+			if !bytes.Equal(c[i], dc.KzgCommitments[i]) {
+				return errors.Wrapf(errUnexpectedCommitment, "column %d commitment %#x != block commitment %#x for root %#x", dc.Index, dc.KzgCommitments[i], c[i], root)
+			}
+		}
+		if err := cs.store.PersistColumns(cs.current, dc); err != nil {
+			return err
+		}
+		delete(cs.missing[root], dc.Index)
+		if len(cs.missing[root]) == 0 {
+			delete(cs.missing, root)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package backfill
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestColumnSync(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	start := params.BeaconConfig().SlotsPerEpoch
+	current := start + 10
+	custody := map[uint64]bool{5: true, 90: true}
+	pre, preBlobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, start-1, 2)
+	first, firstColumns := util.GenerateTestDenebBlockWithColumns(t, pre.Root(), start, 2)
+	second, secondColumns := util.GenerateTestDenebBlockWithColumns(t, first.Root(), start+2, 1)
+	blks := verifiedROBlocks{pre, first, second}
+
+	store := filesystem.NewEphemeralDataColumnStorage(t)
+	require.NoError(t, store.Save(blocks.NewVerifiedRODataColumn(secondColumns[90])))
+	bsync, err := newBlobSync(current, blks, &blobSyncConfig{
+		nbv:     testNewBlobVerifier(),
+		store:   filesystem.NewEphemeralBlobStorage(t),
+		columns: &columnSyncConfig{store: store, custody: custody},
+	})
+	require.NoError(t, err)
+	// Blobs are only expected for the block from before the EIP-7594 fork.
+	require.Equal(t, len(preBlobs), bsync.blobsNeeded())
+	// The column already on disk is not requested again.
+	require.Equal(t, 3, bsync.columnsNeeded())
+
+	cs := bsync.columns
+	require.DeepEqual(t, []uint64{90}, cs.missingColumns(map[uint64]bool{90: true, 100: true}))
+	req := cs.request([]uint64{5, 90})
+	require.Equal(t, start, req.StartSlot)
+	require.Equal(t, uint64(3), req.Count)
+
+	_, other := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, start, 1)
+	bad, err := blocks.NewRODataColumnWithRoot(other[5].DataColumnSidecar, first.Root())
+	require.NoError(t, err)
+	require.ErrorIs(t, cs.add([]blocks.RODataColumn{bad}), errUnexpectedColumn)
+	require.Equal(t, 3, bsync.columnsNeeded())
+
+	// Columns which are not needed are ignored.
+	require.NoError(t, cs.add([]blocks.RODataColumn{firstColumns[5], firstColumns[6], secondColumns[90]}))
+	require.Equal(t, 2, bsync.columnsNeeded())
+	require.NoError(t, cs.add([]blocks.RODataColumn{firstColumns[90], secondColumns[5]}))
+	require.Equal(t, 0, bsync.columnsNeeded())
+
+	t.Run("outside of retention", func(t *testing.T) {
+		bsync, err := newBlobSync(current, blks, &blobSyncConfig{
+			nbv:     testNewBlobVerifier(),
+			store:   filesystem.NewEphemeralBlobStorage(t),
+			columns: &columnSyncConfig{retentionStart: start + primitives.Slot(1), store: store, custody: custody},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, bsync.columnsNeeded())
+	})
+}
@@ -0,0 +1,116 @@
+package backfill
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+var errGenesisSync = errors.New("node was synced from genesis, there is no history to backfill")
+
+// ImportEra backfills the blocks between the lowest backfilled block and the minimum slot from the era files in
+// the given directory. Blocks are verified against the proposer keys of the origin state, and must chain up to the
+// lowest backfilled block. Import stops at the first missing era file, and before blocks that would also need
+// blobs, which are left to be backfilled from peers. It returns the number of imported blocks.
+func ImportEra(ctx context.Context, su *Store, dir string, minimum, current primitives.Slot) (int, error) {
+	if su.isGenesisSync() {
+		return 0, errGenesisSync
+	}
+	v, _, err := newOriginVerifier(ctx, su)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not initialize backfill verifier")
+	}
+	return fillFromEra(ctx, su, v, dir, minimum, current)
+}
+
+func fillFromEra(ctx context.Context, su *Store, v *verifier, dir string, minimum, current primitives.Slot) (int, error) {
+	retentionStart, err := sync.BlobRPCMinValidSlot(current)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not compute minimum blob retention slot")
+	}
+	files, err := era.ListFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+	// The genesis block has no valid signature and is never backfilled.
+	minimum = max(minimum, 1)
+	imported := 0
+	for {
+		if ctx.Err() != nil {
+			return imported, ctx.Err()
+		}
+		low := primitives.Slot(su.status().LowSlot)
+		if low <= minimum {
+			return imported, nil
+		}
+		n := era.ForSlot(low - 1)
+		path, ok := files[n]
+		if !ok {
+			log.WithField("era", n).Info("No era file to backfill from")
+			return imported, nil
+		}
+		blks, err := readEraBlocks(path, minimum, low)
+		if err != nil {
+			return imported, err
+		}
+		if len(blks) == 0 {
+			return imported, errors.Errorf("era file %s has no block below slot %d", path, low)
+		}
+		vbs, err := v.verify(blks)
+		if err != nil {
+			return imported, errors.Wrapf(err, "could not verify blocks of era file %s", path)
+		}
+		blobs, err := vbs.blobIdents(retentionStart)
+		if err != nil {
+			return imported, err
+		}
+		if len(blobs) > 0 {
+			log.WithField("era", n).Info("Stopping era backfill at blocks within the blob retention period")
+			return imported, nil
+		}
+		if _, err := su.fillBack(ctx, current, vbs, noBlobsAvailabilityStore{}); err != nil {
+			return imported, errors.Wrapf(err, "could not import blocks of era file %s", path)
+		}
+		imported += len(vbs)
+		log.WithFields(logrus.Fields{
+			"era":       n,
+			"blocks":    len(vbs),
+			"lowSlot":   vbs[0].Block().Slot(),
+			"remaining": vbs[0].Block().Slot() - min(minimum, vbs[0].Block().Slot()),
+		}).Info("Backfilled blocks from era file")
+	}
+}
+
+func readEraBlocks(path string, minimum, low primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	f, err := era.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close era file")
+		}
+	}()
+	blks, err := f.Blocks(minimum, low)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read blocks of era file %s", path)
+	}
+	return blks, nil
+}
+
+// noBlobsAvailabilityStore is used to import blocks which are outside of the blob retention period.
+type noBlobsAvailabilityStore struct{}
+
+func (noBlobsAvailabilityStore) IsDataAvailable(context.Context, primitives.Slot, blocks.ROBlock) error {
+	return nil
+}
+
+func (noBlobsAvailabilityStore) Persist(primitives.Slot, ...blocks.ROBlob) error {
+	return nil
+}
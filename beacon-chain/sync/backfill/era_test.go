@@ -0,0 +1,113 @@
+package backfill
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestFillFromEra(t *testing.T) {
+	// Blocks are generated in a deneb era so that their fork is detected when they are read back.
+	denebSlot, err := slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+	require.NoError(t, err)
+	n := era.ForSlot(denebSlot) + 1
+	start := era.StartSlot(n)
+	vr := make([]byte, 32)
+	copy(vr, "yooooo")
+	sks, pks, err := interop.DeterministicallyGenerateKeys(0, 6)
+	require.NoError(t, err)
+	blks := make([]blocks.ROBlock, 6)
+	prevRoot := [32]byte{}
+	for i := range blks {
+		blks[i], _ = util.GenerateTestDenebBlockWithSidecar(t, prevRoot, start+primitives.Slot(i), 0, util.WithProposerSigning(primitives.ValidatorIndex(i), sks[i], vr))
+		prevRoot = blks[i].Root()
+	}
+	pubkeys := make([][fieldparams.BLSPubkeyLength]byte, len(pks))
+	for i := range pks {
+		pubkeys[i] = bytesutil.ToBytes48(pks[i].Marshal())
+	}
+	v, err := newBackfillVerifier(vr, pubkeys)
+	require.NoError(t, err)
+
+	// The era file holds blocks 1-4, block 5 is the lowest block that was already backfilled.
+	// Importing stops at block 1, the minimum slot.
+	st, err := util.NewBeaconStateDeneb()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(era.StateSlot(n)))
+	eraBlks := make([]interfaces.ReadOnlySignedBeaconBlock, 0, 4)
+	for _, b := range blks[1:5] {
+		eraBlks = append(eraBlks, b.ReadOnlySignedBeaconBlock)
+	}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, era.Write(buf, eraBlks, st))
+	dir := t.TempDir()
+	name := fmt.Sprintf("%s-%05d-%x%s", params.BeaconConfig().ConfigName, n, []byte{1, 2, 3, 4}, era.FileExtension)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0600))
+
+	low := blks[5]
+	lpr := low.Block().ParentRoot()
+	mdb := &mockBackfillDB{status: &dbval.BackfillStatus{
+		LowSlot:       uint64(low.Block().Slot()),
+		LowRoot:       low.RootSlice(),
+		LowParentRoot: lpr[:],
+		OriginSlot:    uint64(low.Block().Slot()),
+		OriginRoot:    low.RootSlice(),
+	}}
+	su, err := NewUpdater(context.Background(), mdb)
+	require.NoError(t, err)
+
+	current := start + 100
+	imported, err := fillFromEra(context.Background(), su, v, dir, start+1, current)
+	require.NoError(t, err)
+	require.Equal(t, 4, imported)
+	require.Equal(t, uint64(start+1), su.status().LowSlot)
+	require.DeepEqual(t, blks[1].RootSlice(), su.status().LowRoot)
+	for _, b := range blks[1:5] {
+		_, ok := mdb.blocks[b.Root()]
+		require.Equal(t, true, ok)
+	}
+
+	// Nothing is left to import once the minimum slot is reached.
+	imported, err = fillFromEra(context.Background(), su, v, dir, start+1, current)
+	require.NoError(t, err)
+	require.Equal(t, 0, imported)
+}
+
+func TestFillFromEra_MissingFile(t *testing.T) {
+	blks, _, _, _ := testBlocksWithKeys(t, 2, 0, make([]byte, 32))
+	low := blks[1]
+	lpr := low.Block().ParentRoot()
+	mdb := &mockBackfillDB{status: &dbval.BackfillStatus{
+		LowSlot:       uint64(low.Block().Slot()) + 100,
+		LowRoot:       low.RootSlice(),
+		LowParentRoot: lpr[:],
+	}}
+	su, err := NewUpdater(context.Background(), mdb)
+	require.NoError(t, err)
+	n, err := fillFromEra(context.Background(), su, &verifier{}, t.TempDir(), 0, 200)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+	require.Equal(t, uint64(101), su.status().LowSlot)
+}
+
+func TestImportEra_GenesisSync(t *testing.T) {
+	su := &Store{genesisSync: true}
+	_, err := ImportEra(context.Background(), su, t.TempDir(), 0, 100)
+	require.ErrorIs(t, err, errGenesisSync)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -38,7 +39,10 @@ type Service struct {
 	pa              PeerAssigner
 	batchImporter   batchImporter
 	blobStore       *filesystem.BlobStorage
+	columnStore     *filesystem.DataColumnStorage
+	custody         map[uint64]bool
 	initSyncWaiter  func() error
+	eraDir          string
 }
 
 var _ runtime.Service = (*Service)(nil)
@@ -102,6 +106,22 @@ func WithInitSyncWaiter(w func() error) ServiceOption {
 	}
 }
 
+// WithEraDir sets a directory of era files that backfill imports blocks from before requesting them from peers.
+func WithEraDir(dir string) ServiceOption {
+	return func(s *Service) error {
+		s.eraDir = dir
+		return nil
+	}
+}
+
+// WithDataColumnStorage sets the data column storage used to make blocks from the EIP-7594 fork onwards available.
+func WithDataColumnStorage(cs *filesystem.DataColumnStorage) ServiceOption {
+	return func(s *Service) error {
+		s.columnStore = cs
+		return nil
+	}
+}
+
 // InitializerWaiter is an interface that is satisfied by verification.InitializerWaiter.
 // Using this interface enables node init to satisfy this requirement for the backfill service
 // while also allowing backfill to mock it in tests.
@@ -160,7 +180,21 @@ func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage,
 }
 
 func (s *Service) initVerifier(ctx context.Context) (*verifier, sync.ContextByteVersions, error) {
-	cps, err := s.store.originState(ctx)
+	v, vr, err := newOriginVerifier(ctx, s.store)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctxMap, err := sync.ContextByteVersionsForValRoot(bytesutil.ToBytes32(vr))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to initialize context version map using genesis validator root %#x", vr)
+	}
+	return v, ctxMap, nil
+}
+
+// newOriginVerifier initializes a block verifier with the validator keys of the checkpoint sync origin state,
+// and returns it along with the genesis validators root.
+func newOriginVerifier(ctx context.Context, su *Store) (*verifier, []byte, error) {
+	cps, err := su.originState(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -169,12 +203,8 @@ func (s *Service) initVerifier(ctx context.Context) (*verifier, sync.ContextByte
 		return nil, nil, errors.Wrap(err, "unable to retrieve public keys for all validators in the origin state")
 	}
 	vr := cps.GenesisValidatorsRoot()
-	ctxMap, err := sync.ContextByteVersionsForValRoot(bytesutil.ToBytes32(vr))
-	if err != nil {
-		return nil, nil, errors.Wrapf(err, "unable to initialize context version map using genesis validator root %#x", vr)
-	}
 	v, err := newBackfillVerifier(vr, keys)
-	return v, ctxMap, err
+	return v, vr, err
 }
 
 func (s *Service) updateComplete() bool {
@@ -288,6 +318,16 @@ func (s *Service) Start() {
 		log.WithError(err).Error("Unable to initialize backfill verifier")
 		return
 	}
+	if s.eraDir != "" {
+		if _, err := fillFromEra(ctx, s.store, s.verifier, s.eraDir, s.ms(s.clock.CurrentSlot()), s.clock.CurrentSlot()); err != nil {
+			log.WithError(err).Error("Could not backfill blocks from era files, falling back to peers")
+		}
+		status = s.store.status()
+		if primitives.Slot(status.LowSlot) <= s.ms(s.clock.CurrentSlot()) {
+			log.WithField("backfillLowestSlot", status.LowSlot).Info("Exiting backfill service; history was backfilled from era files")
+			return
+		}
+	}
 
 	if s.initSyncWaiter != nil {
 		log.Info("Backfill service waiting for initial-sync to reach head before starting")
@@ -296,7 +336,16 @@ func (s *Service) Start() {
 			return
 		}
 	}
-	s.pool.spawn(ctx, s.nWorkers, clock, s.pa, s.verifier, s.ctxMap, s.newBlobVerifier, s.blobStore)
+	if s.columnStore != nil && params.BeaconConfig().Eip7594ForkEpoch != params.BeaconConfig().FarFutureEpoch {
+		s.custody, err = p2p.CustodyColumns(s.p2p.PeerID())
+		if err != nil {
+			log.WithError(err).Error("Could not compute custody columns for backfill")
+			return
+		}
+	} else {
+		s.columnStore = nil
+	}
+	s.pool.spawn(ctx, s.nWorkers, clock, s.pa, s.verifier, s.ctxMap, s.newBlobVerifier, s.blobStore, s.columnStore, s.custody)
 	s.batchSeq = newBatchSequencer(s.nWorkers, s.ms(s.clock.CurrentSlot()), primitives.Slot(status.LowSlot), primitives.Slot(s.batchSize))
 	if err = s.initBatches(); err != nil {
 		log.WithError(err).Error("Non-recoverable error in backfill service")
@@ -35,6 +35,10 @@ func (v verifiedROBlocks) blobIdents(retentionStart primitives.Slot) ([]blobSumm
 		if v[i].Block().Version() < version.Deneb {
 			continue
 		}
+		// Blocks from the EIP-7594 fork onwards are made available by their data columns, see columnSync.
+		if params.PeerDASEnabled(slots.ToEpoch(v[i].Block().Slot())) {
+			continue
+		}
 		c, err := v[i].Block().Body().BlobKzgCommitments()
 		if err != nil {
 			return nil, errors.Wrapf(err, "unexpected error checking commitments for block root %#x", v[i].Root())
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
@@ -24,6 +25,8 @@ type p2pWorker struct {
 	cm   sync.ContextByteVersions
 	nbv  verification.NewBlobVerifier
 	bfs  *filesystem.BlobStorage
+	cfs  *filesystem.DataColumnStorage
+	cust map[uint64]bool
 }
 
 func (w *p2pWorker) run(ctx context.Context) {
@@ -73,7 +76,15 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 	}
 	backfillBlocksApproximateBytes.Add(float64(bdl))
 	log.WithFields(b.logFields()).WithField("dlbytes", bdl).Debug("Backfill batch block bytes downloaded")
-	bs, err := newBlobSync(cs, vb, &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs})
+	bcfg := &blobSyncConfig{retentionStart: blobRetentionStart, nbv: w.nbv, store: w.bfs}
+	if w.cfs != nil {
+		columnRetentionStart, err := sync.DataColumnRPCMinValidSlot(cs)
+		if err != nil {
+			return b.withRetryableError(errors.Wrap(err, "configuration issue, could not compute minimum data column retention slot"))
+		}
+		bcfg.columns = &columnSyncConfig{retentionStart: columnRetentionStart, store: w.cfs, custody: w.cust}
+	}
+	bs, err := newBlobSync(cs, vb, bcfg)
 	if err != nil {
 		return b.withRetryableError(err)
 	}
@@ -82,26 +93,60 @@ func (w *p2pWorker) handleBlocks(ctx context.Context, b batch) batch {
 
 func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	b.blobPid = b.busy
-	start := time.Now()
-	// we don't need to use the response for anything other than metrics, because blobResponseValidation
-	// adds each of them to a batch AvailabilityStore once it is checked.
-	blobs, err := sync.SendBlobsByRangeRequest(ctx, w.c, w.p2p, b.blobPid, w.cm, b.blobRequest(), b.blobResponseValidator(), blobValidationMetrics)
-	if err != nil {
-		b.bs = nil
-		return b.withRetryableError(err)
+	if b.blobsNeeded() > 0 {
+		start := time.Now()
+		// we don't need to use the response for anything other than metrics, because blobResponseValidation
+		// adds each of them to a batch AvailabilityStore once it is checked.
+		blobs, err := sync.SendBlobsByRangeRequest(ctx, w.c, w.p2p, b.blobPid, w.cm, b.blobRequest(), b.blobResponseValidator(), blobValidationMetrics)
+		if err != nil {
+			b.bs = nil
+			return b.withRetryableError(err)
+		}
+		dlt := time.Now()
+		backfillBatchTimeDownloadingBlobs.Observe(float64(dlt.Sub(start).Milliseconds()))
+		if len(blobs) > 0 {
+			// All blobs are the same size, so we can compute 1 and use it for all in the batch.
+			sz := blobs[0].SizeSSZ() * len(blobs)
+			backfillBlobsApproximateBytes.Add(float64(sz))
+			log.WithFields(b.logFields()).WithField("dlbytes", sz).Debug("Backfill batch blob bytes downloaded")
+		}
 	}
-	dlt := time.Now()
-	backfillBatchTimeDownloadingBlobs.Observe(float64(dlt.Sub(start).Milliseconds()))
-	if len(blobs) > 0 {
-		// All blobs are the same size, so we can compute 1 and use it for all in the batch.
-		sz := blobs[0].SizeSSZ() * len(blobs)
-		backfillBlobsApproximateBytes.Add(float64(sz))
-		log.WithFields(b.logFields()).WithField("dlbytes", sz).Debug("Backfill batch blob bytes downloaded")
+	if b.bs.columnsNeeded() > 0 {
+		w.handleColumns(ctx, b)
 	}
 	return b.postBlobSync()
 }
 
-func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage) *p2pWorker {
+// handleColumns requests the custodied data columns still missing for the batch, starting with the peer assigned
+// to the batch. Since a peer only custodies a subset of the columns, the other connected peers are asked for the
+// columns it lacks.
+func (w *p2pWorker) handleColumns(ctx context.Context, b batch) {
+	cs := b.bs.columns
+	peers := append([]peer.ID{b.blobPid}, w.p2p.Peers().Connected()...)
+	for _, pid := range peers {
+		if cs.columnsNeeded() == 0 {
+			return
+		}
+		pc, err := p2p.CustodyColumns(pid)
+		if err != nil {
+			continue
+		}
+		columns := cs.missingColumns(pc)
+		if len(columns) == 0 {
+			continue
+		}
+		dcs, err := sync.SendDataColumnSidecarsByRangeRequest(ctx, w.c, w.p2p, pid, w.cm, cs.request(columns))
+		if err != nil {
+			log.WithError(err).WithFields(b.logFields()).WithField("peer", pid).Debug("Could not request data columns by range from peer")
+			continue
+		}
+		if err := cs.add(dcs); err != nil {
+			log.WithError(err).WithFields(b.logFields()).WithField("peer", pid).Debug("Invalid DataColumnSidecarsByRange response")
+		}
+	}
+}
+
+func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Clock, v *verifier, cm sync.ContextByteVersions, nbv verification.NewBlobVerifier, bfs *filesystem.BlobStorage, cfs *filesystem.DataColumnStorage, custody map[uint64]bool) *p2pWorker {
 	return &p2pWorker{
 		id:   id,
 		todo: todo,
@@ -112,5 +157,7 @@ func newP2pWorker(id workerId, p p2p.P2P, todo, done chan batch, c *startup.Cloc
 		cm:   cm,
 		nbv:  nbv,
 		bfs:  bfs,
+		cfs:  cfs,
+		cust: custody,
 	}
 }
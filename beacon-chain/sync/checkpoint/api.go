@@ -2,6 +2,7 @@ package checkpoint
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
@@ -12,17 +13,35 @@ import (
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
 // from the remote beacon node api.
 type APIInitializer struct {
-	c *beacon.Client
+	clients []*beacon.Client
+	quorum  int
 }
 
 // NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
 // using the provided host string.
 func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
-	c, err := beacon.NewClient(beaconNodeHost)
-	if err != nil {
-		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
+	return NewQuorumAPIInitializer([]string{beaconNodeHost}, 1)
+}
+
+// NewQuorumAPIInitializer creates an APIInitializer which downloads the checkpoint sync data only once quorum of the
+// beacon node apis at the provided host strings agree on the finalized checkpoint. A quorum of 0 requires all of them
+// to agree.
+func NewQuorumAPIInitializer(beaconNodeHosts []string, quorum int) (*APIInitializer, error) {
+	if len(beaconNodeHosts) == 0 {
+		return nil, errors.New("no beacon node url or hostname provided for checkpoint sync")
+	}
+	if quorum < 0 || quorum > len(beaconNodeHosts) {
+		return nil, fmt.Errorf("checkpoint sync quorum %d is not satisfiable with %d beacon nodes", quorum, len(beaconNodeHosts))
 	}
-	return &APIInitializer{c: c}, nil
+	clients := make([]*beacon.Client, len(beaconNodeHosts))
+	for i, h := range beaconNodeHosts {
+		c, err := beacon.NewClient(h)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", h)
+		}
+		clients[i] = c
+	}
+	return &APIInitializer{clients: clients, quorum: quorum}, nil
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -37,7 +56,12 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 			return errors.Wrap(err, "error while checking database for origin root")
 		}
 	}
-	od, err := beacon.DownloadFinalizedData(ctx, dl.c)
+	var od *beacon.OriginData
+	if len(dl.clients) == 1 {
+		od, err = beacon.DownloadFinalizedData(ctx, dl.clients[0])
+	} else {
+		od, err = beacon.DownloadFinalizedDataWithQuorum(ctx, dl.clients, dl.quorum)
+	}
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
@@ -0,0 +1,305 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errBundleStateMismatch    = errors.New("checkpoint bundle block is not the latest block of the bundled state")
+	errBundleWeakSubjectivity = errors.New("checkpoint bundle does not contain the weak subjectivity checkpoint block")
+	errBundleHistory          = errors.New("checkpoint bundle history does not chain to the checkpoint block")
+	errBundleBlobs            = errors.New("checkpoint bundle blob sidecars do not match the bundled blocks")
+)
+
+// BlobStorageInitializer is an Initializer which also saves blob sidecars.
+// The beacon node gives it its blob storage before calling Initialize.
+type BlobStorageInitializer interface {
+	Initializer
+	SetBlobStorage(bs *filesystem.BlobStorage)
+}
+
+// NewBundleInitializer validates the given path information and creates an Initializer which will use the checkpoint
+// bundle stored in the file to prepare the node for checkpoint sync. See beacon.CheckpointBundle for the format.
+// The bundle is only imported if it contains the block of the given weak subjectivity checkpoint.
+func NewBundleInitializer(bundlePath string, wsc *ethpb.Checkpoint) (*BundleInitializer, error) {
+	if err := existsAndIsFile(bundlePath); err != nil {
+		return nil, err
+	}
+	if wsc == nil || len(wsc.Root) == 0 {
+		return nil, errors.New("a weak subjectivity checkpoint is required to verify the checkpoint bundle")
+	}
+	return &BundleInitializer{bundlePath: bundlePath, wsc: wsc}, nil
+}
+
+// BundleInitializer initializes a beacon-node database to use checkpoint sync, using a checkpoint bundle stored
+// in a file on the local filesystem. The bundled state, block, ancestor blocks and blob sidecars are verified
+// against the weak subjectivity checkpoint before anything is saved.
+type BundleInitializer struct {
+	bundlePath  string
+	wsc         *ethpb.Checkpoint
+	blobStorage *filesystem.BlobStorage
+}
+
+// SetBlobStorage sets the storage the bundled blob sidecars are saved to.
+func (bi *BundleInitializer) SetBlobStorage(bs *filesystem.BlobStorage) {
+	bi.blobStorage = bs
+}
+
+// Initialize is called in the BeaconNode db startup code if an Initializer is present.
+// Initialize does what is needed to prepare the beacon node database for syncing from the weak subjectivity checkpoint.
+func (bi *BundleInitializer) Initialize(ctx context.Context, d db.Database) error {
+	origin, err := d.OriginCheckpointBlockRoot(ctx)
+	if err == nil && origin != params.BeaconConfig().ZeroHash {
+		log.Warnf("Origin checkpoint root %#x found in db, ignoring checkpoint sync flags", origin)
+		return nil
+	} else {
+		if !errors.Is(err, db.ErrNotFound) {
+			return errors.Wrap(err, "error while checking database for origin root")
+		}
+	}
+	serBundle, err := file.ReadFileAsBytes(bi.bundlePath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading checkpoint bundle file %s for checkpoint sync init", bi.bundlePath)
+	}
+	bundle, err := beacon.ReadCheckpointBundle(bytes.NewReader(serBundle))
+	if err != nil {
+		return errors.Wrapf(err, "error reading checkpoint bundle file %s for checkpoint sync init", bi.bundlePath)
+	}
+	vb, err := verifyBundle(ctx, bundle, bi.wsc)
+	if err != nil {
+		return errors.Wrapf(err, "could not verify checkpoint bundle %s", bi.bundlePath)
+	}
+
+	// The origin is saved last, so that the bundle is imported again if the node stops halfway through.
+	if len(vb.blobs) > 0 && bi.blobStorage == nil {
+		return errors.New("no blob storage to save the checkpoint bundle blob sidecars to")
+	}
+	for _, sc := range vb.blobs {
+		if err := bi.blobStorage.Save(sc); err != nil {
+			return errors.Wrapf(err, "could not save blob sidecar %d of block %#x", sc.Index, sc.BlockRoot())
+		}
+	}
+	if len(vb.history) > 0 {
+		history := make([]interfaces.ReadOnlySignedBeaconBlock, len(vb.history))
+		for i := range vb.history {
+			history[i] = vb.history[i]
+		}
+		if err := d.SaveBlocks(ctx, history); err != nil {
+			return errors.Wrap(err, "could not save checkpoint bundle history blocks")
+		}
+	}
+	if err := d.SaveOrigin(ctx, bundle.State, bundle.Block); err != nil {
+		return err
+	}
+	if len(vb.history) > 0 {
+		// Backfill resumes from the oldest bundled block rather than from the checkpoint block.
+		status, err := d.BackfillStatus(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not read backfill status saved for the checkpoint block")
+		}
+		oldest := vb.history[len(vb.history)-1]
+		root, parent := oldest.Root(), oldest.Block().ParentRoot()
+		status.LowSlot = uint64(oldest.Block().Slot())
+		status.LowRoot = root[:]
+		status.LowParentRoot = parent[:]
+		if err := d.SaveBackfillStatus(ctx, status); err != nil {
+			return errors.Wrap(err, "could not save backfill status for checkpoint bundle history")
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"blockRoot": fmt.Sprintf("%#x", vb.block.Root()),
+		"blockSlot": vb.block.Block().Slot(),
+		"history":   len(vb.history),
+		"blobs":     len(vb.blobs),
+	}).Info("Imported checkpoint bundle")
+	return nil
+}
+
+var _ BlobStorageInitializer = &BundleInitializer{}
+
+// verifiedBundle holds the blocks and blob sidecars of a checkpoint bundle which passed verification.
+type verifiedBundle struct {
+	block   blocks.ROBlock
+	history []blocks.ROBlock
+	blobs   []blocks.VerifiedROBlob
+}
+
+func verifyBundle(ctx context.Context, b *beacon.CheckpointBundle, wsc *ethpb.Checkpoint) (*verifiedBundle, error) {
+	vu, err := detect.FromState(b.State)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sniff config+fork for checkpoint bundle state")
+	}
+	st, err := vu.UnmarshalBeaconState(b.State)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal checkpoint bundle state")
+	}
+	sb, err := vu.UnmarshalBeaconBlock(b.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal checkpoint bundle block")
+	}
+	blk, err := blocks.NewROBlock(sb)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root of the latest block header of the state, once its state root is filled in, is the block root.
+	h := st.LatestBlockHeader()
+	if bytesutil.ToBytes32(h.StateRoot) == params.BeaconConfig().ZeroHash {
+		sr, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute hash_tree_root of checkpoint bundle state")
+		}
+		h.StateRoot = sr[:]
+	}
+	hr, err := h.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute hash_tree_root of checkpoint bundle state latest block header")
+	}
+	if hr != blk.Root() {
+		return nil, errors.Wrapf(errBundleStateMismatch, "state latest block root = %#x, block root = %#x", hr, blk.Root())
+	}
+
+	history, err := verifyHistory(blk, b.History)
+	if err != nil {
+		return nil, err
+	}
+	chain := append([]blocks.ROBlock{blk}, history...)
+	if err := verifyWeakSubjectivity(wsc, chain); err != nil {
+		return nil, err
+	}
+	blobs, err := verifyBlobs(b.Blobs, chain)
+	if err != nil {
+		return nil, err
+	}
+	return &verifiedBundle{block: blk, history: history, blobs: blobs}, nil
+}
+
+// verifyHistory decodes the ancestors of the checkpoint block, each of which must be the parent of the previous one.
+func verifyHistory(child blocks.ROBlock, encoded [][]byte) ([]blocks.ROBlock, error) {
+	history := make([]blocks.ROBlock, len(encoded))
+	for i := range encoded {
+		vu, err := detect.FromBlock(encoded[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not sniff config+fork for checkpoint bundle ancestor block %d", i+1)
+		}
+		sb, err := vu.UnmarshalBeaconBlock(encoded[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal checkpoint bundle ancestor block %d", i+1)
+		}
+		blk, err := blocks.NewROBlock(sb)
+		if err != nil {
+			return nil, err
+		}
+		if parent := child.Block().ParentRoot(); blk.Root() != parent {
+			return nil, errors.Wrapf(errBundleHistory, "ancestor block %d has root %#x, expected %#x", i+1, blk.Root(), parent)
+		}
+		history[i] = blk
+		child = blk
+	}
+	return history, nil
+}
+
+// verifyWeakSubjectivity checks that the weak subjectivity checkpoint block is one of the bundled blocks, in the epoch
+// of the checkpoint. The blockchain service asserts the same on startup, from the blocks saved in the database.
+func verifyWeakSubjectivity(wsc *ethpb.Checkpoint, chain []blocks.ROBlock) error {
+	start, err := slots.EpochStart(wsc.Epoch)
+	if err != nil {
+		return err
+	}
+	end := start + params.BeaconConfig().SlotsPerEpoch
+	root := bytesutil.ToBytes32(wsc.Root)
+	for _, blk := range chain {
+		if blk.Root() != root {
+			continue
+		}
+		if slot := blk.Block().Slot(); slot < start || slot > end {
+			return errors.Wrapf(errBundleWeakSubjectivity, "block %#x is at slot %d, outside of epoch %d", root, slot, wsc.Epoch)
+		}
+		log.WithFields(logrus.Fields{
+			"root":  fmt.Sprintf("%#x", root),
+			"epoch": wsc.Epoch,
+		}).Info("Checkpoint bundle contains the weak subjectivity checkpoint block")
+		return nil
+	}
+	if latest := slots.ToEpoch(chain[0].Block().Slot()); wsc.Epoch > latest {
+		return errors.Wrapf(errBundleWeakSubjectivity, "checkpoint epoch %d is after the bundled block epoch %d", wsc.Epoch, latest)
+	}
+	return errors.Wrapf(errBundleWeakSubjectivity, "root=%#x, epoch=%d", root, wsc.Epoch)
+}
+
+// verifyBlobs checks that the bundle holds a valid sidecar for every kzg commitment of the bundled blocks,
+// and nothing else.
+func verifyBlobs(encoded [][]byte, chain []blocks.ROBlock) ([]blocks.VerifiedROBlob, error) {
+	if len(encoded) > 0 {
+		if err := kzg.Start(); err != nil {
+			return nil, errors.Wrap(err, "could not initialize go-kzg context")
+		}
+	}
+	byRoot := make(map[[32]byte][]blocks.ROBlob, len(chain))
+	for _, blk := range chain {
+		byRoot[blk.Root()] = nil
+	}
+	for i := range encoded {
+		sc := &ethpb.BlobSidecar{}
+		if err := sc.UnmarshalSSZ(encoded[i]); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal checkpoint bundle blob sidecar %d", i)
+		}
+		rob, err := blocks.NewROBlob(sc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid checkpoint bundle blob sidecar %d", i)
+		}
+		if _, ok := byRoot[rob.BlockRoot()]; !ok {
+			return nil, errors.Wrapf(errBundleBlobs, "blob sidecar %d is for block %#x, which is not bundled", i, rob.BlockRoot())
+		}
+		byRoot[rob.BlockRoot()] = append(byRoot[rob.BlockRoot()], rob)
+	}
+
+	verified := make([]blocks.VerifiedROBlob, 0, len(encoded))
+	for _, blk := range chain {
+		scs := byRoot[blk.Root()]
+		var commitments [][]byte
+		if blk.Version() >= version.Deneb {
+			var err error
+			if commitments, err = blk.Block().Body().BlobKzgCommitments(); err != nil {
+				return nil, err
+			}
+		}
+		if len(scs) != len(commitments) {
+			return nil, errors.Wrapf(errBundleBlobs, "block %#x has %d kzg commitments, bundle has %d blob sidecars",
+				blk.Root(), len(commitments), len(scs))
+		}
+		seen := make(map[uint64]bool, len(scs))
+		for _, sc := range scs {
+			if seen[sc.Index] {
+				return nil, errors.Wrapf(errBundleBlobs, "duplicate blob sidecar %d for block %#x", sc.Index, blk.Root())
+			}
+			seen[sc.Index] = true
+		}
+		vscs, err := verification.VerifiedROBlobsForTrustedBlock(blk, scs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid blob sidecars for block %#x", blk.Root())
+		}
+		verified = append(verified, vscs...)
+	}
+	return verified, nil
+}
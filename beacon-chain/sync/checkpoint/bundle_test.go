@@ -0,0 +1,206 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// testBundle is a checkpoint bundle of a Deneb block without blobs, at the slot of its state, with a parent
+// and a grandparent which have blobs. The grandparent is at the start of the Deneb fork epoch.
+type testBundle struct {
+	bundle      *beacon.CheckpointBundle
+	block       [32]byte
+	parent      blocks.ROBlock
+	grandparent blocks.ROBlock
+	blobs       []blocks.ROBlob
+}
+
+func newTestBundle(t *testing.T) *testBundle {
+	ctx := context.Background()
+	start, err := slots.EpochStart(params.BeaconConfig().DenebForkEpoch)
+	require.NoError(t, err)
+	grandparent, grandparentBlobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, start, 1, util.WithEmptyBlobs())
+	parent, parentBlobs := util.GenerateTestDenebBlockWithSidecar(t, grandparent.Root(), start+1, 2, util.WithEmptyBlobs())
+
+	blk := util.NewBeaconBlockDeneb()
+	blk.Block.Slot = start + 2
+	pr := parent.Root()
+	blk.Block.ParentRoot = pr[:]
+	sb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	header, err := sb.Header()
+	require.NoError(t, err)
+	st, err := util.NewBeaconStateDeneb()
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: params.BeaconConfig().CapellaForkVersion,
+		CurrentVersion:  params.BeaconConfig().DenebForkVersion,
+		Epoch:           params.BeaconConfig().DenebForkEpoch,
+	}))
+	require.NoError(t, st.SetSlot(start+2))
+	require.NoError(t, st.SetLatestBlockHeader(header.Header))
+	sr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	blk.Block.StateRoot = sr[:]
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	b := &beacon.CheckpointBundle{}
+	b.State, err = st.MarshalSSZ()
+	require.NoError(t, err)
+	b.Block, err = blk.MarshalSSZ()
+	require.NoError(t, err)
+	for _, h := range []blocks.ROBlock{parent, grandparent} {
+		mb, err := h.MarshalSSZ()
+		require.NoError(t, err)
+		b.History = append(b.History, mb)
+	}
+	blobs := append(parentBlobs, grandparentBlobs...)
+	for _, sc := range blobs {
+		ms, err := sc.MarshalSSZ()
+		require.NoError(t, err)
+		b.Blobs = append(b.Blobs, ms)
+	}
+	return &testBundle{bundle: b, block: root, parent: parent, grandparent: grandparent, blobs: blobs}
+}
+
+func (tb *testBundle) checkpoint() *ethpb.Checkpoint {
+	root := tb.grandparent.Root()
+	return &ethpb.Checkpoint{Epoch: params.BeaconConfig().DenebForkEpoch, Root: root[:]}
+}
+
+func writeTestBundle(t *testing.T, b *beacon.CheckpointBundle) string {
+	buf := &bytes.Buffer{}
+	require.NoError(t, b.Write(buf))
+	p := filepath.Join(t.TempDir(), "bundle.tar")
+	require.NoError(t, file.WriteFile(p, buf.Bytes()))
+	return p
+}
+
+func TestBundleInitializer(t *testing.T) {
+	ctx := context.Background()
+	tb := newTestBundle(t)
+
+	_, err := NewBundleInitializer(writeTestBundle(t, tb.bundle), nil)
+	require.ErrorContains(t, "a weak subjectivity checkpoint is required", err)
+
+	bi, err := NewBundleInitializer(writeTestBundle(t, tb.bundle), tb.checkpoint())
+	require.NoError(t, err)
+	bs := filesystem.NewEphemeralBlobStorage(t)
+	bi.SetBlobStorage(bs)
+	d := dbtest.SetupDB(t)
+	require.NoError(t, bi.Initialize(ctx, d))
+
+	origin, err := d.OriginCheckpointBlockRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, tb.block, origin)
+	require.Equal(t, true, d.HasBlock(ctx, tb.parent.Root()))
+	require.Equal(t, true, d.HasBlock(ctx, tb.grandparent.Root()))
+	for _, sc := range tb.blobs {
+		indices, err := bs.Indices(sc.BlockRoot())
+		require.NoError(t, err)
+		require.Equal(t, true, indices[sc.Index])
+	}
+	status, err := d.BackfillStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(tb.grandparent.Block().Slot()), status.LowSlot)
+	gr := tb.grandparent.Root()
+	require.DeepEqual(t, gr[:], status.LowRoot)
+	require.Equal(t, uint64(tb.grandparent.Block().Slot()+2), status.OriginSlot)
+}
+
+func TestVerifyBundle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		tb := newTestBundle(t)
+		vb, err := verifyBundle(ctx, tb.bundle, tb.checkpoint())
+		require.NoError(t, err)
+		require.Equal(t, tb.block, vb.block.Root())
+		require.Equal(t, 2, len(vb.history))
+		require.Equal(t, len(tb.blobs), len(vb.blobs))
+	})
+	t.Run("checkpoint block of the bundled state", func(t *testing.T) {
+		tb := newTestBundle(t)
+		_, err := verifyBundle(ctx, tb.bundle, &ethpb.Checkpoint{Epoch: params.BeaconConfig().DenebForkEpoch, Root: tb.block[:]})
+		require.NoError(t, err)
+	})
+	t.Run("block is not the latest block of the state", func(t *testing.T) {
+		tb := newTestBundle(t)
+		tb.bundle.Block = tb.bundle.History[0]
+		_, err := verifyBundle(ctx, tb.bundle, tb.checkpoint())
+		require.ErrorIs(t, err, errBundleStateMismatch)
+	})
+	t.Run("weak subjectivity root not bundled", func(t *testing.T) {
+		tb := newTestBundle(t)
+		_, err := verifyBundle(ctx, tb.bundle, &ethpb.Checkpoint{Epoch: params.BeaconConfig().DenebForkEpoch, Root: make([]byte, 32)})
+		require.ErrorIs(t, err, errBundleWeakSubjectivity)
+	})
+	t.Run("weak subjectivity root in another epoch", func(t *testing.T) {
+		tb := newTestBundle(t)
+		wsc := tb.checkpoint()
+		wsc.Epoch = wsc.Epoch - 2
+		_, err := verifyBundle(ctx, tb.bundle, wsc)
+		require.ErrorIs(t, err, errBundleWeakSubjectivity)
+		require.ErrorContains(t, "outside of epoch", err)
+	})
+	t.Run("weak subjectivity checkpoint after the bundle", func(t *testing.T) {
+		tb := newTestBundle(t)
+		wsc := tb.checkpoint()
+		wsc.Epoch = wsc.Epoch + primitives.Epoch(2)
+		wsc.Root = make([]byte, 32)
+		_, err := verifyBundle(ctx, tb.bundle, wsc)
+		require.ErrorIs(t, err, errBundleWeakSubjectivity)
+		require.ErrorContains(t, "is after the bundled block epoch", err)
+	})
+	t.Run("history out of order", func(t *testing.T) {
+		tb := newTestBundle(t)
+		tb.bundle.History[0], tb.bundle.History[1] = tb.bundle.History[1], tb.bundle.History[0]
+		_, err := verifyBundle(ctx, tb.bundle, tb.checkpoint())
+		require.ErrorIs(t, err, errBundleHistory)
+	})
+	t.Run("missing blob sidecar", func(t *testing.T) {
+		tb := newTestBundle(t)
+		tb.bundle.Blobs = tb.bundle.Blobs[1:]
+		_, err := verifyBundle(ctx, tb.bundle, tb.checkpoint())
+		require.ErrorIs(t, err, errBundleBlobs)
+	})
+	t.Run("duplicate blob sidecar", func(t *testing.T) {
+		tb := newTestBundle(t)
+		tb.bundle.Blobs[1] = tb.bundle.Blobs[0]
+		_, err := verifyBundle(ctx, tb.bundle, tb.checkpoint())
+		require.ErrorIs(t, err, errBundleBlobs)
+	})
+	t.Run("blob sidecar of a block which is not bundled", func(t *testing.T) {
+		tb := newTestBundle(t)
+		tb.bundle.History = tb.bundle.History[:1]
+		_, err := verifyBundle(ctx, tb.bundle, &ethpb.Checkpoint{Epoch: params.BeaconConfig().DenebForkEpoch, Root: tb.block[:]})
+		require.ErrorIs(t, err, errBundleBlobs)
+		require.ErrorContains(t, "which is not bundled", err)
+	})
+	t.Run("invalid blob", func(t *testing.T) {
+		tb := newTestBundle(t)
+		sc := tb.blobs[0].BlobSidecar
+		sc.Blob[0] = 0x01
+		ms, err := sc.MarshalSSZ()
+		require.NoError(t, err)
+		tb.bundle.Blobs[0] = ms
+		_, err = verifyBundle(ctx, tb.bundle, tb.checkpoint())
+		require.ErrorIs(t, err, verification.ErrSidecarKzgProofInvalid)
+	})
+}
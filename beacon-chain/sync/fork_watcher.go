@@ -24,6 +24,10 @@ func (s *Service) forkWatcher() {
 				log.WithError(err).Error("Unable to check for fork in the next epoch")
 				continue
 			}
+			if err := s.registerForUpcomingPeerDAS(currEpoch); err != nil {
+				log.WithError(err).Error("Unable to check for EIP-7594 activation in the next epoch")
+				continue
+			}
 			if err := s.deregisterFromPastFork(currEpoch); err != nil {
 				log.WithError(err).Error("Unable to check for fork in the previous epoch")
 				continue
@@ -70,6 +74,25 @@ func (s *Service) registerForUpcomingFork(currEpoch primitives.Epoch) error {
 	return nil
 }
 
+// EIP-7594 does not come with a new fork digest, so the data column topics are registered
+// separately, on the current digest, in the epoch before activation. This is only called
+// from the forkWatcher routine, so the registered flag needs no locking.
+func (s *Service) registerForUpcomingPeerDAS(currEpoch primitives.Epoch) error {
+	nextEpoch := currEpoch + 1
+	if nextEpoch != params.BeaconConfig().Eip7594ForkEpoch || s.peerDASRegistered {
+		return nil
+	}
+	genRoot := s.cfg.clock.GenesisValidatorsRoot()
+	digest, err := forks.ForkDigestFromEpoch(nextEpoch, genRoot[:])
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve fork digest")
+	}
+	s.subscribeCustodySubnets(digest)
+	s.registerRPCHandlersPeerDAS()
+	s.peerDASRegistered = true
+	return nil
+}
+
 // Checks if there was a fork in the previous epoch, and if there
 // was then we deregister the topics from that particular fork.
 func (s *Service) deregisterFromPastFork(currEpoch primitives.Epoch) error {
@@ -78,6 +78,8 @@ type blocksFetcherConfig struct {
 	peerFilterCapacityWeight float64
 	mode                     syncMode
 	bs                       filesystem.BlobStorageSummarizer
+	cs                       filesystem.DataColumnStorageSummarizer
+	custody                  map[uint64]bool
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
@@ -94,6 +96,8 @@ type blocksFetcher struct {
 	p2p             p2p.P2P
 	db              db.ReadOnlyDatabase
 	bs              filesystem.BlobStorageSummarizer
+	cs              filesystem.DataColumnStorageSummarizer
+	custody         map[uint64]bool
 	blocksPerPeriod uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
@@ -153,6 +157,8 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		p2p:             cfg.p2p,
 		db:              cfg.db,
 		bs:              cfg.bs,
+		cs:              cfg.cs,
+		custody:         cfg.custody,
 		blocksPerPeriod: uint64(blocksPerPeriod),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
@@ -322,6 +328,13 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		}
 		response.bwb = bwb
 	}
+	if response.err == nil {
+		bwb, err := f.fetchColumnsFromPeers(ctx, response.bwb, response.pid, peers)
+		if err != nil {
+			response.err = err
+		}
+		response.bwb = bwb
+	}
 	return response
 }
 
@@ -406,6 +419,10 @@ func countCommitments(bwb []blocks2.BlockWithROBlobs, retentionStart primitives.
 		if slot < retentionStart {
 			continue
 		}
+		// Blocks from the EIP-7594 fork onwards are made available by their data columns, see fetchColumnsFromPeers.
+		if params.PeerDASEnabled(slots.ToEpoch(slot)) {
+			continue
+		}
 		commits, err := b.Block.Block().Body().BlobKzgCommitments()
 		if err != nil || len(commits) == 0 {
 			continue
@@ -497,6 +514,9 @@ func populateBlock(bw blocks2.BlockWithROBlobs, blobs []blocks.ROBlob, req *p2pp
 	if blk.Version() < version.Deneb || blk.Block().Slot() < req.StartSlot {
 		return bw, errDidntPopulate
 	}
+	if params.PeerDASEnabled(slots.ToEpoch(blk.Block().Slot())) {
+		return bw, errDidntPopulate
+	}
 	commits, err := blk.Block().Body().BlobKzgCommitments()
 	if err != nil {
 		return bw, errDidntPopulate
@@ -0,0 +1,239 @@
+package initialsync
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	prysmsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	p2ppb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+var errColumnVerification = errors.New("peers unable to serve aligned DataColumnSidecarsByRange and BeaconBlockSidecarsByRange responses")
+var errMissingColumnsForBlockCommitments = errors.Wrap(errColumnVerification, "data columns unavailable for processing block with kzg commitments")
+
+// blocksNeedingColumns returns the indices of the blocks in bwb from the EIP-7594 fork onwards, within the data
+// column retention window and with kzg commitments, which are missing some of the custodied columns on disk.
+func blocksNeedingColumns(bwb []blocks.BlockWithROBlobs, retentionStart primitives.Slot, custody map[uint64]bool, cs filesystem.DataColumnStorageSummarizer) []int {
+	needed := make([]int, 0)
+	for i := range bwb {
+		b := bwb[i].Block
+		slot := b.Block().Slot()
+		if b.Version() < version.Deneb || slot < retentionStart || !params.PeerDASEnabled(slots.ToEpoch(slot)) {
+			continue
+		}
+		commits, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		if cs != nil && hasColumns(cs.Summary(b.Root()), custody) {
+			continue
+		}
+		needed = append(needed, i)
+	}
+	return needed
+}
+
+func hasColumns(sum filesystem.DataColumnStorageSummary, columns map[uint64]bool) bool {
+	for idx := range columns {
+		if !sum.HasIndex(idx) {
+			return false
+		}
+	}
+	return true
+}
+
+// columnRequest builds the request for the given columns over the slot range of the blocks in need of them.
+func columnRequest(bwb []blocks.BlockWithROBlobs, needed []int, columns []uint64) *p2ppb.DataColumnSidecarsByRangeRequest {
+	low := bwb[needed[0]].Block.Block().Slot()
+	high := bwb[needed[len(needed)-1]].Block.Block().Slot()
+	return &p2ppb.DataColumnSidecarsByRangeRequest{
+		StartSlot: low,
+		Count:     uint64(high.FlooredSubSlot(low)) + 1,
+		Columns:   columns,
+	}
+}
+
+// columnCollector gathers the custodied columns of a set of blocks from the responses of several peers, each of
+// them only custodying a subset of the columns.
+type columnCollector struct {
+	bwb       []blocks.BlockWithROBlobs
+	needed    []int
+	custody   map[uint64]bool
+	byRoot    map[[32]byte]int
+	collected map[[32]byte]map[uint64]blocks.RODataColumn
+}
+
+func newColumnCollector(bwb []blocks.BlockWithROBlobs, needed []int, custody map[uint64]bool) *columnCollector {
+	c := &columnCollector{
+		bwb:       bwb,
+		needed:    needed,
+		custody:   custody,
+		byRoot:    make(map[[32]byte]int, len(needed)),
+		collected: make(map[[32]byte]map[uint64]blocks.RODataColumn, len(needed)),
+	}
+	for _, i := range needed {
+		r := bwb[i].Block.Root()
+		c.byRoot[r] = i
+		c.collected[r] = make(map[uint64]blocks.RODataColumn, len(custody))
+	}
+	return c
+}
+
+// missing returns the sorted custodied column indices that are still missing for at least one block, and that are
+// custodied by a peer with the given custody set.
+func (c *columnCollector) missing(peerCustody map[uint64]bool) []uint64 {
+	columns := make([]uint64, 0)
+	for idx := uint64(0); idx < fieldparams.NumberOfColumns; idx++ {
+		if !c.custody[idx] || !peerCustody[idx] {
+			continue
+		}
+		for _, cols := range c.collected {
+			if _, ok := cols[idx]; !ok {
+				columns = append(columns, idx)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// add keeps the custodied columns in the response which belong to one of the blocks and carry the same kzg
+// commitments, and drops the rest.
+func (c *columnCollector) add(columns []blocks.RODataColumn) {
+	for _, dc := range columns {
+		i, ok := c.byRoot[dc.BlockRoot()]
+		if !ok || !c.custody[dc.Index] {
+			continue
+		}
+		if err := columnAlignsWithBlock(dc, c.bwb[i].Block); err != nil {
+			log.WithError(err).WithField("index", dc.Index).Debug("Dropping data column not aligned with block")
+			continue
+		}
+		c.collected[dc.BlockRoot()][dc.Index] = dc
+	}
+}
+
+// populate attaches the collected columns to their blocks, failing if any custodied column is missing.
+func (c *columnCollector) populate() ([]blocks.BlockWithROBlobs, error) {
+	for _, i := range c.needed {
+		blk := c.bwb[i].Block
+		cols := c.collected[blk.Root()]
+		populated := make([]blocks.RODataColumn, 0, len(c.custody))
+		for idx := uint64(0); idx < fieldparams.NumberOfColumns; idx++ {
+			if !c.custody[idx] {
+				continue
+			}
+			dc, ok := cols[idx]
+			if !ok {
+				return c.bwb, errors.Wrapf(errMissingColumnsForBlockCommitments,
+					"block root %#x at slot %d missing column %d", blk.Root(), blk.Block().Slot(), idx)
+			}
+			populated = append(populated, dc)
+		}
+		c.bwb[i].Columns = populated
+	}
+	return c.bwb, nil
+}
+
+func columnAlignsWithBlock(dc blocks.RODataColumn, blk blocks.ROBlock) error {
+	if dc.Slot() != blk.Block().Slot() {
+		return errors.Wrapf(errColumnVerification, "slot %d != block slot %d", dc.Slot(), blk.Block().Slot())
+	}
+	commits, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return err
+	}
+	if len(commits) != len(dc.KzgCommitments) {
+		return errors.Wrapf(errColumnVerification, "column has %d commitments, block has %d", len(dc.KzgCommitments), len(commits))
+	}
+	for i := range commits {
+		if !bytes.Equal(commits[i], dc.KzgCommitments[i]) {
+			return errors.Wrapf(errColumnVerification, "commitment %#x != block commitment %#x at index %d", dc.KzgCommitments[i], commits[i], i)
+		}
+	}
+	return nil
+}
+
+// fetchColumnsFromPeers is the data column counterpart of fetchBlobsFromPeer. Since a peer only custodies a subset
+// of the columns, each peer is asked for the custodied columns it has in common with this node that are still
+// missing, until all of them have been received.
+func (f *blocksFetcher) fetchColumnsFromPeers(ctx context.Context, bwb []blocks.BlockWithROBlobs, pid peer.ID, peers []peer.ID) ([]blocks.BlockWithROBlobs, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchColumnsFromPeers")
+	defer span.End()
+	if !params.PeerDASEnabled(slots.ToEpoch(f.clock.CurrentSlot())) {
+		return bwb, nil
+	}
+	columnWindowStart, err := prysmsync.DataColumnRPCMinValidSlot(f.clock.CurrentSlot())
+	if err != nil {
+		return nil, err
+	}
+	needed := blocksNeedingColumns(bwb, columnWindowStart, f.custody, f.cs)
+	if len(needed) == 0 {
+		return bwb, nil
+	}
+	collector := newColumnCollector(bwb, needed, f.custody)
+	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
+	// We dial the peer which served the blocks first, the remaining peers are only used for the columns it lacks.
+	peers = dedupPeers(append([]peer.ID{pid}, peers...))
+	for i := 0; i < len(peers); i++ {
+		p := peers[i]
+		pc, err := p2p.CustodyColumns(p)
+		if err != nil {
+			log.WithField("peer", p).WithError(err).Debug("Could not compute peer custody columns")
+			continue
+		}
+		columns := collector.missing(pc)
+		if len(columns) == 0 {
+			continue
+		}
+		dcs, err := f.requestColumns(ctx, columnRequest(bwb, needed, columns), p)
+		if err != nil {
+			log.WithField("peer", p).WithError(err).Debug("Could not request data columns by range from peer")
+			continue
+		}
+		f.p2p.Peers().Scorers().BlockProviderScorer().Touch(p)
+		collector.add(dcs)
+		if len(collector.missing(f.custody)) == 0 {
+			break
+		}
+	}
+	return collector.populate()
+}
+
+func (f *blocksFetcher) requestColumns(ctx context.Context, req *p2ppb.DataColumnSidecarsByRangeRequest, pid peer.ID) ([]blocks.RODataColumn, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	l := f.peerLock(pid)
+	l.Lock()
+	log.WithFields(logrus.Fields{
+		"peer":     pid,
+		"start":    req.StartSlot,
+		"count":    req.Count,
+		"columns":  len(req.Columns),
+		"capacity": f.rateLimiter.Remaining(pid.String()),
+		"score":    f.p2p.Peers().Scorers().BlockProviderScorer().FormatScorePretty(pid),
+	}).Debug("Requesting data columns")
+	// As with blobs, data column requests are accounted for as if they were block requests.
+	if f.rateLimiter.Remaining(pid.String()) < int64(req.Count) {
+		if err := f.waitForBandwidth(pid, req.Count); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+	}
+	f.rateLimiter.Add(pid.String(), int64(req.Count))
+	l.Unlock()
+	return prysmsync.SendDataColumnSidecarsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
+}
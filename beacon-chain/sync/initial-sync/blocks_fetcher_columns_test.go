@@ -0,0 +1,70 @@
+package initialsync
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestBlocksNeedingColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 0
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	custody := map[uint64]bool{3: true, 70: true}
+	start := params.BeaconConfig().SlotsPerEpoch
+	// Before the EIP-7594 fork, made available by blobs.
+	pre, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, start-1, 1)
+	withColumns, columns := util.GenerateTestDenebBlockWithColumns(t, pre.Root(), start, 2)
+	noCommitments, _ := util.GenerateTestDenebBlockWithColumns(t, withColumns.Root(), start+1, 0)
+	saved, savedColumns := util.GenerateTestDenebBlockWithColumns(t, noCommitments.Root(), start+2, 1)
+	bwb := []blocks.BlockWithROBlobs{{Block: pre}, {Block: withColumns}, {Block: noCommitments}, {Block: saved}}
+
+	store := filesystem.NewEphemeralDataColumnStorage(t)
+	for idx := range custody {
+		require.NoError(t, store.Save(blocks.NewVerifiedRODataColumn(savedColumns[idx])))
+	}
+	require.DeepEqual(t, []int{1, 3}, blocksNeedingColumns(bwb, 0, custody, nil))
+	require.DeepEqual(t, []int{1}, blocksNeedingColumns(bwb, 0, custody, store))
+	require.DeepEqual(t, []int{3}, blocksNeedingColumns(bwb, start+1, custody, nil))
+
+	req := columnRequest(bwb, []int{1, 3}, []uint64{3, 70})
+	require.Equal(t, start, req.StartSlot)
+	require.Equal(t, uint64(3), req.Count)
+	require.DeepEqual(t, []uint64{3, 70}, req.Columns)
+
+	t.Run("columns gathered from several peers", func(t *testing.T) {
+		bwb := []blocks.BlockWithROBlobs{{Block: withColumns}}
+		c := newColumnCollector(bwb, []int{0}, custody)
+		require.DeepEqual(t, []uint64{3, 70}, c.missing(map[uint64]bool{3: true, 70: true, 100: true}))
+		// Columns outside of custody and for unknown blocks are dropped.
+		c.add([]blocks.RODataColumn{columns[3], columns[100], savedColumns[70]})
+		require.DeepEqual(t, []uint64{70}, c.missing(custody))
+		_, err := c.populate()
+		require.ErrorIs(t, err, errMissingColumnsForBlockCommitments)
+
+		c.add([]blocks.RODataColumn{columns[70]})
+		require.Equal(t, 0, len(c.missing(custody)))
+		populated, err := c.populate()
+		require.NoError(t, err)
+		require.Equal(t, 2, len(populated[0].Columns))
+		require.Equal(t, uint64(3), populated[0].Columns[0].Index)
+		require.Equal(t, uint64(70), populated[0].Columns[1].Index)
+	})
+
+	t.Run("columns not matching the block commitments are dropped", func(t *testing.T) {
+		bwb := []blocks.BlockWithROBlobs{{Block: withColumns}}
+		c := newColumnCollector(bwb, []int{0}, custody)
+		_, other := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, start, 1)
+		bad, err := blocks.NewRODataColumnWithRoot(other[3].DataColumnSidecar, withColumns.Root())
+		require.NoError(t, err)
+		c.add([]blocks.RODataColumn{bad})
+		require.DeepEqual(t, []uint64{3, 70}, c.missing(custody))
+	})
+}
@@ -71,6 +71,8 @@ type blocksQueueConfig struct {
 	db                  db.ReadOnlyDatabase
 	mode                syncMode
 	bs                  filesystem.BlobStorageSummarizer
+	cs                  filesystem.DataColumnStorageSummarizer
+	custody             map[uint64]bool
 }
 
 // blocksQueue is a priority queue that serves as a intermediary between block fetchers (producers)
@@ -107,12 +109,14 @@ func newBlocksQueue(ctx context.Context, cfg *blocksQueueConfig) *blocksQueue {
 			log.Warn("rpc fetcher starting without blob availability cache, duplicate blobs may be requested.")
 		}
 		blocksFetcher = newBlocksFetcher(ctx, &blocksFetcherConfig{
-			ctxMap: cfg.ctxMap,
-			chain:  cfg.chain,
-			p2p:    cfg.p2p,
-			db:     cfg.db,
-			clock:  cfg.clock,
-			bs:     cfg.bs,
+			ctxMap:  cfg.ctxMap,
+			chain:   cfg.chain,
+			p2p:     cfg.p2p,
+			db:      cfg.db,
+			clock:   cfg.clock,
+			bs:      cfg.bs,
+			cs:      cfg.cs,
+			custody: cfg.custody,
 		})
 	}
 	highestExpectedSlot := cfg.highestExpectedSlot
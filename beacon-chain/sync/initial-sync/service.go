@@ -24,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
@@ -53,6 +54,7 @@ type Config struct {
 	ClockWaiter         startup.ClockWaiter
 	InitialSyncComplete chan struct{}
 	BlobStorage         *filesystem.BlobStorage
+	DataColumnStorage   *filesystem.DataColumnStorage
 }
 
 // Service service.
@@ -68,6 +70,7 @@ type Service struct {
 	verifierWaiter  *verification.InitializerWaiter
 	newBlobVerifier verification.NewBlobVerifier
 	ctxMap          sync.ContextByteVersions
+	custody         map[uint64]bool
 }
 
 // Option is a functional option for the initial-sync Service.
@@ -148,6 +151,14 @@ func (s *Service) Start() {
 		return
 	}
 	s.newBlobVerifier = newBlobVerifierFromInitializer(v)
+	if params.BeaconConfig().Eip7594ForkEpoch != params.BeaconConfig().FarFutureEpoch {
+		custody, err := p2p.CustodyColumns(s.cfg.P2P.PeerID())
+		if err != nil {
+			log.WithError(err).Error("Could not compute custody columns")
+			return
+		}
+		s.custody = custody
+	}
 
 	gt := clock.GenesisTime()
 	if gt.IsZero() {
@@ -188,6 +199,10 @@ func (s *Service) Start() {
 		log.WithError(err).Error("Failed to fetch missing blobs for checkpoint origin")
 		return
 	}
+	if err := s.fetchOriginColumns(peers); err != nil {
+		log.WithError(err).Error("Failed to fetch missing data columns for checkpoint origin")
+		return
+	}
 	if err := s.roundRobinSync(gt); err != nil {
 		if errors.Is(s.ctx.Err(), context.Canceled) {
 			return
@@ -316,6 +331,9 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		log.WithField("root", fmt.Sprintf("%#x", r)).Error("Block for checkpoint sync origin root not found in db")
 		return err
 	}
+	if params.PeerDASEnabled(slots.ToEpoch(blk.Block().Slot())) {
+		return nil
+	}
 	if !params.WithinDAPeriod(slots.ToEpoch(blk.Block().Slot()), slots.ToEpoch(s.clock.CurrentSlot())) {
 		return nil
 	}
@@ -340,8 +358,7 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 		if len(sidecars) != len(req) {
 			continue
 		}
-		bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncSidecarRequirements)
-		avs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+		avs := s.availabilityStore()
 		current := s.clock.CurrentSlot()
 		if err := avs.Persist(current, sidecars...); err != nil {
 			return err
@@ -356,6 +373,116 @@ func (s *Service) fetchOriginBlobs(pids []peer.ID) error {
 	return fmt.Errorf("no connected peer able to provide blobs for checkpoint sync block %#x", r)
 }
 
+// missingColumnRequest returns a single slot DataColumnSidecarsByRange request for the custodied columns of the
+// given block that are not on disk yet, or nil if there are none.
+func missingColumnRequest(blk blocks.ROBlock, store *filesystem.DataColumnStorage, custody map[uint64]bool) (*eth.DataColumnSidecarsByRangeRequest, error) {
+	cmts, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		log.WithField("root", fmt.Sprintf("%#x", blk.Root())).Error("Error reading commitments from checkpoint sync origin block")
+		return nil, err
+	}
+	if len(cmts) == 0 {
+		return nil, nil
+	}
+	summary := store.Summary(blk.Root())
+	columns := make([]uint64, 0, len(custody))
+	for idx := uint64(0); idx < fieldparams.NumberOfColumns; idx++ {
+		if custody[idx] && !summary.HasIndex(idx) {
+			columns = append(columns, idx)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	return &eth.DataColumnSidecarsByRangeRequest{StartSlot: blk.Block().Slot(), Count: 1, Columns: columns}, nil
+}
+
+// fetchOriginColumns is the data column counterpart of fetchOriginBlobs, used when the checkpoint sync origin block
+// is from the EIP-7594 fork onwards. Since peers only custody a subset of the columns, the missing columns are
+// gathered from as many peers as needed.
+func (s *Service) fetchOriginColumns(pids []peer.ID) error {
+	r, err := s.cfg.DB.OriginCheckpointBlockRoot(s.ctx)
+	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return nil
+	}
+	blk, err := s.cfg.DB.Block(s.ctx, r)
+	if err != nil {
+		log.WithField("root", fmt.Sprintf("%#x", r)).Error("Block for checkpoint sync origin root not found in db")
+		return err
+	}
+	if !params.PeerDASEnabled(slots.ToEpoch(blk.Block().Slot())) {
+		return nil
+	}
+	if !params.WithinColumnDAPeriod(slots.ToEpoch(blk.Block().Slot()), slots.ToEpoch(s.clock.CurrentSlot())) {
+		return nil
+	}
+	rob, err := blocks.NewROBlockWithRoot(blk, r)
+	if err != nil {
+		return err
+	}
+	req, err := missingColumnRequest(rob, s.cfg.DataColumnStorage, s.custody)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		log.WithField("root", fmt.Sprintf("%#x", r)).Debug("All data columns for checkpoint block are present")
+		return nil
+	}
+	missing := make(map[uint64]bool, len(req.Columns))
+	for _, idx := range req.Columns {
+		missing[idx] = true
+	}
+	found := make([]blocks.RODataColumn, 0, len(req.Columns))
+	shufflePeers(pids)
+	for i := 0; i < len(pids) && len(missing) > 0; i++ {
+		pc, err := p2p.CustodyColumns(pids[i])
+		if err != nil {
+			continue
+		}
+		preq := &eth.DataColumnSidecarsByRangeRequest{StartSlot: req.StartSlot, Count: req.Count}
+		for _, idx := range req.Columns {
+			if missing[idx] && pc[idx] {
+				preq.Columns = append(preq.Columns, idx)
+			}
+		}
+		if len(preq.Columns) == 0 {
+			continue
+		}
+		sidecars, err := sync.SendDataColumnSidecarsByRangeRequest(s.ctx, s.clock, s.cfg.P2P, pids[i], s.ctxMap, preq)
+		if err != nil {
+			continue
+		}
+		for _, sc := range sidecars {
+			if sc.BlockRoot() != r || !missing[sc.Index] {
+				continue
+			}
+			delete(missing, sc.Index)
+			found = append(found, sc)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no connected peers able to provide %d data columns for checkpoint sync block %#x", len(missing), r)
+	}
+	avs := s.availabilityStore()
+	current := s.clock.CurrentSlot()
+	if err := avs.PersistColumns(current, found...); err != nil {
+		return err
+	}
+	if err := avs.IsDataAvailable(s.ctx, current, rob); err != nil {
+		return errors.Wrapf(err, "data columns from peers for checkpoint sync block %#x were unusable", r)
+	}
+	log.WithField("nColumns", len(found)).WithField("root", fmt.Sprintf("%#x", r)).Info("Successfully downloaded data columns for checkpoint sync block")
+	return nil
+}
+
+// availabilityStore returns the AvailabilityStore used to check the blocks imported by initial-sync. Blocks from the
+// EIP-7594 fork onwards are checked against the custodied data columns, earlier blocks against their blobs.
+func (s *Service) availabilityStore() *das.LazilyPersistentStoreColumn {
+	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncSidecarRequirements)
+	blobs := das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv)
+	return das.NewLazilyPersistentStoreColumn(s.cfg.DataColumnStorage, verification.NewDataColumnBatchVerifier(), s.custody, blobs)
+}
+
 func shufflePeers(pids []peer.ID) {
 	rg := rand.NewGenerator()
 	rg.Shuffle(len(pids), func(i, j int) {
@@ -0,0 +1,107 @@
+package sync
+
+import (
+	"testing"
+
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	types "github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func setupPeerDASTestConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	repositionFutureEpochs(cfg)
+	cfg.Eip7594ForkEpoch = cfg.DenebForkEpoch + 10
+	params.OverrideBeaconConfig(cfg)
+}
+
+func TestDataColumnRPCMinValidSlot(t *testing.T) {
+	setupPeerDASTestConfig(t)
+	forkSlot, err := slots.EpochStart(params.BeaconConfig().Eip7594ForkEpoch)
+	require.NoError(t, err)
+	minReqEpochs := params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest
+	cases := []struct {
+		name     string
+		current  types.Epoch
+		expected types.Slot
+	}{
+		{
+			name:     "before fork",
+			current:  params.BeaconConfig().Eip7594ForkEpoch - 1,
+			expected: forkSlot,
+		},
+		{
+			name:     "after fork, before expiry starts",
+			current:  params.BeaconConfig().Eip7594ForkEpoch + minReqEpochs,
+			expected: forkSlot,
+		},
+		{
+			name:     "expiry starts one epoch after fork + MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS",
+			current:  params.BeaconConfig().Eip7594ForkEpoch + minReqEpochs + 1,
+			expected: forkSlot + params.BeaconConfig().SlotsPerEpoch,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			current, err := slots.EpochStart(c.current)
+			require.NoError(t, err)
+			got, err := DataColumnRPCMinValidSlot(current)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, got)
+		})
+	}
+}
+
+func TestDataColumnsByRangeValidation(t *testing.T) {
+	setupPeerDASTestConfig(t)
+	forkSlot, err := slots.EpochStart(params.BeaconConfig().Eip7594ForkEpoch)
+	require.NoError(t, err)
+	current := forkSlot + 100
+
+	t.Run("zero count", func(t *testing.T) {
+		_, _, err := validateDataColumnsByRange(&ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Columns: []uint64{0}}, current)
+		require.ErrorIs(t, err, p2ptypes.ErrInvalidRequest)
+	})
+	t.Run("no columns", func(t *testing.T) {
+		_, _, err := validateDataColumnsByRange(&ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 1}, current)
+		require.ErrorIs(t, err, p2ptypes.ErrInvalidRequest)
+	})
+	t.Run("column out of range", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 1, Columns: []uint64{fieldparams.NumberOfColumns}}
+		_, _, err := validateDataColumnsByRange(req, current)
+		require.ErrorIs(t, err, p2ptypes.ErrInvalidRequest)
+	})
+	t.Run("duplicate columns removed", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 5, Columns: []uint64{3, 1, 3}}
+		rp, columns, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.DeepEqual(t, []uint64{3, 1}, columns)
+		require.Equal(t, forkSlot, rp.start)
+		require.Equal(t, forkSlot+4, rp.end)
+	})
+	t.Run("start before fork is clamped", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot - 10, Count: 5, Columns: []uint64{0}}
+		rp, _, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.Equal(t, forkSlot, rp.start)
+		require.Equal(t, forkSlot, rp.end)
+	})
+	t.Run("start after current is a noop", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: current + 1, Count: 5, Columns: []uint64{0}}
+		rp, _, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), rp.size)
+	})
+	t.Run("count capped at batch limit", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: forkSlot, Count: 1000, Columns: []uint64{0}}
+		rp, _, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.Equal(t, dataColumnBatchLimit(), rp.size)
+		require.Equal(t, current, rp.end)
+	})
+}
@@ -0,0 +1,33 @@
+package sync
+
+import (
+	"testing"
+
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestDataColumnsByRootValidation(t *testing.T) {
+	root := make([]byte, 32)
+	t.Run("ok", func(t *testing.T) {
+		req := p2ptypes.DataColumnSidecarsByRootReq{
+			{BlockRoot: root, Index: 0},
+			{BlockRoot: root, Index: fieldparams.NumberOfColumns - 1},
+		}
+		require.NoError(t, validateDataColumnByRootRequest(req))
+	})
+	t.Run("index out of range", func(t *testing.T) {
+		req := p2ptypes.DataColumnSidecarsByRootReq{{BlockRoot: root, Index: fieldparams.NumberOfColumns}}
+		require.ErrorIs(t, validateDataColumnByRootRequest(req), p2ptypes.ErrInvalidRequest)
+	})
+	t.Run("exceeds req max", func(t *testing.T) {
+		req := make(p2ptypes.DataColumnSidecarsByRootReq, params.BeaconConfig().MaxRequestDataColumnSidecars+1)
+		for i := range req {
+			req[i] = &ethpb.DataColumnIdentifier{BlockRoot: root}
+		}
+		require.ErrorIs(t, validateDataColumnByRootRequest(req), p2ptypes.ErrMaxColumnReqExceeded)
+	})
+}
@@ -0,0 +1,186 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	db "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestLightClientBootstrapRPCHandler(t *testing.T) {
+	setupLightClientTestConfig(t)
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	d := db.SetupDB(t)
+	chain := &mock.ChainService{ValidatorsRoot: [32]byte{'a'}}
+	r := &Service{cfg: &config{beaconDB: d, p2p: p1, chain: chain}, rateLimiter: newRateLimiter(p1)}
+	bootstrap := util.HydrateLightClientBootstrapDeneb(&pb.LightClientBootstrapDeneb{
+		Header: &pb.LightClientHeaderDeneb{Beacon: &pb.BeaconBlockHeader{Slot: 64}},
+	})
+	root := [32]byte{'b'}
+	require.NoError(t, d.SaveLightClientBootstrap(context.Background(), root, bootstrap))
+
+	pcl := protocol.ID(p2p.RPCLightClientBootstrapTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(2, 2, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		ctxBytes, err := readContextFromStream(stream)
+		require.NoError(t, err)
+		want, err := forks.ForkDigestFromEpoch(slots.ToEpoch(64), chain.ValidatorsRoot[:])
+		require.NoError(t, err)
+		assert.DeepEqual(t, want[:], ctxBytes)
+		got := &pb.LightClientBootstrapDeneb{}
+		require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, got))
+		assert.DeepEqual(t, bootstrap, got)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := p2ptypes.LightClientBootstrapReq(root)
+	require.NoError(t, r.lightClientBootstrapRPCHandler(context.Background(), &req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	// Unknown block roots are reported as unavailable.
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err = p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req = p2ptypes.LightClientBootstrapReq{'c'}
+	require.ErrorIs(t, r.lightClientBootstrapRPCHandler(context.Background(), &req, stream), p2ptypes.ErrResourceUnavailable)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler(t *testing.T) {
+	setupLightClientTestConfig(t)
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	d := db.SetupDB(t)
+	r := &Service{cfg: &config{beaconDB: d, p2p: p1, chain: &mock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	for _, period := range []uint64{1, 2, 4} {
+		update := util.HydrateLightClientUpdate(&pb.LightClientUpdateAltair{SignatureSlot: primitives.Slot(period)})
+		require.NoError(t, d.SaveLightClientUpdate(context.Background(), period, update))
+	}
+
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10, 10, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		// The updates stop at the first missing period.
+		for _, period := range []uint64{1, 2} {
+			expectSuccess(t, stream)
+			_, err := readContextFromStream(stream)
+			require.NoError(t, err)
+			got := &pb.LightClientUpdateAltair{}
+			require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, got))
+			assert.Equal(t, primitives.Slot(period), got.SignatureSlot)
+		}
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(context.Background(), &pb.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 4}, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeInvalidRequest, p2ptypes.ErrInvalidRequest.Error(), stream)
+	})
+	stream, err = p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.lightClientUpdatesByRangeRPCHandler(context.Background(), &pb.LightClientUpdatesByRangeRequest{StartPeriod: 1}, stream), p2ptypes.ErrInvalidRequest)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientFinalityUpdateRPCHandler(t *testing.T) {
+	setupLightClientTestConfig(t)
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	chain := &mock.ChainService{}
+	r := &Service{cfg: &config{p2p: p1, chain: chain}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCLightClientFinalityUpdateTopicV1)
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(2, 2, time.Second, false)
+
+	// No finality update was computed yet.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.lightClientFinalityUpdateRPCHandler(context.Background(), new(interface{}), stream), p2ptypes.ErrResourceUnavailable)
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	chain.LCFinalityUpdate = util.HydrateLightClientFinalityUpdate(&pb.LightClientFinalityUpdateAltair{SignatureSlot: 10})
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		_, err := readContextFromStream(stream)
+		require.NoError(t, err)
+		got := &pb.LightClientFinalityUpdateAltair{}
+		require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, got))
+		assert.DeepEqual(t, chain.LCFinalityUpdate, got)
+	})
+	stream, err = p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientFinalityUpdateRPCHandler(context.Background(), new(interface{}), stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+// setupLightClientTestConfig schedules Altair at genesis, Capella at epoch 1 and Deneb at epoch 2, so that
+// light client data of each of their containers can be served.
+func setupLightClientTestConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 1
+	cfg.DenebForkEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+}
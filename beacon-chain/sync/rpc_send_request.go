@@ -27,6 +27,7 @@ import (
 
 var errBlobChunkedReadFailure = errors.New("failed to read stream of chunk-encoded blobs")
 var errBlobUnmarshal = errors.New("Could not unmarshal chunk-encoded blob")
+var errDataColumnChunkedReadFailure = errors.New("failed to read stream of chunk-encoded data columns")
 
 // Any error from the following declaration block should result in peer downscoring.
 var (
@@ -40,6 +41,10 @@ var (
 	errBlobResponseOutOfBounds        = errors.Wrap(ErrInvalidFetchedData, "received BlobSidecar with slot outside BlobSidecarsByRangeRequest bounds")
 	errChunkResponseBlockMismatch     = errors.Wrap(ErrInvalidFetchedData, "blob block details do not match")
 	errChunkResponseParentMismatch    = errors.Wrap(ErrInvalidFetchedData, "parent root for response element doesn't match previous element root")
+
+	errMaxRequestDataColumnSidecarsExceeded = errors.Wrap(ErrInvalidFetchedData, "peer exceeded req data column chunk tx limit")
+	errDataColumnResponseOutOfBounds        = errors.Wrap(ErrInvalidFetchedData, "received DataColumnSidecar with slot outside DataColumnSidecarsByRangeRequest bounds")
+	errUnrequestedDataColumn                = errors.Wrap(ErrInvalidFetchedData, "received DataColumnSidecar for a column that was not requested")
 )
 
 // BeaconBlockProcessor defines a block processing function, which allows to start utilizing
@@ -182,6 +187,95 @@ func SendBlobsByRangeRequest(ctx context.Context, tor blockchain.TemporalOracle,
 	return readChunkEncodedBlobs(stream, p2pApi.Encoding(), ctxMap, composeBlobValidations(vfuncs...), max)
 }
 
+// SendDataColumnSidecarsByRangeRequest sends a DataColumnSidecarsByRange request and returns the data column sidecars
+// in the response. Sidecars outside of the requested slot range or column set are rejected.
+func SendDataColumnSidecarsByRangeRequest(ctx context.Context, tor blockchain.TemporalOracle, p2pApi p2p.SenderEncoder, pid peer.ID, ctxMap ContextByteVersions, req *pb.DataColumnSidecarsByRangeRequest) ([]blocks.RODataColumn, error) {
+	topic, err := p2p.TopicFromMessage(p2p.DataColumnSidecarsByRangeName, slots.ToEpoch(tor.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"topic":     topic,
+		"startSlot": req.StartSlot,
+		"count":     req.Count,
+		"columns":   len(req.Columns),
+	}).Debug("Sending data column by range request")
+	stream, err := p2pApi.Send(ctx, req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	max := params.BeaconConfig().MaxRequestDataColumnSidecars
+	if max > req.Count*uint64(len(req.Columns)) {
+		max = req.Count * uint64(len(req.Columns))
+	}
+	requested := make(map[uint64]bool, len(req.Columns))
+	for _, c := range req.Columns {
+		requested[c] = true
+	}
+	end := req.StartSlot + primitives.Slot(req.Count)
+	vf := func(sc blocks.RODataColumn) error {
+		if sc.Slot() < req.StartSlot || sc.Slot() >= end {
+			return errors.Wrapf(errDataColumnResponseOutOfBounds, "req start,end:%d,%d, resp:%d", req.StartSlot, end, sc.Slot())
+		}
+		if !requested[sc.Index] {
+			return errors.Wrapf(errUnrequestedDataColumn, "index=%d", sc.Index)
+		}
+		return nil
+	}
+	sidecars := make([]blocks.RODataColumn, 0)
+	// Attempt an extra read beyond max to check if the peer is sending more sidecars than allowed.
+	for i := uint64(0); i < max+1; i++ {
+		sc, err := readChunkedDataColumnSidecar(stream, p2pApi.Encoding(), ctxMap, vf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if i == max {
+			return nil, errMaxRequestDataColumnSidecarsExceeded
+		}
+		sidecars = append(sidecars, sc)
+	}
+	return sidecars, nil
+}
+
+func readChunkedDataColumnSidecar(stream network.Stream, encoding encoder.NetworkEncoding, ctxMap ContextByteVersions, vf func(blocks.RODataColumn) error) (blocks.RODataColumn, error) {
+	var dc blocks.RODataColumn
+	code, msg, err := ReadStatusCode(stream, encoding)
+	if err != nil {
+		return dc, err
+	}
+	if code != 0 {
+		return dc, errors.Wrap(errDataColumnChunkedReadFailure, msg)
+	}
+	ctxb, err := readContextFromStream(stream)
+	if err != nil {
+		return dc, errors.Wrap(err, "error reading chunk context bytes from stream")
+	}
+	v, found := ctxMap[bytesutil.ToBytes4(ctxb)]
+	if !found {
+		return dc, errors.Wrapf(errDataColumnChunkedReadFailure, "unrecognized fork digest %#x", ctxb)
+	}
+	if v < version.Deneb {
+		return dc, fmt.Errorf("unexpected context bytes for DataColumnSidecar, ctx=%#x, v=%s", ctxb, version.String(v))
+	}
+	pb := &ethpb.DataColumnSidecar{}
+	if err := encoding.DecodeWithMaxLength(stream, pb); err != nil {
+		return dc, errors.Wrap(err, "failed to decode the protobuf-encoded DataColumnSidecar message from RPC chunk stream")
+	}
+	dc, err = blocks.NewRODataColumn(pb)
+	if err != nil {
+		return dc, errors.Wrap(err, "unexpected error initializing RODataColumn")
+	}
+	if err := vf(dc); err != nil {
+		return dc, errors.Wrap(err, "validation failure decoding data column RPC response")
+	}
+	return dc, nil
+}
+
 func SendBlobSidecarByRoot(
 	ctx context.Context, tor blockchain.TemporalOracle, p2pApi p2p.P2P, pid peer.ID,
 	ctxMap ContextByteVersions, req *p2ptypes.BlobSidecarsByRootReq,
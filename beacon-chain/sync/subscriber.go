@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
@@ -123,6 +124,21 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 				digest,
 			)
 		}
+		// The light client containers are implemented up to Deneb, Electra changed the depth of their branches.
+		if features.Get().EnableLightClient && epoch < params.BeaconConfig().ElectraForkEpoch {
+			s.subscribe(
+				p2p.LightClientFinalityUpdateTopicFormat,
+				s.validateLightClientFinalityUpdate,
+				s.lightClientFinalityUpdateSubscriber,
+				digest,
+			)
+			s.subscribe(
+				p2p.LightClientOptimisticUpdateTopicFormat,
+				s.validateLightClientOptimisticUpdate,
+				s.lightClientOptimisticUpdateSubscriber,
+				digest,
+			)
+		}
 	}
 
 	// New Gossip Topic in Capella
@@ -145,6 +161,27 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 			params.BeaconConfig().BlobsidecarSubnetCount,
 		)
 	}
+
+	// New Gossip Topics with EIP-7594
+	if params.PeerDASEnabled(epoch) {
+		s.subscribeCustodySubnets(digest)
+	}
+}
+
+// subscribeCustodySubnets subscribes to the data column subnets of every column custodied by this node.
+func (s *Service) subscribeCustodySubnets(digest [4]byte) {
+	custody, err := p2p.CustodyColumns(s.cfg.p2p.PeerID())
+	if err != nil {
+		log.WithError(err).Error("Could not compute custody columns")
+		return
+	}
+	for subnet := range peerdas.CustodySubnets(custody) {
+		s.subscribeWithBase(
+			s.addDigestAndIndexToTopic(p2p.DataColumnSubnetTopicFormat, digest, subnet),
+			s.validateDataColumn,   /* validator */
+			s.dataColumnSubscriber, /* message handler */
+		)
+	}
 }
 
 // subscribe to a given topic with a given validator and subscription handler.
@@ -305,6 +342,9 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			log.WithError(err).WithFields(fields).Debugf("Gossip message was rejected")
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
 		}
+		if b != pubsub.ValidationAccept && err != nil {
+			s.cfg.p2p.TraceValidationFailure(msg, err)
+		}
 		if b == pubsub.ValidationIgnore {
 			if err != nil && !errorIsIgnored(err) {
 				log.WithError(err).WithFields(logrus.Fields{
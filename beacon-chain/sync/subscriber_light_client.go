@@ -0,0 +1,27 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientFinalityUpdateSubscriber handles validated finality updates. They match the locally computed
+// update, so there is nothing left to do once they were forwarded by the validator.
+func (*Service) lightClientFinalityUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	if _, ok := msg.(ethpb.LightClientFinalityUpdate); !ok {
+		return errors.Errorf("incorrect type of message received, wanted a light client finality update but got %T", msg)
+	}
+	return nil
+}
+
+// lightClientOptimisticUpdateSubscriber handles validated optimistic updates. They match the locally computed
+// update, so there is nothing left to do once they were forwarded by the validator.
+func (*Service) lightClientOptimisticUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	if _, ok := msg.(ethpb.LightClientOptimisticUpdate); !ok {
+		return errors.Errorf("incorrect type of message received, wanted a light client optimistic update but got %T", msg)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func (s *Service) validateDataColumn(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	receivedTime := prysmTime.Now()
+
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+	if msg.Topic == nil {
+		return pubsub.ValidationReject, errInvalidTopic
+	}
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		log.WithError(err).Error("Failed to decode message")
+		return pubsub.ValidationReject, err
+	}
+
+	dpb, ok := m.(*eth.DataColumnSidecar)
+	if !ok {
+		log.WithField("message", m).Error("Message is not of type *eth.DataColumnSidecar")
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	column, err := blocks.NewRODataColumn(dpb)
+	if err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "rodatacolumn conversion failure")
+	}
+	vf := s.newColumnVerifier(column, verification.GossipColumnSidecarRequirements)
+
+	if err := vf.DataColumnSidecarValid(); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	// [REJECT] The sidecar is for the correct subnet -- i.e. compute_subnet_for_data_column_sidecar(sidecar.index) == subnet_id.
+	want := fmt.Sprintf("data_column_sidecar_%d/", peerdas.ComputeSubnetForDataColumnSidecar(column.Index))
+	if !strings.Contains(*msg.Topic, want) {
+		log.WithFields(logging.DataColumnFields(column)).Debug("Sidecar index does not match topic")
+		return pubsub.ValidationReject, fmt.Errorf("wrong topic name: %s", *msg.Topic)
+	}
+
+	if err := vf.NotFromFutureSlot(); err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+
+	startTime, err := slots.ToTime(uint64(s.cfg.chain.GenesisTime().Unix()), column.Slot())
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+
+	// [IGNORE] The sidecar is the first sidecar for the tuple (block_header.slot, block_header.proposer_index, sidecar.index)
+	// with valid header signature, sidecar inclusion proof, and kzg proof.
+	if s.hasSeenDataColumnIndex(column.Slot(), column.ProposerIndex(), column.Index) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	if err := vf.SlotAboveFinalized(); err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+
+	if err := vf.SidecarParentSeen(s.hasBadBlock); err != nil {
+		go func() {
+			if err := s.sendBatchRootRequest(context.Background(), [][32]byte{column.ParentRoot()}, rand.NewGenerator()); err != nil {
+				log.WithError(err).WithFields(logging.DataColumnFields(column)).Debug("Failed to send batch root request")
+			}
+		}()
+		missingParentDataColumnSidecarCount.Inc()
+		return pubsub.ValidationIgnore, err
+	}
+
+	if err := vf.ValidProposerSignature(ctx); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	if err := vf.SidecarParentValid(s.hasBadBlock); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	if err := vf.SidecarParentSlotLower(); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	if err := vf.SidecarDescendsFromFinalized(); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	if err := vf.SidecarInclusionProven(); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	if err := vf.SidecarKzgProofVerified(); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	if err := vf.SidecarProposerExpected(ctx); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	fields := logging.DataColumnFields(column)
+	fields["sinceSlotStartTime"] = receivedTime.Sub(startTime)
+	fields["validationTime"] = s.cfg.clock.Now().Sub(receivedTime)
+	log.WithFields(fields).Debug("Received data column sidecar gossip")
+
+	verifiedColumn, err := vf.VerifiedRODataColumn()
+	if err != nil {
+		return pubsub.ValidationReject, err
+	}
+	msg.ValidatorData = verifiedColumn
+	return pubsub.ValidationAccept, nil
+}
+
+// Returns true if the data column with the same slot, proposer index, and column index has been seen before.
+func (s *Service) hasSeenDataColumnIndex(slot primitives.Slot, proposerIndex primitives.ValidatorIndex, index uint64) bool {
+	s.seenDataColumnLock.RLock()
+	defer s.seenDataColumnLock.RUnlock()
+	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIndex))...)
+	b = append(b, bytesutil.Bytes32(index)...)
+	_, seen := s.seenDataColumnCache.Get(string(b))
+	return seen
+}
+
+// Sets the data column with the same slot, proposer index, and column index as seen.
+func (s *Service) setSeenDataColumnIndex(slot primitives.Slot, proposerIndex primitives.ValidatorIndex, index uint64) {
+	s.seenDataColumnLock.Lock()
+	defer s.seenDataColumnLock.Unlock()
+	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIndex))...)
+	b = append(b, bytesutil.Bytes32(index)...)
+	s.seenDataColumnCache.Add(string(b), true)
+}
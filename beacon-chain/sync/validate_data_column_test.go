@@ -0,0 +1,120 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestValidateDataColumn_ErrorPathsWithMock(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier *verification.MockDataColumnVerifier
+		result   pubsub.ValidationResult
+	}{
+		{
+			name:     "sidecar invalid",
+			verifier: &verification.MockDataColumnVerifier{ErrDataColumnSidecarValid: errors.New("sidecar invalid")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "slot too early",
+			verifier: &verification.MockDataColumnVerifier{ErrSlotTooEarly: errors.New("slot too early")},
+			result:   pubsub.ValidationIgnore,
+		},
+		{
+			name:     "slot above finalized",
+			verifier: &verification.MockDataColumnVerifier{ErrSlotAboveFinalized: errors.New("slot above finalized")},
+			result:   pubsub.ValidationIgnore,
+		},
+		{
+			name:     "valid proposer signature",
+			verifier: &verification.MockDataColumnVerifier{ErrValidProposerSignature: errors.New("valid proposer signature")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "sidecar parent seen",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarParentSeen: errors.New("sidecar parent seen")},
+			result:   pubsub.ValidationIgnore,
+		},
+		{
+			name:     "sidecar parent valid",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarParentValid: errors.New("sidecar parent valid")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "sidecar parent slot lower",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarParentSlotLower: errors.New("sidecar parent slot lower")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "descends from finalized",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarDescendsFromFinalized: errors.New("descends from finalized")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "inclusion proven",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarInclusionProven: errors.New("inclusion proven")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "kzg proof verified",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarKzgProofVerified: errors.New("kzg proof verified")},
+			result:   pubsub.ValidationReject,
+		},
+		{
+			name:     "sidecar proposer expected",
+			verifier: &verification.MockDataColumnVerifier{ErrSidecarProposerExpected: errors.New("sidecar proposer expected")},
+			result:   pubsub.ValidationReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := p2ptest.NewTestP2P(t)
+			chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0)}
+			s := &Service{
+				seenDataColumnCache: lruwrpr.New(10),
+				seenPendingBlocks:   make(map[[32]byte]bool),
+				cfg:                 &config{chain: chainService, p2p: p, initialSync: &mockSync.Sync{}, clock: startup.NewClock(chainService.Genesis, chainService.ValidatorsRoot)}}
+			s.newColumnVerifier = func(blocks.RODataColumn, []verification.Requirement) verification.DataColumnVerifier {
+				return tt.verifier
+			}
+
+			_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, chainService.CurrentSlot()+1, 1)
+			msg := columns[0].DataColumnSidecar
+			buf := new(bytes.Buffer)
+			_, err := p.Encoding().EncodeGossip(buf, msg)
+			require.NoError(t, err)
+
+			digest, err := s.currentForkDigest()
+			require.NoError(t, err)
+			topic := s.addDigestAndIndexToTopic(p2p.DataColumnSubnetTopicFormat, digest, peerdas.ComputeSubnetForDataColumnSidecar(msg.Index))
+			topic += p.Encoding().ProtocolSuffix()
+			result, err := s.validateDataColumn(ctx, "", &pubsub.Message{
+				Message: &pb.Message{
+					Data:  buf.Bytes(),
+					Topic: &topic,
+				}})
+			require.ErrorContains(t, tt.name, err)
+			require.Equal(t, tt.result, result)
+		})
+	}
+}
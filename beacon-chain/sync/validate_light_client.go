@@ -0,0 +1,118 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errLightClientUpdateTooEarly   = errors.New("light client update received before one third of its signature slot")
+	errLightClientUpdateNotMatched = errors.New("light client update does not match the locally computed one")
+)
+
+// validateLightClientFinalityUpdate validates light_client_finality_update gossip messages.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_finality_update
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	update, ok := m.(ethpb.LightClientFinalityUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+
+	if !lightClient.IsSupportedSlot(update.AttestedHeaderVal().GetBeacon().GetSlot()) {
+		return pubsub.ValidationIgnore, lightClient.ErrUnsupportedFork
+	}
+	// [IGNORE] The finality_update is received after the block at signature_slot was given enough time
+	// to propagate through the network.
+	if err := s.validateLightClientUpdateTime(update.GetSignatureSlot()); err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	// [IGNORE] The received finality_update matches the locally computed one exactly.
+	if !proto.Equal(update, s.cfg.chain.LightClientFinalityUpdate()) {
+		return pubsub.ValidationIgnore, errLightClientUpdateNotMatched
+	}
+	msg.ValidatorData = update
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate validates light_client_optimistic_update gossip messages.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_optimistic_update
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	update, ok := m.(ethpb.LightClientOptimisticUpdate)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+
+	if !lightClient.IsSupportedSlot(update.AttestedHeaderVal().GetBeacon().GetSlot()) {
+		return pubsub.ValidationIgnore, lightClient.ErrUnsupportedFork
+	}
+	// [IGNORE] The optimistic_update is received after the block at signature_slot was given enough time
+	// to propagate through the network.
+	if err := s.validateLightClientUpdateTime(update.GetSignatureSlot()); err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	// [IGNORE] The received optimistic_update matches the locally computed one exactly.
+	if !proto.Equal(update, s.cfg.chain.LightClientOptimisticUpdate()) {
+		return pubsub.ValidationIgnore, errLightClientUpdateNotMatched
+	}
+	msg.ValidatorData = update
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientUpdateTime checks that one third of the signature slot has passed, with a
+// MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance.
+func (s *Service) validateLightClientUpdateTime(signatureSlot primitives.Slot) error {
+	earliest := slots.BeginsAt(signatureSlot, s.cfg.chain.GenesisTime()).
+		Add(slots.DivideSlotBy(int64(params.BeaconConfig().IntervalsPerSlot))).
+		Add(-params.BeaconConfig().MaximumGossipClockDisparityDuration())
+	if prysmTime.Now().Before(earliest) {
+		return errors.Wrapf(errLightClientUpdateTooEarly, "%s early", earliest.Sub(prysmTime.Now()).Round(time.Millisecond))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
+	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestValidateLightClientFinalityUpdate(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.ElectraForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+	topic := fmt.Sprintf(p2p.LightClientFinalityUpdateTopicFormat, []byte{0xb5, 0x30, 0x3f, 0x2a}) + "/" + encoder.ProtocolSuffixSSZSnappy
+	update := func(signatureSlot primitives.Slot) *ethpb.LightClientFinalityUpdateAltair {
+		return util.HydrateLightClientFinalityUpdate(&ethpb.LightClientFinalityUpdateAltair{SignatureSlot: signatureSlot})
+	}
+	message := func(u *ethpb.LightClientFinalityUpdateAltair) *pubsub.Message {
+		enc, err := u.MarshalSSZ()
+		require.NoError(t, err)
+		return &pubsub.Message{Message: &pubsubpb.Message{Data: snappy.Encode(nil, enc), Topic: &topic}}
+	}
+	electra := update(10)
+	electra.AttestedHeader.Beacon.Slot = params.BeaconConfig().SlotsPerEpoch
+	// The genesis is set so that one third of slot 10 has passed, but not of slot 11.
+	genesis := time.Now().Add(-10*time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second - time.Second*time.Duration(params.BeaconConfig().SecondsPerSlot)/2)
+
+	tests := []struct {
+		name  string
+		local ethpb.LightClientFinalityUpdate
+		msg   *ethpb.LightClientFinalityUpdateAltair
+		want  pubsub.ValidationResult
+	}{
+		{
+			name:  "matches the local update",
+			local: update(10),
+			msg:   update(10),
+			want:  pubsub.ValidationAccept,
+		},
+		{
+			name:  "no local update",
+			local: nil,
+			msg:   update(10),
+			want:  pubsub.ValidationIgnore,
+		},
+		{
+			name:  "does not match the local update",
+			local: update(9),
+			msg:   update(10),
+			want:  pubsub.ValidationIgnore,
+		},
+		{
+			name:  "received too early",
+			local: update(11),
+			msg:   update(11),
+			want:  pubsub.ValidationIgnore,
+		},
+		{
+			name:  "electra attested header",
+			local: electra,
+			msg:   electra,
+			want:  pubsub.ValidationIgnore,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{cfg: &config{
+				p2p:         mockp2p.NewTestP2P(t),
+				initialSync: &mockSync.Sync{IsSyncing: false},
+				chain:       &mockChain.ChainService{Genesis: genesis, LCFinalityUpdate: tt.local},
+			}}
+			got, err := s.validateLightClientFinalityUpdate(context.Background(), "peer", message(tt.msg))
+			require.Equal(t, tt.want, got, err)
+		})
+	}
+}
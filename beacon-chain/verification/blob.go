@@ -27,6 +27,7 @@ const (
 	RequireSidecarInclusionProven
 	RequireSidecarKzgProofVerified
 	RequireSidecarProposerExpected
+	RequireDataColumnSidecarValid
 )
 
 var allSidecarRequirements = []Requirement{
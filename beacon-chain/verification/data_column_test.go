@@ -0,0 +1,155 @@
+package verification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestDataColumnBatchVerifier(t *testing.T) {
+	ctx := context.Background()
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	errKzg := errors.New("kzg")
+	passKzg := func([]blocks.RODataColumn) error { return nil }
+
+	batch := &DataColumnBatchVerifier{verifyKzg: passKzg}
+	vcs, err := batch.VerifiedRODataColumns(ctx, blk, columns[:4])
+	require.NoError(t, err)
+	require.Equal(t, 4, len(vcs))
+
+	batch.verifyKzg = func([]blocks.RODataColumn) error { return errKzg }
+	_, err = batch.VerifiedRODataColumns(ctx, blk, columns[:4])
+	require.ErrorIs(t, err, errKzg)
+
+	batch.verifyKzg = passKzg
+	other, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 2, 2)
+	_, err = batch.VerifiedRODataColumns(ctx, other, columns[:4])
+	require.ErrorIs(t, err, ErrBatchBlockRootMismatch)
+
+	bad, err := blocks.NewRODataColumnWithRoot(columns[5].DataColumnSidecar, blk.Root())
+	require.NoError(t, err)
+	bad.KzgCommitments = bad.KzgCommitments[:1]
+	bad.Column = bad.Column[:1]
+	bad.KzgProofs = bad.KzgProofs[:1]
+	_, err = batch.VerifiedRODataColumns(ctx, blk, []blocks.RODataColumn{bad})
+	require.ErrorIs(t, err, ErrColumnInclusionProofInvalid)
+}
+
+func TestDataColumnSidecarValid(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	ini := Initializer{}
+	v := ini.NewDataColumnVerifier(columns[0], GossipColumnSidecarRequirements)
+	require.NoError(t, v.DataColumnSidecarValid())
+	require.Equal(t, true, v.results.executed(RequireDataColumnSidecarValid))
+	require.NoError(t, v.results.result(RequireDataColumnSidecarValid))
+
+	bad, err := blocks.NewRODataColumnWithRoot(columns[1].DataColumnSidecar, columns[1].BlockRoot())
+	require.NoError(t, err)
+	bad.KzgProofs = nil
+	v = ini.NewDataColumnVerifier(bad, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.DataColumnSidecarValid(), ErrDataColumnSidecarInvalid)
+	require.NotNil(t, v.results.result(RequireDataColumnSidecarValid))
+}
+
+func TestColumnValidProposerSignature(t *testing.T) {
+	ctx := context.Background()
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	c := columns[0]
+	expectedSd := columnToSignatureData(c)
+	sc := &mockSignatureCache{
+		svcb: func(sig SignatureData) (bool, error) {
+			if sig != expectedSd {
+				t.Error("Did not see expected SignatureData")
+			}
+			return true, nil
+		},
+		vscb: func(sig SignatureData, v ValidatorAtIndexer) (err error) {
+			t.Error("VerifySignature should not be called if the result is cached")
+			return nil
+		},
+	}
+	ini := Initializer{shared: &sharedResources{sc: sc, sr: &mockStateByRooter{sbr: sbrErrorIfCalled(t)}}}
+	v := ini.NewDataColumnVerifier(c, GossipColumnSidecarRequirements)
+	require.NoError(t, v.ValidProposerSignature(ctx))
+	require.NoError(t, v.results.result(RequireValidProposerSignature))
+
+	// A cache miss falls back to verifying the signature against the parent state.
+	sc.svcb = func(sig SignatureData) (bool, error) {
+		return false, nil
+	}
+	sc.vscb = func(sig SignatureData, v ValidatorAtIndexer) (err error) {
+		return errors.New("signature mismatch")
+	}
+	ini = Initializer{shared: &sharedResources{sc: sc, sr: sbrForValOverride(c.ProposerIndex(), &ethpb.Validator{})}}
+	v = ini.NewDataColumnVerifier(c, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.ValidProposerSignature(ctx), ErrInvalidProposerSignature)
+	require.NotNil(t, v.results.result(RequireValidProposerSignature))
+}
+
+func TestColumnSidecarParent(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	c := columns[0]
+	fcLacks := &mockForkchoicer{HasNodeCB: func([32]byte) bool { return false }}
+	ini := Initializer{shared: &sharedResources{fc: fcLacks}}
+
+	v := ini.NewDataColumnVerifier(c, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarParentSeen(nil), ErrSidecarParentNotSeen)
+	require.NoError(t, v.SidecarParentSeen(badParentCb(t, c.ParentRoot(), true)))
+	require.ErrorIs(t, v.SidecarParentValid(badParentCb(t, c.ParentRoot(), true)), ErrSidecarParentInvalid)
+	require.ErrorIs(t, v.SidecarDescendsFromFinalized(), ErrSidecarNotFinalizedDescendent)
+
+	ini.shared.fc = &mockForkchoicer{SlotCB: func([32]byte) (primitives.Slot, error) { return c.Slot(), nil }}
+	require.ErrorIs(t, v.SidecarParentSlotLower(), ErrSlotNotAfterParent)
+}
+
+func TestColumnSidecarProofs(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	ini := Initializer{}
+	v := ini.NewDataColumnVerifier(columns[0], GossipColumnSidecarRequirements)
+	require.NoError(t, v.SidecarInclusionProven())
+	v.verifyKzg = func([]blocks.RODataColumn) error { return errors.New("kzg") }
+	require.ErrorIs(t, v.SidecarKzgProofVerified(), ErrSidecarKzgProofInvalid)
+
+	bad, err := blocks.NewRODataColumnWithRoot(columns[1].DataColumnSidecar, columns[1].BlockRoot())
+	require.NoError(t, err)
+	bad.KzgCommitmentsInclusionProof = make([][]byte, len(bad.KzgCommitmentsInclusionProof))
+	v = ini.NewDataColumnVerifier(bad, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarInclusionProven(), ErrColumnInclusionProofInvalid)
+}
+
+func TestColumnSidecarProposerExpected(t *testing.T) {
+	ctx := context.Background()
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	c := columns[0]
+	fc := &mockForkchoicer{TargetRootForEpochCB: fcReturnsTargetRoot([32]byte{})}
+
+	ini := Initializer{shared: &sharedResources{pc: &mockProposerCache{ProposerCB: pcReturnsIdx(c.ProposerIndex())}, fc: fc}}
+	v := ini.NewDataColumnVerifier(c, GossipColumnSidecarRequirements)
+	require.NoError(t, v.SidecarProposerExpected(ctx))
+
+	ini = Initializer{shared: &sharedResources{pc: &mockProposerCache{ProposerCB: pcReturnsIdx(c.ProposerIndex() + 1)}, fc: fc}}
+	v = ini.NewDataColumnVerifier(c, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarProposerExpected(ctx), ErrSidecarUnexpectedProposer)
+}
+
+func TestColumnRequirementSatisfaction(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	ini := Initializer{}
+	v := ini.NewDataColumnVerifier(columns[0], GossipColumnSidecarRequirements)
+
+	_, err := v.VerifiedRODataColumn()
+	require.ErrorIs(t, err, ErrColumnInvalid)
+
+	for _, r := range GossipColumnSidecarRequirements {
+		v.SatisfyRequirement(r)
+	}
+	vc, err := v.VerifiedRODataColumn()
+	require.NoError(t, err)
+	require.Equal(t, columns[0].Index, vc.Index)
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -57,6 +58,16 @@ func (ini *Initializer) NewBlobVerifier(b blocks.ROBlob, reqs []Requirement) *RO
 	}
 }
 
+// NewDataColumnVerifier creates a DataColumnVerifier for a single data column sidecar, with the given set of requirements.
+func (ini *Initializer) NewDataColumnVerifier(dc blocks.RODataColumn, reqs []Requirement) *RODataColumnVerifier {
+	return &RODataColumnVerifier{
+		sharedResources: ini.shared,
+		column:          dc,
+		results:         newResults(reqs...),
+		verifyKzg:       peerdas.VerifyDataColumnsSidecarKZGProofs,
+	}
+}
+
 // InitializerWaiter provides an Initializer once all dependent resources are ready
 // via the WaitForInitializer method.
 type InitializerWaiter struct {
@@ -61,3 +61,9 @@ func TestAllBlobRequirementsHaveStrings(t *testing.T) {
 		require.NotEqual(t, unknownRequirementName, allSidecarRequirements[i].String())
 	}
 }
+
+func TestAllColumnRequirementsHaveStrings(t *testing.T) {
+	for i := range allColumnSidecarRequirements {
+		require.NotEqual(t, unknownRequirementName, allColumnSidecarRequirements[i].String())
+	}
+}
@@ -0,0 +1,41 @@
+package verification
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+// VerifiedROBlobsForTrustedBlock verifies the blob sidecars of a block which was authenticated without checking its
+// proposer signature, for instance a block chained to the weak subjectivity checkpoint when importing a checkpoint
+// bundle. Each sidecar must carry the header and signature of the block, prove the inclusion of its commitment in the
+// block body and have a valid kzg proof. The kzg trusted setup must be loaded.
+func VerifiedROBlobsForTrustedBlock(blk blocks.ROBlock, scs []blocks.ROBlob) ([]blocks.VerifiedROBlob, error) {
+	if len(scs) == 0 {
+		return nil, nil
+	}
+	blkSig := blk.Signature()
+	for i := range scs {
+		if blk.Root() != scs[i].BlockRoot() {
+			return nil, ErrBatchBlockRootMismatch
+		}
+		if blkSig != bytesutil.ToBytes96(scs[i].SignedBlockHeader.Signature) {
+			return nil, ErrBatchSignatureMismatch
+		}
+		if scs[i].Index >= fieldparams.MaxBlobsPerBlock {
+			return nil, ErrBlobIndexInvalid
+		}
+		if err := blocks.VerifyKZGInclusionProof(scs[i]); err != nil {
+			return nil, ErrSidecarInclusionProofInvalid
+		}
+	}
+	if err := kzg.Verify(scs...); err != nil {
+		return nil, ErrSidecarKzgProofInvalid
+	}
+	vs := make([]blocks.VerifiedROBlob, len(scs))
+	for i := range scs {
+		vs[i] = blocks.NewVerifiedROBlob(scs[i])
+	}
+	return vs, nil
+}
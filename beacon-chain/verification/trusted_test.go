@@ -0,0 +1,46 @@
+package verification
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestVerifiedROBlobsForTrustedBlock(t *testing.T) {
+	require.NoError(t, kzg.Start())
+	blk, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 3, util.WithEmptyBlobs())
+
+	t.Run("valid", func(t *testing.T) {
+		vs, err := VerifiedROBlobsForTrustedBlock(blk, scs)
+		require.NoError(t, err)
+		require.Equal(t, len(scs), len(vs))
+		for i := range vs {
+			require.Equal(t, blk.Root(), vs[i].BlockRoot())
+		}
+	})
+	t.Run("no sidecars", func(t *testing.T) {
+		vs, err := VerifiedROBlobsForTrustedBlock(blk, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(vs))
+	})
+	t.Run("sidecar of another block", func(t *testing.T) {
+		_, other := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 2, 1, util.WithEmptyBlobs())
+		_, err := VerifiedROBlobsForTrustedBlock(blk, other)
+		require.ErrorIs(t, err, ErrBatchBlockRootMismatch)
+	})
+	t.Run("invalid inclusion proof", func(t *testing.T) {
+		blk, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 3, util.WithEmptyBlobs())
+		scs[0].CommitmentInclusionProof[0] = make([]byte, fieldparams.RootLength)
+		_, err := VerifiedROBlobsForTrustedBlock(blk, scs)
+		require.ErrorIs(t, err, ErrSidecarInclusionProofInvalid)
+	})
+	t.Run("invalid kzg proof", func(t *testing.T) {
+		blk, scs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 3, util.WithEmptyBlobs())
+		scs[1].Blob[0] = 0x01
+		_, err := VerifiedROBlobsForTrustedBlock(blk, scs)
+		require.ErrorIs(t, err, ErrSidecarKzgProofInvalid)
+	})
+}
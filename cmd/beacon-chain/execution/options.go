@@ -31,6 +31,37 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	if len(jwtSecret) > 0 {
 		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
 	}
+	if path := c.String(flags.RecordEngineCallsFlag.Name); path != "" {
+		opts = append(opts, execution.WithEngineCallRecorder(path))
+	}
+	fallbackOpts, err := parseFallbackEndpoints(c, jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, fallbackOpts...), nil
+}
+
+// Parses the fallback execution endpoints along with their JWT secrets. Fallback endpoints use the
+// secret of the primary endpoint unless their own secret files are provided, one per endpoint.
+func parseFallbackEndpoints(c *cli.Context, jwtSecret []byte) ([]execution.Option, error) {
+	endpoints := c.StringSlice(flags.FallbackExecutionEngineEndpoints.Name)
+	secretFiles := c.StringSlice(flags.FallbackExecutionJWTSecretFlag.Name)
+	if len(secretFiles) > 0 && len(secretFiles) != len(endpoints) {
+		return nil, fmt.Errorf("%s must be set once per %s, got %d secrets for %d endpoints",
+			flags.FallbackExecutionJWTSecretFlag.Name, flags.FallbackExecutionEngineEndpoints.Name, len(secretFiles), len(endpoints))
+	}
+	opts := make([]execution.Option, 0, len(endpoints))
+	for i, endpoint := range endpoints {
+		secret := jwtSecret
+		if len(secretFiles) > 0 {
+			var err error
+			secret, err = readJWTSecretFile(secretFiles[i])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read JWT secret file for fallback execution endpoint %d", i)
+			}
+		}
+		opts = append(opts, execution.WithFallbackHttpEndpoint(endpoint, secret))
+	}
 	return opts, nil
 }
 
@@ -47,6 +78,10 @@ func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
+	return readJWTSecretFile(jwtSecretFile)
+}
+
+func readJWTSecretFile(jwtSecretFile string) ([]byte, error) {
 	enc, err := file.ReadFileAsBytes(jwtSecretFile)
 	if err != nil {
 		return nil, err
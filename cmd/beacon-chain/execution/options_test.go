@@ -99,3 +99,55 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	_, err := parseExecutionChainEndpoint(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
+
+func Test_parseFallbackEndpoints(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	secret := bytesutil.ToBytes32([]byte("foo"))
+	require.NoError(t, file.WriteFile(secretFile, []byte(fmt.Sprintf("%#x", secret))))
+
+	t.Run("no fallback", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		ctx := cli.NewContext(&app, set, nil)
+		opts, err := parseFallbackEndpoints(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(opts))
+	})
+	t.Run("shared secret", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.Var(cli.NewStringSlice("http://a", "http://b"), flags.FallbackExecutionEngineEndpoints.Name, "")
+		ctx := cli.NewContext(&app, set, nil)
+		opts, err := parseFallbackEndpoints(ctx, secret[:])
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(opts))
+	})
+	t.Run("one secret per endpoint", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.Var(cli.NewStringSlice("http://a"), flags.FallbackExecutionEngineEndpoints.Name, "")
+		set.Var(cli.NewStringSlice(secretFile), flags.FallbackExecutionJWTSecretFlag.Name, "")
+		ctx := cli.NewContext(&app, set, nil)
+		opts, err := parseFallbackEndpoints(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(opts))
+	})
+	t.Run("secret count mismatch", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.Var(cli.NewStringSlice("http://a", "http://b"), flags.FallbackExecutionEngineEndpoints.Name, "")
+		set.Var(cli.NewStringSlice(secretFile), flags.FallbackExecutionJWTSecretFlag.Name, "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseFallbackEndpoints(ctx, nil)
+		require.ErrorContains(t, "must be set once per", err)
+	})
+	t.Run("bad secret file", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.Var(cli.NewStringSlice("http://a"), flags.FallbackExecutionEngineEndpoints.Name, "")
+		set.Var(cli.NewStringSlice("/tmp/askdjkajsd"), flags.FallbackExecutionJWTSecretFlag.Name, "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseFallbackEndpoints(ctx, nil)
+		require.ErrorContains(t, "no such file", err)
+	})
+}
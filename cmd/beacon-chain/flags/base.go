@@ -55,6 +55,27 @@ var (
 			"This is not required if using an IPC connection.",
 		Value: "",
 	}
+	// FallbackExecutionEngineEndpoints provides execution client endpoints to switch to when the primary one is unhealthy.
+	FallbackExecutionEngineEndpoints = &cli.StringSliceFlag{
+		Name: "fallback-execution-endpoint",
+		Usage: "Additional execution client http endpoints, used in the given order when the endpoint in use is offline " +
+			"or syncing. The beacon node switches back to the primary endpoint once it is healthy again. Forkchoice updates " +
+			"are sent to every endpoint, so that they follow the same head. This flag can be used multiple times.",
+	}
+	// FallbackExecutionJWTSecretFlag provides the JWT secret files of the fallback execution endpoints.
+	FallbackExecutionJWTSecretFlag = &cli.StringSliceFlag{
+		Name: "fallback-jwt-secret",
+		Usage: "Paths to the files containing the hex-encoded JWT secrets of the fallback execution endpoints, in the same " +
+			"order as --fallback-execution-endpoint. If not set, the fallback endpoints use the secret of --jwt-secret.",
+	}
+	// RecordEngineCallsFlag defines a file in which the calls to the execution client are recorded.
+	RecordEngineCallsFlag = &cli.StringFlag{
+		Name: "record-engine-api-calls",
+		Usage: "Path to a file in which every engine API and block JSON-RPC request to the execution client and its " +
+			"response are appended, with timing, as one JSON object per line. The deposit contract and log requests are " +
+			"not recorded. The recording can be replayed by the mock execution client of the beacon node tests. " +
+			"Meant for debugging, the file is never pruned.",
+	}
 	// JwtId is the id field of the JWT claims. The consensus layer client MAY use this to communicate a unique identifier for the individual consensus layer client
 	JwtId = &cli.StringFlag{
 		Name:  "jwt-id",
@@ -186,8 +207,9 @@ var (
 	}
 	// HistoricalSlasherNode is a set of beacon node flags required for performing historical detection with a slasher.
 	HistoricalSlasherNode = &cli.BoolFlag{
-		Name:  "historical-slasher-node",
-		Usage: "Enables required flags for serving historical data to a slasher client. Results in additional storage usage",
+		Name: "historical-slasher-node",
+		Usage: "Enables required flags for serving historical data to a slasher client. Results in additional storage usage. " +
+			"With the slasher enabled, also runs slashing detection on the stored blocks of the slasher history window, resuming from the last checkpoint after a restart",
 	}
 	// ChainID defines a flag to set the chain id. If none is set, it derives this value from NetworkConfig
 	ChainID = &cli.Uint64Flag{
@@ -44,6 +44,9 @@ var appFlags = []cli.Flag{
 	flags.ExecutionEngineEndpoint,
 	flags.ExecutionEngineHeaders,
 	flags.ExecutionJWTSecretFlag,
+	flags.FallbackExecutionEngineEndpoints,
+	flags.FallbackExecutionJWTSecretFlag,
+	flags.RecordEngineCallsFlag,
 	flags.RPCHost,
 	flags.RPCPort,
 	flags.CertFlag,
@@ -102,6 +105,9 @@ var appFlags = []cli.Flag{
 	cmd.P2PMetadata,
 	cmd.P2PAllowList,
 	cmd.P2PDenyList,
+	cmd.P2PScoringPolicy,
+	cmd.GossipTraceFile,
+	cmd.GossipTraceMaxSize,
 	cmd.PubsubQueueSize,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
@@ -136,7 +142,10 @@ var appFlags = []cli.Flag{
 	cmd.ApiTimeoutFlag,
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
+	checkpoint.BundlePath,
 	checkpoint.RemoteURL,
+	checkpoint.ProviderURLs,
+	checkpoint.Quorum,
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
@@ -147,6 +156,7 @@ var appFlags = []cli.Flag{
 	bflags.BackfillBatchSize,
 	bflags.BackfillWorkerCount,
 	bflags.BackfillOldestSlot,
+	bflags.BackfillEraDir,
 }
 
 func init() {
@@ -35,12 +35,23 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	if err != nil {
 		return nil, err
 	}
-	opts := []node.Option{node.WithBlobStorageOptions(
-		filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(blobStoragePath(c)),
-	)}
+	opts := []node.Option{
+		node.WithBlobStorageOptions(
+			filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(blobStoragePath(c)),
+		),
+		node.WithDataColumnStorageOptions(
+			filesystem.WithDataColumnRetentionEpochs(params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest),
+			filesystem.WithDataColumnBasePath(dataColumnStoragePath(c)),
+		),
+	}
 	return opts, nil
 }
 
+// dataColumnStoragePath returns a "data-columns" directory next to the blob storage directory.
+func dataColumnStoragePath(c *cli.Context) string {
+	return path.Join(path.Dir(blobStoragePath(c)), "data-columns")
+}
+
 func blobStoragePath(c *cli.Context) string {
 	blobsPath := c.Path(BlobStoragePathFlag.Name)
 	if blobsPath == "" {
@@ -40,4 +40,11 @@ var (
 		Usage: "Specifies the oldest slot that backfill should download. " +
 			"If this value is greater than current_slot - MIN_EPOCHS_FOR_BLOCK_REQUESTS, it will be ignored with a warning log.",
 	}
+	// BackfillEraDir sets a directory of era files to backfill blocks from before downloading them from peers.
+	BackfillEraDir = &cli.StringFlag{
+		Name: "backfill-era-dir",
+		Usage: "Directory of era files to backfill blocks from. " +
+			"Blocks found in era files are verified and imported before the remaining history is requested from peers. " +
+			"Blocks within the blob retention period are always backfilled from peers.",
+	}
 )
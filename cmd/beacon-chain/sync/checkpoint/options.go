@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/checkpoint"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,12 +24,34 @@ var (
 		Usage: "Rather than syncing from genesis, you can start processing from a ssz-serialized BeaconState+Block." +
 			" This flag allows you to specify a local file containing the checkpoint Block to load.",
 	}
+	// BundlePath defines a flag to start the beacon chain from a checkpoint bundle file, as produced by
+	// `prysmctl checkpoint-sync bundle`.
+	BundlePath = &cli.PathFlag{
+		Name: "checkpoint-bundle",
+		Usage: "Rather than syncing from genesis, you can start processing from a checkpoint bundle holding a " +
+			"ssz-serialized BeaconState+Block, the blob sidecars of the block and optionally some of its ancestors. " +
+			"This flag allows you to specify a local checkpoint bundle file to load. The bundle is verified against, " +
+			"and requires, the --weak-subjectivity-checkpoint flag.",
+	}
 	RemoteURL = &cli.StringFlag{
 		Name: "checkpoint-sync-url",
 		Usage: "URL of a synced beacon node to trust in obtaining checkpoint sync data. " +
 			"As an additional safety measure, it is strongly recommended to only use this option in conjunction with " +
 			"--weak-subjectivity-checkpoint flag",
 	}
+	// ProviderURLs adds beacon nodes to obtain checkpoint sync data from, in addition to --checkpoint-sync-url.
+	ProviderURLs = &cli.StringSliceFlag{
+		Name: "checkpoint-sync-provider-url",
+		Usage: "URL of an additional synced beacon node to obtain checkpoint sync data from. Can be repeated. " +
+			"When several checkpoint sync providers are configured, the finalized checkpoint must be agreed on by " +
+			"--checkpoint-sync-quorum of them, and the node refuses to start otherwise.",
+	}
+	// Quorum is the number of checkpoint sync providers which must agree on the finalized checkpoint.
+	Quorum = &cli.IntFlag{
+		Name: "checkpoint-sync-quorum",
+		Usage: "Number of checkpoint sync providers which must agree on the finalized checkpoint. " +
+			"Defaults to all of the configured providers.",
+	}
 )
 
 // BeaconNodeOptions is responsible for determining if the checkpoint sync options have been used, and if so,
@@ -36,11 +60,25 @@ var (
 func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	blockPath := c.Path(BlockPath.Name)
 	statePath := c.Path(StatePath.Name)
-	remoteURL := c.String(RemoteURL.Name)
-	if remoteURL != "" {
+	bundlePath := c.Path(BundlePath.Name)
+	remoteURLs := c.StringSlice(ProviderURLs.Name)
+	if remoteURL := c.String(RemoteURL.Name); remoteURL != "" {
+		remoteURLs = append([]string{remoteURL}, remoteURLs...)
+	}
+	if c.IsSet(Quorum.Name) && len(remoteURLs) == 0 {
+		return nil, fmt.Errorf("--%s specified without any checkpoint sync provider", Quorum.Name)
+	}
+	if bundlePath != "" {
+		if len(remoteURLs) > 0 || blockPath != "" || statePath != "" {
+			return nil, fmt.Errorf("--%s can not be combined with other checkpoint sync sources", BundlePath.Name)
+		}
+		return bundleOptions(c, bundlePath)
+	}
+	if len(remoteURLs) > 0 {
+		quorum := c.Int(Quorum.Name)
 		opt := func(node *node.BeaconNode) error {
 			var err error
-			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL)
+			node.CheckpointInitializer, err = checkpoint.NewQuorumAPIInitializer(remoteURLs, quorum)
 			if err != nil {
 				return errors.Wrap(err, "error while constructing beacon node api client for checkpoint sync")
 			}
@@ -68,3 +106,22 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	}
 	return []node.Option{opt}, nil
 }
+
+func bundleOptions(c *cli.Context, bundlePath string) ([]node.Option, error) {
+	wsc := c.String(flags.WeakSubjectivityCheckpoint.Name)
+	if wsc == "" {
+		return nil, fmt.Errorf("--%s requires --%s to verify the bundle", BundlePath.Name, flags.WeakSubjectivityCheckpoint.Name)
+	}
+	checkpt, err := helpers.ParseWeakSubjectivityInputString(wsc)
+	if err != nil {
+		return nil, err
+	}
+	opt := func(node *node.BeaconNode) (err error) {
+		node.CheckpointInitializer, err = checkpoint.NewBundleInitializer(bundlePath, checkpt)
+		if err != nil {
+			return errors.Wrap(err, "error preparing to initialize checkpoint from local checkpoint bundle")
+		}
+		return nil
+	}
+	return []node.Option{opt}, nil
+}
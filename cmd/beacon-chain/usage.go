@@ -110,6 +110,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
+			flags.FallbackExecutionEngineEndpoints,
+			flags.FallbackExecutionJWTSecretFlag,
+			flags.RecordEngineCallsFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
 			flags.BlockBatchLimit,
@@ -134,7 +137,10 @@ var appHelpFlagGroups = []flagGroup{
 			flags.JwtId,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
+			checkpoint.BundlePath,
 			checkpoint.RemoteURL,
+			checkpoint.ProviderURLs,
+			checkpoint.Quorum,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
@@ -143,6 +149,7 @@ var appHelpFlagGroups = []flagGroup{
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
 			backfill.BackfillOldestSlot,
+			backfill.BackfillEraDir,
 		},
 	},
 	{
@@ -166,6 +173,9 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
+			cmd.P2PScoringPolicy,
+			cmd.GossipTraceFile,
+			cmd.GossipTraceMaxSize,
 			cmd.PubsubQueueSize,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
@@ -185,6 +185,25 @@ var (
 			"192.168.0.0/16 would deny connections from peers on your local network only. The " +
 			"default is to accept all connections.",
 	}
+	// P2PScoringPolicy defines the path to a YAML file with the peer scoring policy.
+	P2PScoringPolicy = &cli.StringFlag{
+		Name: "p2p-scoring-policy",
+		Usage: "The path to a YAML file defining the peer scoring policy: scorer weights, peer IDs to allow, " +
+			"deny or pin, and score adjustments by client name as found in the peer agent strings.",
+	}
+	// GossipTraceFile defines the file the gossip trace is written to.
+	GossipTraceFile = &cli.StringFlag{
+		Name: "gossip-trace-file",
+		Usage: "Writes the receive, deliver, reject and duplicate events of every gossip message, along with " +
+			"per-topic propagation summaries at every epoch, as JSON lines to the given file, or to the standard " +
+			"output if set to -. Intended to diagnose late propagation, as it traces every message.",
+	}
+	// GossipTraceMaxSize defines the size at which the gossip trace file is rotated.
+	GossipTraceMaxSize = &cli.Uint64Flag{
+		Name:  "gossip-trace-max-size-mb",
+		Usage: "The size in megabytes at which the gossip trace file is rotated. The 3 last rotated files are kept.",
+		Value: 100,
+	}
 	PubsubQueueSize = &cli.IntFlag{
 		Name:  "pubsub-queue-size",
 		Usage: "The size of the pubsub validation and outbound queue for the node.",
@@ -251,7 +270,7 @@ var (
 	// track for performance updates
 	ValidatorMonitorIndicesFlag = &cli.IntSliceFlag{
 		Name:  "monitor-indices",
-		Usage: "List of validator indices to track performance",
+		Usage: "List of validator indices to track performance. Validators can also be tracked at runtime through the /prysm/v1/validators/performance/tracked endpoint.",
 	}
 
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
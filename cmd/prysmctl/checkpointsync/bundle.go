@@ -0,0 +1,97 @@
+package checkpointsync
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var bundleFlags = struct {
+	BeaconNodeHosts cli.StringSlice
+	Quorum          int
+	HistoryBlocks   uint64
+	Timeout         time.Duration
+}{}
+
+var bundleCmd = &cli.Command{
+	Name: "bundle",
+	Usage: "Download the latest finalized state, the most recent block it integrates with its blob sidecars, and " +
+		"optionally the blocks preceding it with their blob sidecars, into a single checkpoint bundle file. " +
+		"To be used for checkpoint sync with the beacon node --checkpoint-bundle flag.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionBundle(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not download checkpoint bundle")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection. Can be repeated to download from the first of several beacon nodes agreeing on the finalized checkpoint",
+			Destination: &bundleFlags.BeaconNodeHosts,
+			Value:       cli.NewStringSlice("localhost:3500"),
+		},
+		&cli.IntFlag{
+			Name:        "quorum",
+			Usage:       "number of beacon nodes which must agree on the finalized checkpoint when several are given. default: all of them",
+			Destination: &bundleFlags.Quorum,
+		},
+		&cli.Uint64Flag{
+			Name:        "history-blocks",
+			Usage:       "number of blocks preceding the checkpoint block to include in the bundle, with their blob sidecars",
+			Destination: &bundleFlags.HistoryBlocks,
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 4m",
+			Destination: &bundleFlags.Timeout,
+			Value:       time.Minute * 4,
+		},
+	},
+}
+
+func cliActionBundle(_ *cli.Context) error {
+	ctx := context.Background()
+	f := bundleFlags
+
+	clients, err := newClients(f.BeaconNodeHosts.Value(), f.Timeout)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	od, err := downloadFinalizedData(ctx, clients, f.Quorum)
+	if err != nil {
+		return err
+	}
+
+	// The blob sidecars and ancestors are requested by block root, so any of the beacon nodes can serve them.
+	var b *beacon.CheckpointBundle
+	for _, c := range clients {
+		b, err = beacon.DownloadCheckpointBundle(ctx, c, od, f.HistoryBlocks)
+		if err == nil {
+			break
+		}
+		log.WithError(err).WithField("host", c.NodeURL()).Warn("Could not download checkpoint bundle data from beacon node")
+	}
+	if b == nil {
+		return errors.New("could not download checkpoint bundle data from any beacon node")
+	}
+
+	bundlePath, err := od.SaveBundle(cwd, b)
+	if err != nil {
+		return err
+	}
+	log.Printf("saved checkpoint bundle to %s", bundlePath)
+
+	return nil
+}
@@ -9,6 +9,7 @@ var Commands = []*cli.Command{
 		Usage:   "commands for managing checkpoint sync",
 		Subcommands: []*cli.Command{
 			downloadCmd,
+			bundleCmd,
 		},
 	},
 }
@@ -12,8 +12,9 @@ import (
 )
 
 var downloadFlags = struct {
-	BeaconNodeHost string
-	Timeout        time.Duration
+	BeaconNodeHosts cli.StringSlice
+	Quorum          int
+	Timeout         time.Duration
 }{}
 
 var downloadCmd = &cli.Command{
@@ -27,11 +28,16 @@ var downloadCmd = &cli.Command{
 		return nil
 	},
 	Flags: []cli.Flag{
-		&cli.StringFlag{
+		&cli.StringSliceFlag{
 			Name:        "beacon-node-host",
-			Usage:       "host:port for beacon node connection",
-			Destination: &downloadFlags.BeaconNodeHost,
-			Value:       "localhost:3500",
+			Usage:       "host:port for beacon node connection. Can be repeated to download from the first of several beacon nodes agreeing on the finalized checkpoint",
+			Destination: &downloadFlags.BeaconNodeHosts,
+			Value:       cli.NewStringSlice("localhost:3500"),
+		},
+		&cli.IntFlag{
+			Name:        "quorum",
+			Usage:       "number of beacon nodes which must agree on the finalized checkpoint when several are given. default: all of them",
+			Destination: &downloadFlags.Quorum,
 		},
 		&cli.DurationFlag{
 			Name:        "http-timeout",
@@ -46,8 +52,7 @@ func cliActionDownload(_ *cli.Context) error {
 	ctx := context.Background()
 	f := downloadFlags
 
-	opts := []client.ClientOpt{client.WithTimeout(f.Timeout)}
-	client, err := beacon.NewClient(downloadFlags.BeaconNodeHost, opts...)
+	clients, err := newClients(f.BeaconNodeHosts.Value(), f.Timeout)
 	if err != nil {
 		return err
 	}
@@ -57,7 +62,7 @@ func cliActionDownload(_ *cli.Context) error {
 		return err
 	}
 
-	od, err := beacon.DownloadFinalizedData(ctx, client)
+	od, err := downloadFinalizedData(ctx, clients, f.Quorum)
 	if err != nil {
 		return err
 	}
@@ -76,3 +81,23 @@ func cliActionDownload(_ *cli.Context) error {
 
 	return nil
 }
+
+func newClients(hosts []string, timeout time.Duration) ([]*beacon.Client, error) {
+	opts := []client.ClientOpt{client.WithTimeout(timeout)}
+	clients := make([]*beacon.Client, len(hosts))
+	for i, h := range hosts {
+		c, err := beacon.NewClient(h, opts...)
+		if err != nil {
+			return nil, err
+		}
+		clients[i] = c
+	}
+	return clients, nil
+}
+
+func downloadFinalizedData(ctx context.Context, clients []*beacon.Client, quorum int) (*beacon.OriginData, error) {
+	if len(clients) == 1 {
+		return beacon.DownloadFinalizedData(ctx, clients[0])
+	}
+	return beacon.DownloadFinalizedDataWithQuorum(ctx, clients, quorum)
+}
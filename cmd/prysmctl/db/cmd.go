@@ -10,6 +10,11 @@ var Commands = []*cli.Command{
 			queryCmd,
 			bucketsCmd,
 			spanCmd,
+			pruneCmd,
+			compactCmd,
+			slasherCmd,
+			eraExportCmd,
+			eraImportCmd,
 		},
 	},
 }
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// compactTxMaxSize is the number of bytes copied in a single transaction when compacting the db.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+var compactFlags = struct {
+	Path string
+}{}
+
+var compactCmd = &cli.Command{
+	Name:  "compact",
+	Usage: "rewrite beaconchain.db to reclaim the space of deleted data, requires as much free disk space as the compacted db",
+	Action: func(cliCtx *cli.Context) error {
+		if err := compactAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not compact db")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &compactFlags.Path,
+			Required:    true,
+		},
+	},
+}
+
+func compactAction(_ *cli.Context) error {
+	return compactDB(kv.StoreDatafilePath(compactFlags.Path))
+}
+
+// compactDB rewrites the bolt db at srcPath into a new file, then replaces srcPath with it.
+func compactDB(srcPath string) error {
+	dstPath := srcPath + ".compact"
+	srcInfo, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	src, err := getDB(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	if err := os.Remove(dstPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not remove leftover compacted db")
+	}
+	dst, err := getDB(dstPath)
+	if err != nil {
+		return err
+	}
+	log.WithField("path", srcPath).Info("Compacting db")
+	if err := bolt.Compact(dst, src, compactTxMaxSize); err != nil {
+		if cErr := dst.Close(); cErr != nil {
+			log.WithError(cErr).Error("Could not close compacted db")
+		}
+		if rErr := os.Remove(dstPath); rErr != nil {
+			log.WithError(rErr).Error("Could not remove compacted db")
+		}
+		return errors.Wrap(err, "could not compact db")
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	if err := src.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(dstPath, srcPath); err != nil {
+		return errors.Wrap(err, "could not replace db with compacted db")
+	}
+	dstInfo, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"sizeBefore": srcInfo.Size(),
+		"sizeAfter":  dstInfo.Size(),
+	}).Info("Compacted db")
+	return nil
+}
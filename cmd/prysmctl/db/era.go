@@ -0,0 +1,234 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var eraExportFlags = struct {
+	Path string
+	Dir  string
+	From uint64
+	To   uint64
+}{}
+
+var eraExportCmd = &cli.Command{
+	Name: "era-export",
+	Usage: "write the finalized history in beaconchain.db to era files. Blocks must be stored with their full " +
+		"execution payloads, so databases which save blinded blocks cannot be exported",
+	Action: func(cliCtx *cli.Context) error {
+		if err := eraExportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &eraExportFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "dir",
+			Usage:       "directory the era files are written to",
+			Destination: &eraExportFlags.Dir,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "from-era",
+			Usage:       "first era to export",
+			Destination: &eraExportFlags.From,
+		},
+		&cli.Uint64Flag{
+			Name:        "to-era",
+			Usage:       "last era to export, defaults to the last finalized era",
+			Destination: &eraExportFlags.To,
+		},
+	},
+}
+
+func eraExportAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	flags := eraExportFlags
+	d, err := kv.NewKVStore(ctx, flags.Path)
+	if err != nil {
+		return errors.Wrap(err, "could not open db")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	e := era.NewExporter(d, flags.Dir)
+	to := flags.To
+	if !cliCtx.IsSet("to-era") {
+		if to, err = e.LastFinalizedEra(ctx); err != nil {
+			return err
+		}
+	}
+	if flags.From > to {
+		return errors.Errorf("from-era %d is after to-era %d", flags.From, to)
+	}
+	paths, err := e.Export(ctx, flags.From, to)
+	if err != nil {
+		if errors.Is(err, era.ErrBlindedBlock) {
+			return errors.Wrap(err, "the db stores blinded blocks, export requires a db that saves full execution payloads")
+		}
+		return err
+	}
+	log.WithFields(log.Fields{
+		"fromEra": flags.From,
+		"toEra":   to,
+		"files":   len(paths),
+	}).Info("Exported era files")
+	return nil
+}
+
+var eraImportFlags = struct {
+	Path       string
+	Dir        string
+	OldestSlot uint64
+}{}
+
+var eraImportCmd = &cli.Command{
+	Name: "era-import",
+	Usage: "backfill the block history of a checkpoint synced beaconchain.db from era files. An empty db is " +
+		"initialized from the state of the latest era file, as an alternative to checkpoint sync",
+	Action: func(cliCtx *cli.Context) error {
+		if err := eraImportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &eraImportFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "era-dir",
+			Usage:       "directory containing the era files to import",
+			Destination: &eraImportFlags.Dir,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "oldest-slot",
+			Usage:       "oldest slot to import blocks for, defaults to the genesis slot",
+			Destination: &eraImportFlags.OldestSlot,
+		},
+	},
+}
+
+func eraImportAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	flags := eraImportFlags
+	d, err := kv.NewKVStore(ctx, flags.Path)
+	if err != nil {
+		return errors.Wrap(err, "could not open db")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	if err := initEraOrigin(ctx, d, flags.Dir); err != nil {
+		return err
+	}
+	su, err := backfill.NewUpdater(ctx, d)
+	if err != nil {
+		return errors.Wrap(err, "could not read backfill status")
+	}
+	origin, err := d.OriginCheckpointBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "db was not checkpoint synced, there is no history to import")
+	}
+	st, err := d.StateOrError(ctx, origin)
+	if err != nil {
+		return errors.Wrap(err, "could not read origin state")
+	}
+	current := slots.CurrentSlot(st.GenesisTime())
+	n, err := backfill.ImportEra(ctx, su, flags.Dir, primitives.Slot(flags.OldestSlot), current)
+	if err != nil {
+		return err
+	}
+	log.WithField("blocks", n).Info("Imported blocks from era files")
+	return nil
+}
+
+// initEraOrigin saves the state of the latest era file, and the block it was built on, as the origin of an empty db.
+func initEraOrigin(ctx context.Context, d *kv.Store, dir string) error {
+	if _, err := d.OriginCheckpointBlockRoot(ctx); !errors.Is(err, kv.ErrNotFoundOriginBlockRoot) {
+		return err
+	}
+	if _, err := d.GenesisBlockRoot(ctx); !errors.Is(err, kv.ErrNotFoundGenesisBlockRoot) {
+		return err
+	}
+	files, err := era.ListFiles(dir)
+	if err != nil {
+		return err
+	}
+	eras := make([]uint64, 0, len(files))
+	for e := range files {
+		eras = append(eras, e)
+	}
+	if len(eras) == 0 {
+		return errors.Errorf("no era files found in %s", dir)
+	}
+	sort.Slice(eras, func(i, j int) bool { return eras[i] < eras[j] })
+	path := files[eras[len(eras)-1]]
+	f, err := era.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close era file")
+		}
+	}()
+	if err := f.Verify(); err != nil {
+		return errors.Wrapf(err, "could not verify era file %s", path)
+	}
+	st, err := f.State()
+	if err != nil {
+		return err
+	}
+	blk, err := f.Block(st.LatestBlockHeader().Slot)
+	if err != nil {
+		return errors.Wrapf(err, "could not read the latest block of the state in era file %s", path)
+	}
+	stateSSZ, err := st.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	blockSSZ, err := blk.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	if err := d.SaveOrigin(ctx, stateSSZ, blockSSZ); err != nil {
+		return errors.Wrap(err, "could not save era state as the db origin")
+	}
+	log.WithFields(log.Fields{
+		"file": filepath.Base(path),
+		"slot": st.Slot(),
+	}).Info("Initialized db from the latest era file")
+	return nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var pruneFlags = struct {
+	Path     string
+	BlobPath string
+	Epoch    uint64
+}{}
+
+var pruneCmd = &cli.Command{
+	Name: "prune",
+	Usage: "remove blocks, states, state summaries and blobs before the given epoch. History is pruned up to the " +
+		"last finalized state saved before the epoch, so that the remaining chain stays consistent",
+	Action: func(cliCtx *cli.Context) error {
+		if err := pruneAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not prune db")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &pruneFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "blob-path",
+			Usage:       "path to the blob storage directory, blobs are not pruned if unset",
+			Destination: &pruneFlags.BlobPath,
+		},
+		&cli.Uint64Flag{
+			Name:        "before-epoch",
+			Usage:       "history before the start of this epoch is removed, must not be after the finalized epoch",
+			Destination: &pruneFlags.Epoch,
+			Required:    true,
+		},
+	},
+}
+
+func pruneAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	flags := pruneFlags
+	beforeSlot, err := slots.EpochStart(primitives.Epoch(flags.Epoch))
+	if err != nil {
+		return err
+	}
+	d, err := kv.NewKVStore(ctx, flags.Path)
+	if err != nil {
+		return errors.Wrap(err, "could not open db")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	stats, err := d.PruneHistory(ctx, beforeSlot)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"anchorSlot":       stats.AnchorSlot,
+		"anchorRoot":       stats.AnchorRoot,
+		"blocks":           stats.Blocks,
+		"states":           stats.States,
+		"validatorEntries": stats.ValidatorEntries,
+	}).Info("Pruned db history")
+	if stats.AnchorSlot > 0 && flags.BlobPath != "" {
+		bs, err := filesystem.NewBlobStorage(filesystem.WithBasePath(flags.BlobPath))
+		if err != nil {
+			return err
+		}
+		if err := bs.PruneBefore(stats.AnchorSlot); err != nil {
+			return errors.Wrap(err, "could not prune blobs")
+		}
+		log.WithField("beforeSlot", stats.AnchorSlot).Info("Pruned blobs")
+	}
+	log.Info("Run `prysmctl db compact` to reclaim the space freed in the database file")
+	return nil
+}
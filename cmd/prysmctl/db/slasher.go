@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var slasherFlags = struct {
+	Path         string
+	Repair       bool
+	Prune        bool
+	CurrentEpoch uint64
+}{}
+
+var slasherPathFlag = &cli.StringFlag{
+	Name:        "db-path-directory",
+	Usage:       "path to directory containing slasher.db",
+	Destination: &slasherFlags.Path,
+	Required:    true,
+}
+
+var slasherCmd = &cli.Command{
+	Name:  "slasher",
+	Usage: "commands to maintain the slasher db while the beacon node is stopped",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "verify",
+			Usage: "check that every entry of slasher.db can be decoded, and optionally delete the invalid ones",
+			Action: func(cliCtx *cli.Context) error {
+				if err := slasherVerifyAction(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not verify slasher db")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				slasherPathFlag,
+				&cli.BoolFlag{
+					Name:        "repair",
+					Usage:       "delete the entries which cannot be decoded",
+					Destination: &slasherFlags.Repair,
+				},
+			},
+		},
+		{
+			Name:  "compact",
+			Usage: "rewrite slasher.db to reclaim the space of deleted data, optionally pruning data outside of the history window first",
+			Action: func(cliCtx *cli.Context) error {
+				if err := slasherCompactAction(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not compact slasher db")
+				}
+				return nil
+			},
+			Flags: []cli.Flag{
+				slasherPathFlag,
+				&cli.BoolFlag{
+					Name:        "prune",
+					Usage:       "prune attestations, proposals and span chunks outside of the history window before compacting",
+					Destination: &slasherFlags.Prune,
+				},
+				&cli.Uint64Flag{
+					Name:        "current-epoch",
+					Usage:       "epoch ending the history window when pruning, defaults to the epoch following the last one processed by the slasher",
+					Destination: &slasherFlags.CurrentEpoch,
+				},
+			},
+		},
+	},
+}
+
+func slasherVerifyAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	store, err := openSlasherDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher db")
+		}
+	}()
+
+	chunkLength := int(slasherDefaultParams.ChunkSize() * slasherDefaultParams.ValidatorChunkSize())
+	report, err := store.Verify(ctx, chunkLength, slasherFlags.Repair)
+	if err != nil {
+		return err
+	}
+	logger := log.WithFields(log.Fields{
+		"spanChunks":                   report.SpanChunks,
+		"invalidSpanChunks":            report.InvalidSpanChunks,
+		"attestationRecords":           report.AttestationRecords,
+		"invalidAttestationRecords":    report.InvalidAttestationRecords,
+		"attestationDataRoots":         report.AttestationDataRoots,
+		"danglingAttestationDataRoots": report.DanglingAttestationDataRoots,
+		"proposals":                    report.Proposals,
+		"invalidProposals":             report.InvalidProposals,
+		"validatorEpochs":              report.ValidatorEpochs,
+		"invalidValidatorEpochs":       report.InvalidValidatorEpochs,
+	})
+	switch {
+	case report.Invalid() == 0:
+		logger.Info("Slasher db is valid")
+	case slasherFlags.Repair:
+		logger.WithField("deleted", report.Deleted).Warn("Deleted invalid entries from slasher db")
+	default:
+		logger.Warn("Found invalid entries in slasher db, run again with --repair to delete them")
+	}
+	return nil
+}
+
+func slasherCompactAction(cliCtx *cli.Context) error {
+	if slasherFlags.Prune {
+		if err := slasherPrune(cliCtx); err != nil {
+			return err
+		}
+	}
+	return compactDB(path.Join(slasherFlags.Path, slasherkv.DatabaseFileName))
+}
+
+func slasherPrune(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	store, err := openSlasherDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher db")
+		}
+	}()
+
+	currentEpoch := primitives.Epoch(slasherFlags.CurrentEpoch)
+	if !cliCtx.IsSet("current-epoch") {
+		epoch, ok, err := store.DetectionProgress(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("slasher db has no detection progress, set --current-epoch to prune")
+		}
+		currentEpoch = epoch + 1
+	}
+	latestEpochs, err := store.LastEpochWrittenForAllValidators(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get last epoch written for validators")
+	}
+	result, err := slasher.PruneDatabase(ctx, store, slasherDefaultParams, latestEpochs, currentEpoch)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"currentEpoch": currentEpoch,
+		"attestations": result.Attestations,
+		"proposals":    result.Proposals,
+		"spanChunks":   result.SpanChunks,
+	}).Info("Pruned slasher db")
+	return nil
+}
+
+// openSlasherDB opens the existing slasher db in the directory given by the flags.
+func openSlasherDB(ctx context.Context) (*slasherkv.Store, error) {
+	if _, err := os.Stat(path.Join(slasherFlags.Path, slasherkv.DatabaseFileName)); err != nil {
+		return nil, errors.Wrap(err, "could not find slasher db")
+	}
+	store, err := slasherkv.NewKVStore(ctx, slasherFlags.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open slasher db at %s", slasherFlags.Path)
+	}
+	return store, nil
+}
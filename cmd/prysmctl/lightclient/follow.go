@@ -0,0 +1,107 @@
+package lightclient
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/lightclient"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var followFlags = struct {
+	Network          string
+	BeaconNodeHost   string
+	TrustedBlockRoot string
+	Timeout          time.Duration
+}{}
+
+var followCmd = &cli.Command{
+	Name:  "follow",
+	Usage: "Bootstrap a light client from a trusted block root and follow the finalized and optimistic headers of the chain, along with their verified execution blocks.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionFollow(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not follow the chain with the light client")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		cmd.ChainConfigFileFlag,
+		&cli.StringFlag{
+			Name:        "network",
+			Usage:       "network to run on (mainnet, sepolia, holesky)",
+			Destination: &followFlags.Network,
+			Value:       "mainnet",
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection",
+			Destination: &followFlags.BeaconNodeHost,
+			Value:       "localhost:3500",
+		},
+		&cli.StringFlag{
+			Name:        "trusted-block-root",
+			Usage:       "hex encoded root of a block trusted by the light client, usually a recent finalized checkpoint root",
+			Destination: &followFlags.TrustedBlockRoot,
+			Required:    true,
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-host (uses duration format, ex: 2m31s)",
+			Destination: &followFlags.Timeout,
+			Value:       time.Second * 30,
+		},
+	},
+}
+
+func cliActionFollow(cliCtx *cli.Context) error {
+	switch followFlags.Network {
+	case params.SepoliaName:
+		if err := params.SetActive(params.SepoliaConfig()); err != nil {
+			return err
+		}
+	case params.HoleskyName:
+		if err := params.SetActive(params.HoleskyConfig()); err != nil {
+			return err
+		}
+	case params.MainnetName:
+		// Do nothing
+	default:
+		return errors.Errorf("unknown network provided: %s", followFlags.Network)
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return err
+		}
+	}
+
+	root, err := bytesutil.DecodeHexWithLength(followFlags.TrustedBlockRoot, fieldparams.RootLength)
+	if err != nil {
+		return errors.Wrap(err, "could not decode trusted block root")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	c, err := lightclient.NewClient(followFlags.BeaconNodeHost, client.WithTimeout(followFlags.Timeout))
+	if err != nil {
+		return err
+	}
+	f, err := lightclient.NewFollower(ctx, c, bytesutil.ToBytes32(root))
+	if err != nil {
+		return err
+	}
+	if err := f.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
@@ -166,6 +166,7 @@ var Commands = &cli.Command{
 				flags.ExitAllFlag,
 				flags.ForceExitFlag,
 				flags.VoluntaryExitJSONOutputPathFlag,
+				flags.DistributedSignHookURLFlag,
 				features.Mainnet,
 				features.SepoliaTestnet,
 				features.HoleskyTestnet,
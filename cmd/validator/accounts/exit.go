@@ -85,6 +85,9 @@ func Exit(c *cli.Context, r io.Reader) error {
 		accounts.WithGRPCHeaders(grpcHeaders),
 		accounts.WithExitJSONOutputPath(c.String(flags.VoluntaryExitJSONOutputPathFlag.Name)),
 	}
+	if url := c.String(flags.DistributedSignHookURLFlag.Name); url != "" {
+		opts = append(opts, accounts.WithSignHooks([]client.SignHook{client.NewRemoteSignHook(url)}))
+	}
 	// Get full set of public keys from the keymanager.
 	validatingPublicKeys, err := km.FetchValidatingPublicKeys(c.Context)
 	if err != nil {
@@ -32,7 +32,7 @@ var (
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint.",
+		Usage: "Beacon node RPC provider endpoint. Multiple comma separated endpoints can be provided, in which case calls are routed to the healthiest node.",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -44,7 +44,7 @@ var (
 	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint.
 	BeaconRESTApiProviderFlag = &cli.StringFlag{
 		Name:  "beacon-rest-api-provider",
-		Usage: "Beacon node REST API provider endpoint.",
+		Usage: "Beacon node REST API provider endpoint. Multiple comma separated endpoints can be provided, in which case calls are routed to the healthiest node.",
 		Value: "http://127.0.0.1:3500",
 	}
 	// CertFlag defines a flag for the node's TLS certificate.
@@ -312,6 +312,28 @@ var (
 		Aliases: []string{"remote-signer-keys-file"},
 	}
 
+	// Web3SignerFallbackURLsFlag defines the URLs of replicas of the web3signer, used when the signers before them are unavailable.
+	// example:--validators-external-signer-fallback-urls=http://replica1:9000,http://replica2:9000
+	Web3SignerFallbackURLsFlag = &cli.StringSliceFlag{
+		Name:  "validators-external-signer-fallback-urls",
+		Usage: "Comma separated list of URLs of replicas of the web3signer, in order of preference, used when the web3signer at --validators-external-signer-url is unavailable.",
+	}
+
+	// Web3SignerKeyDiscoveryIntervalFlag defines the interval at which the public keys are fetched again from the web3signer.
+	Web3SignerKeyDiscoveryIntervalFlag = &cli.DurationFlag{
+		Name: "validators-external-signer-key-discovery-interval",
+		Usage: "Interval at which the public keys are fetched again from the web3signer, or from the url given to --validators-external-signer-public-keys, " +
+			"adding and removing keys as the signer does. Cannot be used with a list of public keys or a key file. Disabled when 0.",
+		Value: 0,
+	}
+
+	// Web3SignerHealthCheckIntervalFlag defines the interval at which the upcheck api of each web3signer is polled.
+	Web3SignerHealthCheckIntervalFlag = &cli.DurationFlag{
+		Name:  "validators-external-signer-health-check-interval",
+		Usage: "Interval at which the upcheck api of each web3signer is polled to pick the signer used for signing. Disabled when 0.",
+		Value: 30 * time.Second,
+	}
+
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
 		Name:  "keymanager-kind",
@@ -337,6 +359,33 @@ var (
 		Usage: "Allows users to specify the output directory to export their slashing protection EIP-3076 standard JSON File.",
 		Value: "",
 	}
+	// SlashingProtectionExportPublicKeysFlag restricts a slashing protection export to the given validator public keys.
+	SlashingProtectionExportPublicKeysFlag = &cli.StringSliceFlag{
+		Name:  "slashing-protection-export-public-keys",
+		Usage: "Comma-separated list of hex encoded validator public keys to export. Exports every key if not set.",
+	}
+	// SlashingProtectionExportStartEpochFlag is the first epoch included in a slashing protection export.
+	SlashingProtectionExportStartEpochFlag = &cli.Uint64Flag{
+		Name:  "slashing-protection-export-start-epoch",
+		Usage: "Only exports blocks and attestations (by target epoch) from this epoch onwards.",
+	}
+	// SlashingProtectionExportEndEpochFlag is the last epoch included in a slashing protection export.
+	SlashingProtectionExportEndEpochFlag = &cli.Uint64Flag{
+		Name:  "slashing-protection-export-end-epoch",
+		Usage: "Only exports blocks and attestations (by target epoch) up to and including this epoch. 0 means no upper bound.",
+	}
+	// SlashingProtectionMergeFlag imports a slashing protection file by keeping the highest watermarks
+	// instead of rejecting keys with conflicting history.
+	SlashingProtectionMergeFlag = &cli.BoolFlag{
+		Name: "slashing-protection-merge",
+		Usage: "Merges the slashing protection JSON into the database, keeping for every key the highest signed block slot and " +
+			"attestation source and target epochs, instead of refusing to import keys with conflicting history.",
+	}
+	// SlashingProtectionDryRunFlag prints what importing a slashing protection file would change, without writing it.
+	SlashingProtectionDryRunFlag = &cli.BoolFlag{
+		Name:  "slashing-protection-dry-run",
+		Usage: "Prints a per-key report of what importing the slashing protection JSON would change, without modifying the database.",
+	}
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
 		Name:  "graffiti-file",
@@ -386,12 +435,49 @@ var (
 		Usage: "Sets the maximum size for one batch of validator registrations. Use a non-positive value to disable batching.",
 		Value: 0,
 	}
+	// BeaconNodeBroadcastFlag publishes signed duties through every healthy beacon node.
+	BeaconNodeBroadcastFlag = &cli.BoolFlag{
+		Name: "beacon-node-broadcast",
+		Usage: `Publishes signed blocks, attestations, aggregates and sync committee messages through every healthy
+		beacon node in parallel instead of only the primary node. Requires multiple beacon node endpoints.`,
+	}
+	// BeaconNodeBroadcastPolicyFlag defines which responses count as a successful broadcast.
+	BeaconNodeBroadcastPolicyFlag = &cli.StringFlag{
+		Name: "beacon-node-broadcast-policy",
+		Usage: `Defines when a broadcast submission is successful. 'first-success' succeeds as soon as one beacon node
+		accepts it, 'quorum' once a majority of the healthy beacon nodes accept it.`,
+		Value: "first-success",
+	}
 	// EnableDistributed enables the usage of prysm validator client in a Distributed Validator Cluster.
 	EnableDistributed = &cli.BoolFlag{
-		Name:  "distributed",
-		Usage: "To enable the use of prysm validator client in Distributed Validator Cluster",
+		Name: "distributed",
+		Usage: `To enable the use of prysm validator client in Distributed Validator Cluster. Duties are routed through the
+		DV middleware, usually set as --beacon-rest-api-provider with --enable-beacon-rest-api, which is required by
+		--distributed-duty-offset and --distributed-sign-hook-url.`,
 		Value: false,
 	}
+	// DistributedDutyOffsetFlag delays the duties of a validator client in a Distributed Validator Cluster.
+	DistributedDutyOffsetFlag = &cli.DurationFlag{
+		Name: "distributed-duty-offset",
+		Usage: `Delays attestation, aggregation and sync committee duties, as well as the slot deadline, by the given
+		duration. It must be the same for every node of the Distributed Validator Cluster, to give the middleware time to
+		reach consensus. Requires --distributed.`,
+	}
+	// DistributedSignHookURLFlag sets the sign hook of the DV middleware called around every signature.
+	DistributedSignHookURLFlag = &cli.StringFlag{
+		Name: "distributed-sign-hook-url",
+		Usage: `URL of the sign hook of the Distributed Validator middleware. Every sign request is posted to <url>/pre_sign
+		before signing and the partial signature to <url>/post_sign once signed; the post_sign response may return the
+		signature to use instead. Requires --distributed, and is also used to sign the exits of the accounts voluntary-exit
+		command.`,
+	}
+	// DutyTimelineFileFlag defines the file the duty timeline is persisted to.
+	DutyTimelineFileFlag = &cli.StringFlag{
+		Name: "duty-timeline-file",
+		Usage: `Appends the record of every duty performed, including when it was assigned, the beacon node used, the time
+		spent signing and the result, to the given file. The records of the last epochs are loaded on restart and served by
+		the duty timeline API. By default the duty timeline is only kept in memory.`,
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
@@ -75,11 +75,19 @@ var appFlags = []cli.Flag{
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
 	flags.EnableDistributed,
+	flags.DistributedDutyOffsetFlag,
+	flags.DistributedSignHookURLFlag,
+	flags.BeaconNodeBroadcastFlag,
+	flags.BeaconNodeBroadcastPolicyFlag,
+	flags.DutyTimelineFileFlag,
 	flags.AuthTokenPathFlag,
 	// Consensys' Web3Signer flags
 	flags.Web3SignerURLFlag,
 	flags.Web3SignerPublicValidatorKeysFlag,
 	flags.Web3SignerKeyFileFlag,
+	flags.Web3SignerFallbackURLsFlag,
+	flags.Web3SignerKeyDiscoveryIntervalFlag,
+	flags.Web3SignerHealthCheckIntervalFlag,
 	flags.SuggestedFeeRecipientFlag,
 	flags.ProposerSettingsURLFlag,
 	flags.ProposerSettingsFlag,
@@ -9,11 +9,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
 	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
 	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
 	"github.com/urfave/cli/v2"
@@ -94,8 +96,13 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 		}
 	}()
 
+	filter, err := exportFilterFromCLI(cliCtx)
+	if err != nil {
+		return err
+	}
+
 	// Export the slashing protection history from the validator's database.
-	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	eipJSON, err := slashingprotection.ExportFilteredStandardProtectionJSON(cliCtx.Context, validatorDB, filter)
 	if err != nil {
 		return errors.Wrap(err, "could not export slashing protection history")
 	}
@@ -119,6 +126,25 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 	return nil
 }
 
+// Builds the export filter from the public keys and the epoch window given on the command line.
+func exportFilterFromCLI(cliCtx *cli.Context) (*slashingprotection.ExportFilter, error) {
+	filter := &slashingprotection.ExportFilter{
+		StartEpoch: primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionExportStartEpochFlag.Name)),
+		EndEpoch:   primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionExportEndEpochFlag.Name)),
+	}
+	for _, pubKeyHex := range cliCtx.StringSlice(flags.SlashingProtectionExportPublicKeysFlag.Name) {
+		pubKey, err := helpers.PubKeyFromHex(pubKeyHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse public key %s", pubKeyHex)
+		}
+		filter.PubKeys = append(filter.PubKeys, pubKey[:])
+	}
+	if filter.EndEpoch != 0 && filter.EndEpoch < filter.StartEpoch {
+		return nil, fmt.Errorf("--%s must not be lower than --%s", flags.SlashingProtectionExportEndEpochFlag.Name, flags.SlashingProtectionExportStartEpochFlag.Name)
+	}
+	return filter, nil
+}
+
 func writeToOutput(cliCtx *cli.Context, eipJSON *format.EIPSlashingProtectionFormat) error {
 	// Get the output directory where the slashing protection history file will be stored
 	outputDir, err := userprompt.InputDirectory(
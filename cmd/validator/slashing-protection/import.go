@@ -2,6 +2,7 @@ package historycmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
+	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
 	"github.com/urfave/cli/v2"
 )
 
@@ -99,9 +101,35 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 		return err
 	}
 
+	buf := bytes.NewBuffer(enc)
+
+	// Only report what the import would change.
+	if cliCtx.Bool(flags.SlashingProtectionDryRunFlag.Name) {
+		report, err := slashingprotection.DiffStandardProtectionJSON(cliCtx.Context, valDB, buf)
+		if err != nil {
+			return errors.Wrapf(err, "could not compare slashing protection JSON file %s with the database", protectionFilePath)
+		}
+		return printMergeReport(report)
+	}
+
+	// Merge the data into our database, keeping the highest watermarks for every key.
+	if cliCtx.Bool(flags.SlashingProtectionMergeFlag.Name) {
+		log.Infof("Starting merge of slashing protection file %s", protectionFilePath)
+		report, err := slashingprotection.MergeStandardProtectionJSON(cliCtx.Context, valDB, buf)
+		if err != nil {
+			return errors.Wrapf(err, "could not merge slashing protection JSON file %s", protectionFilePath)
+		}
+		for _, d := range report.Keys {
+			if len(d.Conflicts) > 0 {
+				log.WithField("pubkey", d.Pubkey).WithField("conflicts", d.Conflicts).Warn("Merged conflicting slashing protection history")
+			}
+		}
+		log.Infof("Slashing protection JSON successfully merged into %s", dataDir)
+		return nil
+	}
+
 	// Import the data from the standard slashing protection JSON file into our database.
 	log.Infof("Starting import of slashing protection file %s", protectionFilePath)
-	buf := bytes.NewBuffer(enc)
 
 	if err := valDB.ImportStandardProtectionJSON(cliCtx.Context, buf); err != nil {
 		return errors.Wrapf(err, "could not import slashing protection JSON file %s", protectionFilePath)
@@ -111,3 +139,12 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 
 	return nil
 }
+
+func printMergeReport(report *slashingprotection.MergeReport) error {
+	encoded, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not JSON marshal slashing protection report")
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
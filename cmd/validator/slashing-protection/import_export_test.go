@@ -188,3 +188,53 @@ func TestImportExportSlashingProtectionCli_EmptyData(t *testing.T) {
 		require.DeepEqual(t, make([]*format.SignedAttestation, 0), item.SignedAttestations)
 	}
 }
+
+// TestImportExportSlashingProtectionCli_MergeAndFilter merges a EIP-3076 interchange format JSON file
+// into the database, and exports the history of a subset of its keys.
+func TestImportExportSlashingProtectionCli_MergeAndFilter(t *testing.T) {
+	numValidators := 4
+	outputPath := filepath.Join(t.TempDir(), "slashing-exports")
+	require.NoError(t, file.MkdirAll(outputPath))
+
+	pubKeys, err := mocks.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	attestingHistory, proposalHistory := mocks.MockAttestingAndProposalHistories(pubKeys)
+	mockJSON, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(mockJSON)
+	require.NoError(t, err)
+	protectionFilePath := filepath.Join(outputPath, "slashing_history_import.json")
+	require.NoError(t, file.WriteFile(protectionFilePath, encoded))
+
+	validatorDB := dbTest.SetupDB(t, pubKeys, false)
+	dbPath := validatorDB.DatabasePath()
+	require.NoError(t, validatorDB.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dbPath, "")
+	set.String(flags.SlashingProtectionJSONFileFlag.Name, protectionFilePath, "")
+	set.String(flags.SlashingProtectionExportDirFlag.Name, outputPath, "")
+	set.Bool(flags.SlashingProtectionMergeFlag.Name, true, "")
+	exportedKeys := cli.NewStringSlice(mockJSON.Data[0].Pubkey, mockJSON.Data[1].Pubkey)
+	set.Var(exportedKeys, flags.SlashingProtectionExportPublicKeysFlag.Name, "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, dbPath))
+	require.NoError(t, set.Set(flags.SlashingProtectionJSONFileFlag.Name, protectionFilePath))
+	require.NoError(t, set.Set(flags.SlashingProtectionExportDirFlag.Name, outputPath))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, importSlashingProtectionJSON(cliCtx))
+	// Merging the same file again is a no-op rather than an error.
+	require.NoError(t, importSlashingProtectionJSON(cliCtx))
+	require.NoError(t, exportSlashingProtectionJSON(cliCtx))
+
+	enc, err := file.ReadFileAsBytes(filepath.Join(outputPath, jsonExportFileName))
+	require.NoError(t, err)
+	receivedJSON := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, receivedJSON))
+	require.DeepEqual(t, mockJSON.Metadata, receivedJSON.Metadata)
+	require.Equal(t, 2, len(receivedJSON.Data))
+	for _, item := range receivedJSON.Data {
+		assert.Equal(t, true, item.Pubkey == mockJSON.Data[0].Pubkey || item.Pubkey == mockJSON.Data[1].Pubkey)
+	}
+}
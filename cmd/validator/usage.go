@@ -107,6 +107,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GRPCGatewayCorsDomain,
 			flags.GRPCHeadersFlag,
 			flags.BeaconRESTApiProviderFlag,
+			flags.BeaconNodeBroadcastFlag,
+			flags.BeaconNodeBroadcastPolicyFlag,
 		},
 	},
 	{
@@ -128,6 +130,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Web3SignerURLFlag,
 			flags.Web3SignerPublicValidatorKeysFlag,
 			flags.Web3SignerKeyFileFlag,
+			flags.Web3SignerFallbackURLsFlag,
+			flags.Web3SignerKeyDiscoveryIntervalFlag,
+			flags.Web3SignerHealthCheckIntervalFlag,
 		},
 	},
 	{
@@ -144,6 +149,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.DisablePenaltyRewardLogFlag,
 			flags.DisableAccountMetricsFlag,
 			flags.EnableDistributed,
+			flags.DistributedDutyOffsetFlag,
+			flags.DistributedSignHookURLFlag,
+			flags.DutyTimelineFileFlag,
 			flags.AuthTokenPathFlag,
 		},
 	},
@@ -47,6 +47,8 @@ type Flags struct {
 	WriteWalletPasswordOnWebOnboarding  bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
 	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
+	EnableHistoricalStateArchive        bool // EnableHistoricalStateArchive enables the saving of per-epoch state diffs for historical state lookups.
+	EnableForkchoiceSnapshot            bool // EnableForkchoiceSnapshot persists the fork choice store to the DB and restores it on startup.
 	EnableBeaconRESTApi                 bool // EnableBeaconRESTApi enables experimental usage of the beacon REST API by the validator when querying a beacon node
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
@@ -213,6 +215,14 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		log.WithField(enableHistoricalSpaceRepresentation.Name, enableHistoricalSpaceRepresentation.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableHistoricalSpaceRepresentation = true
 	}
+	if ctx.Bool(enableHistoricalStateArchive.Name) {
+		logEnabled(enableHistoricalStateArchive)
+		cfg.EnableHistoricalStateArchive = true
+	}
+	if ctx.Bool(enableForkchoiceSnapshot.Name) {
+		logEnabled(enableForkchoiceSnapshot)
+		cfg.EnableForkchoiceSnapshot = true
+	}
 	if ctx.Bool(disableStakinContractCheck.Name) {
 		logEnabled(disableStakinContractCheck)
 		cfg.DisableStakinContractCheck = true
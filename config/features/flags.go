@@ -117,6 +117,16 @@ var (
 			" (Warning): Once enabled, this feature migrates your database in to a new schema and " +
 			"there is no going back. At worst, your entire database might get corrupted.",
 	}
+	enableHistoricalStateArchive = &cli.BoolFlag{
+		Name: "enable-historical-state-archive",
+		Usage: "(Experimental): Stores a state diff for every finalized epoch so that any historical state can be " +
+			"loaded without long block replays. Existing databases are backfilled in the background.",
+	}
+	enableForkchoiceSnapshot = &cli.BoolFlag{
+		Name: "enable-forkchoice-snapshot",
+		Usage: "(Experimental): Saves the fork choice store to the database every epoch and on shutdown, and restores it " +
+			"on startup instead of starting from the finalized checkpoint.",
+	}
 	enableStartupOptimistic = &cli.BoolFlag{
 		Name:   "startup-optimistic",
 		Usage:  "Treats every block as optimistically synced at launch. Use with caution.",
@@ -209,6 +219,8 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	disableBroadcastSlashingFlag,
 	enableSlasherFlag,
 	enableHistoricalSpaceRepresentation,
+	enableHistoricalStateArchive,
+	enableForkchoiceSnapshot,
 	disableStakinContractCheck,
 	SaveFullExecutionPayloads,
 	enableStartupOptimistic,
@@ -32,6 +32,10 @@ const (
 	BlobLength                            = 131072        // BlobLength defines the byte length of a blob.
 	BlobSize                              = 131072        // defined to match blob.size in bazel ssz codegen
 	KzgCommitmentInclusionProofDepth      = 17            // Merkle proof depth for blob_kzg_commitments list item
+	KzgCommitmentsInclusionProofDepth     = 4             // Merkle proof depth for the blob_kzg_commitments list root in the block body.
+	NumberOfColumns                       = 128           // NumberOfColumns defines the number of columns in the extended data matrix.
+	CellsPerBlob                          = 128           // CellsPerBlob defines the number of cells in an extended blob.
+	BytesPerCell                          = 2048          // BytesPerCell defines the byte length of a cell, FIELD_ELEMENTS_PER_CELL * BYTES_PER_FIELD_ELEMENT.
 	NextSyncCommitteeBranchDepth          = 5             // NextSyncCommitteeBranchDepth defines the depth of the next sync committee branch.
 	PendingBalanceDepositsLimit           = 134217728     // Maximum number of pending balance deposits in the beacon state.
 	PendingPartialWithdrawalsLimit        = 64            // Maximum number of pending partial withdrawals in the beacon state.
@@ -23,6 +23,7 @@ var (
 	errInvalidIndex          = errors.New("index out of bounds")
 	errInvalidBodyRoot       = errors.New("invalid Beacon Block Body root")
 	errInvalidInclusionProof = errors.New("invalid KZG commitment inclusion proof")
+	errEmptyCommitments      = errors.New("no KZG commitments to prove")
 )
 
 // VerifyKZGInclusionProof verifies the Merkle proof in a Blob sidecar against
@@ -47,6 +48,60 @@ func VerifyKZGInclusionProof(blob ROBlob) error {
 	return nil
 }
 
+// VerifyKZGCommitmentsInclusionProof verifies the Merkle proof in a data column sidecar that its list of KZG
+// commitments is included in the beacon block body root.
+func VerifyKZGCommitmentsInclusionProof(dc RODataColumn) error {
+	if dc.SignedBlockHeader == nil || dc.SignedBlockHeader.Header == nil {
+		return errNilBlockHeader
+	}
+	root := dc.SignedBlockHeader.Header.BodyRoot
+	if len(root) != field_params.RootLength {
+		return errInvalidBodyRoot
+	}
+	leaf, err := kzgCommitmentsRoot(dc.KzgCommitments)
+	if err != nil {
+		return err
+	}
+	if !trie.VerifyMerkleProof(root, leaf[:], kzgPosition, dc.KzgCommitmentsInclusionProof) {
+		return errInvalidInclusionProof
+	}
+	return nil
+}
+
+// MerkleProofKZGCommitments constructs the Merkle proof of inclusion of the whole list of KZG commitments into the
+// Beacon Block with the given `body`.
+func MerkleProofKZGCommitments(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	if body.Version() < version.Deneb {
+		return nil, errUnsupportedBeaconBlockBody
+	}
+	membersRoots, err := topLevelRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	sparse, err := trie.GenerateTrieFromItems(membersRoots, logBodyLength)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := sparse.MerkleProof(kzgPosition)
+	if err != nil {
+		return nil, err
+	}
+	// sparse.MerkleProof always includes the length of the slice, which is not part of the body.
+	return proof[:len(proof)-1], nil
+}
+
+// kzgCommitmentsRoot computes the hash tree root of a list of KZG commitments.
+func kzgCommitmentsRoot(commitments [][]byte) ([32]byte, error) {
+	if len(commitments) == 0 {
+		return [32]byte{}, errEmptyCommitments
+	}
+	sparse, err := trie.GenerateTrieFromItems(leavesFromCommitments(commitments), field_params.LogMaxBlobCommitments)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sparse.HashTreeRoot()
+}
+
 // MerkleProofKZGCommitment constructs a Merkle proof of inclusion of the KZG
 // commitment of index `index` into the Beacon Block with the given `body`
 func MerkleProofKZGCommitment(body interfaces.ReadOnlyBeaconBlockBody, index int) ([][]byte, error) {
@@ -104,6 +104,65 @@ func Test_MerkleProofKZGCommitment(t *testing.T) {
 	require.Equal(t, true, trie.VerifyMerkleProof(root[:], chunk[0][:], uint64(index+KZGOffset), proof))
 }
 
+func Test_VerifyKZGCommitmentsInclusionProof(t *testing.T) {
+	kzgs := make([][]byte, 3)
+	for i := range kzgs {
+		kzgs[i] = make([]byte, 48)
+		_, err := rand.Read(kzgs[i])
+		require.NoError(t, err)
+	}
+	pbBody := &ethpb.BeaconBlockBodyDeneb{
+		SyncAggregate: &ethpb.SyncAggregate{
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+			ParentHash:    make([]byte, fieldparams.RootLength),
+			FeeRecipient:  make([]byte, 20),
+			StateRoot:     make([]byte, fieldparams.RootLength),
+			ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+			LogsBloom:     make([]byte, 256),
+			PrevRandao:    make([]byte, fieldparams.RootLength),
+			BaseFeePerGas: make([]byte, fieldparams.RootLength),
+			BlockHash:     make([]byte, fieldparams.RootLength),
+			Transactions:  make([][]byte, 0),
+			ExtraData:     make([]byte, 0),
+		},
+		Eth1Data: &ethpb.Eth1Data{
+			DepositRoot: make([]byte, fieldparams.RootLength),
+			BlockHash:   make([]byte, fieldparams.RootLength),
+		},
+		BlobKzgCommitments: kzgs,
+	}
+	body, err := NewBeaconBlockBody(pbBody)
+	require.NoError(t, err)
+	bodyRoot, err := body.HashTreeRoot()
+	require.NoError(t, err)
+	proof, err := MerkleProofKZGCommitments(body)
+	require.NoError(t, err)
+	require.Equal(t, fieldparams.KzgCommitmentsInclusionProofDepth, len(proof))
+
+	dc, err := NewRODataColumn(&ethpb.DataColumnSidecar{
+		KzgCommitments: kzgs,
+		SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				ParentRoot: make([]byte, fieldparams.RootLength),
+				StateRoot:  make([]byte, fieldparams.RootLength),
+				BodyRoot:   bodyRoot[:],
+			},
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		KzgCommitmentsInclusionProof: proof,
+	})
+	require.NoError(t, err)
+	require.NoError(t, VerifyKZGCommitmentsInclusionProof(dc))
+
+	dc.KzgCommitments = kzgs[:2]
+	require.ErrorIs(t, VerifyKZGCommitmentsInclusionProof(dc), errInvalidInclusionProof)
+	dc.KzgCommitments = nil
+	require.ErrorIs(t, VerifyKZGCommitmentsInclusionProof(dc), errEmptyCommitments)
+}
+
 // This test explains the calculation of the KZG commitment root's Merkle index
 // in the Body's Merkle tree based on the index of the KZG commitment list in the Body.
 func Test_KZGRootIndex(t *testing.T) {
@@ -0,0 +1,65 @@
+package blocks
+
+import (
+	ssz "github.com/prysmaticlabs/fastssz"
+	field_params "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const payloadPosition = 9 // The index of the execution payload in the Body
+
+// PayloadProof constructs the Merkle proof of inclusion of the execution payload into the Beacon Block
+// with the given `body`, as used by the light client headers from Capella onwards.
+func PayloadProof(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	bodyVersion := body.Version()
+	if bodyVersion < version.Capella || bodyVersion >= version.Electra {
+		return nil, errUnsupportedBeaconBlockBody
+	}
+	membersRoots, err := topLevelRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	// The KZG commitments are not needed by topLevelRoots, but their root is a sibling on the path of
+	// the execution payload.
+	if bodyVersion >= version.Deneb {
+		commitments, err := body.BlobKzgCommitments()
+		if err != nil {
+			return nil, err
+		}
+		root, err := blobKzgCommitmentsRoot(commitments)
+		if err != nil {
+			return nil, err
+		}
+		copy(membersRoots[kzgPosition], root[:])
+	}
+	sparse, err := trie.GenerateTrieFromItems(membersRoots, logBodyLength)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := sparse.MerkleProof(payloadPosition)
+	if err != nil {
+		return nil, err
+	}
+	// sparse.MerkleProof always includes the length of the slice, which is not part of the body.
+	return proof[:len(proof)-1], nil
+}
+
+// blobKzgCommitmentsRoot computes the hash tree root of the list of KZG commitments of a block body,
+// which may be empty.
+func blobKzgCommitmentsRoot(commitments [][]byte) ([32]byte, error) {
+	if len(commitments) > field_params.MaxBlobCommitmentsPerBlock {
+		return [32]byte{}, ssz.ErrListTooBigFn("BlobKzgCommitments", len(commitments), field_params.MaxBlobCommitmentsPerBlock)
+	}
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, c := range commitments {
+		if len(c) != field_params.BLSPubkeyLength {
+			return [32]byte{}, ssz.ErrBytesLength
+		}
+		hh.PutBytes(c)
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(commitments)), field_params.MaxBlobCommitmentsPerBlock)
+	return hh.HashRoot()
+}
@@ -0,0 +1,111 @@
+package blocks
+
+import (
+	"crypto/rand"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func Test_PayloadProof(t *testing.T) {
+	syncAggregate := &ethpb.SyncAggregate{
+		SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+		SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+	}
+	eth1Data := &ethpb.Eth1Data{
+		DepositRoot: make([]byte, fieldparams.RootLength),
+		BlockHash:   make([]byte, fieldparams.RootLength),
+	}
+	kzg := make([]byte, 48)
+	_, err := rand.Read(kzg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		pbBody interface{}
+	}{
+		{
+			name: "capella",
+			pbBody: &ethpb.BeaconBlockBodyCapella{
+				Eth1Data:      eth1Data,
+				SyncAggregate: syncAggregate,
+				ExecutionPayload: &enginev1.ExecutionPayloadCapella{
+					ParentHash:    make([]byte, fieldparams.RootLength),
+					FeeRecipient:  make([]byte, 20),
+					StateRoot:     make([]byte, fieldparams.RootLength),
+					ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, fieldparams.RootLength),
+					BaseFeePerGas: make([]byte, fieldparams.RootLength),
+					BlockHash:     make([]byte, fieldparams.RootLength),
+					BlockNumber:   12,
+				},
+			},
+		},
+		{
+			name: "deneb without blobs",
+			pbBody: &ethpb.BeaconBlockBodyDeneb{
+				Eth1Data:      eth1Data,
+				SyncAggregate: syncAggregate,
+				ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+					ParentHash:    make([]byte, fieldparams.RootLength),
+					FeeRecipient:  make([]byte, 20),
+					StateRoot:     make([]byte, fieldparams.RootLength),
+					ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, fieldparams.RootLength),
+					BaseFeePerGas: make([]byte, fieldparams.RootLength),
+					BlockHash:     make([]byte, fieldparams.RootLength),
+					BlockNumber:   13,
+				},
+			},
+		},
+		{
+			name: "deneb with blobs",
+			pbBody: &ethpb.BeaconBlockBodyDeneb{
+				Eth1Data:      eth1Data,
+				SyncAggregate: syncAggregate,
+				ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+					ParentHash:    make([]byte, fieldparams.RootLength),
+					FeeRecipient:  make([]byte, 20),
+					StateRoot:     make([]byte, fieldparams.RootLength),
+					ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+					LogsBloom:     make([]byte, 256),
+					PrevRandao:    make([]byte, fieldparams.RootLength),
+					BaseFeePerGas: make([]byte, fieldparams.RootLength),
+					BlockHash:     make([]byte, fieldparams.RootLength),
+					BlobGasUsed:   131072,
+				},
+				BlobKzgCommitments: [][]byte{kzg},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := NewBeaconBlockBody(tt.pbBody)
+			require.NoError(t, err)
+			proof, err := PayloadProof(body)
+			require.NoError(t, err)
+			require.Equal(t, 4, len(proof))
+
+			execution, err := body.Execution()
+			require.NoError(t, err)
+			payloadRoot, err := execution.HashTreeRoot()
+			require.NoError(t, err)
+			root, err := body.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, true, trie.VerifyMerkleProof(root[:], payloadRoot[:], payloadPosition, proof))
+		})
+	}
+
+	t.Run("bellatrix", func(t *testing.T) {
+		body, err := NewBeaconBlockBody(&ethpb.BeaconBlockBodyBellatrix{})
+		require.NoError(t, err)
+		_, err = PayloadProof(body)
+		require.ErrorIs(t, err, errUnsupportedBeaconBlockBody)
+	})
+}
@@ -0,0 +1,93 @@
+package blocks
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// RODataColumn represents a read-only data column sidecar with its block root.
+type RODataColumn struct {
+	*ethpb.DataColumnSidecar
+	root [32]byte
+}
+
+func roDataColumnNilCheck(dc *ethpb.DataColumnSidecar) error {
+	if dc == nil {
+		return errNilDataColumn
+	}
+	if dc.SignedBlockHeader == nil || dc.SignedBlockHeader.Header == nil {
+		return errNilBlockHeader
+	}
+	if len(dc.SignedBlockHeader.Signature) == 0 {
+		return errMissingBlockSignature
+	}
+	return nil
+}
+
+// NewRODataColumnWithRoot creates a new RODataColumn with a given root.
+func NewRODataColumnWithRoot(dc *ethpb.DataColumnSidecar, root [32]byte) (RODataColumn, error) {
+	if err := roDataColumnNilCheck(dc); err != nil {
+		return RODataColumn{}, err
+	}
+	return RODataColumn{DataColumnSidecar: dc, root: root}, nil
+}
+
+// NewRODataColumn creates a new RODataColumn by computing the HashTreeRoot of the header.
+func NewRODataColumn(dc *ethpb.DataColumnSidecar) (RODataColumn, error) {
+	if err := roDataColumnNilCheck(dc); err != nil {
+		return RODataColumn{}, err
+	}
+	root, err := dc.SignedBlockHeader.Header.HashTreeRoot()
+	if err != nil {
+		return RODataColumn{}, err
+	}
+	return RODataColumn{DataColumnSidecar: dc, root: root}, nil
+}
+
+// BlockRoot returns the root of the block.
+func (dc *RODataColumn) BlockRoot() [32]byte {
+	return dc.root
+}
+
+// Slot returns the slot of the data column sidecar.
+func (dc *RODataColumn) Slot() primitives.Slot {
+	return dc.SignedBlockHeader.Header.Slot
+}
+
+// ParentRoot returns the parent root of the data column sidecar.
+func (dc *RODataColumn) ParentRoot() [32]byte {
+	return bytesutil.ToBytes32(dc.SignedBlockHeader.Header.ParentRoot)
+}
+
+// ParentRootSlice returns the parent root as a byte slice.
+func (dc *RODataColumn) ParentRootSlice() []byte {
+	return dc.SignedBlockHeader.Header.ParentRoot
+}
+
+// BodyRoot returns the body root of the data column sidecar.
+func (dc *RODataColumn) BodyRoot() [32]byte {
+	return bytesutil.ToBytes32(dc.SignedBlockHeader.Header.BodyRoot)
+}
+
+// ProposerIndex returns the proposer index of the data column sidecar.
+func (dc *RODataColumn) ProposerIndex() primitives.ValidatorIndex {
+	return dc.SignedBlockHeader.Header.ProposerIndex
+}
+
+// BlockRootSlice returns the block root as a byte slice.
+func (dc *RODataColumn) BlockRootSlice() []byte {
+	return dc.root[:]
+}
+
+// VerifiedRODataColumn represents an RODataColumn that has undergone full verification
+// (eg block sig, inclusion proof, cell proofs).
+type VerifiedRODataColumn struct {
+	RODataColumn
+}
+
+// NewVerifiedRODataColumn "upgrades" an RODataColumn to a VerifiedRODataColumn. This method should only be used by
+// the verification package.
+func NewVerifiedRODataColumn(rodc RODataColumn) VerifiedRODataColumn {
+	return VerifiedRODataColumn{RODataColumn: rodc}
+}
@@ -0,0 +1,237 @@
+package eth
+
+import (
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"google.golang.org/protobuf/proto"
+)
+
+// LightClientHeader defines common functionality for all light client header types.
+type LightClientHeader interface {
+	proto.Message
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+	Version() int
+	GetBeacon() *BeaconBlockHeader
+}
+
+// LightClientBootstrap defines common functionality for all light client bootstrap types.
+type LightClientBootstrap interface {
+	proto.Message
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+	Version() int
+	HeaderVal() LightClientHeader
+	GetCurrentSyncCommittee() *SyncCommittee
+	GetCurrentSyncCommitteeBranch() [][]byte
+}
+
+// LightClientUpdate defines common functionality for all light client update types.
+type LightClientUpdate interface {
+	proto.Message
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+	Version() int
+	AttestedHeaderVal() LightClientHeader
+	GetNextSyncCommittee() *SyncCommittee
+	GetNextSyncCommitteeBranch() [][]byte
+	FinalizedHeaderVal() LightClientHeader
+	GetFinalityBranch() [][]byte
+	GetSyncAggregate() *SyncAggregate
+	GetSignatureSlot() primitives.Slot
+}
+
+// LightClientFinalityUpdate defines common functionality for all light client finality update types.
+type LightClientFinalityUpdate interface {
+	proto.Message
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+	Version() int
+	AttestedHeaderVal() LightClientHeader
+	FinalizedHeaderVal() LightClientHeader
+	GetFinalityBranch() [][]byte
+	GetSyncAggregate() *SyncAggregate
+	GetSignatureSlot() primitives.Slot
+}
+
+// LightClientOptimisticUpdate defines common functionality for all light client optimistic update types.
+type LightClientOptimisticUpdate interface {
+	proto.Message
+	ssz.Marshaler
+	ssz.Unmarshaler
+	ssz.HashRoot
+	Version() int
+	AttestedHeaderVal() LightClientHeader
+	GetSyncAggregate() *SyncAggregate
+	GetSignatureSlot() primitives.Slot
+}
+
+// Version --
+func (*LightClientHeaderAltair) Version() int {
+	return version.Altair
+}
+
+// Version --
+func (*LightClientHeaderCapella) Version() int {
+	return version.Capella
+}
+
+// Version --
+func (*LightClientHeaderDeneb) Version() int {
+	return version.Deneb
+}
+
+// Version --
+func (*LightClientBootstrapAltair) Version() int {
+	return version.Altair
+}
+
+// HeaderVal --
+func (b *LightClientBootstrapAltair) HeaderVal() LightClientHeader {
+	return b.GetHeader()
+}
+
+// Version --
+func (*LightClientBootstrapCapella) Version() int {
+	return version.Capella
+}
+
+// HeaderVal --
+func (b *LightClientBootstrapCapella) HeaderVal() LightClientHeader {
+	return b.GetHeader()
+}
+
+// Version --
+func (*LightClientBootstrapDeneb) Version() int {
+	return version.Deneb
+}
+
+// HeaderVal --
+func (b *LightClientBootstrapDeneb) HeaderVal() LightClientHeader {
+	return b.GetHeader()
+}
+
+// Version --
+func (*LightClientUpdateAltair) Version() int {
+	return version.Altair
+}
+
+// AttestedHeaderVal --
+func (u *LightClientUpdateAltair) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// FinalizedHeaderVal --
+func (u *LightClientUpdateAltair) FinalizedHeaderVal() LightClientHeader {
+	return u.GetFinalizedHeader()
+}
+
+// Version --
+func (*LightClientUpdateCapella) Version() int {
+	return version.Capella
+}
+
+// AttestedHeaderVal --
+func (u *LightClientUpdateCapella) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// FinalizedHeaderVal --
+func (u *LightClientUpdateCapella) FinalizedHeaderVal() LightClientHeader {
+	return u.GetFinalizedHeader()
+}
+
+// Version --
+func (*LightClientUpdateDeneb) Version() int {
+	return version.Deneb
+}
+
+// AttestedHeaderVal --
+func (u *LightClientUpdateDeneb) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// FinalizedHeaderVal --
+func (u *LightClientUpdateDeneb) FinalizedHeaderVal() LightClientHeader {
+	return u.GetFinalizedHeader()
+}
+
+// Version --
+func (*LightClientFinalityUpdateAltair) Version() int {
+	return version.Altair
+}
+
+// AttestedHeaderVal --
+func (u *LightClientFinalityUpdateAltair) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// FinalizedHeaderVal --
+func (u *LightClientFinalityUpdateAltair) FinalizedHeaderVal() LightClientHeader {
+	return u.GetFinalizedHeader()
+}
+
+// Version --
+func (*LightClientFinalityUpdateCapella) Version() int {
+	return version.Capella
+}
+
+// AttestedHeaderVal --
+func (u *LightClientFinalityUpdateCapella) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// FinalizedHeaderVal --
+func (u *LightClientFinalityUpdateCapella) FinalizedHeaderVal() LightClientHeader {
+	return u.GetFinalizedHeader()
+}
+
+// Version --
+func (*LightClientFinalityUpdateDeneb) Version() int {
+	return version.Deneb
+}
+
+// AttestedHeaderVal --
+func (u *LightClientFinalityUpdateDeneb) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// FinalizedHeaderVal --
+func (u *LightClientFinalityUpdateDeneb) FinalizedHeaderVal() LightClientHeader {
+	return u.GetFinalizedHeader()
+}
+
+// Version --
+func (*LightClientOptimisticUpdateAltair) Version() int {
+	return version.Altair
+}
+
+// AttestedHeaderVal --
+func (u *LightClientOptimisticUpdateAltair) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// Version --
+func (*LightClientOptimisticUpdateCapella) Version() int {
+	return version.Capella
+}
+
+// AttestedHeaderVal --
+func (u *LightClientOptimisticUpdateCapella) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
+
+// Version --
+func (*LightClientOptimisticUpdateDeneb) Version() int {
+	return version.Deneb
+}
+
+// AttestedHeaderVal --
+func (u *LightClientOptimisticUpdateDeneb) AttestedHeaderVal() LightClientHeader {
+	return u.GetAttestedHeader()
+}
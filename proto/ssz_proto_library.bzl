@@ -26,6 +26,8 @@ mainnet = {
     "max_blobs_per_block.size": "6",
     "max_blob_commitments.size": "4096",
     "kzg_commitment_inclusion_proof_depth.size": "17",
+    "kzg_commitments_inclusion_proof_depth.size": "4",
+    "bytes_per_cell.size": "2048",  # FIELD_ELEMENTS_PER_CELL * BYTES_PER_FIELD_ELEMENT
     "max_withdrawal_requests_per_payload.size":"16",
     "max_deposit_requests_per_payload.size": "8192",
     "max_attesting_indices.size": "131072",
@@ -58,6 +60,8 @@ minimal = {
     "max_blobs_per_block.size": "6",
     "max_blob_commitments.size": "16",
     "kzg_commitment_inclusion_proof_depth.size": "9",
+    "kzg_commitments_inclusion_proof_depth.size": "4",
+    "bytes_per_cell.size": "2048",
     "max_withdrawal_requests_per_payload.size":"2",
     "max_deposit_requests_per_payload.size": "4",
     "max_attesting_indices.size": "8192",
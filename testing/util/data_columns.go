@@ -0,0 +1,53 @@
+package util
+
+import (
+	"encoding/binary"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// GenerateTestDenebBlockWithColumns generates a Deneb block committing to nblobs blobs, along with one data column
+// sidecar for every column. The sidecars carry a valid KZG commitments inclusion proof, but their cells and cell
+// proofs are placeholders which do not pass KZG verification.
+func GenerateTestDenebBlockWithColumns(t *testing.T, parent [32]byte, slot primitives.Slot, nblobs int, opts ...DenebBlockGeneratorOption) (blocks.ROBlock, []blocks.RODataColumn) {
+	blk, _ := GenerateTestDenebBlockWithSidecar(t, parent, slot, nblobs, opts...)
+	if nblobs == 0 {
+		return blk, nil
+	}
+	header, err := blk.Header()
+	require.NoError(t, err)
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	require.NoError(t, err)
+	proof, err := blocks.MerkleProofKZGCommitments(blk.Block().Body())
+	require.NoError(t, err)
+
+	numberOfColumns := params.BeaconConfig().NumberOfColumns
+	columns := make([]blocks.RODataColumn, numberOfColumns)
+	for c := uint64(0); c < numberOfColumns; c++ {
+		cells := make([][]byte, nblobs)
+		proofs := make([][]byte, nblobs)
+		for i := range cells {
+			cells[i] = make([]byte, fieldparams.BytesPerCell)
+			binary.LittleEndian.PutUint64(cells[i], c)
+			binary.LittleEndian.PutUint64(cells[i][8:], uint64(i))
+			proofs[i] = make([]byte, len(commitments[i]))
+			binary.LittleEndian.PutUint64(proofs[i], c)
+		}
+		columns[c], err = blocks.NewRODataColumnWithRoot(&ethpb.DataColumnSidecar{
+			Index:                        c,
+			Column:                       cells,
+			KzgCommitments:               commitments,
+			KzgProofs:                    proofs,
+			SignedBlockHeader:            header,
+			KzgCommitmentsInclusionProof: proof,
+		}, blk.Root())
+		require.NoError(t, err)
+	}
+	return blk, columns
+}
@@ -32,6 +32,7 @@ type denebBlockGenerator struct {
 	proposer primitives.ValidatorIndex
 	valRoot  []byte
 	payload  *enginev1.ExecutionPayloadDeneb
+	empty    bool
 }
 
 func WithProposerSigning(idx primitives.ValidatorIndex, sk bls.SecretKey, valRoot []byte) DenebBlockGeneratorOption {
@@ -49,6 +50,14 @@ func WithPayloadSetter(p *enginev1.ExecutionPayloadDeneb) DenebBlockGeneratorOpt
 	}
 }
 
+// WithEmptyBlobs generates blobs of zeros. Their kzg commitment and proof are the point at infinity,
+// so the sidecars pass kzg verification.
+func WithEmptyBlobs() DenebBlockGeneratorOption {
+	return func(g *denebBlockGenerator) {
+		g.empty = true
+	}
+}
+
 func GenerateTestDenebBlockWithSidecar(t *testing.T, parent [32]byte, slot primitives.Slot, nblobs int, opts ...DenebBlockGeneratorOption) (blocks.ROBlock, []blocks.ROBlob) {
 	g := &denebBlockGenerator{
 		parent: parent,
@@ -109,8 +118,12 @@ func GenerateTestDenebBlockWithSidecar(t *testing.T, parent [32]byte, slot primi
 	commitments := make([][48]byte, g.nblobs)
 	block.Block.Body.BlobKzgCommitments = make([][]byte, g.nblobs)
 	for i := range commitments {
-		binary.LittleEndian.PutUint16(commitments[i][0:16], uint16(i))
-		binary.LittleEndian.PutUint16(commitments[i][16:32], uint16(g.slot))
+		if g.empty {
+			commitments[i][0] = 0xc0
+		} else {
+			binary.LittleEndian.PutUint16(commitments[i][0:16], uint16(i))
+			binary.LittleEndian.PutUint16(commitments[i][16:32], uint16(g.slot))
+		}
 		block.Block.Body.BlobKzgCommitments[i] = commitments[i][:]
 	}
 
@@ -146,6 +159,9 @@ func GenerateTestDenebBlockWithSidecar(t *testing.T, parent [32]byte, slot primi
 	require.NoError(t, err)
 	for i, c := range block.Block.Body.BlobKzgCommitments {
 		sidecars[i] = GenerateTestDenebBlobSidecar(t, root, sh, i, c, inclusion[i])
+		if g.empty {
+			sidecars[i].Blob = make([]byte, fieldparams.BlobSize)
+		}
 	}
 
 	rob, err := blocks.NewROBlock(sbb)
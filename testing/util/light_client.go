@@ -0,0 +1,306 @@
+package util
+
+import (
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+const (
+	lightClientFinalityBranchDepth  = 6
+	lightClientExecutionBranchDepth = 4
+)
+
+// HydrateLightClientHeader hydrates a light client header with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientHeader(h *ethpb.LightClientHeaderAltair) *ethpb.LightClientHeaderAltair {
+	if h == nil {
+		h = &ethpb.LightClientHeaderAltair{}
+	}
+	h.Beacon = HydrateBeaconHeader(h.Beacon)
+	return h
+}
+
+// HydrateLightClientBootstrap hydrates a light client bootstrap with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientBootstrap(b *ethpb.LightClientBootstrapAltair) *ethpb.LightClientBootstrapAltair {
+	b.Header = HydrateLightClientHeader(b.Header)
+	b.CurrentSyncCommittee = hydrateSyncCommittee(b.CurrentSyncCommittee)
+	if b.CurrentSyncCommitteeBranch == nil {
+		b.CurrentSyncCommitteeBranch = hydrateBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	}
+	return b
+}
+
+// HydrateLightClientUpdate hydrates a light client update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientUpdate(u *ethpb.LightClientUpdateAltair) *ethpb.LightClientUpdateAltair {
+	u.AttestedHeader = HydrateLightClientHeader(u.AttestedHeader)
+	u.NextSyncCommittee = hydrateSyncCommittee(u.NextSyncCommittee)
+	if u.NextSyncCommitteeBranch == nil {
+		u.NextSyncCommitteeBranch = hydrateBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	}
+	u.FinalizedHeader = HydrateLightClientHeader(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = hydrateBranch(lightClientFinalityBranchDepth)
+	}
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientFinalityUpdate hydrates a light client finality update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientFinalityUpdate(u *ethpb.LightClientFinalityUpdateAltair) *ethpb.LightClientFinalityUpdateAltair {
+	u.AttestedHeader = HydrateLightClientHeader(u.AttestedHeader)
+	u.FinalizedHeader = HydrateLightClientHeader(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = hydrateBranch(lightClientFinalityBranchDepth)
+	}
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientOptimisticUpdate hydrates a light client optimistic update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientOptimisticUpdate(u *ethpb.LightClientOptimisticUpdateAltair) *ethpb.LightClientOptimisticUpdateAltair {
+	u.AttestedHeader = HydrateLightClientHeader(u.AttestedHeader)
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientHeaderCapella hydrates a Capella light client header with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientHeaderCapella(h *ethpb.LightClientHeaderCapella) *ethpb.LightClientHeaderCapella {
+	if h == nil {
+		h = &ethpb.LightClientHeaderCapella{}
+	}
+	h.Beacon = HydrateBeaconHeader(h.Beacon)
+	if h.Execution == nil {
+		h.Execution = &enginev1.ExecutionPayloadHeaderCapella{}
+	}
+	hydrateExecutionPayloadHeaderCapella(h.Execution)
+	if h.ExecutionBranch == nil {
+		h.ExecutionBranch = hydrateBranch(lightClientExecutionBranchDepth)
+	}
+	return h
+}
+
+// HydrateLightClientBootstrapCapella hydrates a Capella light client bootstrap with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientBootstrapCapella(b *ethpb.LightClientBootstrapCapella) *ethpb.LightClientBootstrapCapella {
+	b.Header = HydrateLightClientHeaderCapella(b.Header)
+	b.CurrentSyncCommittee = hydrateSyncCommittee(b.CurrentSyncCommittee)
+	if b.CurrentSyncCommitteeBranch == nil {
+		b.CurrentSyncCommitteeBranch = hydrateBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	}
+	return b
+}
+
+// HydrateLightClientUpdateCapella hydrates a Capella light client update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientUpdateCapella(u *ethpb.LightClientUpdateCapella) *ethpb.LightClientUpdateCapella {
+	u.AttestedHeader = HydrateLightClientHeaderCapella(u.AttestedHeader)
+	u.NextSyncCommittee = hydrateSyncCommittee(u.NextSyncCommittee)
+	if u.NextSyncCommitteeBranch == nil {
+		u.NextSyncCommitteeBranch = hydrateBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	}
+	u.FinalizedHeader = HydrateLightClientHeaderCapella(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = hydrateBranch(lightClientFinalityBranchDepth)
+	}
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientFinalityUpdateCapella hydrates a Capella light client finality update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientFinalityUpdateCapella(u *ethpb.LightClientFinalityUpdateCapella) *ethpb.LightClientFinalityUpdateCapella {
+	u.AttestedHeader = HydrateLightClientHeaderCapella(u.AttestedHeader)
+	u.FinalizedHeader = HydrateLightClientHeaderCapella(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = hydrateBranch(lightClientFinalityBranchDepth)
+	}
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientOptimisticUpdateCapella hydrates a Capella light client optimistic update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientOptimisticUpdateCapella(u *ethpb.LightClientOptimisticUpdateCapella) *ethpb.LightClientOptimisticUpdateCapella {
+	u.AttestedHeader = HydrateLightClientHeaderCapella(u.AttestedHeader)
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientHeaderDeneb hydrates a Deneb light client header with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientHeaderDeneb(h *ethpb.LightClientHeaderDeneb) *ethpb.LightClientHeaderDeneb {
+	if h == nil {
+		h = &ethpb.LightClientHeaderDeneb{}
+	}
+	h.Beacon = HydrateBeaconHeader(h.Beacon)
+	if h.Execution == nil {
+		h.Execution = &enginev1.ExecutionPayloadHeaderDeneb{}
+	}
+	hydrateExecutionPayloadHeaderDeneb(h.Execution)
+	if h.ExecutionBranch == nil {
+		h.ExecutionBranch = hydrateBranch(lightClientExecutionBranchDepth)
+	}
+	return h
+}
+
+// HydrateLightClientBootstrapDeneb hydrates a Deneb light client bootstrap with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientBootstrapDeneb(b *ethpb.LightClientBootstrapDeneb) *ethpb.LightClientBootstrapDeneb {
+	b.Header = HydrateLightClientHeaderDeneb(b.Header)
+	b.CurrentSyncCommittee = hydrateSyncCommittee(b.CurrentSyncCommittee)
+	if b.CurrentSyncCommitteeBranch == nil {
+		b.CurrentSyncCommitteeBranch = hydrateBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	}
+	return b
+}
+
+// HydrateLightClientUpdateDeneb hydrates a Deneb light client update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientUpdateDeneb(u *ethpb.LightClientUpdateDeneb) *ethpb.LightClientUpdateDeneb {
+	u.AttestedHeader = HydrateLightClientHeaderDeneb(u.AttestedHeader)
+	u.NextSyncCommittee = hydrateSyncCommittee(u.NextSyncCommittee)
+	if u.NextSyncCommitteeBranch == nil {
+		u.NextSyncCommitteeBranch = hydrateBranch(fieldparams.NextSyncCommitteeBranchDepth)
+	}
+	u.FinalizedHeader = HydrateLightClientHeaderDeneb(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = hydrateBranch(lightClientFinalityBranchDepth)
+	}
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientFinalityUpdateDeneb hydrates a Deneb light client finality update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientFinalityUpdateDeneb(u *ethpb.LightClientFinalityUpdateDeneb) *ethpb.LightClientFinalityUpdateDeneb {
+	u.AttestedHeader = HydrateLightClientHeaderDeneb(u.AttestedHeader)
+	u.FinalizedHeader = HydrateLightClientHeaderDeneb(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = hydrateBranch(lightClientFinalityBranchDepth)
+	}
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+// HydrateLightClientOptimisticUpdateDeneb hydrates a Deneb light client optimistic update with correct field length sizes
+// to comply with SSZ marshalling and unmarshalling rules.
+func HydrateLightClientOptimisticUpdateDeneb(u *ethpb.LightClientOptimisticUpdateDeneb) *ethpb.LightClientOptimisticUpdateDeneb {
+	u.AttestedHeader = HydrateLightClientHeaderDeneb(u.AttestedHeader)
+	u.SyncAggregate = hydrateSyncAggregate(u.SyncAggregate)
+	return u
+}
+
+func hydrateSyncCommittee(c *ethpb.SyncCommittee) *ethpb.SyncCommittee {
+	if c == nil {
+		c = &ethpb.SyncCommittee{}
+	}
+	if c.Pubkeys == nil {
+		c.Pubkeys = make([][]byte, fieldparams.SyncCommitteeLength)
+		for i := range c.Pubkeys {
+			c.Pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+		}
+	}
+	if c.AggregatePubkey == nil {
+		c.AggregatePubkey = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return c
+}
+
+func hydrateSyncAggregate(a *ethpb.SyncAggregate) *ethpb.SyncAggregate {
+	if a == nil {
+		a = &ethpb.SyncAggregate{}
+	}
+	if a.SyncCommitteeBits == nil {
+		a.SyncCommitteeBits = make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength)
+	}
+	if a.SyncCommitteeSignature == nil {
+		a.SyncCommitteeSignature = make([]byte, fieldparams.BLSSignatureLength)
+	}
+	return a
+}
+
+func hydrateBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func hydrateExecutionPayloadHeaderCapella(h *enginev1.ExecutionPayloadHeaderCapella) {
+	if h.ParentHash == nil {
+		h.ParentHash = make([]byte, fieldparams.RootLength)
+	}
+	if h.FeeRecipient == nil {
+		h.FeeRecipient = make([]byte, fieldparams.FeeRecipientLength)
+	}
+	if h.StateRoot == nil {
+		h.StateRoot = make([]byte, fieldparams.RootLength)
+	}
+	if h.ReceiptsRoot == nil {
+		h.ReceiptsRoot = make([]byte, fieldparams.RootLength)
+	}
+	if h.LogsBloom == nil {
+		h.LogsBloom = make([]byte, fieldparams.LogsBloomLength)
+	}
+	if h.PrevRandao == nil {
+		h.PrevRandao = make([]byte, fieldparams.RootLength)
+	}
+	if h.ExtraData == nil {
+		h.ExtraData = make([]byte, 0)
+	}
+	if h.BaseFeePerGas == nil {
+		h.BaseFeePerGas = make([]byte, fieldparams.RootLength)
+	}
+	if h.BlockHash == nil {
+		h.BlockHash = make([]byte, fieldparams.RootLength)
+	}
+	if h.TransactionsRoot == nil {
+		h.TransactionsRoot = make([]byte, fieldparams.RootLength)
+	}
+	if h.WithdrawalsRoot == nil {
+		h.WithdrawalsRoot = make([]byte, fieldparams.RootLength)
+	}
+}
+
+func hydrateExecutionPayloadHeaderDeneb(h *enginev1.ExecutionPayloadHeaderDeneb) {
+	if h.ParentHash == nil {
+		h.ParentHash = make([]byte, fieldparams.RootLength)
+	}
+	if h.FeeRecipient == nil {
+		h.FeeRecipient = make([]byte, fieldparams.FeeRecipientLength)
+	}
+	if h.StateRoot == nil {
+		h.StateRoot = make([]byte, fieldparams.RootLength)
+	}
+	if h.ReceiptsRoot == nil {
+		h.ReceiptsRoot = make([]byte, fieldparams.RootLength)
+	}
+	if h.LogsBloom == nil {
+		h.LogsBloom = make([]byte, fieldparams.LogsBloomLength)
+	}
+	if h.PrevRandao == nil {
+		h.PrevRandao = make([]byte, fieldparams.RootLength)
+	}
+	if h.ExtraData == nil {
+		h.ExtraData = make([]byte, 0)
+	}
+	if h.BaseFeePerGas == nil {
+		h.BaseFeePerGas = make([]byte, fieldparams.RootLength)
+	}
+	if h.BlockHash == nil {
+		h.BlockHash = make([]byte, fieldparams.RootLength)
+	}
+	if h.TransactionsRoot == nil {
+		h.TransactionsRoot = make([]byte, fieldparams.RootLength)
+	}
+	if h.WithdrawalsRoot == nil {
+		h.WithdrawalsRoot = make([]byte, fieldparams.RootLength)
+	}
+}
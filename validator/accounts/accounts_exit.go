@@ -31,6 +31,8 @@ type PerformExitCfg struct {
 	RawPubKeys       [][]byte
 	FormattedPubKeys []string
 	OutputDirectory  string
+	// SignHooks are run around the signature of the exits, such as the sign hook of a Distributed Validator middleware.
+	SignHooks []client.SignHook
 }
 
 // Exit performs a voluntary exit on one or more accounts.
@@ -66,6 +68,7 @@ func (acm *CLIManager) Exit(ctx context.Context) error {
 		acm.rawPubKeys,
 		acm.formattedPubKeys,
 		acm.exitJSONOutputPath,
+		acm.signHooks,
 	}
 	rawExitedKeys, trimmedExitedKeys, err := PerformVoluntaryExit(ctx, cfg)
 	if err != nil {
@@ -85,6 +88,7 @@ func PerformVoluntaryExit(
 	if err != nil {
 		log.WithError(err).Errorf("voluntary exit failed: %v", err)
 	}
+	signer := client.WithSignHooks(cfg.Keymanager.Sign, cfg.SignHooks...)
 	for i, key := range cfg.RawPubKeys {
 		// When output directory is present, only create the signed exit, but do not propose it.
 		// Otherwise, propose the exit immediately.
@@ -93,7 +97,7 @@ func PerformVoluntaryExit(
 			log.WithError(err).Errorf("voluntary exit failed: %v", err)
 		}
 		if len(cfg.OutputDirectory) > 0 {
-			sve, err := client.CreateSignedVoluntaryExit(ctx, cfg.ValidatorClient, cfg.Keymanager.Sign, key, epoch)
+			sve, err := client.CreateSignedVoluntaryExit(ctx, cfg.ValidatorClient, signer, key, epoch)
 			if err != nil {
 				rawNotExitedKeys = append(rawNotExitedKeys, key)
 				msg := err.Error()
@@ -106,7 +110,7 @@ func PerformVoluntaryExit(
 			} else if err := writeSignedVoluntaryExitJSON(sve, cfg.OutputDirectory); err != nil {
 				log.WithError(err).Error("failed to write voluntary exit")
 			}
-		} else if err := client.ProposeExit(ctx, cfg.ValidatorClient, cfg.Keymanager.Sign, key, epoch); err != nil {
+		} else if err := client.ProposeExit(ctx, cfg.ValidatorClient, signer, key, epoch); err != nil {
 			rawNotExitedKeys = append(rawNotExitedKeys, key)
 
 			msg := err.Error()
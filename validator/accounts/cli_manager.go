@@ -11,6 +11,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v5/api/grpc"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
 	iface "github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	nodeClientFactory "github.com/prysmaticlabs/prysm/v5/validator/client/node-client-factory"
@@ -61,6 +62,7 @@ type CLIManager struct {
 	rawPubKeys           [][]byte
 	formattedPubKeys     []string
 	exitJSONOutputPath   string
+	signHooks            []client.SignHook
 	walletDir            string
 	walletPassword       string
 	mnemonic             string
@@ -6,6 +6,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"google.golang.org/grpc"
 )
@@ -205,6 +206,14 @@ func WithExitJSONOutputPath(outputPath string) Option {
 	}
 }
 
+// WithSignHooks specifies the sign hooks run around the signature of voluntary exits.
+func WithSignHooks(hooks []client.SignHook) Option {
+	return func(acc *CLIManager) error {
+		acc.signHooks = hooks
+		return nil
+	}
+}
+
 // WithWalletDir specifies the password for backups.
 func WithWalletDir(walletDir string) Option {
 	return func(acc *CLIManager) error {
@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -103,9 +104,11 @@ func (_ *Wallet) InitializeKeymanager(_ context.Context, _ iface.InitKeymanagerC
 }
 
 type Validator struct {
-	Km               keymanager.IKeymanager
-	graffiti         string
-	proposerSettings *proposer.Settings
+	Km                   keymanager.IKeymanager
+	DoppelGangerStatuses map[[fieldparams.BLSPubkeyLength]byte]iface2.DoppelGangerStatus
+	DutyRecords          []*iface2.DutyRecord
+	graffiti             string
+	proposerSettings     *proposer.Settings
 }
 
 func (_ *Validator) LogSubmittedSyncCommitteeMessages() {}
@@ -198,6 +201,34 @@ func (_ *Validator) CheckDoppelGanger(_ context.Context) error {
 	panic("implement me")
 }
 
+func (_ *Validator) CheckPendingDoppelGangers(_ context.Context, _ primitives.Slot) error {
+	panic("implement me")
+}
+
+// DoppelGangerStatus for mocking
+func (m *Validator) DoppelGangerStatus(pubKey [fieldparams.BLSPubkeyLength]byte) iface2.DoppelGangerStatus {
+	status, ok := m.DoppelGangerStatuses[pubKey]
+	if !ok {
+		return iface2.DoppelGangerUnknown
+	}
+	return status
+}
+
+// DutyTimeline for mocking
+func (m *Validator) DutyTimeline(pubKeys [][fieldparams.BLSPubkeyLength]byte, fromSlot, toSlot primitives.Slot) []*iface2.DutyRecord {
+	records := make([]*iface2.DutyRecord, 0)
+	for _, r := range m.DutyRecords {
+		if r.Slot < fromSlot || r.Slot > toSlot {
+			continue
+		}
+		if len(pubKeys) != 0 && !slices.Contains(pubKeys, r.PubKey) {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
 // HasProposerSettings for mocking
 func (*Validator) HasProposerSettings() bool {
 	panic("implement me")
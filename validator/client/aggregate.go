@@ -19,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -35,9 +36,13 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 
+	ctx, record := v.startDuty(ctx, pubKey, slot, iface.RoleAggregator)
+	defer record.finish()
+
 	duty, err := v.duty(pubKey)
 	if err != nil {
 		log.WithError(err).Error("Could not fetch validator assignment")
+		record.fail("duty", err)
 		if v.emitAccountMetrics {
 			ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -59,6 +64,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		slotSig, err = v.attSelection(attSelectionKey{slot: slot, index: duty.ValidatorIndex})
 		if err != nil {
 			log.WithError(err).Error("Could not find aggregated selection proof")
+			record.fail("selection_proof", err)
 			if v.emitAccountMetrics {
 				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 			}
@@ -68,6 +74,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		slotSig, err = v.signSlotWithSelectionProof(ctx, pubKey, slot)
 		if err != nil {
 			log.WithError(err).Error("Could not sign slot")
+			record.fail("selection_proof", err)
 			if v.emitAccountMetrics {
 				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 			}
@@ -93,6 +100,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		res, err := v.validatorClient.SubmitAggregateSelectionProofElectra(ctx, aggSelectionRequest, duty.ValidatorIndex, uint64(len(duty.Committee)))
 		if err != nil {
 			v.handleSubmitAggSelectionProofError(err, slot, fmtKey)
+			record.fail("aggregate_request", err)
 			return
 		}
 		agg = res.AggregateAndProof
@@ -100,6 +108,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		res, err := v.validatorClient.SubmitAggregateSelectionProof(ctx, aggSelectionRequest, duty.ValidatorIndex, uint64(len(duty.Committee)))
 		if err != nil {
 			v.handleSubmitAggSelectionProofError(err, slot, fmtKey)
+			record.fail("aggregate_request", err)
 			return
 		}
 		agg = res.AggregateAndProof
@@ -108,6 +117,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 	sig, err := v.aggregateAndProofSig(ctx, pubKey, agg, slot)
 	if err != nil {
 		log.WithError(err).Error("Could not sign aggregate and proof")
+		record.fail("sign", err)
 		return
 	}
 
@@ -120,7 +130,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 			}
 			return
 		}
-		_, err = v.validatorClient.SubmitSignedAggregateSelectionProofElectra(ctx, &ethpb.SignedAggregateSubmitElectraRequest{
+		_, err = v.validatorClient.SubmitSignedAggregateSelectionProofElectra(withBroadcastDuty(ctx, broadcastDutyAggregate), &ethpb.SignedAggregateSubmitElectraRequest{
 			SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProofElectra{
 				Message:   msg,
 				Signature: sig,
@@ -128,6 +138,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		})
 		if err != nil {
 			log.WithError(err).Error("Could not submit signed aggregate and proof to beacon node")
+			record.fail("submit", err)
 			if v.emitAccountMetrics {
 				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 			}
@@ -142,7 +153,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 			}
 			return
 		}
-		_, err = v.validatorClient.SubmitSignedAggregateSelectionProof(ctx, &ethpb.SignedAggregateSubmitRequest{
+		_, err = v.validatorClient.SubmitSignedAggregateSelectionProof(withBroadcastDuty(ctx, broadcastDutyAggregate), &ethpb.SignedAggregateSubmitRequest{
 			SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProof{
 				Message:   msg,
 				Signature: sig,
@@ -150,6 +161,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		})
 		if err != nil {
 			log.WithError(err).Error("Could not submit signed aggregate and proof to beacon node")
+			record.fail("submit", err)
 			if v.emitAccountMetrics {
 				ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
 			}
@@ -157,6 +169,8 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 		}
 	}
 
+	record.submitted()
+
 	if err := v.saveSubmittedAtt(agg.AggregateVal().GetData(), pubKey[:], true); err != nil {
 		log.WithError(err).Error("Could not add aggregator indices to logs")
 		if v.emitAccountMetrics {
@@ -185,7 +199,7 @@ func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fiel
 	if err != nil {
 		return nil, err
 	}
-	sig, err = v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -201,14 +215,15 @@ func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fiel
 
 // waitToSlotTwoThirds waits until two third through the current slot period
 // such that any attestations from this slot have time to reach the beacon node
-// before creating the aggregated attestation.
+// before creating the aggregated attestation. In a Distributed Validator cluster,
+// the wait is delayed by the duty offset.
 func (v *validator) waitToSlotTwoThirds(ctx context.Context, slot primitives.Slot) {
 	ctx, span := trace.StartSpan(ctx, "validator.waitToSlotTwoThirds")
 	defer span.End()
 
 	oneThird := slots.DivideSlotBy(3 /* one third of slot duration */)
 	twoThird := oneThird + oneThird
-	delay := twoThird
+	delay := twoThird + v.dutyOffset
 
 	startTime := slots.StartTime(v.genesisTime, slot)
 	finalTime := startTime.Add(delay)
@@ -262,7 +277,7 @@ func (v *validator) aggregateAndProofSig(ctx context.Context, pubKey [fieldparam
 		signRequest.Object = &validatorpb.SignRequest_AggregateAttestationAndProof{AggregateAttestationAndProof: aggregate}
 	}
 
-	sig, err := v.km.Sign(ctx, signRequest)
+	sig, err := v.sign(ctx, signRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +39,9 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 
 	v.waitOneThirdOrValidBlock(ctx, slot)
 
+	ctx, record := v.startDuty(ctx, pubKey, slot, iface.RoleAttester)
+	defer record.finish()
+
 	var b strings.Builder
 	if err := b.WriteByte(byte(iface.RoleAttester)); err != nil {
 		log.WithError(err).Error("Could not write role byte for lock key")
@@ -60,6 +63,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	duty, err := v.duty(pubKey)
 	if err != nil {
 		log.WithError(err).Error("Could not fetch validator assignment")
+		record.fail("duty", err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -78,6 +82,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	data, err := v.validatorClient.AttestationData(ctx, req)
 	if err != nil {
 		log.WithError(err).Error("Could not request attestation to sign at slot")
+		record.fail("attestation_data", err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -88,6 +93,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	sig, _, err := v.signAtt(ctx, pubKey, data, slot)
 	if err != nil {
 		log.WithError(err).Error("Could not sign attestation")
+		record.fail("sign", err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -115,6 +121,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	_, signingRoot, err := v.domainAndSigningRoot(ctx, indexedAtt.GetData())
 	if err != nil {
 		log.WithError(err).Error("Could not get domain and signing root from attestation")
+		record.fail("signing_root", err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -133,6 +140,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	}
 	if !found {
 		log.Errorf("Validator ID %d not found in committee of %v", duty.ValidatorIndex, duty.Committee)
+		record.fail("committee", fmt.Errorf("validator ID %d not found in committee", duty.ValidatorIndex))
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -145,6 +153,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 		// Send the attestation to the beacon node.
 		if err := v.db.SlashableAttestationCheck(ctx, phase0Att, pubKey, signingRoot, v.emitAccountMetrics, ValidatorAttestFailVec); err != nil {
 			log.WithError(err).Error("Failed attestation slashing protection check")
+			record.fail("slashing_protection", err)
 			log.WithFields(
 				attestationLogFields(pubKey, indexedAtt),
 			).Debug("Attempted slashable attestation details")
@@ -166,17 +175,18 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 			Signature:       sig,
 		}
 		attestation.CommitteeBits.SetBitAt(uint64(req.CommitteeIndex), true)
-		attResp, err = v.validatorClient.ProposeAttestationElectra(ctx, attestation)
+		attResp, err = v.validatorClient.ProposeAttestationElectra(withBroadcastDuty(ctx, broadcastDutyAttestation), attestation)
 	} else {
 		attestation := &ethpb.Attestation{
 			Data:            data,
 			AggregationBits: aggregationBitfield,
 			Signature:       sig,
 		}
-		attResp, err = v.validatorClient.ProposeAttestation(ctx, attestation)
+		attResp, err = v.validatorClient.ProposeAttestation(withBroadcastDuty(ctx, broadcastDutyAttestation), attestation)
 	}
 	if err != nil {
 		log.WithError(err).Error("Could not submit attestation to beacon node")
+		record.fail("submit", err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -184,6 +194,8 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 		return
 	}
 
+	record.submitted()
+
 	if err := v.saveSubmittedAtt(data, pubKey[:], false); err != nil {
 		log.WithError(err).Error("Could not save validator index for logging")
 		if v.emitAccountMetrics {
@@ -239,7 +251,7 @@ func (v *validator) signAtt(ctx context.Context, pubKey [fieldparams.BLSPubkeyLe
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -286,6 +298,8 @@ func (v *validator) setHighestSlot(slot primitives.Slot) {
 //
 //	(a) the validator has received a valid block that is the same slot as input slot
 //	(b) one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
+//
+// In a Distributed Validator cluster, (b) is delayed by the duty offset.
 func (v *validator) waitOneThirdOrValidBlock(ctx context.Context, slot primitives.Slot) {
 	ctx, span := trace.StartSpan(ctx, "validator.waitOneThirdOrValidBlock")
 	defer span.End()
@@ -297,7 +311,7 @@ func (v *validator) waitOneThirdOrValidBlock(ctx context.Context, slot primitive
 
 	delay := slots.DivideSlotBy(3 /* a third of the slot duration */)
 	startTime := slots.StartTime(v.genesisTime, slot)
-	finalTime := startTime.Add(delay)
+	finalTime := startTime.Add(delay + v.dutyOffset)
 	wait := prysmTime.Until(finalTime)
 	if wait <= 0 {
 		return
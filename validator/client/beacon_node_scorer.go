@@ -0,0 +1,401 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Number of recent request outcomes used to compute the error rate of a beacon node.
+	nodeErrorWindowSize = 32
+	// A healthy peer count. Nodes with fewer peers are penalized proportionally.
+	nodeTargetPeerCount = 32
+	// Maximum number of slots a node's head is allowed to lag behind the best known head before
+	// the lag penalty stops increasing.
+	nodeMaxHeadLag = 32
+	// The best scoring node only replaces the current primary if it beats it by this margin.
+	// This prevents flapping between nodes with similar scores.
+	nodePrimarySwitchMargin = 10
+
+	nodeBaseScore          = 100
+	nodeSyncingPenalty     = 50
+	nodeOptimisticPenalty  = 20
+	nodeMaxPeerPenalty     = 10
+	nodeMaxErrorPenalty    = 40
+	nodeHeadLagPenaltyStep = 1
+)
+
+// beaconNodeStatus is a point-in-time view of a beacon node, as reported by a probe.
+type beaconNodeStatus struct {
+	syncing    bool
+	optimistic bool
+	headSlot   primitives.Slot
+	peers      uint64
+}
+
+// beaconNodeProber fetches the status of a single beacon node.
+type beaconNodeProber interface {
+	probe(ctx context.Context) (*beaconNodeStatus, error)
+}
+
+type beaconNodeStats struct {
+	status    *beaconNodeStatus // nil if the last probe failed.
+	lastProbe time.Time
+	outcomes  [nodeErrorWindowSize]bool
+	pos       int
+	count     int
+}
+
+func (s *beaconNodeStats) recordOutcome(failed bool) {
+	s.outcomes[s.pos] = failed
+	s.pos = (s.pos + 1) % nodeErrorWindowSize
+	if s.count < nodeErrorWindowSize {
+		s.count++
+	}
+}
+
+func (s *beaconNodeStats) errorRate() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	failures := 0
+	for i := 0; i < s.count; i++ {
+		if s.outcomes[i] {
+			failures++
+		}
+	}
+	return float64(failures) / float64(s.count)
+}
+
+// beaconNodeScorer scores every configured beacon node by its sync status, head slot, peer count and
+// recent error rate, and keeps track of the node that duties should be sent to.
+type beaconNodeScorer struct {
+	sync.RWMutex
+	endpoints []string
+	probers   map[string]beaconNodeProber
+	stats     map[string]*beaconNodeStats
+	primary   string
+}
+
+func newBeaconNodeScorer(probers map[string]beaconNodeProber, endpoints []string) *beaconNodeScorer {
+	s := &beaconNodeScorer{
+		endpoints: endpoints,
+		probers:   probers,
+		stats:     make(map[string]*beaconNodeStats, len(endpoints)),
+	}
+	for _, e := range endpoints {
+		s.stats[e] = &beaconNodeStats{}
+	}
+	if len(endpoints) > 0 {
+		s.primary = endpoints[0]
+	}
+	return s
+}
+
+// Endpoints returns all configured beacon node endpoints in their configured order.
+func (s *beaconNodeScorer) Endpoints() []string {
+	return s.endpoints
+}
+
+// Primary returns the endpoint that requests should be sent to first.
+func (s *beaconNodeScorer) Primary() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.primary
+}
+
+// Score returns the current score of the endpoint. A score of 0 means the node is unreachable.
+func (s *beaconNodeScorer) Score(endpoint string) float64 {
+	s.RLock()
+	defer s.RUnlock()
+	return s.score(endpoint, s.bestHeadSlot())
+}
+
+// Ordered returns all endpoints, starting with the primary and followed by the remaining
+// endpoints sorted by descending score. Ties keep the configured order.
+func (s *beaconNodeScorer) Ordered() []string {
+	s.RLock()
+	defer s.RUnlock()
+	head := s.bestHeadSlot()
+	ordered := make([]string, 0, len(s.endpoints))
+	for _, e := range s.endpoints {
+		if e != s.primary {
+			ordered = append(ordered, e)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return s.score(ordered[i], head) > s.score(ordered[j], head)
+	})
+	return append([]string{s.primary}, ordered...)
+}
+
+// RecordResult records the outcome of a request sent to the endpoint. Only errors which indicate a
+// problem with the node itself, as opposed to a rejected request, count towards the error rate.
+func (s *beaconNodeScorer) RecordResult(endpoint string, err error) {
+	s.Lock()
+	defer s.Unlock()
+	stats, ok := s.stats[endpoint]
+	if !ok {
+		return
+	}
+	failed := isNodeFailure(err)
+	stats.recordOutcome(failed)
+	if failed && endpoint == s.primary {
+		s.updatePrimary()
+	}
+}
+
+// Probe queries the status of all endpoints concurrently and re-evaluates the primary.
+func (s *beaconNodeScorer) Probe(ctx context.Context) {
+	type result struct {
+		endpoint string
+		status   *beaconNodeStatus
+		err      error
+	}
+	results := make(chan result, len(s.endpoints))
+	for _, e := range s.endpoints {
+		go func(endpoint string) {
+			st, err := s.probers[endpoint].probe(ctx)
+			results <- result{endpoint: endpoint, status: st, err: err}
+		}(e)
+	}
+	received := make([]result, 0, len(s.endpoints))
+	for range s.endpoints {
+		received = append(received, <-results)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	now := time.Now()
+	for _, r := range received {
+		stats := s.stats[r.endpoint]
+		stats.lastProbe = now
+		if r.err != nil {
+			log.WithError(r.err).WithField("endpoint", r.endpoint).Debug("Could not probe beacon node")
+			stats.status = nil
+			stats.recordOutcome(true)
+			continue
+		}
+		stats.status = r.status
+	}
+	head := s.bestHeadSlot()
+	for _, e := range s.endpoints {
+		beaconNodeScoreGaugeVec.WithLabelValues(e).Set(s.score(e, head))
+		if st := s.stats[e].status; st != nil {
+			beaconNodeHeadSlotGaugeVec.WithLabelValues(e).Set(float64(st.headSlot))
+		}
+	}
+	s.updatePrimary()
+}
+
+// Run probes all endpoints at the given interval until the context is canceled.
+func (s *beaconNodeScorer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, interval)
+		s.Probe(probeCtx)
+		cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updatePrimary must be called with the write lock held.
+func (s *beaconNodeScorer) updatePrimary() {
+	head := s.bestHeadSlot()
+	best, bestScore := s.primary, s.score(s.primary, head)
+	currentScore := bestScore
+	for _, e := range s.endpoints {
+		if sc := s.score(e, head); sc > bestScore {
+			best, bestScore = e, sc
+		}
+	}
+	if best == s.primary {
+		return
+	}
+	if currentScore > 0 && bestScore < currentScore+nodePrimarySwitchMargin {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"previous":      s.primary,
+		"previousScore": currentScore,
+		"new":           best,
+		"newScore":      bestScore,
+	}).Info("Switching primary beacon node")
+	beaconNodeSwitchCount.Inc()
+	s.primary = best
+}
+
+// bestHeadSlot must be called with the lock held.
+func (s *beaconNodeScorer) bestHeadSlot() primitives.Slot {
+	var head primitives.Slot
+	for _, stats := range s.stats {
+		if stats.status != nil && stats.status.headSlot > head {
+			head = stats.status.headSlot
+		}
+	}
+	return head
+}
+
+// score must be called with the lock held.
+func (s *beaconNodeScorer) score(endpoint string, bestHead primitives.Slot) float64 {
+	stats, ok := s.stats[endpoint]
+	if !ok {
+		return 0
+	}
+	errPenalty := stats.errorRate() * nodeMaxErrorPenalty
+	if stats.status == nil {
+		// Endpoints that have never been probed are scored on their error rate alone,
+		// so that the configured order is respected on startup.
+		if stats.lastProbe.IsZero() {
+			return nodeBaseScore - nodeSyncingPenalty - errPenalty
+		}
+		return 0
+	}
+	score := float64(nodeBaseScore)
+	if stats.status.syncing {
+		score -= nodeSyncingPenalty
+	}
+	if stats.status.optimistic {
+		score -= nodeOptimisticPenalty
+	}
+	if lag := bestHead - stats.status.headSlot; lag > 0 {
+		if lag > nodeMaxHeadLag {
+			lag = nodeMaxHeadLag
+		}
+		score -= float64(lag) * nodeHeadLagPenaltyStep
+	}
+	if stats.status.peers < nodeTargetPeerCount {
+		score -= float64(nodeTargetPeerCount-stats.status.peers) / nodeTargetPeerCount * nodeMaxPeerPenalty
+	}
+	score -= errPenalty
+	// A reachable node always scores higher than an unreachable one.
+	if score < 1 {
+		score = 1
+	}
+	return score
+}
+
+// isNodeFailure returns true if the error indicates that the beacon node could not serve the request,
+// as opposed to the beacon node rejecting an invalid request.
+func isNodeFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	jsonErr := &httputil.DefaultJsonError{}
+	if errors.As(err, &jsonErr) {
+		return jsonErr.Code >= 500
+	}
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.ResourceExhausted, codes.Unknown:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// beaconNodeProbeInterval returns how often beacon nodes are probed, so that a stalled node
+// is detected within the slot.
+func beaconNodeProbeInterval() time.Duration {
+	return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3
+}
+
+type restBeaconNodeProber struct {
+	handler beaconApi.JsonRestHandler
+}
+
+func (p *restBeaconNodeProber) probe(ctx context.Context) (*beaconNodeStatus, error) {
+	syncResp := &structs.SyncStatusResponse{}
+	if err := p.handler.Get(ctx, "/eth/v1/node/syncing", syncResp); err != nil {
+		return nil, err
+	}
+	if syncResp.Data == nil {
+		return nil, errors.New("syncing data is nil")
+	}
+	headSlot, err := strconv.ParseUint(syncResp.Data.HeadSlot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse head slot %s", syncResp.Data.HeadSlot)
+	}
+	peerResp := &structs.GetPeerCountResponse{}
+	if err := p.handler.Get(ctx, "/eth/v1/node/peer_count", peerResp); err != nil {
+		return nil, err
+	}
+	if peerResp.Data == nil {
+		return nil, errors.New("peer count data is nil")
+	}
+	peers, err := strconv.ParseUint(peerResp.Data.Connected, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse peer count %s", peerResp.Data.Connected)
+	}
+	return &beaconNodeStatus{
+		syncing:    syncResp.Data.IsSyncing,
+		optimistic: syncResp.Data.IsOptimistic || syncResp.Data.ElOffline,
+		headSlot:   primitives.Slot(headSlot),
+		peers:      peers,
+	}, nil
+}
+
+type grpcBeaconNodeProber struct {
+	nodeClient  ethpb.NodeClient
+	chainClient ethpb.BeaconChainClient
+}
+
+func newGrpcBeaconNodeProber(cc grpc.ClientConnInterface) *grpcBeaconNodeProber {
+	return &grpcBeaconNodeProber{
+		nodeClient:  ethpb.NewNodeClient(cc),
+		chainClient: ethpb.NewBeaconChainClient(cc),
+	}
+}
+
+func (p *grpcBeaconNodeProber) probe(ctx context.Context) (*beaconNodeStatus, error) {
+	syncStatus, err := p.nodeClient.GetSyncStatus(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	head, err := p.chainClient.GetChainHead(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	peers, err := p.nodeClient.ListPeers(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	var connected uint64
+	for _, peer := range peers.Peers {
+		if peer.ConnectionState == ethpb.ConnectionState_CONNECTED {
+			connected++
+		}
+	}
+	return &beaconNodeStatus{
+		syncing:    syncStatus.Syncing,
+		optimistic: head.OptimisticStatus,
+		headSlot:   head.HeadSlot,
+		peers:      connected,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockBeaconNodeProber struct {
+	status *beaconNodeStatus
+	err    error
+}
+
+func (m *mockBeaconNodeProber) probe(_ context.Context) (*beaconNodeStatus, error) {
+	return m.status, m.err
+}
+
+func healthyNodeStatus() *beaconNodeStatus {
+	return &beaconNodeStatus{headSlot: 100, peers: nodeTargetPeerCount}
+}
+
+func TestBeaconNodeScorer_Score(t *testing.T) {
+	probers := map[string]beaconNodeProber{
+		"healthy":    &mockBeaconNodeProber{status: healthyNodeStatus()},
+		"syncing":    &mockBeaconNodeProber{status: &beaconNodeStatus{syncing: true, headSlot: 100, peers: nodeTargetPeerCount}},
+		"optimistic": &mockBeaconNodeProber{status: &beaconNodeStatus{optimistic: true, headSlot: 100, peers: nodeTargetPeerCount}},
+		"lagging":    &mockBeaconNodeProber{status: &beaconNodeStatus{headSlot: 90, peers: nodeTargetPeerCount}},
+		"no-peers":   &mockBeaconNodeProber{status: &beaconNodeStatus{headSlot: 100}},
+		"down":       &mockBeaconNodeProber{err: errors.New("connection refused")},
+	}
+	s := newBeaconNodeScorer(probers, []string{"healthy", "syncing", "optimistic", "lagging", "no-peers", "down"})
+	s.Probe(context.Background())
+
+	assert.Equal(t, float64(nodeBaseScore), s.Score("healthy"))
+	assert.Equal(t, float64(nodeBaseScore-nodeSyncingPenalty), s.Score("syncing"))
+	assert.Equal(t, float64(nodeBaseScore-nodeOptimisticPenalty), s.Score("optimistic"))
+	assert.Equal(t, float64(nodeBaseScore-10*nodeHeadLagPenaltyStep), s.Score("lagging"))
+	assert.Equal(t, float64(nodeBaseScore-nodeMaxPeerPenalty), s.Score("no-peers"))
+	assert.Equal(t, float64(0), s.Score("down"))
+	assert.Equal(t, float64(0), s.Score("unknown"))
+}
+
+func TestBeaconNodeScorer_ErrorRate(t *testing.T) {
+	probers := map[string]beaconNodeProber{
+		"a": &mockBeaconNodeProber{status: healthyNodeStatus()},
+	}
+	s := newBeaconNodeScorer(probers, []string{"a"})
+	s.Probe(context.Background())
+
+	for i := 0; i < nodeErrorWindowSize/2; i++ {
+		s.RecordResult("a", nil)
+		s.RecordResult("a", status.Error(codes.Unavailable, "unavailable"))
+	}
+	assert.Equal(t, float64(nodeBaseScore-nodeMaxErrorPenalty/2), s.Score("a"))
+
+	// Rejected requests do not count against the node.
+	for i := 0; i < nodeErrorWindowSize; i++ {
+		s.RecordResult("a", &httputil.DefaultJsonError{Code: 400})
+	}
+	assert.Equal(t, float64(nodeBaseScore), s.Score("a"))
+}
+
+func TestBeaconNodeScorer_Primary(t *testing.T) {
+	first := &mockBeaconNodeProber{status: healthyNodeStatus()}
+	second := &mockBeaconNodeProber{status: healthyNodeStatus()}
+	s := newBeaconNodeScorer(map[string]beaconNodeProber{"first": first, "second": second}, []string{"first", "second"})
+	assert.Equal(t, "first", s.Primary())
+
+	s.Probe(context.Background())
+	assert.Equal(t, "first", s.Primary())
+
+	t.Run("small difference keeps primary", func(t *testing.T) {
+		first.status = &beaconNodeStatus{headSlot: 95, peers: nodeTargetPeerCount}
+		s.Probe(context.Background())
+		assert.Equal(t, "first", s.Primary())
+		assert.DeepEqual(t, []string{"first", "second"}, s.Ordered())
+	})
+	t.Run("syncing primary is replaced", func(t *testing.T) {
+		first.status = &beaconNodeStatus{syncing: true, headSlot: 100, peers: nodeTargetPeerCount}
+		s.Probe(context.Background())
+		assert.Equal(t, "second", s.Primary())
+		assert.DeepEqual(t, []string{"second", "first"}, s.Ordered())
+	})
+	t.Run("unreachable primary is replaced", func(t *testing.T) {
+		first.status = healthyNodeStatus()
+		second.err = errors.New("connection refused")
+		s.Probe(context.Background())
+		assert.Equal(t, "first", s.Primary())
+	})
+	t.Run("failing requests replace primary before next probe", func(t *testing.T) {
+		second.err = nil
+		s.Probe(context.Background())
+		require.Equal(t, "first", s.Primary())
+		for i := 0; i < nodeErrorWindowSize; i++ {
+			s.RecordResult("second", nil)
+			s.RecordResult("first", status.Error(codes.Unavailable, "unavailable"))
+		}
+		assert.Equal(t, "second", s.Primary())
+	})
+}
+
+func TestIsNodeFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "connection error", err: errors.New("connection refused"), want: true},
+		{name: "bad request", err: &httputil.DefaultJsonError{Code: 400}, want: false},
+		{name: "not found", err: &httputil.DefaultJsonError{Code: 404}, want: false},
+		{name: "server error", err: &httputil.DefaultJsonError{Code: 503}, want: true},
+		{name: "grpc unavailable", err: status.Error(codes.Unavailable, ""), want: true},
+		{name: "grpc deadline", err: status.Error(codes.DeadlineExceeded, ""), want: true},
+		{name: "grpc invalid argument", err: status.Error(codes.InvalidArgument, ""), want: false},
+		{name: "grpc not found", err: status.Error(codes.NotFound, ""), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isNodeFailure(tt.err))
+		})
+	}
+}
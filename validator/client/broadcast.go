@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// BroadcastFirstSuccess considers a broadcast submission successful as soon as one beacon node accepts it.
+	BroadcastFirstSuccess = "first-success"
+	// BroadcastQuorum considers a broadcast submission successful once a majority of the healthy beacon nodes accept it.
+	BroadcastQuorum = "quorum"
+)
+
+const (
+	broadcastDutyBlock            = "block"
+	broadcastDutyAttestation      = "attestation"
+	broadcastDutyAggregate        = "aggregate"
+	broadcastDutySyncMessage      = "sync_message"
+	broadcastDutySyncContribution = "sync_contribution"
+)
+
+type broadcastPolicy int
+
+const (
+	broadcastPolicyFirstSuccess broadcastPolicy = iota
+	broadcastPolicyQuorum
+)
+
+func parseBroadcastPolicy(policy string) (broadcastPolicy, error) {
+	switch policy {
+	case "", BroadcastFirstSuccess:
+		return broadcastPolicyFirstSuccess, nil
+	case BroadcastQuorum:
+		return broadcastPolicyQuorum, nil
+	default:
+		return 0, fmt.Errorf("unknown broadcast policy %s, expected %s or %s", policy, BroadcastFirstSuccess, BroadcastQuorum)
+	}
+}
+
+type broadcastDutyKey struct{}
+
+// withBroadcastDuty marks the context of a signed duty submission. When broadcasting is enabled, such submissions
+// are published through every healthy beacon node instead of only the primary.
+func withBroadcastDuty(ctx context.Context, duty string) context.Context {
+	return context.WithValue(ctx, broadcastDutyKey{}, duty)
+}
+
+func broadcastDutyFromContext(ctx context.Context) (string, bool) {
+	duty, ok := ctx.Value(broadcastDutyKey{}).(string)
+	return duty, ok
+}
+
+// broadcaster publishes signed duties through all healthy beacon nodes in parallel.
+type broadcaster struct {
+	scorer *beaconNodeScorer
+	policy broadcastPolicy
+}
+
+type broadcastResult struct {
+	endpoint string
+	err      error
+}
+
+// broadcast calls send for every healthy beacon node in parallel and returns the endpoint whose response should be used,
+// once enough nodes accepted the submission according to the policy. Submissions still in flight when broadcast returns
+// keep running until the deadline of the parent context, so that slow nodes still receive the message.
+func (b *broadcaster) broadcast(ctx context.Context, duty string, send func(ctx context.Context, endpoint string) error) (string, error) {
+	endpoints := b.healthyEndpoints()
+	required := 1
+	if b.policy == broadcastPolicyQuorum {
+		required = len(endpoints)/2 + 1
+	}
+
+	var sendCtx context.Context
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); ok {
+		sendCtx, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline)
+	} else {
+		sendCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+	}
+	results := make(chan broadcastResult, len(endpoints))
+	for _, e := range endpoints {
+		go func(endpoint string) {
+			start := time.Now()
+			err := send(sendCtx, endpoint)
+			b.scorer.RecordResult(endpoint, err)
+			result := "success"
+			if err != nil {
+				result = "failure"
+			}
+			beaconNodeSubmissionsCounterVec.WithLabelValues(endpoint, duty, result).Inc()
+			beaconNodeSubmissionLatencyHistogramVec.WithLabelValues(endpoint, duty).Observe(time.Since(start).Seconds())
+			results <- broadcastResult{endpoint: endpoint, err: err}
+		}(e)
+	}
+	// Release the context once every submission has completed.
+	done := make(chan broadcastResult, len(endpoints))
+	go func() {
+		defer cancel()
+		for range endpoints {
+			done <- <-results
+		}
+	}()
+
+	var winner string
+	var firstErr error
+	successes, failures := 0, 0
+	for range endpoints {
+		var r broadcastResult
+		select {
+		case r = <-done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if r.err != nil {
+			failures++
+			if firstErr == nil {
+				firstErr = errors.Wrapf(r.err, "beacon node %s rejected %s", r.endpoint, duty)
+			}
+			log.WithError(r.err).WithField("endpoint", r.endpoint).WithField("duty", duty).Debug("Beacon node did not accept submission")
+		} else {
+			successes++
+			if winner == "" {
+				winner = r.endpoint
+			}
+		}
+		if successes >= required {
+			return winner, nil
+		}
+		if len(endpoints)-failures < required {
+			break
+		}
+	}
+	if successes > 0 {
+		return "", errors.Wrapf(firstErr, "only %d of %d beacon nodes accepted %s, %d required", successes, len(endpoints), duty, required)
+	}
+	return "", firstErr
+}
+
+// healthyEndpoints returns all reachable beacon nodes, or every configured node if none of them is reachable.
+func (b *broadcaster) healthyEndpoints() []string {
+	ordered := b.scorer.Ordered()
+	healthy := make([]string, 0, len(ordered))
+	for _, e := range ordered {
+		if b.scorer.Score(e) > 0 {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		return ordered
+	}
+	return healthy
+}
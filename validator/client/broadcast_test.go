@@ -0,0 +1,165 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newTestBroadcaster(t *testing.T, policy broadcastPolicy, endpoints ...string) *broadcaster {
+	probers := make(map[string]beaconNodeProber, len(endpoints))
+	for _, e := range endpoints {
+		probers[e] = &mockBeaconNodeProber{status: healthyNodeStatus()}
+	}
+	s := newBeaconNodeScorer(probers, endpoints)
+	s.Probe(context.Background())
+	return &broadcaster{scorer: s, policy: policy}
+}
+
+func TestParseBroadcastPolicy(t *testing.T) {
+	policy, err := parseBroadcastPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, broadcastPolicyFirstSuccess, policy)
+	policy, err = parseBroadcastPolicy(BroadcastFirstSuccess)
+	require.NoError(t, err)
+	assert.Equal(t, broadcastPolicyFirstSuccess, policy)
+	policy, err = parseBroadcastPolicy(BroadcastQuorum)
+	require.NoError(t, err)
+	assert.Equal(t, broadcastPolicyQuorum, policy)
+	_, err = parseBroadcastPolicy("all")
+	require.ErrorContains(t, "unknown broadcast policy", err)
+}
+
+func TestBroadcaster_FirstSuccess(t *testing.T) {
+	b := newTestBroadcaster(t, broadcastPolicyFirstSuccess, "a", "b", "c")
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(3)
+	called := make(map[string]bool)
+	winner, err := b.broadcast(context.Background(), broadcastDutyAttestation, func(_ context.Context, endpoint string) error {
+		defer wg.Done()
+		mu.Lock()
+		called[endpoint] = true
+		mu.Unlock()
+		if endpoint == "b" {
+			return nil
+		}
+		return errors.New("rejected")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b", winner)
+
+	_, err = b.broadcast(context.Background(), broadcastDutyAttestation, func(_ context.Context, _ string) error {
+		return errors.New("rejected")
+	})
+	require.ErrorContains(t, "rejected", err)
+
+	// Every node receives the submission, even after the first success.
+	wg.Wait()
+	assert.Equal(t, 3, len(called))
+}
+
+func TestBroadcaster_FirstSuccessDoesNotWaitForSlowNodes(t *testing.T) {
+	b := newTestBroadcaster(t, broadcastPolicyFirstSuccess, "fast", "slow")
+	release := make(chan struct{})
+	defer close(release)
+	winner, err := b.broadcast(context.Background(), broadcastDutyBlock, func(_ context.Context, endpoint string) error {
+		if endpoint == "slow" {
+			<-release
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fast", winner)
+}
+
+func TestBroadcaster_Quorum(t *testing.T) {
+	b := newTestBroadcaster(t, broadcastPolicyQuorum, "a", "b", "c")
+
+	_, err := b.broadcast(context.Background(), broadcastDutyBlock, func(_ context.Context, endpoint string) error {
+		if endpoint == "a" {
+			return nil
+		}
+		return errors.New("rejected")
+	})
+	require.ErrorContains(t, "only 1 of 3 beacon nodes accepted block, 2 required", err)
+
+	winner, err := b.broadcast(context.Background(), broadcastDutyBlock, func(_ context.Context, endpoint string) error {
+		if endpoint == "c" {
+			return errors.New("rejected")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, "c", winner)
+}
+
+func TestBroadcaster_SkipsUnreachableNodes(t *testing.T) {
+	probers := map[string]beaconNodeProber{
+		"up":   &mockBeaconNodeProber{status: healthyNodeStatus()},
+		"down": &mockBeaconNodeProber{err: errors.New("connection refused")},
+	}
+	s := newBeaconNodeScorer(probers, []string{"down", "up"})
+	s.Probe(context.Background())
+	b := &broadcaster{scorer: s, policy: broadcastPolicyQuorum}
+
+	var mu sync.Mutex
+	var called []string
+	_, err := b.broadcast(context.Background(), broadcastDutySyncMessage, func(_ context.Context, endpoint string) error {
+		mu.Lock()
+		called = append(called, endpoint)
+		mu.Unlock()
+		return nil
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"up"}, called)
+}
+
+func TestFailoverJsonRestHandler_Broadcast(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]string)
+	record := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			received[name] = r.Method
+			mu.Unlock()
+			writeVersion(t, name)(w, r)
+		}
+	}
+	first := newTestBeaconNodeServer(t, "100", false, record("first"))
+	defer first.Close()
+	second := newTestBeaconNodeServer(t, "100", false, record("second"))
+	defer second.Close()
+
+	h := newFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{first.URL, second.URL})
+	h.scorer.Probe(context.Background())
+
+	t.Run("not broadcast without duty", func(t *testing.T) {
+		h.enableBroadcast(broadcastPolicyQuorum)
+		require.NoError(t, h.Post(context.Background(), "/endpoint", nil, bytes.NewBufferString("data"), nil))
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, 1, len(received))
+	})
+	t.Run("broadcast with duty", func(t *testing.T) {
+		mu.Lock()
+		received = make(map[string]string)
+		mu.Unlock()
+		resp := &structs.GetVersionResponse{}
+		ctx := withBroadcastDuty(context.Background(), broadcastDutyAttestation)
+		require.NoError(t, h.Post(ctx, "/endpoint", nil, bytes.NewBufferString("data"), resp))
+		assert.Equal(t, "data", resp.Data.Version)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.DeepEqual(t, map[string]string{"first": http.MethodPost, "second": http.MethodPost}, received)
+	})
+}
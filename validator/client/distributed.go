@@ -0,0 +1,193 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+// SignHook is called around every signature requested from the keymanager. In a Distributed Validator
+// cluster the keymanager only holds a share of each validator key, so the signatures it produces are
+// partial signatures which are combined by the DV middleware.
+type SignHook interface {
+	// PreSign is called before the keymanager signs the request. Returning an error aborts the signature.
+	PreSign(ctx context.Context, req *validatorpb.SignRequest) error
+	// PostSign is called with the signature produced by the keymanager, and returns the signature to use instead.
+	PostSign(ctx context.Context, req *validatorpb.SignRequest, sig bls.Signature) (bls.Signature, error)
+}
+
+// sign signs the request with the keymanager, running the sign hooks around it. The time spent signing
+// is accounted to the duty recorded in the context, if any.
+func (v *validator) sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	start := time.Now()
+	defer func() {
+		dutyRecordFromContext(ctx).addSigningLatency(time.Since(start))
+	}()
+	return v.withSignHooks(v.km.Sign)(ctx, req)
+}
+
+// withSignHooks wraps the signing function so that the sign hooks of the validator run around it.
+func (v *validator) withSignHooks(signer iface.SigningFunc) iface.SigningFunc {
+	return WithSignHooks(signer, v.signHooks...)
+}
+
+// WithSignHooks wraps the signing function so that the given sign hooks run around it.
+func WithSignHooks(signer iface.SigningFunc, hooks ...SignHook) iface.SigningFunc {
+	if len(hooks) == 0 {
+		return signer
+	}
+	return func(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+		for _, hook := range hooks {
+			if err := hook.PreSign(ctx, req); err != nil {
+				return nil, errors.Wrap(err, "pre-sign hook failed")
+			}
+		}
+		sig, err := signer(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, hook := range hooks {
+			sig, err = hook.PostSign(ctx, req, sig)
+			if err != nil {
+				return nil, errors.Wrap(err, "post-sign hook failed")
+			}
+		}
+		return sig, nil
+	}
+}
+
+// partialSignatureMetrics counts the partial signatures produced for the DV middleware.
+type partialSignatureMetrics struct{}
+
+// PreSign --
+func (partialSignatureMetrics) PreSign(context.Context, *validatorpb.SignRequest) error {
+	return nil
+}
+
+// PostSign --
+func (partialSignatureMetrics) PostSign(_ context.Context, req *validatorpb.SignRequest, sig bls.Signature) (bls.Signature, error) {
+	distributedPartialSignaturesCounterVec.WithLabelValues(signRequestType(req)).Inc()
+	return sig, nil
+}
+
+// remoteSignHook is a sign hook served over HTTP by the DV middleware, configured with --distributed-sign-hook-url.
+// Every sign request is posted to <url>/pre_sign before signing, and the partial signature to <url>/post_sign once
+// signed. A response other than 200 aborts the signature. The post-sign response may carry the signature to use
+// instead of the partial one.
+type remoteSignHook struct {
+	url    string
+	client *http.Client
+}
+
+// signHookRequest is the body posted to the remote sign hook.
+type signHookRequest struct {
+	PublicKey   string `json:"pubkey"`
+	SigningRoot string `json:"signing_root"`
+	Type        string `json:"type"`
+	SigningSlot string `json:"signing_slot"`
+	Signature   string `json:"signature,omitempty"`
+}
+
+// signHookResponse is the optional body returned by the post-sign endpoint of the remote sign hook.
+type signHookResponse struct {
+	Signature string `json:"signature"`
+}
+
+// NewRemoteSignHook creates a sign hook calling the pre-sign and post-sign endpoints served at url.
+func NewRemoteSignHook(url string) SignHook {
+	return newRemoteSignHook(url)
+}
+
+func newRemoteSignHook(url string) *remoteSignHook {
+	return &remoteSignHook{url: strings.TrimSuffix(url, "/"), client: &http.Client{}}
+}
+
+// PreSign --
+func (h *remoteSignHook) PreSign(ctx context.Context, req *validatorpb.SignRequest) error {
+	return h.post(ctx, "/pre_sign", newSignHookRequest(req, nil), nil)
+}
+
+// PostSign --
+func (h *remoteSignHook) PostSign(ctx context.Context, req *validatorpb.SignRequest, sig bls.Signature) (bls.Signature, error) {
+	resp := &signHookResponse{}
+	if err := h.post(ctx, "/post_sign", newSignHookRequest(req, sig), resp); err != nil {
+		return nil, err
+	}
+	if resp.Signature == "" {
+		return sig, nil
+	}
+	enc, err := hexutil.Decode(resp.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature returned by sign hook")
+	}
+	return bls.SignatureFromBytes(enc)
+}
+
+func newSignHookRequest(req *validatorpb.SignRequest, sig bls.Signature) *signHookRequest {
+	r := &signHookRequest{
+		PublicKey:   hexutil.Encode(req.PublicKey),
+		SigningRoot: hexutil.Encode(req.SigningRoot),
+		Type:        signRequestType(req),
+		SigningSlot: strconv.FormatUint(uint64(req.SigningSlot), 10),
+	}
+	if sig != nil {
+		r.Signature = hexutil.Encode(sig.Marshal())
+	}
+	return r
+}
+
+func (h *remoteSignHook) post(ctx context.Context, endpoint string, body *signHookRequest, resp *signHookResponse) error {
+	enc, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "could not encode sign hook request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url+endpoint, bytes.NewReader(enc))
+	if err != nil {
+		return errors.Wrap(err, "could not create sign hook request")
+	}
+	req.Header.Set("Content-Type", api.JsonMediaType)
+	httpResp, err := h.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not call sign hook %s", endpoint)
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close sign hook response body")
+		}
+	}()
+	if httpResp.StatusCode != http.StatusOK {
+		return errors.Errorf("sign hook %s returned status code %d", endpoint, httpResp.StatusCode)
+	}
+	if resp == nil {
+		return nil
+	}
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrap(err, "could not read sign hook response")
+	}
+	if len(respBody) == 0 {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(respBody, resp), "could not decode sign hook response")
+}
+
+// signRequestType returns the name of the object signed by the request, such as "Block" or "AttestationData".
+func signRequestType(req *validatorpb.SignRequest) string {
+	if req.Object == nil {
+		return "unknown"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", req.Object), "*validatorpb.SignRequest_")
+}
@@ -0,0 +1,355 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type recordingSignHook struct {
+	lock       sync.Mutex
+	preSigned  []*validatorpb.SignRequest
+	postSigned []bls.Signature
+	preErr     error
+	replaceSig bls.Signature
+}
+
+func (h *recordingSignHook) PreSign(_ context.Context, req *validatorpb.SignRequest) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.preSigned = append(h.preSigned, req)
+	return h.preErr
+}
+
+func (h *recordingSignHook) PostSign(_ context.Context, _ *validatorpb.SignRequest, sig bls.Signature) (bls.Signature, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.postSigned = append(h.postSigned, sig)
+	if h.replaceSig != nil {
+		return h.replaceSig, nil
+	}
+	return sig, nil
+}
+
+func TestValidator_sign_Hooks(t *testing.T) {
+	kp := randKeypair(t)
+	req := &validatorpb.SignRequest{
+		PublicKey:   kp.pub[:],
+		SigningRoot: bytesutil.PadTo([]byte("root"), 32),
+		Object:      &validatorpb.SignRequest_Slot{Slot: 1},
+	}
+
+	t.Run("no hooks", func(t *testing.T) {
+		v := &validator{km: newMockKeymanager(t, kp)}
+		sig, err := v.sign(context.Background(), req)
+		require.NoError(t, err)
+		require.DeepEqual(t, kp.pri.Sign(req.SigningRoot).Marshal(), sig.Marshal())
+	})
+	t.Run("hooks run in order", func(t *testing.T) {
+		replaced := kp.pri.Sign([]byte("replaced"))
+		first := &recordingSignHook{}
+		second := &recordingSignHook{replaceSig: replaced}
+		v := &validator{km: newMockKeymanager(t, kp), signHooks: []SignHook{first, second, partialSignatureMetrics{}}}
+		sig, err := v.sign(context.Background(), req)
+		require.NoError(t, err)
+		require.DeepEqual(t, replaced.Marshal(), sig.Marshal())
+		require.Equal(t, 1, len(first.preSigned))
+		require.Equal(t, 1, len(second.preSigned))
+		require.DeepEqual(t, kp.pri.Sign(req.SigningRoot).Marshal(), second.postSigned[0].Marshal())
+	})
+	t.Run("pre-sign error aborts signature", func(t *testing.T) {
+		hook := &recordingSignHook{preErr: errors.New("no consensus")}
+		v := &validator{km: newMockKeymanager(t, kp), signHooks: []SignHook{hook}}
+		_, err := v.sign(context.Background(), req)
+		require.ErrorContains(t, "no consensus", err)
+		require.Equal(t, 0, len(hook.postSigned))
+	})
+}
+
+func TestSignRequestType(t *testing.T) {
+	require.Equal(t, "Slot", signRequestType(&validatorpb.SignRequest{Object: &validatorpb.SignRequest_Slot{Slot: 1}}))
+	require.Equal(t, "AttestationData", signRequestType(&validatorpb.SignRequest{Object: &validatorpb.SignRequest_AttestationData{}}))
+	require.Equal(t, "unknown", signRequestType(&validatorpb.SignRequest{}))
+}
+
+func TestNewValidatorService_Distributed(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewValidatorService(ctx, &Config{DistributedDutyOffset: time.Second})
+	require.ErrorContains(t, "only be used in a Distributed Validator cluster", err)
+	_, err = NewValidatorService(ctx, &Config{Distributed: true, DistributedDutyOffset: -time.Second})
+	require.ErrorContains(t, "cannot be negative", err)
+	_, err = NewValidatorService(ctx, &Config{Distributed: true, DistributedDutyOffset: time.Second})
+	require.ErrorContains(t, "require the beacon REST API", err)
+	_, err = NewValidatorService(ctx, &Config{Distributed: true, DistributedSignHookURL: "http://localhost:3600"})
+	require.ErrorContains(t, "require the beacon REST API", err)
+
+	// A Distributed Validator without the DV options keeps working over gRPC.
+	hook := logTest.NewGlobal()
+	s, err := NewValidatorService(ctx, &Config{Distributed: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(s.signHooks))
+	require.LogsContain(t, hook, "without the beacon REST API")
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableBeaconRESTApi: true})
+	defer resetCfg()
+	s, err = NewValidatorService(ctx, &Config{Distributed: true, DistributedDutyOffset: time.Second})
+	require.NoError(t, err)
+	require.Equal(t, time.Second, s.dutyOffset)
+	require.Equal(t, 1, len(s.signHooks))
+}
+
+func TestValidator_SlotDeadline_DutyOffset(t *testing.T) {
+	v := &validator{genesisTime: 100}
+	deadline := v.SlotDeadline(1)
+	v.dutyOffset = 2 * time.Second
+	require.Equal(t, deadline.Add(2*time.Second), v.SlotDeadline(1))
+}
+
+func TestRemoteSignHook(t *testing.T) {
+	kp := randKeypair(t)
+	req := &validatorpb.SignRequest{
+		PublicKey:   kp.pub[:],
+		SigningRoot: bytesutil.PadTo([]byte("root"), 32),
+		SigningSlot: 5,
+		Object:      &validatorpb.SignRequest_Slot{Slot: 5},
+	}
+	partial := kp.pri.Sign(req.SigningRoot)
+	combined := kp.pri.Sign([]byte("combined"))
+
+	var received []*signHookRequest
+	replace := false
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := &signHookRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+		received = append(received, body)
+		w.WriteHeader(status)
+		if r.URL.Path == "/hook/post_sign" && replace {
+			require.NoError(t, json.NewEncoder(w).Encode(&signHookResponse{Signature: hexutil.Encode(combined.Marshal())}))
+		}
+	}))
+	defer srv.Close()
+	hook := newRemoteSignHook(srv.URL + "/hook/")
+
+	require.NoError(t, hook.PreSign(context.Background(), req))
+	sig, err := hook.PostSign(context.Background(), req, partial)
+	require.NoError(t, err)
+	require.DeepEqual(t, partial.Marshal(), sig.Marshal())
+	require.DeepEqual(t, &signHookRequest{
+		PublicKey:   hexutil.Encode(kp.pub[:]),
+		SigningRoot: hexutil.Encode(req.SigningRoot),
+		Type:        "Slot",
+		SigningSlot: "5",
+	}, received[0])
+	require.Equal(t, hexutil.Encode(partial.Marshal()), received[1].Signature)
+
+	replace = true
+	sig, err = hook.PostSign(context.Background(), req, partial)
+	require.NoError(t, err)
+	require.DeepEqual(t, combined.Marshal(), sig.Marshal())
+
+	status = http.StatusServiceUnavailable
+	require.ErrorContains(t, "returned status code 503", hook.PreSign(context.Background(), req))
+}
+
+func TestNewValidatorService_SignHookURL(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewValidatorService(ctx, &Config{DistributedSignHookURL: "http://localhost:3600"})
+	require.ErrorContains(t, "only be used in a Distributed Validator cluster", err)
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableBeaconRESTApi: true})
+	defer resetCfg()
+	s, err := NewValidatorService(ctx, &Config{Distributed: true, DistributedSignHookURL: "http://localhost:3600"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(s.signHooks))
+	hook, ok := s.signHooks[1].(*remoteSignHook)
+	require.Equal(t, true, ok)
+	require.Equal(t, "http://localhost:3600", hook.url)
+}
+
+// mockMiddleware is a local Distributed Validator middleware. It combines the partial selection proofs and
+// the partial signatures of attestations and aggregates of this node with the ones of another operator of the
+// cluster, and records the duties submitted through it.
+type mockMiddleware struct {
+	lock          sync.Mutex
+	operatorKey   bls.SecretKey
+	attData       *structs.AttestationData
+	partialProofs map[primitives.ValidatorIndex][]byte
+	hookTypes     []string
+	attestations  []*structs.Attestation
+	aggregates    []*structs.SignedAggregateAttestationAndProof
+}
+
+func (m *mockMiddleware) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.GetGenesisResponse{
+			Data: &structs.Genesis{
+				GenesisTime:           "0",
+				GenesisValidatorsRoot: hexutil.Encode(make([]byte, 32)),
+				GenesisForkVersion:    "0x00000000",
+			},
+		})
+	})
+	mux.HandleFunc("/eth/v1/node/syncing", func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.SyncStatusResponse{Data: &structs.SyncStatusResponseData{HeadSlot: m.attData.Slot, SyncDistance: "0"}})
+	})
+	mux.HandleFunc("/eth/v1/validator/beacon_committee_selections", func(w http.ResponseWriter, r *http.Request) {
+		var selections []iface.BeaconCommitteeSelection
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&selections))
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		for i, s := range selections {
+			m.partialProofs[s.ValidatorIndex] = s.SelectionProof
+			selections[i].SelectionProof = m.aggregate(t, s.SelectionProof)
+		}
+		httputil.WriteJson(w, map[string]interface{}{"data": selections})
+	})
+	mux.HandleFunc("/eth/v1/validator/attestation_data", func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.GetAttestationDataResponse{Data: m.attData})
+	})
+	mux.HandleFunc("/eth/v1/beacon/pool/attestations", func(_ http.ResponseWriter, r *http.Request) {
+		var atts []*structs.Attestation
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&atts))
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.attestations = append(m.attestations, atts...)
+	})
+	mux.HandleFunc("/eth/v1/validator/aggregate_attestation", func(w http.ResponseWriter, _ *http.Request) {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		require.Equal(t, 1, len(m.attestations))
+		httputil.WriteJson(w, &structs.AggregateAttestationResponse{Data: m.attestations[0]})
+	})
+	mux.HandleFunc("/eth/v1/validator/aggregate_and_proofs", func(_ http.ResponseWriter, r *http.Request) {
+		var aggregates []*structs.SignedAggregateAttestationAndProof
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&aggregates))
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.aggregates = append(m.aggregates, aggregates...)
+	})
+	mux.HandleFunc("/sign_hook/pre_sign", func(_ http.ResponseWriter, r *http.Request) {
+		req := &signHookRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.hookTypes = append(m.hookTypes, req.Type)
+	})
+	mux.HandleFunc("/sign_hook/post_sign", func(w http.ResponseWriter, r *http.Request) {
+		req := &signHookRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		// Selection proofs are combined through the beacon committee selections endpoint instead.
+		if req.Type == "Slot" {
+			return
+		}
+		partial, err := hexutil.Decode(req.Signature)
+		require.NoError(t, err)
+		httputil.WriteJson(w, &signHookResponse{Signature: hexutil.Encode(m.aggregate(t, partial))})
+	})
+	return mux
+}
+
+func (m *mockMiddleware) aggregate(t *testing.T, partialSig []byte) []byte {
+	partial, err := bls.SignatureFromBytes(partialSig)
+	require.NoError(t, err)
+	return bls.AggregateSignatures([]bls.Signature{partial, m.operatorKey.Sign(partialSig)}).Marshal()
+}
+
+func TestValidator_Distributed_MockMiddleware(t *testing.T) {
+	ctx := context.Background()
+	operatorKey, err := bls.RandKey()
+	require.NoError(t, err)
+	root := hexutil.Encode(bytesutil.PadTo([]byte("root"), 32))
+	middleware := &mockMiddleware{
+		operatorKey: operatorKey,
+		attData: &structs.AttestationData{
+			Slot:            "3",
+			CommitteeIndex:  "0",
+			BeaconBlockRoot: root,
+			Source:          &structs.Checkpoint{Epoch: "0", Root: root},
+			Target:          &structs.Checkpoint{Epoch: "0", Root: root},
+		},
+		partialProofs: make(map[primitives.ValidatorIndex][]byte),
+	}
+	srv := httptest.NewServer(middleware.handler(t))
+	defer srv.Close()
+
+	cache, err := ristretto.NewCache(&ristretto.Config{NumCounters: 1920, MaxCost: 192, BufferItems: 64})
+	require.NoError(t, err)
+	v, _, validatorKey, finish := setup(t, false)
+	defer finish()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	hook := &recordingSignHook{}
+	v.validatorClient = beaconApi.NewBeaconApiValidatorClient(beaconApi.NewBeaconApiJsonRestHandler(http.Client{Timeout: time.Second}, srv.URL))
+	v.domainDataCache = cache
+	v.attSelections = make(map[attSelectionKey]iface.BeaconCommitteeSelection)
+	v.distributed = true
+	v.signHooks = []SignHook{hook, partialSignatureMetrics{}, newRemoteSignHook(srv.URL + "/sign_hook")}
+	// A committee of less than 16 validators makes every member an aggregator.
+	v.duties = &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				PublicKey:      pubKey[:],
+				ValidatorIndex: 7,
+				AttesterSlot:   3,
+				CommitteeIndex: 0,
+				Committee:      []primitives.ValidatorIndex{5, 7, 9},
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+		},
+	}
+
+	require.NoError(t, v.aggregatedSelectionProofs(ctx, v.duties))
+	// The middleware received the partial selection proof produced through the sign hooks.
+	require.Equal(t, 1, len(hook.preSigned))
+	require.Equal(t, primitives.Slot(3), hook.preSigned[0].SigningSlot)
+	partialProof := hook.postSigned[0].Marshal()
+	require.DeepEqual(t, partialProof, middleware.partialProofs[7])
+	aggregatedProof := middleware.aggregate(t, partialProof)
+	selection, err := v.attSelection(attSelectionKey{slot: 3, index: 7})
+	require.NoError(t, err)
+	require.DeepEqual(t, aggregatedProof, selection)
+
+	// The attestation is signed with the key share, and submitted with the signature combined by the middleware.
+	v.SubmitAttestation(ctx, 3, pubKey)
+	require.Equal(t, 1, len(middleware.attestations))
+	att := middleware.attestations[0]
+	require.DeepEqual(t, middleware.attData, att.Data)
+	require.Equal(t, "0x0a", att.AggregationBits)
+	require.Equal(t, 2, len(hook.postSigned))
+	require.Equal(t, hexutil.Encode(middleware.aggregate(t, hook.postSigned[1].Marshal())), att.Signature)
+
+	// The aggregation duty uses the selection proof combined by the middleware.
+	v.SubmitAggregateAndProof(ctx, 3, pubKey)
+	require.Equal(t, 1, len(middleware.aggregates))
+	agg := middleware.aggregates[0]
+	require.Equal(t, "7", agg.Message.AggregatorIndex)
+	require.Equal(t, hexutil.Encode(aggregatedProof), agg.Message.SelectionProof)
+	require.DeepEqual(t, att, agg.Message.Aggregate)
+	require.Equal(t, 3, len(hook.postSigned))
+	require.Equal(t, hexutil.Encode(middleware.aggregate(t, hook.postSigned[2].Marshal())), agg.Signature)
+
+	require.DeepEqual(t, []string{"Slot", "AttestationData", "AggregateAttestationAndProof"}, middleware.hookTypes)
+}
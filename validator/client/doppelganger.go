@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// doppelGangerEpochs is the number of epochs during which a key must not be seen live in the network,
+// after the epoch it was added in, before it performs duties.
+const doppelGangerEpochs = 2
+
+// doppelGangerKey is the doppelganger protection state of a key.
+type doppelGangerKey struct {
+	status iface.DoppelGangerStatus
+	// since is the first epoch the beacon node can check the liveness of the key for.
+	since primitives.Epoch
+}
+
+// trackDoppelGangerKeys starts the doppelganger window of the keys which are not tracked yet. When untrackMissing
+// is set, the keys which are not part of pubkeys anymore are forgotten, so that they wait for the window again
+// if they come back.
+func (v *validator) trackDoppelGangerKeys(pubkeys [][fieldparams.BLSPubkeyLength]byte, epoch primitives.Epoch, untrackMissing bool) {
+	v.doppelGangerLock.Lock()
+	defer v.doppelGangerLock.Unlock()
+
+	if v.doppelGangerKeys == nil {
+		v.doppelGangerKeys = make(map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey)
+	}
+	current := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		current[pubkey] = true
+		if _, ok := v.doppelGangerKeys[pubkey]; ok {
+			continue
+		}
+		v.doppelGangerKeys[pubkey] = &doppelGangerKey{status: iface.DoppelGangerPending, since: epoch}
+		log.WithFields(logrus.Fields{
+			"pubkey": fmt.Sprintf("%#x", bytesutil.Trunc(pubkey[:])),
+			"epoch":  epoch,
+		}).Info("Waiting for doppelganger window before performing duties")
+	}
+	if !untrackMissing {
+		return
+	}
+	for pubkey := range v.doppelGangerKeys {
+		if !current[pubkey] {
+			delete(v.doppelGangerKeys, pubkey)
+		}
+	}
+}
+
+// updateDoppelGangerStatuses records the result of a doppelganger check performed at the given epoch.
+func (v *validator) updateDoppelGangerStatuses(
+	requests []*ethpb.DoppelGangerRequest_ValidatorRequest,
+	responses []*ethpb.DoppelGangerResponse_ValidatorResponse,
+	epoch primitives.Epoch,
+) {
+	v.doppelGangerLock.Lock()
+	defer v.doppelGangerLock.Unlock()
+
+	for _, req := range requests {
+		key, ok := v.doppelGangerKeys[bytesutil.ToBytes48(req.PublicKey)]
+		if !ok || key.status != iface.DoppelGangerPending {
+			continue
+		}
+		// The beacon node does not check the liveness of a key during the 2 epochs following
+		// its latest attestation, as it cannot tell it apart from the attestations of this client.
+		if req.Epoch != 0 && req.Epoch+2 > key.since {
+			key.since = req.Epoch + 2
+		}
+	}
+	for _, resp := range responses {
+		pubkey := bytesutil.ToBytes48(resp.PublicKey)
+		key, ok := v.doppelGangerKeys[pubkey]
+		if !ok || key.status == iface.DoppelGangerDetected {
+			continue
+		}
+		logFields := logrus.Fields{
+			"pubkey": fmt.Sprintf("%#x", bytesutil.Trunc(pubkey[:])),
+			"epoch":  epoch,
+		}
+		if resp.DuplicateExists {
+			key.status = iface.DoppelGangerDetected
+			log.WithFields(logFields).Error("Doppelganger detected, the key will not perform duties until it is removed and added again")
+			continue
+		}
+		if key.status == iface.DoppelGangerPending && epoch >= key.since+doppelGangerEpochs {
+			key.status = iface.DoppelGangerSafe
+			log.WithFields(logFields).Info("No doppelganger found, the key starts performing duties")
+		}
+	}
+}
+
+// CheckPendingDoppelGangers checks the liveness of the keys waiting for the doppelganger window. It is meant to be
+// called at the end of each epoch, once most attestations of the epoch are included on chain. The keys which
+// appeared since the previous check start their own window.
+func (v *validator) CheckPendingDoppelGangers(ctx context.Context, slot primitives.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "validator.CheckPendingDoppelGangers")
+	defer span.End()
+
+	if !features.Get().EnableDoppelGanger {
+		return nil
+	}
+	pubkeys, err := v.km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating public keys")
+	}
+	epoch := slots.ToEpoch(slot)
+	v.trackDoppelGangerKeys(pubkeys, epoch, true /* untrackMissing */)
+
+	pending := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	v.doppelGangerLock.RLock()
+	for pubkey, key := range v.doppelGangerKeys {
+		if key.status == iface.DoppelGangerPending {
+			pending = append(pending, pubkey)
+		}
+	}
+	v.doppelGangerLock.RUnlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	_, err = v.checkDoppelGanger(ctx, pending, epoch)
+	return err
+}
+
+// DoppelGangerStatus returns the doppelganger protection status of the key.
+func (v *validator) DoppelGangerStatus(pubkey [fieldparams.BLSPubkeyLength]byte) iface.DoppelGangerStatus {
+	if !features.Get().EnableDoppelGanger {
+		return iface.DoppelGangerDisabled
+	}
+	v.doppelGangerLock.RLock()
+	defer v.doppelGangerLock.RUnlock()
+	key, ok := v.doppelGangerKeys[pubkey]
+	if !ok {
+		return iface.DoppelGangerUnknown
+	}
+	return key.status
+}
+
+// performsDuties returns whether the key can perform duties according to doppelganger protection.
+func (v *validator) performsDuties(pubkey [fieldparams.BLSPubkeyLength]byte) bool {
+	status := v.DoppelGangerStatus(pubkey)
+	return status == iface.DoppelGangerDisabled || status == iface.DoppelGangerSafe
+}
+
+// currentEpoch returns the epoch of the wall clock.
+func (v *validator) currentEpoch() primitives.Epoch {
+	return slots.ToEpoch(slots.CurrentSlot(v.genesisTime))
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	dbTest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidator_DoppelGangerStatus_Disabled(t *testing.T) {
+	v := &validator{}
+	kp := randKeypair(t)
+	v.trackDoppelGangerKeys([][fieldparams.BLSPubkeyLength]byte{kp.pub}, 1, false)
+	require.Equal(t, iface.DoppelGangerDisabled, v.DoppelGangerStatus(kp.pub))
+	require.Equal(t, true, v.performsDuties(kp.pub))
+}
+
+func TestValidator_updateDoppelGangerStatuses(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{EnableDoppelGanger: true})
+	defer reset()
+
+	fresh, attested, duplicate := randKeypair(t), randKeypair(t), randKeypair(t)
+	keys := [][fieldparams.BLSPubkeyLength]byte{fresh.pub, attested.pub, duplicate.pub}
+	v := &validator{}
+	v.trackDoppelGangerKeys(keys, 10, false)
+	for _, k := range keys {
+		require.Equal(t, iface.DoppelGangerPending, v.DoppelGangerStatus(k))
+		require.Equal(t, false, v.performsDuties(k))
+	}
+	require.Equal(t, iface.DoppelGangerUnknown, v.DoppelGangerStatus(randKeypair(t).pub))
+
+	requests := []*ethpb.DoppelGangerRequest_ValidatorRequest{
+		{PublicKey: fresh.pub[:]},
+		{PublicKey: attested.pub[:], Epoch: 11},
+		{PublicKey: duplicate.pub[:]},
+	}
+	responses := func(dup bool) []*ethpb.DoppelGangerResponse_ValidatorResponse {
+		return []*ethpb.DoppelGangerResponse_ValidatorResponse{
+			{PublicKey: fresh.pub[:]},
+			{PublicKey: attested.pub[:]},
+			{PublicKey: duplicate.pub[:], DuplicateExists: dup},
+		}
+	}
+
+	v.updateDoppelGangerStatuses(requests, responses(false), 11)
+	require.Equal(t, iface.DoppelGangerPending, v.DoppelGangerStatus(fresh.pub))
+	v.updateDoppelGangerStatuses(requests, responses(true), 12)
+	require.Equal(t, iface.DoppelGangerSafe, v.DoppelGangerStatus(fresh.pub))
+	require.Equal(t, true, v.performsDuties(fresh.pub))
+	require.Equal(t, iface.DoppelGangerDetected, v.DoppelGangerStatus(duplicate.pub))
+	require.Equal(t, false, v.performsDuties(duplicate.pub))
+
+	// The key which attested in epoch 11 cannot be checked before epoch 13.
+	v.updateDoppelGangerStatuses(requests, responses(false), 14)
+	require.Equal(t, iface.DoppelGangerPending, v.DoppelGangerStatus(attested.pub))
+	v.updateDoppelGangerStatuses(requests, responses(false), 15)
+	require.Equal(t, iface.DoppelGangerSafe, v.DoppelGangerStatus(attested.pub))
+	require.Equal(t, iface.DoppelGangerDetected, v.DoppelGangerStatus(duplicate.pub))
+
+	// A removed key waits for the window again once added back.
+	v.trackDoppelGangerKeys([][fieldparams.BLSPubkeyLength]byte{attested.pub}, 15, true)
+	require.Equal(t, iface.DoppelGangerUnknown, v.DoppelGangerStatus(fresh.pub))
+	v.trackDoppelGangerKeys(keys, 16, true)
+	require.Equal(t, iface.DoppelGangerPending, v.DoppelGangerStatus(fresh.pub))
+	require.Equal(t, iface.DoppelGangerPending, v.DoppelGangerStatus(duplicate.pub))
+	require.Equal(t, iface.DoppelGangerSafe, v.DoppelGangerStatus(attested.pub))
+}
+
+func TestValidator_CheckPendingDoppelGangers(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{EnableDoppelGanger: true})
+	defer reset()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	safe, imported := randKeypair(t), randKeypair(t)
+	km := newMockKeymanager(t, safe, imported)
+	client := validatormock.NewMockValidatorClient(ctrl)
+	v := &validator{
+		validatorClient: client,
+		km:              km,
+		db:              dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{safe.pub, imported.pub}, false),
+		doppelGangerKeys: map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey{
+			safe.pub: {status: iface.DoppelGangerSafe},
+		},
+	}
+
+	// Only the imported key is checked.
+	client.EXPECT().CheckDoppelGanger(gomock.Any(), &doppelGangerRequestMatcher{&ethpb.DoppelGangerRequest{
+		ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{
+			{PublicKey: imported.pub[:], SignedRoot: make([]byte, fieldparams.RootLength)},
+		},
+	}}).Return(&ethpb.DoppelGangerResponse{
+		Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{{PublicKey: imported.pub[:]}},
+	}, nil).Times(3)
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	for epoch := primitives.Epoch(4); epoch <= 6; epoch++ {
+		require.Equal(t, iface.DoppelGangerSafe, v.DoppelGangerStatus(safe.pub))
+		require.NoError(t, v.CheckPendingDoppelGangers(context.Background(), primitives.Slot(epoch+1)*slotsPerEpoch-1))
+		if epoch < 6 {
+			require.Equal(t, iface.DoppelGangerPending, v.DoppelGangerStatus(imported.pub))
+		}
+	}
+	require.Equal(t, iface.DoppelGangerSafe, v.DoppelGangerStatus(imported.pub))
+
+	// No request is sent once every key is safe.
+	require.NoError(t, v.CheckPendingDoppelGangers(context.Background(), 7*slotsPerEpoch))
+}
+
+func TestValidator_RolesAt_DoppelGangerPending(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{EnableDoppelGanger: true})
+	defer reset()
+	v, _, validatorKey, finish := setup(t, false)
+	defer finish()
+
+	v.duties = &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 1,
+				AttesterSlot:   1,
+				PublicKey:      validatorKey.PublicKey().Marshal(),
+			},
+		},
+	}
+	var pubkey [fieldparams.BLSPubkeyLength]byte
+	copy(pubkey[:], validatorKey.PublicKey().Marshal())
+	v.trackDoppelGangerKeys([][fieldparams.BLSPubkeyLength]byte{pubkey}, 0, false)
+
+	roleMap, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(roleMap))
+}
@@ -0,0 +1,542 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+// dutyTimelineEpochs is the number of epochs of duties kept in memory by the duty timeline.
+const dutyTimelineEpochs = 8
+
+// dutyAssignmentKey identifies the assignment of a duty. Sync committee assignments are recorded
+// at the start slot of the epoch, as they cover every slot of the epoch.
+type dutyAssignmentKey struct {
+	pubkey [fieldparams.BLSPubkeyLength]byte
+	slot   primitives.Slot
+	role   iface.ValidatorRole
+}
+
+// dutyTimeline records the duties performed by each key, along with when they were assigned, the beacon node
+// used, the time spent signing and the result, to diagnose late and missed duties. When persisted, the file is
+// compacted once per epoch to the records of the timeline window.
+type dutyTimeline struct {
+	lock           sync.RWMutex
+	records        map[[fieldparams.BLSPubkeyLength]byte][]*iface.DutyRecord
+	assignments    map[dutyAssignmentKey]time.Time
+	path           string
+	file           *os.File
+	compactedEpoch primitives.Epoch
+}
+
+// persistedDutyRecord is the format of the duty records appended to the duty timeline file, one JSON object per line.
+type persistedDutyRecord struct {
+	Slot           primitives.Slot `json:"slot"`
+	PubKey         string          `json:"pubkey"`
+	Role           string          `json:"role"`
+	AssignedAt     time.Time       `json:"assigned_at"`
+	StartedAt      time.Time       `json:"started_at"`
+	BeaconNode     string          `json:"beacon_node"`
+	SigningLatency time.Duration   `json:"signing_latency_ns"`
+	SubmittedAt    time.Time       `json:"submitted_at"`
+	Result         string          `json:"result"`
+	FailedStep     string          `json:"failed_step,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// newDutyTimeline creates a duty timeline. When path is set, the records are appended to the file at path,
+// and the records already in the file are loaded. Records older than the timeline window are removed from the
+// file once the duties of the next slot are assigned.
+func newDutyTimeline(path string) (*dutyTimeline, error) {
+	t := &dutyTimeline{
+		records:     make(map[[fieldparams.BLSPubkeyLength]byte][]*iface.DutyRecord),
+		assignments: make(map[dutyAssignmentKey]time.Time),
+	}
+	if path == "" {
+		return t, nil
+	}
+	if err := t.load(path); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create duty timeline directory")
+	}
+	t.path = filepath.Clean(path)
+	f, err := openDutyTimelineFile(t.path)
+	if err != nil {
+		return nil, err
+	}
+	t.file = f
+	return t, nil
+}
+
+func openDutyTimelineFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open duty timeline file")
+	}
+	return f, nil
+}
+
+func (t *dutyTimeline) load(path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not open duty timeline file")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close duty timeline file")
+		}
+	}()
+	scanner := bufio.NewScanner(f)
+	invalid := 0
+	for scanner.Scan() {
+		var p persistedDutyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &p); err != nil {
+			invalid++
+			continue
+		}
+		pubkey, err := hexutil.Decode(p.PubKey)
+		if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+			invalid++
+			continue
+		}
+		record := &iface.DutyRecord{
+			Slot:           p.Slot,
+			PubKey:         bytesutil.ToBytes48(pubkey),
+			Role:           roleFromString(p.Role),
+			AssignedAt:     p.AssignedAt,
+			StartedAt:      p.StartedAt,
+			BeaconNode:     p.BeaconNode,
+			SigningLatency: p.SigningLatency,
+			SubmittedAt:    p.SubmittedAt,
+			Result:         iface.DutyResult(p.Result),
+			FailedStep:     p.FailedStep,
+			Error:          p.Error,
+		}
+		t.records[record.PubKey] = append(t.records[record.PubKey], record)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "could not read duty timeline file")
+	}
+	if invalid > 0 {
+		log.WithField("count", invalid).Warn("Ignored invalid records in duty timeline file")
+	}
+	return nil
+}
+
+func roleFromString(s string) iface.ValidatorRole {
+	for _, role := range []iface.ValidatorRole{
+		iface.RoleAttester,
+		iface.RoleProposer,
+		iface.RoleAggregator,
+		iface.RoleSyncCommittee,
+		iface.RoleSyncCommitteeAggregator,
+	} {
+		if role.String() == s {
+			return role
+		}
+	}
+	return iface.RoleUnknown
+}
+
+// assign records when the duties fetched at the given slot were first seen, and prunes the records older
+// than the timeline window.
+func (t *dutyTimeline) assign(slot primitives.Slot, duties *ethpb.DutiesResponse, now time.Time) {
+	if t == nil || duties == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	add := func(key dutyAssignmentKey) {
+		if _, ok := t.assignments[key]; !ok {
+			t.assignments[key] = now
+		}
+	}
+	epoch := slots.ToEpoch(slot)
+	for i, epochDuties := range [][]*ethpb.DutiesResponse_Duty{duties.CurrentEpochDuties, duties.NextEpochDuties} {
+		epochStart, err := slots.EpochStart(epoch + primitives.Epoch(i))
+		if err != nil {
+			continue
+		}
+		for _, duty := range epochDuties {
+			if duty == nil {
+				continue
+			}
+			pubkey := bytesutil.ToBytes48(duty.PublicKey)
+			add(dutyAssignmentKey{pubkey: pubkey, slot: duty.AttesterSlot, role: iface.RoleAttester})
+			for _, proposerSlot := range duty.ProposerSlots {
+				add(dutyAssignmentKey{pubkey: pubkey, slot: proposerSlot, role: iface.RoleProposer})
+			}
+			if duty.IsSyncCommittee {
+				add(dutyAssignmentKey{pubkey: pubkey, slot: epochStart, role: iface.RoleSyncCommittee})
+			}
+		}
+	}
+	t.prune(slot)
+}
+
+// prune removes the records and assignments older than the timeline window, and compacts the timeline file
+// when entering a new epoch. The lock must be held.
+func (t *dutyTimeline) prune(slot primitives.Slot) {
+	window := primitives.Slot(dutyTimelineEpochs) * params.BeaconConfig().SlotsPerEpoch
+	if slot < window {
+		return
+	}
+	defer func() {
+		if epoch := slots.ToEpoch(slot); t.file != nil && epoch > t.compactedEpoch {
+			t.compact()
+			t.compactedEpoch = epoch
+		}
+	}()
+	oldest := slot - window
+	for key := range t.assignments {
+		if key.slot < oldest {
+			delete(t.assignments, key)
+		}
+	}
+	for pubkey, records := range t.records {
+		kept := records[:0]
+		for _, record := range records {
+			if record.Slot >= oldest {
+				kept = append(kept, record)
+			}
+		}
+		if len(kept) == 0 {
+			delete(t.records, pubkey)
+		} else {
+			t.records[pubkey] = kept
+		}
+	}
+}
+
+// compact rewrites the timeline file with the records kept in memory. The new file is written next to the
+// current one and renamed over it, so that the records are not lost if the validator stops meanwhile.
+// The lock must be held.
+func (t *dutyTimeline) compact() {
+	records := make([]*iface.DutyRecord, 0)
+	for _, r := range t.records {
+		records = append(records, r...)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Slot < records[j].Slot
+	})
+
+	tmpPath := t.path + ".tmp"
+	if err := writeDutyRecords(tmpPath, records); err != nil {
+		log.WithError(err).Error("Could not compact duty timeline file")
+		if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Error("Could not remove temporary duty timeline file")
+		}
+		return
+	}
+	if err := os.Rename(tmpPath, t.path); err != nil {
+		log.WithError(err).Error("Could not replace duty timeline file")
+		return
+	}
+	if err := t.file.Close(); err != nil {
+		log.WithError(err).Error("Could not close duty timeline file")
+	}
+	f, err := openDutyTimelineFile(t.path)
+	if err != nil {
+		log.WithError(err).Error("Could not reopen duty timeline file, duties will no longer be persisted")
+		t.file = nil
+		return
+	}
+	t.file = f
+}
+
+func writeDutyRecords(path string, records []*iface.DutyRecord) (err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "could not create duty timeline file")
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "could not close duty timeline file")
+		}
+	}()
+	w := bufio.NewWriter(f)
+	for _, record := range records {
+		line, err := encodeDutyRecord(record)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(line); err != nil {
+			return errors.Wrap(err, "could not write duty record")
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Wrap(err, "could not write duty records")
+	}
+	return f.Sync()
+}
+
+// encodeDutyRecord encodes a record as a line of the timeline file.
+func encodeDutyRecord(record *iface.DutyRecord) ([]byte, error) {
+	line, err := json.Marshal(&persistedDutyRecord{
+		Slot:           record.Slot,
+		PubKey:         hexutil.Encode(record.PubKey[:]),
+		Role:           record.Role.String(),
+		AssignedAt:     record.AssignedAt,
+		StartedAt:      record.StartedAt,
+		BeaconNode:     record.BeaconNode,
+		SigningLatency: record.SigningLatency,
+		SubmittedAt:    record.SubmittedAt,
+		Result:         string(record.Result),
+		FailedStep:     record.FailedStep,
+		Error:          record.Error,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode duty record")
+	}
+	return append(line, '\n'), nil
+}
+
+// assignedAt returns when the duty was first seen, or the zero time if it is unknown.
+func (t *dutyTimeline) assignedAt(pubkey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, role iface.ValidatorRole) time.Time {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	switch role {
+	case iface.RoleAggregator:
+		role = iface.RoleAttester
+	case iface.RoleSyncCommitteeAggregator:
+		role = iface.RoleSyncCommittee
+	}
+	if role == iface.RoleSyncCommittee {
+		start, err := slots.EpochStart(slots.ToEpoch(slot))
+		if err != nil {
+			return time.Time{}
+		}
+		slot = start
+	}
+	return t.assignments[dutyAssignmentKey{pubkey: pubkey, slot: slot, role: role}]
+}
+
+// add records a finished duty.
+func (t *dutyTimeline) add(record *iface.DutyRecord) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.records[record.PubKey] = append(t.records[record.PubKey], record)
+	if t.file == nil {
+		return
+	}
+	line, err := encodeDutyRecord(record)
+	if err != nil {
+		log.WithError(err).Error("Could not encode duty record")
+		return
+	}
+	if _, err := t.file.Write(line); err != nil {
+		log.WithError(err).Error("Could not write duty record to duty timeline file")
+	}
+}
+
+// query returns the records of the given keys, or of every key if none is given, between the given slots
+// included, sorted by slot. The duties assigned before the current slot which were never performed are
+// returned as missed.
+func (t *dutyTimeline) query(
+	pubkeys [][fieldparams.BLSPubkeyLength]byte, fromSlot, toSlot, currentSlot primitives.Slot,
+) []*iface.DutyRecord {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	queried := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		queried[pubkey] = true
+	}
+	if len(pubkeys) == 0 {
+		for pubkey := range t.records {
+			queried[pubkey] = true
+		}
+		for key := range t.assignments {
+			queried[key.pubkey] = true
+		}
+	}
+	result := make([]*iface.DutyRecord, 0)
+	performed := make(map[dutyAssignmentKey]bool)
+	for pubkey := range queried {
+		for _, record := range t.records[pubkey] {
+			performed[dutyAssignmentKey{pubkey: pubkey, slot: record.Slot, role: record.Role}] = true
+			if record.Slot >= fromSlot && record.Slot <= toSlot {
+				r := *record
+				result = append(result, &r)
+			}
+		}
+	}
+	missed := func(key dutyAssignmentKey, slot primitives.Slot, assignedAt time.Time) {
+		if slot < fromSlot || slot > toSlot || slot >= currentSlot {
+			return
+		}
+		if performed[dutyAssignmentKey{pubkey: key.pubkey, slot: slot, role: key.role}] {
+			return
+		}
+		result = append(result, &iface.DutyRecord{
+			Slot:       slot,
+			PubKey:     key.pubkey,
+			Role:       key.role,
+			AssignedAt: assignedAt,
+			Result:     iface.DutyMissed,
+		})
+	}
+	for key, assignedAt := range t.assignments {
+		if !queried[key.pubkey] {
+			continue
+		}
+		if key.role != iface.RoleSyncCommittee {
+			missed(key, key.slot, assignedAt)
+			continue
+		}
+		// The sync committee assignment covers every slot of the epoch.
+		for slot := key.slot; slot < key.slot+params.BeaconConfig().SlotsPerEpoch; slot++ {
+			missed(key, slot, assignedAt)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Slot != result[j].Slot {
+			return result[i].Slot < result[j].Slot
+		}
+		if result[i].Role != result[j].Role {
+			return result[i].Role < result[j].Role
+		}
+		return bytes.Compare(result[i].PubKey[:], result[j].PubKey[:]) < 0
+	})
+	return result
+}
+
+func (t *dutyTimeline) close() error {
+	if t == nil || t.file == nil {
+		return nil
+	}
+	return t.file.Close()
+}
+
+// dutyRecord is a duty being performed. A nil dutyRecord records nothing, so that duties can be performed
+// without a duty timeline.
+type dutyRecord struct {
+	timeline       *dutyTimeline
+	record         *iface.DutyRecord
+	signingLatency atomic.Int64
+}
+
+// fail records that the given step of the duty failed.
+func (r *dutyRecord) fail(step string, err error) {
+	if r == nil {
+		return
+	}
+	r.record.FailedStep = step
+	if err != nil {
+		r.record.Error = err.Error()
+	}
+}
+
+// submitted records that the duty was submitted to the beacon node.
+func (r *dutyRecord) submitted() {
+	if r == nil {
+		return
+	}
+	r.record.SubmittedAt = time.Now()
+}
+
+func (r *dutyRecord) addSigningLatency(d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.signingLatency.Add(int64(d))
+}
+
+// finish adds the record of the duty to the timeline.
+func (r *dutyRecord) finish() {
+	if r == nil {
+		return
+	}
+	r.record.SigningLatency = time.Duration(r.signingLatency.Load())
+	switch {
+	case !r.record.SubmittedAt.IsZero():
+		r.record.Result = iface.DutySubmitted
+	case r.record.FailedStep != "":
+		r.record.Result = iface.DutyFailed
+	default:
+		r.record.Result = iface.DutySkipped
+	}
+	r.timeline.add(r.record)
+}
+
+type dutyRecordContextKey struct{}
+
+func withDutyRecord(ctx context.Context, r *dutyRecord) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, dutyRecordContextKey{}, r)
+}
+
+func dutyRecordFromContext(ctx context.Context) *dutyRecord {
+	r, _ := ctx.Value(dutyRecordContextKey{}).(*dutyRecord)
+	return r
+}
+
+// startDuty starts recording a duty in the duty timeline. The record is attached to the returned context
+// so that the signatures of the duty are accounted for. It must be finished once the duty is done.
+func (v *validator) startDuty(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, role iface.ValidatorRole,
+) (context.Context, *dutyRecord) {
+	if v.dutyTimeline == nil {
+		return ctx, nil
+	}
+	r := &dutyRecord{
+		timeline: v.dutyTimeline,
+		record: &iface.DutyRecord{
+			Slot:       slot,
+			PubKey:     pubKey,
+			Role:       role,
+			AssignedAt: v.dutyTimeline.assignedAt(pubKey, slot, role),
+			StartedAt:  time.Now(),
+			BeaconNode: v.beaconNode(),
+		},
+	}
+	return withDutyRecord(ctx, r), r
+}
+
+// beaconNode returns the beacon node the duties are sent to.
+func (v *validator) beaconNode() string {
+	if v.nodeScorer != nil {
+		return v.nodeScorer.Primary()
+	}
+	if v.validatorClient == nil {
+		return ""
+	}
+	return v.validatorClient.Host()
+}
+
+// DutyTimeline returns the recorded duties of the given keys, or of every key if none is given, between
+// the given slots included, along with the assigned duties of past slots which were missed.
+func (v *validator) DutyTimeline(pubKeys [][fieldparams.BLSPubkeyLength]byte, fromSlot, toSlot primitives.Slot) []*iface.DutyRecord {
+	if v.dutyTimeline == nil {
+		return []*iface.DutyRecord{}
+	}
+	var currentSlot primitives.Slot
+	if v.genesisTime != 0 {
+		currentSlot = slots.CurrentSlot(v.genesisTime)
+	}
+	return v.dutyTimeline.query(pubKeys, fromSlot, toSlot, currentSlot)
+}
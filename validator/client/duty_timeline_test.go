@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidator_DutyTimeline_Disabled(t *testing.T) {
+	v := &validator{}
+	kp := randKeypair(t)
+	ctx, record := v.startDuty(context.Background(), kp.pub, 1, iface.RoleAttester)
+	require.Equal(t, (*dutyRecord)(nil), record)
+	require.Equal(t, (*dutyRecord)(nil), dutyRecordFromContext(ctx))
+	record.fail("sign", errors.New("failed"))
+	record.submitted()
+	record.finish()
+	require.Equal(t, 0, len(v.DutyTimeline(nil, 0, 10)))
+}
+
+func TestValidator_DutyTimeline(t *testing.T) {
+	timeline, err := newDutyTimeline("")
+	require.NoError(t, err)
+	v := &validator{dutyTimeline: timeline}
+	attester, other := randKeypair(t), randKeypair(t)
+	assigned := time.Now()
+	timeline.assign(0, &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: attester.pub[:], AttesterSlot: 3, ProposerSlots: []primitives.Slot{5}},
+			{PublicKey: other.pub[:], AttesterSlot: 4, IsSyncCommittee: true},
+		},
+	}, assigned)
+
+	ctx, record := v.startDuty(context.Background(), attester.pub, 3, iface.RoleAttester)
+	dutyRecordFromContext(ctx).addSigningLatency(10 * time.Millisecond)
+	dutyRecordFromContext(ctx).addSigningLatency(5 * time.Millisecond)
+	record.submitted()
+	record.finish()
+
+	_, record = v.startDuty(context.Background(), attester.pub, 3, iface.RoleAggregator)
+	record.fail("aggregate_request", errors.New("no aggregate"))
+	record.finish()
+
+	_, record = v.startDuty(context.Background(), attester.pub, 5, iface.RoleProposer)
+	record.fail("block_request", errors.New("beacon node unavailable"))
+	record.finish()
+
+	_, record = v.startDuty(context.Background(), other.pub, 6, iface.RoleSyncCommittee)
+	record.finish()
+
+	records := v.DutyTimeline([][fieldparams.BLSPubkeyLength]byte{attester.pub}, 0, 10)
+	require.Equal(t, 3, len(records))
+	assert.Equal(t, iface.RoleAttester, records[0].Role)
+	assert.Equal(t, iface.DutySubmitted, records[0].Result)
+	assert.Equal(t, true, records[0].AssignedAt.Equal(assigned))
+	assert.Equal(t, 15*time.Millisecond, records[0].SigningLatency)
+	assert.Equal(t, iface.RoleAggregator, records[1].Role)
+	assert.Equal(t, iface.DutyFailed, records[1].Result)
+	assert.Equal(t, true, records[1].AssignedAt.Equal(assigned))
+	assert.Equal(t, iface.RoleProposer, records[2].Role)
+	assert.Equal(t, "block_request", records[2].FailedStep)
+	assert.Equal(t, "beacon node unavailable", records[2].Error)
+
+	records = v.DutyTimeline([][fieldparams.BLSPubkeyLength]byte{other.pub}, 0, 10)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, iface.DutySkipped, records[0].Result)
+	assert.Equal(t, true, records[0].AssignedAt.Equal(assigned))
+
+	require.Equal(t, 4, len(v.DutyTimeline(nil, 0, 10)))
+	require.Equal(t, 2, len(v.DutyTimeline(nil, 4, 10)))
+	require.Equal(t, 0, len(v.DutyTimeline(nil, 7, 10)))
+}
+
+func TestValidator_DutyTimeline_Missed(t *testing.T) {
+	timeline, err := newDutyTimeline("")
+	require.NoError(t, err)
+	// The current slot is 10.
+	genesis := time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	v := &validator{dutyTimeline: timeline, genesisTime: uint64(genesis.Unix())}
+	offline, syncMember := randKeypair(t), randKeypair(t)
+	assigned := time.Now()
+	timeline.assign(0, &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: offline.pub[:], AttesterSlot: 3, ProposerSlots: []primitives.Slot{5, 12}},
+			{PublicKey: syncMember.pub[:], AttesterSlot: 20, IsSyncCommittee: true},
+		},
+	}, assigned)
+
+	_, record := v.startDuty(context.Background(), offline.pub, 3, iface.RoleAttester)
+	record.submitted()
+	record.finish()
+	_, record = v.startDuty(context.Background(), syncMember.pub, 4, iface.RoleSyncCommittee)
+	record.submitted()
+	record.finish()
+
+	// The proposal of slot 5 never started, the one of slot 12 is still to come.
+	records := v.DutyTimeline([][fieldparams.BLSPubkeyLength]byte{offline.pub}, 0, 20)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, iface.DutySubmitted, records[0].Result)
+	assert.Equal(t, primitives.Slot(5), records[1].Slot)
+	assert.Equal(t, iface.RoleProposer, records[1].Role)
+	assert.Equal(t, iface.DutyMissed, records[1].Result)
+	assert.Equal(t, true, records[1].AssignedAt.Equal(assigned))
+	assert.Equal(t, true, records[1].StartedAt.IsZero())
+
+	// The sync committee duty is missed at every past slot but the one performed.
+	records = v.DutyTimeline([][fieldparams.BLSPubkeyLength]byte{syncMember.pub}, 0, 20)
+	require.Equal(t, 10, len(records))
+	for i, r := range records {
+		assert.Equal(t, primitives.Slot(i), r.Slot)
+		assert.Equal(t, iface.RoleSyncCommittee, r.Role)
+		if r.Slot == 4 {
+			assert.Equal(t, iface.DutySubmitted, r.Result)
+		} else {
+			assert.Equal(t, iface.DutyMissed, r.Result)
+		}
+	}
+
+	// Keys without any performed duty are returned when every key is queried.
+	records = v.DutyTimeline(nil, 5, 5)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, 4, len(v.DutyTimeline(nil, 6, 20)))
+}
+
+func TestDutyTimeline_Prune(t *testing.T) {
+	timeline, err := newDutyTimeline("")
+	require.NoError(t, err)
+	kp := randKeypair(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	timeline.add(&iface.DutyRecord{Slot: 1, PubKey: kp.pub, Role: iface.RoleAttester, Result: iface.DutySubmitted})
+	timeline.add(&iface.DutyRecord{Slot: slotsPerEpoch * 2, PubKey: kp.pub, Role: iface.RoleAttester, Result: iface.DutySubmitted})
+
+	timeline.assign(slotsPerEpoch*dutyTimelineEpochs+2, &ethpb.DutiesResponse{}, time.Now())
+	records := timeline.query(nil, 0, slotsPerEpoch*dutyTimelineEpochs, 0)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, slotsPerEpoch*2, records[0].Slot)
+}
+
+func TestDutyTimeline_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeline", "duties.jsonl")
+	timeline, err := newDutyTimeline(path)
+	require.NoError(t, err)
+	kp := randKeypair(t)
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := &iface.DutyRecord{
+		Slot:           12,
+		PubKey:         kp.pub,
+		Role:           iface.RoleSyncCommitteeAggregator,
+		StartedAt:      started,
+		BeaconNode:     "localhost:4000",
+		SigningLatency: 3 * time.Millisecond,
+		Result:         iface.DutyFailed,
+		FailedStep:     "submit",
+		Error:          "rejected",
+	}
+	timeline.add(record)
+	require.NoError(t, timeline.close())
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("not a record\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	timeline, err = newDutyTimeline(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, timeline.close())
+	}()
+	records := timeline.query(nil, 0, 20, 0)
+	require.Equal(t, 1, len(records))
+	require.DeepEqual(t, record, records[0])
+}
+
+func TestProposeBlock_DutyTimeline(t *testing.T) {
+	v, m, validatorKey, finish := setup(t, false)
+	defer finish()
+	timeline, err := newDutyTimeline("")
+	require.NoError(t, err)
+	v.dutyTimeline = timeline
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+
+	m.validatorClient.EXPECT().Host().Return("localhost:4000")
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+	m.validatorClient.EXPECT().BeaconBlock(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.BlockRequest{}),
+	).Return(nil /*response*/, errors.New("uh oh"))
+
+	v.ProposeBlock(context.Background(), 1, pubKey)
+	records := v.DutyTimeline(nil, 1, 1)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, iface.RoleProposer, records[0].Role)
+	assert.Equal(t, "localhost:4000", records[0].BeaconNode)
+	assert.Equal(t, iface.DutyFailed, records[0].Result)
+	assert.Equal(t, "block_request", records[0].FailedStep)
+	assert.Equal(t, "uh oh", records[0].Error)
+	assert.Equal(t, true, records[0].SigningLatency > 0)
+}
+
+func TestDutyTimeline_Compaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "duties.jsonl")
+	timeline, err := newDutyTimeline(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, timeline.close())
+	}()
+	kp := randKeypair(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	window := slotsPerEpoch * dutyTimelineEpochs
+	for _, slot := range []primitives.Slot{1, slotsPerEpoch * 2, window} {
+		timeline.add(&iface.DutyRecord{Slot: slot, PubKey: kp.pub, Role: iface.RoleAttester, Result: iface.DutySubmitted})
+	}
+
+	timeline.assign(window+slotsPerEpoch, &ethpb.DutiesResponse{}, time.Now())
+	loaded, err := newDutyTimeline(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.close())
+	records := loaded.query(nil, 0, window, 0)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, slotsPerEpoch*2, records[0].Slot)
+	assert.Equal(t, window, records[1].Slot)
+	_, err = os.Stat(path + ".tmp")
+	require.Equal(t, true, os.IsNotExist(err))
+
+	// Records are appended to the compacted file.
+	timeline.add(&iface.DutyRecord{Slot: window + slotsPerEpoch, PubKey: kp.pub, Role: iface.RoleAttester, Result: iface.DutySubmitted})
+	loaded, err = newDutyTimeline(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.close())
+	require.Equal(t, 3, len(loaded.query(nil, 0, window+slotsPerEpoch, 0)))
+
+	// The file is compacted once per epoch.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	timeline.add(&iface.DutyRecord{Slot: 1, PubKey: kp.pub, Role: iface.RoleAttester, Result: iface.DutySubmitted})
+	timeline.assign(window+slotsPerEpoch+1, &ethpb.DutiesResponse{}, time.Now())
+	grown, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, true, grown.Size() > info.Size())
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = grpc.ClientConnInterface(&failoverGrpcConn{})
+
+// failoverGrpcConn routes every call to the connection of the primary beacon node selected by the scorer.
+// When the primary fails or stalls, unary calls are retried against the remaining nodes in order of their score.
+type failoverGrpcConn struct {
+	conns       map[string]*grpc.ClientConn
+	scorer      *beaconNodeScorer
+	broadcaster *broadcaster
+}
+
+func newFailoverGrpcConn(conns map[string]*grpc.ClientConn, endpoints []string) *failoverGrpcConn {
+	probers := make(map[string]beaconNodeProber, len(conns))
+	for e, c := range conns {
+		probers[e] = newGrpcBeaconNodeProber(c)
+	}
+	return &failoverGrpcConn{
+		conns:  conns,
+		scorer: newBeaconNodeScorer(probers, endpoints),
+	}
+}
+
+// enableBroadcast publishes signed duties through all healthy beacon nodes.
+func (f *failoverGrpcConn) enableBroadcast(policy broadcastPolicy) {
+	f.broadcaster = &broadcaster{scorer: f.scorer, policy: policy}
+}
+
+// Invoke performs a unary RPC against the primary beacon node, failing over to the other nodes on error.
+func (f *failoverGrpcConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	if duty, ok := broadcastDutyFromContext(ctx); ok && f.broadcaster != nil {
+		return f.broadcastInvoke(ctx, duty, method, args, reply, opts...)
+	}
+	candidates := f.scorer.Ordered()
+	var err error
+	for i, endpoint := range candidates {
+		attemptCtx, cancel := failoverAttemptContext(ctx, len(candidates)-i)
+		err = f.conns[endpoint].Invoke(attemptCtx, method, args, reply, opts...)
+		cancel()
+		f.scorer.RecordResult(endpoint, err)
+		if !isNodeFailure(err) || ctx.Err() != nil {
+			return err
+		}
+		if i < len(candidates)-1 {
+			log.WithError(err).WithField("endpoint", endpoint).Debug("Beacon node request failed, trying next node")
+		}
+	}
+	return err
+}
+
+func (f *failoverGrpcConn) broadcastInvoke(ctx context.Context, duty string, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	replyMsg, ok := reply.(proto.Message)
+	if !ok {
+		return fmt.Errorf("reply %T is not a proto message", reply)
+	}
+	var mu sync.Mutex
+	replies := make(map[string]proto.Message)
+	winner, err := f.broadcaster.broadcast(ctx, duty, func(ctx context.Context, endpoint string) error {
+		endpointReply := replyMsg.ProtoReflect().New().Interface()
+		if err := f.conns[endpoint].Invoke(ctx, method, args, endpointReply, opts...); err != nil {
+			return err
+		}
+		mu.Lock()
+		replies[endpoint] = endpointReply
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	proto.Merge(replyMsg, replies[winner])
+	return nil
+}
+
+// NewStream opens a stream to the primary beacon node. If the stream cannot be opened, the other nodes are tried
+// in order of their score. Streams are not moved to another node once established.
+func (f *failoverGrpcConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var err error
+	for _, endpoint := range f.scorer.Ordered() {
+		var stream grpc.ClientStream
+		stream, err = f.conns[endpoint].NewStream(ctx, desc, method, opts...)
+		f.scorer.RecordResult(endpoint, err)
+		if err == nil {
+			return stream, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// Close closes the connections to all beacon nodes.
+func (f *failoverGrpcConn) Close() error {
+	var closeErr error
+	for e, c := range f.conns {
+		if err := c.Close(); err != nil {
+			closeErr = errors.Wrapf(err, "could not close connection to %s", e)
+		}
+	}
+	return closeErr
+}
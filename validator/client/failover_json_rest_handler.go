@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
+)
+
+var _ = beaconApi.JsonRestHandler(&failoverJsonRestHandler{})
+
+// failoverJsonRestHandler sends every request to the primary beacon node selected by the scorer.
+// When the primary fails or stalls, the request is retried against the remaining nodes in order of their score.
+type failoverJsonRestHandler struct {
+	client      http.Client
+	handlers    map[string]beaconApi.JsonRestHandler
+	scorer      *beaconNodeScorer
+	broadcaster *broadcaster
+}
+
+func newFailoverJsonRestHandler(client http.Client, hosts []string) *failoverJsonRestHandler {
+	handlers := make(map[string]beaconApi.JsonRestHandler, len(hosts))
+	probers := make(map[string]beaconNodeProber, len(hosts))
+	for _, h := range hosts {
+		handlers[h] = beaconApi.NewBeaconApiJsonRestHandler(client, h)
+		probers[h] = &restBeaconNodeProber{handler: handlers[h]}
+	}
+	return &failoverJsonRestHandler{
+		client:   client,
+		handlers: handlers,
+		scorer:   newBeaconNodeScorer(probers, hosts),
+	}
+}
+
+// enableBroadcast publishes signed duties through all healthy beacon nodes.
+func (f *failoverJsonRestHandler) enableBroadcast(policy broadcastPolicy) {
+	f.broadcaster = &broadcaster{scorer: f.scorer, policy: policy}
+}
+
+// Get sends a GET request to the primary beacon node, failing over to the other nodes on error.
+func (f *failoverJsonRestHandler) Get(ctx context.Context, endpoint string, resp interface{}) error {
+	return f.do(ctx, func(ctx context.Context, h beaconApi.JsonRestHandler) error {
+		return h.Get(ctx, endpoint, resp)
+	})
+}
+
+// Post sends a POST request to the primary beacon node, failing over to the other nodes on error.
+func (f *failoverJsonRestHandler) Post(
+	ctx context.Context,
+	endpoint string,
+	headers map[string]string,
+	data *bytes.Buffer,
+	resp interface{},
+) error {
+	if data == nil {
+		return errors.New("data is nil")
+	}
+	body := data.Bytes()
+	if duty, ok := broadcastDutyFromContext(ctx); ok && f.broadcaster != nil {
+		return f.broadcastPost(ctx, duty, endpoint, headers, body, resp)
+	}
+	return f.do(ctx, func(ctx context.Context, h beaconApi.JsonRestHandler) error {
+		return h.Post(ctx, endpoint, headers, bytes.NewBuffer(body), resp)
+	})
+}
+
+// HttpClient returns the HTTP client shared by all beacon nodes.
+func (f *failoverJsonRestHandler) HttpClient() *http.Client {
+	return &f.client
+}
+
+// Host returns the primary beacon node.
+func (f *failoverJsonRestHandler) Host() string {
+	return f.scorer.Primary()
+}
+
+// SetHost is a no-op, the host is selected by the scorer.
+func (*failoverJsonRestHandler) SetHost(_ string) {}
+
+func (f *failoverJsonRestHandler) do(ctx context.Context, req func(context.Context, beaconApi.JsonRestHandler) error) error {
+	candidates := f.scorer.Ordered()
+	var err error
+	for i, host := range candidates {
+		attemptCtx, cancel := failoverAttemptContext(ctx, len(candidates)-i)
+		err = req(attemptCtx, f.handlers[host])
+		cancel()
+		f.scorer.RecordResult(host, err)
+		if !isNodeFailure(err) || ctx.Err() != nil {
+			return err
+		}
+		if i < len(candidates)-1 {
+			log.WithError(err).WithField("host", host).Debug("Beacon node request failed, trying next node")
+		}
+	}
+	return err
+}
+
+func (f *failoverJsonRestHandler) broadcastPost(
+	ctx context.Context,
+	duty string,
+	endpoint string,
+	headers map[string]string,
+	body []byte,
+	resp interface{},
+) error {
+	var mu sync.Mutex
+	responses := make(map[string]interface{})
+	winner, err := f.broadcaster.broadcast(ctx, duty, func(ctx context.Context, host string) error {
+		var hostResp interface{}
+		if resp != nil {
+			hostResp = reflect.New(reflect.TypeOf(resp).Elem()).Interface()
+		}
+		if err := f.handlers[host].Post(ctx, endpoint, headers, bytes.NewBuffer(body), hostResp); err != nil {
+			return err
+		}
+		mu.Lock()
+		responses[host] = hostResp
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		mu.Lock()
+		defer mu.Unlock()
+		reflect.ValueOf(resp).Elem().Set(reflect.ValueOf(responses[winner]).Elem())
+	}
+	return nil
+}
+
+// failoverAttemptContext limits a single attempt to a share of the remaining time, so that a stalled
+// beacon node leaves enough time to retry the request against the remaining nodes.
+func failoverAttemptContext(ctx context.Context, remainingCandidates int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || remainingCandidates <= 1 {
+		return context.WithCancel(ctx)
+	}
+	share := time.Until(deadline) / 2
+	return context.WithTimeout(ctx, share)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func newTestBeaconNodeServer(t *testing.T, headSlot string, syncing bool, endpointHandler http.HandlerFunc) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/node/syncing", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		require.NoError(t, json.NewEncoder(w).Encode(&structs.SyncStatusResponse{
+			Data: &structs.SyncStatusResponseData{HeadSlot: headSlot, IsSyncing: syncing},
+		}))
+	})
+	mux.HandleFunc("/eth/v1/node/peer_count", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		require.NoError(t, json.NewEncoder(w).Encode(&structs.GetPeerCountResponse{
+			Data: &structs.PeerCount{Connected: "50"},
+		}))
+	})
+	mux.HandleFunc("/endpoint", endpointHandler)
+	return httptest.NewServer(mux)
+}
+
+func writeVersion(t *testing.T, version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			version = string(body)
+		}
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		require.NoError(t, json.NewEncoder(w).Encode(&structs.GetVersionResponse{
+			Data: &structs.Version{Version: version},
+		}))
+	}
+}
+
+func TestFailoverJsonRestHandler_UsesHealthiestNode(t *testing.T) {
+	syncingNode := newTestBeaconNodeServer(t, "100", true, writeVersion(t, "syncing"))
+	defer syncingNode.Close()
+	healthyNode := newTestBeaconNodeServer(t, "100", false, writeVersion(t, "healthy"))
+	defer healthyNode.Close()
+
+	h := newFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{syncingNode.URL, healthyNode.URL})
+	h.scorer.Probe(context.Background())
+	assert.Equal(t, healthyNode.URL, h.Host())
+
+	resp := &structs.GetVersionResponse{}
+	require.NoError(t, h.Get(context.Background(), "/endpoint", resp))
+	assert.Equal(t, "healthy", resp.Data.Version)
+}
+
+func TestFailoverJsonRestHandler_FailsOver(t *testing.T) {
+	failingNode := newTestBeaconNodeServer(t, "100", false, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		require.NoError(t, json.NewEncoder(w).Encode(&httputil.DefaultJsonError{Code: http.StatusServiceUnavailable, Message: "unavailable"}))
+	})
+	defer failingNode.Close()
+	backupNode := newTestBeaconNodeServer(t, "100", false, writeVersion(t, "backup"))
+	defer backupNode.Close()
+
+	h := newFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{failingNode.URL, backupNode.URL})
+	h.scorer.Probe(context.Background())
+	require.Equal(t, failingNode.URL, h.Host())
+
+	resp := &structs.GetVersionResponse{}
+	require.NoError(t, h.Get(context.Background(), "/endpoint", resp))
+	assert.Equal(t, "backup", resp.Data.Version)
+
+	resp = &structs.GetVersionResponse{}
+	require.NoError(t, h.Post(context.Background(), "/endpoint", nil, bytes.NewBufferString("posted"), resp))
+	assert.Equal(t, "posted", resp.Data.Version)
+}
+
+func TestFailoverJsonRestHandler_DoesNotFailOverOnRejectedRequest(t *testing.T) {
+	calls := 0
+	rejectingNode := newTestBeaconNodeServer(t, "100", false, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", api.JsonMediaType)
+		w.WriteHeader(http.StatusBadRequest)
+		require.NoError(t, json.NewEncoder(w).Encode(&httputil.DefaultJsonError{Code: http.StatusBadRequest, Message: "bad request"}))
+	})
+	defer rejectingNode.Close()
+	backupNode := newTestBeaconNodeServer(t, "100", false, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeVersion(t, "backup")(w, r)
+	})
+	defer backupNode.Close()
+
+	h := newFailoverJsonRestHandler(http.Client{Timeout: time.Second}, []string{rejectingNode.URL, backupNode.URL})
+	err := h.Get(context.Background(), "/endpoint", &structs.GetVersionResponse{})
+	require.ErrorContains(t, "bad request", err)
+	assert.Equal(t, 0, calls)
+}
+
+func TestFailoverJsonRestHandler_FailsOverOnStall(t *testing.T) {
+	stalledNode := newTestBeaconNodeServer(t, "100", false, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer stalledNode.Close()
+	backupNode := newTestBeaconNodeServer(t, "100", false, writeVersion(t, "backup"))
+	defer backupNode.Close()
+
+	h := newFailoverJsonRestHandler(http.Client{Timeout: 10 * time.Second}, []string{stalledNode.URL, backupNode.URL})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp := &structs.GetVersionResponse{}
+	require.NoError(t, h.Get(ctx, "/endpoint", resp))
+	assert.Equal(t, "backup", resp.Data.Version)
+}
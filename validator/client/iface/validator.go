@@ -33,6 +33,76 @@ const (
 	RoleSyncCommitteeAggregator
 )
 
+// String returns the name of the role.
+func (r ValidatorRole) String() string {
+	switch r {
+	case RoleAttester:
+		return "attester"
+	case RoleProposer:
+		return "proposer"
+	case RoleAggregator:
+		return "aggregator"
+	case RoleSyncCommittee:
+		return "sync_committee"
+	case RoleSyncCommitteeAggregator:
+		return "sync_committee_aggregator"
+	default:
+		return "unknown"
+	}
+}
+
+// DutyResult is the outcome of a duty recorded in the duty timeline.
+type DutyResult string
+
+const (
+	// DutySubmitted means that the duty was submitted to the beacon node.
+	DutySubmitted DutyResult = "submitted"
+	// DutyFailed means that the duty could not be performed, see the error of the record.
+	DutyFailed DutyResult = "failed"
+	// DutySkipped means that there was nothing to submit for the duty, such as an empty committee.
+	DutySkipped DutyResult = "skipped"
+	// DutyMissed means that the duty was assigned but never performed, for instance because the validator
+	// client was offline or the key was held back by doppelganger protection.
+	DutyMissed DutyResult = "missed"
+)
+
+// DutyRecord is the record of a duty performed by a key, kept in the duty timeline.
+type DutyRecord struct {
+	Slot   primitives.Slot
+	PubKey [fieldparams.BLSPubkeyLength]byte
+	Role   ValidatorRole
+	// AssignedAt is the time the duty was first seen in the duties fetched from the beacon node, if known.
+	AssignedAt time.Time
+	// StartedAt is the time the validator client started performing the duty.
+	StartedAt time.Time
+	// BeaconNode is the beacon node used when the duty started.
+	BeaconNode string
+	// SigningLatency is the total time spent waiting for the signatures of the duty.
+	SigningLatency time.Duration
+	// SubmittedAt is the time the duty was submitted to the beacon node, if it was.
+	SubmittedAt time.Time
+	Result      DutyResult
+	// FailedStep is the step of the duty which failed, such as "sign" or "submit".
+	FailedStep string
+	Error      string
+}
+
+// DoppelGangerStatus is the doppelganger protection status of a key.
+type DoppelGangerStatus string
+
+const (
+	// DoppelGangerDisabled means that doppelganger protection is disabled, so the key performs duties.
+	DoppelGangerDisabled DoppelGangerStatus = "disabled"
+	// DoppelGangerUnknown means that the key is not tracked by doppelganger protection yet.
+	DoppelGangerUnknown DoppelGangerStatus = "unknown"
+	// DoppelGangerPending means that the key waits for the doppelganger window before performing duties.
+	DoppelGangerPending DoppelGangerStatus = "pending"
+	// DoppelGangerSafe means that no doppelganger was found during the window, so the key performs duties.
+	DoppelGangerSafe DoppelGangerStatus = "safe"
+	// DoppelGangerDetected means that the key was seen live in the network, so it does not perform duties.
+	DoppelGangerDetected DoppelGangerStatus = "detected"
+)
+
 // Validator interface defines the primary methods of a validator client.
 type Validator interface {
 	Done()
@@ -57,6 +127,9 @@ type Validator interface {
 	Keymanager() (keymanager.IKeymanager, error)
 	HandleKeyReload(ctx context.Context, currentKeys [][fieldparams.BLSPubkeyLength]byte) (bool, error)
 	CheckDoppelGanger(ctx context.Context) error
+	CheckPendingDoppelGangers(ctx context.Context, slot primitives.Slot) error
+	DoppelGangerStatus(pubKey [fieldparams.BLSPubkeyLength]byte) DoppelGangerStatus
+	DutyTimeline(pubKeys [][fieldparams.BLSPubkeyLength]byte, fromSlot, toSlot primitives.Slot) []*DutyRecord
 	PushProposerSettings(ctx context.Context, km keymanager.IKeymanager, slot primitives.Slot, deadline time.Time) error
 	SignValidatorRegistrationRequest(ctx context.Context, signer SigningFunc, newValidatorRegistration *ethpb.ValidatorRegistrationV1) (*ethpb.SignedValidatorRegistrationV1, error)
 	StartEventStream(ctx context.Context, topics []string, eventsChan chan<- *event.Event)
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	validator2 "github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -17,6 +18,11 @@ func (v *validator) HandleKeyReload(ctx context.Context, currentKeys [][fieldpar
 	ctx, span := trace.StartSpan(ctx, "validator.HandleKeyReload")
 	defer span.End()
 
+	// Keys added while the client runs wait for their own doppelganger window.
+	if features.Get().EnableDoppelGanger {
+		v.trackDoppelGangerKeys(currentKeys, v.currentEpoch(), true /* untrackMissing */)
+	}
+
 	statusRequestKeys := make([][]byte, len(currentKeys))
 	for i := range currentKeys {
 		statusRequestKeys[i] = currentKeys[i][:]
@@ -203,6 +203,73 @@ var (
 			"pubkey",
 		},
 	)
+	// beaconNodeScoreGaugeVec used to track the health score of each configured beacon node.
+	beaconNodeScoreGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_score",
+			Help:      "Health score of a beacon node. 0 means the node is unreachable, higher is better.",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeHeadSlotGaugeVec used to track the head slot reported by each configured beacon node.
+	beaconNodeHeadSlotGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_head_slot",
+			Help:      "Head slot reported by a beacon node.",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeSubmissionsCounterVec used to count signed duty submissions broadcast to each beacon node.
+	beaconNodeSubmissionsCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_submissions_total",
+			Help:      "Number of signed duties broadcast to a beacon node, by duty and result.",
+		},
+		[]string{
+			"endpoint",
+			"duty",
+			"result",
+		},
+	)
+	// beaconNodeSubmissionLatencyHistogramVec used to track the latency of signed duty submissions to each beacon node.
+	beaconNodeSubmissionLatencyHistogramVec = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_submission_latency_seconds",
+			Help:      "Latency of signed duties broadcast to a beacon node in seconds.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 4},
+		},
+		[]string{
+			"endpoint",
+			"duty",
+		},
+	)
+	// distributedPartialSignaturesCounterVec used to count the partial signatures produced in a Distributed Validator cluster.
+	distributedPartialSignaturesCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "distributed_partial_signatures_total",
+			Help:      "Number of partial signatures produced for the Distributed Validator middleware, by signed object.",
+		},
+		[]string{
+			"type",
+		},
+	)
+	// beaconNodeSwitchCount used to count switches of the primary beacon node.
+	beaconNodeSwitchCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_switches_total",
+			Help:      "Number of times the primary beacon node was switched.",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
@@ -55,6 +55,9 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	lock.Lock()
 	defer lock.Unlock()
 
+	ctx, record := v.startDuty(ctx, pubKey, slot, iface.RoleProposer)
+	defer record.finish()
+
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 	span.AddAttributes(trace.StringAttribute("validator", fmtKey))
 	log := log.WithField("pubkey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
@@ -64,6 +67,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	randaoReveal, err := v.signRandaoReveal(ctx, pubKey, epoch, slot)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign randao reveal")
+		record.fail("randao", err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -86,6 +90,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	})
 	if err != nil {
 		log.WithField("slot", slot).WithError(err).Error("Failed to request block from beacon node")
+		record.fail("block_request", err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -96,6 +101,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	wb, err := blocks.NewBeaconBlock(b.Block)
 	if err != nil {
 		log.WithError(err).Error("Failed to wrap block")
+		record.fail("block_request", err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -105,6 +111,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	sig, signingRoot, err := v.signBlock(ctx, pubKey, epoch, slot, wb)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign block")
+		record.fail("sign", err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -114,6 +121,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	blk, err := blocks.BuildSignedBeaconBlock(wb, sig)
 	if err != nil {
 		log.WithError(err).Error("Failed to build signed beacon block")
+		record.fail("sign", err)
 		return
 	}
 
@@ -121,6 +129,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		log.WithFields(
 			blockLogFields(pubKey, wb, nil),
 		).WithError(err).Error("Failed block slashing protection check")
+		record.fail("slashing_protection", err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -133,6 +142,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		pb, err := blk.Proto()
 		if err != nil {
 			log.WithError(err).Error("Failed to get deneb block")
+			record.fail("build_request", err)
 			return
 		}
 		switch blk.Version() {
@@ -140,12 +150,14 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 			genericSignedBlock, err = buildGenericSignedBlockDenebWithBlobs(pb, b)
 			if err != nil {
 				log.WithError(err).Error("Failed to build generic signed block")
+				record.fail("build_request", err)
 				return
 			}
 		case version.Electra:
 			genericSignedBlock, err = buildGenericSignedBlockElectraWithBlobs(pb, b)
 			if err != nil {
 				log.WithError(err).Error("Failed to build generic signed block")
+				record.fail("build_request", err)
 				return
 			}
 		default:
@@ -155,6 +167,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		genericSignedBlock, err = blk.PbGenericBlock()
 		if err != nil {
 			log.WithError(err).Error("Failed to create proposal request")
+			record.fail("build_request", err)
 			if v.emitAccountMetrics {
 				ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 			}
@@ -162,15 +175,18 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		}
 	}
 
-	blkResp, err := v.validatorClient.ProposeBeaconBlock(ctx, genericSignedBlock)
+	blkResp, err := v.validatorClient.ProposeBeaconBlock(withBroadcastDuty(ctx, broadcastDutyBlock), genericSignedBlock)
 	if err != nil {
 		log.WithField("slot", slot).WithError(err).Error("Failed to propose block")
+		record.fail("submit", err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
 		return
 	}
 
+	record.submitted()
+
 	span.AddAttributes(
 		trace.StringAttribute("blockRoot", fmt.Sprintf("%#x", blkResp.BlockRoot)),
 		trace.Int64Attribute("numDeposits", int64(len(blk.Block().Body().Deposits()))),
@@ -346,7 +362,7 @@ func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BL
 	if err != nil {
 		return nil, err
 	}
-	randaoReveal, err = v.km.Sign(ctx, &validatorpb.SignRequest{
+	randaoReveal, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -381,7 +397,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [fieldparams.BLSPubkey
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     blockRoot[:],
 		SignatureDomain: domain.SignatureDomain,
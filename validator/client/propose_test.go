@@ -862,6 +862,33 @@ func TestProposeExit_BroadcastsBlock(t *testing.T) {
 	}
 }
 
+func TestProposeExit_SignHooks(t *testing.T) {
+	_, m, validatorKey, finish := setup(t, false)
+	defer finish()
+
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), gomock.Any()).
+		Return(&ethpb.ValidatorIndexResponse{Index: 1}, nil)
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), gomock.Any()).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+	m.validatorClient.EXPECT().
+		ProposeExit(gomock.Any(), gomock.AssignableToTypeOf(&ethpb.SignedVoluntaryExit{})).
+		Return(&ethpb.ProposeExitResponse{}, nil)
+
+	hook := &recordingSignHook{}
+	require.NoError(t, ProposeExit(
+		context.Background(),
+		m.validatorClient,
+		WithSignHooks(m.signfunc, hook),
+		validatorKey.PublicKey().Marshal(),
+		params.BeaconConfig().GenesisEpoch,
+	))
+	require.Equal(t, 1, len(hook.preSigned))
+	require.NotNil(t, hook.preSigned[0].GetExit())
+	require.Equal(t, 1, len(hook.postSigned))
+}
+
 func TestSignBlock(t *testing.T) {
 	for _, isSlashingProtectionMinimal := range [...]bool{false, true} {
 		t.Run(fmt.Sprintf("SlashingProtectionMinimal:%v", isSlashingProtectionMinimal), func(t *testing.T) {
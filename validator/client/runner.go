@@ -113,6 +113,11 @@ func run(ctx context.Context, v iface.Validator) {
 			// Start fetching domain data for the next epoch.
 			if slots.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
+				go func() {
+					if err := v.CheckPendingDoppelGangers(ctx, slot); err != nil {
+						log.WithError(err).Warn("Could not check pending doppelgangers")
+					}
+				}()
 			}
 
 			var wg sync.WaitGroup
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v5/api/grpc"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
@@ -57,6 +59,11 @@ type ValidatorService struct {
 	emitAccountMetrics      bool
 	logValidatorPerformance bool
 	distributed             bool
+	dutyOffset              time.Duration
+	signHooks               []SignHook
+	broadcast               bool
+	broadcastPolicy         broadcastPolicy
+	dutyTimeline            *dutyTimeline
 }
 
 // Config for the validator service.
@@ -83,6 +90,12 @@ type Config struct {
 	LogValidatorPerformance bool
 	EmitAccountMetrics      bool
 	Distributed             bool
+	DistributedDutyOffset   time.Duration
+	DistributedSignHookURL  string
+	SignHooks               []SignHook
+	BroadcastSubmissions    bool
+	BroadcastPolicy         string
+	DutyTimelinePath        string
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -106,8 +119,46 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		emitAccountMetrics:      cfg.EmitAccountMetrics,
 		logValidatorPerformance: cfg.LogValidatorPerformance,
 		distributed:             cfg.Distributed,
+		dutyOffset:              cfg.DistributedDutyOffset,
+		signHooks:               cfg.SignHooks,
+		broadcast:               cfg.BroadcastSubmissions,
 	}
 
+	if cfg.DistributedDutyOffset < 0 {
+		return s, errors.New("duty offset cannot be negative")
+	}
+	if cfg.DistributedDutyOffset != 0 && !cfg.Distributed {
+		return s, errors.New("duty offset can only be used in a Distributed Validator cluster")
+	}
+	if cfg.DistributedSignHookURL != "" && !cfg.Distributed {
+		return s, errors.New("sign hook URL can only be used in a Distributed Validator cluster")
+	}
+	if cfg.Distributed && !features.Get().EnableBeaconRESTApi {
+		// The duty offset and the sign hook are served by a DV middleware, which only speaks the beacon API.
+		if cfg.DistributedSignHookURL != "" || cfg.DistributedDutyOffset != 0 {
+			return s, errors.New("the duty offset and the sign hook of a Distributed Validator require the beacon REST API, with the DV middleware as REST API provider")
+		}
+		log.Warn("Distributed Validator running without the beacon REST API, DV middlewares are usually reached through the beacon REST API")
+	}
+	if cfg.Distributed {
+		s.signHooks = append(s.signHooks, partialSignatureMetrics{})
+		if cfg.DistributedSignHookURL != "" {
+			s.signHooks = append(s.signHooks, newRemoteSignHook(cfg.DistributedSignHookURL))
+		}
+	}
+
+	policy, err := parseBroadcastPolicy(cfg.BroadcastPolicy)
+	if err != nil {
+		return s, err
+	}
+	s.broadcastPolicy = policy
+
+	timeline, err := newDutyTimeline(cfg.DutyTimelinePath)
+	if err != nil {
+		return s, err
+	}
+	s.dutyTimeline = timeline
+
 	dialOpts := ConstructDialOptions(
 		cfg.GRPCMaxCallRecvMsgSize,
 		cfg.BeaconNodeCert,
@@ -120,10 +171,13 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 
 	s.ctx = grpcutil.AppendHeaders(ctx, cfg.GRPCHeaders)
 
-	grpcConn, err := grpc.DialContext(ctx, cfg.BeaconNodeGRPCEndpoint, dialOpts...)
+	grpcConn, err := dialBeaconNodes(ctx, cfg.BeaconNodeGRPCEndpoint, dialOpts)
 	if err != nil {
 		return s, err
 	}
+	if conn, ok := grpcConn.(*failoverGrpcConn); ok && s.broadcast {
+		conn.enableBroadcast(s.broadcastPolicy)
+	}
 	if cfg.BeaconNodeCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
@@ -172,10 +226,30 @@ func (v *ValidatorService) Start() {
 		log.WithError(err).Error("No API hosts provided")
 		return
 	}
-	restHandler := beaconApi.NewBeaconApiJsonRestHandler(
-		http.Client{Timeout: v.conn.GetBeaconApiTimeout()},
-		hosts[0],
-	)
+	var restHandler beaconApi.JsonRestHandler
+	var nodeScorer *beaconNodeScorer
+	if len(hosts) > 1 {
+		failoverHandler := newFailoverJsonRestHandler(http.Client{Timeout: v.conn.GetBeaconApiTimeout()}, hosts)
+		if features.Get().EnableBeaconRESTApi {
+			nodeScorer = failoverHandler.scorer
+		}
+		if v.broadcast {
+			failoverHandler.enableBroadcast(v.broadcastPolicy)
+		}
+		restHandler = failoverHandler
+	} else {
+		restHandler = beaconApi.NewBeaconApiJsonRestHandler(
+			http.Client{Timeout: v.conn.GetBeaconApiTimeout()},
+			hosts[0],
+		)
+	}
+	if conn, ok := v.conn.GetGrpcClientConn().(*failoverGrpcConn); ok && !features.Get().EnableBeaconRESTApi {
+		nodeScorer = conn.scorer
+	}
+	if nodeScorer != nil {
+		log.WithField("endpoints", nodeScorer.Endpoints()).Info("Scoring beacon nodes to select the primary node")
+		go nodeScorer.Run(v.ctx, beaconNodeProbeInterval())
+	}
 
 	validatorClient := validatorclientfactory.NewValidatorClient(v.conn, restHandler)
 
@@ -193,6 +267,7 @@ func (v *ValidatorService) Start() {
 		graffitiOrderedIndex:           graffitiOrderedIndex,
 		beaconNodeHosts:                hosts,
 		currentHostIndex:               0,
+		nodeScorer:                     nodeScorer,
 		validatorClient:                validatorClient,
 		chainClient:                    beaconChainClientFactory.NewChainClient(v.conn, restHandler),
 		nodeClient:                     nodeclientfactory.NewNodeClient(v.conn, restHandler),
@@ -215,6 +290,9 @@ func (v *ValidatorService) Start() {
 		emitAccountMetrics:             v.emitAccountMetrics,
 		useWeb:                         v.useWeb,
 		distributed:                    v.distributed,
+		dutyOffset:                     v.dutyOffset,
+		signHooks:                      v.signHooks,
+		dutyTimeline:                   v.dutyTimeline,
 	}
 
 	v.validator = valStruct
@@ -225,12 +303,40 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	if err := v.dutyTimeline.close(); err != nil {
+		log.WithError(err).Error("Could not close duty timeline file")
+	}
 	if v.conn != nil {
-		return v.conn.GetGrpcClientConn().Close()
+		if c, ok := v.conn.GetGrpcClientConn().(io.Closer); ok {
+			return c.Close()
+		}
 	}
 	return nil
 }
 
+// dialBeaconNodes dials the comma separated beacon node gRPC endpoints. When more than one endpoint
+// is provided, each node is dialed separately and calls are routed to the healthiest node.
+func dialBeaconNodes(ctx context.Context, endpoint string, dialOpts []grpc.DialOption) (grpc.ClientConnInterface, error) {
+	endpoints := strings.Split(strings.ReplaceAll(endpoint, " ", ""), ",")
+	if len(endpoints) == 1 {
+		return grpc.DialContext(ctx, endpoint, dialOpts...)
+	}
+	conns := make(map[string]*grpc.ClientConn, len(endpoints))
+	for _, e := range endpoints {
+		conn, err := grpc.DialContext(ctx, e, dialOpts...)
+		if err != nil {
+			for _, c := range conns {
+				if closeErr := c.Close(); closeErr != nil {
+					log.WithError(closeErr).Error("Could not close gRPC connection")
+				}
+			}
+			return nil, errors.Wrapf(err, "could not dial beacon node %s", e)
+		}
+		conns[e] = conn
+	}
+	return newFailoverGrpcConn(conns, endpoints), nil
+}
+
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
 	if v.conn == nil {
@@ -254,6 +360,11 @@ func (v *ValidatorService) RemoteSignerConfig() *remoteweb3signer.SetupConfig {
 	return v.web3SignerConfig
 }
 
+// SignHooks returns the sign hooks run around every signature of the validator.
+func (v *ValidatorService) SignHooks() []SignHook {
+	return v.signHooks
+}
+
 // ProposerSettings returns a deep copy of the underlying proposer settings in the validator
 func (v *ValidatorService) ProposerSettings() *proposer.Settings {
 	settings := v.validator.ProposerSettings()
@@ -335,6 +446,22 @@ func (v *ValidatorService) Graffiti(ctx context.Context, pubKey [fieldparams.BLS
 	return v.validator.Graffiti(ctx, pubKey)
 }
 
+// DoppelGangerStatus returns the doppelganger protection status of the key.
+func (v *ValidatorService) DoppelGangerStatus(pubKey [fieldparams.BLSPubkeyLength]byte) (iface.DoppelGangerStatus, error) {
+	if v.validator == nil {
+		return "", errors.New("validator is unavailable")
+	}
+	return v.validator.DoppelGangerStatus(pubKey), nil
+}
+
+// DutyTimeline returns the recorded duties of the given keys, or of every key if none is given, between the given slots included.
+func (v *ValidatorService) DutyTimeline(pubKeys [][fieldparams.BLSPubkeyLength]byte, fromSlot, toSlot primitives.Slot) ([]*iface.DutyRecord, error) {
+	if v.validator == nil {
+		return nil, errors.New("validator is unavailable")
+	}
+	return v.validator.DutyTimeline(pubKeys, fromSlot, toSlot), nil
+}
+
 func (v *ValidatorService) SetGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
 	if v.validator == nil {
 		return errors.New("validator is unavailable")
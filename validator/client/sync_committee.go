@@ -32,9 +32,13 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot primiti
 
 	v.waitOneThirdOrValidBlock(ctx, slot)
 
+	ctx, record := v.startDuty(ctx, pubKey, slot, iface.RoleSyncCommittee)
+	defer record.finish()
+
 	res, err := v.validatorClient.SyncMessageBlockRoot(ctx, &emptypb.Empty{})
 	if err != nil {
 		log.WithError(err).Error("Could not request sync message block root to sign")
+		record.fail("block_root", err)
 		tracing.AnnotateError(span, err)
 		return
 	}
@@ -42,22 +46,25 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot primiti
 	duty, err := v.duty(pubKey)
 	if err != nil {
 		log.WithError(err).Error("Could not fetch validator assignment")
+		record.fail("duty", err)
 		return
 	}
 
 	d, err := v.domainData(ctx, slots.ToEpoch(slot), params.BeaconConfig().DomainSyncCommittee[:])
 	if err != nil {
 		log.WithError(err).Error("Could not get sync committee domain data")
+		record.fail("signing_root", err)
 		return
 	}
 	sszRoot := primitives.SSZBytes(res.Root)
 	r, err := signing.ComputeSigningRoot(&sszRoot, d.SignatureDomain)
 	if err != nil {
 		log.WithError(err).Error("Could not get sync committee message signing root")
+		record.fail("signing_root", err)
 		return
 	}
 
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     r[:],
 		SignatureDomain: d.SignatureDomain,
@@ -68,6 +75,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot primiti
 	})
 	if err != nil {
 		log.WithError(err).Error("Could not sign sync committee message")
+		record.fail("sign", err)
 		return
 	}
 
@@ -77,11 +85,14 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot primiti
 		ValidatorIndex: duty.ValidatorIndex,
 		Signature:      sig.Marshal(),
 	}
-	if _, err := v.validatorClient.SubmitSyncMessage(ctx, msg); err != nil {
+	if _, err := v.validatorClient.SubmitSyncMessage(withBroadcastDuty(ctx, broadcastDutySyncMessage), msg); err != nil {
 		log.WithError(err).Error("Could not submit sync committee message")
+		record.fail("submit", err)
 		return
 	}
 
+	record.submitted()
+
 	msgSlot := msg.Slot
 	slotTime := time.Unix(int64(v.genesisTime+uint64(msgSlot)*params.BeaconConfig().SecondsPerSlot), 0)
 	log.WithFields(logrus.Fields{
@@ -127,6 +138,11 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot p
 
 	v.waitToSlotTwoThirds(ctx, slot)
 
+	// The duty is only recorded once the key is selected as aggregator in one of its subcommittees.
+	var record *dutyRecord
+	defer func() {
+		record.finish()
+	}()
 	for i, comIdx := range indexRes.Indices {
 		isAggregator, err := altair.IsSyncCommitteeAggregator(selectionProofs[i])
 		if err != nil {
@@ -136,6 +152,9 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot p
 		if !isAggregator {
 			continue
 		}
+		if record == nil {
+			ctx, record = v.startDuty(ctx, pubKey, slot, iface.RoleSyncCommitteeAggregator)
+		}
 		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
 		subnet := uint64(comIdx) / subCommitteeSize
 		contribution, err := v.validatorClient.SyncCommitteeContribution(ctx, &ethpb.SyncCommitteeContributionRequest{
@@ -145,6 +164,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot p
 		})
 		if err != nil {
 			log.WithError(err).Error("Could not get sync committee contribution")
+			record.fail("contribution_request", err)
 			return
 		}
 		if contribution.AggregationBits.Count() == 0 {
@@ -164,17 +184,21 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot p
 		sig, err := v.signContributionAndProof(ctx, pubKey, contributionAndProof, slot)
 		if err != nil {
 			log.WithError(err).Error("Could not sign contribution and proof")
+			record.fail("sign", err)
 			return
 		}
 
-		if _, err := v.validatorClient.SubmitSignedContributionAndProof(ctx, &ethpb.SignedContributionAndProof{
+		if _, err := v.validatorClient.SubmitSignedContributionAndProof(withBroadcastDuty(ctx, broadcastDutySyncContribution), &ethpb.SignedContributionAndProof{
 			Message:   contributionAndProof,
 			Signature: sig,
 		}); err != nil {
 			log.WithError(err).Error("Could not submit signed contribution and proof")
+			record.fail("submit", err)
 			return
 		}
 
+		record.submitted()
+
 		contributionSlot := contributionAndProof.Contribution.Slot
 		slotTime := time.Unix(int64(v.genesisTime+uint64(contributionSlot)*params.BeaconConfig().SecondsPerSlot), 0)
 		log.WithFields(logrus.Fields{
@@ -248,7 +272,7 @@ func (v *validator) signSyncSelectionData(ctx context.Context, pubKey [fieldpara
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -274,7 +298,7 @@ func (v *validator) signContributionAndProof(ctx context.Context, pubKey [fieldp
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: d.SignatureDomain,
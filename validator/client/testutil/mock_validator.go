@@ -233,6 +233,21 @@ func (*FakeValidator) CheckDoppelGanger(_ context.Context) error {
 	return nil
 }
 
+// CheckPendingDoppelGangers for mocking
+func (*FakeValidator) CheckPendingDoppelGangers(_ context.Context, _ primitives.Slot) error {
+	return nil
+}
+
+// DoppelGangerStatus for mocking
+func (*FakeValidator) DoppelGangerStatus(_ [fieldparams.BLSPubkeyLength]byte) iface.DoppelGangerStatus {
+	return iface.DoppelGangerDisabled
+}
+
+// DutyTimeline for mocking
+func (*FakeValidator) DutyTimeline(_ [][fieldparams.BLSPubkeyLength]byte, _, _ primitives.Slot) []*iface.DutyRecord {
+	return []*iface.DutyRecord{}
+}
+
 // HandleKeyReload for mocking
 func (fv *FakeValidator) HandleKeyReload(_ context.Context, newKeys [][fieldparams.BLSPubkeyLength]byte) (anyActive bool, err error) {
 	fv.HandleKeyReloadCalled = true
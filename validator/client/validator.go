@@ -86,6 +86,7 @@ type validator struct {
 	graffitiOrderedIndex               uint64
 	beaconNodeHosts                    []string
 	currentHostIndex                   uint64
+	nodeScorer                         *beaconNodeScorer
 	validatorClient                    iface.ValidatorClient
 	chainClient                        iface.ChainClient
 	nodeClient                         iface.NodeClient
@@ -108,6 +109,9 @@ type validator struct {
 	emitAccountMetrics                 bool
 	useWeb                             bool
 	distributed                        bool
+	dutyOffset                         time.Duration
+	signHooks                          []SignHook
+	dutyTimeline                       *dutyTimeline
 	domainDataLock                     sync.RWMutex
 	attLogsLock                        sync.Mutex
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
@@ -116,6 +120,8 @@ type validator struct {
 	blacklistedPubkeysLock             sync.RWMutex
 	attSelectionLock                   sync.Mutex
 	dutiesLock                         sync.RWMutex
+	doppelGangerKeys                   map[[fieldparams.BLSPubkeyLength]byte]*doppelGangerKey
+	doppelGangerLock                   sync.RWMutex
 }
 
 type validatorStatus struct {
@@ -428,14 +434,15 @@ func (v *validator) NextSlot() <-chan primitives.Slot {
 	return v.ticker.C()
 }
 
-// SlotDeadline is the start time of the next slot.
+// SlotDeadline is the start time of the next slot, delayed by the duty offset.
 func (v *validator) SlotDeadline(slot primitives.Slot) time.Time {
 	secs := time.Duration((slot + 1).Mul(params.BeaconConfig().SecondsPerSlot))
-	return time.Unix(int64(v.genesisTime), 0 /*ns*/).Add(secs * time.Second)
+	return time.Unix(int64(v.genesisTime), 0 /*ns*/).Add(secs*time.Second + v.dutyOffset)
 }
 
 // CheckDoppelGanger checks if the current actively provided keys have
-// any duplicates active in the network.
+// any duplicates active in the network. The keys which are not tracked yet
+// must then wait for the doppelganger window before performing duties.
 func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelganger")
 	defer span.End()
@@ -452,12 +459,26 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	if len(pubkeys) == 0 {
 		return nil
 	}
+	epoch := v.currentEpoch()
+	v.trackDoppelGangerKeys(pubkeys, epoch, false /* untrackMissing */)
+	resp, err := v.checkDoppelGanger(ctx, pubkeys, epoch)
+	if err != nil {
+		return err
+	}
+	return buildDuplicateError(resp.Responses)
+}
+
+// checkDoppelGanger asks the beacon node whether the given keys are live in the network,
+// and updates their doppelganger status.
+func (v *validator) checkDoppelGanger(
+	ctx context.Context, pubkeys [][fieldparams.BLSPubkeyLength]byte, epoch primitives.Epoch,
+) (*ethpb.DoppelGangerResponse, error) {
 	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
 	for _, pkey := range pubkeys {
 		copiedKey := pkey
 		attRec, err := v.db.AttestationHistoryForPubKey(ctx, copiedKey)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if len(attRec) == 0 {
 			// If no history exists we simply send in a zero
@@ -472,7 +493,7 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 		}
 		r := retrieveLatestRecord(attRec)
 		if copiedKey != r.PubKey {
-			return errors.New("attestation record mismatched public key")
+			return nil, errors.New("attestation record mismatched public key")
 		}
 		req.ValidatorRequests = append(req.ValidatorRequests,
 			&ethpb.DoppelGangerRequest_ValidatorRequest{
@@ -483,14 +504,15 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	}
 	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// If nothing is returned by the beacon node, we return an
 	// error as it is unsafe for us to proceed.
 	if resp == nil || resp.Responses == nil || len(resp.Responses) == 0 {
-		return errors.New("beacon node returned 0 responses for doppelganger check")
+		return nil, errors.New("beacon node returned 0 responses for doppelganger check")
 	}
-	return buildDuplicateError(resp.Responses)
+	v.updateDoppelGangerStatuses(req.ValidatorRequests, resp.Responses, epoch)
+	return resp, nil
 }
 
 func buildDuplicateError(response []*ethpb.DoppelGangerResponse_ValidatorResponse) error {
@@ -588,6 +610,7 @@ func (v *validator) UpdateDuties(ctx context.Context, slot primitives.Slot) erro
 	v.duties = resp
 	v.logDuties(slot, v.duties.CurrentEpochDuties, v.duties.NextEpochDuties)
 	v.dutiesLock.Unlock()
+	v.dutyTimeline.assign(slot, resp, time.Now())
 
 	allExitedCounter := 0
 	for i := range resp.CurrentEpochDuties {
@@ -723,6 +746,9 @@ func (v *validator) RolesAt(ctx context.Context, slot primitives.Slot) (map[[fie
 		if duty == nil {
 			continue
 		}
+		if !v.performsDuties(bytesutil.ToBytes48(duty.PublicKey)) {
+			continue
+		}
 		if len(duty.ProposerSlots) > 0 {
 			for _, proposerSlot := range duty.ProposerSlots {
 				if proposerSlot != 0 && proposerSlot == slot {
@@ -1145,7 +1171,7 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 		return err
 	}
 
-	signedRegReqs := v.buildSignedRegReqs(ctx, filteredKeys, km.Sign)
+	signedRegReqs := v.buildSignedRegReqs(ctx, filteredKeys, v.withSignHooks(km.Sign))
 	if err := SubmitValidatorRegistrations(ctx, v.validatorClient, signedRegReqs, v.validatorsRegBatchSize); err != nil {
 		return errors.Wrap(ErrBuilderValidatorRegistration, err.Error())
 	}
@@ -1196,6 +1222,11 @@ func (v *validator) Host() string {
 }
 
 func (v *validator) ChangeHost() {
+	if v.nodeScorer != nil {
+		// The scorer already routes requests to the healthiest beacon node, so every configured node is failing.
+		log.WithField("primary", v.nodeScorer.Primary()).Warn("None of the configured beacon nodes is responding")
+		return
+	}
 	if len(v.beaconNodeHosts) == 1 {
 		log.Infof("Beacon node at %s is not responding, no backup node configured", v.Host())
 		return
@@ -8,19 +8,19 @@ import (
 
 // Use an interface with a private dummy function to force all other packages to call NewNodeConnection
 type NodeConnection interface {
-	GetGrpcClientConn() *grpc.ClientConn
+	GetGrpcClientConn() grpc.ClientConnInterface
 	GetBeaconApiUrl() string
 	GetBeaconApiTimeout() time.Duration
 	dummy()
 }
 
 type nodeConnection struct {
-	grpcClientConn   *grpc.ClientConn
+	grpcClientConn   grpc.ClientConnInterface
 	beaconApiUrl     string
 	beaconApiTimeout time.Duration
 }
 
-func (c *nodeConnection) GetGrpcClientConn() *grpc.ClientConn {
+func (c *nodeConnection) GetGrpcClientConn() grpc.ClientConnInterface {
 	return c.grpcClientConn
 }
 
@@ -34,7 +34,7 @@ func (c *nodeConnection) GetBeaconApiTimeout() time.Duration {
 
 func (*nodeConnection) dummy() {}
 
-func NewNodeConnection(grpcConn *grpc.ClientConn, beaconApiUrl string, beaconApiTimeout time.Duration) NodeConnection {
+func NewNodeConnection(grpcConn grpc.ClientConnInterface, beaconApiUrl string, beaconApiTimeout time.Duration) NodeConnection {
 	conn := &nodeConnection{}
 	conn.grpcClientConn = grpcConn
 	conn.beaconApiUrl = beaconApiUrl
@@ -24,8 +24,14 @@ import (
 
 const (
 	ethApiNamespace = "/api/v1/eth2/sign/"
+	// PublicKeysPath is the path of the web3signer api listing the public keys of the signer.
+	PublicKeysPath = "/api/v1/eth2/publicKeys"
 )
 
+// ErrUnavailable is returned when the web3signer could not be reached or could not process the request,
+// in which case the request may be sent to another replica of the signer.
+var ErrUnavailable = errors.New("web3signer is unavailable")
+
 type SignRequestJson []byte
 
 // SignatureResponse is the struct representing the signing request response in json format
@@ -133,6 +139,20 @@ func (client *ApiClient) GetServerStatus(ctx context.Context) (string, error) {
 	return status, nil
 }
 
+// Upcheck returns an error if the web3signer upcheck api does not report the signer as up.
+func (client *ApiClient) Upcheck(ctx context.Context) error {
+	const requestPath = "/upcheck"
+	resp, err := client.doRequest(ctx, http.MethodGet, client.BaseURL.String()+requestPath, nil /* no body needed on get request */)
+	if err != nil {
+		return err
+	}
+	closeBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrapf(ErrUnavailable, "upcheck returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
 // doRequest is a utility method for requests.
 func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath string, body io.Reader) (*http.Response, error) {
 	var requestDump []byte
@@ -154,13 +174,19 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 	duration := time.Since(start)
 	if err != nil {
 		signRequestDurationSeconds.WithLabelValues(req.Method, "error").Observe(duration.Seconds())
-		err = errors.Wrap(err, "failed to execute json request")
+		err = errors.Wrapf(ErrUnavailable, "failed to execute json request: %v", err)
 		tracing.AnnotateError(span, err)
 		return resp, err
 	} else {
 		signRequestDurationSeconds.WithLabelValues(req.Method, strconv.Itoa(resp.StatusCode)).Observe(duration.Seconds())
 	}
 	if resp.StatusCode != http.StatusOK {
+		// The request body was consumed when sending the request.
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
 		requestDump, err = httputil.DumpRequestOut(req, true)
 		if err != nil {
 			return nil, err
@@ -175,8 +201,9 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 			"response": string(responseDump),
 		}).Error("web3signer request failed")
 	}
-	if resp.StatusCode == http.StatusInternalServerError {
-		err = fmt.Errorf("internal Web3Signer server error, Signing Request URL: %v Status: %v", fullPath, resp.StatusCode)
+	if resp.StatusCode == http.StatusInternalServerError || resp.StatusCode == http.StatusServiceUnavailable {
+		closeBody(resp.Body)
+		err = errors.Wrapf(ErrUnavailable, "internal Web3Signer server error, Signing Request URL: %v Status: %v", fullPath, resp.StatusCode)
 		tracing.AnnotateError(span, err)
 		return nil, err
 	} else if resp.StatusCode == http.StatusBadRequest {
@@ -14,4 +14,11 @@ var (
 		},
 		[]string{"method", "status_code"},
 	)
+	signerUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "remote_web3signer_internal_client_signer_up",
+			Help: "Whether the web3signer was available when last used or checked, 1 if available, 0 otherwise",
+		},
+		[]string{"url"},
+	)
 )
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/sirupsen/logrus"
+)
+
+// SignerPool sends the requests to the first healthy web3signer of a list of replicas of the same signer,
+// and falls back to the next replicas when a signer is unavailable.
+// Requests rejected by a signer, such as the ones refused by its slashing protection, are never sent to
+// another replica.
+type SignerPool struct {
+	clients []*ApiClient
+	healthy []bool
+	lock    sync.RWMutex
+}
+
+// NewSignerPool instantiates a pool of the web3signers at the given endpoints, in order of preference.
+func NewSignerPool(endpoints []string) (*SignerPool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no web3signer endpoint provided")
+	}
+	pool := &SignerPool{
+		clients: make([]*ApiClient, len(endpoints)),
+		healthy: make([]bool, len(endpoints)),
+	}
+	for i, endpoint := range endpoints {
+		client, err := NewApiClient(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create client for web3signer %s", endpoint)
+		}
+		pool.clients[i] = client
+		pool.healthy[i] = true
+		signerUp.WithLabelValues(client.BaseURL.String()).Set(1)
+	}
+	return pool, nil
+}
+
+// Sign signs the request with the first available web3signer.
+func (p *SignerPool) Sign(ctx context.Context, pubKey string, request SignRequestJson) (bls.Signature, error) {
+	var sig bls.Signature
+	err := p.do(func(client *ApiClient) error {
+		var err error
+		sig, err = client.Sign(ctx, pubKey, request)
+		return err
+	})
+	return sig, err
+}
+
+// GetPublicKeys returns the public keys listed at the url. A url starting with / is a path of the web3signer api,
+// such as PublicKeysPath, which is requested from the first available web3signer.
+func (p *SignerPool) GetPublicKeys(ctx context.Context, url string) ([]string, error) {
+	if !strings.HasPrefix(url, "/") {
+		return p.clients[0].GetPublicKeys(ctx, url)
+	}
+	var keys []string
+	err := p.do(func(client *ApiClient) error {
+		var err error
+		keys, err = client.GetPublicKeys(ctx, client.BaseURL.String()+url)
+		return err
+	})
+	return keys, err
+}
+
+// do runs the request against the healthy web3signers in order, then against the unhealthy ones as their health
+// may have changed since the last check, until a signer is available.
+func (p *SignerPool) do(request func(client *ApiClient) error) error {
+	p.lock.RLock()
+	order := make([]int, 0, len(p.clients))
+	for i := range p.clients {
+		if p.healthy[i] {
+			order = append(order, i)
+		}
+	}
+	for i := range p.clients {
+		if !p.healthy[i] {
+			order = append(order, i)
+		}
+	}
+	p.lock.RUnlock()
+
+	var err error
+	for n, i := range order {
+		err = request(p.clients[i])
+		if !errors.Is(err, ErrUnavailable) {
+			if err == nil {
+				p.setHealthy(i, true)
+			}
+			return err
+		}
+		p.setHealthy(i, false)
+		if n < len(order)-1 {
+			log.WithError(err).WithFields(logrus.Fields{
+				"url":      p.clients[i].BaseURL.String(),
+				"fallback": p.clients[order[n+1]].BaseURL.String(),
+			}).Warn("Web3signer unavailable, falling back to next signer")
+		}
+	}
+	return err
+}
+
+// CheckHealth updates the health of every web3signer according to its upcheck api.
+func (p *SignerPool) CheckHealth(ctx context.Context) {
+	for i, client := range p.clients {
+		err := client.Upcheck(ctx)
+		if err != nil {
+			log.WithError(err).WithField("url", client.BaseURL.String()).Debug("Web3signer upcheck failed")
+		}
+		p.setHealthy(i, err == nil)
+	}
+}
+
+// MonitorHealth checks the health of the web3signers at every interval until the context is canceled.
+func (p *SignerPool) MonitorHealth(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.CheckHealth(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Healthy returns whether each web3signer, in order of preference, was available when last used or checked.
+func (p *SignerPool) Healthy() []bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return append([]bool{}, p.healthy...)
+}
+
+func (p *SignerPool) setHealthy(i int, healthy bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.healthy[i] == healthy {
+		return
+	}
+	p.healthy[i] = healthy
+	url := p.clients[i].BaseURL.String()
+	if healthy {
+		signerUp.WithLabelValues(url).Set(1)
+		log.WithField("url", url).Info("Web3signer is available again")
+	} else {
+		signerUp.WithLabelValues(url).Set(0)
+		log.WithField("url", url).Warn("Web3signer is unavailable")
+	}
+}
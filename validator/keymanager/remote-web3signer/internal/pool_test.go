@@ -0,0 +1,141 @@
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/internal"
+)
+
+const (
+	testSignature = "0xb3baa751d0a9132cfe93e4e3d5ff9075111100e3789dca219ade5a24d27e19d16b3353149da1833e9b691bb38634e8dc04469be7032132906c927d7e1a49b414730612877bc6b2810c8f202daf793d1ab0d6b5cb21d52f9e52e883859887a5d9"
+	testPubkey    = "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+)
+
+// mockSigner is a web3signer replica answering sign requests with the given status.
+type mockSigner struct {
+	signStatus atomic.Int64
+	up         atomic.Bool
+	signs      atomic.Int64
+}
+
+func newMockSigner(t *testing.T, signStatus int) (*mockSigner, *httptest.Server) {
+	s := &mockSigner{}
+	s.signStatus.Store(int64(signStatus))
+	s.up.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/upcheck":
+			if !s.up.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, err := w.Write([]byte("OK"))
+			require.NoError(t, err)
+		case r.URL.Path == internal.PublicKeysPath:
+			w.Header().Set("Content-Type", "application/json")
+			require.NoError(t, json.NewEncoder(w).Encode([]string{testPubkey}))
+		case strings.HasPrefix(r.URL.Path, "/api/v1/eth2/sign/"):
+			s.signs.Add(1)
+			status := int(s.signStatus.Load())
+			w.WriteHeader(status)
+			if status == http.StatusOK {
+				_, err := w.Write([]byte(testSignature))
+				require.NoError(t, err)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func TestNewSignerPool(t *testing.T) {
+	_, err := internal.NewSignerPool(nil)
+	require.ErrorContains(t, "no web3signer endpoint provided", err)
+	_, err = internal.NewSignerPool([]string{"http://localhost:9000", "localhost:9001"})
+	require.ErrorContains(t, "could not create client for web3signer localhost:9001", err)
+	pool, err := internal.NewSignerPool([]string{"http://localhost:9000", "http://localhost:9001"})
+	require.NoError(t, err)
+	require.DeepEqual(t, []bool{true, true}, pool.Healthy())
+}
+
+func TestSignerPool_Sign_Failover(t *testing.T) {
+	primary, primarySrv := newMockSigner(t, http.StatusInternalServerError)
+	fallback, fallbackSrv := newMockSigner(t, http.StatusOK)
+	pool, err := internal.NewSignerPool([]string{primarySrv.URL, fallbackSrv.URL})
+	require.NoError(t, err)
+
+	sig, err := pool.Sign(context.Background(), testPubkey, []byte("{}"))
+	require.NoError(t, err)
+	require.NotNil(t, sig)
+	require.Equal(t, int64(1), primary.signs.Load())
+	require.Equal(t, int64(1), fallback.signs.Load())
+	require.DeepEqual(t, []bool{false, true}, pool.Healthy())
+
+	// The unhealthy signer is only used once the healthy ones are unavailable.
+	_, err = pool.Sign(context.Background(), testPubkey, []byte("{}"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), primary.signs.Load())
+	require.Equal(t, int64(2), fallback.signs.Load())
+
+	// The primary signer is used again once it is back up.
+	primary.signStatus.Store(http.StatusOK)
+	pool.CheckHealth(context.Background())
+	require.DeepEqual(t, []bool{true, true}, pool.Healthy())
+	_, err = pool.Sign(context.Background(), testPubkey, []byte("{}"))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), primary.signs.Load())
+	require.Equal(t, int64(2), fallback.signs.Load())
+}
+
+func TestSignerPool_Sign_NoFailoverOnRejection(t *testing.T) {
+	primary, primarySrv := newMockSigner(t, http.StatusPreconditionFailed)
+	fallback, fallbackSrv := newMockSigner(t, http.StatusOK)
+	pool, err := internal.NewSignerPool([]string{primarySrv.URL, fallbackSrv.URL})
+	require.NoError(t, err)
+
+	_, err = pool.Sign(context.Background(), testPubkey, []byte("{}"))
+	require.ErrorContains(t, "slashing protection", err)
+	require.Equal(t, int64(1), primary.signs.Load())
+	require.Equal(t, int64(0), fallback.signs.Load())
+	require.DeepEqual(t, []bool{true, true}, pool.Healthy())
+}
+
+func TestSignerPool_Sign_AllUnavailable(t *testing.T) {
+	_, primarySrv := newMockSigner(t, http.StatusServiceUnavailable)
+	_, fallbackSrv := newMockSigner(t, http.StatusInternalServerError)
+	pool, err := internal.NewSignerPool([]string{primarySrv.URL, fallbackSrv.URL})
+	require.NoError(t, err)
+
+	_, err = pool.Sign(context.Background(), testPubkey, []byte("{}"))
+	require.ErrorIs(t, err, internal.ErrUnavailable)
+	require.DeepEqual(t, []bool{false, false}, pool.Healthy())
+}
+
+func TestSignerPool_CheckHealth(t *testing.T) {
+	primary, primarySrv := newMockSigner(t, http.StatusOK)
+	_, fallbackSrv := newMockSigner(t, http.StatusOK)
+	pool, err := internal.NewSignerPool([]string{primarySrv.URL, fallbackSrv.URL})
+	require.NoError(t, err)
+
+	primary.up.Store(false)
+	pool.CheckHealth(context.Background())
+	require.DeepEqual(t, []bool{false, true}, pool.Healthy())
+
+	// Public keys are discovered from the healthy signer.
+	keys, err := pool.GetPublicKeys(context.Background(), internal.PublicKeysPath)
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{testPubkey}, keys)
+
+	primary.up.Store(true)
+	pool.CheckHealth(context.Background())
+	require.DeepEqual(t, []bool{true, true}, pool.Healthy())
+}
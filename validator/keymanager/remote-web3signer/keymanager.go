@@ -54,6 +54,19 @@ type SetupConfig struct {
 	// a static list of public keys to be passed by the user to determine what accounts should sign.
 	// This will provide a layer of safety against slashing if the web3signer is shared across validators.
 	ProvidedPublicKeys []string
+
+	// FallbackEndpoints are the urls of replicas of the web3signer at BaseEndpoint, in order of preference,
+	// used when the signers before them are unavailable.
+	FallbackEndpoints []string
+
+	// KeyDiscoveryInterval is the interval at which the public keys are fetched again from PublicKeysURL,
+	// or from the web3signer if it is not set, adding and removing keys as the signer does. Zero disables discovery.
+	// caution: like PublicKeysURL, this option is susceptible to slashing if the web3signer's validator keys are shared across validators
+	KeyDiscoveryInterval time.Duration
+
+	// HealthCheckInterval is the interval at which the upcheck api of each web3signer is polled. Zero disables
+	// health checks, in which case the health of a signer is only updated when it is used.
+	HealthCheckInterval time.Duration
 }
 
 // Keymanager defines the web3signer keymanager.
@@ -66,6 +79,7 @@ type Keymanager struct {
 	validator             *validator.Validate
 	retriesRemaining      int
 	keyFilePath           string
+	watermarks            *watermarks
 	lock                  sync.RWMutex
 }
 
@@ -76,18 +90,22 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if cfg.BaseEndpoint == "" || !bytesutil.IsValidRoot(cfg.GenesisValidatorsRoot) {
 		return nil, fmt.Errorf("invalid setup config, one or more configs are empty: BaseEndpoint: %v, GenesisValidatorsRoot: %#x", cfg.BaseEndpoint, cfg.GenesisValidatorsRoot)
 	}
-	client, err := internal.NewApiClient(cfg.BaseEndpoint)
+	if cfg.KeyDiscoveryInterval > 0 && (len(cfg.ProvidedPublicKeys) != 0 || cfg.KeyFilePath != "") {
+		return nil, errors.New("key discovery cannot be used with provided public keys or a key file")
+	}
+	pool, err := internal.NewSignerPool(append([]string{cfg.BaseEndpoint}, cfg.FallbackEndpoints...))
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create apiClient")
 	}
 
 	km := &Keymanager{
-		client:                internal.HttpSignerClient(client),
+		client:                internal.HttpSignerClient(pool),
 		genesisValidatorsRoot: cfg.GenesisValidatorsRoot,
 		accountsChangedFeed:   new(event.Feed),
 		validator:             validator.New(),
 		retriesRemaining:      maxRetries,
 		keyFilePath:           cfg.KeyFilePath,
+		watermarks:            newWatermarks(),
 	}
 
 	keyFileExists := false
@@ -101,32 +119,26 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 		}
 	}
 
+	publicKeysURL := cfg.PublicKeysURL
+	if publicKeysURL == "" && cfg.KeyDiscoveryInterval > 0 {
+		publicKeysURL = internal.PublicKeysPath
+	}
 	var ppk []string
 	// load key values
-	if cfg.PublicKeysURL != "" {
-		providedPublicKeys, err := km.client.GetPublicKeys(ctx, cfg.PublicKeysURL)
+	if publicKeysURL != "" {
+		providedPublicKeys, err := km.client.GetPublicKeys(ctx, publicKeysURL)
 		if err != nil {
 			erroredResponsesTotal.Inc()
-			return nil, errors.Wrapf(err, "could not get public keys from remote server URL %v", cfg.PublicKeysURL)
+			return nil, errors.Wrapf(err, "could not get public keys from remote server URL %v", publicKeysURL)
 		}
 		ppk = providedPublicKeys
 	} else if len(cfg.ProvidedPublicKeys) != 0 {
 		ppk = cfg.ProvidedPublicKeys
 	}
 
-	// use a map to remove duplicates
-	flagLoadedKeys := make(map[string][48]byte)
-
-	// Populate the map with existing keys
-	for _, key := range ppk {
-		decodedKey, err := hexutil.Decode(key)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode public key %s", key)
-		}
-		if len(decodedKey) != fieldparams.BLSPubkeyLength {
-			return nil, fmt.Errorf("public key %s has invalid length (expected %d, got %d)", decodedKey, fieldparams.BLSPubkeyLength, len(decodedKey))
-		}
-		flagLoadedKeys[key] = bytesutil.ToBytes48(decodedKey)
+	flagLoadedKeys, err := decodePublicKeys(ppk)
+	if err != nil {
+		return nil, err
 	}
 	km.flagLoadedKeysMap = flagLoadedKeys
 
@@ -160,9 +172,90 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 		km.lock.Unlock()
 	}
 
+	if cfg.KeyDiscoveryInterval > 0 {
+		go km.discoverPublicKeys(ctx, publicKeysURL, cfg.KeyDiscoveryInterval)
+	}
+	if cfg.HealthCheckInterval > 0 {
+		go pool.MonitorHealth(ctx, cfg.HealthCheckInterval)
+	}
+
 	return km, nil
 }
 
+// decodePublicKeys decodes hex encoded public keys, removing duplicates.
+func decodePublicKeys(keys []string) (map[string][48]byte, error) {
+	decodedKeys := make(map[string][48]byte, len(keys))
+	for _, key := range keys {
+		decodedKey, err := hexutil.Decode(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode public key %s", key)
+		}
+		if len(decodedKey) != fieldparams.BLSPubkeyLength {
+			return nil, fmt.Errorf("public key %s has invalid length (expected %d, got %d)", decodedKey, fieldparams.BLSPubkeyLength, len(decodedKey))
+		}
+		decodedKeys[key] = bytesutil.ToBytes48(decodedKey)
+	}
+	return decodedKeys, nil
+}
+
+// discoverPublicKeys fetches the public keys from the url at every interval until the context is canceled.
+func (km *Keymanager) discoverPublicKeys(ctx context.Context, url string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := km.refreshDiscoveredPublicKeys(ctx, url); err != nil {
+				erroredResponsesTotal.Inc()
+				log.WithError(err).WithField("url", url).Warn("Could not discover remote signer public keys")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshDiscoveredPublicKeys fetches the public keys from the url, and updates the keys if they changed.
+func (km *Keymanager) refreshDiscoveredPublicKeys(ctx context.Context, url string) error {
+	keys, err := km.client.GetPublicKeys(ctx, url)
+	if err != nil {
+		return errors.Wrap(err, "could not get public keys")
+	}
+	discoveredKeys, err := decodePublicKeys(keys)
+	if err != nil {
+		return err
+	}
+	km.lock.RLock()
+	currentKeys := make(map[[48]byte]bool, len(km.providedPublicKeys))
+	for _, key := range km.providedPublicKeys {
+		currentKeys[key] = true
+	}
+	km.lock.RUnlock()
+
+	discoveredSet := make(map[[48]byte]bool, len(discoveredKeys))
+	added, removed := 0, 0
+	for _, key := range discoveredKeys {
+		if !discoveredSet[key] && !currentKeys[key] {
+			added++
+		}
+		discoveredSet[key] = true
+	}
+	for key := range currentKeys {
+		if !discoveredSet[key] {
+			removed++
+		}
+	}
+	if added == 0 && removed == 0 {
+		return nil
+	}
+	log.WithFields(logrus.Fields{
+		"added":   added,
+		"removed": removed,
+	}).Info("Discovered remote signer public keys changes")
+	km.updatePublicKeys(maps.Keys(discoveredSet))
+	return nil
+}
+
 func (km *Keymanager) refreshRemoteKeysFromFileChangesWithRetry(ctx context.Context, retryDelay time.Duration) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
@@ -381,11 +474,16 @@ func (km *Keymanager) Sign(ctx context.Context, request *validatorpb.SignRequest
 		erroredResponsesTotal.Inc()
 		return nil, err
 	}
+	if err := km.watermarks.check(request); err != nil {
+		erroredResponsesTotal.Inc()
+		return nil, errors.Wrap(err, "refusing to sign the request")
+	}
 	signature, err := km.client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
 	if err != nil {
 		erroredResponsesTotal.Inc()
 		return nil, errors.Wrap(err, "failed to sign the request")
 	}
+	km.watermarks.update(request)
 	log.WithField("publicKey", request.PublicKey).Debug("Successfully signed the request")
 	signRequestsTotal.Inc()
 	return signature, nil
@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -721,3 +722,61 @@ func TestKeymanager_DeletePublicKeys_WithFile(t *testing.T) {
 	require.Equal(t, len(keys), 1)
 	require.Equal(t, hexutil.Encode(keys[0][:]), publicKeys[1])
 }
+
+func TestKeymanager_KeyDiscovery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	key1 := "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+	key2 := "0x8000a9a6d3f5e22d783eefaadbcf0298146adb5d95b04db910a0d4e7976dd30bbd7f39ee0b4bc5542a1c3ef6d4d4e8b0"
+	var lock sync.Mutex
+	signerKeys := []string{key1}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/upcheck" {
+			_, err := w.Write([]byte("OK"))
+			require.NoError(t, err)
+			return
+		}
+		require.Equal(t, internal.PublicKeysPath, r.URL.Path)
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(signerKeys))
+	}))
+	defer srv.Close()
+	root, err := hexutil.Decode("0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69")
+	require.NoError(t, err)
+
+	_, err = NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: root,
+		ProvidedPublicKeys:    []string{key1},
+		KeyDiscoveryInterval:  time.Second,
+	})
+	require.ErrorContains(t, "key discovery cannot be used with provided public keys or a key file", err)
+
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: root,
+		KeyDiscoveryInterval:  10 * time.Millisecond,
+		HealthCheckInterval:   10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+	require.Equal(t, key1, hexutil.Encode(keys[0][:]))
+
+	keysChan := make(chan [][48]byte, 1)
+	sub := km.SubscribeAccountChanges(keysChan)
+	defer sub.Unsubscribe()
+	lock.Lock()
+	signerKeys = []string{key2}
+	lock.Unlock()
+	select {
+	case keys = <-keysChan:
+		require.Equal(t, 1, len(keys))
+		require.Equal(t, key2, hexutil.Encode(keys[0][:]))
+	case <-time.After(5 * time.Second):
+		t.Fatal("discovered keys were not updated")
+	}
+}
@@ -0,0 +1,105 @@
+package remote_web3signer
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+)
+
+// watermark is the latest block and attestation signed for a key.
+type watermark struct {
+	blockSlot       primitives.Slot
+	blockRoot       []byte
+	sourceEpoch     primitives.Epoch
+	targetEpoch     primitives.Epoch
+	attestationRoot []byte
+}
+
+// watermarks keeps the latest block and attestation signed for each key by any of the web3signers, so that
+// a request sent to a replica which did not see the latest signatures, after a failover, can never sign
+// a block or an attestation older than the ones already signed.
+type watermarks struct {
+	keys map[[fieldparams.BLSPubkeyLength]byte]*watermark
+	lock sync.Mutex
+}
+
+func newWatermarks() *watermarks {
+	return &watermarks{keys: make(map[[fieldparams.BLSPubkeyLength]byte]*watermark)}
+}
+
+// check returns an error if signing the request would go below the watermark of its key. A request signing
+// the same root as the latest signature is allowed.
+func (w *watermarks) check(request *validatorpb.SignRequest) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	mark, ok := w.keys[bytesutil.ToBytes48(request.PublicKey)]
+	if !ok {
+		return nil
+	}
+	if isBlockRequest(request) && mark.blockRoot != nil {
+		if request.SigningSlot < mark.blockSlot ||
+			(request.SigningSlot == mark.blockSlot && !bytes.Equal(request.SigningRoot, mark.blockRoot)) {
+			return fmt.Errorf("block at slot %d is below the signed block watermark at slot %d", request.SigningSlot, mark.blockSlot)
+		}
+	}
+	if data := request.GetAttestationData(); data != nil && mark.attestationRoot != nil {
+		source, target := data.Source.Epoch, data.Target.Epoch
+		if source < mark.sourceEpoch || target < mark.targetEpoch ||
+			(target == mark.targetEpoch && !bytes.Equal(request.SigningRoot, mark.attestationRoot)) {
+			return fmt.Errorf(
+				"attestation with source %d and target %d is below the signed attestation watermark with source %d and target %d",
+				source, target, mark.sourceEpoch, mark.targetEpoch,
+			)
+		}
+	}
+	return nil
+}
+
+// update raises the watermark of the key of a signed request.
+func (w *watermarks) update(request *validatorpb.SignRequest) {
+	data := request.GetAttestationData()
+	isBlock := isBlockRequest(request)
+	if data == nil && !isBlock {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	pubkey := bytesutil.ToBytes48(request.PublicKey)
+	mark, ok := w.keys[pubkey]
+	if !ok {
+		mark = &watermark{}
+		w.keys[pubkey] = mark
+	}
+	if isBlock {
+		mark.blockSlot = request.SigningSlot
+		mark.blockRoot = bytesutil.SafeCopyBytes(request.SigningRoot)
+	}
+	if data != nil {
+		mark.sourceEpoch = data.Source.Epoch
+		mark.targetEpoch = data.Target.Epoch
+		mark.attestationRoot = bytesutil.SafeCopyBytes(request.SigningRoot)
+	}
+}
+
+func isBlockRequest(request *validatorpb.SignRequest) bool {
+	switch request.Object.(type) {
+	case *validatorpb.SignRequest_Block,
+		*validatorpb.SignRequest_BlockAltair,
+		*validatorpb.SignRequest_BlockBellatrix,
+		*validatorpb.SignRequest_BlindedBlockBellatrix,
+		*validatorpb.SignRequest_BlockCapella,
+		*validatorpb.SignRequest_BlindedBlockCapella,
+		*validatorpb.SignRequest_BlockDeneb,
+		*validatorpb.SignRequest_BlindedBlockDeneb,
+		*validatorpb.SignRequest_BlockElectra,
+		*validatorpb.SignRequest_BlindedBlockElectra:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+package remote_web3signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/v1/mock"
+)
+
+func TestWatermarks_Block(t *testing.T) {
+	w := newWatermarks()
+	req := mock.GetMockSignRequest("BLOCK")
+	req.SigningSlot = 10
+	require.NoError(t, w.check(req))
+	w.update(req)
+
+	// Signing the same block again is allowed.
+	require.NoError(t, w.check(req))
+
+	other := mock.GetMockSignRequest("BLOCK_V2_BLINDED_DENEB")
+	other.SigningSlot = 10
+	other.SigningRoot = bytesutil.PadTo([]byte("other"), 32)
+	require.ErrorContains(t, "below the signed block watermark at slot 10", w.check(other))
+	other.SigningSlot = 9
+	require.ErrorContains(t, "below the signed block watermark at slot 10", w.check(other))
+	other.SigningSlot = 11
+	require.NoError(t, w.check(other))
+
+	// Other keys and requests are not affected.
+	other.SigningSlot = 9
+	other.PublicKey = bytesutil.PadTo([]byte("other"), 48)
+	require.NoError(t, w.check(other))
+	randao := mock.GetMockSignRequest("RANDAO_REVEAL")
+	require.NoError(t, w.check(randao))
+}
+
+func TestWatermarks_Attestation(t *testing.T) {
+	w := newWatermarks()
+	att := func(source, target primitives.Epoch, root string) *validatorpb.SignRequest {
+		req := mock.GetMockSignRequest("ATTESTATION")
+		data := req.GetAttestationData()
+		data.Source.Epoch = source
+		data.Target.Epoch = target
+		req.SigningRoot = bytesutil.PadTo([]byte(root), 32)
+		return req
+	}
+	signed := att(4, 5, "signed")
+	require.NoError(t, w.check(signed))
+	w.update(signed)
+	require.NoError(t, w.check(att(4, 5, "signed")))
+
+	require.ErrorContains(t, "below the signed attestation watermark with source 4 and target 5", w.check(att(4, 5, "other")))
+	require.ErrorContains(t, "below the signed attestation watermark", w.check(att(4, 4, "other")))
+	require.ErrorContains(t, "below the signed attestation watermark", w.check(att(3, 6, "other")))
+	require.NoError(t, w.check(att(4, 6, "other")))
+	require.NoError(t, w.check(att(5, 6, "other")))
+}
+
+func TestKeymanager_Sign_Watermarks(t *testing.T) {
+	km := &Keymanager{
+		client: &MockClient{
+			Signature: "0xb3baa751d0a9132cfe93e4e3d5ff9075111100e3789dca219ade5a24d27e19d16b3353149da1833e9b691bb38634e8dc04469be7032132906c927d7e1a49b414730612877bc6b2810c8f202daf793d1ab0d6b5cb21d52f9e52e883859887a5d9",
+		},
+		genesisValidatorsRoot: bytesutil.PadTo([]byte("root"), 32),
+		validator:             validator.New(),
+		watermarks:            newWatermarks(),
+	}
+	req := mock.GetMockSignRequest("BLOCK")
+	req.SigningSlot = 10
+	_, err := km.Sign(context.Background(), req)
+	require.NoError(t, err)
+
+	older := mock.GetMockSignRequest("BLOCK")
+	older.SigningSlot = 9
+	_, err = km.Sign(context.Background(), older)
+	require.ErrorContains(t, "refusing to sign the request", err)
+}
@@ -534,6 +534,11 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		LogValidatorPerformance: !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
 		EmitAccountMetrics:      !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
 		Distributed:             c.cliCtx.Bool(flags.EnableDistributed.Name),
+		DistributedDutyOffset:   c.cliCtx.Duration(flags.DistributedDutyOffsetFlag.Name),
+		DistributedSignHookURL:  c.cliCtx.String(flags.DistributedSignHookURLFlag.Name),
+		BroadcastSubmissions:    c.cliCtx.Bool(flags.BeaconNodeBroadcastFlag.Name),
+		BroadcastPolicy:         c.cliCtx.String(flags.BeaconNodeBroadcastPolicyFlag.Name),
+		DutyTimelinePath:        c.cliCtx.String(flags.DutyTimelineFileFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -576,6 +581,18 @@ func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 		if cliCtx.IsSet(flags.Web3SignerKeyFileFlag.Name) {
 			web3signerConfig.KeyFilePath = cliCtx.String(flags.Web3SignerKeyFileFlag.Name)
 		}
+		for _, fallbackStr := range cliCtx.StringSlice(flags.Web3SignerFallbackURLsFlag.Name) {
+			fallback, err := url.ParseRequestURI(fallbackStr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "web3signer fallback url %s is invalid", fallbackStr)
+			}
+			if fallback.Scheme == "" || fallback.Host == "" {
+				return nil, fmt.Errorf("web3signer fallback url must be in the format of http(s)://host:port url used: %v", fallbackStr)
+			}
+			web3signerConfig.FallbackEndpoints = append(web3signerConfig.FallbackEndpoints, fallback.String())
+		}
+		web3signerConfig.KeyDiscoveryInterval = cliCtx.Duration(flags.Web3SignerKeyDiscoveryIntervalFlag.Name)
+		web3signerConfig.HealthCheckInterval = cliCtx.Duration(flags.Web3SignerHealthCheckIntervalFlag.Name)
 	}
 	return web3signerConfig, nil
 }
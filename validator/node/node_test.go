@@ -213,6 +213,7 @@ func TestWeb3SignerConfig(t *testing.T) {
 		baseURL          string
 		publicKeysOrURLs []string
 		persistentFile   string
+		fallbackURLs     []string
 	}
 	tests := []struct {
 		name       string
@@ -280,6 +281,26 @@ func TestWeb3SignerConfig(t *testing.T) {
 				KeyFilePath:  "/remote/key/file.txt",
 			},
 		},
+		{
+			name: "happy path with fallback urls",
+			args: &args{
+				baseURL:      "http://localhost:8545",
+				fallbackURLs: []string{"http://replica1:8545", "http://replica2:8545"},
+			},
+			want: &remoteweb3signer.SetupConfig{
+				BaseEndpoint:      "http://localhost:8545",
+				FallbackEndpoints: []string{"http://replica1:8545", "http://replica2:8545"},
+			},
+		},
+		{
+			name: "Bad fallback URL",
+			args: &args{
+				baseURL:      "http://localhost:8545",
+				fallbackURLs: []string{"replica1:8545"},
+			},
+			want:       nil,
+			wantErrMsg: "web3signer fallback url must be in the format of http(s)://host:port url used: replica1:8545",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -292,7 +313,11 @@ func TestWeb3SignerConfig(t *testing.T) {
 			}
 			err := c.Apply(set)
 			require.NoError(t, err)
+			require.NoError(t, flags.Web3SignerFallbackURLsFlag.Apply(set))
 			require.NoError(t, set.Set(flags.Web3SignerURLFlag.Name, tt.args.baseURL))
+			for _, fallbackURL := range tt.args.fallbackURLs {
+				require.NoError(t, set.Set(flags.Web3SignerFallbackURLsFlag.Name, fallbackURL))
+			}
 			for _, key := range tt.args.publicKeysOrURLs {
 				require.NoError(t, set.Set(flags.Web3SignerPublicValidatorKeysFlag.Name, key))
 			}
@@ -21,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/petnames"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
@@ -272,3 +273,36 @@ func (s *Server) VoluntaryExit(w http.ResponseWriter, r *http.Request) {
 		ExitedKeys: rawExitedKeys,
 	})
 }
+
+// GetDoppelGangerStatus returns the doppelganger protection status of the key. A key which was just imported,
+// or which the client just started with, waits for the doppelganger window before performing duties.
+func (s *Server) GetDoppelGangerStatus(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.web.accounts.GetDoppelGangerStatus")
+	defer span.End()
+
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready.", http.StatusServiceUnavailable)
+		return
+	}
+	rawPubkey, pubkey, ok := shared.HexFromRoute(w, r, "pubkey", fieldparams.BLSPubkeyLength)
+	if !ok {
+		return
+	}
+
+	status, err := s.validatorService.DoppelGangerStatus(bytesutil.ToBytes48(pubkey))
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if status == iface.DoppelGangerUnknown {
+		httputil.HandleError(w, "Doppelganger protection does not track this key", http.StatusNotFound)
+		return
+	}
+
+	httputil.WriteJson(w, &GetDoppelGangerStatusResponse{
+		Data: &DoppelGangerStatusData{
+			Pubkey: rawPubkey,
+			Status: string(status),
+		},
+	})
+}
@@ -15,8 +15,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
 	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -25,6 +28,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/iface"
 	mock "github.com/prysmaticlabs/prysm/v5/validator/accounts/testing"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
+	iface2 "github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	constant "github.com/prysmaticlabs/prysm/v5/validator/testing"
@@ -326,3 +330,50 @@ func TestServer_VoluntaryExit(t *testing.T) {
 	}
 
 }
+
+func TestServer_GetDoppelGangerStatus(t *testing.T) {
+	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+	rawPubkey, err := hexutil.Decode(pubkey)
+	require.NoError(t, err)
+	m := &mock.Validator{
+		DoppelGangerStatuses: map[[fieldparams.BLSPubkeyLength]byte]iface2.DoppelGangerStatus{
+			bytesutil.ToBytes48(rawPubkey): iface2.DoppelGangerPending,
+		},
+	}
+	vs, err := client.NewValidatorService(context.Background(), &client.Config{
+		Validator: m,
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+	}
+
+	t.Run("tracked key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/accounts/{pubkey}/doppelganger", nil)
+		req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetDoppelGangerStatus(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &GetDoppelGangerStatusResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		assert.Equal(t, pubkey, resp.Data.Pubkey)
+		assert.Equal(t, string(iface2.DoppelGangerPending), resp.Data.Status)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/accounts/{pubkey}/doppelganger", nil)
+		req = mux.SetURLVars(req, map[string]string{"pubkey": hexutil.Encode(make([]byte, fieldparams.BLSPubkeyLength))})
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetDoppelGangerStatus(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("validator service not ready", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/accounts/{pubkey}/doppelganger", nil)
+		req = mux.SetURLVars(req, map[string]string{"pubkey": pubkey})
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		(&Server{}).GetDoppelGangerStatus(w, req)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
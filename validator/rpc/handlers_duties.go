@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"go.opencensus.io/trace"
+)
+
+// GetDutyTimeline returns the duties recorded by the validator client for the requested keys, or for every key
+// if none is requested, between from_slot and to_slot included. Each duty comes with when it was assigned and
+// performed, the beacon node used, the time spent signing and the step which failed, if any. Assigned duties of
+// past slots which were never performed are returned with the missed result.
+func (s *Server) GetDutyTimeline(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.web.duties.GetDutyTimeline")
+	defer span.End()
+
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready.", http.StatusServiceUnavailable)
+		return
+	}
+	publicKeys := r.URL.Query()["public_keys"]
+	pubkeys := make([][fieldparams.BLSPubkeyLength]byte, len(publicKeys))
+	for i, key := range publicKeys {
+		pk, ok := shared.ValidateHex(w, fmt.Sprintf("PublicKeys[%d]", i), key, fieldparams.BLSPubkeyLength)
+		if !ok {
+			return
+		}
+		pubkeys[i] = bytesutil.ToBytes48(pk)
+	}
+	rawFrom, fromSlot, ok := shared.UintFromQuery(w, r, "from_slot", false)
+	if !ok {
+		return
+	}
+	rawTo, toSlot, ok := shared.UintFromQuery(w, r, "to_slot", false)
+	if !ok {
+		return
+	}
+	if rawTo == "" {
+		toSlot = math.MaxUint64
+	}
+	if rawFrom != "" && rawTo != "" && fromSlot > toSlot {
+		httputil.HandleError(w, "from_slot cannot be greater than to_slot", http.StatusBadRequest)
+		return
+	}
+
+	records, err := s.validatorService.DutyTimeline(pubkeys, primitives.Slot(fromSlot), primitives.Slot(toSlot))
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	data := make([]*DutyRecord, len(records))
+	for i, record := range records {
+		data[i] = dutyRecordFromClient(record)
+	}
+	httputil.WriteJson(w, &DutyTimelineResponse{Data: data})
+}
+
+func dutyRecordFromClient(record *iface.DutyRecord) *DutyRecord {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return &DutyRecord{
+		Slot:             strconv.FormatUint(uint64(record.Slot), 10),
+		Pubkey:           hexutil.Encode(record.PubKey[:]),
+		Role:             record.Role.String(),
+		AssignedAt:       formatTime(record.AssignedAt),
+		StartedAt:        formatTime(record.StartedAt),
+		BeaconNode:       record.BeaconNode,
+		SigningLatencyMs: strconv.FormatInt(record.SigningLatency.Milliseconds(), 10),
+		SubmittedAt:      formatTime(record.SubmittedAt),
+		Result:           string(record.Result),
+		FailedStep:       record.FailedStep,
+		Error:            record.Error,
+	}
+}
@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	mock "github.com/prysmaticlabs/prysm/v5/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v5/validator/client"
+	iface2 "github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+func TestServer_GetDutyTimeline(t *testing.T) {
+	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+	rawPubkey, err := hexutil.Decode(pubkey)
+	require.NoError(t, err)
+	otherPubkey := bytesutil.ToBytes48([]byte("other"))
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := &mock.Validator{
+		DutyRecords: []*iface2.DutyRecord{
+			{
+				Slot:           10,
+				PubKey:         bytesutil.ToBytes48(rawPubkey),
+				Role:           iface2.RoleAttester,
+				StartedAt:      started,
+				BeaconNode:     "localhost:4000",
+				SigningLatency: 25 * time.Millisecond,
+				SubmittedAt:    started.Add(time.Second),
+				Result:         iface2.DutySubmitted,
+			},
+			{
+				Slot:       12,
+				PubKey:     bytesutil.ToBytes48(rawPubkey),
+				Role:       iface2.RoleProposer,
+				StartedAt:  started,
+				Result:     iface2.DutyFailed,
+				FailedStep: "block_request",
+				Error:      "beacon node unavailable",
+			},
+			{
+				Slot:   10,
+				PubKey: otherPubkey,
+				Role:   iface2.RoleAttester,
+				Result: iface2.DutySkipped,
+			},
+		},
+	}
+	vs, err := client.NewValidatorService(context.Background(), &client.Config{
+		Validator: m,
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+	}
+
+	get := func(t *testing.T, query string) (*httptest.ResponseRecorder, *DutyTimelineResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/duties/timeline"+query, nil)
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetDutyTimeline(w, req)
+		resp := &DutyTimelineResponse{}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		}
+		return w, resp
+	}
+
+	t.Run("all keys", func(t *testing.T) {
+		w, resp := get(t, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, 3, len(resp.Data))
+	})
+	t.Run("filtered", func(t *testing.T) {
+		w, resp := get(t, "?public_keys="+pubkey+"&from_slot=10&to_slot=11")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, 1, len(resp.Data))
+		assert.DeepEqual(t, &DutyRecord{
+			Slot:             "10",
+			Pubkey:           pubkey,
+			Role:             "attester",
+			StartedAt:        "2024-01-01T00:00:00Z",
+			BeaconNode:       "localhost:4000",
+			SigningLatencyMs: "25",
+			SubmittedAt:      "2024-01-01T00:00:01Z",
+			Result:           "submitted",
+		}, resp.Data[0])
+	})
+	t.Run("failed duty", func(t *testing.T) {
+		w, resp := get(t, "?public_keys="+pubkey+"&from_slot=12")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "failed", resp.Data[0].Result)
+		assert.Equal(t, "block_request", resp.Data[0].FailedStep)
+		assert.Equal(t, "beacon node unavailable", resp.Data[0].Error)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		w, _ := get(t, "?from_slot=12&to_slot=10")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		w, _ := get(t, "?public_keys=0x01")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("validator service not ready", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/duties/timeline", nil)
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		(&Server{}).GetDutyTimeline(w, req)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
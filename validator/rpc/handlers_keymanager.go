@@ -316,6 +316,111 @@ func (s *Server) slashingProtectionHistoryForDeletedKeys(
 	return slashingprotection.ExportStandardProtectionJSON(ctx, s.db, filteredKeys...)
 }
 
+// ExportSlashingProtectionHistory exports the EIP-3076 slashing protection history of the keys given by the
+// pubkey query parameters, or of every key if there are none, restricted to the [start_epoch, end_epoch] window.
+func (s *Server) ExportSlashingProtectionHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.ExportSlashingProtectionHistory")
+	defer span.End()
+
+	if s.db == nil {
+		httputil.HandleError(w, "Could not find validator database", http.StatusInternalServerError)
+		return
+	}
+	filter := &slashingprotection.ExportFilter{}
+	for i, rawPubkey := range r.URL.Query()["pubkey"] {
+		pubkey, ok := shared.ValidateHex(w, fmt.Sprintf("pubkey[%d]", i), rawPubkey, fieldparams.BLSPubkeyLength)
+		if !ok {
+			return
+		}
+		filter.PubKeys = append(filter.PubKeys, pubkey)
+	}
+	_, startEpoch, ok := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !ok {
+		return
+	}
+	_, endEpoch, ok := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !ok {
+		return
+	}
+	if endEpoch != 0 && endEpoch < startEpoch {
+		httputil.HandleError(w, "end_epoch must not be lower than start_epoch", http.StatusBadRequest)
+		return
+	}
+	filter.StartEpoch = primitives.Epoch(startEpoch)
+	filter.EndEpoch = primitives.Epoch(endEpoch)
+
+	eipJSON, err := slashingprotection.ExportFilteredStandardProtectionJSON(ctx, s.db, filter)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not export slashing protection history").Error(), http.StatusInternalServerError)
+		return
+	}
+	encoded, err := json.Marshal(eipJSON)
+	if err != nil {
+		httputil.HandleError(w, errors.Wrap(err, "Could not JSON marshal slashing protection history").Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &ExportSlashingProtectionHistoryResponse{
+		SlashingProtection: string(encoded),
+	})
+}
+
+// ImportSlashingProtectionHistory imports an EIP-3076 slashing protection history. With merge set, keys with
+// conflicting history keep the highest watermarks instead of being rejected. With dry_run set, the database
+// is left untouched and only the per-key report of what would change is returned.
+func (s *Server) ImportSlashingProtectionHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.ImportSlashingProtectionHistory")
+	defer span.End()
+
+	if s.db == nil {
+		httputil.HandleError(w, "Could not find validator database", http.StatusInternalServerError)
+		return
+	}
+
+	var req ImportSlashingProtectionHistoryRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.SlashingProtection == "" {
+		httputil.HandleError(w, "Empty slashing_protection specified", http.StatusBadRequest)
+		return
+	}
+	// Reject malformed files and files of another chain before any of the imports touches the database.
+	if err := slashingprotection.ValidateStandardProtectionJSON(ctx, s.db, strings.NewReader(req.SlashingProtection)); err != nil {
+		handleSlashingProtectionImportError(w, err)
+		return
+	}
+	buf := bytes.NewBufferString(req.SlashingProtection)
+
+	var report *slashingprotection.MergeReport
+	switch {
+	case req.DryRun:
+		report, err = slashingprotection.DiffStandardProtectionJSON(ctx, s.db, buf)
+	case req.Merge:
+		report, err = slashingprotection.MergeStandardProtectionJSON(ctx, s.db, buf)
+	default:
+		err = s.db.ImportStandardProtectionJSON(ctx, buf)
+	}
+	if err != nil {
+		handleSlashingProtectionImportError(w, err)
+		return
+	}
+	httputil.WriteJson(w, &ImportSlashingProtectionHistoryResponse{Data: report})
+}
+
+func handleSlashingProtectionImportError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, slashingprotection.ErrInvalidInterchange) {
+		status = http.StatusBadRequest
+	}
+	httputil.HandleError(w, errors.Wrap(err, "Could not import slashing protection history").Error(), status)
+}
+
 // SetVoluntaryExit creates a signed voluntary exit message and returns a VoluntaryExit object.
 func (s *Server) SetVoluntaryExit(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "validator.keymanagerAPI.SetVoluntaryExit")
@@ -362,7 +467,7 @@ func (s *Server) SetVoluntaryExit(w http.ResponseWriter, r *http.Request) {
 	sve, err := client.CreateSignedVoluntaryExit(
 		ctx,
 		s.beaconNodeValidatorClient,
-		km.Sign,
+		client.WithSignHooks(km.Sign, s.validatorService.SignHooks()...),
 		pubkey,
 		epoch,
 	)
@@ -1964,3 +1964,104 @@ func TestServer_Graffiti(t *testing.T) {
 	s.DeleteGraffiti(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestServer_ImportExportSlashingProtectionHistory(t *testing.T) {
+	numValidators := 3
+	pubKeys, err := mocks.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	s := &Server{db: dbtest.SetupDB(t, pubKeys, false)}
+
+	attestingHistory, proposalHistory := mocks.MockAttestingAndProposalHistories(pubKeys)
+	mockJSON, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(mockJSON)
+	require.NoError(t, err)
+
+	importHistory := func(t *testing.T, merge, dryRun bool) *ImportSlashingProtectionHistoryResponse {
+		var buf bytes.Buffer
+		require.NoError(t, json.NewEncoder(&buf).Encode(&ImportSlashingProtectionHistoryRequest{
+			SlashingProtection: string(encoded),
+			Merge:              merge,
+			DryRun:             dryRun,
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/v2/validator/slashing-protection/history", &buf)
+		wr := httptest.NewRecorder()
+		s.ImportSlashingProtectionHistory(wr, req)
+		require.Equal(t, http.StatusOK, wr.Code, wr.Body.String())
+		resp := &ImportSlashingProtectionHistoryResponse{}
+		require.NoError(t, json.Unmarshal(wr.Body.Bytes(), resp))
+		return resp
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		resp := importHistory(t, false, true)
+		require.Equal(t, numValidators, len(resp.Data.Keys))
+		for _, d := range resp.Data.Keys {
+			assert.Equal(t, true, d.NewKey)
+			assert.Equal(t, true, d.Changed())
+		}
+		// Nothing was written, so there is not even a genesis validators root to export.
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/slashing-protection/history", nil)
+		wr := httptest.NewRecorder()
+		s.ExportSlashingProtectionHistory(wr, req)
+		require.Equal(t, http.StatusInternalServerError, wr.Code)
+	})
+	t.Run("merge", func(t *testing.T) {
+		resp := importHistory(t, true, false)
+		require.Equal(t, numValidators, len(resp.Data.Keys))
+		resp = importHistory(t, false, true)
+		for _, d := range resp.Data.Keys {
+			assert.Equal(t, false, d.Changed())
+		}
+	})
+	t.Run("filtered export", func(t *testing.T) {
+		url := fmt.Sprintf("/v2/validator/slashing-protection/history?pubkey=%s&start_epoch=1", mockJSON.Data[0].Pubkey)
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		wr := httptest.NewRecorder()
+		s.ExportSlashingProtectionHistory(wr, req)
+		require.Equal(t, http.StatusOK, wr.Code, wr.Body.String())
+		resp := &ExportSlashingProtectionHistoryResponse{}
+		require.NoError(t, json.Unmarshal(wr.Body.Bytes(), resp))
+		exported := &format.EIPSlashingProtectionFormat{}
+		require.NoError(t, json.Unmarshal([]byte(resp.SlashingProtection), exported))
+		require.DeepEqual(t, mockJSON.Metadata, exported.Metadata)
+		require.Equal(t, 1, len(exported.Data))
+		assert.Equal(t, mockJSON.Data[0].Pubkey, exported.Data[0].Pubkey)
+	})
+	t.Run("invalid epoch range", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/validator/slashing-protection/history?start_epoch=5&end_epoch=4", nil)
+		wr := httptest.NewRecorder()
+		s.ExportSlashingProtectionHistory(wr, req)
+		require.Equal(t, http.StatusBadRequest, wr.Code)
+	})
+	t.Run("invalid interchange data", func(t *testing.T) {
+		badEpoch, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+		require.NoError(t, err)
+		badEpoch.Data[0].SignedAttestations[0].TargetEpoch = "not-an-epoch"
+		badEpochJSON, err := json.Marshal(badEpoch)
+		require.NoError(t, err)
+		otherChain, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+		require.NoError(t, err)
+		otherChain.Metadata.GenesisValidatorsRoot = hexutil.Encode(bytesutil.PadTo([]byte("other chain"), 32))
+		otherChainJSON, err := json.Marshal(otherChain)
+		require.NoError(t, err)
+
+		for name, protection := range map[string]string{
+			"malformed json":                   "{",
+			"invalid epoch":                    string(badEpochJSON),
+			"genesis validators root mismatch": string(otherChainJSON),
+		} {
+			for _, merge := range []bool{false, true} {
+				var buf bytes.Buffer
+				require.NoError(t, json.NewEncoder(&buf).Encode(&ImportSlashingProtectionHistoryRequest{
+					SlashingProtection: protection,
+					Merge:              merge,
+				}))
+				req := httptest.NewRequest(http.MethodPost, "/v2/validator/slashing-protection/history", &buf)
+				wr := httptest.NewRecorder()
+				s.ImportSlashingProtectionHistory(wr, req)
+				require.Equal(t, http.StatusBadRequest, wr.Code, name)
+			}
+		}
+	})
+}
@@ -215,6 +215,7 @@ func (s *Server) InitializeRoutes() error {
 	s.router.HandleFunc(api.WebUrlPrefix+"accounts", s.ListAccounts).Methods(http.MethodGet)
 	s.router.HandleFunc(api.WebUrlPrefix+"accounts/backup", s.BackupAccounts).Methods(http.MethodPost)
 	s.router.HandleFunc(api.WebUrlPrefix+"accounts/voluntary-exit", s.VoluntaryExit).Methods(http.MethodPost)
+	s.router.HandleFunc(api.WebUrlPrefix+"accounts/{pubkey}/doppelganger", s.GetDoppelGangerStatus).Methods(http.MethodGet)
 	// web health endpoints
 	s.router.HandleFunc(api.WebUrlPrefix+"health/version", s.GetVersion).Methods(http.MethodGet)
 	s.router.HandleFunc(api.WebUrlPrefix+"health/logs/validator/stream", s.StreamValidatorLogs).Methods(http.MethodGet)
@@ -230,9 +231,13 @@ func (s *Server) InitializeRoutes() error {
 	s.router.HandleFunc(api.WebUrlPrefix+"wallet/create", s.CreateWallet).Methods(http.MethodPost)
 	s.router.HandleFunc(api.WebUrlPrefix+"wallet/keystores/validate", s.ValidateKeystores).Methods(http.MethodPost)
 	s.router.HandleFunc(api.WebUrlPrefix+"wallet/recover", s.RecoverWallet).Methods(http.MethodPost)
+	// duties endpoints
+	s.router.HandleFunc(api.WebUrlPrefix+"duties/timeline", s.GetDutyTimeline).Methods(http.MethodGet)
 	// slashing protection endpoints
 	s.router.HandleFunc(api.WebUrlPrefix+"slashing-protection/export", s.ExportSlashingProtection).Methods(http.MethodGet)
 	s.router.HandleFunc(api.WebUrlPrefix+"slashing-protection/import", s.ImportSlashingProtection).Methods(http.MethodPost)
+	s.router.HandleFunc(api.WebUrlPrefix+"slashing-protection/history", s.ExportSlashingProtectionHistory).Methods(http.MethodGet)
+	s.router.HandleFunc(api.WebUrlPrefix+"slashing-protection/history", s.ImportSlashingProtectionHistory).Methods(http.MethodPost)
 	log.Info("Initialized REST API routes")
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
 )
 
 // local keymanager api
@@ -43,6 +44,21 @@ type DeleteKeystoresResponse struct {
 	SlashingProtection string                  `json:"slashing_protection"`
 }
 
+// slashing protection keymanager api
+type ExportSlashingProtectionHistoryResponse struct {
+	SlashingProtection string `json:"slashing_protection"`
+}
+
+type ImportSlashingProtectionHistoryRequest struct {
+	SlashingProtection string `json:"slashing_protection"`
+	Merge              bool   `json:"merge"`
+	DryRun             bool   `json:"dry_run"`
+}
+
+type ImportSlashingProtectionHistoryResponse struct {
+	Data *slashingprotection.MergeReport `json:"data"`
+}
+
 // voluntary exit keymanager api
 type SetVoluntaryExitResponse struct {
 	Data *structs.SignedVoluntaryExit `json:"data"`
@@ -109,6 +125,34 @@ type GraffitiData struct {
 	Graffiti string `json:"graffiti"`
 }
 
+// Doppelganger keymanager api
+type GetDoppelGangerStatusResponse struct {
+	Data *DoppelGangerStatusData `json:"data"`
+}
+
+type DoppelGangerStatusData struct {
+	Pubkey string `json:"pubkey"`
+	Status string `json:"status"`
+}
+
+type DutyTimelineResponse struct {
+	Data []*DutyRecord `json:"data"`
+}
+
+type DutyRecord struct {
+	Slot             string `json:"slot"`
+	Pubkey           string `json:"pubkey"`
+	Role             string `json:"role"`
+	AssignedAt       string `json:"assigned_at,omitempty"`
+	StartedAt        string `json:"started_at"`
+	BeaconNode       string `json:"beacon_node"`
+	SigningLatencyMs string `json:"signing_latency_ms"`
+	SubmittedAt      string `json:"submitted_at,omitempty"`
+	Result           string `json:"result"`
+	FailedStep       string `json:"failed_step,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
 type BeaconStatusResponse struct {
 	BeaconNodeEndpoint     string     `json:"beacon_node_endpoint"`
 	Connected              bool       `json:"connected"`
@@ -8,13 +8,32 @@ import (
 
 	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/progress"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/prysmaticlabs/prysm/v5/validator/db"
 	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
 	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
 )
 
+// ExportFilter restricts the slashing protection data returned by ExportFilteredStandardProtectionJSON.
+// An empty PubKeys list exports every key in the database. Blocks are kept if the epoch of their slot,
+// and attestations if their target epoch, lies within [StartEpoch, EndEpoch]. A zero EndEpoch means
+// there is no upper bound.
+type ExportFilter struct {
+	PubKeys    [][]byte
+	StartEpoch primitives.Epoch
+	EndEpoch   primitives.Epoch
+}
+
+func (f *ExportFilter) epochInRange(e primitives.Epoch) bool {
+	if e < f.StartEpoch {
+		return false
+	}
+	return f.EndEpoch == 0 || e <= f.EndEpoch
+}
+
 // ExportStandardProtectionJSON extracts all slashing protection data from a validator database
 // and packages it into an EIP-3076 compliant, standard
 func ExportStandardProtectionJSON(
@@ -22,6 +41,23 @@ func ExportStandardProtectionJSON(
 	validatorDB db.Database,
 	filteredKeys ...[]byte,
 ) (*format.EIPSlashingProtectionFormat, error) {
+	return ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{PubKeys: filteredKeys})
+}
+
+// ExportFilteredStandardProtectionJSON is like ExportStandardProtectionJSON, but only exports the keys
+// and the epoch window selected by the given filter.
+func ExportFilteredStandardProtectionJSON(
+	ctx context.Context,
+	validatorDB db.Database,
+	filter *ExportFilter,
+) (*format.EIPSlashingProtectionFormat, error) {
+	if filter == nil {
+		filter = &ExportFilter{}
+	}
+	if filter.EndEpoch != 0 && filter.EndEpoch < filter.StartEpoch {
+		return nil, fmt.Errorf("end epoch %d is lower than start epoch %d", filter.EndEpoch, filter.StartEpoch)
+	}
+	filteredKeys := filter.PubKeys
 	interchangeJSON := &format.EIPSlashingProtectionFormat{}
 	genesisValidatorsRoot, err := validatorDB.GenesisValidatorsRoot(ctx)
 	if err != nil {
@@ -68,7 +104,7 @@ func ExportStandardProtectionJSON(
 		if err != nil {
 			return nil, errors.Wrap(err, "could not convert public key to hex string")
 		}
-		signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKey)
+		signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKey, filter)
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not retrieve signed blocks for public key %s", pubKeyHex)
 		}
@@ -94,7 +130,7 @@ func ExportStandardProtectionJSON(
 		if err != nil {
 			return nil, errors.Wrap(err, "could not convert public key to hex string")
 		}
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKey)
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKey, filter)
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not retrieve signed attestations for public key %s", pubKeyHex)
 		}
@@ -127,7 +163,7 @@ func ExportStandardProtectionJSON(
 	return interchangeJSON, nil
 }
 
-func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*format.SignedAttestation, error) {
+func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte, filter *ExportFilter) ([]*format.SignedAttestation, error) {
 	// If a key does not have an attestation history in our database, we return nil.
 	// This way, a user will be able to export their slashing protection history
 	// even if one of their keys does not have a history of signed attestations.
@@ -153,6 +189,9 @@ func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pu
 				continue
 			}
 		}
+		if filter != nil && !filter.epochInRange(att.Target) {
+			continue
+		}
 		var root string
 		if len(att.SigningRoot) != 0 {
 			root, err = helpers.RootToHexString(att.SigningRoot)
@@ -169,7 +208,7 @@ func signedAttestationsByPubKey(ctx context.Context, validatorDB db.Database, pu
 	return signedAttestations, nil
 }
 
-func signedBlocksByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*format.SignedBlock, error) {
+func signedBlocksByPubKey(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte, filter *ExportFilter) ([]*format.SignedBlock, error) {
 	// If a key does not have a lowest or highest signed proposal history
 	// in our database, we return an empty list. This way, a user will be able to export
 	// their slashing protection history even if one of their keys does not have a history
@@ -183,6 +222,9 @@ func signedBlocksByPubKey(ctx context.Context, validatorDB db.Database, pubKey [
 		if ctx.Err() != nil {
 			return nil, errors.Wrap(err, "context canceled")
 		}
+		if filter != nil && !filter.epochInRange(slots.ToEpoch(proposal.Slot)) {
+			continue
+		}
 		signingRootHex, err := helpers.RootToHexString(proposal.SigningRoot)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not convert signing root to hex string")
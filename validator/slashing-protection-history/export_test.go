@@ -43,7 +43,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 			validatorDB := dbtest.SetupDB(t, pubKeys, isSlashingProtectionMinimal)
 
 			// No attestation history stored should return empty.
-			signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+			signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 			require.NoError(t, err)
 			assert.Equal(t, 0, len(signedAttestations))
 
@@ -61,7 +61,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 			)))
 
 			// We then retrieve the signed attestations and expect a correct result.
-			signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+			signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 			require.NoError(t, err)
 
 			wanted := []*format.SignedAttestation{
@@ -101,7 +101,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 		validatorDB := dbtest.SetupDB(t, pubKeys, isSlashingProtectionMinimal)
 
 		// No attestation history stored should return empty.
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(signedAttestations))
 
@@ -123,7 +123,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 
 		// We then retrieve the signed attestations and expect to have
 		// skipped the 0th, corrupted entry.
-		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 
 		wanted := []*format.SignedAttestation{
@@ -148,7 +148,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 		validatorDB := dbtest.SetupDB(t, pubKeys, isSlashingProtectionMinimal)
 
 		// No attestation history stored should return empty.
-		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err := signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(signedAttestations))
 
@@ -170,7 +170,7 @@ func Test_getSignedAttestationsByPubKey(t *testing.T) {
 
 		// We then retrieve the signed attestations and do not expect changes
 		// as the bug only manifests in the genesis epoch.
-		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0])
+		signedAttestations, err = signedAttestationsByPubKey(ctx, validatorDB, pubKeys[0], nil)
 		require.NoError(t, err)
 
 		wanted := []*format.SignedAttestation{
@@ -199,7 +199,7 @@ func Test_getSignedBlocksByPubKey(t *testing.T) {
 			validatorDB := dbtest.SetupDB(t, pubKeys, isSlashingProtectionMinimal)
 
 			// No highest and/or lowest signed blocks will return empty.
-			signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKeys[0])
+			signedBlocks, err := signedBlocksByPubKey(ctx, validatorDB, pubKeys[0], nil)
 			require.NoError(t, err)
 			assert.Equal(t, 0, len(signedBlocks))
 
@@ -219,7 +219,7 @@ func Test_getSignedBlocksByPubKey(t *testing.T) {
 
 			// We expect a valid proposal history containing slot 1 and slot 5 only
 			// when we attempt to retrieve it from disk.
-			signedBlocks, err = signedBlocksByPubKey(ctx, validatorDB, pubKeys[0])
+			signedBlocks, err = signedBlocksByPubKey(ctx, validatorDB, pubKeys[0], nil)
 			require.NoError(t, err)
 
 			wanted := []*format.SignedBlock{
@@ -263,3 +263,46 @@ func createAttestation(source, target primitives.Epoch) *ethpb.IndexedAttestatio
 		},
 	}
 }
+
+func TestExportFilteredStandardProtectionJSON(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}}
+	validatorDB := dbtest.SetupDB(t, pubKeys, false)
+	genesisValidatorsRoot := [32]byte{1}
+	require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, genesisValidatorsRoot[:]))
+
+	for _, pubKey := range pubKeys {
+		// Blocks in epochs 0, 1 and 3.
+		for _, slot := range []primitives.Slot{1, 40, 100} {
+			require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, slot, []byte{1}))
+		}
+		// Attestations with targets 1, 2 and 5.
+		atts := []*ethpb.IndexedAttestation{createAttestation(0, 1), createAttestation(1, 2), createAttestation(2, 5)}
+		require.NoError(t, validatorDB.SaveAttestationsForPubKey(ctx, pubKey, [][]byte{{1}, {2}, {3}}, atts))
+	}
+
+	filter := &ExportFilter{PubKeys: [][]byte{pubKeys[1][:]}, StartEpoch: 1, EndEpoch: 2}
+	eipStandard, err := ExportFilteredStandardProtectionJSON(ctx, validatorDB, filter)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(eipStandard.Data))
+	assert.Equal(t, fmt.Sprintf("%#x", pubKeys[1]), eipStandard.Data[0].Pubkey)
+	require.Equal(t, 1, len(eipStandard.Data[0].SignedBlocks))
+	assert.Equal(t, "40", eipStandard.Data[0].SignedBlocks[0].Slot)
+	require.Equal(t, 2, len(eipStandard.Data[0].SignedAttestations))
+	assert.Equal(t, "1", eipStandard.Data[0].SignedAttestations[0].TargetEpoch)
+	assert.Equal(t, "2", eipStandard.Data[0].SignedAttestations[1].TargetEpoch)
+
+	// No upper bound.
+	eipStandard, err = ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{StartEpoch: 3})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(eipStandard.Data))
+	for _, item := range eipStandard.Data {
+		require.Equal(t, 1, len(item.SignedBlocks))
+		assert.Equal(t, "100", item.SignedBlocks[0].Slot)
+		require.Equal(t, 1, len(item.SignedAttestations))
+		assert.Equal(t, "5", item.SignedAttestations[0].TargetEpoch)
+	}
+
+	_, err = ExportFilteredStandardProtectionJSON(ctx, validatorDB, &ExportFilter{StartEpoch: 3, EndEpoch: 2})
+	require.ErrorContains(t, "end epoch 2 is lower than start epoch 3", err)
+}
@@ -0,0 +1,390 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/slashings"
+	"github.com/prysmaticlabs/prysm/v5/validator/db"
+	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+// ErrInvalidInterchange is returned when an EIP-3076 interchange file is malformed, or was created for another chain.
+var ErrInvalidInterchange = errors.New("invalid slashing protection interchange file")
+
+// Watermarks are the highest block slot and attestation source and target epochs signed by a key.
+// A nil field means nothing has been signed.
+type Watermarks struct {
+	BlockSlot   *primitives.Slot  `json:"block_slot,omitempty"`
+	SourceEpoch *primitives.Epoch `json:"source_epoch,omitempty"`
+	TargetEpoch *primitives.Epoch `json:"target_epoch,omitempty"`
+}
+
+// KeyDiff describes how the interchange data of a single public key compares with the database.
+type KeyDiff struct {
+	Pubkey string `json:"pubkey"`
+	// NewKey is true if the database has no slashing protection data for the key.
+	NewKey   bool       `json:"new_key"`
+	Database Watermarks `json:"database"`
+	File     Watermarks `json:"file"`
+	Merged   Watermarks `json:"merged"`
+	// Conflicts lists the entries of the file which are slashable with respect to the database.
+	// A regular import would blacklist the key, a merge only keeps the highest watermarks.
+	Conflicts []string `json:"conflicts"`
+}
+
+// Changed returns true if merging the file would raise any of the database watermarks.
+func (d *KeyDiff) Changed() bool {
+	return !d.Database.equal(d.Merged)
+}
+
+// MergeReport is the per-key result of comparing an interchange file with the database.
+type MergeReport struct {
+	Keys []*KeyDiff `json:"keys"`
+}
+
+// DiffStandardProtectionJSON compares an EIP-3076 interchange file with the slashing protection database,
+// without writing anything to the database.
+func DiffStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader) (*MergeReport, error) {
+	interchangeJSON, err := readInterchange(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGenesisValidatorsRoot(ctx, validatorDB, interchangeJSON); err != nil {
+		return nil, err
+	}
+	return diffInterchange(ctx, validatorDB, interchangeJSON)
+}
+
+// MergeStandardProtectionJSON merges an EIP-3076 interchange file into the slashing protection database.
+// Instead of rejecting keys whose data conflicts with the database, as ImportStandardProtectionJSON does,
+// the database is only ever moved forward: for every key, the highest block slot and the highest
+// attestation source and target epochs from either side are saved as the new watermarks.
+func MergeStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader) (*MergeReport, error) {
+	interchangeJSON, err := readInterchange(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := helpers.ValidateMetadata(ctx, validatorDB, interchangeJSON); err != nil {
+		return nil, errors.Wrap(err, "slashing protection JSON metadata was incorrect")
+	}
+	report, err := diffInterchange(ctx, validatorDB, interchangeJSON)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range report.Keys {
+		if !d.Changed() {
+			continue
+		}
+		pubKey, err := helpers.PubKeyFromHex(d.Pubkey)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveWatermarks(ctx, validatorDB, pubKey, d); err != nil {
+			return nil, errors.Wrapf(err, "could not merge slashing protection for key %s", d.Pubkey)
+		}
+	}
+	return report, nil
+}
+
+// ValidateStandardProtectionJSON checks an EIP-3076 interchange file without writing anything to the database:
+// its format version, its genesis validators root against the one of the database, and every entry.
+// The errors caused by the file wrap ErrInvalidInterchange.
+func ValidateStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader) error {
+	interchangeJSON, err := readInterchange(r)
+	if err != nil {
+		return errors.Wrap(ErrInvalidInterchange, err.Error())
+	}
+	if err := checkGenesisValidatorsRoot(ctx, validatorDB, interchangeJSON); err != nil {
+		return err
+	}
+	for _, item := range interchangeJSON.Data {
+		if item == nil {
+			continue
+		}
+		if err := validateProtectionData(item); err != nil {
+			return errors.Wrap(ErrInvalidInterchange, errors.Wrapf(err, "invalid slashing protection data for key %s", item.Pubkey).Error())
+		}
+	}
+	return nil
+}
+
+func validateProtectionData(item *format.ProtectionData) error {
+	if _, err := helpers.PubKeyFromHex(item.Pubkey); err != nil {
+		return fmt.Errorf("%s is not a valid public key: %w", item.Pubkey, err)
+	}
+	for _, sb := range item.SignedBlocks {
+		if sb == nil {
+			continue
+		}
+		if _, err := helpers.SlotFromString(sb.Slot); err != nil {
+			return fmt.Errorf("%s is not a valid slot: %w", sb.Slot, err)
+		}
+		if _, err := optionalRoot(sb.SigningRoot); err != nil {
+			return err
+		}
+	}
+	for _, sa := range item.SignedAttestations {
+		if sa == nil {
+			continue
+		}
+		source, err := helpers.EpochFromString(sa.SourceEpoch)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid epoch: %w", sa.SourceEpoch, err)
+		}
+		target, err := helpers.EpochFromString(sa.TargetEpoch)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid epoch: %w", sa.TargetEpoch, err)
+		}
+		if source > target {
+			return fmt.Errorf("attestation source epoch %d is greater than target epoch %d", source, target)
+		}
+		if _, err := optionalRoot(sa.SigningRoot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readInterchange(r io.Reader) (*format.EIPSlashingProtectionFormat, error) {
+	encodedJSON, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read slashing protection JSON file")
+	}
+	interchangeJSON := &format.EIPSlashingProtectionFormat{}
+	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+	}
+	return interchangeJSON, nil
+}
+
+// checkGenesisValidatorsRoot is the read-only counterpart of helpers.ValidateMetadata.
+func checkGenesisValidatorsRoot(ctx context.Context, validatorDB db.Database, interchangeJSON *format.EIPSlashingProtectionFormat) error {
+	if v := interchangeJSON.Metadata.InterchangeFormatVersion; v != format.InterchangeFormatVersion {
+		return errors.Wrapf(ErrInvalidInterchange, "slashing protection JSON version '%s' is not supported, wanted '%s'", v, format.InterchangeFormatVersion)
+	}
+	gvr, err := helpers.RootFromHex(interchangeJSON.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidInterchange, "%s is not a valid root: %v", interchangeJSON.Metadata.GenesisValidatorsRoot, err)
+	}
+	dbGvr, err := validatorDB.GenesisValidatorsRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve genesis validators root from db")
+	}
+	if dbGvr != nil && !bytes.Equal(dbGvr, gvr[:]) {
+		return errors.Wrap(ErrInvalidInterchange, "genesis validators root doesn't match the one that is stored in slashing protection db")
+	}
+	return nil
+}
+
+func diffInterchange(ctx context.Context, validatorDB db.Database, interchangeJSON *format.EIPSlashingProtectionFormat) (*MergeReport, error) {
+	byKey := make(map[[fieldparams.BLSPubkeyLength]byte]*KeyDiff)
+	for _, item := range interchangeJSON.Data {
+		if item == nil {
+			continue
+		}
+		pubKey, err := helpers.PubKeyFromHex(item.Pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid public key: %w", item.Pubkey, err)
+		}
+		d, ok := byKey[pubKey]
+		if !ok {
+			if d, err = newKeyDiff(ctx, validatorDB, pubKey); err != nil {
+				return nil, err
+			}
+			byKey[pubKey] = d
+		}
+		if err := d.addFileData(ctx, validatorDB, pubKey, item); err != nil {
+			return nil, errors.Wrapf(err, "invalid slashing protection data for key %s", item.Pubkey)
+		}
+	}
+
+	report := &MergeReport{Keys: make([]*KeyDiff, 0, len(byKey))}
+	for _, d := range byKey {
+		d.Merged = d.Database.max(d.File)
+		report.Keys = append(report.Keys, d)
+	}
+	sort.Slice(report.Keys, func(i, j int) bool {
+		return report.Keys[i].Pubkey < report.Keys[j].Pubkey
+	})
+	return report, nil
+}
+
+func newKeyDiff(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte) (*KeyDiff, error) {
+	pubKeyHex, err := helpers.PubKeyToHexString(pubKey[:])
+	if err != nil {
+		return nil, err
+	}
+	d := &KeyDiff{Pubkey: pubKeyHex, Conflicts: make([]string, 0)}
+	proposals, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get proposal history for public key %s", pubKeyHex)
+	}
+	for _, p := range proposals {
+		d.Database.raiseSlot(p.Slot)
+	}
+	atts, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get attestation history for public key %s", pubKeyHex)
+	}
+	for _, a := range atts {
+		d.Database.raiseEpochs(a.Source, a.Target)
+	}
+	d.NewKey = len(proposals) == 0 && len(atts) == 0
+	return d, nil
+}
+
+func (d *KeyDiff) addFileData(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte, item *format.ProtectionData) error {
+	proposals, err := validatorDB.ProposalHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return err
+	}
+	for _, sb := range item.SignedBlocks {
+		if sb == nil {
+			continue
+		}
+		slot, err := helpers.SlotFromString(sb.Slot)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid slot: %w", sb.Slot, err)
+		}
+		root, err := optionalRoot(sb.SigningRoot)
+		if err != nil {
+			return err
+		}
+		d.File.raiseSlot(slot)
+		for _, p := range proposals {
+			if p.Slot == slot && slashings.SigningRootsDiffer(p.SigningRoot, root) {
+				d.Conflicts = append(d.Conflicts, fmt.Sprintf("double proposal at slot %d", slot))
+				break
+			}
+		}
+	}
+
+	atts, err := validatorDB.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return err
+	}
+	for _, sa := range item.SignedAttestations {
+		if sa == nil {
+			continue
+		}
+		source, err := helpers.EpochFromString(sa.SourceEpoch)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid epoch: %w", sa.SourceEpoch, err)
+		}
+		target, err := helpers.EpochFromString(sa.TargetEpoch)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid epoch: %w", sa.TargetEpoch, err)
+		}
+		if source > target {
+			return fmt.Errorf("attestation source epoch %d is greater than target epoch %d", source, target)
+		}
+		root, err := optionalRoot(sa.SigningRoot)
+		if err != nil {
+			return err
+		}
+		d.File.raiseEpochs(source, target)
+		incoming := createIndexedAttestation(source, target)
+		for _, a := range atts {
+			existing := createIndexedAttestation(a.Source, a.Target)
+			if a.Target == target && slashings.SigningRootsDiffer(a.SigningRoot, root) {
+				d.Conflicts = append(d.Conflicts, fmt.Sprintf("double vote at target epoch %d", target))
+				break
+			}
+			if slashings.IsSurround(incoming, existing) || slashings.IsSurround(existing, incoming) {
+				d.Conflicts = append(d.Conflicts, fmt.Sprintf("surround vote between source %d, target %d and source %d, target %d", source, target, a.Source, a.Target))
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// saveWatermarks writes the merged watermarks which are higher than those of the database, without signing roots,
+// so that the validator refuses to sign anything at or below them.
+func saveWatermarks(ctx context.Context, validatorDB db.Database, pubKey [fieldparams.BLSPubkeyLength]byte, d *KeyDiff) error {
+	if d.Merged.BlockSlot != nil && (d.Database.BlockSlot == nil || *d.Merged.BlockSlot > *d.Database.BlockSlot) {
+		if err := validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, *d.Merged.BlockSlot, []byte{}); err != nil {
+			return errors.Wrap(err, "could not save block proposal watermark")
+		}
+	}
+	if d.Merged.TargetEpoch != nil && !d.Database.equalEpochs(d.Merged) {
+		att := createIndexedAttestation(*d.Merged.SourceEpoch, *d.Merged.TargetEpoch)
+		if err := validatorDB.SaveAttestationsForPubKey(ctx, pubKey, [][]byte{{}}, []*ethpb.IndexedAttestation{att}); err != nil {
+			return errors.Wrap(err, "could not save attestation watermark")
+		}
+	}
+	return nil
+}
+
+func optionalRoot(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	root, err := helpers.RootFromHex(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid root: %w", s, err)
+	}
+	return root[:], nil
+}
+
+func createIndexedAttestation(source, target primitives.Epoch) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: source},
+			Target: &ethpb.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func (w *Watermarks) raiseSlot(s primitives.Slot) {
+	if w.BlockSlot == nil || s > *w.BlockSlot {
+		w.BlockSlot = &s
+	}
+}
+
+func (w *Watermarks) raiseEpochs(source, target primitives.Epoch) {
+	if w.SourceEpoch == nil || source > *w.SourceEpoch {
+		w.SourceEpoch = &source
+	}
+	if w.TargetEpoch == nil || target > *w.TargetEpoch {
+		w.TargetEpoch = &target
+	}
+}
+
+func (w Watermarks) max(o Watermarks) Watermarks {
+	m := Watermarks{}
+	for _, x := range []Watermarks{w, o} {
+		if x.BlockSlot != nil {
+			m.raiseSlot(*x.BlockSlot)
+		}
+		if x.TargetEpoch != nil {
+			m.raiseEpochs(*x.SourceEpoch, *x.TargetEpoch)
+		}
+	}
+	return m
+}
+
+func (w Watermarks) equal(o Watermarks) bool {
+	return equalPtr(w.BlockSlot, o.BlockSlot) && w.equalEpochs(o)
+}
+
+func (w Watermarks) equalEpochs(o Watermarks) bool {
+	return equalPtr(w.SourceEpoch, o.SourceEpoch) && equalPtr(w.TargetEpoch, o.TargetEpoch)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,149 @@
+package history_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	dbtest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
+	history "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+func setupMergeTest(t *testing.T, isSlashingProtectionMinimal bool) (iface.ValidatorDB, [fieldparams.BLSPubkeyLength]byte, *format.EIPSlashingProtectionFormat) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, isSlashingProtectionMinimal)
+	gvr := [32]byte{1}
+	require.NoError(t, validatorDB.SaveGenesisValidatorsRoot(ctx, gvr[:]))
+
+	// The database has a block at slot 5 and an attestation with source 1 and target 2.
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 5, bytes.Repeat([]byte{1}, 32)))
+	att := &ethpb.IndexedAttestation{Data: &ethpb.AttestationData{
+		Source: &ethpb.Checkpoint{Epoch: 1},
+		Target: &ethpb.Checkpoint{Epoch: 2},
+	}}
+	require.NoError(t, validatorDB.SaveAttestationsForPubKey(ctx, pubKey, [][]byte{bytes.Repeat([]byte{1}, 32)}, []*ethpb.IndexedAttestation{att}))
+
+	// The file conflicts with both, and has higher watermarks.
+	otherRoot := fmt.Sprintf("%#x", bytes.Repeat([]byte{2}, 32))
+	file := &format.EIPSlashingProtectionFormat{}
+	file.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	file.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", gvr)
+	file.Data = []*format.ProtectionData{
+		{
+			Pubkey: fmt.Sprintf("%#x", pubKey),
+			SignedBlocks: []*format.SignedBlock{
+				{Slot: "5", SigningRoot: otherRoot},
+				{Slot: "10"},
+			},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: otherRoot},
+				{SourceEpoch: "3", TargetEpoch: "8"},
+			},
+		},
+		{
+			Pubkey:             fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{2}),
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "4", TargetEpoch: "5"}},
+		},
+	}
+	return validatorDB, pubKey, file
+}
+
+func encodeInterchange(t *testing.T, f *format.EIPSlashingProtectionFormat) *bytes.Buffer {
+	blob, err := json.Marshal(f)
+	require.NoError(t, err)
+	return bytes.NewBuffer(blob)
+}
+
+func TestDiffStandardProtectionJSON(t *testing.T) {
+	for _, isSlashingProtectionMinimal := range [...]bool{false, true} {
+		t.Run(fmt.Sprintf("isSlashingProtectionMinimal=%v", isSlashingProtectionMinimal), func(t *testing.T) {
+			ctx := context.Background()
+			validatorDB, pubKey, file := setupMergeTest(t, isSlashingProtectionMinimal)
+
+			report, err := history.DiffStandardProtectionJSON(ctx, validatorDB, encodeInterchange(t, file))
+			require.NoError(t, err)
+			require.Equal(t, 2, len(report.Keys))
+
+			existing := report.Keys[0]
+			assert.Equal(t, fmt.Sprintf("%#x", pubKey), existing.Pubkey)
+			assert.Equal(t, false, existing.NewKey)
+			assert.Equal(t, true, existing.Changed())
+			assert.Equal(t, primitives.Slot(5), *existing.Database.BlockSlot)
+			assert.Equal(t, primitives.Slot(10), *existing.Merged.BlockSlot)
+			assert.Equal(t, primitives.Epoch(3), *existing.Merged.SourceEpoch)
+			assert.Equal(t, primitives.Epoch(8), *existing.Merged.TargetEpoch)
+			require.Equal(t, 2, len(existing.Conflicts))
+			assert.Equal(t, "double proposal at slot 5", existing.Conflicts[0])
+			assert.Equal(t, "double vote at target epoch 2", existing.Conflicts[1])
+
+			newKey := report.Keys[1]
+			assert.Equal(t, true, newKey.NewKey)
+			assert.Equal(t, true, newKey.Changed())
+			assert.Equal(t, 0, len(newKey.Conflicts))
+
+			// Nothing has been written.
+			again, err := history.DiffStandardProtectionJSON(ctx, validatorDB, encodeInterchange(t, file))
+			require.NoError(t, err)
+			assert.DeepEqual(t, report, again)
+		})
+	}
+}
+
+func TestDiffStandardProtectionJSON_GenesisValidatorsRootMismatch(t *testing.T) {
+	validatorDB, _, file := setupMergeTest(t, false)
+	file.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [32]byte{2})
+	_, err := history.DiffStandardProtectionJSON(context.Background(), validatorDB, encodeInterchange(t, file))
+	require.ErrorContains(t, "genesis validators root doesn't match", err)
+}
+
+func TestMergeStandardProtectionJSON(t *testing.T) {
+	for _, isSlashingProtectionMinimal := range [...]bool{false, true} {
+		t.Run(fmt.Sprintf("isSlashingProtectionMinimal=%v", isSlashingProtectionMinimal), func(t *testing.T) {
+			ctx := context.Background()
+			validatorDB, _, file := setupMergeTest(t, isSlashingProtectionMinimal)
+
+			report, err := history.MergeStandardProtectionJSON(ctx, validatorDB, encodeInterchange(t, file))
+			require.NoError(t, err)
+			require.Equal(t, 2, len(report.Keys))
+
+			// Conflicting keys are merged rather than blacklisted.
+			blacklisted, err := validatorDB.EIPImportBlacklistedPublicKeys(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(blacklisted))
+
+			// The database now holds the merged watermarks, so merging a second time changes nothing.
+			again, err := history.DiffStandardProtectionJSON(ctx, validatorDB, encodeInterchange(t, file))
+			require.NoError(t, err)
+			require.Equal(t, 2, len(again.Keys))
+			for i, d := range again.Keys {
+				assert.Equal(t, false, d.Changed(), d.Pubkey)
+				assert.DeepEqual(t, report.Keys[i].Merged, d.Database)
+			}
+		})
+	}
+}
+
+func TestMergeStandardProtectionJSON_DoesNotLowerWatermarks(t *testing.T) {
+	ctx := context.Background()
+	validatorDB, _, file := setupMergeTest(t, false)
+	file.Data = file.Data[:1]
+	file.Data[0].SignedBlocks = []*format.SignedBlock{{Slot: "1"}}
+	file.Data[0].SignedAttestations = []*format.SignedAttestation{{SourceEpoch: "0", TargetEpoch: "1"}}
+
+	report, err := history.MergeStandardProtectionJSON(ctx, validatorDB, encodeInterchange(t, file))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Keys))
+	assert.Equal(t, false, report.Keys[0].Changed())
+	assert.Equal(t, primitives.Slot(5), *report.Keys[0].Merged.BlockSlot)
+	assert.Equal(t, primitives.Epoch(2), *report.Keys[0].Merged.TargetEpoch)
+}